@@ -7,7 +7,7 @@
 -- ----------------------------------------------------------------------------
 -- Account / User (base only)
 -- Optional trait columns: see deploy/migrations/traits/
---   - user_email.up.sql    → email column
+--   - user_email.up.sql    → email column (email_verified: 029_user_email_verified)
 --   - user_password.up.sql → password + required_password_reset columns
 --   - user_username.up.sql → username column
 -- ----------------------------------------------------------------------------
@@ -0,0 +1,14 @@
+-- Previous password hashes of the user
+-- Used by the password policy to prevent the reuse of the recent passwords
+CREATE TABLE IF NOT EXISTS account_user_password_history
+( id                      BIGSERIAL                   PRIMARY KEY
+, user_id                 BIGINT                      NOT NULL        REFERENCES account_user (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, password                VARCHAR(128)                NOT NULL
+
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+);
+
+CREATE INDEX idx_account_user_password_history_user_id ON
+    account_user_password_history (user_id, created_at);
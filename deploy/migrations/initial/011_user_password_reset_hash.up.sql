@@ -0,0 +1,5 @@
+-- Reset password tokens are stored as SHA-256 hashes now.
+-- Pending plain tokens can't be matched anymore and are removed.
+DELETE FROM account_user_password_reset;
+
+COMMENT ON COLUMN account_user_password_reset.token IS 'SHA-256 hash of the reset token';
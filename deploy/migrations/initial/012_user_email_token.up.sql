@@ -0,0 +1,22 @@
+-- Single-use tokens sent to the user email
+-- Purpose: `verify` - confirmation of the email, `login` - login by the link
+CREATE TABLE IF NOT EXISTS account_user_email_token
+( id                      BIGSERIAL                   PRIMARY KEY
+, user_id                 BIGINT                      NOT NULL        REFERENCES account_user (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, email                   VARCHAR(128)                NOT NULL
+, purpose                 VARCHAR(16)                 NOT NULL
+, token                   VARCHAR(128)                NOT NULL        UNIQUE
+
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, expires_at              TIMESTAMP                   NOT NULL
+);
+
+COMMENT ON COLUMN account_user_email_token.token IS 'SHA-256 hash of the email token';
+
+CREATE INDEX idx_account_user_email_token_user_id ON
+    account_user_email_token (user_id, purpose, created_at);
+
+CREATE INDEX idx_account_user_email_token_expires_at ON
+    account_user_email_token (expires_at);
@@ -0,0 +1,17 @@
+-- Direct access tokens are stored as SHA-256 hashes with the visible prefix,
+-- the usage of the token could be limited by the permission scopes and IP allow-list
+ALTER TABLE direct_access_tokens
+  ADD COLUMN IF NOT EXISTS token_prefix   VARCHAR(16)     NOT NULL      DEFAULT ''
+, ADD COLUMN IF NOT EXISTS scopes         TEXT[]
+, ADD COLUMN IF NOT EXISTS allowed_ips    TEXT[]
+, ADD COLUMN IF NOT EXISTS last_used_at   TIMESTAMP
+, ADD COLUMN IF NOT EXISTS last_used_ip   VARCHAR(64)     NOT NULL      DEFAULT '';
+
+-- Existing tokens keep working, only their hashes are stored
+UPDATE direct_access_tokens
+   SET token_prefix = LEFT(token, 8)
+     , token        = ENCODE(SHA256(CONVERT_TO(token, 'UTF8')), 'hex');
+
+COMMENT ON COLUMN direct_access_tokens.token IS 'SHA-256 hash of the direct access token';
+COMMENT ON COLUMN direct_access_tokens.scopes IS 'RBAC permission patterns allowed for the token, NULL - all rights of the owner';
+COMMENT ON COLUMN direct_access_tokens.allowed_ips IS 'IP addresses or CIDR networks allowed to use the token, NULL - any address';
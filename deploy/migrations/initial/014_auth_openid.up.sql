@@ -0,0 +1,39 @@
+-- OpenID Connect sessions keep the resource owner who authorized the client
+-- and the ID token claims which are reused on the token refresh
+ALTER TABLE auth_session
+  ADD COLUMN IF NOT EXISTS user_id        BIGINT          NOT NULL      DEFAULT 0
+, ADD COLUMN IF NOT EXISTS account_id     BIGINT          NOT NULL      DEFAULT 0
+, ADD COLUMN IF NOT EXISTS data           JSONB;
+
+-- Authorization codes, PKCE challenges and OpenID Connect requests live only
+-- until the code is exchanged, they share the request_id with the issued session
+CREATE TABLE IF NOT EXISTS auth_request
+( id                      BIGSERIAL                   PRIMARY KEY
+, kind                    VARCHAR(16)                 NOT NULL
+, active                  BOOLEAN                     NOT NULL      DEFAULT TRUE
+, signature               VARCHAR(512)                NOT NULL
+, request_id              VARCHAR(256)                NOT NULL
+, client_id               VARCHAR(128)                NOT NULL      REFERENCES auth_client (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, user_id                 BIGINT                      NOT NULL      DEFAULT 0
+, account_id              BIGINT                      NOT NULL      DEFAULT 0
+
+, form                    TEXT                        NOT NULL      DEFAULT ''
+, requested_scope         TEXT[]
+, granted_scope           TEXT[]
+, requested_audience      TEXT[]
+, granted_audience        TEXT[]
+, data                    JSONB
+
+, requested_at            TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, expires_at              TIMESTAMP
+, created_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, deleted_at              TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_auth_request_uniq_kind_signature
+    ON auth_request (kind, signature) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_auth_request_expires_at
+    ON auth_request (expires_at) WHERE deleted_at IS NULL;
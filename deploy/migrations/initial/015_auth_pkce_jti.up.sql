@@ -0,0 +1,14 @@
+-- Confidential clients can be forced to use PKCE as well as the public ones
+ALTER TABLE auth_client
+  ADD COLUMN IF NOT EXISTS require_pkce   BOOLEAN         NOT NULL      DEFAULT FALSE;
+
+-- JWT IDs of the client assertions which were already used for the authentication,
+-- the records are kept until the assertion expires to prevent the replay attacks
+CREATE TABLE IF NOT EXISTS auth_client_assertion
+( jti                     VARCHAR(512)                PRIMARY KEY
+, expires_at              TIMESTAMP                   NOT NULL
+, created_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+);
+
+CREATE INDEX idx_auth_client_assertion_expires_at
+    ON auth_client_assertion (expires_at);
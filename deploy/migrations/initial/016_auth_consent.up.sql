@@ -0,0 +1,49 @@
+-- Scopes which the user granted to the client, the consent page is skipped
+-- while the grant covers the requested scopes and isn't expired
+CREATE TABLE IF NOT EXISTS auth_grant
+( id                      BIGSERIAL                   PRIMARY KEY
+, user_id                 BIGINT                      NOT NULL      REFERENCES account_user (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, client_id               VARCHAR(128)                NOT NULL      REFERENCES auth_client (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, scope                   TEXT[]
+, audience                TEXT[]
+
+-- NULL means the grant never expires
+, expires_at              TIMESTAMP
+
+, created_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, deleted_at              TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_auth_grant_uniq_user_client
+    ON auth_grant (user_id, client_id) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON auth_grant FOR EACH ROW EXECUTE PROCEDURE updated_at_column();
+
+-- Authorization requests waiting for the user decision on the consent page
+CREATE TABLE IF NOT EXISTS auth_consent_request
+( challenge               VARCHAR(128)                PRIMARY KEY
+, status                  VARCHAR(16)                 NOT NULL      DEFAULT 'pending'
+, user_id                 BIGINT                      NOT NULL
+, account_id              BIGINT                      NOT NULL      DEFAULT 0
+, client_id               VARCHAR(128)                NOT NULL      REFERENCES auth_client (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, requested_scope         TEXT[]
+, requested_audience      TEXT[]
+, granted_scope           TEXT[]
+, granted_audience        TEXT[]
+, request_url             TEXT                        NOT NULL
+
+, expires_at              TIMESTAMP                   NOT NULL
+, created_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+);
+
+CREATE INDEX idx_auth_consent_request_expires_at
+    ON auth_consent_request (expires_at);
@@ -0,0 +1,38 @@
+-- Device authorization requests (RFC 8628) of the clients which can't redirect
+-- the user, the client polls the token endpoint until the user approves the request
+CREATE TABLE IF NOT EXISTS auth_device_request
+( id                      BIGSERIAL                   PRIMARY KEY
+, status                  VARCHAR(16)                 NOT NULL      DEFAULT 'pending'
+, device_code             VARCHAR(128)                NOT NULL
+, user_code               VARCHAR(16)                 NOT NULL
+, request_id              VARCHAR(128)                NOT NULL
+, client_id               VARCHAR(128)                NOT NULL      REFERENCES auth_client (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, user_id                 BIGINT                      NOT NULL      DEFAULT 0
+, account_id              BIGINT                      NOT NULL      DEFAULT 0
+
+, form                    TEXT                        NOT NULL      DEFAULT ''
+, requested_scope         TEXT[]
+, granted_scope           TEXT[]
+, requested_audience      TEXT[]
+, granted_audience        TEXT[]
+
+, poll_interval           INT                         NOT NULL      DEFAULT 5
+, last_polled_at          TIMESTAMP
+
+, expires_at              TIMESTAMP                   NOT NULL
+, created_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, deleted_at              TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_auth_device_request_device_code
+    ON auth_device_request (device_code);
+CREATE UNIQUE INDEX idx_auth_device_request_user_code
+    ON auth_device_request (user_code);
+CREATE INDEX idx_auth_device_request_expires_at
+    ON auth_device_request (expires_at);
+
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON auth_device_request FOR EACH ROW EXECUTE PROCEDURE updated_at_column();
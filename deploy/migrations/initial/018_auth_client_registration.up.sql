@@ -0,0 +1,38 @@
+-- The rotated secret is accepted together with the new one during the grace period,
+-- the `private_key_jwt` clients authenticate with the keys of the JWKS URI or the inline JWKS
+ALTER TABLE auth_client
+  ADD COLUMN IF NOT EXISTS previous_secret                   TEXT            NOT NULL      DEFAULT ''
+, ADD COLUMN IF NOT EXISTS previous_secret_expires_at        TIMESTAMP
+, ADD COLUMN IF NOT EXISTS token_endpoint_auth_method        VARCHAR(32)     NOT NULL      DEFAULT ''
+, ADD COLUMN IF NOT EXISTS token_endpoint_auth_signing_alg   VARCHAR(16)     NOT NULL      DEFAULT ''
+, ADD COLUMN IF NOT EXISTS jwks_uri                          TEXT            NOT NULL      DEFAULT ''
+, ADD COLUMN IF NOT EXISTS jwks                              TEXT            NOT NULL      DEFAULT '';
+
+-- Initial access tokens authorize the dynamic client registration (RFC 7591),
+-- the registered clients belong to the account of the token
+CREATE TABLE IF NOT EXISTS auth_initial_access_token
+( id                      BIGSERIAL                   PRIMARY KEY
+, token                   VARCHAR(128)                NOT NULL
+, description             TEXT                        NOT NULL      DEFAULT ''
+, account_id              BIGINT                      NOT NULL      REFERENCES account_base (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, user_id                 BIGINT                      NOT NULL      DEFAULT 0
+
+, scope                   TEXT                        NOT NULL      DEFAULT ''
+, max_clients             INT                         NOT NULL      DEFAULT 0
+, client_count            INT                         NOT NULL      DEFAULT 0
+
+, expires_at              TIMESTAMP                   NOT NULL
+, created_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL      DEFAULT NOW()
+, deleted_at              TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_auth_initial_access_token_token
+    ON auth_initial_access_token (token);
+CREATE INDEX idx_auth_initial_access_token_account_id
+    ON auth_initial_access_token (account_id);
+
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON auth_initial_access_token FOR EACH ROW EXECUTE PROCEDURE updated_at_column();
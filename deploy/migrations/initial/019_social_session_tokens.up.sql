@@ -0,0 +1,6 @@
+-- The encrypted provider tokens are longer than the plain ones,
+-- the session is invalidated if the provider refuses to refresh its token
+ALTER TABLE account_social_session
+  ALTER COLUMN access_token                               TYPE TEXT
+, ALTER COLUMN refresh_token                              TYPE TEXT
+, ADD COLUMN IF NOT EXISTS invalidated_at                 TIMESTAMP;
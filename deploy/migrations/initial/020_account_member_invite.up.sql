@@ -0,0 +1,30 @@
+-- Invitations of the account members by email, the invitee accepts or declines
+-- the invite by the single-use token sent to the email
+CREATE TABLE IF NOT EXISTS account_member_invite
+( id                      BIGSERIAL                   PRIMARY KEY
+, account_id              BIGINT                      NOT NULL        REFERENCES account_base (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, email                   VARCHAR(128)                NOT NULL
+, roles                   TEXT[]                      NOT NULL        DEFAULT '{}'
+, token                   VARCHAR(128)                NOT NULL        UNIQUE
+, status                  VARCHAR(16)                 NOT NULL        DEFAULT 'pending'
+
+, invited_by              BIGINT                      NOT NULL        DEFAULT 0
+-- The user who accepted or declined the invite
+, user_id                 BIGINT                      NOT NULL        DEFAULT 0
+, sent_count              INT                         NOT NULL        DEFAULT 1
+
+, sent_at                 TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, expires_at              TIMESTAMP                   NOT NULL
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+);
+
+COMMENT ON COLUMN account_member_invite.token IS 'SHA-256 hash of the invite token';
+
+CREATE UNIQUE INDEX idx_account_member_invite_pending_email
+    ON account_member_invite (account_id, LOWER(email)) WHERE status = 'pending';
+
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON account_member_invite FOR EACH ROW EXECUTE PROCEDURE updated_at_column();
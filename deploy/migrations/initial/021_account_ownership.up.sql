@@ -0,0 +1,36 @@
+-- The owner of the account, only one member per account can be the owner
+-- and the owner is always the admin of the account
+ALTER TABLE account_member ADD COLUMN IF NOT EXISTS is_owner BOOL NOT NULL DEFAULT FALSE;
+
+-- The first admin of every account becomes the owner
+UPDATE account_member SET is_owner = TRUE
+WHERE id IN (
+  SELECT DISTINCT ON (account_id) id FROM account_member
+  WHERE is_admin AND deleted_at IS NULL
+  ORDER BY account_id, created_at, id
+);
+
+CREATE UNIQUE INDEX idx_account_member_owner
+    ON account_member (account_id) WHERE is_owner AND deleted_at IS NULL;
+
+-- Transfer of the account ownership, requested by the owner
+-- and confirmed or declined by the new owner
+CREATE TABLE IF NOT EXISTS account_ownership_transfer
+( id                      BIGSERIAL                   PRIMARY KEY
+, account_id              BIGINT                      NOT NULL        REFERENCES account_base (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, from_user_id            BIGINT                      NOT NULL
+, to_user_id              BIGINT                      NOT NULL
+, status                  VARCHAR(16)                 NOT NULL        DEFAULT 'pending'
+
+, expires_at              TIMESTAMP                   NOT NULL
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_account_ownership_transfer_pending
+    ON account_ownership_transfer (account_id) WHERE status = 'pending';
+
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON account_ownership_transfer FOR EACH ROW EXECUTE PROCEDURE updated_at_column();
@@ -0,0 +1,9 @@
+-- Accounts can be nested into the parent account (organization with sub-accounts).
+-- The members of the parent keep their permissions in the sub-account
+-- which inherits the permissions, the inheritance cascades by the whole chain
+ALTER TABLE account_base ADD COLUMN IF NOT EXISTS parent_id           BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE account_base ADD COLUMN IF NOT EXISTS inherit_permissions BOOL   NOT NULL DEFAULT FALSE;
+
+ALTER TABLE account_base ADD CONSTRAINT account_base_parent_check CHECK (parent_id <> id);
+
+CREATE INDEX idx_account_base_parent_id ON account_base (parent_id) WHERE parent_id > 0;
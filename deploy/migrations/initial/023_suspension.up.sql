@@ -0,0 +1,7 @@
+-- Suspended users and accounts keep all the data but can't be used for the authentication,
+-- the suspension is removed by resetting the suspended_at column
+ALTER TABLE account_user ADD COLUMN IF NOT EXISTS suspended_at   TIMESTAMP;
+ALTER TABLE account_user ADD COLUMN IF NOT EXISTS suspend_reason TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE account_base ADD COLUMN IF NOT EXISTS suspended_at   TIMESTAMP;
+ALTER TABLE account_base ADD COLUMN IF NOT EXISTS suspend_reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- The custom roles of the accounts are visible and assignable only within the account,
+-- the roles without the account are global and shared by all accounts
+ALTER TABLE rbac_role ADD COLUMN IF NOT EXISTS account_id BIGINT DEFAULT NULL REFERENCES account_base (id) MATCH SIMPLE
+                                                                      ON UPDATE NO ACTION
+                                                                      ON DELETE RESTRICT;
+
+-- The role name is unique within the account or among the global roles
+ALTER TABLE rbac_role DROP CONSTRAINT IF EXISTS rbac_role_name_key;
+CREATE UNIQUE INDEX idx_rbac_role_account_id_name ON rbac_role (COALESCE(account_id, 0), name);
@@ -0,0 +1,46 @@
+-- Teams of the account members, the members of the team get the team roles
+-- in addition to their own roles
+CREATE TABLE IF NOT EXISTS account_team
+( id                      BIGSERIAL                   PRIMARY KEY
+, account_id              BIGINT                      NOT NULL        REFERENCES account_base (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, name                    VARCHAR(128)                NOT NULL
+, description             TEXT                        NOT NULL        DEFAULT ''
+
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, deleted_at              TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_account_team_account_id_name
+    ON account_team (account_id, name) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON account_team FOR EACH ROW EXECUTE PROCEDURE updated_at_column();
+
+CREATE TABLE IF NOT EXISTS m2m_account_team_member
+( team_id                 BIGINT                      NOT NULL        REFERENCES account_team (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, member_id               BIGINT                      NOT NULL        REFERENCES account_member (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+
+, PRIMARY KEY (team_id, member_id)
+);
+
+CREATE INDEX idx_m2m_account_team_member_member_id ON m2m_account_team_member (member_id);
+
+CREATE TABLE IF NOT EXISTS m2m_account_team_role
+( team_id                 BIGINT                      NOT NULL        REFERENCES account_team (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, role_id                 BIGINT                      NOT NULL        REFERENCES rbac_role (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+
+, PRIMARY KEY (team_id, role_id)
+);
@@ -0,0 +1,28 @@
+-- Email domains of the accounts, the domain ownership is confirmed by the DNS TXT record.
+-- The verified domain belongs to one account only and can auto-join the new users
+-- or require the SSO login for its emails
+CREATE TABLE IF NOT EXISTS account_domain
+( id                      BIGSERIAL                   PRIMARY KEY
+, account_id              BIGINT                      NOT NULL        REFERENCES account_base (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, domain                  VARCHAR(255)                NOT NULL
+, token                   VARCHAR(128)                NOT NULL
+, verified_at             TIMESTAMP
+, auto_join               BOOLEAN                     NOT NULL        DEFAULT FALSE
+, default_roles           TEXT[]
+, sso_required            BOOLEAN                     NOT NULL        DEFAULT FALSE
+
+, created_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, updated_at              TIMESTAMP                   NOT NULL        DEFAULT NOW()
+, deleted_at              TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_account_domain_account_id_domain
+    ON account_domain (account_id, domain) WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX idx_account_domain_verified_domain
+    ON account_domain (domain) WHERE verified_at IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON account_domain FOR EACH ROW EXECUTE PROCEDURE updated_at_column();
@@ -0,0 +1,5 @@
+-- The real actor of the impersonated session, the actions are stored with the impersonated user
+-- and the support staff user who acted on behalf of it (0 - not impersonated)
+ALTER TABLE history_actions ADD COLUMN IF NOT EXISTS actor_id BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_history_actions_actor_id ON history_actions(actor_id);
@@ -0,0 +1,37 @@
+-- Last activity of the users in the accounts by the authorizer, the session
+-- without the account is stored with the account_id 0
+CREATE TABLE IF NOT EXISTS account_member_activity
+( user_id                 BIGINT                      NOT NULL        REFERENCES account_user (id) MATCH SIMPLE
+                                                                        ON UPDATE NO ACTION
+                                                                        ON DELETE CASCADE
+, account_id              BIGINT                      NOT NULL        DEFAULT 0
+, authorizer              VARCHAR(64)                 NOT NULL
+, last_seen_at            TIMESTAMP                   NOT NULL        DEFAULT NOW()
+
+, PRIMARY KEY (user_id, account_id, authorizer)
+);
+
+-- The last activity is copied to the user and the member to filter the inactive ones
+ALTER TABLE account_user   ADD COLUMN IF NOT EXISTS last_active_at TIMESTAMP;
+ALTER TABLE account_member ADD COLUMN IF NOT EXISTS last_active_at TIMESTAMP;
+
+-- Suspended members keep the roles but can't act in the account
+ALTER TABLE account_member ADD COLUMN IF NOT EXISTS suspended_at   TIMESTAMP;
+ALTER TABLE account_member ADD COLUMN IF NOT EXISTS suspend_reason TEXT NOT NULL DEFAULT '';
+
+-- The activity update is not the modification of the user or the member
+DROP TRIGGER IF EXISTS updated_at_triger ON account_user;
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON account_user FOR EACH ROW
+    WHEN (OLD.last_active_at IS NOT DISTINCT FROM NEW.last_active_at)
+    EXECUTE PROCEDURE updated_at_column();
+
+DROP TRIGGER IF EXISTS updated_at_triger ON account_member;
+CREATE TRIGGER updated_at_triger BEFORE UPDATE
+    ON account_member FOR EACH ROW
+    WHEN (OLD.last_active_at IS NOT DISTINCT FROM NEW.last_active_at)
+    EXECUTE PROCEDURE updated_at_column();
+
+-- The activity wasn't tracked before, the inactivity period starts from the migration
+UPDATE account_user   SET last_active_at = NOW() WHERE last_active_at IS NULL;
+UPDATE account_member SET last_active_at = NOW() WHERE last_active_at IS NULL;
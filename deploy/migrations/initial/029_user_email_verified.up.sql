@@ -0,0 +1,3 @@
+-- Confirmation of the user email by the verification link, used together with the email trait
+ALTER TABLE account_user
+  ADD COLUMN IF NOT EXISTS email_verified BOOL NOT NULL DEFAULT FALSE;
@@ -26,6 +26,10 @@ type serverConfig struct {
 		Listen       string        `default:":8080" field:"listen" json:"listen" yaml:"listen" cli:"http-listen" env:"SERVER_HTTP_LISTEN"`
 		ReadTimeout  time.Duration `default:"120s" field:"read_timeout" json:"read_timeout" yaml:"read_timeout" env:"SERVER_HTTP_READ_TIMEOUT"`
 		WriteTimeout time.Duration `default:"120s" field:"write_timeout" json:"write_timeout" yaml:"write_timeout" env:"SERVER_HTTP_WRITE_TIMEOUT"`
+
+		// TrustedProxies are the addresses or the networks of the reverse proxies,
+		// the client address headers of other peers are ignored
+		TrustedProxies []string `field:"trusted_proxies" json:"trusted_proxies" yaml:"trusted_proxies" env:"SERVER_HTTP_TRUSTED_PROXIES"`
 	}
 	Profile struct {
 		Mode   string `json:"mode" yaml:"mode" default:"net" env:"SERVER_PROFILE_MODE"`
@@ -40,6 +44,15 @@ type sessionConfig struct {
 	DevToken     string `json:"dev_token" yaml:"dev_token" env:"SESSION_DEV_TOKEN"`
 	DevUserID    uint64 `json:"dev_user_id" yaml:"dev_user_id" env:"SESSION_DEV_USER_ID"`
 	DevAccountID uint64 `json:"dev_account_id" yaml:"dev_account_id" env:"SESSION_DEV_ACCOUNT_ID"`
+
+	// ImpersonationLifetime of the token issued on behalf of the user by the support staff
+	ImpersonationLifetime time.Duration `json:"impersonation_lifetime" yaml:"impersonation_lifetime" env:"SESSION_IMPERSONATION_LIFETIME" default:"15m"`
+
+	// ImpersonationBlocked mutations rejected in the impersonated session (empty - the default list)
+	ImpersonationBlocked []string `json:"impersonation_blocked" yaml:"impersonation_blocked" env:"SESSION_IMPERSONATION_BLOCKED"`
+
+	// ActivityInterval between two writes of the last activity of the same session
+	ActivityInterval time.Duration `json:"activity_interval" yaml:"activity_interval" env:"SESSION_ACTIVITY_INTERVAL" default:"5m"`
 }
 
 type storageConfig struct {
@@ -100,6 +113,11 @@ type socialAuthProviderConfig struct {
 
 	// Scope specifies optional requested permissions.
 	Scopes []string `json:"scopes" yaml:"scopes" env:"SCOPES"`
+
+	// Issuer of the OpenID Connect provider (https://accounts.google.com).
+	// The endpoints are loaded from the discovery document of the issuer
+	// and the user data is taken from the ID token if it's set.
+	Issuer string `json:"issuer" yaml:"issuer" env:"ISSUER"`
 }
 
 func (s *socialAuthProviderConfig) OAuth2Config(provider string) *oauth2.Config {
@@ -116,11 +134,43 @@ func (s *socialAuthProviderConfig) IsValid() bool {
 	return s != nil && s.ClientID != "" && s.ClientSecret != "" && s.RedirectURL != ""
 }
 
+// IsOpenIDConnect returns true if the provider is configured by the issuer discovery
+func (s *socialAuthProviderConfig) IsOpenIDConnect() bool {
+	return s.IsValid() && s.Issuer != ""
+}
+
 type socialAuthConfig struct {
 	Google   socialAuthProviderConfig `json:"google" yaml:"google" envPrefix:"GOOGLE_"`
 	Facebook socialAuthProviderConfig `json:"facebook" yaml:"facebook" envPrefix:"FACEBOOK_"`
 	XCOM     socialAuthProviderConfig `json:"xcom" yaml:"xcom" envPrefix:"XCOM_"` // Ex Twitter
 	LinkedIn socialAuthProviderConfig `json:"linkedin" yaml:"linkedin" envPrefix:"LINKEDIN_"`
+	GitLab   socialAuthProviderConfig `json:"gitlab" yaml:"gitlab" envPrefix:"GITLAB_"`
+	Keycloak socialAuthProviderConfig `json:"keycloak" yaml:"keycloak" envPrefix:"KEYCLOAK_"`
+	AzureAD  socialAuthProviderConfig `json:"azuread" yaml:"azuread" envPrefix:"AZUREAD_"`
+
+	// LinkAuthAge is the max age of the session which links the social account to the user
+	LinkAuthAge time.Duration `json:"link_auth_age" yaml:"link_auth_age" env:"SOCIAL_LINK_AUTH_AGE" default:"15m"`
+
+	// TokenSecret encrypts the provider tokens stored in the database
+	TokenSecret string `json:"token_secret" yaml:"token_secret" env:"SOCIAL_TOKEN_SECRET"`
+}
+
+// OpenIDConnectProviders returns the providers configured by the issuer discovery
+func (c *socialAuthConfig) OpenIDConnectProviders() map[string]*socialAuthProviderConfig {
+	providers := map[string]*socialAuthProviderConfig{}
+	for name, conf := range map[string]*socialAuthProviderConfig{
+		"google":   &c.Google,
+		"facebook": &c.Facebook,
+		"linkedin": &c.LinkedIn,
+		"gitlab":   &c.GitLab,
+		"keycloak": &c.Keycloak,
+		"azuread":  &c.AzureAD,
+	} {
+		if conf.IsOpenIDConnect() {
+			providers[name] = conf
+		}
+	}
+	return providers
 }
 
 type oauth2Config struct {
@@ -154,12 +204,100 @@ type oauth2Config struct {
 
 	// CacheLifetime define the lifetime of elements in the cache
 	CacheLifetime time.Duration `json:"cache_lifetime" yaml:"cache_lifetime" env:"OAUTH2_CACHE_LIFETIME"`
+
+	// Issuer is the public URL of the OpenID Connect provider (https://auth.example.com)
+	Issuer string `json:"issuer" yaml:"issuer" env:"OAUTH2_ISSUER"`
+
+	// IDTokenLifespan sets how long an ID token is going to be valid. Defaults to one hour.
+	IDTokenLifespan time.Duration `json:"id_token_lifespan" yaml:"id_token_lifespan" env:"OAUTH2_ID_TOKEN_LIFESPAN" default:"1h"`
+
+	// SigningKey is the PEM encoded RSA private key of the ID token signature.
+	// The random key is generated on start if it's empty, so the ID tokens become invalid after restart.
+	SigningKey   string `json:"signing_key" yaml:"signing_key" env:"OAUTH2_SIGNING_KEY"`
+	SigningKeyID string `json:"signing_key_id" yaml:"signing_key_id" env:"OAUTH2_SIGNING_KEY_ID" default:"default"`
+
+	// PairwiseSalt is the secret of the pairwise subject identifiers
+	PairwiseSalt string `json:"pairwise_salt" yaml:"pairwise_salt" env:"OAUTH2_PAIRWISE_SALT"`
+
+	// LoginURL of the page where the user logs in before the authorization,
+	// the page has to redirect back to the `return_to` URL
+	LoginURL string `json:"login_url" yaml:"login_url" env:"OAUTH2_LOGIN_URL"`
+
+	// ConsentURL of the page where the user accepts or rejects the requested scopes,
+	// the page gets the `consent_challenge` parameter. The consent is disabled if it's empty.
+	ConsentURL string `json:"consent_url" yaml:"consent_url" env:"OAUTH2_CONSENT_URL"`
+
+	// ConsentLifespan sets how long the user can decide on the consent page. Defaults to fifteen minutes.
+	ConsentLifespan time.Duration `json:"consent_lifespan" yaml:"consent_lifespan" env:"OAUTH2_CONSENT_LIFESPAN" default:"15m"`
+
+	// DeviceVerificationURL of the page where the user enters the user code of the device authorization,
+	// the page gets the `user_code` parameter if it's known. The device authorization is disabled if it's empty.
+	DeviceVerificationURL string `json:"device_verification_url" yaml:"device_verification_url" env:"OAUTH2_DEVICE_VERIFICATION_URL"`
+
+	// DeviceCodeLifespan sets how long the device code is valid. Defaults to ten minutes.
+	DeviceCodeLifespan time.Duration `json:"device_code_lifespan" yaml:"device_code_lifespan" env:"OAUTH2_DEVICE_CODE_LIFESPAN" default:"10m"`
+
+	// DevicePollInterval is the minimal time between the token requests of the device. Defaults to five seconds.
+	DevicePollInterval time.Duration `json:"device_poll_interval" yaml:"device_poll_interval" env:"OAUTH2_DEVICE_POLL_INTERVAL" default:"5s"`
 }
 
 type permissionConfig struct {
 	RoleCacheLifetime time.Duration `json:"role_cache_lifetime" yaml:"role_cache_lifetime" env:"PERMISSIONS_CACHE_LIFETIME" default:"10s"`
 }
 
+type bruteForceConfig struct {
+	// CacheConnect of the failed attempts storage. Supports: redis://host:port/dbNum, :memory:, :dummy:
+	CacheConnect string `json:"cache_connect" yaml:"cache_connect" env:"BRUTEFORCE_CACHE_CONNECT" default:":memory:"`
+
+	FreeAttempts    int           `json:"free_attempts" yaml:"free_attempts" env:"BRUTEFORCE_FREE_ATTEMPTS" default:"3"`
+	BaseDelay       time.Duration `json:"base_delay" yaml:"base_delay" env:"BRUTEFORCE_BASE_DELAY" default:"1s"`
+	MaxDelay        time.Duration `json:"max_delay" yaml:"max_delay" env:"BRUTEFORCE_MAX_DELAY" default:"5m"`
+	LockoutAfter    int           `json:"lockout_after" yaml:"lockout_after" env:"BRUTEFORCE_LOCKOUT_AFTER" default:"10"`
+	LockoutDuration time.Duration `json:"lockout_duration" yaml:"lockout_duration" env:"BRUTEFORCE_LOCKOUT_DURATION" default:"15m"`
+
+	// CaptchaAfter is the number of failures after which the client have to pass CAPTCHA (0 - disabled)
+	CaptchaAfter int           `json:"captcha_after" yaml:"captcha_after" env:"BRUTEFORCE_CAPTCHA_AFTER"`
+	Window       time.Duration `json:"window" yaml:"window" env:"BRUTEFORCE_WINDOW" default:"1h"`
+}
+
+type passwordConfig struct {
+	MinLength      int  `json:"min_length" yaml:"min_length" env:"PASSWORD_MIN_LENGTH" default:"8"`
+	MaxLength      int  `json:"max_length" yaml:"max_length" env:"PASSWORD_MAX_LENGTH" default:"256"`
+	RequireLower   bool `json:"require_lower" yaml:"require_lower" env:"PASSWORD_REQUIRE_LOWER"`
+	RequireUpper   bool `json:"require_upper" yaml:"require_upper" env:"PASSWORD_REQUIRE_UPPER"`
+	RequireDigit   bool `json:"require_digit" yaml:"require_digit" env:"PASSWORD_REQUIRE_DIGIT"`
+	RequireSpecial bool `json:"require_special" yaml:"require_special" env:"PASSWORD_REQUIRE_SPECIAL"`
+
+	// HistorySize is the number of the last passwords which can't be reused (0 - disabled)
+	HistorySize int `json:"history_size" yaml:"history_size" env:"PASSWORD_HISTORY_SIZE" default:"5"`
+
+	// BreachedList is the path to the file with SHA-1 hashes of breached passwords (HIBP format)
+	BreachedList string `json:"breached_list" yaml:"breached_list" env:"PASSWORD_BREACHED_LIST"`
+
+	// ResetLinkURL is the template of the password reset link with {token} and {email} placeholders
+	ResetLinkURL string `json:"reset_link_url" yaml:"reset_link_url" env:"PASSWORD_RESET_LINK_URL"`
+}
+
+type emailConfig struct {
+	// VerifyLinkURL is the template of the email verification link with {token} and {email} placeholders
+	VerifyLinkURL string `json:"verify_link_url" yaml:"verify_link_url" env:"EMAIL_VERIFY_LINK_URL"`
+
+	// LoginLinkURL is the template of the login link with {token} and {email} placeholders (empty - disabled)
+	LoginLinkURL string `json:"login_link_url" yaml:"login_link_url" env:"EMAIL_LOGIN_LINK_URL"`
+
+	// InviteLinkURL is the template of the member invite link with {token} and {email} placeholders
+	InviteLinkURL  string        `json:"invite_link_url" yaml:"invite_link_url" env:"EMAIL_INVITE_LINK_URL"`
+	InviteLifetime time.Duration `json:"invite_lifetime" yaml:"invite_lifetime" env:"EMAIL_INVITE_LIFETIME" default:"168h"`
+
+	VerifyTokenLifetime time.Duration `json:"verify_token_lifetime" yaml:"verify_token_lifetime" env:"EMAIL_VERIFY_TOKEN_LIFETIME" default:"24h"`
+	LoginTokenLifetime  time.Duration `json:"login_token_lifetime" yaml:"login_token_lifetime" env:"EMAIL_LOGIN_TOKEN_LIFETIME" default:"15m"`
+	ResendCooldown      time.Duration `json:"resend_cooldown" yaml:"resend_cooldown" env:"EMAIL_RESEND_COOLDOWN" default:"1m"`
+
+	// ApproveAfterVerification keeps new users pending until the email is verified
+	// and rejects the password, link and social login of the pending users with unverified email
+	ApproveAfterVerification bool `json:"approve_after_verification" yaml:"approve_after_verification" env:"EMAIL_APPROVE_AFTER_VERIFICATION"`
+}
+
 type superuserConfig struct {
 	Email    string `json:"email" yaml:"email" env:"SUPERUSER_EMAIL" default:"super@project.com"`
 	Password string `json:"password" yaml:"password" env:"SUPERUSER_PASSWORD"`
@@ -187,6 +325,9 @@ type ConfigType struct {
 	SocialAuth  socialAuthConfig `json:"social_auth" yaml:"social_auth"`
 	OAuth2      oauth2Config     `json:"oauth2" yaml:"oauth2"`
 	Permissions permissionConfig `json:"permissions" yaml:"permissions"`
+	BruteForce  bruteForceConfig `json:"brute_force" yaml:"brute_force"`
+	Password    passwordConfig   `json:"password" yaml:"password"`
+	Email       emailConfig      `json:"email" yaml:"email"`
 }
 
 // String implementation of Stringer interface
@@ -10,10 +10,14 @@ import (
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/pkg/permissions"
+	"github.com/geniusrabbit/blaze-api/repository/account"
 	"github.com/geniusrabbit/blaze-api/repository/authclient"
 	daModels "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/models"
 	"github.com/geniusrabbit/blaze-api/repository/historylog"
 	"github.com/geniusrabbit/blaze-api/repository/option"
+	"github.com/geniusrabbit/blaze-api/repository/privacy"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	prbac "github.com/geniusrabbit/blaze-api/repository/rbac"
 	rbacModels "github.com/geniusrabbit/blaze-api/repository/rbac/models"
 	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
 )
@@ -30,26 +34,35 @@ const (
 	PermPermissionList  = `permission.list`
 	PermUserPassReset   = `password.reset`
 	PermUserPassSet     = `password.set`
+	PermUserEmailVerify = `email.verify`
 )
 
 // InitModelPermissions models
 func InitModelPermissions(pm *permissions.Manager, deps *Deps) {
+	acl.InitModelPermissionsWithCustomCheck(pm, prbac.CheckGlobalRole, &rbacModels.Role{})
 	acl.InitModelPermissions(pm,
 		&domain.User{},
-		&rbacModels.Role{},
 		&authclient.AuthClient{},
+		&authclient.AuthGrant{},
+		&authclient.AuthConsentRequest{},
+		&authclient.AuthDeviceRequest{},
+		&authclient.AuthInitialAccessToken{},
 		&domain.Account{},
 		&domain.AccountMember{},
+		&account.Team{},
+		&account.Domain{},
 		&socialaccount.AccountSocialSession{},
 		&socialaccount.AccountSocial{},
 		&historylog.HistoryAction{},
 		&option.Option{},
 		&daModels.DirectAccessToken{},
+		&quota.Usage{},
+		&privacy.Subject{},
 	)
 
-	_ = pm.RegisterNewOwningPermissions(&domain.User{}, append(crudPermissions, PermUserPassReset, PermUserPassSet))
+	_ = pm.RegisterNewOwningPermissions(&domain.User{}, append(crudPermissions, PermUserPassReset, PermUserPassSet, PermUserEmailVerify, acl.PermSuspend, acl.PermImpersonate))
 
-	_ = pm.RegisterNewOwningPermissions(&domain.Account{}, crudPermissionsWithApprove, rbac.WithCustomCheck(func(ctx context.Context, resource any, perm rbac.Permission) bool {
+	_ = pm.RegisterNewOwningPermissions(&domain.Account{}, append(crudPermissionsWithApprove, acl.PermSuspend), rbac.WithCustomCheck(func(ctx context.Context, resource any, perm rbac.Permission) bool {
 		return accountCustomCheck(ctx, resource, perm, deps)
 	}))
 	_ = pm.RegisterNewPermission(nil, PermAccountRegister, rbac.WithoutCustomCheck)
@@ -60,20 +73,28 @@ func InitModelPermissions(pm *permissions.Manager, deps *Deps) {
 	_ = pm.RegisterNewPermission(nil, PermPermissionList, rbac.WithDescription("List all permissions"))
 
 	_ = pm.RegisterNewOwningPermissions(&authclient.AuthClient{}, crudPermissions)
+	_ = pm.RegisterNewOwningPermissions(&authclient.AuthGrant{}, []string{acl.PermList, acl.PermDelete})
+	_ = pm.RegisterNewOwningPermissions(&authclient.AuthConsentRequest{}, []string{acl.PermView, acl.PermUpdate})
+	_ = pm.RegisterNewOwningPermissions(&authclient.AuthDeviceRequest{}, []string{acl.PermView, acl.PermUpdate})
+	_ = pm.RegisterNewOwningPermissions(&authclient.AuthInitialAccessToken{}, []string{acl.PermList, acl.PermCreate, acl.PermDelete})
 
-	_ = pm.RegisterNewOwningPermissions(&domain.AccountMember{}, crudPermissionsWithApprove)
+	_ = pm.RegisterNewOwningPermissions(&domain.AccountMember{}, append(crudPermissionsWithApprove, acl.PermSuspend))
 	_ = pm.RegisterNewPermissions(&domain.AccountMember{}, []string{`roles.set.account`, `roles.set.all`, `invite`})
+	_ = pm.RegisterNewOwningPermissions(&account.Team{}, crudPermissions)
+	_ = pm.RegisterNewOwningPermissions(&account.Domain{}, crudPermissions)
 
 	_ = pm.RegisterNewOwningPermissions(&historylog.HistoryAction{}, []string{acl.PermView, acl.PermList, acl.PermCount})
 	_ = pm.RegisterNewOwningPermissions(&option.Option{}, []string{acl.PermGet, acl.PermSet, acl.PermList, acl.PermCount})
 	_ = pm.RegisterNewOwningPermissions(&daModels.DirectAccessToken{}, []string{acl.PermGet, acl.PermList, acl.PermCount, acl.PermCreate, acl.PermDelete})
+	_ = pm.RegisterNewOwningPermissions(&quota.Usage{}, []string{acl.PermView})
+	_ = pm.RegisterNewOwningPermissions(&privacy.Subject{}, []string{`export`, `erase`})
 
 	pm.RegisterRole(context.Background(),
 		rbac.MustNewRole(session.AnonymousDefaultRole,
 			rbac.WithDescription("Anonymous user role"),
 			rbac.WithPermissions(
 				`user.view.owner`, `user.list.owner`, `user.count.owner`,
-				`user.password.reset.owner`, `user.password.set.owner`, PermAccountRegister,
+				`user.password.reset.owner`, `user.password.set.owner`, `user.email.verify.owner`, PermAccountRegister,
 				`account.view.owner`, `account.list.owner`, `account.count.owner`,
 				`directaccesstoken.view.owner`, `directaccesstoken.list.owner`, `directaccesstoken.count.owner`,
 				`role.check`,
@@ -83,9 +104,12 @@ func InitModelPermissions(pm *permissions.Manager, deps *Deps) {
 			rbac.WithDescription("Default user role"),
 			rbac.WithPermissions(
 				`user.view.owner`, `user.list.owner`, `user.count.owner`,
-				`user.password.reset.owner`, `user.password.set.owner`, PermAccountRegister,
+				`user.password.reset.owner`, `user.password.set.owner`, `user.email.verify.owner`, PermAccountRegister,
 				`account.view.owner`, `account.list.owner`, `account.count.owner`,
 				`directaccesstoken.view.owner`, `directaccesstoken.list.owner`, `directaccesstoken.count.owner`, `directaccesstoken.create.owner`, `directaccesstoken.update.owner`, `directaccesstoken.delete.owner`,
+				`quota.view.owner`, `privacy.export.owner`,
+				`auth_grant.list.owner`, `auth_grant.delete.owner`, `auth_consent.view.owner`, `auth_consent.update.owner`,
+				`auth_device.view.owner`, `auth_device.update.owner`,
 				`role.check`,
 			),
 		),
@@ -102,6 +126,10 @@ func accountCustomCheck(ctx context.Context, resource any, perm rbac.Permission,
 		return true
 	}
 	if acc.ID > 0 {
+		// The parent account members act in the sub-accounts inheriting the permissions
+		if sessAcc, _ := session.Account(ctx).(*domain.Account); strings.HasSuffix(perm.Name(), `.account`) && sessAcc.IsSubAccount(acc.ID) {
+			return true
+		}
 		if perm.MatchPermissionPattern(`*.{view|list|count}.*`) {
 			return deps.MemberRepo.IsMember(ctx, userObj.GetID(), acc.ID)
 		}
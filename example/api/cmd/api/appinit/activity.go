@@ -0,0 +1,20 @@
+package appinit
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/example/api/cmd/api/appcontext"
+	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	accountrepo "github.com/geniusrabbit/blaze-api/repository/account/repository"
+)
+
+// ActivityTracker of the authorized sessions, the activity is written
+// in the background until the context is done
+func ActivityTracker(ctx context.Context, conf *appcontext.ConfigType) *accauth.ActivityTracker {
+	tracker := accauth.NewActivityTracker(
+		accountrepo.NewActivityRepository(new(UserType).TableName()),
+		accauth.WithActivityInterval(conf.Session.ActivityInterval),
+	)
+	go tracker.Run(ctx)
+	return tracker
+}
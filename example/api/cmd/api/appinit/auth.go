@@ -2,6 +2,11 @@ package appinit
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"strings"
 	"time"
 
@@ -13,6 +18,7 @@ import (
 	"github.com/geniusrabbit/blaze-api/example/api/cmd/api/appcontext"
 	"github.com/geniusrabbit/blaze-api/example/api/internal/domain"
 	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/oidc"
 	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/serverprovider"
 	"github.com/geniusrabbit/blaze-api/pkg/cache"
 	"github.com/geniusrabbit/blaze-api/pkg/cache/dummy"
@@ -34,9 +40,13 @@ func (a oauthUserAccessor) GetByPassword(ctx context.Context, userID uint64, pas
 	return a.password.GetByPassword(ctx, userID, password)
 }
 
-// Auth new provider
-func Auth(ctx context.Context, conf *appcontext.ConfigType, masterDatabase *gorm.DB, deps *Deps) (fosite.OAuth2Provider, *jwt.Provider) {
+// Auth new provider, the signing key enables OpenID Connect.
+// The storage revokes the sessions of the apps revoked by the user.
+func Auth(ctx context.Context, conf *appcontext.ConfigType, masterDatabase *gorm.DB, deps *Deps, signingKey *rsa.PrivateKey) (fosite.OAuth2Provider, *serverprovider.DatabaseStorage, *jwt.Provider) {
 	oauth2config := &fosite.Config{
+		IDTokenIssuer:                 conf.OAuth2.Issuer,
+		TokenURL:                      strings.TrimSuffix(conf.OAuth2.Issuer, "/") + oidc.PathToken,
+		IDTokenLifespan:               conf.OAuth2.IDTokenLifespan,
 		AccessTokenLifespan:           conf.OAuth2.AccessTokenLifespan,
 		RefreshTokenLifespan:          conf.OAuth2.RefreshTokenLifespan,
 		AuthorizeCodeLifespan:         conf.OAuth2.AuthorizeCodeLifespan,
@@ -51,14 +61,16 @@ func Auth(ctx context.Context, conf *appcontext.ConfigType, masterDatabase *gorm
 		sessionCache,
 		conf.OAuth2.CacheLifetime,
 	)
-	oauth2provider := serverprovider.NewProvider(
-		oauth2config,
-		oauth2storage,
-		&compose.CommonStrategy{
-			CoreStrategy: compose.NewOAuth2HMACStrategy(oauth2config),
-		},
-		nil,
-	)
+	strategy := &compose.CommonStrategy{
+		CoreStrategy: compose.NewOAuth2HMACStrategy(oauth2config),
+	}
+	if signingKey != nil {
+		oidcStrategy := compose.NewOpenIDConnectStrategy(func(context.Context) (any, error) {
+			return signingKey, nil
+		}, oauth2config)
+		strategy.OpenIDConnectTokenStrategy = oidcStrategy
+		strategy.Signer = oidcStrategy.Signer
+	}
 	jwtProvider := &jwt.Provider{
 		TokenLifetime: conf.OAuth2.AccessTokenLifespan,
 		Secret:        conf.OAuth2.Secret,
@@ -70,7 +82,32 @@ func Auth(ctx context.Context, conf *appcontext.ConfigType, masterDatabase *gorm
 			),
 		},
 	}
-	return oauth2provider, jwtProvider
+	oauth2provider := serverprovider.NewProvider(oauth2config, oauth2storage, strategy, nil,
+		serverprovider.TokenExchangeFactory(jwtProvider))
+	return oauth2provider, oauth2storage, jwtProvider
+}
+
+// SigningKey of the OpenID Connect ID tokens
+func SigningKey(conf *appcontext.ConfigType) *rsa.PrivateKey {
+	if conf.OAuth2.SigningKey == "" {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		fatalError(err, "generate signing key")
+		return key
+	}
+	block, _ := pem.Decode([]byte(conf.OAuth2.SigningKey))
+	if block == nil {
+		fatalError(errors.New("invalid PEM data"), "signing key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	fatalError(err, "signing key")
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		fatalError(errors.New("RSA key expected"), "signing key")
+	}
+	return rsaKey
 }
 
 func newCache(ctx context.Context, connect string, lifetime time.Duration) cache.Client {
@@ -0,0 +1,44 @@
+package appinit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/example/api/cmd/api/appcontext"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+)
+
+// BruteForce limiter of the authentication attempts
+func BruteForce(ctx context.Context, conf *appcontext.ConfigType) *bruteforce.Limiter {
+	bfConf := bruteforce.Config{
+		FreeAttempts:    conf.BruteForce.FreeAttempts,
+		BaseDelay:       conf.BruteForce.BaseDelay,
+		MaxDelay:        conf.BruteForce.MaxDelay,
+		LockoutAfter:    conf.BruteForce.LockoutAfter,
+		LockoutDuration: conf.BruteForce.LockoutDuration,
+		CaptchaAfter:    conf.BruteForce.CaptchaAfter,
+		Window:          conf.BruteForce.Window,
+	}
+	return bruteforce.New(
+		newCache(ctx, conf.BruteForce.CacheConnect, bfConf.Window+bfConf.LockoutDuration+bfConf.MaxDelay),
+		bfConf,
+		bruteforce.WithLockoutHandler(logLockout),
+	)
+}
+
+func logLockout(ctx context.Context, event *bruteforce.LockoutEvent) {
+	ctx = historylog.WithMessage(ctx, "too many failed attempts")
+	err := historylog.Write(ctx, "bruteforce.lockout", "auth_"+event.Key.Scope, event.Key.Value, map[string]any{
+		"action":       event.Action,
+		"key":          event.Key.String(),
+		"failures":     event.Failures,
+		"locked_until": event.LockedUntil.Format(time.RFC3339),
+	})
+	if err != nil {
+		ctxlogger.Get(ctx).Error("log lockout event", zap.String("key", event.Key.String()), zap.Error(err))
+	}
+}
@@ -1,6 +1,8 @@
 package appinit
 
 import (
+	"context"
+
 	"github.com/geniusrabbit/blaze-api/example/api/internal/domain"
 	exmodels "github.com/geniusrabbit/blaze-api/example/api/internal/server/graphql/models"
 	userstack "github.com/geniusrabbit/blaze-api/example/api/internal/user"
@@ -9,6 +11,7 @@ import (
 	accountgraphql "github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql"
 	accountrepo "github.com/geniusrabbit/blaze-api/repository/account/repository"
 	accountuc "github.com/geniusrabbit/blaze-api/repository/account/usecase"
+	optionrepo "github.com/geniusrabbit/blaze-api/repository/option/repository"
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
 
@@ -39,21 +42,37 @@ type Deps struct {
 	MemberRepo  account.MemberRepository[*UserType, *AccountType]
 	AccountUC   account.Usecase[*UserType, *AccountType]
 	MemberUC    account.MemberUsecase[*UserType, *AccountType]
+	InviteUC    account.InviteUsecase[*UserType, *AccountType]
+	OwnershipUC account.OwnershipUsecase
+	TeamUC      account.TeamUsecase
+	DomainUC    account.DomainUsecase[*UserType, *AccountType]
+	ActivityUC  account.ActivityUsecase[*UserType, *AccountType]
 	AuthLoader  *accauth.Loader[*UserType, *AccountType]
 	GraphQL     GraphQLDeps
 }
 
-// NewDeps wires example User/Account repository and usecase stack.
-func NewDeps() *Deps {
+// NewDeps wires example User/Account repository and usecase stack,
+// the activity tracker records the last activity of the authorized sessions.
+func NewDeps(userOpts userstack.ModuleOptions, activity *accauth.ActivityTracker, inviteOpts ...accountuc.InviteOption[*UserType]) *Deps {
 	newUser := func() *UserType { return new(UserType) }
 	newAccount := func() *AccountType { return new(AccountType) }
 	newMember := func() *AccountMemberType { return new(AccountMemberType) }
-	userModule := userstack.NewModule(newUser)
+	userModule := userstack.NewModule(newUser, userOpts)
 	accountRepo := accountrepo.NewSessionRepository(newUser, newAccount, newMember)
 	memberRepo := accountrepo.NewMemberRepositoryFor(newMember)
 	accountUC := accountuc.NewAccountUsecase(userModule.Repo, accountRepo, memberRepo)
 	memberUC := accountuc.NewMemberUsecase(userModule.Repo, accountRepo, memberRepo)
-	authLoader := accauth.NewLoader(userModule.Repo, accountRepo, memberRepo)
+	inviteUC := accountuc.NewInviteUsecase(userModule.Repo, userModule.Repo, accountRepo, memberRepo,
+		accountrepo.NewInviteRepository(),
+		append([]accountuc.InviteOption[*UserType]{
+			accountuc.WithInviteUserCreator(inviteUserCreator(userModule)),
+		}, inviteOpts...)...)
+	ownershipUC := accountuc.NewOwnershipUsecase(memberRepo, accountrepo.NewOwnershipRepository())
+	teamUC := accountuc.NewTeamUsecase(memberRepo, accountrepo.NewTeamRepository())
+	domainUC := accountuc.NewDomainUsecase(accountRepo, memberRepo, accountrepo.NewDomainRepository())
+	activityUC := accountuc.NewActivityUsecase(memberRepo, optionrepo.NewOptionRepository(nil))
+	authLoader := accauth.NewLoader(userModule.Repo, accountRepo, memberRepo,
+		accauth.WithActivityTracker[*UserType, *AccountType](activity))
 	graphqlDeps := GraphQLDeps{
 		UserRepo:    userModule.Repo,
 		AccountRepo: accountRepo,
@@ -69,7 +88,26 @@ func NewDeps() *Deps {
 		MemberRepo:  memberRepo,
 		AccountUC:   accountUC,
 		MemberUC:    memberUC,
+		InviteUC:    inviteUC,
+		OwnershipUC: ownershipUC,
+		TeamUC:      teamUC,
+		DomainUC:    domainUC,
+		ActivityUC:  activityUC,
 		AuthLoader:  authLoader,
 		GraphQL:     graphqlDeps,
 	}
 }
+
+// inviteUserCreator stores the password of the invitee with the password policy,
+// the user without the password can login by the email link or reset it later
+func inviteUserCreator(users userstack.Module[*UserType]) accountuc.InviteUserCreator[*UserType] {
+	return func(ctx context.Context, userObj *UserType, password string) (uint64, error) {
+		if password == "" {
+			return users.Repo.Create(ctx, userObj)
+		}
+		if err := users.Password.ValidatePassword(ctx, userObj, password); err != nil {
+			return 0, err
+		}
+		return users.Repo.CreateWithPassword(ctx, userObj, password)
+	}
+}
@@ -0,0 +1,17 @@
+package appinit
+
+import (
+	"github.com/geniusrabbit/blaze-api/example/api/cmd/api/appcontext"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
+	useruc "github.com/geniusrabbit/blaze-api/repository/user/usecase"
+)
+
+// EmailOptions of the email verification and the login by link from the application config
+func EmailOptions(conf *appcontext.ConfigType, limiter *bruteforce.Limiter) []useruc.EmailOption {
+	return []useruc.EmailOption{
+		useruc.WithEmailLimiter(limiter),
+		useruc.WithEmailTokenLifetime(conf.Email.VerifyTokenLifetime, conf.Email.LoginTokenLifetime),
+		useruc.WithEmailResendCooldown(conf.Email.ResendCooldown),
+		useruc.WithApproveAfterVerification(conf.Email.ApproveAfterVerification),
+	}
+}
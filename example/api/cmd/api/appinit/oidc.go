@@ -0,0 +1,51 @@
+package appinit
+
+import (
+	"context"
+	"crypto/rsa"
+	"time"
+
+	"github.com/ory/fosite"
+
+	"github.com/geniusrabbit/blaze-api/example/api/cmd/api/appcontext"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/oidc"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/serverprovider"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	authclientrepo "github.com/geniusrabbit/blaze-api/repository/authclient/repository"
+	authclientusecase "github.com/geniusrabbit/blaze-api/repository/authclient/usecase"
+)
+
+// OpenIDConnect server of the "Log in with" flow and the device authorization of the CLI tools
+func OpenIDConnect(conf *appcontext.ConfigType, provider fosite.OAuth2Provider, storage *serverprovider.DatabaseStorage, jwtProvider *jwt.Provider, deps *Deps, signingKey *rsa.PrivateKey) *oidc.Server[*UserType, *AccountType] {
+	opts := []oidc.Option{
+		oidc.WithIssuer(conf.OAuth2.Issuer),
+		oidc.WithLoginURL(conf.OAuth2.LoginURL),
+		oidc.WithPairwiseSalt(conf.OAuth2.PairwiseSalt),
+		oidc.WithSigningKey(signingKey, conf.OAuth2.SigningKeyID),
+		oidc.WithAuthTime(func(ctx context.Context) time.Time {
+			// The login time is known only for the session tokens
+			data, err := jwtProvider.ParseToken(session.Token(ctx))
+			if err != nil || data.AuthTime == 0 {
+				return time.Time{}
+			}
+			return time.Unix(data.AuthTime, 0)
+		}),
+		oidc.WithTokenExchange(),
+		oidc.WithClientRegistration(authclientusecase.NewRegistrationUsecase(
+			authclientrepo.NewRegistrationRepository())),
+	}
+	if conf.OAuth2.ConsentURL != "" {
+		opts = append(opts, oidc.WithConsent(conf.OAuth2.ConsentURL,
+			authclientrepo.NewConsentRepository(), conf.OAuth2.ConsentLifespan))
+	}
+	if conf.OAuth2.DeviceVerificationURL != "" {
+		opts = append(opts, oidc.WithDeviceAuthorizer(serverprovider.NewDeviceAuthorizer(provider, storage,
+			serverprovider.DeviceConfig{
+				VerificationURI: conf.OAuth2.DeviceVerificationURL,
+				CodeLifespan:    conf.OAuth2.DeviceCodeLifespan,
+				PollInterval:    conf.OAuth2.DevicePollInterval,
+			})))
+	}
+	return oidc.NewServer(provider, deps.AccountRepo, opts...)
+}
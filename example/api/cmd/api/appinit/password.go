@@ -0,0 +1,25 @@
+package appinit
+
+import (
+	"github.com/geniusrabbit/blaze-api/example/api/cmd/api/appcontext"
+	"github.com/geniusrabbit/blaze-api/repository/user/password"
+)
+
+// PasswordPolicy from the application config
+func PasswordPolicy(conf *appcontext.ConfigType) *password.Policy {
+	policy := &password.Policy{
+		MinLength:      conf.Password.MinLength,
+		MaxLength:      conf.Password.MaxLength,
+		RequireLower:   conf.Password.RequireLower,
+		RequireUpper:   conf.Password.RequireUpper,
+		RequireDigit:   conf.Password.RequireDigit,
+		RequireSpecial: conf.Password.RequireSpecial,
+		HistorySize:    conf.Password.HistorySize,
+	}
+	if conf.Password.BreachedList != "" {
+		source, err := password.NewLocalRangeSourceFromFile(conf.Password.BreachedList)
+		fatalError(err, "load breached passwords list")
+		policy.Breached = password.NewKAnonymityChecker(source)
+	}
+	return policy
+}
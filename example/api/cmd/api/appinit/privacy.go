@@ -0,0 +1,95 @@
+package appinit
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/example/api/internal/domain"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accountModels "github.com/geniusrabbit/blaze-api/repository/account/models"
+	authModels "github.com/geniusrabbit/blaze-api/repository/authclient/models"
+	daModels "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/models"
+	historyModels "github.com/geniusrabbit/blaze-api/repository/historylog/models"
+	optionModels "github.com/geniusrabbit/blaze-api/repository/option/models"
+	"github.com/geniusrabbit/blaze-api/repository/privacy"
+	privacyrepo "github.com/geniusrabbit/blaze-api/repository/privacy/repository"
+	privacyuc "github.com/geniusrabbit/blaze-api/repository/privacy/usecase"
+	socialModels "github.com/geniusrabbit/blaze-api/repository/socialaccount/models"
+	userModels "github.com/geniusrabbit/blaze-api/repository/user/models"
+)
+
+// Privacy of the user personal data, every table with the user data must be listed here.
+// The erased user row is kept for the references but can't be used anymore.
+func Privacy() privacy.Usecase {
+	return privacyuc.New(
+		// The invites are found by the email of the user, so they are erased before the user row.
+		// The invite is kept for the account audit, the pending one can't be accepted anymore.
+		privacyrepo.NewTableSource("member_invites", &account.MemberInvite{},
+			`EXISTS (SELECT 1 FROM account_user u WHERE u.id=? AND `+
+				`(u.id=account_member_invite.user_id OR LOWER(u.email)=LOWER(account_member_invite.email)))`,
+			privacyrepo.WithOmit("token"),
+			privacyrepo.WithAnonymize(map[string]any{
+				"email":  gorm.Expr(`'erased-' || id || '@erased.invalid'`),
+				"status": gorm.Expr(`CASE WHEN status='pending' THEN 'revoked' ELSE status END`),
+			})),
+		privacyrepo.NewTableSource("user", &domain.User{}, `id=?`,
+			privacyrepo.WithOmit("password"),
+			privacyrepo.WithAnonymize(map[string]any{
+				"email":          gorm.Expr(`'erased-' || id || '@erased.invalid'`),
+				"email_verified": false,
+				"password":       "",
+				"suspended_at":   gorm.Expr(`NOW()`),
+				"suspend_reason": "erased",
+				"last_active_at": gorm.Expr(`NULL`),
+			})),
+		privacyrepo.NewTableSource("members", &accountModels.MemberBase{}, `user_id=?`),
+		privacyrepo.NewTableSource("activity", &account.MemberActivity{}, `user_id=?`,
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("social_accounts", &socialModels.AccountSocial{}, `user_id=?`,
+			privacyrepo.WithAnonymize(map[string]any{
+				"email":      "",
+				"first_name": "",
+				"last_name":  "",
+				"username":   "",
+				"avatar":     "",
+				"link":       "",
+				"data":       gorm.Expr(`NULL`),
+			}),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("social_sessions", &socialModels.AccountSocialSession{},
+			`account_social_id IN (SELECT id FROM account_social WHERE user_id=?)`,
+			privacyrepo.WithOmit("access_token", "refresh_token"),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("email_tokens", &userModels.UserEmailToken{}, `user_id=?`,
+			privacyrepo.WithOmit("token"),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("password_resets", &userModels.UserPasswordReset{}, `user_id=?`,
+			privacyrepo.WithOmit("token"),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("password_history", &userModels.UserPasswordHistory{}, `user_id=?`,
+			privacyrepo.WithOmit("password"),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("options", &optionModels.Option{}, `type='user' AND target_id=?`,
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("history", &historyModels.HistoryAction{}, `user_id=?`,
+			privacyrepo.WithAnonymize(map[string]any{"data": gorm.Expr(`'{}'::jsonb`)})),
+		// The revoked auth sessions keep the tokens and the names, so the rows are removed completely
+		privacyrepo.NewTableSource("auth_sessions", &authModels.AuthSession{}, `user_id=?`,
+			privacyrepo.WithScopes(unscoped),
+			privacyrepo.WithOmit("access_token", "refresh_token"),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("auth_grants", &authModels.AuthGrant{}, `user_id=?`,
+			privacyrepo.WithScopes(unscoped),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("auth_device_requests", &authModels.AuthDeviceRequest{}, `user_id=?`,
+			privacyrepo.WithScopes(unscoped),
+			privacyrepo.WithOmit("device_code", "user_code"),
+			privacyrepo.WithDelete()),
+		privacyrepo.NewTableSource("direct_access_tokens", &daModels.DirectAccessToken{}, `user_id=?`,
+			privacyrepo.WithOmit("token"),
+			privacyrepo.WithDelete()),
+	)
+}
+
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
@@ -0,0 +1,60 @@
+package appinit
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/testsuite"
+)
+
+type testPrivacySuite struct {
+	testsuite.DatabaseSuite
+}
+
+func (s *testPrivacySuite) SetupSuite() {
+	s.DatabaseSuite.SetupSuite()
+	s.Ctx = session.WithUserAccountDevelop(s.Ctx)
+}
+
+// TestExportSources checks that every table with the personal data is the part of the archive
+func (s *testPrivacySuite) TestExportSources() {
+	sources := []struct {
+		name  string
+		query string
+	}{
+		{name: "member_invites", query: `SELECT .* FROM "account_member_invite" WHERE EXISTS`},
+		{name: "user", query: `FROM "account_user" WHERE id=\$1`},
+		{name: "members", query: `FROM "account_member" WHERE user_id=\$1`},
+		{name: "activity", query: `FROM "account_member_activity" WHERE user_id=\$1`},
+		{name: "social_accounts", query: `FROM "account_social" WHERE user_id=\$1`},
+		{name: "social_sessions", query: `FROM "account_social_session"`},
+		{name: "email_tokens", query: `SELECT .* FROM "account_user_email_token" WHERE user_id=\$1$`},
+		{name: "password_resets", query: `SELECT .* FROM "account_user_password_reset" WHERE user_id=\$1$`},
+		{name: "password_history", query: `FROM "account_user_password_history" WHERE user_id=\$1`},
+		{name: "options", query: `FROM "option" WHERE \(type='user' AND target_id=\$1\)`},
+		{name: "history", query: `FROM "history_actions" WHERE user_id=\$1`},
+		{name: "auth_sessions", query: `SELECT .* FROM "auth_session" WHERE user_id=\$1$`},
+		{name: "auth_grants", query: `SELECT .* FROM "auth_grant" WHERE user_id=\$1$`},
+		{name: "auth_device_requests", query: `SELECT .* FROM "auth_device_request" WHERE user_id=\$1$`},
+		{name: "direct_access_tokens", query: `FROM "direct_access_tokens" WHERE user_id=\$1`},
+	}
+	for _, source := range sources {
+		s.Mock.ExpectQuery(source.query).WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+
+	archive, err := Privacy().Export(s.Ctx, 10)
+	s.Require().NoError(err)
+	s.Len(archive.Sections, len(sources))
+	for _, source := range sources {
+		s.Contains(archive.Sections, source.name)
+	}
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func TestPrivacySuite(t *testing.T) {
+	suite.Run(t, &testPrivacySuite{})
+}
@@ -0,0 +1,30 @@
+package appinit
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/example/api/internal/domain"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	daModels "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/models"
+	optionrepo "github.com/geniusrabbit/blaze-api/repository/option/repository"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	quotarepo "github.com/geniusrabbit/blaze-api/repository/quota/repository"
+	quotauc "github.com/geniusrabbit/blaze-api/repository/quota/usecase"
+)
+
+// Quotas of the account resources, limits are defined by the plan options
+func Quotas() quota.Usecase {
+	return quotauc.New(
+		optionrepo.NewOptionRepository(nil),
+		quotauc.WithCounter((&domain.AccountMember{}).RBACResourceName(),
+			quotarepo.NewCounter(&domain.AccountMember{})),
+		quotauc.WithCounter((&authclient.AuthClient{}).RBACResourceName(),
+			quotarepo.NewCounter(&authclient.AuthClient{})),
+		quotauc.WithCounter((&daModels.DirectAccessToken{}).RBACResourceName(),
+			quotarepo.NewCounter(&daModels.DirectAccessToken{}, activeTokens)),
+	)
+}
+
+func activeTokens(db *gorm.DB) *gorm.DB {
+	return db.Where(`expires_at > NOW()`)
+}
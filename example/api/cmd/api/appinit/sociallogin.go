@@ -0,0 +1,93 @@
+package appinit
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/oauth2"
+
+	"github.com/geniusrabbit/blaze-api/example/api/cmd/api/appcontext"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/facebook"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/oidc"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/tokencrypt"
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
+	socaccrepo "github.com/geniusrabbit/blaze-api/repository/socialaccount/repository"
+	socaccusecase "github.com/geniusrabbit/blaze-api/repository/socialaccount/usecase"
+)
+
+// SocialLogins returns the providers of the "Log in with" flow.
+// The providers with the issuer use the OpenID Connect discovery,
+// Facebook without the issuer uses the Graph API.
+func SocialLogins(ctx context.Context, conf *appcontext.ConfigType) []elogin.AuthAccessor {
+	var accessors []elogin.AuthAccessor
+	providers := conf.SocialAuth.OpenIDConnectProviders()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		providerConf := providers[name]
+		provider, err := oidc.New(ctx, oidc.Config{
+			ProviderName: name,
+			Issuer:       providerConf.Issuer,
+			ClientID:     providerConf.ClientID,
+			ClientSecret: providerConf.ClientSecret,
+			RedirectURL:  providerConf.RedirectURL,
+			Scopes:       providerConf.Scopes,
+		})
+		fatalError(err, "social login "+name)
+		accessors = append(accessors, provider)
+	}
+	if _, ok := providers["facebook"]; !ok && conf.SocialAuth.Facebook.IsValid() {
+		accessors = append(accessors, facebook.NewFacebookConfig(conf.SocialAuth.Facebook.OAuth2Config("facebook")))
+	}
+	return accessors
+}
+
+// SocialTokenCipher returns the cipher of the stored provider tokens if the secret is configured
+func SocialTokenCipher(conf *appcontext.ConfigType) tokencrypt.Cipher {
+	if conf.SocialAuth.TokenSecret == "" {
+		return nil
+	}
+	cipher, err := tokencrypt.NewAESCipher(conf.SocialAuth.TokenSecret)
+	fatalError(err, "social token cipher")
+	return cipher
+}
+
+// SocialAccounts returns the social account usecase which refreshes the tokens of the login providers.
+// The last social account of the user without the password can't be disconnected.
+func SocialAccounts(deps *Deps, cipher tokencrypt.Cipher, logins []elogin.AuthAccessor) socialaccount.Usecase {
+	var repoOpts []socaccrepo.Option
+	if cipher != nil {
+		repoOpts = append(repoOpts, socaccrepo.WithTokenCipher(cipher))
+	}
+	repo := socaccrepo.NewSocaccRepository(repoOpts...)
+
+	var tokenOpts []socaccusecase.TokenManagerOption
+	for _, login := range logins {
+		if conf, ok := login.(interface{ OAuth2Config() *oauth2.Config }); ok {
+			tokenOpts = append(tokenOpts, socaccusecase.WithProviderConfig(login.Provider(), conf.OAuth2Config()))
+		}
+	}
+	return socaccusecase.NewSocaccUsecase(repo,
+		socaccusecase.WithTokenManager(socaccusecase.NewTokenManager(repo, tokenOpts...)),
+		socaccusecase.WithPasswordChecker(func(ctx context.Context, userID uint64) (bool, error) {
+			obj, err := deps.UserModule.Repo.Get(ctx, userID)
+			if err != nil {
+				return false, err
+			}
+			return obj.GetPasswordHash() != "", nil
+		}),
+	)
+}
+
+// SocialProviderNames returns the names of the login providers
+func SocialProviderNames(logins []elogin.AuthAccessor) []string {
+	names := make([]string, 0, len(logins))
+	for _, login := range logins {
+		names = append(names, login.Provider())
+	}
+	return names
+}
@@ -69,6 +69,10 @@ func EnsureSuperuser(ctx context.Context, email, password string, deps *Deps) er
 		lg.Error("Failed to link user as admin member", zap.String("email", email), zap.Error(err))
 		return err
 	}
+	if err = deps.MemberRepo.SetOwner(ctx, acc.ID, u.ID); err != nil {
+		lg.Error("Failed to set user as owner of the system account", zap.String("email", email), zap.Error(err))
+		return err
+	}
 
 	// ===========================================================================
 	// Assign system:admin role to the member.
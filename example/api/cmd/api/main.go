@@ -20,13 +20,15 @@ import (
 	"github.com/geniusrabbit/blaze-api/example/api/internal/server/graphql"
 	exmodels "github.com/geniusrabbit/blaze-api/example/api/internal/server/graphql/models"
 	"github.com/geniusrabbit/blaze-api/example/api/internal/server/graphql/wiring"
+	userstack "github.com/geniusrabbit/blaze-api/example/api/internal/user"
 	"github.com/geniusrabbit/blaze-api/pkg/auth"
-	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/facebook"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
 	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
 	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2"
 	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/pkg/context/version"
 	"github.com/geniusrabbit/blaze-api/pkg/database"
+	"github.com/geniusrabbit/blaze-api/pkg/middleware"
 	"github.com/geniusrabbit/blaze-api/pkg/migratedb"
 	"github.com/geniusrabbit/blaze-api/pkg/permissions"
 	"github.com/geniusrabbit/blaze-api/pkg/profiler"
@@ -35,11 +37,15 @@ import (
 	"github.com/geniusrabbit/blaze-api/repository/account/authorizer"
 	accountgraphql "github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql"
 	accountlogin "github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql/account_login"
+	accountuc "github.com/geniusrabbit/blaze-api/repository/account/usecase"
 	"github.com/geniusrabbit/blaze-api/repository/historylog/middleware/gormlog"
+	"github.com/geniusrabbit/blaze-api/repository/privacy"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
 	rbacrepo "github.com/geniusrabbit/blaze-api/repository/rbac/repository"
 	"github.com/geniusrabbit/blaze-api/repository/socialauth/delivery/rest"
 	socautherepo "github.com/geniusrabbit/blaze-api/repository/socialauth/repository"
 	socautheuse "github.com/geniusrabbit/blaze-api/repository/socialauth/usecase"
+	useruc "github.com/geniusrabbit/blaze-api/repository/user/usecase"
 )
 
 var (
@@ -116,30 +122,53 @@ func main() {
 	// Register callback for history log (only for modifications)
 	fatalError(gormlog.Register(masterDatabase), "register history log")
 
-	deps := appinit.NewDeps()
+	// Brute-force protection of the login and password reset
+	limiter := appinit.BruteForce(ctx, conf)
+
+	deps := appinit.NewDeps(userstack.ModuleOptions{
+		Password: []useruc.PasswordOption{
+			useruc.WithPasswordLimiter(limiter),
+			useruc.WithPasswordPolicy(appinit.PasswordPolicy(conf)),
+		},
+		Email: appinit.EmailOptions(conf, limiter),
+	}, appinit.ActivityTracker(ctx, conf),
+		accountuc.WithInviteLifetime[*domain.User](conf.Email.InviteLifetime))
 
 	// Init permission manager
 	permissionManager := permissions.NewManager(masterDatabase, conf.Permissions.RoleCacheLifetime)
 	appinit.InitModelPermissions(permissionManager, deps)
 
 	// Init OAuth2 provider
-	oauth2provider, jwtProvider := appinit.Auth(ctx, conf, masterDatabase, deps)
+	signingKey := appinit.SigningKey(conf)
+	oauth2provider, oauth2storage, jwtProvider := appinit.Auth(ctx, conf, masterDatabase, deps, signingKey)
+	oidcServer := appinit.OpenIDConnect(conf, oauth2provider, oauth2storage, jwtProvider, deps, signingKey)
+	socialLogins := appinit.SocialLogins(ctx, conf)
+	socialTokenCipher := appinit.SocialTokenCipher(conf)
+	quotas := appinit.Quotas()
+	privacyUC := appinit.Privacy()
 
 	// Prepare context
 	ctx = ctxlogger.WithLogger(ctx, loggerObj)
 	ctx = database.WithDatabase(ctx, masterDatabase, slaveDatabase)
 	ctx = permissions.WithManager(ctx, permissionManager)
+	ctx = quota.WithUsecase(ctx, quotas)
+	ctx = privacy.WithUsecase(ctx, privacyUC)
 
 	fatalError(
 		appinit.EnsureSuperuser(ctx, conf.Superuser.Email, conf.Superuser.Password, deps),
 		"init superuser")
 
+	trustedProxies, err := middleware.ParseProxies(conf.Server.HTTP.TrustedProxies...)
+	fatalError(err, "trusted proxies")
+
 	httpServer := server.HTTPServer{
 		Logger:         loggerObj,
+		TrustedProxies: trustedProxies,
 		JWTProvider:    jwtProvider,
 		SessionManager: appinit.SessionManager(conf.Session.CookieName, conf.Session.Lifetime),
 		AuthLoader:     deps.AuthLoader,
 		Authorizers: []auth.Authorizer[*domain.User, *domain.Account]{
+			authorizer.NewDirectTokenAuthorizer(deps.AuthLoader),
 			jwt.NewAuthorizer(jwtProvider, deps.AuthLoader),
 			oauth2.NewAuthorizer(oauth2provider, deps.AccountRepo),
 			authorizer.NewDevTokenAuthorizer(gocast.IfThen(conf.IsDebug(), &authorizer.AuthOption{
@@ -151,7 +180,10 @@ func main() {
 		GraphqlOptions: graphql.Options{
 			graphql.WithUserAccountResolvers(
 				jwtProvider,
-				wiring.NewExampleUserQueryResolver(deps.UserModule),
+				wiring.NewExampleUserQueryResolver(deps.UserModule, wiring.UserLinks{
+					ResetPassword: conf.Password.ResetLinkURL,
+					VerifyEmail:   conf.Email.VerifyLinkURL,
+				}),
 				accountgraphql.NewAuthResolver(
 					jwtProvider,
 					deps.AccountRepo,
@@ -169,6 +201,7 @@ func main() {
 					accountgraphql.MemberQueryResolverConfig[*domain.User, *domain.Account, *exmodels.Account]{
 						Accounts: deps.AccountUC,
 						Members:  deps.MemberUC,
+						Activity: deps.ActivityUC,
 						UserRepo: deps.GraphQL.UserRepo,
 					},
 				),
@@ -177,35 +210,86 @@ func main() {
 				jwtProvider,
 				accountlogin.NewEmailPasswordLogin(deps.UserModule.Repo, deps.UserModule.Repo),
 				deps.AccountRepo,
+				loginOptions(conf, deps, limiter)...,
+			),
+			graphql.WithMemberInviteResolver(
+				accountgraphql.NewInviteQueryResolver(
+					accountgraphql.InviteQueryResolverConfig[*domain.User, *domain.Account]{
+						Accounts:  deps.AccountUC,
+						Invites:   deps.InviteUC,
+						UserRepo:  deps.GraphQL.UserRepo,
+						AcceptURL: conf.Email.InviteLinkURL,
+					},
+				),
+			),
+			graphql.WithOwnershipResolver(accountgraphql.NewOwnershipQueryResolver(deps.OwnershipUC)),
+			graphql.WithTeamResolver(accountgraphql.NewTeamQueryResolver(deps.TeamUC)),
+			graphql.WithDomainResolver(accountgraphql.NewDomainQueryResolver(deps.DomainUC)),
+			graphql.WithImpersonationResolver(
+				accountgraphql.NewImpersonationResolver(
+					accountgraphql.ImpersonationResolverConfig[*domain.User, *domain.Account]{
+						Provider: jwtProvider,
+						Loader:   deps.AuthLoader,
+						Lifetime: conf.Session.ImpersonationLifetime,
+					},
+				),
+				conf.Session.ImpersonationBlocked...,
+			),
+			graphql.WithConsentResolver(oauth2storage),
+			graphql.WithSocialAccountResolver(
+				appinit.SocialAccounts(deps, socialTokenCipher, socialLogins),
+				appinit.SocialProviderNames(socialLogins)...,
 			),
 		},
 		ContextWrap: func(ctx context.Context) context.Context {
 			ctx = ctxlogger.WithLogger(ctx, loggerObj)
 			ctx = database.WithDatabase(ctx, masterDatabase, slaveDatabase)
 			ctx = permissions.WithManager(ctx, permissionManager)
+			ctx = quota.WithUsecase(ctx, quotas)
+			ctx = privacy.WithUsecase(ctx, privacyUC)
 			return ctx
 		},
 		InitWrap: func(mux *chi.Mux) {
-			if conf.SocialAuth.Facebook.IsValid() {
-				oa2conf := conf.SocialAuth.Facebook.OAuth2Config("facebook")
-				mux.Handle("/auth/facebook/*",
-					rest.NewWrapper(facebook.NewFacebookConfig(oa2conf),
-						rest.WithSessionProvider(jwtProvider),
-						rest.WithAccountResolver(func(ctx context.Context, filter *account.Filter) ([]*domain.Account, error) {
-							return deps.AccountRepo.FetchList(ctx, filter)
-						}),
-						rest.WithSocialAuthUsecase(socautheuse.New(
-							socautherepo.New(),
-							deps.UserModule.Repo,
-						)),
-					).HandleWrapper("/auth/facebook"),
-				)
+			oidcServer.Register(mux)
+			socialOpts := []rest.Option{
+				rest.WithSessionProvider(jwtProvider),
+				rest.WithAccountResolver(func(ctx context.Context, filter *account.Filter) ([]*domain.Account, error) {
+					return deps.AccountRepo.FetchList(ctx, filter)
+				}),
+				rest.WithSocialAuthUsecase(socautheuse.New(
+					socautherepo.New(socautherepo.WithTokenCipher(socialTokenCipher)),
+					deps.UserModule.Repo,
+				)),
+				rest.WithLinkAuthAge(conf.SocialAuth.LinkAuthAge),
+				rest.WithEmailConflictCheck(deps.UserModule.Repo),
+				rest.WithUserProvisioner(accountuc.NewDomainProvisioner(deps.UserModule.Repo, deps.DomainUC)),
+			}
+			if conf.Email.ApproveAfterVerification {
+				socialOpts = append(socialOpts, rest.WithApprovedOnly(deps.UserModule.Repo))
+			}
+			for _, socialLogin := range socialLogins {
+				prefix := "/auth/" + socialLogin.Provider()
+				mux.Handle(prefix+"/*", rest.NewWrapper(socialLogin, socialOpts...).HandleWrapper(prefix))
 			}
 		},
 	}
 	fatalError(httpServer.Run(ctx, conf.Server.HTTP.Listen), "HTTP server")
 }
 
+func loginOptions(conf *appcontext.ConfigType, deps *appinit.Deps, limiter *bruteforce.Limiter) []accountlogin.Option[*domain.User, *domain.Account] {
+	opts := []accountlogin.Option[*domain.User, *domain.Account]{
+		accountlogin.WithLimiter[*domain.User, *domain.Account](limiter),
+		accountlogin.WithSSOPolicy[*domain.User, *domain.Account](deps.DomainUC),
+	}
+	if conf.Email.LoginLinkURL != "" {
+		opts = append(opts, accountlogin.WithLoginLink[*domain.User, *domain.Account](deps.UserModule.Email, conf.Email.LoginLinkURL))
+	}
+	if conf.Email.ApproveAfterVerification {
+		opts = append(opts, accountlogin.WithApprovedOnly[*domain.User, *domain.Account]())
+	}
+	return opts
+}
+
 func fatalError(err error, msgs ...any) {
 	if err != nil {
 		log.Fatalln(append(msgs, err)...)
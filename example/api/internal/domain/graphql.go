@@ -15,17 +15,21 @@ func AccountToGraphQL(acc *Account) *exmodels.Account {
 		return nil
 	}
 	return &exmodels.Account{
-		ID:                acc.GetID(),
-		Status:            basemodels.ApproveStatusFrom(acc.GetApprove()),
-		Title:             acc.GetTitle(),
-		Description:       acc.GetDescription(),
-		LogoURI:           acc.GetLogoURI(),
-		PolicyURI:         acc.GetPolicyURI(),
-		TermsOfServiceURI: acc.GetTermsOfServiceURI(),
-		ClientURI:         acc.GetClientURI(),
-		Contacts:          acc.GetContacts(),
-		CreatedAt:         acc.GetCreatedAt(),
-		UpdatedAt:         acc.GetUpdatedAt(),
+		ID:                 acc.GetID(),
+		Status:             basemodels.ApproveStatusFrom(acc.GetApprove()),
+		Title:              acc.GetTitle(),
+		Description:        acc.GetDescription(),
+		LogoURI:            acc.GetLogoURI(),
+		PolicyURI:          acc.GetPolicyURI(),
+		TermsOfServiceURI:  acc.GetTermsOfServiceURI(),
+		ClientURI:          acc.GetClientURI(),
+		Contacts:           acc.GetContacts(),
+		ParentID:           gocast.IfThen(acc.ParentID > 0, &acc.ParentID, nil),
+		InheritPermissions: acc.InheritPermissions,
+		SuspendedAt:        gocast.IfThen(acc.SuspendedAt.Valid, &acc.SuspendedAt.V, nil),
+		SuspendReason:      gocast.IfThen(acc.SuspendReason != "", &acc.SuspendReason, nil),
+		CreatedAt:          acc.GetCreatedAt(),
+		UpdatedAt:          acc.GetUpdatedAt(),
 	}
 }
 
@@ -103,11 +107,15 @@ func UserToGraphQL(u *User) *exmodels.User {
 		return nil
 	}
 	return &exmodels.User{
-		ID:        u.GetID(),
-		Email:     u.GetEmail(),
-		Status:    basemodels.ApproveStatusFrom(u.GetApprove()),
-		CreatedAt: u.GetCreatedAt(),
-		UpdatedAt: u.GetUpdatedAt(),
+		ID:            u.GetID(),
+		Email:         u.GetEmail(),
+		EmailVerified: u.IsEmailVerified(),
+		Status:        basemodels.ApproveStatusFrom(u.GetApprove()),
+		SuspendedAt:   gocast.IfThen(u.SuspendedAt.Valid, &u.SuspendedAt.V, nil),
+		SuspendReason: gocast.IfThen(u.SuspendReason != "", &u.SuspendReason, nil),
+		LastActiveAt:  gocast.IfThen(u.LastActiveAt.Valid, &u.LastActiveAt.V, nil),
+		CreatedAt:     u.GetCreatedAt(),
+		UpdatedAt:     u.GetUpdatedAt(),
 	}
 }
 
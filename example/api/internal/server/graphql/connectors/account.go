@@ -7,7 +7,10 @@ import (
 )
 
 type (
-	UserConnection    = usergraphql.UserConnection[*exmodels.User]
-	AccountConnection = accountgraphql.AccountConnection[*exmodels.Account]
-	MemberConnection  = accountgraphql.MemberConnection
+	UserConnection          = usergraphql.UserConnection[*exmodels.User]
+	AccountConnection       = accountgraphql.AccountConnection[*exmodels.Account]
+	MemberConnection        = accountgraphql.MemberConnection
+	MemberInviteConnection  = accountgraphql.MemberInviteConnection
+	AccountTeamConnection   = accountgraphql.TeamConnection
+	AccountDomainConnection = accountgraphql.DomainConnection
 )
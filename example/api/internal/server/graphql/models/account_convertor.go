@@ -29,6 +29,8 @@ func (fl *AccountListFilter) Filter() *account.Filter {
 		Status: xtypes.SliceApply(fl.Status, func(st basemodels.ApproveStatus) pkgModels.ApproveStatus {
 			return st.ModelStatus()
 		}),
+		ParentID:   fl.ParentID,
+		AncestorID: fl.AncestorID,
 	}
 }
 
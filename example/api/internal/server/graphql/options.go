@@ -5,6 +5,8 @@ import (
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	accountgraphql "github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql"
 	accountlogin "github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql/account_login"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
 	"github.com/geniusrabbit/blaze-api/repository/user"
 
 	exmodels "github.com/geniusrabbit/blaze-api/example/api/internal/server/graphql/models"
@@ -41,6 +43,42 @@ func WithUserLoginHandler[TUser user.Model, TAccount account.Model](
 	provider *jwt.Provider,
 	userLogin accountlogin.LoginPasswordAuth[TUser],
 	sessionRepo account.SessionRepository[TUser, TAccount],
+	opts ...accountlogin.Option[TUser, TAccount],
 ) wiring.Option {
-	return wiring.WithUserLoginHandler(provider, userLogin, sessionRepo)
+	return wiring.WithUserLoginHandler(provider, userLogin, sessionRepo, opts...)
+}
+
+// WithConsentResolver re-exports wiring.WithConsentResolver.
+func WithConsentResolver(revoker authclient.SessionRevoker) wiring.Option {
+	return wiring.WithConsentResolver(revoker)
+}
+
+// WithSocialAccountResolver re-exports wiring.WithSocialAccountResolver.
+func WithSocialAccountResolver(accounts socialaccount.Usecase, providers ...string) wiring.Option {
+	return wiring.WithSocialAccountResolver(accounts, providers...)
+}
+
+// WithMemberInviteResolver re-exports wiring.WithMemberInviteResolver.
+func WithMemberInviteResolver(invites accountgraphql.MemberInviteQueryHandler) wiring.Option {
+	return wiring.WithMemberInviteResolver(invites)
+}
+
+// WithOwnershipResolver re-exports wiring.WithOwnershipResolver.
+func WithOwnershipResolver(ownership accountgraphql.OwnershipQueryHandler) wiring.Option {
+	return wiring.WithOwnershipResolver(ownership)
+}
+
+// WithTeamResolver re-exports wiring.WithTeamResolver.
+func WithTeamResolver(teams accountgraphql.TeamQueryHandler) wiring.Option {
+	return wiring.WithTeamResolver(teams)
+}
+
+// WithDomainResolver re-exports wiring.WithDomainResolver.
+func WithDomainResolver(domains accountgraphql.DomainQueryHandler) wiring.Option {
+	return wiring.WithDomainResolver(domains)
+}
+
+// WithImpersonationResolver re-exports wiring.WithImpersonationResolver.
+func WithImpersonationResolver(impersonation accountgraphql.ImpersonationQueryHandler, blocked ...string) wiring.Option {
+	return wiring.WithImpersonationResolver(impersonation, blocked...)
 }
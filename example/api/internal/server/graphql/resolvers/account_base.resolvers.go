@@ -33,6 +33,11 @@ func (r *mutationResolver) UpdateAccount(ctx context.Context, id uint64, input e
 	return r.accounts.UpdateAccount(ctx, id, &input)
 }
 
+// SetAccountParent is the resolver for the setAccountParent field.
+func (r *mutationResolver) SetAccountParent(ctx context.Context, id uint64, parentID uint64, inheritPermissions bool) (*exmodels.AccountPayload, error) {
+	return r.accounts.SetAccountParent(ctx, id, parentID, inheritPermissions)
+}
+
 // ApproveAccount is the resolver for the approveAccount field.
 func (r *mutationResolver) ApproveAccount(ctx context.Context, id uint64, msg string) (*exmodels.AccountPayload, error) {
 	return r.accounts.ApproveAccount(ctx, id, msg)
@@ -43,6 +48,16 @@ func (r *mutationResolver) RejectAccount(ctx context.Context, id uint64, msg str
 	return r.accounts.RejectAccount(ctx, id, msg)
 }
 
+// SuspendAccount is the resolver for the suspendAccount field.
+func (r *mutationResolver) SuspendAccount(ctx context.Context, id uint64, reason string) (*exmodels.AccountPayload, error) {
+	return r.accounts.SuspendAccount(ctx, id, reason)
+}
+
+// UnsuspendAccount is the resolver for the unsuspendAccount field.
+func (r *mutationResolver) UnsuspendAccount(ctx context.Context, id uint64) (*exmodels.AccountPayload, error) {
+	return r.accounts.UnsuspendAccount(ctx, id)
+}
+
 // CurrentSession is the resolver for the currentSession field.
 func (r *queryResolver) CurrentSession(ctx context.Context) (*basemodels.SessionToken, error) {
 	return r.accAuth.CurrentSession(ctx)
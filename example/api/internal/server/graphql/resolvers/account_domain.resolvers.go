@@ -0,0 +1,43 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/connectors"
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// AddAccountDomain is the resolver for the addAccountDomain field.
+func (r *mutationResolver) AddAccountDomain(ctx context.Context, accountID uint64, domain string) (*models.AccountDomainPayload, error) {
+	return r.domains.Add(ctx, accountID, domain)
+}
+
+// VerifyAccountDomain is the resolver for the verifyAccountDomain field.
+func (r *mutationResolver) VerifyAccountDomain(ctx context.Context, id uint64) (*models.AccountDomainPayload, error) {
+	return r.domains.Verify(ctx, id)
+}
+
+// SetAccountDomainPolicy is the resolver for the setAccountDomainPolicy field.
+func (r *mutationResolver) SetAccountDomainPolicy(ctx context.Context, id uint64, input models.AccountDomainPolicyInput) (*models.AccountDomainPayload, error) {
+	return r.domains.SetPolicy(ctx, id, &input)
+}
+
+// DeleteAccountDomain is the resolver for the deleteAccountDomain field.
+func (r *mutationResolver) DeleteAccountDomain(ctx context.Context, id uint64) (*models.AccountDomainPayload, error) {
+	return r.domains.Delete(ctx, id)
+}
+
+// AccountDomain is the resolver for the accountDomain field.
+func (r *queryResolver) AccountDomain(ctx context.Context, id uint64) (*models.AccountDomainPayload, error) {
+	return r.domains.Domain(ctx, id)
+}
+
+// ListAccountDomains is the resolver for the listAccountDomains field.
+func (r *queryResolver) ListAccountDomains(ctx context.Context, filter *models.AccountDomainListFilter, order []*models.AccountDomainListOrder, page *models.Page) (*connectors.CollectionConnection[*models.AccountDomain], error) {
+	return r.domains.List(ctx, filter, order, page)
+}
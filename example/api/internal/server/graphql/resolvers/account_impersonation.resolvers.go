@@ -0,0 +1,17 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// ImpersonateUser is the resolver for the impersonateUser field.
+func (r *mutationResolver) ImpersonateUser(ctx context.Context, userID uint64, accountID uint64, reason string) (*models.SessionToken, error) {
+	return r.impersonation.Impersonate(ctx, userID, accountID, reason)
+}
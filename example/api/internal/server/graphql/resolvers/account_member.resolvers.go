@@ -37,7 +37,27 @@ func (r *mutationResolver) RejectAccountMember(ctx context.Context, memberID uin
 	return r.members.Reject(ctx, memberID, msg)
 }
 
+// SuspendAccountMember is the resolver for the suspendAccountMember field.
+func (r *mutationResolver) SuspendAccountMember(ctx context.Context, memberID uint64, reason string) (*basemodels.MemberPayload, error) {
+	return r.members.Suspend(ctx, memberID, reason)
+}
+
+// UnsuspendAccountMember is the resolver for the unsuspendAccountMember field.
+func (r *mutationResolver) UnsuspendAccountMember(ctx context.Context, memberID uint64) (*basemodels.MemberPayload, error) {
+	return r.members.Unsuspend(ctx, memberID)
+}
+
+// SuspendInactiveMembers is the resolver for the suspendInactiveMembers field.
+func (r *mutationResolver) SuspendInactiveMembers(ctx context.Context, accountID uint64) ([]*basemodels.Member, error) {
+	return r.members.SuspendInactive(ctx, accountID)
+}
+
 // ListMembers is the resolver for the listMembers field.
 func (r *queryResolver) ListMembers(ctx context.Context, filter *basemodels.MemberListFilter, order []*basemodels.MemberListOrder, page *basemodels.Page) (*connectors.CollectionConnection[*basemodels.Member], error) {
 	return r.members.List(ctx, filter, order, page)
 }
+
+// ListInactiveMembers is the resolver for the listInactiveMembers field.
+func (r *queryResolver) ListInactiveMembers(ctx context.Context, accountID uint64, days int) ([]*basemodels.Member, error) {
+	return r.members.Inactive(ctx, accountID, days)
+}
@@ -0,0 +1,48 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/connectors"
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// InviteAccountMemberByEmail is the resolver for the inviteAccountMemberByEmail field.
+func (r *mutationResolver) InviteAccountMemberByEmail(ctx context.Context, accountID uint64, invite models.InviteMemberByEmailInput) (*models.MemberInvitePayload, error) {
+	return r.invites.Invite(ctx, accountID, &invite)
+}
+
+// ResendMemberInvite is the resolver for the resendMemberInvite field.
+func (r *mutationResolver) ResendMemberInvite(ctx context.Context, inviteID uint64) (*models.MemberInvitePayload, error) {
+	return r.invites.Resend(ctx, inviteID)
+}
+
+// RevokeMemberInvite is the resolver for the revokeMemberInvite field.
+func (r *mutationResolver) RevokeMemberInvite(ctx context.Context, inviteID uint64) (*models.MemberInvitePayload, error) {
+	return r.invites.Revoke(ctx, inviteID)
+}
+
+// AcceptMemberInvite is the resolver for the acceptMemberInvite field.
+func (r *mutationResolver) AcceptMemberInvite(ctx context.Context, token string, password string) (*models.MemberPayload, error) {
+	return r.invites.Accept(ctx, token, password)
+}
+
+// DeclineMemberInvite is the resolver for the declineMemberInvite field.
+func (r *mutationResolver) DeclineMemberInvite(ctx context.Context, token string) (*models.MemberInvitePayload, error) {
+	return r.invites.Decline(ctx, token)
+}
+
+// ListMemberInvites is the resolver for the listMemberInvites field.
+func (r *queryResolver) ListMemberInvites(ctx context.Context, filter *models.MemberInviteListFilter, order []*models.MemberInviteListOrder, page *models.Page) (*connectors.CollectionConnection[*models.MemberInvite], error) {
+	return r.invites.List(ctx, filter, order, page)
+}
+
+// MemberInvite is the resolver for the memberInvite field.
+func (r *queryResolver) MemberInvite(ctx context.Context, token string) (*models.MemberInvite, error) {
+	return r.invites.InviteByToken(ctx, token)
+}
@@ -0,0 +1,37 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// RequestAccountOwnershipTransfer is the resolver for the requestAccountOwnershipTransfer field.
+func (r *mutationResolver) RequestAccountOwnershipTransfer(ctx context.Context, accountID uint64, userID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	return r.ownership.Request(ctx, accountID, userID)
+}
+
+// ConfirmAccountOwnershipTransfer is the resolver for the confirmAccountOwnershipTransfer field.
+func (r *mutationResolver) ConfirmAccountOwnershipTransfer(ctx context.Context, transferID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	return r.ownership.Confirm(ctx, transferID)
+}
+
+// DeclineAccountOwnershipTransfer is the resolver for the declineAccountOwnershipTransfer field.
+func (r *mutationResolver) DeclineAccountOwnershipTransfer(ctx context.Context, transferID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	return r.ownership.Decline(ctx, transferID)
+}
+
+// CancelAccountOwnershipTransfer is the resolver for the cancelAccountOwnershipTransfer field.
+func (r *mutationResolver) CancelAccountOwnershipTransfer(ctx context.Context, transferID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	return r.ownership.Cancel(ctx, transferID)
+}
+
+// ListAccountOwnershipTransfers is the resolver for the listAccountOwnershipTransfers field.
+func (r *queryResolver) ListAccountOwnershipTransfers(ctx context.Context, status []models.AccountOwnershipTransferStatus) ([]*models.AccountOwnershipTransfer, error) {
+	return r.ownership.List(ctx, status)
+}
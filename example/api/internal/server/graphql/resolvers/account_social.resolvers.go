@@ -27,6 +27,11 @@ func (r *queryResolver) CurrentSocialAccounts(ctx context.Context, filter *basem
 	return r.socAccounts.ListCurrent(ctx, filter, order)
 }
 
+// CurrentSocialProviders is the resolver for the currentSocialProviders field.
+func (r *queryResolver) CurrentSocialProviders(ctx context.Context) ([]*basemodels.SocialProvider, error) {
+	return r.socAccounts.ListCurrentProviders(ctx)
+}
+
 // ListSocialAccounts is the resolver for the listSocialAccounts field.
 func (r *queryResolver) ListSocialAccounts(ctx context.Context, filter *basemodels.SocialAccountListFilter, order []*basemodels.SocialAccountListOrder, page *basemodels.Page) (*connectors.CollectionConnection[*basemodels.SocialAccount], error) {
 	return r.socAccounts.List(ctx, filter, order, page)
@@ -0,0 +1,48 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/connectors"
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// CreateAccountTeam is the resolver for the createAccountTeam field.
+func (r *mutationResolver) CreateAccountTeam(ctx context.Context, input models.AccountTeamInput) (*models.AccountTeamPayload, error) {
+	return r.teams.Create(ctx, &input)
+}
+
+// UpdateAccountTeam is the resolver for the updateAccountTeam field.
+func (r *mutationResolver) UpdateAccountTeam(ctx context.Context, id uint64, input models.AccountTeamInput) (*models.AccountTeamPayload, error) {
+	return r.teams.Update(ctx, id, &input)
+}
+
+// DeleteAccountTeam is the resolver for the deleteAccountTeam field.
+func (r *mutationResolver) DeleteAccountTeam(ctx context.Context, id uint64) (*models.AccountTeamPayload, error) {
+	return r.teams.Delete(ctx, id)
+}
+
+// AddAccountTeamMembers is the resolver for the addAccountTeamMembers field.
+func (r *mutationResolver) AddAccountTeamMembers(ctx context.Context, id uint64, memberIDs []uint64) (*models.AccountTeamPayload, error) {
+	return r.teams.AddMembers(ctx, id, memberIDs)
+}
+
+// RemoveAccountTeamMembers is the resolver for the removeAccountTeamMembers field.
+func (r *mutationResolver) RemoveAccountTeamMembers(ctx context.Context, id uint64, memberIDs []uint64) (*models.AccountTeamPayload, error) {
+	return r.teams.RemoveMembers(ctx, id, memberIDs)
+}
+
+// AccountTeam is the resolver for the accountTeam field.
+func (r *queryResolver) AccountTeam(ctx context.Context, id uint64) (*models.AccountTeamPayload, error) {
+	return r.teams.Team(ctx, id)
+}
+
+// ListAccountTeams is the resolver for the listAccountTeams field.
+func (r *queryResolver) ListAccountTeams(ctx context.Context, filter *models.AccountTeamListFilter, order []*models.AccountTeamListOrder, page *models.Page) (*connectors.CollectionConnection[*models.AccountTeam], error) {
+	return r.teams.List(ctx, filter, order, page)
+}
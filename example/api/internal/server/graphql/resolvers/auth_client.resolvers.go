@@ -27,6 +27,21 @@ func (r *mutationResolver) DeleteAuthClient(ctx context.Context, id string, msg
 	return r.authclients.DeleteAuthClient(ctx, id, msg)
 }
 
+// RotateAuthClientSecret is the resolver for the rotateAuthClientSecret field.
+func (r *mutationResolver) RotateAuthClientSecret(ctx context.Context, id string, gracePeriod *int) (*basemodels.AuthClientSecretPayload, error) {
+	return r.authclients.RotateAuthClientSecret(ctx, id, gracePeriod)
+}
+
+// CreateAuthInitialAccessToken is the resolver for the createAuthInitialAccessToken field.
+func (r *mutationResolver) CreateAuthInitialAccessToken(ctx context.Context, input basemodels.AuthInitialAccessTokenCreateInput) (*basemodels.AuthInitialAccessTokenPayload, error) {
+	return r.registrations.CreateAuthInitialAccessToken(ctx, &input)
+}
+
+// RevokeAuthInitialAccessToken is the resolver for the revokeAuthInitialAccessToken field.
+func (r *mutationResolver) RevokeAuthInitialAccessToken(ctx context.Context, id uint64) (*basemodels.StatusResponse, error) {
+	return r.registrations.RevokeAuthInitialAccessToken(ctx, id)
+}
+
 // AuthClient is the resolver for the authClient field.
 func (r *queryResolver) AuthClient(ctx context.Context, id string) (*basemodels.AuthClientPayload, error) {
 	return r.authclients.AuthClient(ctx, id)
@@ -36,3 +51,8 @@ func (r *queryResolver) AuthClient(ctx context.Context, id string) (*basemodels.
 func (r *queryResolver) ListAuthClients(ctx context.Context, filter *basemodels.AuthClientListFilter, order []*basemodels.AuthClientListOrder, page *basemodels.Page) (*connectors.CollectionConnection[*basemodels.AuthClient], error) {
 	return r.authclients.ListAuthClients(ctx, filter, order, page)
 }
+
+// ListAuthInitialAccessTokens is the resolver for the listAuthInitialAccessTokens field.
+func (r *queryResolver) ListAuthInitialAccessTokens(ctx context.Context) ([]*basemodels.AuthInitialAccessToken, error) {
+	return r.registrations.ListAuthInitialAccessTokens(ctx)
+}
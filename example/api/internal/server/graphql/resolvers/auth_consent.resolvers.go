@@ -0,0 +1,37 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// AcceptAuthConsentRequest is the resolver for the acceptAuthConsentRequest field.
+func (r *mutationResolver) AcceptAuthConsentRequest(ctx context.Context, challenge string, scope []string, rememberFor *int) (*models.AuthConsentPayload, error) {
+	return r.consents.AcceptAuthConsentRequest(ctx, challenge, scope, rememberFor)
+}
+
+// RejectAuthConsentRequest is the resolver for the rejectAuthConsentRequest field.
+func (r *mutationResolver) RejectAuthConsentRequest(ctx context.Context, challenge string) (*models.AuthConsentPayload, error) {
+	return r.consents.RejectAuthConsentRequest(ctx, challenge)
+}
+
+// RevokeAuthorizedApp is the resolver for the revokeAuthorizedApp field.
+func (r *mutationResolver) RevokeAuthorizedApp(ctx context.Context, clientID string) (*models.StatusResponse, error) {
+	return r.consents.RevokeAuthorizedApp(ctx, clientID)
+}
+
+// AuthConsentRequest is the resolver for the authConsentRequest field.
+func (r *queryResolver) AuthConsentRequest(ctx context.Context, challenge string) (*models.AuthConsentRequest, error) {
+	return r.consents.AuthConsentRequest(ctx, challenge)
+}
+
+// MyAuthorizedApps is the resolver for the myAuthorizedApps field.
+func (r *queryResolver) MyAuthorizedApps(ctx context.Context) ([]*models.AuthorizedApp, error) {
+	return r.consents.MyAuthorizedApps(ctx)
+}
@@ -0,0 +1,27 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// ApproveAuthDeviceRequest is the resolver for the approveAuthDeviceRequest field.
+func (r *mutationResolver) ApproveAuthDeviceRequest(ctx context.Context, userCode string) (*models.StatusResponse, error) {
+	return r.devices.ApproveAuthDeviceRequest(ctx, userCode)
+}
+
+// DenyAuthDeviceRequest is the resolver for the denyAuthDeviceRequest field.
+func (r *mutationResolver) DenyAuthDeviceRequest(ctx context.Context, userCode string) (*models.StatusResponse, error) {
+	return r.devices.DenyAuthDeviceRequest(ctx, userCode)
+}
+
+// AuthDeviceRequest is the resolver for the authDeviceRequest field.
+func (r *queryResolver) AuthDeviceRequest(ctx context.Context, userCode string) (*models.AuthDeviceRequest, error) {
+	return r.devices.AuthDeviceRequest(ctx, userCode)
+}
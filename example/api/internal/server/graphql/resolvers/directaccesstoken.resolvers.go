@@ -14,8 +14,8 @@ import (
 )
 
 // GenerateDirectAccessToken is the resolver for the generateDirectAccessToken field.
-func (r *mutationResolver) GenerateDirectAccessToken(ctx context.Context, userID *uint64, description string, expiresAt *time.Time) (*basemodels.DirectAccessTokenPayload, error) {
-	return r.directaccesstoken.Generate(ctx, userID, description, expiresAt)
+func (r *mutationResolver) GenerateDirectAccessToken(ctx context.Context, userID *uint64, description string, expiresAt *time.Time, scopes []string, allowedIPs []string) (*basemodels.DirectAccessTokenPayload, error) {
+	return r.directaccesstoken.Generate(ctx, userID, description, expiresAt, scopes, allowedIPs)
 }
 
 // RevokeDirectAccessToken is the resolver for the revokeDirectAccessToken field.
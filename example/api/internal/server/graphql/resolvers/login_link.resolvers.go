@@ -0,0 +1,26 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// RequestLoginLink is the resolver for the requestLoginLink field.
+func (r *mutationResolver) RequestLoginLink(ctx context.Context, email string) (*models.StatusResponse, error) {
+	return r.loginHandler.RequestLoginLink(ctx, email)
+}
+
+// LoginByLink is the resolver for the loginByLink field.
+func (r *mutationResolver) LoginByLink(ctx context.Context, token string, accountID *uint64) (*models.SessionToken, error) {
+	accIDs := []uint64{}
+	if accountID != nil {
+		accIDs = append(accIDs, *accountID)
+	}
+	return r.loginHandler.LoginByLink(ctx, token, accIDs...)
+}
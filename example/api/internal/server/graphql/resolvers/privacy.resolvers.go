@@ -0,0 +1,23 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+	"github.com/geniusrabbit/blaze-api/server/graphql/types"
+)
+
+// EraseUserData is the resolver for the eraseUserData field.
+func (r *mutationResolver) EraseUserData(ctx context.Context, userID uint64) (*models.StatusResponse, error) {
+	return r.privacy.Erase(ctx, userID)
+}
+
+// ExportUserData is the resolver for the exportUserData field.
+func (r *queryResolver) ExportUserData(ctx context.Context, userID *uint64) (*types.JSON, error) {
+	return r.privacy.Export(ctx, userID)
+}
@@ -0,0 +1,17 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.93
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// AccountQuotaUsage is the resolver for the accountQuotaUsage field.
+func (r *queryResolver) AccountQuotaUsage(ctx context.Context, accountID *uint64) ([]*models.QuotaUsage, error) {
+	return r.quotas.Usage(ctx, accountID)
+}
@@ -9,6 +9,8 @@ import (
 	historyloggraphql "github.com/geniusrabbit/blaze-api/repository/historylog/delivery/graphql"
 	"github.com/geniusrabbit/blaze-api/repository/option"
 	optiongraphql "github.com/geniusrabbit/blaze-api/repository/option/delivery/graphql"
+	privacygraphql "github.com/geniusrabbit/blaze-api/repository/privacy/delivery/graphql"
+	quotagraphql "github.com/geniusrabbit/blaze-api/repository/quota/delivery/graphql"
 	rbacgraphql "github.com/geniusrabbit/blaze-api/repository/rbac/delivery/graphql"
 	socialaccountgraphql "github.com/geniusrabbit/blaze-api/repository/socialaccount/delivery/graphql"
 )
@@ -20,17 +22,28 @@ type Resolver struct {
 	loginHandler      wiring.EmailPasswordLoginHandler
 	accounts          wiring.AccountQueryHandler
 	members           accountgraphql.MemberQueryHandler
+	invites           accountgraphql.MemberInviteQueryHandler
+	ownership         accountgraphql.OwnershipQueryHandler
+	teams             accountgraphql.TeamQueryHandler
+	domains           accountgraphql.DomainQueryHandler
+	impersonation     accountgraphql.ImpersonationQueryHandler
 	socAccounts       *socialaccountgraphql.QueryResolver
 	roles             *rbacgraphql.QueryResolver
 	authclients       *authclientgraphql.QueryResolver
+	consents          *authclientgraphql.ConsentQueryResolver
+	devices           *authclientgraphql.DeviceQueryResolver
+	registrations     *authclientgraphql.RegistrationQueryResolver
 	historylogs       *historyloggraphql.QueryResolver
 	options           *optiongraphql.QueryResolver
 	directaccesstoken *datokengraphql.QueryResolver
+	quotas            *quotagraphql.QueryResolver
+	privacy           *privacygraphql.QueryResolver
 }
 
 // NewResolver wires the example/api GraphQL handler from explicit resolver handles.
-// All custom logic (user, auth, accounts, members) must be provided by the caller.
-// Standard resolvers (rbac, authclient, historylog, option, DAT) are initialized internally.
+// All custom logic (user, auth, accounts, members, invites, ownership, teams, domains, impersonation) must be provided by the caller,
+// the consent and social account resolvers have the defaults if they aren't provided.
+// Standard resolvers (rbac, authclient, device, registration, historylog, option, DAT, quota, privacy) are initialized internally.
 func NewResolver(
 	provider *jwt.Provider,
 	options option.Usecase,
@@ -39,18 +52,41 @@ func NewResolver(
 	loginHandler wiring.EmailPasswordLoginHandler,
 	accountHandler wiring.AccountQueryHandler,
 	memberHandler accountgraphql.MemberQueryHandler,
+	inviteHandler accountgraphql.MemberInviteQueryHandler,
+	ownershipHandler accountgraphql.OwnershipQueryHandler,
+	teamHandler accountgraphql.TeamQueryHandler,
+	domainHandler accountgraphql.DomainQueryHandler,
+	impersonationHandler accountgraphql.ImpersonationQueryHandler,
+	consentHandler *authclientgraphql.ConsentQueryResolver,
+	socialHandler *socialaccountgraphql.QueryResolver,
 ) *Resolver {
+	if consentHandler == nil {
+		consentHandler = authclientgraphql.NewDefaultConsentQueryResolver(nil)
+	}
+	if socialHandler == nil {
+		socialHandler = socialaccountgraphql.NewDefaultQueryResolver(nil)
+	}
 	return &Resolver{
 		users:             userHandler,
 		accAuth:           authHandler,
 		loginHandler:      loginHandler,
 		accounts:          accountHandler,
 		members:           memberHandler,
-		socAccounts:       socialaccountgraphql.NewDefaultQueryResolver(),
+		invites:           inviteHandler,
+		ownership:         ownershipHandler,
+		teams:             teamHandler,
+		domains:           domainHandler,
+		impersonation:     impersonationHandler,
+		socAccounts:       socialHandler,
 		roles:             rbacgraphql.NewDefaultQueryResolver(),
 		authclients:       authclientgraphql.NewDefaultQueryResolver(),
+		consents:          consentHandler,
+		devices:           authclientgraphql.NewDefaultDeviceQueryResolver(),
+		registrations:     authclientgraphql.NewDefaultRegistrationQueryResolver(),
 		historylogs:       historyloggraphql.NewDefaultQueryResolver(),
 		options:           optiongraphql.NewQueryResolver(options),
 		directaccesstoken: datokengraphql.NewDefaultQueryResolver(),
+		quotas:            quotagraphql.NewQueryResolver(nil),
+		privacy:           privacygraphql.NewQueryResolver(nil),
 	}
 }
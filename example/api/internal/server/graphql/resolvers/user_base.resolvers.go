@@ -33,6 +33,16 @@ func (r *mutationResolver) RejectUser(ctx context.Context, id uint64, msg *strin
 	return r.users.RejectUser(ctx, id, msg)
 }
 
+// SuspendUser is the resolver for the suspendUser field.
+func (r *mutationResolver) SuspendUser(ctx context.Context, id uint64, reason string) (*models1.UserPayload, error) {
+	return r.users.SuspendUser(ctx, id, reason)
+}
+
+// UnsuspendUser is the resolver for the unsuspendUser field.
+func (r *mutationResolver) UnsuspendUser(ctx context.Context, id uint64) (*models1.UserPayload, error) {
+	return r.users.UnsuspendUser(ctx, id)
+}
+
 // CurrentUser is the resolver for the currentUser field.
 func (r *queryResolver) CurrentUser(ctx context.Context) (*models1.UserPayload, error) {
 	return r.users.CurrentUser(ctx)
@@ -16,3 +16,17 @@ import (
 func (r *mutationResolver) ChangeUserEmail(ctx context.Context, newEmail string) (*models.StatusResponse, error) {
 	panic(fmt.Errorf("not implemented: ChangeUserEmail - changeUserEmail"))
 }
+
+// RequestEmailVerification is the resolver for the requestEmailVerification field.
+func (r *mutationResolver) RequestEmailVerification(ctx context.Context, userID *uint64) (*models.StatusResponse, error) {
+	var id uint64
+	if userID != nil {
+		id = *userID
+	}
+	return r.users.RequestEmailVerification(ctx, id)
+}
+
+// VerifyEmail is the resolver for the verifyEmail field.
+func (r *mutationResolver) VerifyEmail(ctx context.Context, token string) (*models.StatusResponse, error) {
+	return r.users.VerifyEmail(ctx, token)
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
 	"github.com/geniusrabbit/blaze-api/repository/option"
 	"github.com/geniusrabbit/blaze-api/server/graphql/directives"
+	"github.com/geniusrabbit/blaze-api/server/graphql/extensions"
+	"github.com/geniusrabbit/blaze-api/server/graphql/gqlerrors"
 )
 
 // GraphQL mux handler
@@ -36,6 +38,13 @@ func GraphQL(provider *jwt.Provider, options option.Usecase, opts ...Option) htt
 				cfg.LoginHandler,
 				cfg.AccountHandler,
 				cfg.MemberHandler,
+				cfg.InviteHandler,
+				cfg.OwnerHandler,
+				cfg.TeamHandler,
+				cfg.DomainHandler,
+				cfg.ImpersonationHandler,
+				cfg.ConsentHandler,
+				cfg.SocialHandler,
 			),
 			Directives: generated.DirectiveRoot{
 				HasPermissions:    directives.HasPermissions[*appinit.UserType, *appinit.AccountType],
@@ -63,10 +72,12 @@ func GraphQL(provider *jwt.Provider, options option.Usecase, opts ...Option) htt
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
 
 	srv.Use(extension.Introspection{})
+	srv.Use(extensions.NewImpersonationGuard(cfg.ImpersonationBlocked...))
 	srv.Use(extension.AutomaticPersistedQuery{
 		Cache: lru.New[string](100),
 	})
 	srv.SetRecoverFunc(recoverHandler)
+	srv.SetErrorPresenter(gqlerrors.Presenter)
 
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		span, ctx := opentracing.StartSpanFromContext(r.Context(), "graphql.request")
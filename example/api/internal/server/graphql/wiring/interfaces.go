@@ -6,7 +6,10 @@ import (
 	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
 )
 
-// EmailPasswordLoginHandler handles the login(email, password, accountID) mutation.
+// EmailPasswordLoginHandler handles the login(email, password, accountID) mutation
+// and the login by the email link.
 type EmailPasswordLoginHandler interface {
 	Login(ctx context.Context, email, password string, accountID ...uint64) (*gqlmodels.SessionToken, error)
+	RequestLoginLink(ctx context.Context, email string) (*gqlmodels.StatusResponse, error)
+	LoginByLink(ctx context.Context, token string, accountID ...uint64) (*gqlmodels.SessionToken, error)
 }
@@ -6,6 +6,10 @@ import (
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	accountgraphql "github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql"
 	accountlogin "github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql/account_login"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	authclientgraphql "github.com/geniusrabbit/blaze-api/repository/authclient/delivery/graphql"
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
+	socialaccountgraphql "github.com/geniusrabbit/blaze-api/repository/socialaccount/delivery/graphql"
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
 
@@ -14,11 +18,19 @@ type AccountQueryHandler = accountgraphql.AccountQueryHandler[*exmodels.Account,
 // OptionsConfig carries all resolver overrides for the example/api GraphQL handler.
 // Instantiate with concrete user/account types at the entry point (e.g. main.go).
 type OptionsConfig struct {
-	UserHandler    UserQueryResolver
-	AuthHandler    accountgraphql.AuthQueryHandler
-	LoginHandler   accountgraphql.AccountLoginHandler
-	AccountHandler AccountQueryHandler
-	MemberHandler  accountgraphql.MemberQueryHandler
+	UserHandler          UserQueryResolver
+	AuthHandler          accountgraphql.AuthQueryHandler
+	LoginHandler         accountgraphql.AccountLoginHandler
+	AccountHandler       AccountQueryHandler
+	MemberHandler        accountgraphql.MemberQueryHandler
+	InviteHandler        accountgraphql.MemberInviteQueryHandler
+	OwnerHandler         accountgraphql.OwnershipQueryHandler
+	TeamHandler          accountgraphql.TeamQueryHandler
+	DomainHandler        accountgraphql.DomainQueryHandler
+	ImpersonationHandler accountgraphql.ImpersonationQueryHandler
+	ImpersonationBlocked []string // Mutations rejected in the impersonated session
+	ConsentHandler       *authclientgraphql.ConsentQueryResolver
+	SocialHandler        *socialaccountgraphql.QueryResolver
 }
 
 // Option is a functional option applied to OptionsConfig.
@@ -66,8 +78,61 @@ func WithUserLoginHandler[TUser user.Model, TAccount account.Model](
 	provider *jwt.Provider,
 	userLogin accountlogin.LoginPasswordAuth[TUser],
 	sessionRepo account.SessionRepository[TUser, TAccount],
+	opts ...accountlogin.Option[TUser, TAccount],
 ) Option {
 	return func(cfg *OptionsConfig) {
-		cfg.LoginHandler = accountlogin.New(provider, userLogin, sessionRepo)
+		cfg.LoginHandler = accountlogin.New(provider, userLogin, sessionRepo, opts...)
+	}
+}
+
+// WithConsentResolver sets the OAuth2 consent resolver,
+// the revoker kills the tokens of the apps revoked by the user
+func WithConsentResolver(revoker authclient.SessionRevoker) Option {
+	return func(cfg *OptionsConfig) {
+		cfg.ConsentHandler = authclientgraphql.NewDefaultConsentQueryResolver(revoker)
+	}
+}
+
+// WithSocialAccountResolver sets the social account resolver with the names of the login providers
+func WithSocialAccountResolver(accounts socialaccount.Usecase, providers ...string) Option {
+	return func(cfg *OptionsConfig) {
+		cfg.SocialHandler = socialaccountgraphql.NewQueryResolver(accounts, providers...)
+	}
+}
+
+// WithMemberInviteResolver sets the resolver of the member invitations by email
+func WithMemberInviteResolver(invites accountgraphql.MemberInviteQueryHandler) Option {
+	return func(cfg *OptionsConfig) {
+		cfg.InviteHandler = invites
+	}
+}
+
+// WithOwnershipResolver sets the resolver of the account ownership transfers
+func WithOwnershipResolver(ownership accountgraphql.OwnershipQueryHandler) Option {
+	return func(cfg *OptionsConfig) {
+		cfg.OwnerHandler = ownership
+	}
+}
+
+// WithTeamResolver sets the resolver of the account teams
+func WithTeamResolver(teams accountgraphql.TeamQueryHandler) Option {
+	return func(cfg *OptionsConfig) {
+		cfg.TeamHandler = teams
+	}
+}
+
+// WithDomainResolver sets the resolver of the account domains
+func WithDomainResolver(domains accountgraphql.DomainQueryHandler) Option {
+	return func(cfg *OptionsConfig) {
+		cfg.DomainHandler = domains
+	}
+}
+
+// WithImpersonationResolver sets the resolver of the user impersonation and the mutations
+// blocked in the impersonated session (the default list if empty)
+func WithImpersonationResolver(impersonation accountgraphql.ImpersonationQueryHandler, blocked ...string) Option {
+	return func(cfg *OptionsConfig) {
+		cfg.ImpersonationHandler = impersonation
+		cfg.ImpersonationBlocked = blocked
 	}
 }
@@ -80,15 +80,25 @@ type userQueryResolver struct {
 	userpassreset.PasswordResetQueryResolver[*domain.User]
 }
 
+// UserLinks contains templates of the links sent to the user email.
+type UserLinks struct {
+	// ResetPassword link template (see userpassreset.PasswordResetQueryResolverConfig)
+	ResetPassword string
+
+	// VerifyEmail link template (see useremail.QueryResolverEmailConfig)
+	VerifyEmail string
+}
+
 // NewExampleUserQueryResolver wires a full user resolver from a userstack Module.
-func NewExampleUserQueryResolver(module userstack.Module[*domain.User]) UserQueryResolver {
-	return NewUserQueryResolver(module.Core, module.Email, module.Password)
+func NewExampleUserQueryResolver(module userstack.Module[*domain.User], links UserLinks) UserQueryResolver {
+	return NewUserQueryResolver(module.Core, module.Email, module.Password, links)
 }
 
 func NewUserQueryResolver(
 	core user.Usecase[*domain.User],
 	emailUsecase user.EmailUsecase[*domain.User],
 	passwordUsecase user.PasswordUsecase[*domain.User],
+	links UserLinks,
 ) UserQueryResolver {
 	mapper := &domain.UserGraphQLMappersImpl{}
 	return &userQueryResolver{
@@ -109,10 +119,11 @@ func NewUserQueryResolver(
 			*exmodels.User,
 			*exmodels.UserPayload,
 		]{
-			Core:       core,
-			Email:      emailUsecase,
-			ToGraphQL:  mapper.ToGQL,
-			NewPayload: mapper.NewPayload,
+			Core:          core,
+			Email:         emailUsecase,
+			ToGraphQL:     mapper.ToGQL,
+			NewPayload:    mapper.NewPayload,
+			VerifyLinkURL: links.VerifyEmail,
 		}),
 		// QueryResolverUsername: *userusername.NewQueryResolverUsername(userusername.QueryResolverUsernameConfig[
 		// 	*domain.User,
@@ -134,11 +145,13 @@ func NewUserQueryResolver(
 			*exmodels.UserListOrder,
 		]{
 			Core:      core,
+			Password:  passwordUsecase,
 			ToGraphQL: mapper.ToGQL,
 		}),
 		PasswordResetQueryResolver: *userpassreset.NewPasswordResetQueryResolver(userpassreset.PasswordResetQueryResolverConfig[*domain.User]{
-			Email:    emailUsecase,
-			Password: passwordUsecase,
+			Email:        emailUsecase,
+			Password:     passwordUsecase,
+			ResetLinkURL: links.ResetPassword,
 		}),
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"github.com/99designs/basicauth-go"
@@ -42,6 +43,9 @@ type HTTPServer struct {
 	AuthLoader     *accAuth.Loader[*domain.User, *domain.Account]
 	Logger         *zap.Logger
 	GraphqlOptions graphql.Options
+
+	// TrustedProxies which can pass the client address by the headers
+	TrustedProxies []netip.Prefix
 }
 
 // Run starts a HTTP server and blocks while running if successful.
@@ -67,7 +71,7 @@ func (s *HTTPServer) Run(ctx context.Context, address string) (err error) {
 	h = accAuth.Middleware(h, s.AuthLoader, s.Authorizers...)
 	h = middleware.HTTPContextWrapper(h, s.ContextWrap)
 	h = middleware.HTTPSession(h, s.SessionManager)
-	h = middleware.RealIP(h)
+	h = middleware.TrustedRealIP(h, s.TrustedProxies...)
 	h = middleware.AllowCORS(h)
 	h = middleware.RequestID(h)
 	h = nethttp.Middleware(opentracing.GlobalTracer(), h)
@@ -10,22 +10,24 @@ import (
 type Repository[T user.AuthCapableModel] interface {
 	user.Repository[T]
 	user.EmailRepository[T]
+	user.EmailTokenRepository[T]
 	user.PasswordRepository[T]
+	user.PasswordHistoryRepository
 }
 
 type compositeRepository[T user.AuthCapableModel] struct {
 	user.Repository[T]
-	user.EmailRepository[T]
-	user.PasswordRepository[T]
+	userrepo.EmailRepositoryWithTokens[T]
+	userrepo.PasswordRepositoryWithHistory[T]
 }
 
 // NewRepository wires core, email, and password repositories for auth-capable user type.
 func NewRepository[T user.AuthCapableModel](newModel func() T) Repository[T] {
 	core := userrepo.NewRepository(newModel)
 	return &compositeRepository[T]{
-		Repository:         core,
-		EmailRepository:    userrepo.NewEmailRepository(core, newModel),
-		PasswordRepository: userrepo.NewPasswordRepository(core, newModel),
+		Repository:                    core,
+		EmailRepositoryWithTokens:     userrepo.NewEmailRepository(core, newModel),
+		PasswordRepositoryWithHistory: userrepo.NewPasswordRepository(core, newModel),
 	}
 }
 
@@ -38,15 +40,21 @@ type Module[T user.AuthCapableModel] struct {
 	Password user.PasswordUsecase[T]
 }
 
+// ModuleOptions configures usecases of the user stack.
+type ModuleOptions struct {
+	Password []useruc.PasswordOption
+	Email    []useruc.EmailOption
+}
+
 // NewModule wires the full auth-capable user stack (example/api).
-func NewModule[T user.AuthCapableModel](newModel func() T) Module[T] {
+func NewModule[T user.AuthCapableModel](newModel func() T, opts ModuleOptions) Module[T] {
 	repo := NewRepository(newModel)
 	core := useruc.NewUsecase(repo)
 	return Module[T]{
 		NewModel: newModel,
 		Repo:     repo,
 		Core:     core,
-		Email:    useruc.NewEmailUsecase(repo, newModel),
-		Password: useruc.NewPasswordUsecase(core, repo),
+		Email:    useruc.NewEmailUsecase(repo, newModel, opts.Email...),
+		Password: useruc.NewPasswordUsecase(core, repo, opts.Password...),
 	}
 }
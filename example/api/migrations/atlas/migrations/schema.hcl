@@ -60,9 +60,32 @@ table "account_base" {
     null = true
     type = sql("text[]")
   }
+  column "parent_id" {
+    null    = false
+    type    = bigint
+    default = 0
+  }
+  column "inherit_permissions" {
+    null    = false
+    type    = boolean
+    default = false
+  }
+  column "suspended_at" {
+    null = true
+    type = timestamptz
+  }
+  column "suspend_reason" {
+    null    = false
+    type    = text
+    default = ""
+  }
   primary_key {
     columns = [column.id]
   }
+  index "idx_account_base_parent_id" {
+    columns = [column.parent_id]
+    where   = "parent_id > 0"
+  }
 }
 
 table "account_user" {
@@ -93,6 +116,11 @@ table "account_user" {
     type    = text
     default = ""
   }
+  column "email_verified" {
+    null    = false
+    type    = boolean
+    default = false
+  }
   column "password" {
     null    = false
     type    = text
@@ -103,11 +131,107 @@ table "account_user" {
     type    = boolean
     default = false
   }
+  column "last_active_at" {
+    null = true
+    type = timestamptz
+  }
+  column "suspended_at" {
+    null = true
+    type = timestamptz
+  }
+  column "suspend_reason" {
+    null    = false
+    type    = text
+    default = ""
+  }
   primary_key {
     columns = [column.id]
   }
 }
 
+table "account_user_password_history" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "user_id" {
+    null = false
+    type = bigint
+  }
+  column "password" {
+    null = false
+    type = varchar(128)
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamp
+    default = sql("now()")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "fk_account_user_password_history_user" {
+    columns     = [column.user_id]
+    ref_columns = [table.account_user.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_account_user_password_history_user_id" {
+    columns = [column.user_id, column.created_at]
+  }
+}
+
+table "account_user_email_token" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "user_id" {
+    null = false
+    type = bigint
+  }
+  column "email" {
+    null = false
+    type = text
+  }
+  column "purpose" {
+    null = false
+    type = text
+  }
+  column "token" {
+    null = false
+    type = text
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "expires_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "fk_account_user_email_token_user" {
+    columns     = [column.user_id]
+    ref_columns = [table.account_user.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_account_user_email_token_token" {
+    unique  = true
+    columns = [column.token]
+  }
+  index "idx_account_user_email_token_user_id" {
+    columns = [column.user_id, column.purpose, column.created_at]
+  }
+}
+
 table "account_member" {
   schema = schema.public
 
@@ -131,6 +255,24 @@ table "account_member" {
     null = true
     type = boolean
   }
+  column "is_owner" {
+    null    = false
+    type    = boolean
+    default = false
+  }
+  column "last_active_at" {
+    null = true
+    type = timestamptz
+  }
+  column "suspended_at" {
+    null = true
+    type = timestamptz
+  }
+  column "suspend_reason" {
+    null    = false
+    type    = text
+    default = ""
+  }
   column "created_at" {
     null = true
     type = timestamptz
@@ -160,6 +302,159 @@ table "account_member" {
   }
 }
 
+table "account_member_activity" {
+  schema = schema.public
+
+  column "user_id" {
+    null = false
+    type = bigint
+  }
+  column "account_id" {
+    null    = false
+    type    = bigint
+    default = 0
+  }
+  column "authorizer" {
+    null = false
+    type = text
+  }
+  column "last_seen_at" {
+    null = false
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.user_id, column.account_id, column.authorizer]
+  }
+  foreign_key "fk_account_member_activity_user" {
+    columns     = [column.user_id]
+    ref_columns = [table.account_user.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+}
+
+table "account_member_invite" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "account_id" {
+    null = false
+    type = bigint
+  }
+  column "email" {
+    null = false
+    type = text
+  }
+  column "roles" {
+    null = false
+    type = sql("text[]")
+  }
+  column "token" {
+    null = false
+    type = text
+  }
+  column "status" {
+    null = false
+    type = text
+  }
+  column "invited_by" {
+    null = false
+    type = bigint
+  }
+  column "user_id" {
+    null = false
+    type = bigint
+  }
+  column "sent_count" {
+    null = false
+    type = integer
+  }
+  column "sent_at" {
+    null = true
+    type = timestamptz
+  }
+  column "expires_at" {
+    null = true
+    type = timestamptz
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "fk_account_member_invite_account" {
+    columns     = [column.account_id]
+    ref_columns = [table.account_base.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_account_member_invite_token" {
+    unique  = true
+    columns = [column.token]
+  }
+  index "idx_account_member_invite_account_id" {
+    columns = [column.account_id, column.status]
+  }
+}
+
+table "account_ownership_transfer" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "account_id" {
+    null = false
+    type = bigint
+  }
+  column "from_user_id" {
+    null = false
+    type = bigint
+  }
+  column "to_user_id" {
+    null = false
+    type = bigint
+  }
+  column "status" {
+    null = false
+    type = text
+  }
+  column "expires_at" {
+    null = true
+    type = timestamptz
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "fk_account_ownership_transfer_account" {
+    columns     = [column.account_id]
+    ref_columns = [table.account_base.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_account_ownership_transfer_account_id" {
+    columns = [column.account_id, column.status]
+  }
+}
+
 table "rbac_role" {
   schema = schema.public
 
@@ -191,6 +486,10 @@ table "rbac_role" {
     null = true
     type = bigint
   }
+  column "account_id" {
+    null = true
+    type = bigint
+  }
   column "created_at" {
     null = true
     type = timestamptz
@@ -206,8 +505,204 @@ table "rbac_role" {
   primary_key {
     columns = [column.id]
   }
+  foreign_key "fk_rbac_role_account" {
+    columns     = [column.account_id]
+    ref_columns = [table.account_base.column.id]
+    on_update   = NO_ACTION
+    on_delete   = RESTRICT
+  }
+  index "idx_rbac_role_account_id_name" {
+    unique = true
+    on {
+      expr = "COALESCE(account_id, 0)"
+    }
+    on {
+      column = column.name
+    }
+  }
+}
+
+table "account_team" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "account_id" {
+    null = false
+    type = bigint
+  }
+  column "name" {
+    null = false
+    type = text
+  }
+  column "description" {
+    null    = false
+    type    = text
+    default = ""
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  column "deleted_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "fk_account_team_account" {
+    columns     = [column.account_id]
+    ref_columns = [table.account_base.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_account_team_account_id_name" {
+    unique  = true
+    columns = [column.account_id, column.name]
+    where   = "deleted_at IS NULL"
+  }
+}
+table "m2m_account_team_member" {
+  schema = schema.public
+
+  column "team_id" {
+    null = false
+    type = bigint
+  }
+  column "member_id" {
+    null = false
+    type = bigint
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.team_id, column.member_id]
+  }
+  foreign_key "fk_m2m_account_team_member_team" {
+    columns     = [column.team_id]
+    ref_columns = [table.account_team.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  foreign_key "fk_m2m_account_team_member_account_member" {
+    columns     = [column.member_id]
+    ref_columns = [table.account_member.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_m2m_account_team_member_member_id" {
+    columns = [column.member_id]
+  }
 }
+table "m2m_account_team_role" {
+  schema = schema.public
 
+  column "team_id" {
+    null = false
+    type = bigint
+  }
+  column "role_id" {
+    null = false
+    type = bigint
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.team_id, column.role_id]
+  }
+  foreign_key "fk_m2m_account_team_role_team" {
+    columns     = [column.team_id]
+    ref_columns = [table.account_team.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  foreign_key "fk_m2m_account_team_role_role" {
+    columns     = [column.role_id]
+    ref_columns = [table.rbac_role.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+}
+table "account_domain" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "account_id" {
+    null = false
+    type = bigint
+  }
+  column "domain" {
+    null = false
+    type = text
+  }
+  column "token" {
+    null = false
+    type = text
+  }
+  column "verified_at" {
+    null = true
+    type = timestamptz
+  }
+  column "auto_join" {
+    null    = false
+    type    = boolean
+    default = false
+  }
+  column "default_roles" {
+    null = true
+    type = sql("text[]")
+  }
+  column "sso_required" {
+    null    = false
+    type    = boolean
+    default = false
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  column "deleted_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "fk_account_domain_account" {
+    columns     = [column.account_id]
+    ref_columns = [table.account_base.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_account_domain_account_id_domain" {
+    unique  = true
+    columns = [column.account_id, column.domain]
+    where   = "deleted_at IS NULL"
+  }
+  index "idx_account_domain_verified_domain" {
+    unique  = true
+    columns = [column.domain]
+    where   = "verified_at IS NOT NULL AND deleted_at IS NULL"
+  }
+}
 table "m2m_account_member_role" {
   schema = schema.public
 
@@ -20,17 +20,19 @@ func IsNoPermCheck(ctx context.Context) bool {
 
 // The permission list
 const (
-	PermView      = `view`
-	PermCreate    = `create`
-	PermUpdate    = `update`
-	PermDelete    = `delete`
-	PermList      = `list`
-	PermAuthCross = session.PermAuthCross
-	PermCount     = `count`
-	PermApprove   = `approve`
-	PermReject    = `reject`
-	PermGet       = `get`
-	PermSet       = `set`
+	PermView        = `view`
+	PermCreate      = `create`
+	PermUpdate      = `update`
+	PermDelete      = `delete`
+	PermList        = `list`
+	PermAuthCross   = session.PermAuthCross
+	PermCount       = `count`
+	PermApprove     = `approve`
+	PermReject      = `reject`
+	PermGet         = `get`
+	PermSet         = `set`
+	PermSuspend     = `suspend`
+	PermImpersonate = `impersonate`
 )
 
 // HavePermissions returns `true` if the `user` have all permissions from the list
@@ -83,6 +85,16 @@ func HaveAccessReject(ctx context.Context, obj any) bool {
 	return IsNoPermCheck(ctx) || session.Account(ctx).CheckPermissions(ctx, obj, PermReject+`.*`)
 }
 
+// HaveAccessSuspend of the object returns `true` if user can suspend and unsuspend the object
+func HaveAccessSuspend(ctx context.Context, obj any) bool {
+	return IsNoPermCheck(ctx) || session.Account(ctx).CheckPermissions(ctx, obj, PermSuspend+`.*`)
+}
+
+// HaveAccessImpersonate of the user returns `true` if the session user can act on behalf of it
+func HaveAccessImpersonate(ctx context.Context, obj any) bool {
+	return IsNoPermCheck(ctx) || session.Account(ctx).CheckPermissions(ctx, obj, PermImpersonate+`.*`)
+}
+
 // HaveAccountLink of the object to the current account
 func HaveAccountLink(ctx context.Context, obj any) bool {
 	if IsNoPermCheck(ctx) {
@@ -25,6 +25,10 @@ type userOwnerChecker interface {
 	IsOwnerUser(userID uint64) bool
 }
 
+type subAccountChecker interface {
+	IsSubAccount(id uint64) bool
+}
+
 // InitModelPermissions for particular models
 func InitModelPermissions(pm *permissions.Manager, models ...any) {
 	checkerFnk := commonPermissionCheck()
@@ -66,6 +70,11 @@ func commonPermissionCheck(custCheck ...checkFnk) checkFnk {
 			return true
 		}
 
+		// The account level permissions cascade to the sub-accounts inheriting them
+		if cover == `account` && checkSubAccount(resource, account) {
+			return true
+		}
+
 		// Check if resource belongs to the specific user and account.
 		ccu := checkCreatorUser(resource, user.GetID())
 		coa := checkOwnerAccount(resource, account.GetID())
@@ -104,6 +113,15 @@ func checkOwnerAccount(resource any, ownerID uint64) int {
 	return -1
 }
 
+func checkSubAccount(resource, account any) bool {
+	if _, ok := resource.(userOwnerChecker); ok {
+		return false
+	}
+	own, _ := resource.(owner)
+	sub, _ := account.(subAccountChecker)
+	return own != nil && sub != nil && sub.IsSubAccount(own.OwnerAccountID())
+}
+
 func checkCreatorUser(resource any, creatorID uint64) int {
 	crt, _ := resource.(creator)
 	if crt == nil || crt.CreatorUserID() == 0 {
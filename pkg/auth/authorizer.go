@@ -36,6 +36,13 @@ func NewAuthorizeWrapper[User IsNillable, Account IsNillable](authorizers ...Aut
 // Authorize runs each authorizer until one returns a non-nil user or account.
 // If any authorizer returns an error, authorization stops and the error is returned.
 func (a *AuthorizeWrapper[User, Account]) Authorize(w http.ResponseWriter, r *http.Request) (string, User, Account, error) {
+	_, token, user, account, err := a.AuthorizeWithCode(w, r)
+	return token, user, account, err
+}
+
+// AuthorizeWithCode works as Authorize and also returns the code of the authorizer
+// which accepted the request, the code is empty for the anonymous requests.
+func (a *AuthorizeWrapper[User, Account]) AuthorizeWithCode(w http.ResponseWriter, r *http.Request) (string, string, User, Account, error) {
 	var (
 		zeroUser    User
 		zeroAccount Account
@@ -44,12 +51,12 @@ func (a *AuthorizeWrapper[User, Account]) Authorize(w http.ResponseWriter, r *ht
 	for _, authorizer := range a.authorizers {
 		token, user, account, err := authorizer.Authorize(w, r)
 		if err != nil {
-			return token, zeroUser, zeroAccount, err
+			return authorizer.AuthorizerCode(), token, zeroUser, zeroAccount, err
 		}
 		if (!gocast.IsNil(user) && !user.IsNil()) || (!gocast.IsNil(account) && !account.IsNil()) {
-			return token, user, account, nil
+			return authorizer.AuthorizerCode(), token, user, account, nil
 		}
 	}
 
-	return "", zeroUser, zeroAccount, nil
+	return "", "", zeroUser, zeroAccount, nil
 }
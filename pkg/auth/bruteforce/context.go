@@ -0,0 +1,17 @@
+package bruteforce
+
+import "context"
+
+var ctxCaptchaKey = &struct{ s string }{"bruteforce:captcha"}
+
+// WithCaptchaPassed marks the request as verified by CAPTCHA.
+// Must be set by the CAPTCHA verification middleware of the application.
+func WithCaptchaPassed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxCaptchaKey, true)
+}
+
+// IsCaptchaPassed returns true if the request was verified by CAPTCHA
+func IsCaptchaPassed(ctx context.Context) bool {
+	v, _ := ctx.Value(ctxCaptchaKey).(bool)
+	return v
+}
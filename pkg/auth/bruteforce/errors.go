@@ -0,0 +1,47 @@
+package bruteforce
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrTooManyAttempts is the base error of the limiter
+var ErrTooManyAttempts = errors.New(`too many attempts`)
+
+// Error describes the reason of the rejected attempt
+type Error struct {
+	Action          string
+	RetryAfter      time.Duration
+	Locked          bool
+	CaptchaRequired bool
+}
+
+func (err *Error) Error() string {
+	switch {
+	case err.Locked:
+		return fmt.Sprintf("%s: temporary locked, retry after %s", ErrTooManyAttempts, err.RetryAfter.Round(time.Second))
+	case err.RetryAfter > 0:
+		return fmt.Sprintf("%s: retry after %s", ErrTooManyAttempts, err.RetryAfter.Round(time.Second))
+	case err.CaptchaRequired:
+		return ErrTooManyAttempts.Error() + ": captcha required"
+	}
+	return ErrTooManyAttempts.Error()
+}
+
+// Unwrap returns the base error
+func (err *Error) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// Extensions returns the error details for the API response
+func (err *Error) Extensions() map[string]any {
+	return map[string]any{
+		"code":             "TOO_MANY_ATTEMPTS",
+		"action":           err.Action,
+		"retry_after":      int64(math.Ceil(err.RetryAfter.Seconds())),
+		"locked":           err.Locked,
+		"captcha_required": err.CaptchaRequired,
+	}
+}
@@ -0,0 +1,41 @@
+package bruteforce
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Key of the attempts counter
+type Key struct {
+	Scope string
+	Value string
+}
+
+// EmailKey returns counter key of the login email
+func EmailKey(email string) Key {
+	return Key{Scope: "email", Value: strings.ToLower(strings.TrimSpace(email))}
+}
+
+// IPKey returns counter key of the client address
+func IPKey(ip string) Key {
+	return Key{Scope: "ip", Value: ip}
+}
+
+// AccountKey returns counter key of the target account
+func AccountKey(accountID uint64) Key {
+	return Key{Scope: "account", Value: strconv.FormatUint(accountID, 10)}
+}
+
+// UserKey returns counter key of the target user
+func UserKey(userID uint64) Key {
+	return Key{Scope: "user", Value: strconv.FormatUint(userID, 10)}
+}
+
+// IsEmpty returns true if key value is not defined
+func (k Key) IsEmpty() bool {
+	return k.Value == "" || k.Value == "0"
+}
+
+func (k Key) String() string {
+	return k.Scope + ":" + k.Value
+}
@@ -0,0 +1,246 @@
+// Package bruteforce implements the protection of the authentication endpoints
+// from the password guessing. Failed attempts are counted per key (email, IP, account, ...)
+// in the shared cache, every failure over the free limit delays the next attempt
+// exponentially and the long series of failures locks the key temporary.
+package bruteforce
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/cache"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+)
+
+// Config of the limiter
+type Config struct {
+	// FreeAttempts is the number of failures allowed without any delay
+	FreeAttempts int `json:"free_attempts" yaml:"free_attempts"`
+
+	// BaseDelay is the delay after the first failure over the free limit, doubles with every next failure
+	BaseDelay time.Duration `json:"base_delay" yaml:"base_delay"`
+
+	// MaxDelay is the maximal delay between attempts
+	MaxDelay time.Duration `json:"max_delay" yaml:"max_delay"`
+
+	// LockoutAfter is the number of failures which locks the key (0 - disabled)
+	LockoutAfter int `json:"lockout_after" yaml:"lockout_after"`
+
+	// LockoutDuration is the time of the temporary lockout
+	LockoutDuration time.Duration `json:"lockout_duration" yaml:"lockout_duration"`
+
+	// CaptchaAfter is the number of failures after which CAPTCHA is required (0 - disabled)
+	CaptchaAfter int `json:"captcha_after" yaml:"captcha_after"`
+
+	// Window is the time after the last failure when the counter is reset
+	Window time.Duration `json:"window" yaml:"window"`
+}
+
+// DefaultConfig of the limiter
+func DefaultConfig() Config {
+	return Config{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        5 * time.Minute,
+		LockoutAfter:    10,
+		LockoutDuration: 15 * time.Minute,
+		Window:          time.Hour,
+	}
+}
+
+// LockoutEvent describes the key which was locked
+type LockoutEvent struct {
+	Action      string
+	Key         Key
+	Failures    int
+	LockedUntil time.Time
+}
+
+// LockoutHandler is called every time when the key is locked
+type LockoutHandler func(ctx context.Context, event *LockoutEvent)
+
+// Option of the limiter
+type Option func(*Limiter)
+
+// WithLockoutHandler sets the lockout event handler
+func WithLockoutHandler(h LockoutHandler) Option {
+	return func(l *Limiter) { l.onLockout = h }
+}
+
+// WithTimeNow overrides the time source of the limiter
+func WithTimeNow(now func() time.Time) Option {
+	return func(l *Limiter) { l.now = now }
+}
+
+// maxDoublings prevents the delay overflow if MaxDelay is not defined
+const maxDoublings = 32
+
+type state struct {
+	Failures     int       `json:"f"`
+	LastFailure  time.Time `json:"l"`
+	BlockedUntil time.Time `json:"b,omitempty"`
+	LockedUntil  time.Time `json:"lk,omitempty"`
+}
+
+// Limiter of the failed attempts.
+// Nil limiter is valid and allows everything.
+type Limiter struct {
+	cache     cache.Client
+	conf      Config
+	onLockout LockoutHandler
+	now       func() time.Time
+}
+
+// New limiter based on the cache client
+func New(cacheCli cache.Client, conf Config, opts ...Option) *Limiter {
+	l := &Limiter{cache: cacheCli, conf: conf, now: time.Now}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Check returns *Error if any of the keys is not allowed to make the attempt now
+func (l *Limiter) Check(ctx context.Context, action string, keys ...Key) error {
+	if l == nil {
+		return nil
+	}
+	var (
+		now    = l.now()
+		resErr *Error
+	)
+	for _, key := range keys {
+		if key.IsEmpty() {
+			continue
+		}
+		resErr = mergeError(resErr, l.stateError(ctx, action, l.load(ctx, action, key, now), now))
+	}
+	if resErr != nil {
+		return resErr
+	}
+	return nil
+}
+
+// Fail registers the failed attempt for all keys and returns *Error
+// if the next attempt is not allowed immediately
+func (l *Limiter) Fail(ctx context.Context, action string, keys ...Key) error {
+	if l == nil {
+		return nil
+	}
+	var (
+		now    = l.now()
+		resErr *Error
+	)
+	for _, key := range keys {
+		if key.IsEmpty() {
+			continue
+		}
+		st := l.load(ctx, action, key, now)
+		st.Failures++
+		st.LastFailure = now
+		if over := st.Failures - l.conf.FreeAttempts; over > 0 {
+			st.BlockedUntil = now.Add(l.delay(over))
+		}
+		isLocked := false
+		if l.conf.LockoutAfter > 0 && st.Failures%l.conf.LockoutAfter == 0 {
+			st.LockedUntil = now.Add(l.conf.LockoutDuration)
+			isLocked = true
+		}
+		if err := l.cache.Set(ctx, l.cacheKey(action, key), st, l.lifetime()); err != nil {
+			ctxlogger.Get(ctx).Error("bruteforce: save state",
+				zap.String("action", action), zap.String("key", key.String()), zap.Error(err))
+		}
+		if isLocked && l.onLockout != nil {
+			l.onLockout(ctx, &LockoutEvent{
+				Action:      action,
+				Key:         key,
+				Failures:    st.Failures,
+				LockedUntil: st.LockedUntil,
+			})
+		}
+		resErr = mergeError(resErr, l.stateError(ctx, action, st, now))
+	}
+	if resErr != nil {
+		return resErr
+	}
+	return nil
+}
+
+// Reset the failures counter of the keys (after the successful attempt)
+func (l *Limiter) Reset(ctx context.Context, action string, keys ...Key) error {
+	if l == nil {
+		return nil
+	}
+	for _, key := range keys {
+		if key.IsEmpty() {
+			continue
+		}
+		if err := l.cache.Del(ctx, l.cacheKey(action, key)); err != nil && !errors.Is(err, cache.ErrEntryNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Limiter) load(ctx context.Context, action string, key Key, now time.Time) *state {
+	var st state
+	if err := l.cache.Get(ctx, l.cacheKey(action, key), &st); err != nil {
+		if !errors.Is(err, cache.ErrEntryNotFound) {
+			ctxlogger.Get(ctx).Error("bruteforce: load state",
+				zap.String("action", action), zap.String("key", key.String()), zap.Error(err))
+		}
+		return &state{}
+	}
+	// Reset the expired counter if there is no active lockout
+	if l.conf.Window > 0 && now.Sub(st.LastFailure) > l.conf.Window && !st.LockedUntil.After(now) {
+		return &state{}
+	}
+	return &st
+}
+
+func (l *Limiter) stateError(ctx context.Context, action string, st *state, now time.Time) *Error {
+	switch {
+	case st.LockedUntil.After(now):
+		return &Error{Action: action, Locked: true, RetryAfter: st.LockedUntil.Sub(now)}
+	case st.BlockedUntil.After(now):
+		return &Error{Action: action, RetryAfter: st.BlockedUntil.Sub(now)}
+	case l.conf.CaptchaAfter > 0 && st.Failures >= l.conf.CaptchaAfter && !IsCaptchaPassed(ctx):
+		return &Error{Action: action, CaptchaRequired: true}
+	}
+	return nil
+}
+
+func (l *Limiter) delay(over int) time.Duration {
+	delay := l.conf.BaseDelay
+	for i := 1; i < over && i < maxDoublings && (l.conf.MaxDelay <= 0 || delay < l.conf.MaxDelay); i++ {
+		delay *= 2
+	}
+	if l.conf.MaxDelay > 0 && delay > l.conf.MaxDelay {
+		delay = l.conf.MaxDelay
+	}
+	return delay
+}
+
+func (l *Limiter) lifetime() time.Duration {
+	return l.conf.Window + l.conf.LockoutDuration + l.conf.MaxDelay
+}
+
+func (l *Limiter) cacheKey(action string, key Key) string {
+	return "bf:" + action + ":" + key.String()
+}
+
+func mergeError(target, err *Error) *Error {
+	if err == nil {
+		return target
+	}
+	if target == nil {
+		return err
+	}
+	target.Locked = target.Locked || err.Locked
+	target.CaptchaRequired = target.CaptchaRequired || err.CaptchaRequired
+	target.RetryAfter = max(target.RetryAfter, err.RetryAfter)
+	return target
+}
@@ -0,0 +1,108 @@
+package bruteforce
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/geniusrabbit/blaze-api/pkg/cache/memory"
+)
+
+func newTestLimiter(t *testing.T, conf Config, opts ...Option) (*Limiter, *time.Time) {
+	cacheObj, err := memory.NewTimeout(context.Background(), time.Hour)
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts = append(opts, WithTimeNow(func() time.Time { return now }))
+	return New(cacheObj, conf, opts...), &now
+}
+
+func TestLimiterBackoff(t *testing.T) {
+	ctx := context.Background()
+	lim, now := newTestLimiter(t, Config{
+		FreeAttempts: 2,
+		BaseDelay:    time.Second,
+		MaxDelay:     4 * time.Second,
+		Window:       time.Hour,
+	})
+	key := EmailKey("User@Example.com")
+
+	assert.NoError(t, lim.Fail(ctx, "login", key))
+	assert.NoError(t, lim.Fail(ctx, "login", key))
+	assert.NoError(t, lim.Check(ctx, "login", key))
+
+	var bfErr *Error
+	err := lim.Fail(ctx, "login", key)
+	require.True(t, errors.As(err, &bfErr))
+	assert.Equal(t, time.Second, bfErr.RetryAfter)
+	assert.ErrorIs(t, lim.Check(ctx, "login", EmailKey("user@example.com")), ErrTooManyAttempts)
+
+	*now = now.Add(time.Second)
+	assert.NoError(t, lim.Check(ctx, "login", key))
+
+	_ = lim.Fail(ctx, "login", key)
+	require.True(t, errors.As(lim.Check(ctx, "login", key), &bfErr))
+	assert.Equal(t, 2*time.Second, bfErr.RetryAfter)
+
+	_ = lim.Fail(ctx, "login", key)
+	_ = lim.Fail(ctx, "login", key)
+	require.True(t, errors.As(lim.Check(ctx, "login", key), &bfErr))
+	assert.Equal(t, 4*time.Second, bfErr.RetryAfter, "delay must be limited by MaxDelay")
+
+	assert.NoError(t, lim.Check(ctx, "password.reset", key), "actions must be independent")
+
+	assert.NoError(t, lim.Reset(ctx, "login", key))
+	assert.NoError(t, lim.Check(ctx, "login", key))
+	assert.NoError(t, lim.Reset(ctx, "login", key))
+}
+
+func TestLimiterLockout(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		events []*LockoutEvent
+	)
+	lim, now := newTestLimiter(t, Config{
+		FreeAttempts:    100,
+		LockoutAfter:    3,
+		LockoutDuration: time.Minute,
+		CaptchaAfter:    2,
+		Window:          time.Hour,
+	}, WithLockoutHandler(func(_ context.Context, ev *LockoutEvent) {
+		events = append(events, ev)
+	}))
+	ip, acc := IPKey("10.0.0.1"), AccountKey(10)
+
+	_ = lim.Fail(ctx, "login", ip, acc)
+	var bfErr *Error
+	require.True(t, errors.As(lim.Fail(ctx, "login", ip, acc), &bfErr))
+	assert.True(t, bfErr.CaptchaRequired)
+	assert.False(t, bfErr.Locked)
+	assert.NoError(t, lim.Check(WithCaptchaPassed(ctx), "login", ip, acc))
+
+	require.True(t, errors.As(lim.Fail(ctx, "login", ip, acc), &bfErr))
+	assert.True(t, bfErr.Locked)
+	assert.Equal(t, time.Minute, bfErr.RetryAfter)
+	assert.Len(t, events, 2)
+	assert.Equal(t, ip, events[0].Key)
+	assert.Equal(t, acc, events[1].Key)
+	assert.Equal(t, true, bfErr.Extensions()["locked"])
+
+	*now = now.Add(time.Minute + time.Second)
+	require.True(t, errors.As(lim.Check(ctx, "login", ip), &bfErr))
+	assert.False(t, bfErr.Locked)
+	assert.True(t, bfErr.CaptchaRequired)
+
+	*now = now.Add(2 * time.Hour)
+	assert.NoError(t, lim.Check(ctx, "login", ip, acc), "counter must expire after the window")
+}
+
+func TestNilLimiter(t *testing.T) {
+	var lim *Limiter
+	ctx := context.Background()
+	assert.NoError(t, lim.Check(ctx, "login", IPKey("127.0.0.1")))
+	assert.NoError(t, lim.Fail(ctx, "login", IPKey("127.0.0.1")))
+	assert.NoError(t, lim.Reset(ctx, "login", IPKey("127.0.0.1")))
+}
@@ -15,11 +15,11 @@ type AuthAccessor interface {
 	// Protocol returns the protocol used by the provider (e.g., "oauth2", "saml").
 	Protocol() string
 
-	// LoginURL generates the login URL with the provided URL parameters.
-	LoginURL(urlParams []URLParam) string
+	// LoginURL generates the login URL of the login attempt state with the provided URL parameters.
+	LoginURL(state *LoginState, urlParams []URLParam) string
 
 	// UserData exchanges authentication values for user information and token.
-	// It takes a context, URL values from the callback, and URL parameters,
-	// returning the token, user data, or an error.
-	UserData(ctx context.Context, values url.Values, urlParams []URLParam) (*Token, *UserData, error)
+	// It takes a context, URL values from the callback, the verified state of the login attempt
+	// and URL parameters, returning the token, user data, or an error.
+	UserData(ctx context.Context, values url.Values, state *LoginState, urlParams []URLParam) (*Token, *UserData, error)
 }
@@ -25,21 +25,33 @@ type RedirectParamsExtractor interface {
 	RedirectParams(w http.ResponseWriter, r *http.Request, login bool) []URLParam
 }
 
+// LoginStateHandler defines the interface for creating the state of the login attempt
+// and verifying it on the callback before the code exchange
+type LoginStateHandler interface {
+	NewLoginState(w http.ResponseWriter, r *http.Request, params []URLParam) (*LoginState, error)
+	VerifyLoginState(w http.ResponseWriter, r *http.Request) (*LoginState, error)
+}
+
 // AuthHTTPWrapper provides HTTP handler wrapping for authentication flows
 type AuthHTTPWrapper struct {
 	Auth           AuthAccessor
 	Error          ErrorHandler
 	Success        SuccessHandler
 	RedirectParams RedirectParamsExtractor
+	States         LoginStateHandler
 }
 
 // NewWrapper creates a new instance of AuthHTTPWrapper
-func NewWrapper(auth AuthAccessor, err ErrorHandler, success SuccessHandler, redirectParams RedirectParamsExtractor) *AuthHTTPWrapper {
+func NewWrapper(auth AuthAccessor, err ErrorHandler, success SuccessHandler, redirectParams RedirectParamsExtractor, states LoginStateHandler) *AuthHTTPWrapper {
+	if states == nil {
+		panic("elogin: login state handler is required")
+	}
 	return &AuthHTTPWrapper{
 		Auth:           auth,
 		Error:          err,
 		Success:        success,
 		RedirectParams: redirectParams,
+		States:         states,
 	}
 }
 
@@ -66,7 +78,13 @@ func (wr *AuthHTTPWrapper) HandleWrapper(prefix string) http.Handler {
 
 // Login handles the login request and redirects to the provider
 func (wr *AuthHTTPWrapper) Login(w http.ResponseWriter, r *http.Request) {
-	http.Redirect(w, r, wr.Auth.LoginURL(wr.redirectParams(w, r, true)), http.StatusTemporaryRedirect)
+	params := wr.redirectParams(w, r, true)
+	state, err := wr.States.NewLoginState(w, r, params)
+	if err != nil {
+		wr.Error.Error(w, r, err)
+		return
+	}
+	http.Redirect(w, r, wr.Auth.LoginURL(state, params), http.StatusTemporaryRedirect)
 }
 
 // Callback handles the provider callback and authenticates the user
@@ -76,7 +94,14 @@ func (wr *AuthHTTPWrapper) Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, data, err := wr.Auth.UserData(r.Context(), r.Form, wr.redirectParams(w, r, false))
+	// The state is verified before the code exchange to reject the forged callbacks
+	state, err := wr.States.VerifyLoginState(w, r)
+	if err != nil {
+		wr.Error.Error(w, r, err)
+		return
+	}
+
+	token, data, err := wr.Auth.UserData(r.Context(), r.Form, state, wr.redirectParams(w, r, false))
 	if err != nil {
 		wr.Error.Error(w, r, err)
 		return
@@ -3,5 +3,7 @@ package elogin
 import "errors"
 
 var (
-	ErrInvalidState = errors.New("invalid state")
+	ErrInvalidState        = errors.New("invalid state")
+	ErrStateExpired        = errors.New("state expired")
+	ErrSessionNotAvailable = errors.New("browser session is not available")
 )
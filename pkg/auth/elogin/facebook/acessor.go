@@ -84,6 +84,5 @@ func NewFacebookConfig(conf *oauth2.Config) *oa2.Config {
 		ProviderName: "facebook",
 		OAuth2:       conf,
 		Extractor:    FacebookUserData,
-		StateCode:    "fcodec2024",
 	}
 }
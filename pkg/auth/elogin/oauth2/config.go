@@ -9,7 +9,6 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
-	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/utils"
 )
 
 // DataExtractor extracts user data from an OAuth2 token.
@@ -20,7 +19,7 @@ type Config struct {
 	ProviderName string         // Name of the OAuth2 provider
 	OAuth2       *oauth2.Config // OAuth2 configuration
 	Extractor    DataExtractor  // Function to extract user data from token
-	StateCode    string         // State code for CSRF protection
+	PKCE         bool           // Send the S256 code challenge if the provider supports PKCE
 }
 
 // Protocol returns the authentication protocol name.
@@ -33,26 +32,26 @@ func (c *Config) Provider() string {
 	return c.ProviderName
 }
 
-// LoginURL generates the OAuth2 login URL with the provided parameters.
-func (c *Config) LoginURL(params []elogin.URLParam) string {
-	state := utils.NewState(utils.Param{Key: "sc", Value: c.StateCode})
-
+// LoginURL generates the OAuth2 login URL of the login attempt with the provided parameters.
+func (c *Config) LoginURL(state *elogin.LoginState, params []elogin.URLParam) string {
 	// OAuth2 auth type options:
 	// - rerequest: for declined/revoked permissions
 	// - reauthorize: always requires permissions
 	// - reauthenticate: always confirms password
-	opts := make([]oauth2.AuthCodeOption, 0, 2)
+	opts := make([]oauth2.AuthCodeOption, 0, 3)
 	opts = append(opts, oauth2.SetAuthURLParam("auth_type", "rerequest"))
+	if c.PKCE {
+		opts = append(opts, oauth2.S256ChallengeOption(state.CodeVerifier))
+	}
 
 	// Process additional parameters
 	for _, param := range params {
 		if param.Key == "scope" {
 			opts = append(opts, oauth2.SetAuthURLParam("scope", param.Value))
 		}
-		state = state.Extend(param.Key, param.Value)
 	}
 
-	return c.OAuth2.AuthCodeURL(state.Encode(), opts...)
+	return c.OAuth2.AuthCodeURL(state.State, opts...)
 }
 
 // OAuth2Config returns the underlying OAuth2 configuration.
@@ -61,13 +60,12 @@ func (c *Config) OAuth2Config() *oauth2.Config {
 }
 
 // UserData exchanges the authorization code for a token and extracts user data.
-func (c *Config) UserData(ctx context.Context, values url.Values, params []elogin.URLParam) (*elogin.Token, *elogin.UserData, error) {
+func (c *Config) UserData(ctx context.Context, values url.Values, state *elogin.LoginState, params []elogin.URLParam) (*elogin.Token, *elogin.UserData, error) {
 	code := values.Get("code")
-	state := utils.DecodeState(values.Get("state"))
 	scopes := c.OAuth2.Scopes
 
-	// Validate state code if configured
-	if c.StateCode != "" && c.StateCode != state.Get("sc") {
+	// The state is verified by the login state handler before the exchange
+	if state == nil {
 		return nil, nil, elogin.ErrInvalidState
 	}
 
@@ -83,7 +81,11 @@ func (c *Config) UserData(ctx context.Context, values url.Values, params []elogi
 	}
 
 	// Exchange authorization code for access token
-	oa2token, err := c.OAuth2.Exchange(ctx, code)
+	var exchangeOpts []oauth2.AuthCodeOption
+	if c.PKCE {
+		exchangeOpts = append(exchangeOpts, oauth2.VerifierOption(state.CodeVerifier))
+	}
+	oa2token, err := c.OAuth2.Exchange(ctx, code, exchangeOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,116 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// keysRefreshInterval limits the reloading of the issuer keys
+	// when the ID token is signed by the unknown key
+	keysRefreshInterval = time.Minute
+
+	// maxDocumentSize limits the documents loaded from the issuer
+	maxDocumentSize = 1 << 20
+)
+
+// Discovery is the part of the OpenID Provider metadata used by the login
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type Discovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserInfoEndpoint      string   `json:"userinfo_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	ScopesSupported       []string `json:"scopes_supported"`
+	IDTokenSigningAlgs    []string `json:"id_token_signing_alg_values_supported"`
+	CodeChallengeMethods  []string `json:"code_challenge_methods_supported"`
+}
+
+// SupportsPKCE returns true if the issuer accepts the S256 code challenge
+func (d *Discovery) SupportsPKCE() bool {
+	return slices.Contains(d.CodeChallengeMethods, "S256")
+}
+
+// Discover loads the metadata of the issuer, the issuer of the metadata
+// has to be the same as requested
+func Discover(ctx context.Context, client *http.Client, issuer string) (*Discovery, error) {
+	var disc Discovery
+	if err := getJSON(ctx, client, strings.TrimSuffix(issuer, "/")+discoveryPath, "", &disc); err != nil {
+		return nil, errors.Wrap(err, "discovery")
+	}
+	if disc.Issuer != issuer {
+		return nil, errors.Errorf("discovery: issuer %q doesn't match %q", disc.Issuer, issuer)
+	}
+	if disc.AuthorizationEndpoint == "" || disc.TokenEndpoint == "" || disc.JWKSURI == "" {
+		return nil, errors.New("discovery: authorization_endpoint, token_endpoint and jwks_uri are required")
+	}
+	return &disc, nil
+}
+
+// keySet caches the signing keys of the issuer
+type keySet struct {
+	mx        sync.Mutex
+	client    *http.Client
+	uri       string
+	keys      []jose.JSONWebKey
+	updatedAt time.Time
+}
+
+// lookup returns the keys which can verify the signature with the key ID,
+// the keys are reloaded if the ID is unknown
+func (s *keySet) lookup(ctx context.Context, keyID string) ([]jose.JSONWebKey, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if keys := s.find(keyID); len(keys) > 0 || time.Since(s.updatedAt) < keysRefreshInterval {
+		return keys, nil
+	}
+	var set jose.JSONWebKeySet
+	if err := getJSON(ctx, s.client, s.uri, "", &set); err != nil {
+		return nil, errors.Wrap(err, "load issuer keys")
+	}
+	s.keys, s.updatedAt = set.Keys, time.Now()
+	return s.find(keyID), nil
+}
+
+func (s *keySet) find(keyID string) []jose.JSONWebKey {
+	var keys []jose.JSONWebKey
+	for _, key := range s.keys {
+		if (keyID == "" || key.KeyID == keyID) && (key.Use == "" || key.Use == "sig") {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// getJSON loads the JSON document, the bearer token is used if it's provided
+func getJSON(ctx context.Context, client *http.Client, url, bearer string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxDocumentSize)).Decode(target)
+}
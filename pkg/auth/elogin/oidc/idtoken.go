@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/pkg/errors"
+)
+
+// clockLeeway is the allowed time difference with the issuer
+const clockLeeway = time.Minute
+
+// asymmetricAlgs are the ID token signatures verified by the issuer keys,
+// the HMAC and `none` signatures are rejected
+var asymmetricAlgs = []string{
+	string(jose.RS256), string(jose.RS384), string(jose.RS512),
+	string(jose.PS256), string(jose.PS384), string(jose.PS512),
+	string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	string(jose.EdDSA),
+}
+
+// userClaims are the standard claims of the ID token and userinfo
+// https://openid.net/specs/openid-connect-core-1_0.html#StandardClaims
+type userClaims struct {
+	Subject           string `json:"sub"`
+	Name              string `json:"name"`
+	GivenName         string `json:"given_name"`
+	FamilyName        string `json:"family_name"`
+	Nickname          string `json:"nickname"`
+	PreferredUsername string `json:"preferred_username"`
+	Profile           string `json:"profile"`
+	Picture           string `json:"picture"`
+	Website           string `json:"website"`
+	Email             string `json:"email"`
+	// EmailVerified is the boolean, but some issuers send it as the string
+	EmailVerified any    `json:"email_verified"`
+	Locale        string `json:"locale"`
+}
+
+// IsEmailVerified returns true if the issuer verified the email
+func (c *userClaims) IsEmailVerified() bool {
+	return c.Email != "" && gocast.Bool(c.EmailVerified)
+}
+
+// merge fills the empty claims by the values of the other claims
+func (c *userClaims) merge(other *userClaims) {
+	c.Name = gocast.Or(c.Name, other.Name)
+	c.GivenName = gocast.Or(c.GivenName, other.GivenName)
+	c.FamilyName = gocast.Or(c.FamilyName, other.FamilyName)
+	c.Nickname = gocast.Or(c.Nickname, other.Nickname)
+	c.PreferredUsername = gocast.Or(c.PreferredUsername, other.PreferredUsername)
+	c.Profile = gocast.Or(c.Profile, other.Profile)
+	c.Picture = gocast.Or(c.Picture, other.Picture)
+	c.Website = gocast.Or(c.Website, other.Website)
+	c.Locale = gocast.Or(c.Locale, other.Locale)
+	if c.Email == "" {
+		c.Email, c.EmailVerified = other.Email, other.EmailVerified
+	}
+}
+
+// idTokenClaims are the claims of the ID token besides the registered ones
+type idTokenClaims struct {
+	userClaims
+	Nonce           string `json:"nonce"`
+	AuthorizedParty string `json:"azp"`
+}
+
+// verifyIDToken checks the signature of the ID token by the issuer keys
+// and validates the issuer, audience and lifetime
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken string) (*idTokenClaims, error) {
+	token, err := jwt.ParseSigned(rawIDToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse ID token")
+	}
+	if len(token.Headers) != 1 {
+		return nil, errors.New("ID token must have the single signature")
+	}
+	header := token.Headers[0]
+	if !slices.Contains(p.signingAlgs(), header.Algorithm) {
+		return nil, errors.Errorf("ID token signing algorithm %q is not supported", header.Algorithm)
+	}
+	keys, err := p.keys.lookup(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		registered jwt.Claims
+		claims     idTokenClaims
+		verified   bool
+	)
+	for _, key := range keys {
+		if err := token.Claims(key.Key, &registered, &claims); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("ID token signature is invalid")
+	}
+
+	err = registered.ValidateWithLeeway(jwt.Expected{
+		Issuer:   p.discovery.Issuer,
+		Audience: jwt.Audience{p.conf.ClientID},
+		Time:     time.Now(),
+	}, clockLeeway)
+	switch {
+	case err != nil:
+		return nil, errors.Wrap(err, "ID token")
+	case registered.Expiry == nil:
+		return nil, errors.New("ID token: exp claim is required")
+	case registered.Subject == "":
+		return nil, errors.New("ID token: sub claim is required")
+	case len(registered.Audience) > 1 && claims.AuthorizedParty != p.conf.ClientID:
+		return nil, errors.New("ID token: azp claim doesn't match the client")
+	}
+	return &claims, nil
+}
+
+// signingAlgs returns the asymmetric algorithms supported by the issuer
+func (p *Provider) signingAlgs() []string {
+	if len(p.discovery.IDTokenSigningAlgs) == 0 {
+		return []string{string(jose.RS256)}
+	}
+	var algs []string
+	for _, alg := range p.discovery.IDTokenSigningAlgs {
+		if slices.Contains(asymmetricAlgs, alg) {
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
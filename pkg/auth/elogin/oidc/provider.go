@@ -0,0 +1,209 @@
+// Package oidc implements the generic OpenID Connect login provider
+// configured by the discovery document of the issuer (Google, GitLab, Keycloak, Azure AD, etc.)
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/demdxx/gocast/v2"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
+)
+
+// ScopeOpenID is always requested by the provider
+const ScopeOpenID = "openid"
+
+var (
+	ErrIDTokenMissing = errors.New("ID token is missing in the token response")
+	ErrInvalidNonce   = errors.New("ID token nonce doesn't match the login request")
+)
+
+// Config of the OpenID Connect provider
+type Config struct {
+	ProviderName string       // Name of the provider (e.g. "google", "keycloak")
+	Issuer       string       // Issuer URL, the discovery document is loaded from it
+	ClientID     string       // Client ID registered by the issuer
+	ClientSecret string       // Client secret registered by the issuer
+	RedirectURL  string       // Callback URL of the login
+	Scopes       []string     // Requested scopes, defaults to openid, profile and email
+	HTTPClient   *http.Client // Client of the issuer requests, defaults to http.DefaultClient
+}
+
+// Provider of the login by the OpenID Connect issuer.
+// It validates the ID token and maps the standard claims into the user data.
+type Provider struct {
+	conf      Config
+	discovery *Discovery
+	oauth2    *oauth2.Config
+	keys      *keySet
+}
+
+// New creates the provider and loads the discovery document of the issuer
+func New(ctx context.Context, conf Config) (*Provider, error) {
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = http.DefaultClient
+	}
+	if conf.ProviderName == "" || conf.ClientID == "" {
+		return nil, errors.New("oidc: provider name and client ID are required")
+	}
+	disc, err := Discover(ctx, conf.HTTPClient, conf.Issuer)
+	if err != nil {
+		return nil, errors.Wrap(err, conf.ProviderName)
+	}
+	scopes := conf.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{ScopeOpenID, "profile", "email"}
+	} else if !slices.Contains(scopes, ScopeOpenID) {
+		scopes = append([]string{ScopeOpenID}, scopes...)
+	}
+	return &Provider{
+		conf:      conf,
+		discovery: disc,
+		oauth2: &oauth2.Config{
+			ClientID:     conf.ClientID,
+			ClientSecret: conf.ClientSecret,
+			RedirectURL:  conf.RedirectURL,
+			Scopes:       scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  disc.AuthorizationEndpoint,
+				TokenURL: disc.TokenEndpoint,
+			},
+		},
+		keys: &keySet{client: conf.HTTPClient, uri: disc.JWKSURI},
+	}, nil
+}
+
+// Protocol returns the authentication protocol name
+func (p *Provider) Protocol() string {
+	return "oidc"
+}
+
+// Provider returns the provider name
+func (p *Provider) Provider() string {
+	return p.conf.ProviderName
+}
+
+// Discovery returns the metadata of the issuer
+func (p *Provider) Discovery() *Discovery {
+	return p.discovery
+}
+
+// OAuth2Config returns the underlying OAuth2 configuration
+func (p *Provider) OAuth2Config() *oauth2.Config {
+	return p.oauth2
+}
+
+// LoginURL generates the authorization URL of the login attempt, the nonce of the state
+// is compared with the ID token and the PKCE challenge is sent if the issuer supports it
+func (p *Provider) LoginURL(state *elogin.LoginState, params []elogin.URLParam) string {
+	opts := []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("nonce", state.Nonce)}
+	if p.discovery.SupportsPKCE() {
+		opts = append(opts, oauth2.S256ChallengeOption(state.CodeVerifier))
+	}
+	for _, param := range params {
+		if param.Key == "scope" {
+			opts = append(opts, oauth2.SetAuthURLParam("scope", strings.Join(p.scopes(params), " ")))
+		}
+	}
+	return p.oauth2.AuthCodeURL(state.State, opts...)
+}
+
+// UserData exchanges the authorization code, validates the ID token and returns
+// the user data of the standard claims completed by the userinfo endpoint
+func (p *Provider) UserData(ctx context.Context, values url.Values, state *elogin.LoginState, params []elogin.URLParam) (*elogin.Token, *elogin.UserData, error) {
+	if errCode := values.Get("error"); errCode != "" {
+		return nil, nil, errors.Errorf("%s: %s", errCode, values.Get("error_description"))
+	}
+	if state == nil || state.Nonce == "" {
+		return nil, nil, elogin.ErrInvalidState
+	}
+
+	var exchangeOpts []oauth2.AuthCodeOption
+	if p.discovery.SupportsPKCE() {
+		exchangeOpts = append(exchangeOpts, oauth2.VerifierOption(state.CodeVerifier))
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.conf.HTTPClient)
+	oa2token, err := p.oauth2.Exchange(ctx, values.Get("code"), exchangeOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	rawIDToken, _ := oa2token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, nil, ErrIDTokenMissing
+	}
+	claims, err := p.verifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(state.Nonce)) != 1 {
+		return nil, nil, ErrInvalidNonce
+	}
+
+	if p.discovery.UserInfoEndpoint != "" {
+		var info userClaims
+		if err := getJSON(ctx, p.conf.HTTPClient, p.discovery.UserInfoEndpoint, oa2token.AccessToken, &info); err != nil {
+			return nil, nil, errors.Wrap(err, "userinfo")
+		}
+		if info.Subject != claims.Subject {
+			return nil, nil, errors.New("userinfo: subject doesn't match the ID token")
+		}
+		claims.userClaims.merge(&info)
+	}
+
+	scopes := p.scopes(params)
+	token := &elogin.Token{
+		TokenType:    oa2token.TokenType,
+		AccessToken:  oa2token.AccessToken,
+		RefreshToken: oa2token.RefreshToken,
+		ExpiresAt:    oa2token.Expiry,
+		Scopes:       scopes,
+	}
+	return token, p.userData(&claims.userClaims, scopes), nil
+}
+
+// userData maps the standard claims into the user data
+func (p *Provider) userData(claims *userClaims, scopes []string) *elogin.UserData {
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" && claims.Name != "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+	return &elogin.UserData{
+		ID:         claims.Subject,
+		Email:      claims.Email,
+		FirstName:  firstName,
+		LastName:   lastName,
+		Username:   gocast.Or(claims.PreferredUsername, claims.Nickname),
+		AvatarURL:  claims.Picture,
+		Link:       gocast.Or(claims.Profile, claims.Website),
+		OAuth2conf: p.oauth2,
+		Ext: map[string]any{
+			"scope":          scopes,
+			"issuer":         p.discovery.Issuer,
+			"email_verified": claims.IsEmailVerified(),
+			"locale":         claims.Locale,
+		},
+	}
+}
+
+// scopes returns the scopes requested by the parameters or the configured ones,
+// the openid scope is always included
+func (p *Provider) scopes(params []elogin.URLParam) []string {
+	for _, param := range params {
+		if param.Key != "scope" {
+			continue
+		}
+		scopes := strings.Fields(strings.ReplaceAll(param.Value, ",", " "))
+		if !slices.Contains(scopes, ScopeOpenID) {
+			scopes = append([]string{ScopeOpenID}, scopes...)
+		}
+		return scopes
+	}
+	return p.oauth2.Scopes
+}
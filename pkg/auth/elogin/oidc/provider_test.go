@@ -0,0 +1,243 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
+)
+
+// testIssuer is the local OpenID Connect issuer which signs the ID token
+// with the claims prepared by the test
+type testIssuer struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	claims    map[string]any
+	userInfo  map[string]any
+	challenge string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	iss := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                iss.URL,
+			"authorization_endpoint":                iss.URL + "/auth",
+			"token_endpoint":                        iss.URL + "/token",
+			"userinfo_endpoint":                     iss.URL + "/userinfo",
+			"jwks_uri":                              iss.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256", "HS256"},
+			"code_challenge_methods_supported":      []string{"S256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if oauth2.S256ChallengeFromVerifier(r.PostFormValue("code_verifier")) != iss.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     iss.sign(t, iss.key, iss.claims),
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(iss.userInfo)
+	})
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func (iss *testIssuer) sign(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithHeader("kid", "key"))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestProviderUserData(t *testing.T) {
+	iss := newTestIssuer(t)
+	provider, err := New(context.Background(), Config{
+		ProviderName: "keycloak",
+		Issuer:       iss.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://app.example.com/auth/keycloak/callback",
+	})
+	require.NoError(t, err)
+
+	// login returns the state of the login attempt and the nonce expected in the ID token
+	login := func() (*elogin.LoginState, string) {
+		params := []elogin.URLParam{{Key: "r", Value: "/home"}}
+		state, err := elogin.NewLoginState(params, time.Minute)
+		require.NoError(t, err)
+		loginURL, err := url.Parse(provider.LoginURL(state, params))
+		require.NoError(t, err)
+		assert.Equal(t, iss.URL+"/auth", loginURL.Scheme+"://"+loginURL.Host+loginURL.Path)
+		assert.Equal(t, "openid profile email", loginURL.Query().Get("scope"))
+		assert.Equal(t, state.State, loginURL.Query().Get("state"))
+		assert.Equal(t, "S256", loginURL.Query().Get("code_challenge_method"))
+		iss.challenge = loginURL.Query().Get("code_challenge")
+		return state, loginURL.Query().Get("nonce")
+	}
+	idClaims := func(nonce string) map[string]any {
+		return map[string]any{
+			"iss":         iss.URL,
+			"sub":         "user-1",
+			"aud":         "client",
+			"exp":         time.Now().Add(time.Hour).Unix(),
+			"iat":         time.Now().Unix(),
+			"nonce":       nonce,
+			"given_name":  "Jane",
+			"family_name": "Doe",
+			"email":       "jane@example.com",
+			// Azure AD sends the string value
+			"email_verified": "true",
+		}
+	}
+	callback := func(state *elogin.LoginState) (*elogin.UserData, error) {
+		values := url.Values{"code": {"code"}}
+		if state != nil {
+			values.Set("state", state.State)
+		}
+		_, data, err := provider.UserData(context.Background(), values, state, nil)
+		return data, err
+	}
+
+	t.Run("success", func(t *testing.T) {
+		state, nonce := login()
+		iss.claims = idClaims(nonce)
+		iss.userInfo = map[string]any{"sub": "user-1", "preferred_username": "jane", "picture": "https://img.example.com/jane.png"}
+
+		data, err := callback(state)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", data.ID)
+		assert.Equal(t, "jane@example.com", data.Email)
+		assert.Equal(t, "Jane", data.FirstName)
+		assert.Equal(t, "Doe", data.LastName)
+		assert.Equal(t, "jane", data.Username)
+		assert.Equal(t, "https://img.example.com/jane.png", data.AvatarURL)
+		assert.Equal(t, true, data.Ext["email_verified"])
+		assert.Equal(t, iss.URL, data.Ext["issuer"])
+	})
+
+	t.Run("nonce", func(t *testing.T) {
+		state, _ := login()
+		iss.claims = idClaims("other")
+		_, err := callback(state)
+		assert.ErrorIs(t, err, ErrInvalidNonce)
+	})
+
+	t.Run("no_state", func(t *testing.T) {
+		_, err := callback(nil)
+		assert.ErrorIs(t, err, elogin.ErrInvalidState)
+	})
+
+	t.Run("code_verifier", func(t *testing.T) {
+		state, nonce := login()
+		iss.claims = idClaims(nonce)
+		state.CodeVerifier = oauth2.GenerateVerifier()
+		_, err := callback(state)
+		assert.Error(t, err, "the code is bound to the verifier of the login attempt")
+	})
+
+	t.Run("audience", func(t *testing.T) {
+		state, nonce := login()
+		iss.claims = idClaims(nonce)
+		iss.claims["aud"] = "other"
+		_, err := callback(state)
+		assert.Error(t, err)
+	})
+
+	t.Run("azp", func(t *testing.T) {
+		state, nonce := login()
+		iss.claims = idClaims(nonce)
+		iss.claims["aud"] = []string{"client", "other"}
+		_, err := callback(state)
+		assert.Error(t, err, "the token of several audiences must be issued to the client")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		state, nonce := login()
+		iss.claims = idClaims(nonce)
+		iss.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		_, err := callback(state)
+		assert.Error(t, err)
+	})
+
+	t.Run("issuer", func(t *testing.T) {
+		state, nonce := login()
+		iss.claims = idClaims(nonce)
+		iss.claims["iss"] = "https://evil.example.com"
+		_, err := callback(state)
+		assert.Error(t, err)
+	})
+
+	t.Run("userinfo_subject", func(t *testing.T) {
+		state, nonce := login()
+		iss.claims = idClaims(nonce)
+		iss.userInfo = map[string]any{"sub": "user-2"}
+		_, err := callback(state)
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyIDTokenSignature(t *testing.T) {
+	iss := newTestIssuer(t)
+	provider, err := New(context.Background(), Config{ProviderName: "test", Issuer: iss.URL, ClientID: "client"})
+	require.NoError(t, err)
+	claims := map[string]any{"iss": iss.URL, "sub": "user-1", "aud": "client", "exp": time.Now().Add(time.Hour).Unix()}
+
+	_, err = provider.verifyIDToken(context.Background(), iss.sign(t, iss.key, claims))
+	assert.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = provider.verifyIDToken(context.Background(), iss.sign(t, otherKey, claims))
+	assert.Error(t, err, "the token signed by the unknown key")
+
+	hmacSigner, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("client-secret-of-32-bytes-length")}, nil)
+	require.NoError(t, err)
+	hmacToken, err := jwt.Signed(hmacSigner).Claims(claims).CompactSerialize()
+	require.NoError(t, err)
+	_, err = provider.verifyIDToken(context.Background(), hmacToken)
+	assert.Error(t, err, "the HMAC signature isn't accepted")
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	iss := newTestIssuer(t)
+	_, err := New(context.Background(), Config{ProviderName: "test", Issuer: iss.URL + "/", ClientID: "client"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package elogin
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/utils"
+)
+
+// StateIDKey is the parameter of the state which identifies the login attempt
+const StateIDKey = "sid"
+
+// DefaultStateLifetime is the time the user has to pass the login on the provider side
+const DefaultStateLifetime = 10 * time.Minute
+
+// LoginState of the single login attempt. It's kept in the browser session
+// between the login redirect and the callback and can be used only once.
+type LoginState struct {
+	// ID of the login attempt, the key of the state in the store
+	ID string `json:"id"`
+
+	// State is the encoded value of the `state` parameter sent to the provider
+	State string `json:"state"`
+
+	// Nonce binds the ID token of the OpenID Connect providers to the login attempt
+	Nonce string `json:"nonce"`
+
+	// CodeVerifier of the PKCE extension (RFC 7636)
+	CodeVerifier string `json:"code_verifier"`
+
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewLoginState generates the random state of the login attempt,
+// the parameters are encoded into the state to be restored on the callback
+func NewLoginState(params []URLParam, lifetime time.Duration) (*LoginState, error) {
+	id, err := randomString(24)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString(24)
+	if err != nil {
+		return nil, err
+	}
+	state := utils.NewState(utils.Param{Key: StateIDKey, Value: id})
+	for _, param := range params {
+		state = state.Extend(param.Key, param.Value)
+	}
+	return &LoginState{
+		ID:           id,
+		State:        state.Encode(),
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		ExpiresAt:    time.Now().Add(lifetime),
+	}, nil
+}
+
+// Verify checks the `state` parameter of the callback
+func (s *LoginState) Verify(state string, now time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(s.State), []byte(state)) != 1 {
+		return ErrInvalidState
+	}
+	if now.After(s.ExpiresAt) {
+		return ErrStateExpired
+	}
+	return nil
+}
+
+// StateStore keeps the login states bound to the browser
+type StateStore interface {
+	// Save the state of the new login attempt
+	Save(w http.ResponseWriter, r *http.Request, state *LoginState) error
+
+	// Pop returns the state and removes it from the store,
+	// the ErrInvalidState is returned if the state is unknown
+	Pop(w http.ResponseWriter, r *http.Request, id string) (*LoginState, error)
+}
+
+// StateIDFromRequest returns the ID of the login attempt from the `state` parameter of the callback
+func StateIDFromRequest(r *http.Request) string {
+	return utils.DecodeState(r.FormValue("state")).Get(StateIDKey)
+}
+
+func randomString(size int) (string, error) {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
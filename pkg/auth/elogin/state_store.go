@@ -0,0 +1,137 @@
+package elogin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+)
+
+const sessionStatePrefix = "elogin.state."
+
+// SessionStateStore keeps the login states in the `scs` session of the request
+// which is initialized by the HTTPSession middleware
+type SessionStateStore struct{}
+
+// NewSessionStateStore returns the state store of the browser session
+func NewSessionStateStore() *SessionStateStore {
+	return &SessionStateStore{}
+}
+
+// Save the state into the session and drops the expired states of the abandoned logins
+func (s *SessionStateStore) Save(w http.ResponseWriter, r *http.Request, state *LoginState) error {
+	ctx := r.Context()
+	manager := session.Get(ctx)
+	if manager == nil {
+		return ErrSessionNotAvailable
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, key := range manager.Keys(ctx) {
+		if !strings.HasPrefix(key, sessionStatePrefix) {
+			continue
+		}
+		var prev LoginState
+		if err := json.Unmarshal(manager.GetBytes(ctx, key), &prev); err != nil || now.After(prev.ExpiresAt) {
+			manager.Remove(ctx, key)
+		}
+	}
+	manager.Put(ctx, sessionStatePrefix+state.ID, data)
+	return nil
+}
+
+// Pop the state from the session
+func (s *SessionStateStore) Pop(w http.ResponseWriter, r *http.Request, id string) (*LoginState, error) {
+	ctx := r.Context()
+	manager := session.Get(ctx)
+	if manager == nil {
+		return nil, ErrSessionNotAvailable
+	}
+	data := manager.PopBytes(ctx, sessionStatePrefix+id)
+	if len(data) == 0 {
+		return nil, ErrInvalidState
+	}
+	var state LoginState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, ErrInvalidState
+	}
+	return &state, nil
+}
+
+// CookieStateStore keeps the login state in the cookie signed by the secret,
+// it's used if the server doesn't have the browser session.
+// The cookie is removed on the callback, so the state is used only once by the browser.
+type CookieStateStore struct {
+	secret []byte
+	prefix string
+	path   string
+	secure bool
+}
+
+// NewCookieStateStore returns the state store of the signed cookies
+func NewCookieStateStore(secret []byte, secure bool) *CookieStateStore {
+	return &CookieStateStore{secret: secret, prefix: "elogin_", path: "/", secure: secure}
+}
+
+// Save the state into the signed cookie which lives until the state expiration
+func (s *CookieStateStore) Save(w http.ResponseWriter, r *http.Request, state *LoginState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.prefix + state.ID,
+		Value:    payload + "." + s.sign(payload),
+		Path:     s.path,
+		Expires:  state.ExpiresAt,
+		MaxAge:   int(time.Until(state.ExpiresAt).Seconds()),
+		Secure:   s.secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// Pop the state from the cookie and removes the cookie
+func (s *CookieStateStore) Pop(w http.ResponseWriter, r *http.Request, id string) (*LoginState, error) {
+	cookie, err := r.Cookie(s.prefix + id)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookie.Name,
+		Path:     s.path,
+		MaxAge:   -1,
+		Secure:   s.secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	payload, signature, _ := strings.Cut(cookie.Value, ".")
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return nil, ErrInvalidState
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	var state LoginState
+	if err := json.Unmarshal(data, &state); err != nil || state.ID != id {
+		return nil, ErrInvalidState
+	}
+	return &state, nil
+}
+
+func (s *CookieStateStore) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	_, _ = mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,112 @@
+package elogin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/utils"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+)
+
+func TestLoginState(t *testing.T) {
+	state, err := NewLoginState([]URLParam{{Key: "r", Value: "/home"}}, time.Minute)
+	require.NoError(t, err)
+	other, err := NewLoginState(nil, time.Minute)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, state.ID, other.ID)
+	assert.NotEqual(t, state.Nonce, other.Nonce)
+	assert.NotEqual(t, state.CodeVerifier, other.CodeVerifier)
+
+	decoded := utils.DecodeState(state.State)
+	assert.Equal(t, state.ID, decoded.Get(StateIDKey))
+	assert.Equal(t, "/home", decoded.Get("r"))
+
+	assert.NoError(t, state.Verify(state.State, time.Now()))
+	assert.ErrorIs(t, state.Verify(other.State, time.Now()), ErrInvalidState)
+	assert.ErrorIs(t, state.Verify(state.State, time.Now().Add(time.Hour)), ErrStateExpired)
+}
+
+func TestCookieStateStore(t *testing.T) {
+	store := NewCookieStateStore([]byte("secret"), true)
+	state, err := NewLoginState(nil, time.Minute)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(w, httptest.NewRequest(http.MethodGet, "/login", nil), state))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+
+	pop := func(cookie *http.Cookie) (*LoginState, *httptest.ResponseRecorder, error) {
+		r := httptest.NewRequest(http.MethodGet, "/callback", nil)
+		r.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		state, err := store.Pop(w, r, state.ID)
+		return state, w, err
+	}
+
+	restored, w, err := pop(cookies[0])
+	require.NoError(t, err)
+	assert.Equal(t, state.State, restored.State)
+	assert.Equal(t, state.CodeVerifier, restored.CodeVerifier)
+	require.Len(t, w.Result().Cookies(), 1)
+	assert.Less(t, w.Result().Cookies()[0].MaxAge, 0, "the cookie is removed after the use")
+
+	tampered := *cookies[0]
+	tampered.Value = "e30." + tampered.Value[len(tampered.Value)-10:]
+	_, _, err = pop(&tampered)
+	assert.ErrorIs(t, err, ErrInvalidState)
+
+	_, err = store.Pop(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/callback", nil), state.ID)
+	assert.ErrorIs(t, err, ErrInvalidState)
+}
+
+func TestSessionStateStore(t *testing.T) {
+	manager := scs.New()
+	store := NewSessionStateStore()
+	state, err := NewLoginState(nil, time.Minute)
+	require.NoError(t, err)
+
+	// serve runs the store operation inside the session of the cookie
+	serve := func(cookies []*http.Cookie, fn func(w http.ResponseWriter, r *http.Request)) []*http.Cookie {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, cookie := range cookies {
+			r.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		manager.LoadAndSave(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fn(w, r.WithContext(session.WithSession(r.Context(), manager)))
+		})).ServeHTTP(w, r)
+		return w.Result().Cookies()
+	}
+
+	cookies := serve(nil, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, store.Save(w, r, state))
+	})
+	require.NotEmpty(t, cookies)
+
+	serve(cookies, func(w http.ResponseWriter, r *http.Request) {
+		restored, err := store.Pop(w, r, state.ID)
+		require.NoError(t, err)
+		assert.Equal(t, state.Nonce, restored.Nonce)
+	})
+	serve(cookies, func(w http.ResponseWriter, r *http.Request) {
+		_, err := store.Pop(w, r, state.ID)
+		assert.ErrorIs(t, err, ErrInvalidState, "the state is used only once")
+	})
+	serve(nil, func(w http.ResponseWriter, r *http.Request) {
+		_, err := store.Pop(w, r, state.ID)
+		assert.ErrorIs(t, err, ErrInvalidState, "the state belongs to the other browser")
+	})
+
+	err = store.Save(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), state)
+	assert.ErrorIs(t, err, ErrSessionNotAvailable)
+}
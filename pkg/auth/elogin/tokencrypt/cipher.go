@@ -0,0 +1,80 @@
+// Package tokencrypt encrypts the tokens of the social providers stored in the database
+package tokencrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// prefix marks the encrypted value, the values without it are stored
+// before the encryption was enabled and returned as is
+const prefix = "enc:v1:"
+
+var (
+	ErrEmptySecret       = errors.New("tokencrypt: secret is empty")
+	ErrInvalidCipherText = errors.New("tokencrypt: invalid cipher text")
+)
+
+// Cipher encrypts and decrypts the stored tokens
+type Cipher interface {
+	Encrypt(plain string) (string, error)
+	Decrypt(value string) (string, error)
+}
+
+// AESCipher encrypts the tokens by AES-256-GCM with the key derived from the secret
+type AESCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher returns the cipher of the secret
+func NewAESCipher(secret string) (*AESCipher, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESCipher{aead: aead}, nil
+}
+
+// Encrypt the token, the empty token stays empty
+func (c *AESCipher) Encrypt(plain string) (string, error) {
+	if plain == "" || strings.HasPrefix(plain, prefix) {
+		return plain, nil
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	data := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return prefix + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decrypt the token, the plain token stored before the encryption is returned as is
+func (c *AESCipher) Decrypt(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return value, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(data) < c.aead.NonceSize() {
+		return "", ErrInvalidCipherText
+	}
+	nonce, data := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", ErrInvalidCipherText
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,40 @@
+package tokencrypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESCipher(t *testing.T) {
+	c, err := NewAESCipher("secret")
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt("access-token")
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "access-token")
+	again, err := c.Encrypt("access-token")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "every encryption uses the new nonce")
+
+	plain, err := c.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", plain)
+
+	plain, err = c.Decrypt("legacy-token")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", plain, "the token stored before the encryption")
+
+	empty, err := c.Encrypt("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	other, err := NewAESCipher("other")
+	require.NoError(t, err)
+	_, err = other.Decrypt(encrypted)
+	assert.ErrorIs(t, err, ErrInvalidCipherText)
+
+	_, err = NewAESCipher("")
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
@@ -3,11 +3,13 @@ package jwt
 import (
 	"context"
 	"net/http"
+	"time"
 
 	jwtmiddleware "github.com/auth0/go-jwt-middleware"
 	"go.uber.org/zap"
 
 	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
 	"github.com/geniusrabbit/blaze-api/repository/user"
@@ -49,19 +51,31 @@ func (au *Authorizer[TUser, TAccount]) Authorize(w http.ResponseWriter, r *http.
 	switch t := jwtToken.(type) {
 	case nil:
 	case *Token:
+		var imp *session.Impersonation
 		token = t.Raw
-		usr, acc, err = au.authContextJWT(ctx, t)
+		usr, acc, imp, err = au.authContextJWT(ctx, t)
+		if err == nil && imp != nil {
+			// The auth middleware takes the impersonation state from the request context
+			*r = *r.WithContext(session.WithImpersonation(r.Context(), imp))
+		}
 	}
 
 	return token, usr, acc, err
 }
 
-func (au *Authorizer[TUser, TAccount]) authContextJWT(ctx context.Context, token *Token) (TUser, TAccount, error) {
+func (au *Authorizer[TUser, TAccount]) authContextJWT(ctx context.Context, token *Token) (TUser, TAccount, *session.Impersonation, error) {
 	var zeroUser TUser
 	var zeroAcc TAccount
 	jwtData, err := au.provider.ExtractTokenData(token)
 	if err != nil {
-		return zeroUser, zeroAcc, err
+		return zeroUser, zeroAcc, nil, err
 	}
-	return au.loader.UserAccountByID(ctx, jwtData.UserID, jwtData.AccountID, zeroUser, zeroAcc)
+	usr, acc, err := au.loader.UserAccountByID(ctx, jwtData.UserID, jwtData.AccountID, zeroUser, zeroAcc)
+	if err != nil || jwtData.ActorID == 0 {
+		return usr, acc, nil, err
+	}
+	return usr, acc, &session.Impersonation{
+		ActorID:   jwtData.ActorID,
+		ExpiresAt: time.Unix(jwtData.ExpireAt, 0),
+	}, nil
 }
@@ -16,13 +16,17 @@ import (
 var (
 	errJWTTokenIsExpired = errors.New(`JWT token is expired`)
 	errJWTInvalidToken   = errors.New(`JWT invalid token`)
+	errJWTInvalidActor   = errors.New(`JWT invalid impersonation actor`)
 )
 
 const (
 	claimUserID          = "uid"
 	claimAccountID       = "acc"
 	claimExpiredAt       = "exp"
+	claimIssuedAt        = "iat"
+	claimAuthTime        = "auth_time"
 	claimSocialAccountID = "sid"
+	claimActorID         = "act"
 )
 
 type (
@@ -42,6 +46,9 @@ type TokenData struct {
 	AccountID       uint64
 	SocialAccountID uint64
 	ExpireAt        int64
+	IssuedAt        int64  // Time of the token issue
+	AuthTime        int64  // Time of the login kept by the reissued tokens, zero if unknown
+	ActorID         uint64 // The real user of the impersonation token, zero for the regular tokens
 }
 
 // Provider manages JWT token creation and validation
@@ -64,17 +71,28 @@ func NewDefaultProvider(secret string, tokenLifetime time.Duration, isDebug bool
 	}
 }
 
-// CreateToken generates a new signed JWT token for the given user
+// CreateToken generates a new signed JWT token for the given user after the login
 func (provider *Provider) CreateToken(userID, accountID, socialAccountID uint64) (string, time.Time, error) {
+	return provider.ReissueToken(time.Now(), userID, accountID, socialAccountID)
+}
+
+// ReissueToken generates the token of the login which happened at the authTime,
+// e.g. the token of another account of the same session. Zero authTime means unknown.
+func (provider *Provider) ReissueToken(authTime time.Time, userID, accountID, socialAccountID uint64) (string, time.Time, error) {
 	lifetime := gocast.IfThen(provider.TokenLifetime > time.Minute, provider.TokenLifetime, time.Hour)
-	expireAt := time.Now().Add(lifetime)
+	issuedAt := time.Now()
+	expireAt := issuedAt.Add(lifetime)
 
 	// Build token claims
 	atClaims := jwt.MapClaims{
 		claimUserID:    userID,
 		claimExpiredAt: expireAt.Unix(),
+		claimIssuedAt:  issuedAt.Unix(),
 	}
 
+	if !authTime.IsZero() {
+		atClaims[claimAuthTime] = authTime.Unix()
+	}
 	if accountID > 0 {
 		atClaims[claimAccountID] = accountID
 	}
@@ -82,14 +100,35 @@ func (provider *Provider) CreateToken(userID, accountID, socialAccountID uint64)
 		atClaims[claimSocialAccountID] = socialAccountID
 	}
 
-	// Sign and return token
+	return provider.signToken(atClaims, expireAt)
+}
+
+// CreateImpersonationToken generates the token of the user issued on behalf of the actor.
+// The token keeps the actor ID and expires at the given time independently of the token lifetime.
+func (provider *Provider) CreateImpersonationToken(userID, accountID, actorID uint64, expireAt time.Time) (string, time.Time, error) {
+	if actorID == 0 || actorID == userID {
+		return "", expireAt, errJWTInvalidActor
+	}
+	atClaims := jwt.MapClaims{
+		claimUserID:    userID,
+		claimActorID:   actorID,
+		claimExpiredAt: expireAt.Unix(),
+		claimIssuedAt:  time.Now().Unix(),
+	}
+	if accountID > 0 {
+		atClaims[claimAccountID] = accountID
+	}
+	return provider.signToken(atClaims, expireAt)
+}
+
+// signToken signs the claims with the provider secret
+func (provider *Provider) signToken(claims jwt.MapClaims, expireAt time.Time) (string, time.Time, error) {
 	opt := provider.MiddlewareOptions()
-	at := jwt.NewWithClaims(opt.SigningMethod, atClaims)
+	at := jwt.NewWithClaims(opt.SigningMethod, claims)
 	token, err := at.SignedString([]byte(provider.Secret))
 	if err != nil {
 		return "", expireAt, err
 	}
-
 	return token, expireAt, nil
 }
 
@@ -131,6 +170,9 @@ func (provider *Provider) ExtractTokenData(token *Token) (*TokenData, error) {
 		AccountID:       gocast.Uint64(claims[claimAccountID]),
 		SocialAccountID: gocast.Uint64(claims[claimSocialAccountID]),
 		ExpireAt:        gocast.Int64(claims[claimExpiredAt]),
+		IssuedAt:        gocast.Int64(claims[claimIssuedAt]),
+		AuthTime:        gocast.Int64(claims[claimAuthTime]),
+		ActorID:         gocast.Uint64(claims[claimActorID]),
 	}
 
 	// Validate expiration and user ID
@@ -144,6 +186,19 @@ func (provider *Provider) ExtractTokenData(token *Token) (*TokenData, error) {
 	return data, nil
 }
 
+// ParseToken validates the raw token string and extracts its claims
+func (provider *Provider) ParseToken(raw string) (*TokenData, error) {
+	opt := provider.MiddlewareOptions()
+	token, err := jwt.Parse(raw, opt.ValidationKeyGetter)
+	if err != nil {
+		return nil, err
+	}
+	if token.Header["alg"] != opt.SigningMethod.Alg() {
+		return nil, errJWTInvalidToken
+	}
+	return provider.ExtractTokenData(token)
+}
+
 // validationKeyGetter retrieves and validates the secret key for token verification
 func (provider *Provider) validationKeyGetter(token *Token) (any, error) {
 	if token.Claims == nil {
@@ -0,0 +1,60 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonationToken(t *testing.T) {
+	provider := NewDefaultProvider("secret", time.Hour, false)
+	expireAt := time.Now().Add(10 * time.Minute)
+
+	token, tokenExpireAt, err := provider.CreateImpersonationToken(10, 20, 1, expireAt)
+	require.NoError(t, err)
+	assert.Equal(t, expireAt, tokenExpireAt)
+
+	data, err := provider.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), data.UserID)
+	assert.Equal(t, uint64(20), data.AccountID)
+	assert.Equal(t, uint64(1), data.ActorID)
+	assert.Equal(t, expireAt.Unix(), data.ExpireAt, "the impersonation token ignores the provider lifetime")
+
+	token, _, err = provider.CreateToken(10, 20, 0)
+	require.NoError(t, err)
+	data, err = provider.ParseToken(token)
+	require.NoError(t, err)
+	assert.Zero(t, data.ActorID)
+
+	_, _, err = provider.CreateImpersonationToken(10, 20, 10, expireAt)
+	assert.Error(t, err, "the user can't impersonate itself")
+	_, _, err = provider.CreateImpersonationToken(10, 20, 0, expireAt)
+	assert.Error(t, err, "the actor is required")
+}
+
+func TestReissueTokenAuthTime(t *testing.T) {
+	provider := NewDefaultProvider("secret", time.Hour, false)
+	authTime := time.Now().Add(-30 * time.Minute)
+
+	token, _, err := provider.ReissueToken(authTime, 10, 30, 0)
+	require.NoError(t, err)
+	data, err := provider.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, authTime.Unix(), data.AuthTime, "the reissued token keeps the login time")
+	assert.Greater(t, data.IssuedAt, data.AuthTime)
+
+	token, _, err = provider.ReissueToken(time.Time{}, 10, 30, 0)
+	require.NoError(t, err)
+	data, err = provider.ParseToken(token)
+	require.NoError(t, err)
+	assert.Zero(t, data.AuthTime, "the unknown login time is not set")
+
+	token, _, err = provider.CreateToken(10, 20, 0)
+	require.NoError(t, err)
+	data, err = provider.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, data.IssuedAt, data.AuthTime)
+}
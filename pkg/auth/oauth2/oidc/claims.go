@@ -0,0 +1,62 @@
+package oidc
+
+import (
+	"time"
+
+	"github.com/ory/fosite"
+
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// Scopes of the OpenID Connect claims
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+	ScopeAccount = "account"
+)
+
+type usernameGetter interface {
+	GetUsername() string
+}
+
+type updatedAtGetter interface {
+	GetUpdatedAt() time.Time
+}
+
+type titleGetter interface {
+	GetTitle() string
+}
+
+// UserClaims returns the standard claims of the user and the account
+// which are granted by the scopes. Traits the models don't implement are skipped.
+func UserClaims(usr user.Model, acc account.Model, scopes fosite.Arguments) map[string]any {
+	claims := map[string]any{}
+	if usr == nil || usr.IsAnonymous() {
+		return claims
+	}
+	if scopes.Has(ScopeProfile) {
+		if u, ok := usr.(usernameGetter); ok && u.GetUsername() != "" {
+			claims["preferred_username"] = u.GetUsername()
+		}
+		if u, ok := usr.(updatedAtGetter); ok && !u.GetUpdatedAt().IsZero() {
+			claims["updated_at"] = u.GetUpdatedAt().Unix()
+		}
+	}
+	if scopes.Has(ScopeEmail) {
+		if u, ok := usr.(user.EmailModel); ok && u.GetEmail() != "" {
+			claims["email"] = u.GetEmail()
+			if v, ok := usr.(user.EmailVerificationModel); ok {
+				claims["email_verified"] = v.IsEmailVerified()
+			}
+		}
+	}
+	if scopes.Has(ScopeAccount) && acc != nil && !acc.IsAnonymous() {
+		claims["account_id"] = acc.GetID()
+		if a, ok := acc.(titleGetter); ok && a.GetTitle() != "" {
+			claims["account_name"] = a.GetTitle()
+		}
+	}
+	return claims
+}
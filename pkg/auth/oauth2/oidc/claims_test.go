@@ -0,0 +1,62 @@
+package oidc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accountModels "github.com/geniusrabbit/blaze-api/repository/account/models"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	userModels "github.com/geniusrabbit/blaze-api/repository/user/models"
+)
+
+type testUser struct {
+	userModels.UserBase
+	userModels.UserEmail
+	userModels.UserUsername
+}
+
+func (u *testUser) NewWithID(id uint64) user.Model {
+	return &testUser{UserBase: userModels.UserBase{ID: id}}
+}
+
+type testAccount struct {
+	accountModels.AccountBase
+}
+
+func (a *testAccount) NewWithIDs(id uint64, adminUserIDs ...uint64) account.Model {
+	return &testAccount{AccountBase: accountModels.AccountBase{ID: id}}
+}
+
+func TestUserClaims(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	usr := &testUser{
+		UserBase:     userModels.UserBase{ID: 1, UpdatedAt: updatedAt},
+		UserEmail:    userModels.UserEmail{Email: "user@example.com", EmailVerified: true},
+		UserUsername: userModels.UserUsername{Username: "user"},
+	}
+	acc := &testAccount{AccountBase: accountModels.AccountBase{ID: 7}}
+
+	assert.Empty(t, UserClaims(usr, acc, fosite.Arguments{ScopeOpenID}))
+	assert.Equal(t, map[string]any{
+		"preferred_username": "user",
+		"updated_at":         updatedAt.Unix(),
+	}, UserClaims(usr, acc, fosite.Arguments{ScopeOpenID, ScopeProfile}))
+	assert.Equal(t, map[string]any{
+		"email":          "user@example.com",
+		"email_verified": true,
+		"account_id":     uint64(7),
+	}, UserClaims(usr, acc, fosite.Arguments{ScopeOpenID, ScopeEmail, ScopeAccount}))
+	assert.Empty(t, UserClaims(&testUser{}, acc, fosite.Arguments{ScopeEmail, ScopeAccount}), "anonymous user")
+}
+
+func TestMaxAgeExceeded(t *testing.T) {
+	now := time.Now()
+	assert.False(t, maxAgeExceeded("", time.Time{}, now))
+	assert.False(t, maxAgeExceeded("60", now.Add(-time.Minute/2), now))
+	assert.True(t, maxAgeExceeded("60", now.Add(-2*time.Minute), now))
+	assert.True(t, maxAgeExceeded("60", time.Time{}, now), "unknown authentication time")
+}
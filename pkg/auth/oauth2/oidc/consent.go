@@ -0,0 +1,137 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/ory/fosite"
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+const defaultConsentLifespan = 15 * time.Minute
+
+// ConsentStorage keeps the consent requests and the remembered grants
+type ConsentStorage interface {
+	CreateConsentRequest(ctx context.Context, req *authclient.AuthConsentRequest) error
+	UseConsentRequest(ctx context.Context, challenge string) (*authclient.AuthConsentRequest, error)
+	GetGrant(ctx context.Context, userID uint64, clientID string) (*authclient.AuthGrant, error)
+}
+
+// authorizeConsent grants the scopes the user agreed to.
+// It returns false if the response is already written: the user is redirected
+// to the consent page or the error is sent back to the client.
+func (srv *Server[TUser, TAccount]) authorizeConsent(ctx context.Context, w http.ResponseWriter, r *http.Request, ar fosite.AuthorizeRequester, usr user.Model, acc account.Model, prompt fosite.Arguments) bool {
+	if srv.consents == nil {
+		grantRequested(ar)
+		return true
+	}
+
+	var (
+		now      = time.Now()
+		clientID = ar.GetClient().GetID()
+		form     = ar.GetRequestForm()
+	)
+
+	// The user comes back from the consent page with the decision
+	if challenge := form.Get(authclient.ConsentChallengeParam); challenge != "" {
+		req, err := srv.consents.UseConsentRequest(ctx, challenge)
+		switch {
+		case err != nil && !errors.Is(err, authclient.ErrConsentRequestNotFound):
+			srv.provider.WriteAuthorizeError(ctx, w, ar, fosite.ErrServerError.WithWrap(err))
+		case err != nil || req.UserID != usr.GetID() || req.ClientID != clientID || req.IsExpired(now):
+			srv.provider.WriteAuthorizeError(ctx, w, ar,
+				fosite.ErrInvalidRequest.WithHint("The consent challenge is invalid or expired."))
+		case req.Status == authclient.ConsentStatusRejected:
+			srv.provider.WriteAuthorizeError(ctx, w, ar,
+				fosite.ErrAccessDenied.WithHint("The user rejected the consent request."))
+		default:
+			for _, scope := range req.GrantedScope {
+				if ar.GetRequestedScopes().Has(scope) {
+					ar.GrantScope(scope)
+				}
+			}
+			for _, audience := range req.GrantedAudience {
+				if ar.GetRequestedAudience().Has(audience) {
+					ar.GrantAudience(audience)
+				}
+			}
+			return true
+		}
+		return false
+	}
+
+	// The remembered grant skips the consent page unless it's forced by `prompt=consent`
+	if !prompt.Has("consent") {
+		grant, err := srv.consents.GetGrant(ctx, usr.GetID(), clientID)
+		if err != nil {
+			srv.provider.WriteAuthorizeError(ctx, w, ar, fosite.ErrServerError.WithWrap(err))
+			return false
+		}
+		if grant != nil && !grant.IsExpired(now) &&
+			grant.Covers(ar.GetRequestedScopes(), ar.GetRequestedAudience()) {
+			grantRequested(ar)
+			return true
+		}
+	}
+
+	if prompt.Has("none") || srv.consentURL == "" {
+		srv.provider.WriteAuthorizeError(ctx, w, ar,
+			fosite.ErrConsentRequired.WithHint("The user has to consent to the requested scopes."))
+		return false
+	}
+
+	lifespan := srv.consentLifespan
+	if lifespan <= 0 {
+		lifespan = defaultConsentLifespan
+	}
+	var accountID uint64
+	if acc != nil && !acc.IsAnonymous() {
+		accountID = acc.GetID()
+	}
+	req := &authclient.AuthConsentRequest{
+		UserID:            usr.GetID(),
+		AccountID:         accountID,
+		ClientID:          clientID,
+		RequestedScope:    gosql.NullableStringArray(ar.GetRequestedScopes()),
+		RequestedAudience: gosql.NullableStringArray(ar.GetRequestedAudience()),
+		RequestURL:        srv.authorizeURL(form, "consent"),
+		ExpiresAt:         now.Add(lifespan),
+	}
+	if err := srv.consents.CreateConsentRequest(ctx, req); err != nil {
+		srv.provider.WriteAuthorizeError(ctx, w, ar, fosite.ErrServerError.WithWrap(err))
+		return false
+	}
+	srv.redirectWith(w, r, srv.consentURL, authclient.ConsentChallengeParam, req.Challenge)
+	return false
+}
+
+// grantRequested grants all requested scopes and audiences,
+// they are checked against the client settings by the provider
+func grantRequested(req fosite.Requester) {
+	for _, scope := range req.GetRequestedScopes() {
+		req.GrantScope(scope)
+	}
+	for _, audience := range req.GetRequestedAudience() {
+		req.GrantAudience(audience)
+	}
+}
+
+// redirectWith redirects to the target URL with the additional query parameter
+func (srv *Server[TUser, TAccount]) redirectWith(w http.ResponseWriter, r *http.Request, target, param, value string) {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		srv.internalError(w, r, "invalid redirect URL", err)
+		return
+	}
+	query := targetURL.Query()
+	query.Set(param, value)
+	targetURL.RawQuery = query.Encode()
+	http.Redirect(w, r, targetURL.String(), http.StatusFound)
+}
@@ -0,0 +1,35 @@
+package oidc
+
+import (
+	"database/sql"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+func TestAuthorizeURL(t *testing.T) {
+	srv := &Server[*testUser, *testAccount]{options: options{issuer: "https://auth.example.com"}}
+	form := url.Values{"client_id": {"app"}, "prompt": {"login consent"}}
+
+	assert.Equal(t, "https://auth.example.com/oauth2/auth?client_id=app&prompt=consent", srv.authorizeURL(form, "login"))
+	assert.Equal(t, "https://auth.example.com/oauth2/auth?client_id=app&prompt=login", srv.authorizeURL(form, "consent"))
+	assert.Equal(t, "login consent", form.Get("prompt"), "the request form must stay untouched")
+}
+
+func TestGrantCovers(t *testing.T) {
+	now := time.Now()
+	grant := &authclient.AuthGrant{Scope: []string{"openid", "email"}, Audience: []string{"api"}}
+
+	assert.True(t, grant.Covers([]string{"openid"}, nil))
+	assert.True(t, grant.Covers([]string{"openid", "email"}, []string{"api"}))
+	assert.False(t, grant.Covers([]string{"openid", "profile"}, nil))
+	assert.False(t, grant.Covers(nil, []string{"admin"}))
+
+	assert.False(t, grant.IsExpired(now))
+	grant.ExpiresAt = sql.Null[time.Time]{V: now.Add(-time.Second), Valid: true}
+	assert.True(t, grant.IsExpired(now))
+}
@@ -0,0 +1,81 @@
+package oidc
+
+import (
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/serverprovider"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// Discovery writes the OpenID Provider metadata
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+func (srv *Server[TUser, TAccount]) Discovery(w http.ResponseWriter, r *http.Request) {
+	grantTypes := []string{
+		"authorization_code", "implicit", "refresh_token", "client_credentials",
+	}
+	if srv.devices != nil {
+		grantTypes = append(grantTypes, serverprovider.DeviceCodeGrantType)
+	}
+	if srv.tokenExchange {
+		grantTypes = append(grantTypes, serverprovider.TokenExchangeGrantType)
+	}
+	metadata := map[string]any{
+		"issuer":                 srv.issuer,
+		"authorization_endpoint": srv.issuer + PathAuthorize,
+		"token_endpoint":         srv.issuer + PathToken,
+		"userinfo_endpoint":      srv.issuer + PathUserInfo,
+		"revocation_endpoint":    srv.issuer + PathRevoke,
+		"introspection_endpoint": srv.issuer + PathIntrospect,
+		"jwks_uri":               srv.issuer + PathJWKS,
+		"response_types_supported": []string{
+			"code", "token", "id_token",
+			"code id_token", "code token", "token id_token", "code token id_token",
+		},
+		"response_modes_supported":              []string{"query", "fragment", "form_post"},
+		"grant_types_supported":                 grantTypes,
+		"subject_types_supported":               []string{authclient.SubjectTypePublic, authclient.SubjectTypePairwise},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"token_endpoint_auth_methods_supported": []string{
+			authclient.TokenEndpointAuthSecretBasic, authclient.TokenEndpointAuthSecretPost,
+			authclient.TokenEndpointAuthPrivateKey, authclient.TokenEndpointAuthNone,
+		},
+		"token_endpoint_auth_signing_alg_values_supported": serverprovider.ClientAssertionAlgs,
+		"code_challenge_methods_supported":                 []string{"S256"},
+		"scopes_supported": []string{
+			ScopeOpenID, ScopeProfile, ScopeEmail, ScopeAccount, "offline_access",
+		},
+		"claims_supported": []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce",
+			"preferred_username", "updated_at", "email", "email_verified",
+			"account_id", "account_name",
+		},
+		"claims_parameter_supported":  false,
+		"request_parameter_supported": false,
+	}
+	if srv.devices != nil {
+		metadata["device_authorization_endpoint"] = srv.issuer + PathDeviceAuthorize
+	}
+	if srv.registrar != nil {
+		metadata["registration_endpoint"] = srv.issuer + PathRegister
+	}
+	writeJSON(w, http.StatusOK, metadata)
+}
+
+// JWKS writes the public keys of the ID token signature
+func (srv *Server[TUser, TAccount]) JWKS(w http.ResponseWriter, r *http.Request) {
+	keys := []map[string]string{}
+	if srv.signingKey != nil {
+		pub := srv.signingKey.PublicKey
+		keys = append(keys, map[string]string{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": srv.keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"keys": keys})
+}
@@ -0,0 +1,101 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"time"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/serverprovider"
+)
+
+type options struct {
+	issuer       string
+	loginURL     string
+	pairwiseSalt string
+	signingKey   *rsa.PrivateKey
+	keyID        string
+	authTime     func(ctx context.Context) time.Time
+
+	consentURL      string
+	consents        ConsentStorage
+	consentLifespan time.Duration
+
+	devices       *serverprovider.DeviceAuthorizer
+	tokenExchange bool
+	registrar     ClientRegistrar
+}
+
+// Option of the OpenID Connect server
+type Option func(*options)
+
+// WithIssuer sets the issuer URL, it's also the base of the endpoints in the discovery document
+func WithIssuer(issuer string) Option {
+	return func(o *options) {
+		o.issuer = issuer
+	}
+}
+
+// WithLoginURL sets the page where the user is redirected to log in,
+// the authorization URL to come back is passed in the `return_to` parameter
+func WithLoginURL(loginURL string) Option {
+	return func(o *options) {
+		o.loginURL = loginURL
+	}
+}
+
+// WithPairwiseSalt sets the secret salt of the pairwise subject identifiers
+func WithPairwiseSalt(salt string) Option {
+	return func(o *options) {
+		o.pairwiseSalt = salt
+	}
+}
+
+// WithSigningKey sets the key of the ID token signature published in the JWKS
+func WithSigningKey(key *rsa.PrivateKey, keyID string) Option {
+	return func(o *options) {
+		o.signingKey = key
+		o.keyID = keyID
+	}
+}
+
+// WithAuthTime sets the function which returns the time when the current user logged in.
+// It's required by the `max_age` and `prompt` parameters, zero time means the time is unknown.
+func WithAuthTime(fn func(ctx context.Context) time.Time) Option {
+	return func(o *options) {
+		o.authTime = fn
+	}
+}
+
+// WithConsent enables the consent step of the authorization, the user is redirected
+// to the consent page with the `consent_challenge` parameter unless the stored grant
+// covers the requested scopes. Zero lifespan means the default one.
+func WithConsent(consentURL string, storage ConsentStorage, lifespan time.Duration) Option {
+	return func(o *options) {
+		o.consentURL = consentURL
+		o.consents = storage
+		o.consentLifespan = lifespan
+	}
+}
+
+// WithDeviceAuthorizer enables the device authorization endpoint of the clients
+// which can't redirect the user to the authorization endpoint (CLI tools, TVs)
+func WithDeviceAuthorizer(devices *serverprovider.DeviceAuthorizer) Option {
+	return func(o *options) {
+		o.devices = devices
+	}
+}
+
+// WithTokenExchange advertises the token exchange grant (RFC 8693) in the discovery document,
+// the provider has to be composed with serverprovider.TokenExchangeFactory
+func WithTokenExchange() Option {
+	return func(o *options) {
+		o.tokenExchange = true
+	}
+}
+
+// WithClientRegistration enables the dynamic client registration endpoint (RFC 7591)
+func WithClientRegistration(registrar ClientRegistrar) Option {
+	return func(o *options) {
+		o.registrar = registrar
+	}
+}
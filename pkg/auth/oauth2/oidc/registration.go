@@ -0,0 +1,240 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/serverprovider"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// maxRegistrationSize limits the body of the registration request
+const maxRegistrationSize = 64 << 10
+
+// ClientRegistrar creates the clients of the dynamic client registration
+// authorized by the initial access token
+type ClientRegistrar interface {
+	RegisterClient(ctx context.Context, initialAccessToken string, client *authclient.AuthClient) (string, error)
+}
+
+// Grant and response types the registered clients can use. The token exchange
+// isn't available, the audience of the clients is set by the administrators only.
+var (
+	registrationGrantTypes = []string{
+		"authorization_code", "implicit", "refresh_token", "client_credentials",
+		serverprovider.DeviceCodeGrantType,
+	}
+	registrationResponseTypes = []string{"code", "token", "id_token"}
+	registrationAuthMethods   = []string{
+		authclient.TokenEndpointAuthSecretBasic, authclient.TokenEndpointAuthSecretPost,
+		authclient.TokenEndpointAuthPrivateKey, authclient.TokenEndpointAuthNone,
+	}
+)
+
+// registrationError of the client registration (RFC 7591, section 3.2.2)
+type registrationError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *registrationError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func invalidClientMetadata(description string) *registrationError {
+	return &registrationError{Code: "invalid_client_metadata", Description: description}
+}
+
+// clientMetadata of the dynamic client registration (RFC 7591, section 2)
+type clientMetadata struct {
+	ClientName                  string          `json:"client_name,omitempty"`
+	RedirectURIs                []string        `json:"redirect_uris,omitempty"`
+	TokenEndpointAuthMethod     string          `json:"token_endpoint_auth_method,omitempty"`
+	TokenEndpointAuthSigningAlg string          `json:"token_endpoint_auth_signing_alg,omitempty"`
+	GrantTypes                  []string        `json:"grant_types,omitempty"`
+	ResponseTypes               []string        `json:"response_types,omitempty"`
+	Scope                       string          `json:"scope,omitempty"`
+	SubjectType                 string          `json:"subject_type,omitempty"`
+	JWKSURI                     string          `json:"jwks_uri,omitempty"`
+	JWKS                        json.RawMessage `json:"jwks,omitempty"`
+}
+
+// clientRegistration is the response of the registration (RFC 7591, section 3.2.1)
+type clientRegistration struct {
+	ClientID              string `json:"client_id"`
+	ClientSecret          string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt      int64  `json:"client_id_issued_at"`
+	ClientSecretExpiresAt int64  `json:"client_secret_expires_at"`
+	clientMetadata
+}
+
+// RegisterClient handles the dynamic client registration request (RFC 7591).
+// The request is authorized by the initial access token in the bearer header,
+// the client belongs to the account of the token.
+func (srv *Server[TUser, TAccount]) RegisterClient(w http.ResponseWriter, r *http.Request) {
+	ctx := serverprovider.NewContext(r.Context())
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, &registrationError{
+			Code: "invalid_request", Description: "The registration request must use the POST method."})
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		writeBearerError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	var meta clientMetadata
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRegistrationSize)).Decode(&meta); err != nil {
+		writeJSON(w, http.StatusBadRequest, invalidClientMetadata("The client metadata is not a valid JSON object."))
+		return
+	}
+	if err := meta.normalize(); err != nil {
+		writeJSON(w, http.StatusBadRequest, err)
+		return
+	}
+
+	client := meta.authClient()
+	secret, err := srv.registrar.RegisterClient(ctx, token, client)
+	switch {
+	case errors.Is(err, authclient.ErrInitialAccessTokenInvalid):
+		writeBearerError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	case errors.Is(err, authclient.ErrScopeNotAllowed):
+		writeJSON(w, http.StatusBadRequest, invalidClientMetadata(err.Error()))
+		return
+	case err != nil:
+		ctxlogger.Get(ctx).Error("register client", zap.Error(err))
+		writeJSON(w, http.StatusInternalServerError, &registrationError{
+			Code: fosite.ErrServerError.ErrorField, Description: "The client can't be registered."})
+		return
+	}
+
+	meta.Scope = client.Scope
+	writeJSON(w, http.StatusCreated, &clientRegistration{
+		ClientID:         client.ID,
+		ClientSecret:     secret,
+		ClientIDIssuedAt: client.CreatedAt.Unix(),
+		clientMetadata:   meta,
+	})
+}
+
+// normalize sets the default values of the metadata (RFC 7591, section 2)
+// and validates the combination of the values
+func (m *clientMetadata) normalize() *registrationError {
+	if m.TokenEndpointAuthMethod == "" {
+		m.TokenEndpointAuthMethod = authclient.TokenEndpointAuthSecretBasic
+	}
+	if !slices.Contains(registrationAuthMethods, m.TokenEndpointAuthMethod) {
+		return invalidClientMetadata("The token_endpoint_auth_method '" + m.TokenEndpointAuthMethod + "' is not supported.")
+	}
+	if len(m.GrantTypes) == 0 {
+		m.GrantTypes = []string{"authorization_code"}
+	}
+	if len(m.ResponseTypes) == 0 {
+		m.ResponseTypes = []string{"code"}
+	}
+	if m.SubjectType == "" {
+		m.SubjectType = authclient.SubjectTypePublic
+	}
+	if m.SubjectType != authclient.SubjectTypePublic && m.SubjectType != authclient.SubjectTypePairwise {
+		return invalidClientMetadata("The subject_type '" + m.SubjectType + "' is not supported.")
+	}
+
+	for _, grantType := range m.GrantTypes {
+		if !slices.Contains(registrationGrantTypes, grantType) {
+			return invalidClientMetadata("The grant type '" + grantType + "' is not supported.")
+		}
+	}
+	if m.TokenEndpointAuthMethod == authclient.TokenEndpointAuthNone && slices.Contains(m.GrantTypes, "client_credentials") {
+		return invalidClientMetadata("The public clients can't use the client_credentials grant type.")
+	}
+	for _, responseType := range m.ResponseTypes {
+		for _, part := range strings.Fields(responseType) {
+			if !slices.Contains(registrationResponseTypes, part) {
+				return invalidClientMetadata("The response type '" + responseType + "' is not supported.")
+			}
+		}
+	}
+
+	if slices.Contains(m.GrantTypes, "authorization_code") || slices.Contains(m.GrantTypes, "implicit") {
+		if len(m.RedirectURIs) == 0 {
+			return &registrationError{Code: "invalid_redirect_uri",
+				Description: "The redirect_uris are required by the authorization grant types."}
+		}
+	}
+	for _, redirectURI := range m.RedirectURIs {
+		if !isRedirectURI(redirectURI) {
+			return &registrationError{Code: "invalid_redirect_uri",
+				Description: "The redirect URI '" + redirectURI + "' must be absolute and can't contain a fragment."}
+		}
+	}
+	return m.validateKeys()
+}
+
+// validateKeys checks the keys of the `private_key_jwt` authentication
+func (m *clientMetadata) validateKeys() *registrationError {
+	if m.JWKSURI != "" && len(m.JWKS) > 0 {
+		return invalidClientMetadata("The jwks_uri and jwks can't be used together.")
+	}
+	if u, err := url.Parse(m.JWKSURI); m.JWKSURI != "" && (err != nil || u.Scheme != "https" || u.Host == "") {
+		return invalidClientMetadata("The jwks_uri must be the absolute HTTPS URL.")
+	}
+	if len(m.JWKS) > 0 {
+		if _, err := serverprovider.ParseJSONWebKeys(string(m.JWKS)); err != nil {
+			return invalidClientMetadata("The jwks is invalid: " + err.Error())
+		}
+	}
+	if m.TokenEndpointAuthMethod != authclient.TokenEndpointAuthPrivateKey {
+		return nil
+	}
+	if m.JWKSURI == "" && len(m.JWKS) == 0 {
+		return invalidClientMetadata("The private_key_jwt authentication requires jwks_uri or jwks.")
+	}
+	if m.TokenEndpointAuthSigningAlg == "" {
+		m.TokenEndpointAuthSigningAlg = serverprovider.DefaultClientAssertionAlg
+	}
+	if !slices.Contains(serverprovider.ClientAssertionAlgs, m.TokenEndpointAuthSigningAlg) {
+		return invalidClientMetadata("The token_endpoint_auth_signing_alg '" + m.TokenEndpointAuthSigningAlg + "' is not supported.")
+	}
+	return nil
+}
+
+// authClient returns the new client of the normalized metadata
+func (m *clientMetadata) authClient() *authclient.AuthClient {
+	return &authclient.AuthClient{
+		Title:                       m.ClientName,
+		RedirectURIs:                m.RedirectURIs,
+		GrantTypes:                  m.GrantTypes,
+		ResponseTypes:               m.ResponseTypes,
+		Scope:                       m.Scope,
+		SubjectType:                 m.SubjectType,
+		Public:                      m.TokenEndpointAuthMethod == authclient.TokenEndpointAuthNone,
+		TokenEndpointAuthMethod:     m.TokenEndpointAuthMethod,
+		TokenEndpointAuthSigningAlg: m.TokenEndpointAuthSigningAlg,
+		JWKSURI:                     m.JWKSURI,
+		JWKS:                        string(m.JWKS),
+		CreatedAt:                   time.Now(),
+	}
+}
+
+// isRedirectURI returns true for the absolute URI without the fragment,
+// the private schemes of the native apps don't have the host
+func isRedirectURI(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Fragment != "" || strings.Contains(rawURL, "#") {
+		return false
+	}
+	return u.Host != "" || (u.Scheme != "http" && u.Scheme != "https")
+}
@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+type testRegistrar struct {
+	token string
+}
+
+func (r *testRegistrar) RegisterClient(ctx context.Context, token string, client *authclient.AuthClient) (string, error) {
+	if token != r.token {
+		return "", authclient.ErrInitialAccessTokenInvalid
+	}
+	client.ID = "registered"
+	if client.Scope == "" {
+		client.Scope = "openid"
+	}
+	return "secret", nil
+}
+
+func TestClientMetadataNormalize(t *testing.T) {
+	meta := clientMetadata{RedirectURIs: []string{"https://app.example.com/cb"}}
+	require.Nil(t, meta.normalize())
+	assert.Equal(t, authclient.TokenEndpointAuthSecretBasic, meta.TokenEndpointAuthMethod)
+	assert.Equal(t, []string{"authorization_code"}, meta.GrantTypes)
+	assert.Equal(t, []string{"code"}, meta.ResponseTypes)
+	assert.Equal(t, authclient.SubjectTypePublic, meta.SubjectType)
+
+	tests := []struct {
+		name string
+		meta clientMetadata
+		code string
+	}{
+		{"no_redirect", clientMetadata{}, "invalid_redirect_uri"},
+		{"fragment", clientMetadata{RedirectURIs: []string{"https://app.example.com/#cb"}}, "invalid_redirect_uri"},
+		{"token_exchange", clientMetadata{GrantTypes: []string{"urn:ietf:params:oauth:grant-type:token-exchange"}}, "invalid_client_metadata"},
+		{"public_client_credentials", clientMetadata{
+			TokenEndpointAuthMethod: authclient.TokenEndpointAuthNone,
+			GrantTypes:              []string{"client_credentials"},
+		}, "invalid_client_metadata"},
+		{"private_key_without_keys", clientMetadata{
+			TokenEndpointAuthMethod: authclient.TokenEndpointAuthPrivateKey,
+			GrantTypes:              []string{"client_credentials"},
+		}, "invalid_client_metadata"},
+		{"http_jwks_uri", clientMetadata{
+			TokenEndpointAuthMethod: authclient.TokenEndpointAuthPrivateKey,
+			GrantTypes:              []string{"client_credentials"},
+			JWKSURI:                 "http://app.example.com/jwks",
+		}, "invalid_client_metadata"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.meta.normalize()
+			require.NotNil(t, err)
+			assert.Equal(t, test.code, err.Code)
+		})
+	}
+
+	native := clientMetadata{RedirectURIs: []string{"com.example.app:/callback"}, TokenEndpointAuthMethod: authclient.TokenEndpointAuthNone}
+	assert.Nil(t, native.normalize(), "the native apps use the private URI schemes")
+}
+
+func TestRegisterClient(t *testing.T) {
+	srv := &Server[*testUser, *testAccount]{options: options{registrar: &testRegistrar{token: "iat_token"}}}
+	register := func(token, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, PathRegister, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.RegisterClient(w, r)
+		return w
+	}
+	body := `{"client_name":"App","redirect_uris":["https://app.example.com/cb"]}`
+
+	assert.Equal(t, http.StatusUnauthorized, register("", body).Code)
+	assert.Equal(t, http.StatusUnauthorized, register("iat_other", body).Code)
+	assert.Equal(t, http.StatusBadRequest, register("iat_token", `{"grant_types":["password"]}`).Code)
+
+	w := register("iat_token", body)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "registered", resp["client_id"])
+	assert.Equal(t, "secret", resp["client_secret"])
+	assert.Equal(t, "openid", resp["scope"])
+	assert.Equal(t, authclient.TokenEndpointAuthSecretBasic, resp["token_endpoint_auth_method"])
+}
@@ -0,0 +1,322 @@
+// Package oidc implements the OpenID Connect endpoints on top of the OAuth2 provider.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/oauth2/serverprovider"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// Endpoints of the server
+const (
+	PathDiscovery  = "/.well-known/openid-configuration"
+	PathJWKS       = "/.well-known/jwks.json"
+	PathAuthorize  = "/oauth2/auth"
+	PathToken      = "/oauth2/token"
+	PathRevoke     = "/oauth2/revoke"
+	PathIntrospect = "/oauth2/introspect"
+	PathUserInfo   = "/userinfo"
+
+	PathDeviceAuthorize = "/oauth2/device/auth"
+	PathRegister        = "/oauth2/register"
+)
+
+type router interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// Server of the OpenID Connect provider.
+// The authorization endpoint expects the user to be authorized by the session middleware.
+type Server[TUser user.Model, TAccount account.Model] struct {
+	options
+	provider fosite.OAuth2Provider
+	accounts account.SessionRepository[TUser, TAccount]
+}
+
+// NewServer returns the OpenID Connect server of the provider,
+// the provider must be composed with the OpenID Connect strategy
+func NewServer[TUser user.Model, TAccount account.Model](
+	provider fosite.OAuth2Provider,
+	accounts account.SessionRepository[TUser, TAccount],
+	opts ...Option,
+) *Server[TUser, TAccount] {
+	srv := &Server[TUser, TAccount]{provider: provider, accounts: accounts}
+	for _, opt := range opts {
+		opt(&srv.options)
+	}
+	srv.issuer = strings.TrimSuffix(srv.issuer, "/")
+	return srv
+}
+
+// Register the endpoints in the router
+func (srv *Server[TUser, TAccount]) Register(mux router) {
+	mux.Handle(PathDiscovery, http.HandlerFunc(srv.Discovery))
+	mux.Handle(PathJWKS, http.HandlerFunc(srv.JWKS))
+	mux.Handle(PathAuthorize, http.HandlerFunc(srv.Authorize))
+	mux.Handle(PathToken, http.HandlerFunc(srv.Token))
+	mux.Handle(PathRevoke, http.HandlerFunc(srv.Revoke))
+	mux.Handle(PathIntrospect, http.HandlerFunc(srv.Introspect))
+	mux.Handle(PathUserInfo, http.HandlerFunc(srv.UserInfo))
+	if srv.devices != nil {
+		mux.Handle(PathDeviceAuthorize, http.HandlerFunc(srv.DeviceAuthorize))
+	}
+	if srv.registrar != nil {
+		mux.Handle(PathRegister, http.HandlerFunc(srv.RegisterClient))
+	}
+}
+
+// Authorize handles the authorization request of the current user.
+// Anonymous users and users which have to re-authenticate (`prompt=login`, `max_age`)
+// are redirected to the login page, `prompt=none` responds with `login_required` instead.
+// If the consent is enabled the user confirms the requested scopes on the consent page.
+func (srv *Server[TUser, TAccount]) Authorize(w http.ResponseWriter, r *http.Request) {
+	ctx := serverprovider.NewContext(r.Context())
+	ar, err := srv.provider.NewAuthorizeRequest(ctx, r)
+	if err != nil {
+		srv.provider.WriteAuthorizeError(ctx, w, ar, err)
+		return
+	}
+
+	var (
+		usr, acc = session.UserAccount(ctx)
+		form     = ar.GetRequestForm()
+		prompt   = fosite.Arguments(fosite.RemoveEmpty(strings.Split(form.Get("prompt"), " ")))
+		authTime = srv.userAuthTime(ctx)
+	)
+
+	// The login page helps only if the user can interact with it
+	// and the authentication time is known to verify `max_age` after the login
+	relogin := srv.loginURL != "" && !prompt.Has("none")
+	switch {
+	case usr == nil || usr.IsAnonymous():
+		err = fosite.ErrLoginRequired.WithHint("The user is not logged in.")
+	case prompt.Has("login") && authTime.Before(ar.GetRequestedAt()):
+		err = fosite.ErrLoginRequired.WithHint("The user has to log in again.")
+	case maxAgeExceeded(form.Get("max_age"), authTime, ar.GetRequestedAt()):
+		err = fosite.ErrLoginRequired.WithHint("The authentication is older than max_age.")
+		relogin = relogin && !authTime.IsZero()
+	}
+	if err != nil {
+		if relogin {
+			srv.redirectToLogin(w, r, form)
+		} else {
+			srv.provider.WriteAuthorizeError(ctx, w, ar, err)
+		}
+		return
+	}
+
+	if !srv.authorizeConsent(ctx, w, r, ar, usr, acc, prompt) {
+		return
+	}
+
+	resp, err := srv.provider.NewAuthorizeResponse(ctx, ar, srv.newSession(ctx, ar, usr, acc, authTime))
+	if err != nil {
+		srv.provider.WriteAuthorizeError(ctx, w, ar, err)
+		return
+	}
+	srv.provider.WriteAuthorizeResponse(ctx, w, ar, resp)
+}
+
+// Token handles the token request
+func (srv *Server[TUser, TAccount]) Token(w http.ResponseWriter, r *http.Request) {
+	ctx := serverprovider.NewContext(r.Context())
+	ar, err := srv.provider.NewAccessRequest(ctx, r, serverprovider.NewUserSession(ctx, 0, 0, ""))
+	if err != nil {
+		srv.provider.WriteAccessError(ctx, w, ar, err)
+		return
+	}
+
+	switch {
+	case ar.GetGrantTypes().ExactOne("client_credentials"):
+		// The client acts on its own behalf and receives everything it requested
+		grantRequested(ar)
+	case ar.GetGrantTypes().ExactOne(serverprovider.DeviceCodeGrantType),
+		ar.GetGrantTypes().ExactOne(serverprovider.TokenExchangeGrantType):
+		// The user approved the device request or the token of another client
+		// is exchanged outside of the authorization endpoint
+		if sess, _ := ar.GetSession().(*serverprovider.Session); sess != nil {
+			sess.Subject = serverprovider.SubjectIdentifier(
+				serverprovider.GetContextTargetClient(ctx), sess.UserID, srv.pairwiseSalt)
+		}
+	}
+
+	resp, err := srv.provider.NewAccessResponse(ctx, ar)
+	if err != nil {
+		srv.provider.WriteAccessError(ctx, w, ar, err)
+		return
+	}
+	srv.provider.WriteAccessResponse(ctx, w, ar, resp)
+}
+
+// DeviceAuthorize handles the device authorization request (RFC 8628),
+// the client receives the device code to poll the token endpoint
+// and the user code to show to the user
+func (srv *Server[TUser, TAccount]) DeviceAuthorize(w http.ResponseWriter, r *http.Request) {
+	ctx := serverprovider.NewContext(r.Context())
+	resp, err := srv.devices.NewDeviceAuthorization(ctx, r)
+	if err != nil {
+		srv.provider.WriteAccessError(ctx, w, fosite.NewAccessRequest(nil), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Revoke handles the token revocation request (RFC 7009)
+func (srv *Server[TUser, TAccount]) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := serverprovider.NewContext(r.Context())
+	err := srv.provider.NewRevocationRequest(ctx, r)
+	srv.provider.WriteRevocationResponse(ctx, w, err)
+}
+
+// Introspect handles the token introspection request of the authenticated client (RFC 7662),
+// the exchanged tokens contain the `act` claim of the delegation chain
+func (srv *Server[TUser, TAccount]) Introspect(w http.ResponseWriter, r *http.Request) {
+	ctx := serverprovider.NewContext(r.Context())
+	ir, err := srv.provider.NewIntrospectionRequest(ctx, r, serverprovider.NewUserSession(ctx, 0, 0, ""))
+	if err != nil {
+		srv.provider.WriteIntrospectionError(ctx, w, err)
+		return
+	}
+	srv.provider.WriteIntrospectionResponse(ctx, w, ir)
+}
+
+// UserInfo returns the claims of the user the access token was issued for
+func (srv *Server[TUser, TAccount]) UserInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := serverprovider.NewContext(r.Context())
+	token := fosite.AccessTokenFromRequest(r)
+	if token == "" {
+		writeBearerError(w, http.StatusUnauthorized, "invalid_request")
+		return
+	}
+
+	_, ar, err := srv.provider.IntrospectToken(ctx, token, fosite.AccessToken, serverprovider.NewUserSession(ctx, 0, 0, ""))
+	if err != nil {
+		ctxlogger.Get(ctx).Debug("userinfo token introspection", zap.Error(err))
+		writeBearerError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+	if !ar.GetGrantedScopes().Has(ScopeOpenID) {
+		writeBearerError(w, http.StatusForbidden, "insufficient_scope")
+		return
+	}
+
+	sess, _ := ar.GetSession().(*serverprovider.Session)
+	if sess == nil || sess.UserID == 0 {
+		writeBearerError(w, http.StatusForbidden, "insufficient_scope")
+		return
+	}
+
+	usr, acc, err := srv.accounts.GetByToken(ctx, sess.AccessToken)
+	if err != nil {
+		ctxlogger.Get(ctx).Error("userinfo user loading", zap.Error(err))
+		writeBearerError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	claims := UserClaims(usr, acc, ar.GetGrantedScopes())
+	claims["sub"] = sess.IDTokenClaims().Subject
+	writeJSON(w, http.StatusOK, claims)
+}
+
+func (srv *Server[TUser, TAccount]) newSession(ctx context.Context, ar fosite.AuthorizeRequester, usr user.Model, acc account.Model, authTime time.Time) *serverprovider.Session {
+	var accountID uint64
+	if acc != nil && !acc.IsAnonymous() {
+		accountID = acc.GetID()
+	}
+	var (
+		subject = serverprovider.SubjectIdentifier(serverprovider.GetContextTargetClient(ctx), usr.GetID(), srv.pairwiseSalt)
+		sess    = serverprovider.NewUserSession(ctx, usr.GetID(), accountID, subject)
+		claims  = sess.IDTokenClaims()
+	)
+	claims.Issuer = srv.issuer
+	claims.AuthTime = authTime
+	claims.RequestedAt = ar.GetRequestedAt()
+	claims.Extra = UserClaims(usr, acc, ar.GetGrantedScopes())
+	if srv.keyID != "" {
+		sess.IDTokenHeaders().Add("kid", srv.keyID)
+	}
+	if username, _ := claims.Extra["preferred_username"].(string); username != "" {
+		sess.Username = username
+	} else if email, _ := claims.Extra["email"].(string); email != "" {
+		sess.Username = email
+	}
+	return sess
+}
+
+func (srv *Server[TUser, TAccount]) userAuthTime(ctx context.Context) time.Time {
+	if srv.authTime == nil {
+		return time.Time{}
+	}
+	return srv.authTime(ctx).UTC().Truncate(time.Second)
+}
+
+// redirectToLogin sends the user to the login page which returns back
+// to the same authorization request without the `prompt=login`
+func (srv *Server[TUser, TAccount]) redirectToLogin(w http.ResponseWriter, r *http.Request, form url.Values) {
+	srv.redirectWith(w, r, srv.loginURL, "return_to", srv.authorizeURL(form, "login"))
+}
+
+// authorizeURL returns the URL of the authorization request without the prompt value
+// which is already satisfied
+func (srv *Server[TUser, TAccount]) authorizeURL(form url.Values, satisfiedPrompt string) string {
+	query := url.Values{}
+	for key, values := range form {
+		query[key] = append([]string(nil), values...)
+	}
+	prompt := fosite.RemoveEmpty(strings.Split(query.Get("prompt"), " "))
+	if rest := removeValue(prompt, satisfiedPrompt); len(rest) > 0 {
+		query.Set("prompt", strings.Join(rest, " "))
+	} else {
+		query.Del("prompt")
+	}
+	return srv.issuer + PathAuthorize + "?" + query.Encode()
+}
+
+func (srv *Server[TUser, TAccount]) internalError(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	ctxlogger.Get(r.Context()).Error(msg, zap.Error(err))
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// maxAgeExceeded returns true if the user logged in earlier than `max_age` seconds before the request
+func maxAgeExceeded(maxAgeParam string, authTime, requestedAt time.Time) bool {
+	maxAge, err := strconv.ParseInt(maxAgeParam, 10, 64)
+	if err != nil || maxAge <= 0 {
+		return false
+	}
+	return authTime.IsZero() || authTime.Add(time.Duration(maxAge)*time.Second).Before(requestedAt)
+}
+
+func removeValue(values []string, value string) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func writeBearerError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("WWW-Authenticate", `Bearer error="`+code+`"`)
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
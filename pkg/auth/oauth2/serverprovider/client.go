@@ -0,0 +1,71 @@
+package serverprovider
+
+import (
+	"encoding/json"
+	"slices"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/ory/fosite"
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// DefaultClientAssertionAlg is the signing algorithm of the client assertions
+// if the client doesn't register another one
+const DefaultClientAssertionAlg = "RS256"
+
+// ClientAssertionAlgs contains the signing algorithms of the `private_key_jwt`
+// client assertions supported by the token endpoint
+var ClientAssertionAlgs = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+}
+
+// ParseJSONWebKeys parses the inline JWKS of the client, the set has to contain
+// at least one key and all keys have to be public
+func ParseJSONWebKeys(data string) (*jose.JSONWebKeySet, error) {
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal([]byte(data), &keys); err != nil {
+		return nil, errors.Wrap(err, "parse JWKS")
+	}
+	if len(keys.Keys) == 0 {
+		return nil, errors.New("JWKS doesn't contain any key")
+	}
+	for _, key := range keys.Keys {
+		if !key.IsPublic() {
+			return nil, errors.Errorf("JWKS key %q is not public", key.KeyID)
+		}
+	}
+	return &keys, nil
+}
+
+// newOpenIDConnectClient wraps the client to enforce the token endpoint authentication method,
+// the keys of the `private_key_jwt` clients are taken from the JWKS URI or the inline JWKS
+func newOpenIDConnectClient(client *fosite.DefaultClient, clientObj *authclient.AuthClient) (fosite.Client, error) {
+	oidcClient := &fosite.DefaultOpenIDConnectClient{
+		DefaultClient:                     client,
+		JSONWebKeysURI:                    clientObj.JWKSURI,
+		TokenEndpointAuthMethod:           clientObj.TokenEndpointAuthMethod,
+		TokenEndpointAuthSigningAlgorithm: clientObj.TokenEndpointAuthSigningAlg,
+	}
+	if oidcClient.TokenEndpointAuthSigningAlgorithm == "" {
+		oidcClient.TokenEndpointAuthSigningAlgorithm = DefaultClientAssertionAlg
+	}
+	if clientObj.TokenEndpointAuthMethod != authclient.TokenEndpointAuthPrivateKey {
+		return oidcClient, nil
+	}
+	if !slices.Contains(ClientAssertionAlgs, oidcClient.TokenEndpointAuthSigningAlgorithm) {
+		return nil, fosite.ErrInvalidClient.WithHintf("The client assertion signing algorithm '%s' is not supported.",
+			oidcClient.TokenEndpointAuthSigningAlgorithm)
+	}
+	if clientObj.JWKS != "" {
+		keys, err := ParseJSONWebKeys(clientObj.JWKS)
+		if err != nil {
+			return nil, fosite.ErrInvalidClient.WithWrap(err).WithHint("The JSON Web Keys of the client are invalid.")
+		}
+		oidcClient.JSONWebKeys = keys
+	}
+	return oidcClient, nil
+}
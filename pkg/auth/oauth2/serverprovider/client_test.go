@@ -0,0 +1,145 @@
+package serverprovider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v3"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/storage"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+const testTokenURL = "https://auth.example.com/oauth2/token"
+
+// newTestAuthenticator returns the provider which authenticates the client
+// built from the AuthClient record like DatabaseStorage.GetClient does
+func newTestAuthenticator(t *testing.T, clientObj *authclient.AuthClient) *fosite.Fosite {
+	client := &fosite.DefaultClient{ID: clientObj.ID, Secret: []byte(clientObj.Secret)}
+	for _, secret := range clientObj.RotatedSecrets(time.Now()) {
+		client.RotatedSecrets = append(client.RotatedSecrets, []byte(secret))
+	}
+	var fclient fosite.Client = client
+	if clientObj.TokenEndpointAuthMethod != "" {
+		var err error
+		fclient, err = newOpenIDConnectClient(client, clientObj)
+		require.NoError(t, err)
+	}
+	store := storage.NewMemoryStore()
+	store.Clients[clientObj.ID] = fclient
+	return &fosite.Fosite{Store: store, Config: &fosite.Config{TokenURL: testTokenURL}}
+}
+
+func newClientAssertion(t *testing.T, key *rsa.PrivateKey, clientID, audience string) string {
+	token := jwt.NewWithClaims(jose.RS256, jwt.MapClaims{
+		"iss": clientID,
+		"sub": clientID,
+		"aud": audience,
+		"jti": time.Now().String(),
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	token.Header["kid"] = "key"
+	assertion, err := token.SignedString(key)
+	require.NoError(t, err)
+	return assertion
+}
+
+func TestPrivateKeyJWTClient(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &key.PublicKey, KeyID: "key", Algorithm: "RS256", Use: "sig"},
+	}})
+	require.NoError(t, err)
+
+	provider := newTestAuthenticator(t, &authclient.AuthClient{
+		ID:                      "service",
+		TokenEndpointAuthMethod: authclient.TokenEndpointAuthPrivateKey,
+		JWKS:                    string(jwks),
+	})
+	authenticate := func(form url.Values) error {
+		_, err := provider.AuthenticateClient(context.Background(), httptest.NewRequest(http.MethodPost, testTokenURL, nil), form)
+		return err
+	}
+	assertionForm := func(assertion string) url.Values {
+		return url.Values{
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"client_assertion":      {assertion},
+		}
+	}
+
+	t.Run("assertion", func(t *testing.T) {
+		assert.NoError(t, authenticate(assertionForm(newClientAssertion(t, key, "service", testTokenURL))))
+	})
+
+	t.Run("wrong_audience", func(t *testing.T) {
+		err := authenticate(assertionForm(newClientAssertion(t, key, "service", "https://other.example.com")))
+		assert.ErrorIs(t, err, fosite.ErrInvalidClient)
+	})
+
+	t.Run("other_key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		assert.Error(t, authenticate(assertionForm(newClientAssertion(t, otherKey, "service", testTokenURL))))
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		err := authenticate(url.Values{"client_id": {"service"}, "client_secret": {"secret"}})
+		assert.ErrorIs(t, err, fosite.ErrInvalidClient, "the secret isn't accepted from the private key client")
+	})
+}
+
+func TestRotatedSecretClient(t *testing.T) {
+	hash := func(secret string) string {
+		data, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+		require.NoError(t, err)
+		return string(data)
+	}
+	clientObj := &authclient.AuthClient{
+		ID:                      "service",
+		Secret:                  hash("new"),
+		PreviousSecret:          hash("old"),
+		PreviousSecretExpiresAt: sql.Null[time.Time]{V: time.Now().Add(time.Hour), Valid: true},
+	}
+	authenticate := func(secret string) error {
+		provider := newTestAuthenticator(t, clientObj)
+		r := httptest.NewRequest(http.MethodPost, testTokenURL, nil)
+		r.SetBasicAuth("service", secret)
+		_, err := provider.AuthenticateClient(context.Background(), r, url.Values{})
+		return err
+	}
+
+	assert.NoError(t, authenticate("new"))
+	assert.NoError(t, authenticate("old"), "the previous secret works during the grace period")
+	assert.Error(t, authenticate("other"))
+
+	clientObj.PreviousSecretExpiresAt.V = time.Now().Add(-time.Second)
+	assert.Error(t, authenticate("old"), "the previous secret expired")
+	assert.NoError(t, authenticate("new"))
+}
+
+func TestParseJSONWebKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	private, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key, KeyID: "key"}}})
+	require.NoError(t, err)
+	_, err = ParseJSONWebKeys(string(private))
+	assert.Error(t, err, "the private keys can't be registered")
+
+	_, err = ParseJSONWebKeys(`{"keys":[]}`)
+	assert.Error(t, err)
+}
@@ -58,6 +58,14 @@ func GetContextSession(ctx context.Context) *authclient.AuthSession {
 	return getContextTarget(ctx).sessionObj
 }
 
+// lookupContextTargetClient returns auth client object if the context is initialized by NewContext
+func lookupContextTargetClient(ctx context.Context) *authclient.AuthClient {
+	if tr, _ := ctx.Value(ctxTarget).(*target); tr != nil {
+		return tr.clientObj
+	}
+	return nil
+}
+
 func getContextTarget(ctx context.Context) *target {
 	tr := ctx.Value(ctxTarget)
 	switch v := tr.(type) {
@@ -0,0 +1,377 @@
+package serverprovider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// DeviceCodeGrantType of the token request (RFC 8628)
+const DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+const (
+	defaultDeviceCodeLifespan = 10 * time.Minute
+	defaultDevicePollInterval = 5 * time.Second
+
+	// slowDownInterval is added to the poll interval every time the client polls too fast
+	slowDownInterval = 5 * time.Second
+
+	// userCodeAlphabet has no vowels to avoid the words
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+)
+
+// Errors of the device access token request (RFC 8628, section 3.5)
+var (
+	ErrAuthorizationPending = &fosite.RFC6749Error{
+		ErrorField:       "authorization_pending",
+		DescriptionField: "The authorization request is still pending as the end user hasn't yet completed the user-interaction steps.",
+		CodeField:        http.StatusBadRequest,
+	}
+	ErrSlowDown = &fosite.RFC6749Error{
+		ErrorField:       "slow_down",
+		DescriptionField: "The authorization request is still pending and polling should continue, but the interval must be increased by 5 seconds.",
+		CodeField:        http.StatusBadRequest,
+	}
+	ErrExpiredToken = &fosite.RFC6749Error{
+		ErrorField:       "expired_token",
+		DescriptionField: "The device code has expired, the client has to start a new device authorization request.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	errDeviceCodeCollision = errors.New("device or user code already exists")
+)
+
+// Form parameters which are not stored with the device request
+var deviceSecretParams = []string{"client_secret", "client_assertion"}
+
+type clientAuthenticator interface {
+	AuthenticateClient(ctx context.Context, r *http.Request, form url.Values) (fosite.Client, error)
+}
+
+type deviceStorage interface {
+	createDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error
+	getDeviceRequest(ctx context.Context, signature string) (*authclient.AuthDeviceRequest, error)
+	pollDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error
+	useDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error
+}
+
+// DeviceConfig of the device authorization endpoint
+type DeviceConfig struct {
+	// VerificationURI of the page where the user enters the user code
+	VerificationURI string
+
+	// CodeLifespan of the device and user codes, 10 minutes by default
+	CodeLifespan time.Duration
+
+	// PollInterval is the minimal time between the token requests, 5 seconds by default
+	PollInterval time.Duration
+}
+
+// DeviceAuthorization is the response of the device authorization endpoint (RFC 8628, section 3.2)
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+// DeviceAuthorizer issues the device and user codes of the device authorization grant.
+// The client polls the token endpoint with the device code while the user
+// enters the user code on the verification page and approves the request.
+type DeviceAuthorizer struct {
+	provider fosite.OAuth2Provider
+	config   fosite.Configurator
+	store    deviceStorage
+	conf     DeviceConfig
+}
+
+// NewDeviceAuthorizer returns the device authorization endpoint of the provider,
+// the scopes and the audience are checked by the strategies of the provider configuration
+func NewDeviceAuthorizer(provider fosite.OAuth2Provider, store *DatabaseStorage, conf DeviceConfig) *DeviceAuthorizer {
+	var config fosite.Configurator = &fosite.Config{}
+	if f, _ := provider.(*fosite.Fosite); f != nil && f.Config != nil {
+		config = f.Config
+	}
+	if conf.CodeLifespan <= 0 {
+		conf.CodeLifespan = defaultDeviceCodeLifespan
+	}
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = defaultDevicePollInterval
+	}
+	return &DeviceAuthorizer{provider: provider, config: config, store: store, conf: conf}
+}
+
+// NewDeviceAuthorization authenticates the client and issues the new device and user codes.
+// The context must be initialized by NewContext.
+func (d *DeviceAuthorizer) NewDeviceAuthorization(ctx context.Context, r *http.Request) (*DeviceAuthorization, error) {
+	if r.Method != http.MethodPost {
+		return nil, fosite.ErrInvalidRequest.WithHintf("HTTP method is '%s', expected 'POST'.", r.Method)
+	}
+	if err := r.ParseMultipartForm(1 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, fosite.ErrInvalidRequest.WithHint("Unable to parse HTTP body, make sure to send a properly formatted form request body.").WithWrap(err)
+	}
+	authenticator, _ := d.provider.(clientAuthenticator)
+	if authenticator == nil {
+		return nil, fosite.ErrServerError.WithDebug("The provider doesn't support the client authentication.")
+	}
+	client, err := authenticator.AuthenticateClient(ctx, r, r.PostForm)
+	if err != nil {
+		return nil, err
+	}
+	if !client.GetGrantTypes().Has(DeviceCodeGrantType) {
+		return nil, fosite.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use authorization grant '%s'.", DeviceCodeGrantType)
+	}
+
+	var (
+		scopes   = fosite.Arguments(fosite.RemoveEmpty(strings.Split(r.PostForm.Get("scope"), " ")))
+		audience = fosite.Arguments(fosite.GetAudiences(r.PostForm))
+	)
+	for _, scope := range scopes {
+		if !d.config.GetScopeStrategy(ctx)(client.GetScopes(), scope) {
+			return nil, fosite.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request scope '%s'.", scope)
+		}
+	}
+	if err = d.config.GetAudienceStrategy(ctx)(client.GetAudience(), audience); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	for key, values := range r.PostForm {
+		form[key] = values
+	}
+	for _, param := range deviceSecretParams {
+		form.Del(param)
+	}
+
+	// The codes are random, so the collision is almost impossible but it's cheap to retry
+	for range 3 {
+		deviceCode, err := newDeviceCode()
+		if err != nil {
+			return nil, fosite.ErrServerError.WithWrap(err)
+		}
+		userCode, err := newUserCode()
+		if err != nil {
+			return nil, fosite.ErrServerError.WithWrap(err)
+		}
+		req := &authclient.AuthDeviceRequest{
+			DeviceCode:        codeHash(deviceCode),
+			UserCode:          userCode,
+			RequestID:         fosite.NewRequest().GetID(),
+			ClientID:          client.GetID(),
+			Form:              form.Encode(),
+			RequestedScope:    gosql.NullableStringArray(scopes),
+			RequestedAudience: gosql.NullableStringArray(audience),
+			PollInterval:      int(d.conf.PollInterval / time.Second),
+			ExpiresAt:         time.Now().Add(d.conf.CodeLifespan),
+		}
+		err = d.store.createDeviceRequest(ctx, req)
+		if errors.Is(err, errDeviceCodeCollision) {
+			continue
+		}
+		if err != nil {
+			return nil, fosite.ErrServerError.WithWrap(err)
+		}
+		displayCode := authclient.FormatUserCode(userCode)
+		return &DeviceAuthorization{
+			DeviceCode:              deviceCode,
+			UserCode:                displayCode,
+			VerificationURI:         d.conf.VerificationURI,
+			VerificationURIComplete: verificationURIComplete(d.conf.VerificationURI, displayCode),
+			ExpiresIn:               int64(d.conf.CodeLifespan / time.Second),
+			Interval:                int64(req.PollInterval),
+		}, nil
+	}
+	return nil, fosite.ErrServerError.WithWrap(errDeviceCodeCollision)
+}
+
+// deviceHandler exchanges the approved device code for the access token.
+// The session subject is left empty, it depends on the subject type of the client
+// and has to be set by the caller before the response is created.
+type deviceHandler struct {
+	*oauth2.HandleHelper
+	store           deviceStorage
+	refreshStrategy oauth2.RefreshTokenStrategy
+	refreshStorage  oauth2.RefreshTokenStorage
+	config          fosite.Configurator
+}
+
+// HandleTokenEndpointRequest checks the state of the device request
+// and prepares the token request of the approved one
+func (h *deviceHandler) HandleTokenEndpointRequest(ctx context.Context, requester fosite.AccessRequester) error {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return errors.WithStack(fosite.ErrUnknownRequest)
+	}
+	client := requester.GetClient()
+	if !client.GetGrantTypes().Has(DeviceCodeGrantType) {
+		return errors.WithStack(fosite.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use authorization grant '%s'.", DeviceCodeGrantType))
+	}
+	deviceCode := requester.GetRequestForm().Get("device_code")
+	if deviceCode == "" {
+		return errors.WithStack(fosite.ErrInvalidRequest.WithHint("The 'device_code' parameter is missing."))
+	}
+
+	req, err := h.store.getDeviceRequest(ctx, codeHash(deviceCode))
+	if errors.Is(err, fosite.ErrNotFound) || (err == nil && req.ClientID != client.GetID()) {
+		return errors.WithStack(fosite.ErrInvalidGrant.WithHint("The device code is unknown."))
+	}
+	if err != nil {
+		return errors.WithStack(fosite.ErrServerError.WithWrap(err))
+	}
+
+	now := time.Now()
+	if req.IsExpired(now) {
+		return errors.WithStack(ErrExpiredToken)
+	}
+	switch req.Status {
+	case authclient.DeviceStatusDenied:
+		return errors.WithStack(fosite.ErrAccessDenied.WithHint("The user denied the authorization request."))
+	case authclient.DeviceStatusUsed:
+		return errors.WithStack(fosite.ErrInvalidGrant.WithHint("The device code has already been used."))
+	case authclient.DeviceStatusPending:
+		tooFast := req.IsPolledTooFast(now)
+		if tooFast {
+			req.PollInterval += int(slowDownInterval / time.Second)
+		}
+		req.LastPolledAt.V, req.LastPolledAt.Valid = now, true
+		if err = h.store.pollDeviceRequest(ctx, req); err != nil {
+			return errors.WithStack(fosite.ErrServerError.WithWrap(err))
+		}
+		if tooFast {
+			return errors.WithStack(ErrSlowDown)
+		}
+		return errors.WithStack(ErrAuthorizationPending)
+	}
+
+	if err = h.store.useDeviceRequest(ctx, req); errors.Is(err, fosite.ErrNotFound) {
+		return errors.WithStack(fosite.ErrInvalidGrant.WithHint("The device code has already been used."))
+	} else if err != nil {
+		return errors.WithStack(fosite.ErrServerError.WithWrap(err))
+	}
+
+	session, _ := requester.GetSession().(*Session)
+	if session == nil {
+		session = NewUserSession(ctx, 0, 0, "")
+		requester.SetSession(session)
+	}
+	session.UserID, session.AccountID = req.UserID, req.AccountID
+	session.SetExpiresAt(fosite.AccessToken,
+		now.UTC().Add(h.config.GetAccessTokenLifespan(ctx)).Round(time.Second))
+	if h.canIssueRefreshToken(ctx, req) {
+		if lifespan := h.config.GetRefreshTokenLifespan(ctx); lifespan > 0 {
+			session.SetExpiresAt(fosite.RefreshToken, now.UTC().Add(lifespan).Round(time.Second))
+		}
+	}
+
+	requester.SetID(req.RequestID)
+	requester.SetRequestedScopes(fosite.Arguments(req.RequestedScope))
+	requester.SetRequestedAudience(fosite.Arguments(req.RequestedAudience))
+	for _, scope := range req.GrantedScope {
+		requester.GrantScope(scope)
+	}
+	for _, audience := range req.GrantedAudience {
+		requester.GrantAudience(audience)
+	}
+	return nil
+}
+
+// PopulateTokenEndpointResponse issues the access token and the refresh token
+// if the offline access is granted
+func (h *deviceHandler) PopulateTokenEndpointResponse(ctx context.Context, requester fosite.AccessRequester, responder fosite.AccessResponder) error {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return errors.WithStack(fosite.ErrUnknownRequest)
+	}
+	accessSignature, err := h.IssueAccessToken(ctx, h.config.GetAccessTokenLifespan(ctx), requester, responder)
+	if err != nil {
+		return errors.WithStack(fosite.ErrServerError.WithWrap(err))
+	}
+	if requester.GetSession().GetExpiresAt(fosite.RefreshToken).IsZero() {
+		return nil
+	}
+	refresh, refreshSignature, err := h.refreshStrategy.GenerateRefreshToken(ctx, requester)
+	if err != nil {
+		return errors.WithStack(fosite.ErrServerError.WithWrap(err))
+	}
+	if err = h.refreshStorage.CreateRefreshTokenSession(ctx, refreshSignature, accessSignature, requester.Sanitize([]string{})); err != nil {
+		return errors.WithStack(fosite.ErrServerError.WithWrap(err))
+	}
+	responder.SetExtra("refresh_token", refresh)
+	return nil
+}
+
+// CanSkipClientAuth returns false, the public clients are authenticated by the client ID
+func (h *deviceHandler) CanSkipClientAuth(context.Context, fosite.AccessRequester) bool {
+	return false
+}
+
+// CanHandleTokenEndpointRequest returns true for the device code grant type
+func (h *deviceHandler) CanHandleTokenEndpointRequest(_ context.Context, requester fosite.AccessRequester) bool {
+	return requester.GetGrantTypes().ExactOne(DeviceCodeGrantType)
+}
+
+func (h *deviceHandler) canIssueRefreshToken(ctx context.Context, req *authclient.AuthDeviceRequest) bool {
+	scopes := h.config.GetRefreshTokenScopes(ctx)
+	return len(scopes) == 0 || fosite.Arguments(req.GrantedScope).HasOneOf(scopes...)
+}
+
+// deviceFactory creates the token handler of the device authorization grant
+func deviceFactory(config fosite.Configurator, storage, strategy any) any {
+	return &deviceHandler{
+		HandleHelper: &oauth2.HandleHelper{
+			AccessTokenStrategy: strategy.(oauth2.AccessTokenStrategy),
+			AccessTokenStorage:  storage.(oauth2.AccessTokenStorage),
+			Config:              config,
+		},
+		store:           storage.(deviceStorage),
+		refreshStrategy: strategy.(oauth2.RefreshTokenStrategy),
+		refreshStorage:  storage.(oauth2.RefreshTokenStorage),
+		config:          config,
+	}
+}
+
+func verificationURIComplete(verificationURI, userCode string) string {
+	if verificationURI == "" {
+		return ""
+	}
+	sep := "?"
+	if strings.Contains(verificationURI, "?") {
+		sep = "&"
+	}
+	return verificationURI + sep + "user_code=" + url.QueryEscape(userCode)
+}
+
+func newDeviceCode() (string, error) {
+	code := make([]byte, 32)
+	if _, err := rand.Read(code); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(code), nil
+}
+
+func newUserCode() (string, error) {
+	var (
+		code = make([]byte, authclient.UserCodeLength)
+		max  = big.NewInt(int64(len(userCodeAlphabet)))
+	)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,199 @@
+package serverprovider
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+type testDeviceStorage struct {
+	requests map[string]*authclient.AuthDeviceRequest
+}
+
+func (s *testDeviceStorage) createDeviceRequest(_ context.Context, req *authclient.AuthDeviceRequest) error {
+	req.Status = authclient.DeviceStatusPending
+	s.requests[req.DeviceCode] = req
+	return nil
+}
+
+func (s *testDeviceStorage) getDeviceRequest(_ context.Context, signature string) (*authclient.AuthDeviceRequest, error) {
+	if req := s.requests[signature]; req != nil {
+		copyReq := *req
+		return &copyReq, nil
+	}
+	return nil, fosite.ErrNotFound
+}
+
+func (s *testDeviceStorage) pollDeviceRequest(_ context.Context, req *authclient.AuthDeviceRequest) error {
+	s.requests[req.DeviceCode].LastPolledAt = req.LastPolledAt
+	s.requests[req.DeviceCode].PollInterval = req.PollInterval
+	return nil
+}
+
+func (s *testDeviceStorage) useDeviceRequest(_ context.Context, req *authclient.AuthDeviceRequest) error {
+	if s.requests[req.DeviceCode].Status != authclient.DeviceStatusApproved {
+		return fosite.ErrNotFound
+	}
+	s.requests[req.DeviceCode].Status = authclient.DeviceStatusUsed
+	return nil
+}
+
+type testClientAuthenticator struct {
+	fosite.OAuth2Provider
+	client fosite.Client
+}
+
+func (a testClientAuthenticator) AuthenticateClient(context.Context, *http.Request, url.Values) (fosite.Client, error) {
+	return a.client, nil
+}
+
+func newDeviceTokenRequest(client fosite.Client, deviceCode string) *fosite.AccessRequest {
+	ar := fosite.NewAccessRequest(NewUserSession(context.Background(), 0, 0, ""))
+	ar.GrantTypes = fosite.Arguments{DeviceCodeGrantType}
+	ar.Client = client
+	ar.Form = url.Values{"device_code": {deviceCode}}
+	return ar
+}
+
+func TestDeviceAuthorization(t *testing.T) {
+	var (
+		ctx    = NewContext(context.Background())
+		store  = &testDeviceStorage{requests: map[string]*authclient.AuthDeviceRequest{}}
+		client = &fosite.DefaultClient{ID: "cli", Public: true, Scopes: []string{"openid", "offline"},
+			GrantTypes: []string{DeviceCodeGrantType}}
+		authorizer = &DeviceAuthorizer{
+			provider: testClientAuthenticator{client: client},
+			config:   &fosite.Config{},
+			store:    store,
+			conf: DeviceConfig{
+				VerificationURI: "https://auth.example.com/device",
+				CodeLifespan:    time.Minute,
+				PollInterval:    5 * time.Second,
+			},
+		}
+	)
+
+	newRequest := func(form url.Values) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/oauth2/device/auth", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+
+	t.Run("not_allowed_scope", func(t *testing.T) {
+		_, err := authorizer.NewDeviceAuthorization(ctx, newRequest(url.Values{"client_id": {"cli"}, "scope": {"admin"}}))
+		assert.ErrorIs(t, err, fosite.ErrInvalidScope)
+	})
+
+	t.Run("issue_codes", func(t *testing.T) {
+		resp, err := authorizer.NewDeviceAuthorization(ctx, newRequest(url.Values{"client_id": {"cli"}, "scope": {"openid offline"}}))
+		require.NoError(t, err)
+		assert.Len(t, resp.UserCode, authclient.UserCodeLength+1)
+		assert.Equal(t, "https://auth.example.com/device?user_code="+resp.UserCode, resp.VerificationURIComplete)
+		assert.Equal(t, int64(60), resp.ExpiresIn)
+		assert.Equal(t, int64(5), resp.Interval)
+
+		req := store.requests[codeHash(resp.DeviceCode)]
+		require.NotNil(t, req, "the device code has to be stored by the signature")
+		assert.Equal(t, authclient.NormalizeUserCode(resp.UserCode), req.UserCode)
+		assert.Equal(t, []string{"openid", "offline"}, []string(req.RequestedScope))
+	})
+
+	t.Run("not_allowed_grant", func(t *testing.T) {
+		authorizer.provider = testClientAuthenticator{client: &fosite.DefaultClient{ID: "web"}}
+		_, err := authorizer.NewDeviceAuthorization(ctx, newRequest(url.Values{"client_id": {"web"}}))
+		assert.ErrorIs(t, err, fosite.ErrUnauthorizedClient)
+	})
+}
+
+func TestDeviceTokenPolling(t *testing.T) {
+	var (
+		ctx     = NewContext(context.Background())
+		client  = &fosite.DefaultClient{ID: "cli", Public: true, GrantTypes: []string{DeviceCodeGrantType}}
+		store   = &testDeviceStorage{requests: map[string]*authclient.AuthDeviceRequest{}}
+		handler = &deviceHandler{store: store, config: &fosite.Config{AccessTokenLifespan: time.Hour}}
+		req     = &authclient.AuthDeviceRequest{
+			DeviceCode:     codeHash("device"),
+			Status:         authclient.DeviceStatusPending,
+			RequestID:      "request",
+			ClientID:       "cli",
+			RequestedScope: []string{"openid"},
+			PollInterval:   5,
+			ExpiresAt:      time.Now().Add(time.Minute),
+		}
+	)
+	store.requests[req.DeviceCode] = req
+
+	t.Run("unknown_code", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newDeviceTokenRequest(client, "unknown"))
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant)
+	})
+
+	t.Run("other_client", func(t *testing.T) {
+		other := &fosite.DefaultClient{ID: "other", GrantTypes: []string{DeviceCodeGrantType}}
+		err := handler.HandleTokenEndpointRequest(ctx, newDeviceTokenRequest(other, "device"))
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newDeviceTokenRequest(client, "device"))
+		assert.ErrorIs(t, err, ErrAuthorizationPending)
+		assert.True(t, req.LastPolledAt.Valid)
+	})
+
+	t.Run("slow_down", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newDeviceTokenRequest(client, "device"))
+		assert.ErrorIs(t, err, ErrSlowDown)
+		assert.Equal(t, 10, req.PollInterval, "the interval has to be increased by 5 seconds")
+	})
+
+	t.Run("approved", func(t *testing.T) {
+		req.Status = authclient.DeviceStatusApproved
+		req.UserID, req.AccountID = 7, 3
+		req.GrantedScope = []string{"openid"}
+		req.LastPolledAt = sql.Null[time.Time]{}
+
+		ar := newDeviceTokenRequest(client, "device")
+		require.NoError(t, handler.HandleTokenEndpointRequest(ctx, ar))
+		assert.Equal(t, "request", ar.GetID())
+		assert.Equal(t, fosite.Arguments{"openid"}, ar.GetGrantedScopes())
+
+		sess := ar.GetSession().(*Session)
+		assert.Equal(t, uint64(7), sess.UserID)
+		assert.Equal(t, uint64(3), sess.AccountID)
+		assert.False(t, sess.GetExpiresAt(fosite.AccessToken).IsZero())
+		assert.True(t, sess.GetExpiresAt(fosite.RefreshToken).IsZero(), "the offline access wasn't granted")
+		assert.Equal(t, authclient.DeviceStatusUsed, req.Status)
+	})
+
+	t.Run("used", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newDeviceTokenRequest(client, "device"))
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		req.ExpiresAt = time.Now().Add(-time.Second)
+		err := handler.HandleTokenEndpointRequest(ctx, newDeviceTokenRequest(client, "device"))
+		assert.ErrorIs(t, err, ErrExpiredToken)
+	})
+}
+
+func TestUserCode(t *testing.T) {
+	code, err := newUserCode()
+	require.NoError(t, err)
+	assert.Len(t, code, authclient.UserCodeLength)
+	assert.Empty(t, strings.Trim(code, userCodeAlphabet))
+
+	assert.Equal(t, "BCDF-GHJK", authclient.FormatUserCode("BCDFGHJK"))
+	assert.Equal(t, "BCDFGHJK", authclient.NormalizeUserCode(" bcdf-ghjk "))
+}
@@ -0,0 +1,260 @@
+package serverprovider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+)
+
+// TokenExchangeGrantType of the token request (RFC 8693)
+const TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+// Token types of the token exchange
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// HistoryActionTokenExchange is the name of the history log action of the token exchange
+const HistoryActionTokenExchange = "oauth2.token_exchange"
+
+// ErrInvalidTarget is returned if the requested audience isn't allowed to the client (RFC 8693, section 2.2.2)
+var ErrInvalidTarget = &fosite.RFC6749Error{
+	ErrorField:       "invalid_target",
+	DescriptionField: "The authorization server is unwilling or unable to issue a token for the indicated target.",
+	CodeField:        http.StatusBadRequest,
+}
+
+type jwtParser interface {
+	ParseToken(raw string) (*jwt.TokenData, error)
+}
+
+type historyWriter func(ctx context.Context, name, objectType string, objectID any, data map[string]any) error
+
+// subjectToken is the resolved subject of the exchange
+type subjectToken struct {
+	userID    uint64
+	accountID uint64
+	username  string
+	scopes    fosite.Arguments
+	actor     map[string]any
+	expiresAt time.Time
+}
+
+// tokenExchangeHandler issues the downscoped access token for the target audience
+// on behalf of the user of the subject token. The client becomes the actor
+// of the new token (the `act` claim) and every exchange is written to the history log.
+// The session subject is left empty like in the device grant.
+type tokenExchangeHandler struct {
+	*oauth2.HandleHelper
+	jwt          jwtParser
+	writeHistory historyWriter
+	config       fosite.Configurator
+}
+
+// TokenExchangeFactory enables the token exchange grant (RFC 8693),
+// the subject token is the access token of the provider or the session JWT
+// of the jwt provider (the JWT tokens are not accepted if it's nil)
+func TokenExchangeFactory(jwtProvider *jwt.Provider) compose.Factory {
+	return func(config fosite.Configurator, storage, strategy any) any {
+		handler := &tokenExchangeHandler{
+			HandleHelper: &oauth2.HandleHelper{
+				AccessTokenStrategy: strategy.(oauth2.AccessTokenStrategy),
+				AccessTokenStorage:  storage.(oauth2.AccessTokenStorage),
+				Config:              config,
+			},
+			writeHistory: historylog.Write,
+			config:       config,
+		}
+		if jwtProvider != nil {
+			handler.jwt = jwtProvider
+		}
+		return handler
+	}
+}
+
+// HandleTokenEndpointRequest validates the subject token and prepares the delegated session
+func (h *tokenExchangeHandler) HandleTokenEndpointRequest(ctx context.Context, requester fosite.AccessRequester) error {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return errors.WithStack(fosite.ErrUnknownRequest)
+	}
+	client := requester.GetClient()
+	if !client.GetGrantTypes().Has(TokenExchangeGrantType) {
+		return errors.WithStack(fosite.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use authorization grant '%s'.", TokenExchangeGrantType))
+	}
+
+	form := requester.GetRequestForm()
+	if tokenType := form.Get("requested_token_type"); tokenType != "" && tokenType != TokenTypeAccessToken {
+		return errors.WithStack(fosite.ErrInvalidRequest.WithHintf("The requested token type '%s' is not supported.", tokenType))
+	}
+	if form.Get("actor_token") != "" {
+		return errors.WithStack(fosite.ErrInvalidRequest.WithHint("The 'actor_token' is not supported, the client acts on behalf of the subject."))
+	}
+	if form.Get("subject_token") == "" || form.Get("subject_token_type") == "" {
+		return errors.WithStack(fosite.ErrInvalidRequest.WithHint("The 'subject_token' and 'subject_token_type' parameters are required."))
+	}
+
+	subject, err := h.subjectToken(ctx, client, form.Get("subject_token"), form.Get("subject_token_type"))
+	if err != nil {
+		return err
+	}
+
+	// The audience is mandatory, the exchanged token is issued for the specific service
+	audience := requester.GetRequestedAudience()
+	if len(audience) == 0 {
+		return errors.WithStack(fosite.ErrInvalidRequest.WithHint("The 'audience' parameter is required."))
+	}
+	if err = h.config.GetAudienceStrategy(ctx)(client.GetAudience(), audience); err != nil {
+		return errors.WithStack(ErrInvalidTarget.WithWrap(err).WithHint("The OAuth 2.0 Client is not allowed to request the audience."))
+	}
+
+	// The scopes can only be narrowed: they must be allowed to the client
+	// and granted to the subject token if it's the access token
+	scopes := requester.GetRequestedScopes()
+	if len(scopes) == 0 {
+		scopes = subject.scopes
+	}
+	scopeStrategy := h.config.GetScopeStrategy(ctx)
+	for _, scope := range scopes {
+		if !scopeStrategy(client.GetScopes(), scope) {
+			return errors.WithStack(fosite.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request scope '%s'.", scope))
+		}
+		if subject.scopes != nil && !scopeStrategy(subject.scopes, scope) {
+			return errors.WithStack(fosite.ErrInvalidScope.WithHintf("The scope '%s' is not granted to the subject token.", scope))
+		}
+	}
+
+	session, _ := requester.GetSession().(*Session)
+	if session == nil {
+		session = NewUserSession(ctx, 0, 0, "")
+		requester.SetSession(session)
+	}
+	session.UserID, session.AccountID = subject.userID, subject.accountID
+	session.Username = subject.username
+	session.Actor = map[string]any{"sub": client.GetID()}
+	if subject.actor != nil {
+		session.Actor["act"] = subject.actor
+	}
+
+	// The delegated token doesn't live longer than the subject token
+	expiresAt := time.Now().UTC().Add(h.config.GetAccessTokenLifespan(ctx)).Round(time.Second)
+	if !subject.expiresAt.IsZero() && subject.expiresAt.Before(expiresAt) {
+		expiresAt = subject.expiresAt
+	}
+	session.SetExpiresAt(fosite.AccessToken, expiresAt)
+
+	requester.SetRequestedScopes(scopes)
+	for _, scope := range scopes {
+		requester.GrantScope(scope)
+	}
+	for _, aud := range audience {
+		requester.GrantAudience(aud)
+	}
+	return nil
+}
+
+// PopulateTokenEndpointResponse records the exchange in the history log and issues the access token
+func (h *tokenExchangeHandler) PopulateTokenEndpointResponse(ctx context.Context, requester fosite.AccessRequester, responder fosite.AccessResponder) error {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return errors.WithStack(fosite.ErrUnknownRequest)
+	}
+	session, _ := requester.GetSession().(*Session)
+	if session == nil {
+		return errors.WithStack(fosite.ErrServerError.WithDebug("The session of the token exchange is not prepared."))
+	}
+	err := h.writeHistory(ctx, HistoryActionTokenExchange, `auth_client`, requester.GetClient().GetID(), map[string]any{
+		"request_id":         requester.GetID(),
+		"user_id":            session.UserID,
+		"account_id":         session.AccountID,
+		"act":                session.Actor,
+		"audience":           []string(requester.GetGrantedAudience()),
+		"scope":              []string(requester.GetGrantedScopes()),
+		"subject_token_type": requester.GetRequestForm().Get("subject_token_type"),
+	})
+	if err != nil {
+		return errors.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug("The token exchange can't be recorded."))
+	}
+	_, err = h.IssueAccessToken(ctx, h.config.GetAccessTokenLifespan(ctx), requester, responder)
+	if err != nil {
+		return errors.WithStack(fosite.ErrServerError.WithWrap(err))
+	}
+	responder.SetExtra("issued_token_type", TokenTypeAccessToken)
+	return nil
+}
+
+// CanSkipClientAuth returns false, the actor has to be authenticated
+func (h *tokenExchangeHandler) CanSkipClientAuth(context.Context, fosite.AccessRequester) bool {
+	return false
+}
+
+// CanHandleTokenEndpointRequest returns true for the token exchange grant type
+func (h *tokenExchangeHandler) CanHandleTokenEndpointRequest(_ context.Context, requester fosite.AccessRequester) bool {
+	return requester.GetGrantTypes().ExactOne(TokenExchangeGrantType)
+}
+
+// subjectToken returns the user the subject token was issued to.
+// The access token has to be issued to the client or for its audience,
+// the session JWT isn't issued to any client, so only the clients of its account can exchange it.
+func (h *tokenExchangeHandler) subjectToken(ctx context.Context, client fosite.Client, token, tokenType string) (*subjectToken, error) {
+	actorClient := lookupContextTargetClient(ctx)
+	switch tokenType {
+	case TokenTypeAccessToken:
+		// Loading of the subject session replaces the client of the context
+		if actorClient != nil {
+			defer SetContextTargetClient(ctx, actorClient)
+		}
+
+		signature := h.AccessTokenStrategy.AccessTokenSignature(ctx, token)
+		req, err := h.AccessTokenStorage.GetAccessTokenSession(ctx, signature, NewUserSession(ctx, 0, 0, ""))
+		if err == nil {
+			err = h.AccessTokenStrategy.ValidateAccessToken(ctx, req, token)
+		}
+		if err != nil {
+			return nil, errors.WithStack(fosite.ErrInvalidGrant.WithWrap(err).WithHint("The subject token is invalid or expired."))
+		}
+		sess, _ := req.GetSession().(*Session)
+		if sess == nil || sess.UserID == 0 {
+			return nil, errors.WithStack(fosite.ErrInvalidGrant.WithHint("The subject token is not issued on behalf of the user."))
+		}
+		if req.GetClient().GetID() != client.GetID() && !req.GetGrantedAudience().Has(client.GetID()) {
+			return nil, errors.WithStack(fosite.ErrInvalidGrant.WithHint("The subject token is not issued to the client."))
+		}
+		return &subjectToken{
+			userID:    sess.UserID,
+			accountID: sess.AccountID,
+			username:  sess.Username,
+			scopes:    append(fosite.Arguments{}, req.GetGrantedScopes()...),
+			actor:     sess.Actor,
+			expiresAt: sess.GetExpiresAt(fosite.AccessToken),
+		}, nil
+	case TokenTypeJWT:
+		if h.jwt == nil {
+			break
+		}
+		data, err := h.jwt.ParseToken(token)
+		if err != nil {
+			return nil, errors.WithStack(fosite.ErrInvalidGrant.WithWrap(err).WithHint("The subject token is invalid or expired."))
+		}
+		// The impersonation session can't become the regular token of the user
+		if data.ActorID != 0 {
+			return nil, errors.WithStack(fosite.ErrInvalidGrant.WithHint("The impersonation token can't be exchanged."))
+		}
+		if actorClient == nil || actorClient.AccountID == 0 || actorClient.AccountID != data.AccountID {
+			return nil, errors.WithStack(fosite.ErrInvalidGrant.WithHint("The subject token is not issued in the account of the client."))
+		}
+		return &subjectToken{
+			userID:    data.UserID,
+			accountID: data.AccountID,
+			expiresAt: time.Unix(data.ExpireAt, 0).UTC(),
+		}, nil
+	}
+	return nil, errors.WithStack(fosite.ErrInvalidRequest.WithHintf("The subject token type '%s' is not supported.", tokenType))
+}
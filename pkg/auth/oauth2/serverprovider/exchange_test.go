@@ -0,0 +1,195 @@
+package serverprovider
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// testTokenStore keeps the access tokens by the token itself
+type testTokenStore struct {
+	tokens map[string]fosite.Requester
+}
+
+func (s *testTokenStore) AccessTokenSignature(_ context.Context, token string) string {
+	return token
+}
+
+func (s *testTokenStore) GenerateAccessToken(context.Context, fosite.Requester) (string, string, error) {
+	return "issued", "issued", nil
+}
+
+func (s *testTokenStore) ValidateAccessToken(context.Context, fosite.Requester, string) error {
+	return nil
+}
+
+func (s *testTokenStore) CreateAccessTokenSession(_ context.Context, signature string, req fosite.Requester) error {
+	s.tokens[signature] = req
+	return nil
+}
+
+func (s *testTokenStore) GetAccessTokenSession(_ context.Context, signature string, _ fosite.Session) (fosite.Requester, error) {
+	if req := s.tokens[signature]; req != nil {
+		return req, nil
+	}
+	return nil, fosite.ErrNotFound
+}
+
+func (s *testTokenStore) DeleteAccessTokenSession(_ context.Context, signature string) error {
+	delete(s.tokens, signature)
+	return nil
+}
+
+func newExchangeRequest(client fosite.Client, form url.Values) *fosite.AccessRequest {
+	ar := fosite.NewAccessRequest(NewUserSession(context.Background(), 0, 0, ""))
+	ar.GrantTypes = fosite.Arguments{TokenExchangeGrantType}
+	ar.Client = client
+	ar.Form = form
+	ar.RequestedScope = fosite.RemoveEmpty([]string{form.Get("scope")})
+	ar.RequestedAudience = fosite.RemoveEmpty([]string{form.Get("audience")})
+	return ar
+}
+
+func TestTokenExchange(t *testing.T) {
+	var (
+		ctx         = NewContext(context.Background())
+		jwtProvider = jwt.NewDefaultProvider("secret", time.Hour, false)
+		store       = &testTokenStore{tokens: map[string]fosite.Requester{}}
+		history     []map[string]any
+		config      = &fosite.Config{AccessTokenLifespan: time.Hour}
+		client      = &fosite.DefaultClient{ID: "gateway", Scopes: []string{"profile", "orders"},
+			Audience: []string{"orders-api"}, GrantTypes: []string{TokenExchangeGrantType}}
+		handler = &tokenExchangeHandler{
+			HandleHelper: &oauth2.HandleHelper{AccessTokenStrategy: store, AccessTokenStorage: store, Config: config},
+			jwt:          jwtProvider,
+			config:       config,
+			writeHistory: func(_ context.Context, name, objectType string, objectID any, data map[string]any) error {
+				assert.Equal(t, HistoryActionTokenExchange, name)
+				assert.Equal(t, "gateway", objectID)
+				history = append(history, data)
+				return nil
+			},
+		}
+	)
+
+	// The access token of the user issued to the frontend client for the gateway and already delegated once
+	subjectSession := NewUserSession(ctx, 7, 3, "")
+	subjectSession.Actor = map[string]any{"sub": "frontend"}
+	subjectSession.SetExpiresAt(fosite.AccessToken, time.Now().Add(10*time.Minute).Round(time.Second))
+	subject := fosite.NewAccessRequest(subjectSession)
+	subject.Client = &fosite.DefaultClient{ID: "frontend"}
+	subject.GrantScope("profile")
+	subject.GrantAudience("gateway")
+	store.tokens["user-token"] = subject
+
+	// The token of the same user issued to the frontend only
+	foreign := fosite.NewAccessRequest(NewUserSession(ctx, 7, 3, ""))
+	foreign.Client = &fosite.DefaultClient{ID: "frontend"}
+	foreign.GrantScope("profile")
+	store.tokens["frontend-token"] = foreign
+
+	// The gateway client belongs to the account 4
+	SetContextTargetClient(ctx, &authclient.AuthClient{ID: "gateway", AccountID: 4})
+
+	t.Run("not_allowed_grant", func(t *testing.T) {
+		other := &fosite.DefaultClient{ID: "other"}
+		err := handler.HandleTokenEndpointRequest(ctx, newExchangeRequest(other, url.Values{}))
+		assert.ErrorIs(t, err, fosite.ErrUnauthorizedClient)
+	})
+
+	t.Run("invalid_subject", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newExchangeRequest(client, url.Values{
+			"subject_token": {"unknown"}, "subject_token_type": {TokenTypeAccessToken}, "audience": {"orders-api"},
+		}))
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant)
+	})
+
+	t.Run("foreign_subject", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newExchangeRequest(client, url.Values{
+			"subject_token": {"frontend-token"}, "subject_token_type": {TokenTypeAccessToken}, "audience": {"orders-api"},
+		}))
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant, "the subject token isn't issued to the client")
+	})
+
+	t.Run("invalid_target", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newExchangeRequest(client, url.Values{
+			"subject_token": {"user-token"}, "subject_token_type": {TokenTypeAccessToken}, "audience": {"billing-api"},
+		}))
+		assert.ErrorIs(t, err, ErrInvalidTarget)
+	})
+
+	t.Run("upscope", func(t *testing.T) {
+		err := handler.HandleTokenEndpointRequest(ctx, newExchangeRequest(client, url.Values{
+			"subject_token": {"user-token"}, "subject_token_type": {TokenTypeAccessToken},
+			"audience": {"orders-api"}, "scope": {"orders"},
+		}))
+		assert.ErrorIs(t, err, fosite.ErrInvalidScope, "the scope isn't granted to the subject token")
+	})
+
+	t.Run("access_token", func(t *testing.T) {
+		ar := newExchangeRequest(client, url.Values{
+			"subject_token": {"user-token"}, "subject_token_type": {TokenTypeAccessToken}, "audience": {"orders-api"},
+		})
+		require.NoError(t, handler.HandleTokenEndpointRequest(ctx, ar))
+		assert.Equal(t, fosite.Arguments{"profile"}, ar.GetGrantedScopes())
+		assert.Equal(t, fosite.Arguments{"orders-api"}, ar.GetGrantedAudience())
+
+		sess := ar.GetSession().(*Session)
+		assert.Equal(t, uint64(7), sess.UserID)
+		assert.Equal(t, uint64(3), sess.AccountID)
+		assert.Equal(t, map[string]any{"sub": "gateway", "act": map[string]any{"sub": "frontend"}},
+			sess.GetExtraClaims()["act"])
+		assert.Equal(t, subjectSession.GetExpiresAt(fosite.AccessToken), sess.GetExpiresAt(fosite.AccessToken),
+			"the exchanged token can't outlive the subject token")
+
+		resp := fosite.NewAccessResponse()
+		require.NoError(t, handler.PopulateTokenEndpointResponse(ctx, ar, resp))
+		assert.Equal(t, "issued", resp.GetAccessToken())
+		assert.Equal(t, TokenTypeAccessToken, resp.GetExtra("issued_token_type"))
+		require.Len(t, history, 1)
+		assert.Equal(t, uint64(7), history[0]["user_id"])
+		assert.Equal(t, []string{"orders-api"}, history[0]["audience"])
+	})
+
+	t.Run("jwt", func(t *testing.T) {
+		token, _, err := jwtProvider.CreateToken(9, 4, 0)
+		require.NoError(t, err)
+		ar := newExchangeRequest(client, url.Values{
+			"subject_token": {token}, "subject_token_type": {TokenTypeJWT},
+			"audience": {"orders-api"}, "scope": {"orders"},
+		})
+		require.NoError(t, handler.HandleTokenEndpointRequest(ctx, ar))
+		assert.Equal(t, fosite.Arguments{"orders"}, ar.GetGrantedScopes())
+
+		sess := ar.GetSession().(*Session)
+		assert.Equal(t, uint64(9), sess.UserID)
+		assert.Equal(t, map[string]any{"sub": "gateway"}, sess.Actor)
+	})
+
+	t.Run("jwt_other_account", func(t *testing.T) {
+		token, _, err := jwtProvider.CreateToken(9, 5, 0)
+		require.NoError(t, err)
+		err = handler.HandleTokenEndpointRequest(ctx, newExchangeRequest(client, url.Values{
+			"subject_token": {token}, "subject_token_type": {TokenTypeJWT}, "audience": {"orders-api"},
+		}))
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant, "the session isn't in the account of the client")
+	})
+
+	t.Run("jwt_impersonation", func(t *testing.T) {
+		token, _, err := jwtProvider.CreateImpersonationToken(9, 4, 1, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		err = handler.HandleTokenEndpointRequest(ctx, newExchangeRequest(client, url.Values{
+			"subject_token": {token}, "subject_token_type": {TokenTypeJWT}, "audience": {"orders-api"},
+		}))
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant, "the impersonation can't become the regular token")
+	})
+}
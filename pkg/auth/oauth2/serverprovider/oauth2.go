@@ -5,22 +5,42 @@ import (
 	"github.com/ory/fosite/compose"
 )
 
-// NewProvider returns oauth2 provider
-func NewProvider(config *fosite.Config, store *DatabaseStorage, strat *compose.CommonStrategy, hasher fosite.Hasher) fosite.OAuth2Provider {
-	return compose.Compose(
-		config,
-		store,
-		strat,
-		// hasher,
-
+// NewProvider returns oauth2 provider.
+// PKCE is supported with the S256 challenge method only and it's mandatory
+// for the public clients and the clients with the `RequirePKCE` flag.
+// OpenID Connect handlers are enabled if the strategy contains
+// the ID token strategy and the JWT signer.
+// The device authorization grant (RFC 8628) exchanges the device codes
+// issued by the DeviceAuthorizer. The extra factories enable the optional
+// grants like TokenExchangeFactory.
+func NewProvider(config *fosite.Config, store *DatabaseStorage, strat *compose.CommonStrategy, hasher fosite.Hasher, extra ...compose.Factory) fosite.OAuth2Provider {
+	factories := []compose.Factory{
 		// enabled handlers
 		compose.OAuth2AuthorizeExplicitFactory,
 		compose.OAuth2AuthorizeImplicitFactory,
+		pkceFactory, // must follow the authorize code handler
 		compose.OAuth2ClientCredentialsGrantFactory,
 		compose.OAuth2RefreshTokenGrantFactory,
+		deviceFactory,
 		// compose.OAuth2ResourceOwnerPasswordCredentialsFactory,
 
 		compose.OAuth2TokenRevocationFactory,
 		compose.OAuth2TokenIntrospectionFactory,
+	}
+	if strat.OpenIDConnectTokenStrategy != nil && strat.Signer != nil {
+		factories = append(factories,
+			compose.OpenIDConnectExplicitFactory,
+			compose.OpenIDConnectImplicitFactory,
+			compose.OpenIDConnectHybridFactory,
+			compose.OpenIDConnectRefreshFactory,
+		)
+	}
+	factories = append(factories, extra...)
+	return compose.Compose(
+		config,
+		store,
+		strat,
+		// hasher,
+		factories...,
 	)
 }
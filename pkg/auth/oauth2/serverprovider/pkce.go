@@ -0,0 +1,39 @@
+package serverprovider
+
+import (
+	"context"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+)
+
+// pkceConfig overrides the PKCE settings of the provider configuration:
+// only the S256 challenge method is allowed and PKCE is enforced
+// for the public clients and the clients which require it
+type pkceConfig struct {
+	fosite.Configurator
+}
+
+// GetEnforcePKCE returns true if the global setting or the client requires PKCE
+func (c pkceConfig) GetEnforcePKCE(ctx context.Context) bool {
+	if c.Configurator.GetEnforcePKCE(ctx) {
+		return true
+	}
+	client := lookupContextTargetClient(ctx)
+	return client != nil && client.IsPKCERequired()
+}
+
+// GetEnforcePKCEForPublicClients always enforces PKCE for the public clients
+func (c pkceConfig) GetEnforcePKCEForPublicClients(context.Context) bool {
+	return true
+}
+
+// GetEnablePKCEPlainChallengeMethod disables the `plain` challenge method
+func (c pkceConfig) GetEnablePKCEPlainChallengeMethod(context.Context) bool {
+	return false
+}
+
+// pkceFactory creates the PKCE handler with the client dependent settings
+func pkceFactory(config fosite.Configurator, storage, strategy any) any {
+	return compose.OAuth2PKCEFactory(pkceConfig{Configurator: config}, storage, strategy)
+}
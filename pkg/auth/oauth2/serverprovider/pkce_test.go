@@ -0,0 +1,40 @@
+package serverprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+func TestPKCEConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		global  bool
+		client  *authclient.AuthClient
+		enforce bool
+	}{
+		{name: "no_client", enforce: false},
+		{name: "confidential", client: &authclient.AuthClient{}, enforce: false},
+		{name: "confidential_required", client: &authclient.AuthClient{RequirePKCE: true}, enforce: true},
+		{name: "public", client: &authclient.AuthClient{Public: true}, enforce: true},
+		{name: "global", global: true, client: &authclient.AuthClient{}, enforce: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf := pkceConfig{Configurator: &fosite.Config{EnforcePKCE: test.global}}
+			ctx := NewContext(context.Background())
+			SetContextTargetClient(ctx, test.client)
+			assert.Equal(t, test.enforce, conf.GetEnforcePKCE(ctx))
+			assert.True(t, conf.GetEnforcePKCEForPublicClients(ctx))
+			assert.False(t, conf.GetEnablePKCEPlainChallengeMethod(ctx))
+		})
+	}
+	t.Run("uninitialized_context", func(t *testing.T) {
+		conf := pkceConfig{Configurator: &fosite.Config{}}
+		assert.False(t, conf.GetEnforcePKCE(context.Background()))
+	})
+}
@@ -2,20 +2,36 @@ package serverprovider
 
 import (
 	"context"
+	"maps"
 	"time"
 
 	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/openid"
+	"github.com/ory/fosite/token/jwt"
 )
 
 // Session object value
 type Session struct {
-	Username string
-	Subject  string
+	Username string `json:"username,omitempty"`
+	Subject  string `json:"subject,omitempty"`
 
-	AccessToken           string
-	AccessTokenExpiresAt  time.Time
-	RefreshToken          string
-	RefreshTokenExpiresAt time.Time
+	// UserID and AccountID of the resource owner
+	UserID    uint64 `json:"user_id,omitempty"`
+	AccountID uint64 `json:"account_id,omitempty"`
+
+	AccessToken            string    `json:"-"`
+	AccessTokenExpiresAt   time.Time `json:"access_token_expires_at,omitempty"`
+	RefreshToken           string    `json:"-"`
+	RefreshTokenExpiresAt  time.Time `json:"refresh_token_expires_at,omitempty"`
+	AuthorizeCodeExpiresAt time.Time `json:"authorize_code_expires_at,omitempty"`
+
+	// Actor is the `act` claim of the exchanged token (RFC 8693),
+	// the client acting on behalf of the user and the previous actors nested in it
+	Actor map[string]any `json:"act,omitempty"`
+
+	// Claims and Headers of the ID token (OpenID Connect)
+	Claims  *jwt.IDTokenClaims `json:"id_token_claims,omitempty"`
+	Headers *jwt.Headers       `json:"id_token_headers,omitempty"`
 
 	ctx context.Context
 }
@@ -41,6 +57,16 @@ func NewSession(
 	}
 }
 
+// NewUserSession returns the session of the user authorized the client
+func NewUserSession(ctx context.Context, userID, accountID uint64, subject string) *Session {
+	return &Session{
+		Subject:   subject,
+		UserID:    userID,
+		AccountID: accountID,
+		ctx:       ctx,
+	}
+}
+
 // SetExpiresAt sets the expiration time of a token.
 func (sess *Session) SetExpiresAt(key fosite.TokenType, exp time.Time) {
 	switch key {
@@ -48,6 +74,8 @@ func (sess *Session) SetExpiresAt(key fosite.TokenType, exp time.Time) {
 		sess.AccessTokenExpiresAt = exp
 	case fosite.RefreshToken:
 		sess.RefreshTokenExpiresAt = exp
+	case fosite.AuthorizeCode:
+		sess.AuthorizeCodeExpiresAt = exp
 	}
 }
 
@@ -60,6 +88,8 @@ func (sess *Session) GetExpiresAt(key fosite.TokenType) time.Time {
 		return sess.AccessTokenExpiresAt
 	case fosite.RefreshToken:
 		return sess.RefreshTokenExpiresAt
+	case fosite.AuthorizeCode:
+		return sess.AuthorizeCodeExpiresAt
 	}
 	return time.Time{}
 }
@@ -74,6 +104,33 @@ func (sess *Session) GetSubject() string {
 	return sess.Subject
 }
 
+// GetExtraClaims returns the claims added to the introspection response
+func (sess *Session) GetExtraClaims() map[string]any {
+	if sess.Actor == nil {
+		return nil
+	}
+	return map[string]any{"act": sess.Actor}
+}
+
+// IDTokenClaims returns the claims of the ID token, the subject is taken from the session by default
+func (sess *Session) IDTokenClaims() *jwt.IDTokenClaims {
+	if sess.Claims == nil {
+		sess.Claims = &jwt.IDTokenClaims{}
+	}
+	if sess.Claims.Subject == "" {
+		sess.Claims.Subject = sess.Subject
+	}
+	return sess.Claims
+}
+
+// IDTokenHeaders returns the headers of the ID token
+func (sess *Session) IDTokenHeaders() *jwt.Headers {
+	if sess.Headers == nil {
+		sess.Headers = &jwt.Headers{}
+	}
+	return sess.Headers
+}
+
 // Context of the
 func (sess *Session) Context() context.Context {
 	return sess.ctx
@@ -82,12 +139,50 @@ func (sess *Session) Context() context.Context {
 // Clone clones the session.
 func (sess *Session) Clone() fosite.Session {
 	return &Session{
-		Username:              sess.Username,
-		Subject:               sess.Subject,
-		AccessToken:           sess.AccessToken,
-		AccessTokenExpiresAt:  sess.AccessTokenExpiresAt,
-		RefreshToken:          sess.RefreshToken,
-		RefreshTokenExpiresAt: sess.RefreshTokenExpiresAt,
-		ctx:                   sess.ctx,
+		Username:               sess.Username,
+		Subject:                sess.Subject,
+		UserID:                 sess.UserID,
+		AccountID:              sess.AccountID,
+		AccessToken:            sess.AccessToken,
+		AccessTokenExpiresAt:   sess.AccessTokenExpiresAt,
+		RefreshToken:           sess.RefreshToken,
+		RefreshTokenExpiresAt:  sess.RefreshTokenExpiresAt,
+		AuthorizeCodeExpiresAt: sess.AuthorizeCodeExpiresAt,
+		Actor:                  maps.Clone(sess.Actor),
+		Claims:                 cloneClaims(sess.Claims),
+		Headers:                cloneHeaders(sess.Headers),
+		ctx:                    sess.ctx,
+	}
+}
+
+func cloneClaims(claims *jwt.IDTokenClaims) *jwt.IDTokenClaims {
+	if claims == nil {
+		return nil
+	}
+	newClaims := *claims
+	newClaims.Audience = append([]string(nil), claims.Audience...)
+	newClaims.AuthenticationMethodsReferences = append([]string(nil), claims.AuthenticationMethodsReferences...)
+	if claims.Extra != nil {
+		newClaims.Extra = make(map[string]any, len(claims.Extra))
+		for key, val := range claims.Extra {
+			newClaims.Extra[key] = val
+		}
 	}
+	return &newClaims
 }
+
+func cloneHeaders(headers *jwt.Headers) *jwt.Headers {
+	if headers == nil {
+		return nil
+	}
+	newHeaders := &jwt.Headers{}
+	for key, val := range headers.Extra {
+		newHeaders.Add(key, val)
+	}
+	return newHeaders
+}
+
+var (
+	_ openid.Session            = (*Session)(nil)
+	_ fosite.ExtraClaimsSession = (*Session)(nil)
+)
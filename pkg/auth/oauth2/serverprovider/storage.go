@@ -2,18 +2,23 @@ package serverprovider
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"net/url"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/geniusrabbit/gosql/v2"
 	"github.com/guregu/null"
 	"github.com/ory/fosite"
 	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/handler/openid"
 	"github.com/pkg/errors"
 
 	"github.com/geniusrabbit/blaze-api/pkg/cache"
@@ -60,13 +65,14 @@ func (s *DatabaseStorage) GetClient(ctx context.Context, id string) (fosite.Clie
 		clientObj authclient.AuthClient
 		err       = s.fromCacheOrSelect(ctx, s.clientCacheKey(id), &clientObj, id)
 	)
-	if err == sql.ErrNoRows {
-		return nil, fosite.ErrNotFound
+	if errors.Is(err, fosite.ErrNotFound) {
+		return nil, err
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "get client object")
 	}
-	if clientObj.ExpiresAt.Before(time.Now()) {
+	now := time.Now()
+	if !clientObj.ExpiresAt.IsZero() && clientObj.ExpiresAt.Before(now) {
 		return nil, fosite.ErrInvalidClient.WithHint("get OAuth2 client")
 	}
 	SetContextTargetClient(ctx, &clientObj)
@@ -80,12 +86,29 @@ func (s *DatabaseStorage) GetClient(ctx context.Context, id string) (fosite.Clie
 		Audience:      clientObj.Audience,
 		Public:        clientObj.Public,
 	}
-	return client, nil
+	for _, secret := range clientObj.RotatedSecrets(now) {
+		client.RotatedSecrets = append(client.RotatedSecrets, []byte(secret))
+	}
+	// The token endpoint authentication method is enforced only if it's set explicitly
+	if clientObj.TokenEndpointAuthMethod == "" {
+		return client, nil
+	}
+	return newOpenIDConnectClient(client, &clientObj)
 }
 
 // ClientAssertionJWTValid returns an error if the JTI is
 // known or the DB check failed and nil if the JTI is not known.
 func (s *DatabaseStorage) ClientAssertionJWTValid(ctx context.Context, jti string) error {
+	var count int64
+	err := s.db.Model((*authclient.AuthClientAssertion)(nil)).
+		Where(`jti=? AND expires_at>?`, jti, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return errors.Wrap(err, "check client assertion")
+	}
+	if count > 0 {
+		return fosite.ErrJTIKnown
+	}
 	return nil
 }
 
@@ -94,13 +117,26 @@ func (s *DatabaseStorage) ClientAssertionJWTValid(ctx context.Context, jti strin
 // up any existing JTIs that have expired as those tokens can
 // not be replayed due to the expiry.
 func (s *DatabaseStorage) SetClientAssertionJWT(ctx context.Context, jti string, exp time.Time) error {
+	err := s.db.Where(`expires_at<=?`, time.Now()).
+		Delete(&authclient.AuthClientAssertion{}).Error
+	if err != nil {
+		return errors.Wrap(err, "cleanup client assertions")
+	}
+	res := s.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&authclient.AuthClientAssertion{JTI: jti, ExpiresAt: exp})
+	if res.Error != nil {
+		return errors.Wrap(res.Error, "store client assertion")
+	}
+	if res.RowsAffected == 0 {
+		return fosite.ErrJTIKnown
+	}
 	return nil
 }
 
 // CreateAuthorizeCodeSession stores the authorization request for a given authorization code.
 func (s *DatabaseStorage) CreateAuthorizeCodeSession(ctx context.Context, code string, request fosite.Requester) error {
-	ctxlogger.Get(ctx).Debug("CreateAuthorizeCodeSession", zap.String("access_token", code))
-	return s.newSession(ctx, code, request)
+	ctxlogger.Get(ctx).Debug("CreateAuthorizeCodeSession", zap.String("request_id", request.GetID()))
+	return s.newRequest(ctx, authclient.AuthRequestKindCode, code, request)
 }
 
 // GetAuthorizeCodeSession hydrates the session based on the given code and returns the authorization request.
@@ -109,48 +145,64 @@ func (s *DatabaseStorage) CreateAuthorizeCodeSession(ctx context.Context, code s
 //
 // Make sure to also return the fosite.Requester value when returning the fosite.ErrInvalidatedAuthorizeCode error!
 func (s *DatabaseStorage) GetAuthorizeCodeSession(ctx context.Context, code string, _ fosite.Session) (fosite.Requester, error) {
-	ctxlogger.Get(ctx).Debug("GetAuthorizeCodeSession", zap.String("access_token", code))
-	sess, err := s.getAuthSession(ctx, code, `access_token`)
-	if err != nil {
-		return nil, err
-	}
-	return s.toRequest(ctx, sess)
+	ctxlogger.Get(ctx).Debug("GetAuthorizeCodeSession")
+	return s.getRequest(ctx, authclient.AuthRequestKindCode, code)
 }
 
 // InvalidateAuthorizeCodeSession is called when an authorize code is being used. The state of the authorization
 // code should be set to invalid and consecutive requests to GetAuthorizeCodeSession should return the
 // ErrInvalidatedAuthorizeCode error.
 func (s *DatabaseStorage) InvalidateAuthorizeCodeSession(ctx context.Context, code string) error {
-	ctxlogger.Get(ctx).Debug("InvalidateAuthorizeCodeSession", zap.String("access_token", code))
-	return s.invalidateSession(ctx, code, `access_token`)
+	ctxlogger.Get(ctx).Debug("InvalidateAuthorizeCodeSession")
+	return s.invalidateRequest(ctx, authclient.AuthRequestKindCode, code)
 }
 
 // DeleteAuthorizeCodeSession same as InvalidateAuthorizeCodeSession
 func (s *DatabaseStorage) DeleteAuthorizeCodeSession(ctx context.Context, code string) error {
-	ctxlogger.Get(ctx).Debug("DeleteAuthorizeCodeSession", zap.String("access_token", code))
-	return s.invalidateSession(ctx, code, `access_token`)
+	ctxlogger.Get(ctx).Debug("DeleteAuthorizeCodeSession")
+	return s.invalidateRequest(ctx, authclient.AuthRequestKindCode, code)
 }
 
 // CreatePKCERequestSession action
 func (s *DatabaseStorage) CreatePKCERequestSession(ctx context.Context, code string, request fosite.Requester) error {
-	ctxlogger.Get(ctx).Debug("CreatePKCERequestSession", zap.String("access_token", code))
-	return s.newSession(ctx, code, request)
+	ctxlogger.Get(ctx).Debug("CreatePKCERequestSession", zap.String("request_id", request.GetID()))
+	return s.newRequest(ctx, authclient.AuthRequestKindPKCE, code, request)
 }
 
 // GetPKCERequestSession action
 func (s *DatabaseStorage) GetPKCERequestSession(ctx context.Context, code string, _ fosite.Session) (fosite.Requester, error) {
-	ctxlogger.Get(ctx).Debug("GetPKCERequestSession", zap.String("access_token", code))
-	sess, err := s.getAuthSession(ctx, code, `access_token`)
-	if err != nil {
-		return nil, err
-	}
-	return s.toRequest(ctx, sess)
+	ctxlogger.Get(ctx).Debug("GetPKCERequestSession")
+	return s.getRequest(ctx, authclient.AuthRequestKindPKCE, code)
 }
 
 // DeletePKCERequestSession action
 func (s *DatabaseStorage) DeletePKCERequestSession(ctx context.Context, code string) error {
-	ctxlogger.Get(ctx).Debug("DeletePKCERequestSession", zap.String("access_token", code))
-	return s.invalidateSession(ctx, code, `access_token`)
+	ctxlogger.Get(ctx).Debug("DeletePKCERequestSession")
+	return s.dropRequest(ctx, authclient.AuthRequestKindPKCE, code)
+}
+
+// CreateOpenIDConnectSession creates an OpenID Connect connection for a given authorize code.
+// This is relevant for explicit OpenID Connect flow.
+func (s *DatabaseStorage) CreateOpenIDConnectSession(ctx context.Context, authorizeCode string, requester fosite.Requester) error {
+	ctxlogger.Get(ctx).Debug("CreateOpenIDConnectSession", zap.String("request_id", requester.GetID()))
+	return s.newRequest(ctx, authclient.AuthRequestKindOpenID, codeHash(authorizeCode), requester)
+}
+
+// GetOpenIDConnectSession returns the OpenID Connect session of the authorize code
+// or openid.ErrNoSessionFound if there is no session.
+func (s *DatabaseStorage) GetOpenIDConnectSession(ctx context.Context, authorizeCode string, _ fosite.Requester) (fosite.Requester, error) {
+	ctxlogger.Get(ctx).Debug("GetOpenIDConnectSession")
+	req, err := s.getRequest(ctx, authclient.AuthRequestKindOpenID, codeHash(authorizeCode))
+	if errors.Is(err, fosite.ErrNotFound) {
+		return nil, openid.ErrNoSessionFound
+	}
+	return req, err
+}
+
+// DeleteOpenIDConnectSession removes an OpenID Connect session from the store.
+func (s *DatabaseStorage) DeleteOpenIDConnectSession(ctx context.Context, authorizeCode string) error {
+	ctxlogger.Get(ctx).Debug("DeleteOpenIDConnectSession")
+	return s.dropRequest(ctx, authclient.AuthRequestKindOpenID, codeHash(authorizeCode))
 }
 
 // CreateAccessTokenSession updates session values
@@ -204,7 +256,7 @@ func (s *DatabaseStorage) GetRefreshTokenSession(ctx context.Context, signature
 	if err != nil {
 		return nil, err
 	}
-	if sess.RefreshTokenExpiresAt.After(time.Now()) {
+	if sess.RefreshTokenExpiresAt.Before(time.Now()) {
 		return nil, fosite.ErrTokenExpired
 	}
 	return s.toRequest(ctx, sess)
@@ -216,17 +268,28 @@ func (s *DatabaseStorage) DeleteRefreshTokenSession(ctx context.Context, signatu
 	return s.dropSession(ctx, signature, `refresh_token`)
 }
 
-// RotateRefreshToken invalid method
-func (s *DatabaseStorage) RotateRefreshToken(ctx context.Context, requestID string, refreshTokenSignature string) (err error) {
+// RotateRefreshToken drops the sessions of the request before the new
+// access and refresh tokens are issued for it
+func (s *DatabaseStorage) RotateRefreshToken(ctx context.Context, requestID string, refreshTokenSignature string) error {
 	ctxlogger.Get(ctx).Debug("RotateRefreshToken",
 		zap.String("request_id", requestID))
-	panic("RotateRefreshToken is not emplimented")
+	var sessions []*authclient.AuthSession
+	if err := s.db.Find(&sessions, `request_id=?`, requestID).Error; err != nil {
+		return errors.Wrap(err, "rotate refresh token")
+	}
+	if err := s.db.Where(`request_id=?`, requestID).Delete(&authclient.AuthSession{}).Error; err != nil {
+		return errors.Wrap(err, "rotate refresh token")
+	}
+	for _, sess := range sessions {
+		s.dropSessionCache(ctx, sess.AccessToken)
+	}
+	return nil
 }
 
-// CreateImplicitAccessTokenSession invalid method
+// CreateImplicitAccessTokenSession stores the access token issued by the implicit flow
 func (s *DatabaseStorage) CreateImplicitAccessTokenSession(ctx context.Context, code string, req fosite.Requester) error {
 	ctxlogger.Get(ctx).Debug("CreateImplicitAccessTokenSession", zap.String("access_token", code))
-	panic("CreateImplicitAccessTokenSession is not emplimented")
+	return s.newSession(ctx, code, req)
 }
 
 // RevokeRefreshToken revokes a refresh token as specified in:
@@ -273,6 +336,30 @@ func (s *DatabaseStorage) RevokeAccessToken(ctx context.Context, requestID strin
 	})
 }
 
+// RevokeUserSessions removes all tokens and pending authorization codes
+// which were issued to the client on behalf of the user
+func (s *DatabaseStorage) RevokeUserSessions(ctx context.Context, userID uint64, clientID string) error {
+	ctxlogger.Get(ctx).Debug("RevokeUserSessions",
+		zap.Uint64("user_id", userID), zap.String("client_id", clientID))
+	var sessions []*authclient.AuthSession
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Find(&sessions, `user_id=? AND client_id=?`, userID, clientID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(`user_id=? AND client_id=?`, userID, clientID).Delete(&authclient.AuthSession{}).Error; err != nil {
+			return err
+		}
+		return tx.Where(`user_id=? AND client_id=?`, userID, clientID).Delete(&authclient.AuthRequest{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(err, "revoke user sessions")
+	}
+	for _, sess := range sessions {
+		s.dropSessionCache(ctx, sess.AccessToken)
+	}
+	return nil
+}
+
 // Authenticate user by login and secret (:password)
 func (s *DatabaseStorage) Authenticate(ctx context.Context, email string, secret string) error {
 	ctxlogger.Get(ctx).Debug("Authenticate")
@@ -303,39 +390,97 @@ func (s *DatabaseStorage) Authenticate(ctx context.Context, email string, secret
 	return errors.New("Invalid credentials")
 }
 
+///////////////////////////////////////////////////////////////////////////////
+/// Device authorization methods
+///////////////////////////////////////////////////////////////////////////////
+
+// createDeviceRequest stores the new pending request, the expired requests
+// are removed before to release their user codes
+func (s *DatabaseStorage) createDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error {
+	err := s.db.Unscoped().Where(`expires_at<=?`, time.Now()).
+		Delete(&authclient.AuthDeviceRequest{}).Error
+	if err != nil {
+		return errors.Wrap(err, "cleanup device requests")
+	}
+	req.Status = authclient.DeviceStatusPending
+	req.CreatedAt = time.Now()
+	req.UpdatedAt = req.CreatedAt
+	res := s.db.Clauses(clause.OnConflict{DoNothing: true}).Omit("Client").Create(req)
+	if res.Error != nil {
+		return errors.Wrap(res.Error, "create device request")
+	}
+	if res.RowsAffected == 0 {
+		return errDeviceCodeCollision
+	}
+	return nil
+}
+
+func (s *DatabaseStorage) getDeviceRequest(ctx context.Context, signature string) (*authclient.AuthDeviceRequest, error) {
+	var req authclient.AuthDeviceRequest
+	err := s.db.First(&req, `device_code=?`, signature).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fosite.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get device request")
+	}
+	return &req, nil
+}
+
+func (s *DatabaseStorage) pollDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error {
+	err := s.db.Model(req).Updates(map[string]any{
+		`last_polled_at`: req.LastPolledAt,
+		`poll_interval`:  req.PollInterval,
+	}).Error
+	return errors.Wrap(err, "poll device request")
+}
+
+func (s *DatabaseStorage) useDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error {
+	res := s.db.Model(req).
+		Where(`status=?`, authclient.DeviceStatusApproved).
+		Updates(map[string]any{`status`: authclient.DeviceStatusUsed, `updated_at`: time.Now()})
+	if res.Error != nil {
+		return errors.Wrap(res.Error, "use device request")
+	}
+	if res.RowsAffected == 0 {
+		return fosite.ErrNotFound
+	}
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 /// Session methods
 ///////////////////////////////////////////////////////////////////////////////
 
 func (s *DatabaseStorage) newSession(ctx context.Context, token string, request fosite.Requester) error {
 	var (
-		userID    = GetContextTargetUserID(ctx)
-		clientObj = GetContextTargetClient(ctx)
-		session   = request.GetSession()
-		client    = request.GetClient()
-	)
-	if userID > 0 && clientObj.UserID != userID {
-		return fosite.ErrInvalidClient.WithHint("Check session user")
-	}
-	var (
-		sessionObj = &authclient.AuthSession{
-			Active:                true,
-			ClientID:              client.GetID(),
-			Username:              session.GetUsername(),
-			Subject:               session.GetSubject(),
-			RequestID:             request.GetID(),
-			AccessToken:           token,
-			Form:                  request.GetRequestForm().Encode(),
-			RequestedScope:        gosql.NullableStringArray(request.GetRequestedScopes()),
-			GrantedScope:          gosql.NullableStringArray(request.GetGrantedScopes()),
-			RequestedAudience:     gosql.NullableStringArray(request.GetRequestedAudience()),
-			GrantedAudience:       gosql.NullableStringArray(request.GetGrantedAudience()),
-			AccessTokenExpiresAt:  session.GetExpiresAt(fosite.AccessToken),
-			RefreshTokenExpiresAt: session.GetExpiresAt(fosite.RefreshToken),
-		}
-		err = s.db.Create(sessionObj).Error
+		session           = request.GetSession()
+		client            = request.GetClient()
+		userID, accountID = sessionOwner(ctx, session)
 	)
+	data, err := encodeSession(session)
 	if err != nil {
+		return errors.Wrap(err, "encode session")
+	}
+	sessionObj := &authclient.AuthSession{
+		Active:                true,
+		ClientID:              client.GetID(),
+		Username:              session.GetUsername(),
+		Subject:               session.GetSubject(),
+		UserID:                userID,
+		AccountID:             accountID,
+		RequestID:             request.GetID(),
+		AccessToken:           token,
+		Form:                  request.GetRequestForm().Encode(),
+		RequestedScope:        gosql.NullableStringArray(request.GetRequestedScopes()),
+		GrantedScope:          gosql.NullableStringArray(request.GetGrantedScopes()),
+		RequestedAudience:     gosql.NullableStringArray(request.GetRequestedAudience()),
+		GrantedAudience:       gosql.NullableStringArray(request.GetGrantedAudience()),
+		Data:                  data,
+		AccessTokenExpiresAt:  session.GetExpiresAt(fosite.AccessToken),
+		RefreshTokenExpiresAt: session.GetExpiresAt(fosite.RefreshToken),
+	}
+	if err = s.db.Create(sessionObj).Error; err != nil {
 		return errors.Wrap(err, "create session")
 	}
 	if err = s.cache.Set(ctx, s.sessCacheKey(token), sessionObj, s.cacheLifetime); err != nil {
@@ -347,7 +492,7 @@ func (s *DatabaseStorage) newSession(ctx context.Context, token string, request
 func (s *DatabaseStorage) updateSessionCB(requestID string, cb func(auth *authclient.AuthSession)) error {
 	var (
 		sessionObj authclient.AuthSession
-		err        = s.db.Find(&sessionObj, `request_id=?`, requestID).Error
+		err        = s.db.First(&sessionObj, `request_id=?`, requestID).Error
 	)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return fosite.ErrNotFound
@@ -359,6 +504,88 @@ func (s *DatabaseStorage) updateSessionCB(requestID string, cb func(auth *authcl
 	return s.db.Model(&sessionObj).Where(`request_id=?`, requestID).Updates(&sessionObj).Error
 }
 
+///////////////////////////////////////////////////////////////////////////////
+/// Authorization request methods
+///////////////////////////////////////////////////////////////////////////////
+
+func (s *DatabaseStorage) newRequest(ctx context.Context, kind, signature string, request fosite.Requester) error {
+	var (
+		session           = request.GetSession()
+		userID, accountID = sessionOwner(ctx, session)
+	)
+	data, err := encodeSession(session)
+	if err != nil {
+		return errors.Wrap(err, "encode session")
+	}
+	requestObj := &authclient.AuthRequest{
+		Kind:              kind,
+		Active:            true,
+		Signature:         signature,
+		RequestID:         request.GetID(),
+		ClientID:          request.GetClient().GetID(),
+		UserID:            userID,
+		AccountID:         accountID,
+		Form:              request.GetRequestForm().Encode(),
+		RequestedScope:    gosql.NullableStringArray(request.GetRequestedScopes()),
+		GrantedScope:      gosql.NullableStringArray(request.GetGrantedScopes()),
+		RequestedAudience: gosql.NullableStringArray(request.GetRequestedAudience()),
+		GrantedAudience:   gosql.NullableStringArray(request.GetGrantedAudience()),
+		Data:              data,
+		RequestedAt:       request.GetRequestedAt(),
+		ExpiresAt:         session.GetExpiresAt(fosite.AuthorizeCode),
+	}
+	return errors.Wrap(s.db.Create(requestObj).Error, "create "+kind+" request")
+}
+
+func (s *DatabaseStorage) getRequest(ctx context.Context, kind, signature string) (*fosite.Request, error) {
+	var requestObj authclient.AuthRequest
+	err := s.db.First(&requestObj, `kind=? AND signature=?`, kind, signature).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fosite.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get "+kind+" request")
+	}
+	session := &Session{ctx: ctx}
+	if err = decodeSession(requestObj.Data, session); err != nil {
+		return nil, errors.Wrap(err, "decode session")
+	}
+	session.UserID, session.AccountID = requestObj.UserID, requestObj.AccountID
+	req, err := s.buildRequest(ctx, requestObj.ClientID, requestObj.Form, session)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = requestObj.RequestID
+	req.RequestedAt = requestObj.RequestedAt
+	req.RequestedScope = fosite.Arguments(requestObj.RequestedScope)
+	req.GrantedScope = fosite.Arguments(requestObj.GrantedScope)
+	req.RequestedAudience = fosite.Arguments(requestObj.RequestedAudience)
+	req.GrantedAudience = fosite.Arguments(requestObj.GrantedAudience)
+	if !requestObj.Active {
+		return req, fosite.ErrInvalidatedAuthorizeCode
+	}
+	return req, nil
+}
+
+func (s *DatabaseStorage) invalidateRequest(ctx context.Context, kind, signature string) error {
+	res := s.db.Model((*authclient.AuthRequest)(nil)).
+		Where(`kind=? AND signature=? AND active=TRUE`, kind, signature).
+		Update(`active`, false)
+	if res.Error != nil {
+		return errors.Wrap(res.Error, "invalidate "+kind+" request")
+	}
+	if res.RowsAffected == 0 {
+		return fosite.ErrNotFound
+	}
+	return nil
+}
+
+func (s *DatabaseStorage) dropRequest(ctx context.Context, kind, signature string) error {
+	err := s.db.Where(`kind=? AND signature=?`, kind, signature).
+		Delete(&authclient.AuthRequest{}).Error
+	return errors.Wrap(err, "delete "+kind+" request")
+}
+
 func (s *DatabaseStorage) getAuthSession(ctx context.Context, code, fieldName string) (*authclient.AuthSession, error) {
 	var (
 		sessionObj authclient.AuthSession
@@ -391,18 +618,15 @@ func (s *DatabaseStorage) getSessionCode(ctx context.Context, code, fieldName st
 }
 
 func (s *DatabaseStorage) dropSession(ctx context.Context, code, fieldName string) error {
-	err := s.db.Model((*authclient.AuthSession)(nil)).Delete(fieldName+`=?`, code).Error
-	if err == sql.ErrNoRows {
-		return fosite.ErrNotFound
-	}
-	if err != nil {
-		return errors.Wrap(err, `DeleteOpenIDConnectSession`)
-	}
-	// Drop cache data
+	// Cache key has to be resolved before the session is gone
 	cacheKey, err := s.getSessionCode(ctx, code, fieldName)
 	if err != nil {
 		return err
 	}
+	err = s.db.Where(fieldName+`=?`, code).Delete(&authclient.AuthSession{}).Error
+	if err != nil {
+		return errors.Wrap(err, `delete session`)
+	}
 	if cacheErr := s.cache.Del(ctx, cacheKey); cacheErr != nil {
 		ctxlogger.Get(ctx).Error(`clear session cache`,
 			zap.String(`cache_key`, cacheKey), zap.Error(cacheErr))
@@ -410,28 +634,19 @@ func (s *DatabaseStorage) dropSession(ctx context.Context, code, fieldName strin
 	return nil
 }
 
-func (s *DatabaseStorage) invalidateSession(ctx context.Context, code, fieldName string) error {
-	cacheKey, err := s.getSessionCode(ctx, code, fieldName)
-	if err != nil {
-		return err
-	}
-	err = s.cache.Del(ctx, cacheKey)
-	if err != nil {
-		ctxlogger.Get(ctx).Error("incalidate session cache",
+func (s *DatabaseStorage) dropSessionCache(ctx context.Context, accessToken string) {
+	cacheKey := s.sessCacheKey(accessToken)
+	if err := s.cache.Del(ctx, cacheKey); err != nil {
+		ctxlogger.Get(ctx).Error(`clear session cache`,
 			zap.String(`cache_key`, cacheKey), zap.Error(err))
 	}
-	err = s.db.Model((*authclient.AuthSession)(nil)).Where(fieldName+`=? AND active=TRUE`, code).Update(`active`, true).Error
-	if err == sql.ErrNoRows {
-		return fosite.ErrNotFound
-	}
-	return err
 }
 
 func (s *DatabaseStorage) authToSession(ctx context.Context, sessionObj *authclient.AuthSession) (_ fosite.Session, err error) {
 	if !sessionObj.Active {
 		err = fosite.ErrInvalidatedAuthorizeCode
 	}
-	return NewSession(
+	session := NewSession(
 		ctx,
 		sessionObj.Username,
 		sessionObj.Subject,
@@ -439,7 +654,12 @@ func (s *DatabaseStorage) authToSession(ctx context.Context, sessionObj *authcli
 		sessionObj.AccessTokenExpiresAt,
 		sessionObj.RefreshToken.String,
 		sessionObj.RefreshTokenExpiresAt,
-	), err
+	)
+	if decodeErr := decodeSession(sessionObj.Data, session); decodeErr != nil {
+		return nil, decodeErr
+	}
+	session.UserID, session.AccountID = sessionObj.UserID, sessionObj.AccountID
+	return session, err
 }
 
 func (s *DatabaseStorage) toRequest(ctx context.Context, sessionObj *authclient.AuthSession) (*fosite.Request, error) {
@@ -448,29 +668,35 @@ func (s *DatabaseStorage) toRequest(ctx context.Context, sessionObj *authclient.
 		return nil, errors.Wrap(authErr, "auth session")
 	}
 
-	client, err := s.GetClient(ctx, sessionObj.ClientID)
+	r, err := s.buildRequest(ctx, sessionObj.ClientID, sessionObj.Form, session)
 	if err != nil {
-		return nil, errors.Wrap(err, "get client")
+		return nil, err
 	}
 
-	val, err := url.ParseQuery(sessionObj.Form)
+	SetContextSession(ctx, sessionObj)
+	r.ID = sessionObj.RequestID
+	r.RequestedAt = sessionObj.CreatedAt
+	r.RequestedScope = fosite.Arguments(sessionObj.RequestedScope)
+	r.GrantedScope = fosite.Arguments(sessionObj.GrantedScope)
+	r.RequestedAudience = fosite.Arguments(sessionObj.RequestedAudience)
+	r.GrantedAudience = fosite.Arguments(sessionObj.GrantedAudience)
+	return r, authErr
+}
+
+func (s *DatabaseStorage) buildRequest(ctx context.Context, clientID, form string, session fosite.Session) (*fosite.Request, error) {
+	client, err := s.GetClient(ctx, clientID)
 	if err != nil {
-		return nil, errors.Wrap(err, "parse form data")
+		return nil, errors.Wrap(err, "get client")
 	}
-
-	SetContextSession(ctx, sessionObj)
-	r := &fosite.Request{
-		ID:                sessionObj.RequestID,
-		RequestedAt:       sessionObj.CreatedAt,
-		Client:            client,
-		Session:           session,
-		Form:              val,
-		RequestedScope:    fosite.Arguments(sessionObj.RequestedScope),
-		GrantedScope:      fosite.Arguments(sessionObj.GrantedScope),
-		RequestedAudience: fosite.Arguments(sessionObj.RequestedAudience),
-		GrantedAudience:   fosite.Arguments(sessionObj.GrantedAudience),
+	val, err := url.ParseQuery(form)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse form data")
 	}
-	return r, authErr
+	return &fosite.Request{
+		Client:  client,
+		Session: session,
+		Form:    val,
+	}, nil
 }
 
 func (s *DatabaseStorage) sessCacheKey(key string) string {
@@ -485,7 +711,10 @@ func (s *DatabaseStorage) fromCacheOrSelect(ctx context.Context, cacheKey string
 	if err := s.cache.Get(ctx, cacheKey, target); err != nil && err != cache.ErrEntryNotFound {
 		ctxlogger.Get(ctx).Error("cache load", zap.String("cache_key", cacheKey), zap.Error(err))
 	}
-	err := s.db.Find(target, args...).Error
+	err := s.db.First(target, args...).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fosite.ErrNotFound
+	}
 	if err == nil {
 		err = s.cache.Set(ctx, cacheKey, target, s.cacheLifetime)
 		if err != nil {
@@ -495,4 +724,42 @@ func (s *DatabaseStorage) fromCacheOrSelect(ctx context.Context, cacheKey string
 	return err
 }
 
-var _ oauth2.TokenRevocationStorage = (*DatabaseStorage)(nil)
+// sessionOwner returns the user and account which authorized the request
+func sessionOwner(ctx context.Context, session fosite.Session) (userID, accountID uint64) {
+	if sess, _ := session.(*Session); sess != nil && sess.UserID > 0 {
+		return sess.UserID, sess.AccountID
+	}
+	return GetContextTargetUserID(ctx), 0
+}
+
+func encodeSession(session fosite.Session) (data gosql.NullableJSON[map[string]any], err error) {
+	sess, _ := session.(*Session)
+	if sess == nil {
+		return data, nil
+	}
+	err = data.SetValue(sess)
+	return data, err
+}
+
+func decodeSession(data gosql.NullableJSON[map[string]any], session *Session) error {
+	if data.Data == nil {
+		return nil
+	}
+	raw, err := json.Marshal(data.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, session)
+}
+
+// codeHash of the authorization code, the raw codes are not kept in the storage
+func codeHash(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+var (
+	_ oauth2.TokenRevocationStorage      = (*DatabaseStorage)(nil)
+	_ openid.OpenIDConnectRequestStorage = (*DatabaseStorage)(nil)
+	_ authclient.SessionRevoker          = (*DatabaseStorage)(nil)
+)
@@ -0,0 +1,36 @@
+package serverprovider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// SubjectIdentifier returns the `sub` value of the user for the client.
+//
+// Clients with the `public` subject type (default) receive the same user ID as everyone else,
+// `pairwise` clients receive the identifier unique for the sector (host of the redirect URI)
+// so different services can't correlate users by the subject.
+// See https://openid.net/specs/openid-connect-core-1_0.html#PairwiseAlg
+func SubjectIdentifier(client *authclient.AuthClient, userID uint64, salt string) string {
+	localID := strconv.FormatUint(userID, 10)
+	if client == nil || !client.IsPairwiseSubject() {
+		return localID
+	}
+	hash := sha256.Sum256([]byte(sectorIdentifier(client) + localID + salt))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// sectorIdentifier returns the host of the first registered redirect URI,
+// the clients without redirects are grouped by their ID
+func sectorIdentifier(client *authclient.AuthClient) string {
+	for _, redirectURI := range client.RedirectURIs {
+		if uri, err := url.Parse(redirectURI); err == nil && uri.Host != "" {
+			return uri.Host
+		}
+	}
+	return client.ID
+}
@@ -0,0 +1,54 @@
+package serverprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+func TestSubjectIdentifier(t *testing.T) {
+	public := &authclient.AuthClient{ID: "public", SubjectType: authclient.SubjectTypePublic}
+	pairwise := func(id string, redirects ...string) *authclient.AuthClient {
+		return &authclient.AuthClient{ID: id, SubjectType: authclient.SubjectTypePairwise, RedirectURIs: redirects}
+	}
+
+	assert.Equal(t, "42", SubjectIdentifier(public, 42, "salt"))
+	assert.Equal(t, "42", SubjectIdentifier(nil, 42, "salt"))
+
+	sub := SubjectIdentifier(pairwise("a", "https://app.example.com/cb"), 42, "salt")
+	assert.NotEqual(t, "42", sub)
+	assert.Equal(t, sub, SubjectIdentifier(pairwise("b", "https://app.example.com/other"), 42, "salt"),
+		"clients of the same sector share the subject")
+	assert.NotEqual(t, sub, SubjectIdentifier(pairwise("c", "https://other.example.com/cb"), 42, "salt"))
+	assert.NotEqual(t, sub, SubjectIdentifier(pairwise("a", "https://app.example.com/cb"), 43, "salt"))
+	assert.NotEqual(t, sub, SubjectIdentifier(pairwise("a", "https://app.example.com/cb"), 42, "pepper"))
+}
+
+func TestSessionEncoding(t *testing.T) {
+	authTime := time.Now().UTC().Truncate(time.Second)
+	sess := NewUserSession(context.Background(), 1, 2, "sub")
+	sess.IDTokenClaims().AuthTime = authTime
+	sess.IDTokenClaims().Nonce = "nonce-value"
+	sess.IDTokenHeaders().Add("kid", "key")
+
+	data, err := encodeSession(sess)
+	if !assert.NoError(t, err) {
+		return
+	}
+	decoded := &Session{}
+	if !assert.NoError(t, decodeSession(data, decoded)) {
+		return
+	}
+	assert.Equal(t, "sub", decoded.IDTokenClaims().Subject)
+	assert.True(t, authTime.Equal(decoded.IDTokenClaims().AuthTime))
+	assert.Equal(t, "nonce-value", decoded.IDTokenClaims().Nonce)
+	assert.Equal(t, "key", decoded.IDTokenHeaders().Get("kid"))
+
+	clone := sess.Clone().(*Session)
+	clone.IDTokenClaims().Nonce = "changed"
+	assert.Equal(t, "nonce-value", sess.IDTokenClaims().Nonce, "clone must not share the claims")
+}
@@ -65,5 +65,9 @@ func (c *Cache) Get(ctx context.Context, key string, target any) error {
 
 // Del removes cache item by key
 func (c *Cache) Del(ctx context.Context, key string) error {
-	return c.big.Delete(key)
+	err := c.big.Delete(key)
+	if err == bigcache.ErrEntryNotFound {
+		err = cache.ErrEntryNotFound
+	}
+	return err
 }
@@ -0,0 +1,33 @@
+// Package clientip keeps the remote client address in the request context
+package clientip
+
+import (
+	"context"
+	"net"
+)
+
+var ctxClientIPKey = &struct{ s string }{"clientip"}
+
+// WithIP set client IP address to context
+func WithIP(ctx context.Context, ip string) context.Context {
+	if ip = Normalize(ip); ip == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxClientIPKey, ip)
+}
+
+// Get client IP address from context
+func Get(ctx context.Context) string {
+	if ip, _ := ctx.Value(ctxClientIPKey).(string); ip != "" {
+		return ip
+	}
+	return ""
+}
+
+// Normalize removes the port part from the address if present
+func Normalize(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
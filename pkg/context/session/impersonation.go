@@ -0,0 +1,41 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+var ctxImpersonationKey = &struct{ s string }{"impersonation"}
+
+// Impersonation of the session, the actor acts on behalf of the session user
+type Impersonation struct {
+	ActorID   uint64    // The real user who issued the impersonation token
+	ExpiresAt time.Time // Expiration of the impersonation token
+}
+
+// WithImpersonation puts the impersonation state into the context
+func WithImpersonation(ctx context.Context, imp *Impersonation) context.Context {
+	if imp == nil || imp.ActorID == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxImpersonationKey, imp)
+}
+
+// GetImpersonation returns the impersonation state or nil if the session is not impersonated
+func GetImpersonation(ctx context.Context) *Impersonation {
+	imp, _ := ctx.Value(ctxImpersonationKey).(*Impersonation)
+	return imp
+}
+
+// IsImpersonated returns `true` if the session user is impersonated by another user
+func IsImpersonated(ctx context.Context) bool {
+	return GetImpersonation(ctx) != nil
+}
+
+// ImpersonatorID returns the ID of the real actor (0 when the session is not impersonated)
+func ImpersonatorID(ctx context.Context) uint64 {
+	if imp := GetImpersonation(ctx); imp != nil {
+		return imp.ActorID
+	}
+	return 0
+}
@@ -13,7 +13,8 @@ func WithSession(ctx context.Context, manager *scs.SessionManager) context.Conte
 	return context.WithValue(ctx, ctxSessionStorage, manager)
 }
 
-// Get session storage
+// Get session storage, returns nil if the session isn't initialized
 func Get(ctx context.Context) *scs.SessionManager {
-	return ctx.Value(ctxSessionStorage).(*scs.SessionManager)
+	manager, _ := ctx.Value(ctxSessionStorage).(*scs.SessionManager)
+	return manager
 }
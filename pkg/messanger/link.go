@@ -0,0 +1,21 @@
+package messanger
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Link builds the link for the message from the template with {name} placeholders,
+// values are escaped for the query string. Empty template returns empty link.
+//
+// Example: Link("https://example.com/verify?token={token}", map[string]string{"token": token})
+func Link(tmpl string, params map[string]string) string {
+	if tmpl == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", url.QueryEscape(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
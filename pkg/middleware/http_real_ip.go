@@ -5,7 +5,10 @@ package middleware
 
 import (
 	"net/http"
+	"net/netip"
 	"strings"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/clientip"
 )
 
 var xForwardedFor = http.CanonicalHeaderKey("X-Forwarded-For")
@@ -13,7 +16,7 @@ var xRealIP = http.CanonicalHeaderKey("X-Real-IP")
 
 // RealIP is a middleware that sets a http.Request's RemoteAddr to the results
 // of parsing either the X-Forwarded-For header or the X-Real-IP header (in that
-// order).
+// order). The resulting address is also available in the context by clientip.Get.
 //
 // This middleware should be inserted fairly early in the middleware stack to
 // ensure that subsequent layers (e.g., request loggers) which examine the
@@ -31,11 +34,79 @@ func RealIP(h http.Handler) http.Handler {
 		if rip := realIP(r); rip != "" {
 			r.RemoteAddr = rip
 		}
-		h.ServeHTTP(w, r)
+		h.ServeHTTP(w, r.WithContext(clientip.WithIP(r.Context(), r.RemoteAddr)))
+	}
+	return http.HandlerFunc(fn)
+}
+
+// TrustedRealIP is RealIP which accepts the headers only from the trusted proxies.
+// The requests of other peers keep their own address, so the clients can't spoof it.
+// The trusted proxy must override the X-Real-IP header or append the peer to X-Forwarded-For,
+// the right-most untrusted address of X-Forwarded-For is taken as the client one.
+func TrustedRealIP(h http.Handler, proxies ...netip.Prefix) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if isTrustedProxy(r.RemoteAddr, proxies) {
+			if rip := trustedRealIP(r, proxies); rip != "" {
+				r.RemoteAddr = rip
+			}
+		}
+		h.ServeHTTP(w, r.WithContext(clientip.WithIP(r.Context(), r.RemoteAddr)))
 	}
 	return http.HandlerFunc(fn)
 }
 
+// ParseProxies returns the networks of the trusted proxies from the CIDR or the single addresses
+func ParseProxies(list ...string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(list))
+	for _, val := range list {
+		if val = strings.TrimSpace(val); val == "" {
+			continue
+		}
+		if !strings.Contains(val, "/") {
+			addr, err := netip.ParseAddr(val)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(val)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+func trustedRealIP(r *http.Request, proxies []netip.Prefix) string {
+	if xrip := r.Header.Get(xRealIP); xrip != "" {
+		return xrip
+	}
+	addrs := strings.Split(r.Header.Get(xForwardedFor), ",")
+	for i := len(addrs) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(addrs[i])
+		if addr != "" && (i == 0 || !isTrustedProxy(addr, proxies)) {
+			return addr
+		}
+	}
+	return ""
+}
+
+func isTrustedProxy(remoteAddr string, proxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(clientip.Normalize(remoteAddr))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range proxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 func realIP(r *http.Request) string {
 	var ip string
 
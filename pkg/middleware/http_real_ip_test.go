@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/clientip"
+)
+
+func TestTrustedRealIP(t *testing.T) {
+	proxies, err := ParseProxies("10.0.0.0/8", "127.0.0.1")
+	require.NoError(t, err)
+
+	var ip string
+	h := TrustedRealIP(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ip = clientip.Get(r.Context())
+	}), proxies...)
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		ip      string
+	}{
+		{"untrusted", "1.2.3.4:1000", map[string]string{"X-Forwarded-For": "5.6.7.8"}, "1.2.3.4"},
+		{"real_ip", "10.0.0.1:1000", map[string]string{"X-Real-IP": "5.6.7.8"}, "5.6.7.8"},
+		{"forwarded", "127.0.0.1:1000", map[string]string{"X-Forwarded-For": "9.9.9.9, 5.6.7.8, 10.0.0.2"}, "5.6.7.8"},
+		{"no_headers", "10.0.0.1:1000", nil, "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remote
+			for key, val := range tt.headers {
+				req.Header.Set(key, val)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.ip, ip)
+		})
+	}
+
+	_, err = ParseProxies("10.0.0.0/33")
+	assert.Error(t, err)
+}
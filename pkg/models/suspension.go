@@ -0,0 +1,53 @@
+package models
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+)
+
+// Suspendable model can be suspended keeping all its data
+type Suspendable interface {
+	IsSuspended() bool
+}
+
+// Suspension state of the model, the suspended object keeps the data
+// but can't be used for the authentication
+type Suspension struct {
+	SuspendedAt   sql.Null[time.Time] `json:"suspended_at" db:"suspended_at" gorm:"column:suspended_at"`
+	SuspendReason string              `json:"suspend_reason" db:"suspend_reason" gorm:"column:suspend_reason"`
+}
+
+// IsSuspended returns `true` if the object is suspended
+func (s *Suspension) IsSuspended() bool {
+	return s != nil && s.SuspendedAt.Valid
+}
+
+// Suspend the object with the reason
+func (s *Suspension) Suspend(reason string) {
+	if s != nil {
+		s.SuspendedAt = sql.Null[time.Time]{V: time.Now(), Valid: true}
+		s.SuspendReason = reason
+	}
+}
+
+// Unsuspend the object
+func (s *Suspension) Unsuspend() {
+	if s != nil {
+		s.SuspendedAt = sql.Null[time.Time]{}
+		s.SuspendReason = ""
+	}
+}
+
+// IsSuspended returns `true` if the object supports the suspension and is suspended.
+// It's safe for the nil pointers of the models embedding the Suspension.
+func IsSuspended(obj any) bool {
+	s, ok := obj.(Suspendable)
+	if !ok {
+		return false
+	}
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Pointer && v.IsNil() {
+		return false
+	}
+	return s.IsSuspended()
+}
@@ -3,6 +3,7 @@ package permissions
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/demdxx/gocast/v2"
 	"github.com/demdxx/rbac"
@@ -28,8 +29,8 @@ func (l *DBRoleLoader) ListRoles(ctx context.Context) []rbac.Role {
 		roleCache = make(map[uint64]rbac.Role, 10)
 		query     = l.conn.WithContext(ctx)
 	)
-	// Load roles from database
-	err := query.Find(&roles).Error
+	// Load roles from database, the custom roles of the accounts are loaded separately
+	err := query.Where(`account_id IS NULL`).Find(&roles).Error
 	if err != nil {
 		panic(err)
 	}
@@ -49,7 +50,63 @@ func (l *DBRoleLoader) ListRoles(ctx context.Context) []rbac.Role {
 	return xtypes.Map[uint64, rbac.Role](roleCache).Values()
 }
 
+// ListAccountRoles returns the custom roles of the account. The account roles include
+// the global roles up to the account access level and grant only the owner and account permissions.
+func (l *DBRoleLoader) ListAccountRoles(ctx context.Context, accountID uint64, global []rbac.Role, permissions func(patterns ...string) []rbac.Permission) ([]rbac.Role, error) {
+	var (
+		links []*rbacModels.M2MRole
+		roles []*rbacModels.Role
+	)
+	err := l.conn.WithContext(ctx).Where(`account_id=?`, accountID).Order(`id`).Find(&roles).Error
+	if err != nil || len(roles) == 0 {
+		return nil, err
+	}
+	err = l.conn.WithContext(ctx).
+		Where(`parent_role_id IN (?)`, xtypes.SliceApply(roles, func(r *rbacModels.Role) uint64 { return r.ID })).
+		Find(&links).Error
+	if err != nil && !errors.Is(err, sql.ErrNoRows) && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	roleCache := make(map[uint64]rbac.Role, len(global)+len(roles))
+	for _, role := range global {
+		if data, _ := role.Ext().(*ExtData); data != nil && data.AccessLevel <= rbacModels.AccessLevelAccount {
+			roleCache[data.ID] = role
+		}
+	}
+
+	list := make([]rbac.Role, 0, len(roles))
+	for _, role := range roles {
+		var perms []rbac.Permission
+		if len(role.PermissionPatterns) > 0 {
+			perms = xtypes.Slice[rbac.Permission](permissions(role.PermissionPatterns...)).
+				Filter(func(p rbac.Permission) bool {
+					return strings.HasSuffix(p.Name(), `.owner`) || strings.HasSuffix(p.Name(), `.account`)
+				})
+		}
+		accRole, err := rbac.NewRole(role.Name, rbac.WithChildRoles(childRoles(role, roleCache, links)...),
+			rbac.WithPermissions(perms),
+			rbac.WithExtData(&ExtData{ID: role.ID, Title: role.Title, AccessLevel: role.AccessLevel, AccountID: accountID}),
+			rbac.WithDescription(role.Description),
+		)
+		if err != nil {
+			return nil, err
+		}
+		roleCache[role.ID] = accRole
+		list = append(list, accRole)
+	}
+	return list, nil
+}
+
 func roleByModel(role *rbacModels.Role, roles map[uint64]rbac.Role, links []*rbacModels.M2MRole) (rbac.Role, error) {
+	return rbac.NewRole(role.Name, rbac.WithChildRoles(childRoles(role, roles, links)...),
+		rbac.WithPermissions(gocast.Slice[any](role.PermissionPatterns)...),
+		rbac.WithExtData(&ExtData{ID: role.ID, Title: role.Title, AccessLevel: role.AccessLevel}),
+		rbac.WithDescription(role.Description),
+	)
+}
+
+func childRoles(role *rbacModels.Role, roles map[uint64]rbac.Role, links []*rbacModels.M2MRole) []rbac.Role {
 	roleList := make([]rbac.Role, 0, len(links))
 	for _, link := range links {
 		if link.ParentRoleID != role.ID {
@@ -59,9 +116,5 @@ func roleByModel(role *rbacModels.Role, roles map[uint64]rbac.Role, links []*rba
 			roleList = append(roleList, rls)
 		}
 	}
-	return rbac.NewRole(role.Name, rbac.WithChildRoles(roleList...),
-		rbac.WithPermissions(gocast.Slice[any](role.PermissionPatterns)...),
-		rbac.WithExtData(&ExtData{ID: role.ID, Title: role.Title, AccessLevel: role.AccessLevel}),
-		rbac.WithDescription(role.Description),
-	)
+	return roleList
 }
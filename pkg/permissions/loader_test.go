@@ -0,0 +1,53 @@
+package permissions_test
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/geniusrabbit/blaze-api/pkg/permissions"
+	rbacModels "github.com/geniusrabbit/blaze-api/repository/rbac/models"
+	"github.com/geniusrabbit/blaze-api/repository/testsuite"
+)
+
+type loaderTestSuite struct {
+	testsuite.DatabaseSuite
+}
+
+func (s *loaderTestSuite) TestAccountRoles() {
+	mng := permissions.NewManager(s.DB, time.Minute)
+	s.NoError(mng.RegisterNewOwningPermissions(&rbacModels.Role{}, []string{`view`}))
+
+	s.Mock.ExpectQuery(`SELECT \* FROM "rbac_role" WHERE account_id IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "access_level"}).
+			AddRow(1, "viewer", rbacModels.AccessLevelAccount).
+			AddRow(2, "system:admin", rbacModels.AccessLevelSystem))
+	s.Mock.ExpectQuery(`SELECT \* FROM "m2m_rbac_role"`).
+		WillReturnRows(sqlmock.NewRows([]string{"parent_role_id", "child_role_id"}))
+	s.Mock.ExpectQuery(`SELECT \* FROM "rbac_role" WHERE account_id=\$1`).
+		WithArgs(uint64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "permissions", "account_id"}).
+			AddRow(10, "billing", "{role.*.*}", 5))
+	s.Mock.ExpectQuery(`SELECT \* FROM "m2m_rbac_role" WHERE parent_role_id IN`).
+		WithArgs(uint64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"parent_role_id", "child_role_id"}).
+			AddRow(10, 1).AddRow(10, 2))
+
+	roles := mng.AccountRoles(s.Ctx, 5)
+	s.Require().Len(roles, 1)
+	s.Equal("billing", roles[0].Name())
+	s.True(roles[0].HasRole("viewer"))
+	s.False(roles[0].HasRole("system:admin"), "the system roles can't be included")
+	s.NotNil(roles[0].Permission(`role.view.account`))
+	s.Nil(roles[0].Permission(`role.view.all`), "the all cover permissions can't be granted")
+
+	// The roles are cached until the reset
+	s.Equal(roles, mng.AccountRoles(s.Ctx, 5))
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, &loaderTestSuite{})
+}
@@ -2,12 +2,16 @@ package permissions
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/demdxx/rbac"
 	"github.com/demdxx/xtypes"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 )
 
 const (
@@ -26,11 +30,24 @@ type ExtData struct {
 	ID          uint64 `json:"id"`
 	Title       string `json:"title"`
 	AccessLevel int    `json:"access_level"`
+	AccountID   uint64 `json:"account_id,omitempty"`
+}
+
+type accountRoles struct {
+	roles    []rbac.Role
+	loadedAt time.Time
 }
 
 // Manager provides methods to control and cache permissions
 type Manager struct {
 	*rbac.Manager
+
+	// The custom roles of the accounts are cached separately
+	// because the role names are unique only inside of the account
+	loader        *DBRoleLoader
+	cacheLifetime time.Duration
+	accountMx     sync.RWMutex
+	accountRoles  map[uint64]*accountRoles
 }
 
 // NewManager object to control roles
@@ -38,8 +55,13 @@ func NewManager(conn *gorm.DB, cacheLifetime time.Duration) *Manager {
 	if cacheLifetime == 0 {
 		cacheLifetime = time.Second * 5
 	}
-	return &Manager{Manager: rbac.NewManagerWithLoader(
-		&DBRoleLoader{conn: conn}, cacheLifetime)}
+	loader := &DBRoleLoader{conn: conn}
+	return &Manager{
+		Manager:       rbac.NewManagerWithLoader(loader, cacheLifetime),
+		loader:        loader,
+		cacheLifetime: cacheLifetime,
+		accountRoles:  map[uint64]*accountRoles{},
+	}
 }
 
 // NewTestManager with all permissions
@@ -69,8 +91,50 @@ func (mng *Manager) DefaultRole(ctx context.Context) rbac.Role {
 	return mng.Role(ctx, DefaultRole)
 }
 
-// AsOneRole returns new role object from one or more IDs
+// AccountRoles returns the custom roles of the account
+func (mng *Manager) AccountRoles(ctx context.Context, accountID uint64) []rbac.Role {
+	if mng.loader == nil || accountID == 0 {
+		return nil
+	}
+	mng.accountMx.RLock()
+	cached := mng.accountRoles[accountID]
+	mng.accountMx.RUnlock()
+	if cached != nil && time.Since(cached.loadedAt) <= mng.cacheLifetime {
+		return cached.roles
+	}
+
+	global := mng.RolesByFilter(ctx, func(context.Context, rbac.Role) bool { return true })
+	roles, err := mng.loader.ListAccountRoles(ctx, accountID, global, mng.Permissions)
+	if err != nil {
+		ctxlogger.Get(ctx).Error("Failed to load account roles",
+			zap.Uint64("account_id", accountID), zap.Error(err))
+		if cached != nil {
+			return cached.roles
+		}
+		return nil
+	}
+
+	mng.accountMx.Lock()
+	defer mng.accountMx.Unlock()
+	mng.accountRoles[accountID] = &accountRoles{roles: roles, loadedAt: time.Now()}
+	return roles
+}
+
+// ResetAccountRoles drops the cached custom roles of the account
+func (mng *Manager) ResetAccountRoles(accountID uint64) {
+	mng.accountMx.Lock()
+	defer mng.accountMx.Unlock()
+	delete(mng.accountRoles, accountID)
+}
+
+// AsOneRole returns new role object from one or more IDs of the global roles
 func (mng *Manager) AsOneRole(ctx context.Context, isAdmin bool, filter func(context.Context, rbac.Role) bool, id ...uint64) (rbac.Role, error) {
+	return mng.AsOneAccountRole(ctx, 0, isAdmin, filter, id...)
+}
+
+// AsOneAccountRole returns new role object from one or more IDs of the global roles
+// and the custom roles of the account
+func (mng *Manager) AsOneAccountRole(ctx context.Context, accountID uint64, isAdmin bool, filter func(context.Context, rbac.Role) bool, id ...uint64) (rbac.Role, error) {
 	var roles []rbac.Role
 	if isAdmin {
 		adminRole := mng.Role(ctx, DefaultAdminRole)
@@ -81,7 +145,7 @@ func (mng *Manager) AsOneRole(ctx context.Context, isAdmin bool, filter func(con
 	}
 
 	if len(id) > 0 {
-		roles = append(roles, mng.RolesByFilter(ctx, func(ctx context.Context, r rbac.Role) bool {
+		roleFilter := func(ctx context.Context, r rbac.Role) bool {
 			switch data := r.Ext().(type) {
 			case *ExtData:
 				return xtypes.Slice[uint64](id).Has(func(val uint64) bool {
@@ -89,7 +153,13 @@ func (mng *Manager) AsOneRole(ctx context.Context, isAdmin bool, filter func(con
 				})
 			}
 			return false
-		})...)
+		}
+		roles = append(roles, mng.RolesByFilter(ctx, roleFilter)...)
+		for _, role := range mng.AccountRoles(ctx, accountID) {
+			if roleFilter(ctx, role) {
+				roles = append(roles, role)
+			}
+		}
 	}
 	if len(roles) == 0 && len(id) != 0 {
 		return nil, ErrUndefinedRole
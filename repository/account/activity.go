@@ -0,0 +1,6 @@
+package account
+
+// OptionMemberInactivityDays is the account option with the number of days without
+// any activity after which the member is inactive. The system option of the same name
+// is the default for all accounts, 0 disables the inactivity checks.
+const OptionMemberInactivityDays = "account.member.inactivity_days"
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+)
+
+const (
+	// DefaultActivityInterval between two writes of the activity of the same user, account and authorizer
+	DefaultActivityInterval = 5 * time.Minute
+
+	defaultActivityQueueSize = 1024
+)
+
+type activityKey struct {
+	userID     uint64
+	accountID  uint64
+	authorizer string
+}
+
+type activityTouch struct {
+	ctx      context.Context
+	activity *account.MemberActivity
+}
+
+// ActivityOption configures the activity tracker
+type ActivityOption func(*ActivityTracker)
+
+// WithActivityInterval sets the minimal interval between two writes of the same activity
+func WithActivityInterval(interval time.Duration) ActivityOption {
+	return func(t *ActivityTracker) {
+		if interval > 0 {
+			t.interval = interval
+		}
+	}
+}
+
+// WithActivityQueueSize sets the number of the touches waiting for the write,
+// the touches over the limit are dropped
+func WithActivityQueueSize(size int) ActivityOption {
+	return func(t *ActivityTracker) {
+		if size > 0 {
+			t.queueSize = size
+		}
+	}
+}
+
+// ActivityTracker records the last activity of the users in the accounts.
+// The touches are throttled by the user, the account and the authorizer
+// and written in the background by Run, so the requests never wait for the database.
+type ActivityTracker struct {
+	repo      account.ActivityRepository
+	interval  time.Duration
+	queueSize int
+	queue     chan activityTouch
+	now       func() time.Time
+
+	mx      sync.Mutex
+	touched map[activityKey]time.Time
+}
+
+// NewActivityTracker creates the activity tracker, the touches are written only after Run
+func NewActivityTracker(repo account.ActivityRepository, opts ...ActivityOption) *ActivityTracker {
+	tracker := &ActivityTracker{
+		repo:      repo,
+		interval:  DefaultActivityInterval,
+		queueSize: defaultActivityQueueSize,
+		now:       time.Now,
+		touched:   map[activityKey]time.Time{},
+	}
+	for _, opt := range opts {
+		opt(tracker)
+	}
+	tracker.queue = make(chan activityTouch, tracker.queueSize)
+	return tracker
+}
+
+// Touch marks the activity of the user in the account through the authorizer.
+// The context of the request is detached from the cancellation for the background write.
+func (t *ActivityTracker) Touch(ctx context.Context, userID, accountID uint64, authorizer string) {
+	if t == nil || userID == 0 {
+		return
+	}
+	var (
+		now = t.now()
+		key = activityKey{userID: userID, accountID: accountID, authorizer: authorizer}
+	)
+
+	t.mx.Lock()
+	if last, ok := t.touched[key]; ok && now.Sub(last) < t.interval {
+		t.mx.Unlock()
+		return
+	}
+	t.touched[key] = now
+	t.mx.Unlock()
+
+	touch := activityTouch{
+		ctx: context.WithoutCancel(ctx),
+		activity: &account.MemberActivity{
+			UserID:     userID,
+			AccountID:  accountID,
+			Authorizer: authorizer,
+			LastSeenAt: now,
+		},
+	}
+	select {
+	case t.queue <- touch:
+	default:
+		// The queue is full, the next request of the user retries the touch
+		t.mx.Lock()
+		delete(t.touched, key)
+		t.mx.Unlock()
+	}
+}
+
+// Run writes the queued touches until the context is done.
+// The touches left in the queue are written before the exit.
+func (t *ActivityTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case touch := <-t.queue:
+					t.write(touch)
+				default:
+					return
+				}
+			}
+		case touch := <-t.queue:
+			t.write(touch)
+		case <-ticker.C:
+			t.cleanup()
+		}
+	}
+}
+
+func (t *ActivityTracker) write(touch activityTouch) {
+	if err := t.repo.TouchActivity(touch.ctx, touch.activity); err != nil {
+		ctxlogger.Get(touch.ctx).Error("touch activity",
+			zap.Uint64("user_id", touch.activity.UserID),
+			zap.Uint64("account_id", touch.activity.AccountID),
+			zap.Error(err))
+	}
+}
+
+// cleanup removes the expired throttling marks
+func (t *ActivityTracker) cleanup() {
+	now := t.now()
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	for key, last := range t.touched {
+		if now.Sub(last) >= t.interval {
+			delete(t.touched, key)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/blaze-api/repository/account"
+)
+
+type activityRepoStub struct {
+	touches chan *account.MemberActivity
+}
+
+func (r *activityRepoStub) TouchActivity(_ context.Context, activity *account.MemberActivity) error {
+	r.touches <- activity
+	return nil
+}
+
+func TestActivityTrackerThrottle(t *testing.T) {
+	var (
+		now     = time.Now()
+		repo    = &activityRepoStub{touches: make(chan *account.MemberActivity, 10)}
+		tracker = NewActivityTracker(repo, WithActivityInterval(time.Minute))
+		ctx     = context.Background()
+	)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Touch(ctx, 1, 10, "jwt")
+	tracker.Touch(ctx, 1, 10, "jwt")
+	tracker.Touch(ctx, 1, 10, "oauth2")
+	tracker.Touch(ctx, 0, 10, "jwt")
+	assert.Len(t, tracker.queue, 2, "touches in the interval must be throttled")
+
+	now = now.Add(time.Minute)
+	tracker.Touch(ctx, 1, 10, "jwt")
+	assert.Len(t, tracker.queue, 3, "touch after the interval must be queued")
+
+	runCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	tracker.Run(runCtx)
+	assert.Len(t, repo.touches, 3, "queued touches must be written on exit")
+
+	activity := <-repo.touches
+	assert.Equal(t, uint64(1), activity.UserID)
+	assert.Equal(t, uint64(10), activity.AccountID)
+	assert.Equal(t, "jwt", activity.Authorizer)
+}
+
+func TestActivityTrackerQueueOverflow(t *testing.T) {
+	var (
+		repo    = &activityRepoStub{touches: make(chan *account.MemberActivity, 10)}
+		tracker = NewActivityTracker(repo, WithActivityQueueSize(1))
+		ctx     = context.Background()
+	)
+
+	tracker.Touch(ctx, 1, 10, "jwt")
+	tracker.Touch(ctx, 2, 10, "jwt")
+	assert.Len(t, tracker.queue, 1)
+
+	// The dropped touch isn't throttled and retried by the next request
+	<-tracker.queue
+	tracker.Touch(ctx, 2, 10, "jwt")
+	assert.Len(t, tracker.queue, 1)
+}
@@ -3,17 +3,28 @@ package auth
 import (
 	"context"
 	"errors"
+	"strings"
+
+	"github.com/demdxx/rbac"
 
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/pkg/permissions"
 	"github.com/geniusrabbit/blaze-api/repository/account"
+	rbacModels "github.com/geniusrabbit/blaze-api/repository/rbac/models"
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
 
+// systemAdminRole can impersonate the admins of any account
+const systemAdminRole = "system:admin"
+
 var (
 	// errAuthUserIsNotMemberOfAccount is returned when a user is not a member of the target account
 	errAuthUserIsNotMemberOfAccount = errors.New("user is not a member of the account")
 	// errNoCrossAuthPermission is returned when a user lacks cross-account authentication permissions
 	errNoCrossAuthPermission = errors.New("user don't have cross auth permissions")
+	// errRestrictionUnsupported is returned when the permission scopes can't be applied to the account
+	errRestrictionUnsupported = errors.New("account doesn't support permission restrictions")
 )
 
 // Loader resolves user+account pairs with membership and permission checks.
@@ -21,6 +32,19 @@ type Loader[TUser user.Model, TAccount account.Model] struct {
 	Users    user.Repository[TUser]
 	Accounts account.SessionRepository[TUser, TAccount]
 	Members  account.MemberRepository[TUser, TAccount]
+
+	// Activity tracker of the authorized sessions, optional
+	Activity *ActivityTracker
+}
+
+// LoaderOption configures the session loader.
+type LoaderOption[TUser user.Model, TAccount account.Model] func(*Loader[TUser, TAccount])
+
+// WithActivityTracker records the last activity of the sessions authorized by the middleware
+func WithActivityTracker[TUser user.Model, TAccount account.Model](tracker *ActivityTracker) LoaderOption[TUser, TAccount] {
+	return func(l *Loader[TUser, TAccount]) {
+		l.Activity = tracker
+	}
 }
 
 // NewLoader creates a session loader for auth middleware and authorizers.
@@ -28,12 +52,17 @@ func NewLoader[TUser user.Model, TAccount account.Model](
 	users user.Repository[TUser],
 	accounts account.SessionRepository[TUser, TAccount],
 	members account.MemberRepository[TUser, TAccount],
+	opts ...LoaderOption[TUser, TAccount],
 ) *Loader[TUser, TAccount] {
-	return &Loader[TUser, TAccount]{
+	loader := &Loader[TUser, TAccount]{
 		Users:    users,
 		Accounts: accounts,
 		Members:  members,
 	}
+	for _, opt := range opts {
+		opt(loader)
+	}
+	return loader
 }
 
 // UserAccountByID retrieves and validates a user and account by their IDs, ensuring proper permissions.
@@ -62,20 +91,41 @@ func (l *Loader[TUser, TAccount]) UserAccountByID(ctx context.Context, uID, accI
 		}
 	}
 
+	if err = l.CheckSuspended(userObj, account); err != nil {
+		return zeroUser, zeroAcc, err
+	}
+
 	if any(account) != any(zeroAcc) {
-		if any(userObj) != any(zeroUser) && !l.Members.IsMember(ctx, userObj.GetID(), account.GetID()) {
+		// The members of the parent account act in the sub-account inheriting
+		// the permissions without the membership and cross auth permission
+		inherited := any(prevAccount) != any(zeroAcc) && any(userObj) != any(zeroUser) &&
+			any(preUser) != any(zeroUser) && userObj.GetID() == preUser.GetID() &&
+			isInheritedSubAccount(prevAccount, account.GetID())
+
+		if !inherited && any(userObj) != any(zeroUser) && !l.Members.IsMember(ctx, userObj.GetID(), account.GetID()) {
 			return zeroUser, zeroAcc, errAuthUserIsNotMemberOfAccount
 		}
 
-		if any(prevAccount) != any(zeroAcc) && prevAccount.GetID() != account.GetID() &&
+		if !inherited && any(prevAccount) != any(zeroAcc) && prevAccount.GetID() != account.GetID() &&
 			!prevAccount.CheckPermissions(ctx, account, session.PermAuthCross) {
 			return zeroUser, zeroAcc, errNoCrossAuthPermission
 		}
 
+		// The connected account can't have more rights than the restricted one
+		if any(prevAccount) != any(zeroAcc) && prevAccount.GetID() != account.GetID() {
+			if err = copyRestrictions(account, prevAccount); err != nil {
+				return zeroUser, zeroAcc, err
+			}
+		}
+
 		if err = l.Accounts.LoadPermissions(ctx, account, userObj); err != nil {
 			return zeroUser, zeroAcc, err
 		}
 
+		if err = l.LoadSubAccounts(ctx, account); err != nil {
+			return zeroUser, zeroAcc, err
+		}
+
 		if any(prevAccount) != any(zeroAcc) {
 			account.ExtendPermissions(prevAccount.PermissionsChecker())
 		}
@@ -85,6 +135,7 @@ func (l *Loader[TUser, TAccount]) UserAccountByID(ctx context.Context, uID, accI
 }
 
 // CrossAccountConnect validates and connects to a cross-account context if specified via header.
+// The members of the parent account can connect to its sub-accounts inheriting the permissions.
 // Example:
 //
 //	CrossAuthHeader: "X-Cross-Auth"
@@ -98,3 +149,178 @@ func (l *Loader[TUser, TAccount]) CrossAccountConnect(ctx context.Context, cross
 	}
 	return userObj, accountObj, nil
 }
+
+// CheckSuspended returns the error if the user or the account is suspended.
+// The suspended objects keep the data but any session of them is rejected.
+func (l *Loader[TUser, TAccount]) CheckSuspended(userObj TUser, accountObj TAccount) error {
+	if pkgModels.IsSuspended(userObj) {
+		return account.ErrUserSuspended
+	}
+	if pkgModels.IsSuspended(accountObj) {
+		return account.ErrAccountSuspended
+	}
+	return nil
+}
+
+// CheckImpersonator returns the error if the real actor of the impersonated session
+// doesn't exist anymore or is suspended.
+func (l *Loader[TUser, TAccount]) CheckImpersonator(ctx context.Context, actorID uint64) error {
+	actor, err := l.Users.Get(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if pkgModels.IsSuspended(actor) {
+		return account.ErrUserSuspended
+	}
+	return nil
+}
+
+// CheckImpersonationTarget returns the error if the impersonated user has more rights than
+// the actor of the session. The admin of the account can be impersonated only by the admin
+// of the same account or by the system admin, the system roles of the user must be granted
+// to the actor as well.
+func (l *Loader[TUser, TAccount]) CheckImpersonationTarget(ctx context.Context, userObj TUser, accountObj TAccount) error {
+	var zeroUser TUser
+	var zeroAcc TAccount
+	if any(userObj) == any(zeroUser) || any(accountObj) == any(zeroAcc) {
+		return nil
+	}
+	var (
+		actor, actorAcc = session.UserAccount(ctx)
+		actorRoles      []rbac.Role
+	)
+	if actorAcc != nil && actorAcc.PermissionsChecker() != nil {
+		actorRoles = actorAcc.PermissionsChecker().ChildRoles()
+	}
+	if accountObj.IsAdminUser(userObj.GetID()) {
+		isActorAdmin := actorAcc != nil && actorAcc.GetID() == accountObj.GetID() &&
+			actorAcc.IsAdminUser(actor.GetID())
+		if !isActorAdmin && !hasGlobalRole(actorRoles, systemAdminRole) {
+			return account.ErrImpersonatePrivileged
+		}
+	}
+	if checker := accountObj.PermissionsChecker(); checker != nil {
+		for _, role := range systemRoles(checker.ChildRoles()) {
+			if !hasRole(actorRoles, role) {
+				return account.ErrImpersonatePrivileged
+			}
+		}
+	}
+	return nil
+}
+
+// TouchActivity of the user in the account through the authorizer.
+// The impersonated sessions are skipped as the user doesn't act by itself.
+func (l *Loader[TUser, TAccount]) TouchActivity(ctx context.Context, authorizer string, userObj TUser, accountObj TAccount) {
+	var zeroUser TUser
+	var zeroAcc TAccount
+	if l.Activity == nil || any(userObj) == any(zeroUser) || session.IsImpersonated(ctx) {
+		return
+	}
+	var accountID uint64
+	if any(accountObj) != any(zeroAcc) {
+		accountID = accountObj.GetID()
+	}
+	l.Activity.Touch(ctx, userObj.GetID(), accountID, authorizer)
+}
+
+// LoadSubAccounts sets the sub-accounts inheriting the permissions of the account
+// if the account model supports the hierarchy.
+func (l *Loader[TUser, TAccount]) LoadSubAccounts(ctx context.Context, accountObj TAccount) error {
+	hierarchy, ok := any(accountObj).(account.HierarchyModel)
+	if !ok {
+		return nil
+	}
+	ids, err := l.Accounts.SubAccountIDs(ctx, accountObj.GetID(), true)
+	if err != nil {
+		return err
+	}
+	hierarchy.SetSubAccounts(ids...)
+	return nil
+}
+
+// systemRoleNames returns the names of the system level roles in the role tree
+func systemRoles(roles []rbac.Role) []rbac.Role {
+	var list []rbac.Role
+	for _, role := range roles {
+		if role == nil {
+			continue
+		}
+		if isSystemRole(role) {
+			list = append(list, role)
+		}
+		list = append(list, systemRoles(role.ChildRoles())...)
+	}
+	return list
+}
+
+// isSystemRole by the access level, the custom roles of the accounts are never system ones
+func isSystemRole(role rbac.Role) bool {
+	if data, _ := role.Ext().(*permissions.ExtData); data != nil && data.AccountID != 0 {
+		return false
+	} else if data != nil && data.AccessLevel >= rbacModels.AccessLevelSystem {
+		return true
+	}
+	return strings.HasPrefix(role.Name(), "system:")
+}
+
+// isGlobalRole reports whether the role doesn't belong to any account
+func isGlobalRole(role rbac.Role) bool {
+	data, _ := role.Ext().(*permissions.ExtData)
+	return data == nil || data.AccountID == 0
+}
+
+// sameRole compares the loaded roles by ID and falls back to the names of the global roles
+func sameRole(a, b rbac.Role) bool {
+	da, _ := a.Ext().(*permissions.ExtData)
+	db, _ := b.Ext().(*permissions.ExtData)
+	if da != nil && db != nil {
+		return da.ID == db.ID
+	}
+	return a.Name() == b.Name() && isGlobalRole(a) && isGlobalRole(b)
+}
+
+// hasRole reports whether the role or any of their children is the target one
+func hasRole(roles []rbac.Role, target rbac.Role) bool {
+	for _, role := range roles {
+		if role != nil && (sameRole(role, target) || hasRole(role.ChildRoles(), target)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGlobalRole reports whether the roles include the global role with the name
+func hasGlobalRole(roles []rbac.Role, name string) bool {
+	for _, role := range roles {
+		if role == nil {
+			continue
+		}
+		if (role.Name() == name && isGlobalRole(role)) || hasGlobalRole(role.ChildRoles(), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInheritedSubAccount reports whether the account inherits the permissions of the parent one
+func isInheritedSubAccount(parent account.Model, id uint64) bool {
+	hierarchy, ok := any(parent).(account.HierarchyModel)
+	return ok && hierarchy.IsSubAccount(id)
+}
+
+// copyRestrictions applies the permission scopes of the source account to the target one
+func copyRestrictions(target, source account.Model) error {
+	src, ok := any(source).(account.PermissionRestrictor)
+	if !ok || len(src.PermissionRestrictions()) == 0 {
+		return nil
+	}
+	dst, ok := any(target).(account.PermissionRestrictor)
+	if !ok {
+		return errRestrictionUnsupported
+	}
+	for _, scopes := range src.PermissionRestrictions() {
+		dst.RestrictPermissions(scopes...)
+	}
+	return nil
+}
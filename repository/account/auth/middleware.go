@@ -23,7 +23,7 @@ func Middleware[TUser user.Model, TAccount account.Model](
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		token, user, acc, err := authWrap.Authorize(w, r)
+		code, token, user, acc, err := authWrap.AuthorizeWithCode(w, r)
 		if err != nil {
 			ctxlogger.Get(ctx).Error("authorize", zap.Error(err))
 			unauthorized(w)
@@ -35,8 +35,25 @@ func Middleware[TUser user.Model, TAccount account.Model](
 		if any(user) == any(zeroUser) && any(acc) == any(zeroAcc) {
 			ctx = session.WithAnonymousUserAccount(ctx)
 		} else {
+			// The impersonated session is limited by the account of the token
+			// and can't outlive the real actor
+			if imp := session.GetImpersonation(r.Context()); imp != nil {
+				if r.Header.Get(session.CrossAuthHeader) != "" {
+					ctxlogger.Get(ctx).Error("cross account connect of the impersonated session")
+					unauthorized(w)
+					return
+				}
+				if err = loader.CheckImpersonator(ctx, imp.ActorID); err != nil {
+					ctxlogger.Get(ctx).Error("impersonation actor", zap.Error(err))
+					unauthorized(w)
+					return
+				}
+				ctx = session.WithImpersonation(ctx, imp)
+			}
+
 			// Example:
 			// Header: auth.cross.account = AccountID[:UserID]
+			prevAcc := acc
 			user, acc, err = loader.CrossAccountConnect(ctx, r.Header.Get(session.CrossAuthHeader), user, acc)
 			if err != nil {
 				ctxlogger.Get(ctx).Error("cross account connect", zap.Error(err))
@@ -44,7 +61,15 @@ func Middleware[TUser user.Model, TAccount account.Model](
 				return
 			}
 
-			if any(acc) != any(zeroAcc) {
+			// The suspended user or account can't act even with the valid token
+			if err = loader.CheckSuspended(user, acc); err != nil {
+				ctxlogger.Get(ctx).Error("suspended session", zap.Error(err))
+				unauthorized(w)
+				return
+			}
+
+			// The connected account is already checked and loaded by the loader
+			if any(acc) != any(zeroAcc) && any(acc) == any(prevAcc) {
 				if any(user) != any(zeroUser) && !loader.Members.IsMember(ctx, user.GetID(), acc.GetID()) {
 					ctxlogger.Get(ctx).Error("user is not a member of the account")
 					unauthorized(w)
@@ -56,9 +81,16 @@ func Middleware[TUser user.Model, TAccount account.Model](
 					unauthorized(w)
 					return
 				}
+
+				if err = loader.LoadSubAccounts(ctx, acc); err != nil {
+					ctxlogger.Get(ctx).Error("load sub-accounts", zap.Error(err))
+					unauthorized(w)
+					return
+				}
 			}
 
 			ctx = session.WithUserAccount(ctx, user, acc)
+			loader.TouchActivity(ctx, code, user, acc)
 		}
 
 		next.ServeHTTP(w, r.WithContext(session.WithToken(ctx, token)))
@@ -0,0 +1,133 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	accountModels "github.com/geniusrabbit/blaze-api/repository/account/models"
+	userMocks "github.com/geniusrabbit/blaze-api/repository/user/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type testAccount struct {
+	accountModels.AccountBase
+}
+
+func (a *testAccount) TableName() string { return "account_base" }
+
+func (a *testAccount) NewWithIDs(id uint64, adminUserIDs ...uint64) account.Model {
+	return &testAccount{AccountBase: accountModels.AccountBase{ID: id, Admins: adminUserIDs}}
+}
+
+// impersonationAuthorizer authorizes the user 5 in the account 2 on behalf of the actor
+// the same way as the JWT authorizer with the impersonation token
+type impersonationAuthorizer struct {
+	actorID uint64
+}
+
+func (a *impersonationAuthorizer) AuthorizerCode() string { return "jwt" }
+
+func (a *impersonationAuthorizer) Authorize(_ http.ResponseWriter, r *http.Request) (string, *testutil.User, *testAccount, error) {
+	if a.actorID > 0 {
+		*r = *r.WithContext(session.WithImpersonation(r.Context(),
+			&session.Impersonation{ActorID: a.actorID, ExpiresAt: time.Now().Add(time.Minute)}))
+	}
+	return "token", testutil.Stub(5), &testAccount{AccountBase: accountModels.AccountBase{ID: 2}}, nil
+}
+
+type testMiddlewareSuite struct {
+	suite.Suite
+
+	users    *userMocks.MockRepository[*testutil.User]
+	accounts *mocks.MockSessionRepository[*testutil.User, *testAccount]
+	members  *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	loader   *accauth.Loader[*testutil.User, *testAccount]
+}
+
+func (s *testMiddlewareSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.users = userMocks.NewMockRepository[*testutil.User](ctrl)
+	s.accounts = mocks.NewMockSessionRepository[*testutil.User, *testAccount](ctrl)
+	s.members = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.loader = accauth.NewLoader(s.users, s.accounts, s.members)
+}
+
+// serve the request through the middleware, the session of the handler is returned if it's called
+func (s *testMiddlewareSuite) serve(actorID uint64, header http.Header) (*httptest.ResponseRecorder, context.Context) {
+	var sessCtx context.Context
+	handler := accauth.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		sessCtx = r.Context()
+	}), s.loader, &impersonationAuthorizer{actorID: actorID})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w, sessCtx
+}
+
+func (s *testMiddlewareSuite) expectSession() {
+	s.members.EXPECT().IsMember(gomock.Any(), uint64(5), uint64(2)).Return(true)
+	s.accounts.EXPECT().LoadPermissions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	s.accounts.EXPECT().SubAccountIDs(gomock.Any(), uint64(2), true).Return(nil, nil)
+}
+
+func (s *testMiddlewareSuite) TestSession() {
+	s.expectSession()
+	w, ctx := s.serve(0, nil)
+	s.Equal(http.StatusOK, w.Code)
+	s.Require().NotNil(ctx)
+	s.Equal(uint64(5), session.UserID(ctx))
+	s.False(session.IsImpersonated(ctx))
+}
+
+func (s *testMiddlewareSuite) TestImpersonatedSession() {
+	s.users.EXPECT().Get(gomock.Any(), uint64(1)).Return(testutil.Stub(1), nil)
+	s.expectSession()
+	w, ctx := s.serve(1, nil)
+	s.Equal(http.StatusOK, w.Code)
+	s.Require().NotNil(ctx)
+	s.Equal(uint64(5), session.UserID(ctx))
+	s.Equal(uint64(1), session.ImpersonatorID(ctx))
+}
+
+func (s *testMiddlewareSuite) TestImpersonationActorNotFound() {
+	s.users.EXPECT().Get(gomock.Any(), uint64(1)).Return(nil, errors.New("not found"))
+	w, ctx := s.serve(1, nil)
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.Nil(ctx)
+}
+
+func (s *testMiddlewareSuite) TestImpersonationActorSuspended() {
+	actor := testutil.Stub(1)
+	actor.Suspend("abuse")
+	s.users.EXPECT().Get(gomock.Any(), uint64(1)).Return(actor, nil)
+	w, ctx := s.serve(1, nil)
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.Nil(ctx)
+}
+
+func (s *testMiddlewareSuite) TestImpersonationCrossAccount() {
+	header := http.Header{}
+	header.Set(session.CrossAuthHeader, "3")
+	w, ctx := s.serve(1, header)
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.Nil(ctx)
+}
+
+func TestMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(testMiddlewareSuite))
+}
@@ -1,28 +1,33 @@
 package authorizer
 
 import (
+	"errors"
 	"net/http"
 
 	"go.uber.org/zap"
 
 	"github.com/geniusrabbit/blaze-api/pkg/auth/tokenextractor"
+	"github.com/geniusrabbit/blaze-api/pkg/context/clientip"
 	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken"
+	datauth "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/auth"
+	datrepo "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/repository"
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
 
 // DirectTokenAuthorizer implements authorization using direct token authentication.
 type DirectTokenAuthorizer[TUser user.Model, TAccount account.Model] struct {
-	extractor TokenExtractor
-	loader    *accauth.Loader[TUser, TAccount]
+	extractor     TokenExtractor
+	authenticator *datauth.Authenticator[TUser, TAccount]
 }
 
 // NewDirectTokenAuthorizer creates a new instance of DirectTokenAuthorizer.
 func NewDirectTokenAuthorizer[TUser user.Model, TAccount account.Model](loader *accauth.Loader[TUser, TAccount]) *DirectTokenAuthorizer[TUser, TAccount] {
 	return &DirectTokenAuthorizer[TUser, TAccount]{
-		extractor: tokenextractor.DefaultExtractor,
-		loader:    loader,
+		extractor:     tokenextractor.DefaultExtractor,
+		authenticator: datauth.NewAuthenticator(datrepo.NewDirectAccessTokenRepository(), loader),
 	}
 }
 
@@ -33,6 +38,9 @@ func (au *DirectTokenAuthorizer[TUser, TAccount]) AuthorizerCode() string {
 
 // Authorize validates the request by extracting and verifying the token,
 // then retrieves the associated user and account information.
+// The address allow-list and the permission scopes of the token are applied.
+// The tokens issued before the prefix are looked up by the hash as well, if such
+// token is not found the request is passed to the next authorizer.
 func (au *DirectTokenAuthorizer[TUser, TAccount]) Authorize(w http.ResponseWriter, r *http.Request) (string, TUser, TAccount, error) {
 	var zeroUser TUser
 	var zeroAcc TAccount
@@ -47,6 +55,17 @@ func (au *DirectTokenAuthorizer[TUser, TAccount]) Authorize(w http.ResponseWrite
 		return "", zeroUser, zeroAcc, nil
 	}
 
-	userObj, accountObj, err := au.loader.Accounts.GetByToken(ctx, token)
-	return token, userObj, accountObj, err
+	ip := clientip.Get(ctx)
+	if ip == "" {
+		ip = clientip.Normalize(r.RemoteAddr)
+	}
+
+	userObj, accountObj, err := au.authenticator.Authenticate(ctx, token, ip)
+	if errors.Is(err, datauth.ErrTokenNotFound) && !directaccesstoken.IsDirectAccessToken(token) {
+		return "", zeroUser, zeroAcc, nil
+	}
+	if err != nil {
+		return token, zeroUser, zeroAcc, err
+	}
+	return token, userObj, accountObj, nil
 }
@@ -0,0 +1,106 @@
+package authorizer_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	"github.com/geniusrabbit/blaze-api/repository/account/authorizer"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	accountModels "github.com/geniusrabbit/blaze-api/repository/account/models"
+	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken"
+	datauth "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/auth"
+	"github.com/geniusrabbit/blaze-api/repository/testsuite"
+	userMocks "github.com/geniusrabbit/blaze-api/repository/user/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type testAccount struct {
+	accountModels.AccountBase
+}
+
+func (a *testAccount) TableName() string { return "account_base" }
+
+func (a *testAccount) NewWithIDs(id uint64, adminUserIDs ...uint64) account.Model {
+	return &testAccount{AccountBase: accountModels.AccountBase{ID: id, Admins: adminUserIDs}}
+}
+
+type testDirectTokenSuite struct {
+	testsuite.DatabaseSuite
+
+	users      *userMocks.MockRepository[*testutil.User]
+	accounts   *mocks.MockSessionRepository[*testutil.User, *testAccount]
+	members    *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	authorizer *authorizer.DirectTokenAuthorizer[*testutil.User, *testAccount]
+}
+
+func (s *testDirectTokenSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.users = userMocks.NewMockRepository[*testutil.User](ctrl)
+	s.accounts = mocks.NewMockSessionRepository[*testutil.User, *testAccount](ctrl)
+	s.members = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.authorizer = authorizer.NewDirectTokenAuthorizer(accauth.NewLoader(s.users, s.accounts, s.members))
+}
+
+func (s *testDirectTokenSuite) request(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(s.Ctx)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func (s *testDirectTokenSuite) TestLegacyToken() {
+	const token = "0123456789ABCDEFabcdef"
+	acc := &testAccount{AccountBase: accountModels.AccountBase{ID: 2}}
+
+	s.Mock.ExpectQuery(`SELECT \* FROM "direct_access_tokens"`).
+		WithArgs(directaccesstoken.HashToken(token), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token", "user_id", "account_id", "expires_at"}).
+			AddRow(7, directaccesstoken.HashToken(token), 1, 2, time.Now().Add(time.Hour)))
+	s.Mock.ExpectExec(`UPDATE "direct_access_tokens"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.users.EXPECT().Get(gomock.Any(), uint64(1)).Return(testutil.Stub(1), nil)
+	s.accounts.EXPECT().Get(gomock.Any(), uint64(2)).Return(acc, nil)
+	s.members.EXPECT().IsMember(gomock.Any(), uint64(1), uint64(2)).Return(true)
+	s.accounts.EXPECT().LoadPermissions(gomock.Any(), acc, gomock.Any()).Return(nil)
+	s.accounts.EXPECT().SubAccountIDs(gomock.Any(), uint64(2), true).Return(nil, nil)
+
+	rToken, userObj, accObj, err := s.authorizer.Authorize(httptest.NewRecorder(), s.request(token))
+	s.NoError(err)
+	s.Equal(token, rToken)
+	s.Equal(uint64(1), userObj.GetID())
+	s.Equal(uint64(2), accObj.GetID())
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *testDirectTokenSuite) TestUnknownLegacyToken() {
+	s.Mock.ExpectQuery(`SELECT \* FROM "direct_access_tokens"`).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	rToken, userObj, accObj, err := s.authorizer.Authorize(httptest.NewRecorder(), s.request("jwt.token.value"))
+	s.NoError(err, "unknown token without the prefix must be passed to the next authorizer")
+	s.Empty(rToken)
+	s.Nil(userObj)
+	s.Nil(accObj)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *testDirectTokenSuite) TestUnknownToken() {
+	s.Mock.ExpectQuery(`SELECT \* FROM "direct_access_tokens"`).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, _, _, err := s.authorizer.Authorize(httptest.NewRecorder(), s.request(directaccesstoken.TokenPrefix+"unknown"))
+	s.ErrorIs(err, datauth.ErrInvalidToken)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func TestDirectTokenAuthorizerSuite(t *testing.T) {
+	suite.Run(t, new(testDirectTokenSuite))
+}
@@ -21,3 +21,17 @@ func SessionAccount(ctx context.Context) SessionModel {
 	}
 	return nil
 }
+
+// InSessionScope reports whether the account is the session account
+// or its sub-account inheriting the permissions.
+func InSessionScope(ctx context.Context, accountID uint64) bool {
+	acc := SessionAccount(ctx)
+	if acc == nil || accountID == 0 {
+		return false
+	}
+	if acc.GetID() == accountID {
+		return true
+	}
+	sub, ok := acc.(interface{ IsSubAccount(id uint64) bool })
+	return ok && sub.IsSubAccount(accountID)
+}
@@ -41,3 +41,20 @@ type MemberModel interface {
 	GetID() uint64
 	OwnerAccountID() uint64
 }
+
+// PermissionRestrictor is implemented by the account models which permissions
+// could be limited by scope patterns (for example by the scoped access tokens).
+type PermissionRestrictor interface {
+	RestrictPermissions(scopes ...string)
+	PermissionRestrictions() [][]string
+}
+
+// HierarchyModel is implemented by the account models which can be nested into
+// the parent account. The sub-accounts which inherit the permissions are loaded
+// with the session account and extend the scope of its account level permissions.
+type HierarchyModel interface {
+	GetParentID() uint64
+	InheritsPermissions() bool
+	SetSubAccounts(ids ...uint64)
+	IsSubAccount(id uint64) bool
+}
@@ -0,0 +1,109 @@
+package accountlogin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/demdxx/gocast/v2"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/messanger"
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// MessageLoginLink is the name of the messanger template with the login link.
+// Template variables: user, email, login_token, login_link, expires_at
+const MessageLoginLink = "user.login-link"
+
+var errLoginLinkDisabled = errors.New(`login by link is disabled`)
+
+// LoginLinkAuth is the interface for the login by the single-use email link
+// (implemented by the user email usecase).
+type LoginLinkAuth[T user.Model] interface {
+	RequestLoginLink(ctx context.Context, email string) (*user.UserEmailToken, T, error)
+	LoginByLink(ctx context.Context, token string) (T, error)
+}
+
+// WithLoginLink enables the login by the email link.
+// linkURL is the template of the link with {token} and {email} placeholders,
+// e.g. https://example.com/login?token={token}
+func WithLoginLink[TUser user.Model, TAccount account.Model](auth LoginLinkAuth[TUser], linkURL string) Option[TUser, TAccount] {
+	return func(r *Resolver[TUser, TAccount]) {
+		r.linkAuth = auth
+		r.linkURL = linkURL
+	}
+}
+
+// RequestLoginLink resolves mutation { requestLoginLink(email) }.
+func (r *Resolver[TUser, TAccount]) RequestLoginLink(ctx context.Context, email string) (*gqlmodels.StatusResponse, error) {
+	if r.linkAuth == nil {
+		return nil, errLoginLinkDisabled
+	}
+	if !messanger.Get(ctx).IsEnabled() {
+		ctxlogger.Get(ctx).Error("Email service not configured")
+		return linkResponse(ctx, gqlmodels.ResponseStatusError, "Internal service problem. Request again later."), nil
+	}
+	if err := r.checkSSO(ctx, email); err != nil {
+		return nil, err
+	}
+
+	token, userObj, err := r.linkAuth.RequestLoginLink(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != nil && !gocast.IsNil(userObj) {
+		err = messanger.Get(ctx).Send(ctx, MessageLoginLink, []string{token.Email}, map[string]any{
+			"user":        userObj,
+			"email":       token.Email,
+			"login_token": token.Token,
+			"login_link":  messanger.Link(r.linkURL, map[string]string{"token": token.Token, "email": token.Email}),
+			"expires_at":  token.ExpiresAt,
+		})
+		if err != nil {
+			ctxlogger.Get(ctx).Error("Error sending login link email",
+				zap.String("msgname", MessageLoginLink),
+				zap.Error(err))
+			return linkResponse(ctx, gqlmodels.ResponseStatusError, "Error sending login link email"), nil
+		}
+	} else {
+		ctxlogger.Get(ctx).Info("Login link is not sent, user not found or cooldown", zap.String("email", email))
+	}
+
+	return linkResponse(ctx, gqlmodels.ResponseStatusSuccess, "Login link sent to "+email), nil
+}
+
+// LoginByLink resolves mutation { loginByLink(token, accountID) }.
+// accountID is optional — nil means use the user's default account.
+func (r *Resolver[TUser, TAccount]) LoginByLink(ctx context.Context, token string, accountID ...uint64) (*gqlmodels.SessionToken, error) {
+	if r.linkAuth == nil {
+		return nil, errLoginLinkDisabled
+	}
+	var accID uint64
+	if len(accountID) > 0 {
+		accID = accountID[0]
+	}
+	user, err := r.linkAuth.LoginByLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	// The link could be requested before the domain enforced the single sign-on
+	if em, ok := any(user).(interface{ GetEmail() string }); ok {
+		if err = r.checkSSO(ctx, em.GetEmail()); err != nil {
+			return nil, err
+		}
+	}
+	return r.newSession(ctx, user, accID)
+}
+
+func linkResponse(ctx context.Context, status gqlmodels.ResponseStatus, msg string) *gqlmodels.StatusResponse {
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           status,
+		Message:          &msg,
+	}
+}
@@ -18,7 +18,10 @@ import (
 	lrbac "github.com/demdxx/rbac"
 	"github.com/demdxx/xtypes"
 
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
 	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/pkg/context/clientip"
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
 	"github.com/geniusrabbit/blaze-api/repository"
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	"github.com/geniusrabbit/blaze-api/repository/user"
@@ -27,6 +30,12 @@ import (
 
 var errInvalidAccountTarget = errors.New(`invalid account target`)
 
+// ErrUserNotApproved returned if only approved users can login
+var ErrUserNotApproved = user.ErrUserNotApproved
+
+// LimiterAction is the name of the login action in the brute-force limiter
+const LimiterAction = "login"
+
 // LoginPasswordAuth is the interface for username/password login.
 type LoginPasswordAuth[T user.Model] interface {
 	Login(ctx context.Context, login, password string) (T, error)
@@ -61,6 +70,37 @@ type Resolver[TUser user.Model, TAccount account.Model] struct {
 	provider    *jwt.Provider
 	userLogin   LoginPasswordAuth[TUser]
 	accountRepo account.SessionRepository[TUser, TAccount]
+	limiter     *bruteforce.Limiter
+
+	approvedOnly bool
+	linkAuth     LoginLinkAuth[TUser]
+	linkURL      string
+	ssoPolicy    SSOPolicy
+}
+
+// Option of the login resolver
+type Option[TUser user.Model, TAccount account.Model] func(*Resolver[TUser, TAccount])
+
+// WithLimiter protects the login from password guessing
+func WithLimiter[TUser user.Model, TAccount account.Model](limiter *bruteforce.Limiter) Option[TUser, TAccount] {
+	return func(r *Resolver[TUser, TAccount]) { r.limiter = limiter }
+}
+
+// WithApprovedOnly rejects the login of users which are not approved yet
+// (e.g. the email is not verified, see usecase.WithApproveAfterVerification and user.CheckLoginApproved)
+func WithApprovedOnly[TUser user.Model, TAccount account.Model]() Option[TUser, TAccount] {
+	return func(r *Resolver[TUser, TAccount]) { r.approvedOnly = true }
+}
+
+// SSOPolicy reports whether the email must log in only through the single sign-on
+// (implemented by the account domain usecase).
+type SSOPolicy interface {
+	SSORequired(ctx context.Context, email string) (bool, error)
+}
+
+// WithSSOPolicy rejects the password and the link login for the emails of the SSO enforced domains
+func WithSSOPolicy[TUser user.Model, TAccount account.Model](policy SSOPolicy) Option[TUser, TAccount] {
+	return func(r *Resolver[TUser, TAccount]) { r.ssoPolicy = policy }
 }
 
 // New wraps an existing AuthResolver to serve the login mutation.
@@ -68,8 +108,13 @@ func New[TUser user.Model, TAccount account.Model](
 	provider *jwt.Provider,
 	userLogin LoginPasswordAuth[TUser],
 	accountRepo account.SessionRepository[TUser, TAccount],
+	opts ...Option[TUser, TAccount],
 ) *Resolver[TUser, TAccount] {
-	return &Resolver[TUser, TAccount]{provider: provider, userLogin: userLogin, accountRepo: accountRepo}
+	r := &Resolver[TUser, TAccount]{provider: provider, userLogin: userLogin, accountRepo: accountRepo}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Login resolves mutation { login(email, password, accountID) }.
@@ -80,25 +125,78 @@ func (r *Resolver[TUser, TAccount]) Login(ctx context.Context, login, password s
 		accID = accountID[0]
 	}
 
+	limitKeys := []bruteforce.Key{
+		bruteforce.EmailKey(login),
+		bruteforce.IPKey(clientip.Get(ctx)),
+		bruteforce.AccountKey(accID),
+	}
+	if err := r.limiter.Check(ctx, LimiterAction, limitKeys...); err != nil {
+		return nil, err
+	}
+	if err := r.checkSSO(ctx, login); err != nil {
+		return nil, err
+	}
+
 	user, err := r.userLogin.Login(ctx, login, password)
 	if err != nil {
+		if limErr := r.limiter.Fail(ctx, LimiterAction, limitKeys...); limErr != nil {
+			return nil, limErr
+		}
+		return nil, err
+	}
+
+	// NOTE: Only the user counter is reset, IP and account counters are kept to prevent
+	//       the guessing interleaved with the successful logins into the own account
+	if err := r.limiter.Reset(ctx, LimiterAction, limitKeys[0]); err != nil {
 		return nil, err
 	}
 
-	acc, err := r.accountForUser(ctx, user, accID)
+	return r.newSession(ctx, user, accID)
+}
+
+// checkSSO rejects the login of the email from the SSO enforced domain
+func (r *Resolver[TUser, TAccount]) checkSSO(ctx context.Context, email string) error {
+	if r.ssoPolicy == nil {
+		return nil
+	}
+	required, err := r.ssoPolicy.SSORequired(ctx, email)
+	if err != nil {
+		return err
+	}
+	if required {
+		return account.ErrSSORequired
+	}
+	return nil
+}
+
+// newSession creates the session token of the user in the account
+func (r *Resolver[TUser, TAccount]) newSession(ctx context.Context, userObj TUser, accID uint64) (*gqlmodels.SessionToken, error) {
+	if pkgModels.IsSuspended(userObj) {
+		return nil, account.ErrUserSuspended
+	}
+	if r.approvedOnly {
+		if err := user.CheckLoginApproved(userObj); err != nil {
+			return nil, err
+		}
+	}
+
+	acc, err := r.accountForUser(ctx, userObj, accID)
 	if err != nil {
 		return nil, err
 	}
+	if pkgModels.IsSuspended(acc) {
+		return nil, account.ErrAccountSuspended
+	}
 	if !acc.IsNil() && !acc.IsAnonymous() {
 		accID = acc.GetID()
 	}
 
-	token, expiresAt, err := r.provider.CreateToken(user.GetID(), accID, 0)
+	token, expiresAt, err := r.provider.CreateToken(userObj.GetID(), accID, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sessionTokenFromAccount(user, acc, token, expiresAt)
+	return r.sessionTokenFromAccount(userObj, acc, token, expiresAt)
 }
 
 func (r *Resolver[TUser, TAccount]) sessionTokenFromAccount(
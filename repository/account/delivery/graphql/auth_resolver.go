@@ -60,6 +60,11 @@ func (r *AuthResolver[TUser, TAccount]) SwitchAccount(ctx context.Context, id ui
 		return nil, errUserIsNotAuthorized
 	}
 
+	// The impersonation token is issued for the single account
+	if session.IsImpersonated(ctx) {
+		return nil, account.ErrImpersonatedSession
+	}
+
 	var typedUser TUser
 	if u, ok := any(userObj).(TUser); ok {
 		typedUser = u
@@ -70,12 +75,18 @@ func (r *AuthResolver[TUser, TAccount]) SwitchAccount(ctx context.Context, id ui
 		return nil, err
 	}
 
-	token, expiresAt, err := r.provider.CreateToken(userObj.GetID(), acc.GetID(), 0)
+	// The switch is not the login, the new token keeps the login time of the session
+	var authTime time.Time
+	if data, err := r.provider.ParseToken(session.Token(ctx)); err == nil && data.AuthTime > 0 {
+		authTime = time.Unix(data.AuthTime, 0)
+	}
+
+	token, expiresAt, err := r.provider.ReissueToken(authTime, userObj.GetID(), acc.GetID(), 0)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sessionTokenFromAccount(typedUser, acc, token, expiresAt)
+	return sessionTokenFromAccount(typedUser, acc, token, expiresAt), nil
 }
 
 // CurrentSession is the resolver for the currentSession field.
@@ -92,7 +103,17 @@ func (r *AuthResolver[TUser, TAccount]) CurrentSession(ctx context.Context) (*gq
 	if u, ok := any(userObj).(TUser); ok {
 		typedUser = u
 	}
-	return r.sessionTokenFromAccount(typedUser, acc, token, time.Now().Add(r.provider.TokenLifetime))
+	expiresAt := time.Now().Add(r.provider.TokenLifetime)
+	imp := session.GetImpersonation(ctx)
+	if imp != nil {
+		expiresAt = imp.ExpiresAt
+	}
+	sessToken := sessionTokenFromAccount(typedUser, acc, token, expiresAt)
+	if imp != nil {
+		sessToken.IsImpersonated = true
+		sessToken.ImpersonatorID = &imp.ActorID
+	}
+	return sessToken, nil
 }
 
 // ListRolesAndPermissions is the resolver for the listRolesAndPermissions field.
@@ -133,12 +154,13 @@ func (r *AuthResolver[TUser, TAccount]) ListRolesAndPermissions(ctx context.Cont
 	return rbacgql.NewRBACRoleConnectionByIDs(ctx, r.roleRepo, permIDs, order), nil
 }
 
-func (r *AuthResolver[TUser, TAccount]) sessionTokenFromAccount(
+// sessionTokenFromAccount returns the token with the roles of the user in the account
+func sessionTokenFromAccount[TUser user.Model, TAccount account.Model](
 	user TUser,
 	acc TAccount,
 	token string,
 	expiresAt time.Time,
-) (*gqlmodels.SessionToken, error) {
+) *gqlmodels.SessionToken {
 	var zeroAcc TAccount
 	roles := []lrbac.Role{}
 	if any(acc) != any(zeroAcc) {
@@ -159,7 +181,7 @@ func (r *AuthResolver[TUser, TAccount]) sessionTokenFromAccount(
 		ExpiresAt: expiresAt.UTC(),
 		IsAdmin:   isAdmin,
 		Roles:     xtypes.SliceApply(roles, func(r lrbac.Role) string { return r.Name() }),
-	}, nil
+	}
 }
 
 func (r *AuthResolver[TUser, TAccount]) accountForUser(
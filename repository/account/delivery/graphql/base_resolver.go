@@ -171,6 +171,36 @@ func (r *QueryResolver[TUser, TDomain, TGQLAccount, TGQLAccountPayload, TGQLAcco
 	return r.updateApproveStatus(ctx, id, pkgModels.DisapprovedApproveStatus, msg)
 }
 
+// SetAccountParent is the resolver for the setAccountParent field.
+func (r *QueryResolver[TUser, TDomain, TGQLAccount, TGQLAccountPayload, TGQLAccountCreateInput, TGQLAccountUpdateInput, TGQLAccountListFilter, TGQLAccountListOrder, TGQLUser, TGQLUserCreateInput, TGQLUserUpdateInput]) SetAccountParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) (TGQLAccountPayload, error) {
+	acc, err := r.accounts.SetParent(ctx, id, parentID, inheritPermissions)
+	if err != nil {
+		var zero TGQLAccountPayload
+		return zero, err
+	}
+	return r.accountsMapper.NewPayload(requestid.Get(ctx), id, r.accountsMapper.ToGQL(acc)), nil
+}
+
+// SuspendAccount is the resolver for the suspendAccount field.
+func (r *QueryResolver[TUser, TDomain, TGQLAccount, TGQLAccountPayload, TGQLAccountCreateInput, TGQLAccountUpdateInput, TGQLAccountListFilter, TGQLAccountListOrder, TGQLUser, TGQLUserCreateInput, TGQLUserUpdateInput]) SuspendAccount(ctx context.Context, id uint64, reason string) (TGQLAccountPayload, error) {
+	acc, err := r.accounts.Suspend(ctx, id, reason)
+	if err != nil {
+		var zero TGQLAccountPayload
+		return zero, err
+	}
+	return r.accountsMapper.NewPayload(requestid.Get(ctx), id, r.accountsMapper.ToGQL(acc)), nil
+}
+
+// UnsuspendAccount is the resolver for the unsuspendAccount field.
+func (r *QueryResolver[TUser, TDomain, TGQLAccount, TGQLAccountPayload, TGQLAccountCreateInput, TGQLAccountUpdateInput, TGQLAccountListFilter, TGQLAccountListOrder, TGQLUser, TGQLUserCreateInput, TGQLUserUpdateInput]) UnsuspendAccount(ctx context.Context, id uint64) (TGQLAccountPayload, error) {
+	acc, err := r.accounts.Unsuspend(ctx, id)
+	if err != nil {
+		var zero TGQLAccountPayload
+		return zero, err
+	}
+	return r.accountsMapper.NewPayload(requestid.Get(ctx), id, r.accountsMapper.ToGQL(acc)), nil
+}
+
 func (r *QueryResolver[TUser, TDomain, TGQLAccount, TGQLAccountPayload, TGQLAccountCreateInput, TGQLAccountUpdateInput, TGQLAccountListFilter, TGQLAccountListOrder, TGQLUser, TGQLUserCreateInput, TGQLUserUpdateInput]) updateApproveStatus(ctx context.Context, id uint64, status pkgModels.ApproveStatus, msg string) (TGQLAccountPayload, error) {
 	var zero TGQLAccountPayload
 	type approvable interface {
@@ -71,3 +71,87 @@ func NewMemberConnection[TUser user.Model, TDomain account.Model](
 		},
 	}, page)
 }
+
+// MemberInviteConnection implements collection accessor interface with pagination.
+type MemberInviteConnection = connectors.CollectionConnection[*gqlmodels.MemberInvite]
+
+// NewMemberInviteConnection based on query object.
+func NewMemberInviteConnection[TUser user.Model, TDomain account.Model](
+	ctx context.Context,
+	invitesAccessor account.InviteUsecase[TUser, TDomain],
+	filter *gqlmodels.MemberInviteListFilter,
+	order []*gqlmodels.MemberInviteListOrder,
+	page *gqlmodels.Page,
+) *MemberInviteConnection {
+	return connectors.NewCollectionConnection(ctx, &connectors.DataAccessorFunc[*gqlmodels.MemberInvite]{
+		FetchDataListFunc: func(ctx context.Context) ([]*gqlmodels.MemberInvite, error) {
+			opts := []account.QOption{FromMemberInviteGQLFilter(filter), page.Pagination()}
+			for _, o := range order {
+				if ord := FromMemberInviteGQLOrder(o); ord != nil {
+					opts = append(opts, ord)
+				}
+			}
+			invites, err := invitesAccessor.FetchListInvites(ctx, opts...)
+			return FromMemberInviteModelList(invites), err
+		},
+		CountDataFunc: func(ctx context.Context) (int64, error) {
+			return invitesAccessor.CountInvites(ctx, FromMemberInviteGQLFilter(filter))
+		},
+	}, page)
+}
+
+// TeamConnection implements collection accessor interface with pagination.
+type TeamConnection = connectors.CollectionConnection[*gqlmodels.AccountTeam]
+
+// NewTeamConnection based on query object.
+func NewTeamConnection(
+	ctx context.Context,
+	teamsAccessor account.TeamUsecase,
+	filter *gqlmodels.AccountTeamListFilter,
+	order []*gqlmodels.AccountTeamListOrder,
+	page *gqlmodels.Page,
+) *TeamConnection {
+	return connectors.NewCollectionConnection(ctx, &connectors.DataAccessorFunc[*gqlmodels.AccountTeam]{
+		FetchDataListFunc: func(ctx context.Context) ([]*gqlmodels.AccountTeam, error) {
+			opts := []account.QOption{FromTeamGQLFilter(filter), page.Pagination()}
+			for _, o := range order {
+				if ord := FromTeamGQLOrder(o); ord != nil {
+					opts = append(opts, ord)
+				}
+			}
+			teams, err := teamsAccessor.FetchListTeams(ctx, opts...)
+			return FromTeamModelList(ctx, teams), err
+		},
+		CountDataFunc: func(ctx context.Context) (int64, error) {
+			return teamsAccessor.CountTeams(ctx, FromTeamGQLFilter(filter))
+		},
+	}, page)
+}
+
+// DomainConnection implements collection accessor interface with pagination.
+type DomainConnection = connectors.CollectionConnection[*gqlmodels.AccountDomain]
+
+// NewDomainConnection based on query object.
+func NewDomainConnection[TUser user.Model, TDomain account.Model](
+	ctx context.Context,
+	domainsAccessor account.DomainUsecase[TUser, TDomain],
+	filter *gqlmodels.AccountDomainListFilter,
+	order []*gqlmodels.AccountDomainListOrder,
+	page *gqlmodels.Page,
+) *DomainConnection {
+	return connectors.NewCollectionConnection(ctx, &connectors.DataAccessorFunc[*gqlmodels.AccountDomain]{
+		FetchDataListFunc: func(ctx context.Context) ([]*gqlmodels.AccountDomain, error) {
+			opts := []account.QOption{FromDomainGQLFilter(filter), page.Pagination()}
+			for _, o := range order {
+				if ord := FromDomainGQLOrder(o); ord != nil {
+					opts = append(opts, ord)
+				}
+			}
+			domains, err := domainsAccessor.FetchListDomains(ctx, opts...)
+			return FromDomainModelList(domains), err
+		},
+		CountDataFunc: func(ctx context.Context) (int64, error) {
+			return domainsAccessor.CountDomains(ctx, FromDomainGQLFilter(filter))
+		},
+	}, page)
+}
@@ -0,0 +1,78 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// DomainQueryResolver of the account domains
+type DomainQueryResolver[TUser user.Model, TAccount account.Model] struct {
+	domains account.DomainUsecase[TUser, TAccount]
+}
+
+// NewDomainQueryResolver creates the account domains resolver
+func NewDomainQueryResolver[TUser user.Model, TAccount account.Model](domains account.DomainUsecase[TUser, TAccount]) *DomainQueryResolver[TUser, TAccount] {
+	return &DomainQueryResolver[TUser, TAccount]{domains: domains}
+}
+
+// Domain is the resolver for the accountDomain field.
+func (r *DomainQueryResolver[TUser, TAccount]) Domain(ctx context.Context, id uint64) (*models.AccountDomainPayload, error) {
+	domain, err := r.domains.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, domain), nil
+}
+
+// List is the resolver for the listAccountDomains field.
+func (r *DomainQueryResolver[TUser, TAccount]) List(ctx context.Context, filter *models.AccountDomainListFilter, order []*models.AccountDomainListOrder, page *models.Page) (*DomainConnection, error) {
+	return NewDomainConnection(ctx, r.domains, filter, order, page), nil
+}
+
+// Add is the resolver for the addAccountDomain field.
+func (r *DomainQueryResolver[TUser, TAccount]) Add(ctx context.Context, accountID uint64, name string) (*models.AccountDomainPayload, error) {
+	domain, err := r.domains.Add(ctx, accountID, name)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, domain), nil
+}
+
+// Verify is the resolver for the verifyAccountDomain field.
+func (r *DomainQueryResolver[TUser, TAccount]) Verify(ctx context.Context, id uint64) (*models.AccountDomainPayload, error) {
+	domain, err := r.domains.Verify(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, domain), nil
+}
+
+// SetPolicy is the resolver for the setAccountDomainPolicy field.
+func (r *DomainQueryResolver[TUser, TAccount]) SetPolicy(ctx context.Context, id uint64, input *models.AccountDomainPolicyInput) (*models.AccountDomainPayload, error) {
+	domain, err := r.domains.SetPolicy(ctx, id, input.AutoJoin, input.DefaultRoles, input.SsoRequired)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, domain), nil
+}
+
+// Delete is the resolver for the deleteAccountDomain field.
+func (r *DomainQueryResolver[TUser, TAccount]) Delete(ctx context.Context, id uint64) (*models.AccountDomainPayload, error) {
+	domain, err := r.domains.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, domain), nil
+}
+
+func (r *DomainQueryResolver[TUser, TAccount]) payload(ctx context.Context, domain *account.Domain) *models.AccountDomainPayload {
+	return &models.AccountDomainPayload{
+		ClientMutationID: requestid.Get(ctx),
+		DomainID:         domain.ID,
+		Domain:           FromDomainModel(domain),
+	}
+}
@@ -20,6 +20,8 @@ type AuthQueryHandler interface {
 
 type AccountLoginHandler interface {
 	Login(ctx context.Context, login, password string, accountID ...uint64) (*gqlmodels.SessionToken, error)
+	RequestLoginLink(ctx context.Context, email string) (*gqlmodels.StatusResponse, error)
+	LoginByLink(ctx context.Context, token string, accountID ...uint64) (*gqlmodels.SessionToken, error)
 }
 
 // AccountQueryHandler is the method set required for account GraphQL resolvers.
@@ -30,6 +32,9 @@ type AccountQueryHandler[TGQLAccount, TPayload, TCreateInput, TUpdateInput, TFil
 	UpdateAccount(ctx context.Context, id uint64, input TUpdateInput) (TPayload, error)
 	ApproveAccount(ctx context.Context, id uint64, msg string) (TPayload, error)
 	RejectAccount(ctx context.Context, id uint64, msg string) (TPayload, error)
+	SetAccountParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) (TPayload, error)
+	SuspendAccount(ctx context.Context, id uint64, reason string) (TPayload, error)
+	UnsuspendAccount(ctx context.Context, id uint64) (TPayload, error)
 	ListAccounts(ctx context.Context, filter TFilter, order []TOrder, page *gqlmodels.Page) (*AccountConnection[TGQLAccount], error)
 }
 
@@ -41,6 +46,56 @@ type MemberQueryHandler interface {
 	Approve(ctx context.Context, memberID uint64, msg string) (*gqlmodels.MemberPayload, error)
 	Reject(ctx context.Context, memberID uint64, msg string) (*gqlmodels.MemberPayload, error)
 	List(ctx context.Context, filter *gqlmodels.MemberListFilter, order []*gqlmodels.MemberListOrder, page *gqlmodels.Page) (*MemberConnection, error)
+	Suspend(ctx context.Context, memberID uint64, reason string) (*gqlmodels.MemberPayload, error)
+	Unsuspend(ctx context.Context, memberID uint64) (*gqlmodels.MemberPayload, error)
+	Inactive(ctx context.Context, accountID uint64, days int) ([]*gqlmodels.Member, error)
+	SuspendInactive(ctx context.Context, accountID uint64) ([]*gqlmodels.Member, error)
+}
+
+// MemberInviteQueryHandler is the method set required for account member invite GraphQL resolvers.
+type MemberInviteQueryHandler interface {
+	Invite(ctx context.Context, accountID uint64, invite *gqlmodels.InviteMemberByEmailInput) (*gqlmodels.MemberInvitePayload, error)
+	Resend(ctx context.Context, inviteID uint64) (*gqlmodels.MemberInvitePayload, error)
+	Revoke(ctx context.Context, inviteID uint64) (*gqlmodels.MemberInvitePayload, error)
+	Accept(ctx context.Context, token, password string) (*gqlmodels.MemberPayload, error)
+	Decline(ctx context.Context, token string) (*gqlmodels.MemberInvitePayload, error)
+	InviteByToken(ctx context.Context, token string) (*gqlmodels.MemberInvite, error)
+	List(ctx context.Context, filter *gqlmodels.MemberInviteListFilter, order []*gqlmodels.MemberInviteListOrder, page *gqlmodels.Page) (*MemberInviteConnection, error)
+}
+
+// OwnershipQueryHandler is the method set required for account ownership transfer GraphQL resolvers.
+type OwnershipQueryHandler interface {
+	Request(ctx context.Context, accountID, userID uint64) (*gqlmodels.AccountOwnershipTransferPayload, error)
+	Confirm(ctx context.Context, transferID uint64) (*gqlmodels.AccountOwnershipTransferPayload, error)
+	Decline(ctx context.Context, transferID uint64) (*gqlmodels.AccountOwnershipTransferPayload, error)
+	Cancel(ctx context.Context, transferID uint64) (*gqlmodels.AccountOwnershipTransferPayload, error)
+	List(ctx context.Context, status []gqlmodels.AccountOwnershipTransferStatus) ([]*gqlmodels.AccountOwnershipTransfer, error)
+}
+
+// TeamQueryHandler is the method set required for account team GraphQL resolvers.
+type TeamQueryHandler interface {
+	Team(ctx context.Context, id uint64) (*gqlmodels.AccountTeamPayload, error)
+	List(ctx context.Context, filter *gqlmodels.AccountTeamListFilter, order []*gqlmodels.AccountTeamListOrder, page *gqlmodels.Page) (*TeamConnection, error)
+	Create(ctx context.Context, input *gqlmodels.AccountTeamInput) (*gqlmodels.AccountTeamPayload, error)
+	Update(ctx context.Context, id uint64, input *gqlmodels.AccountTeamInput) (*gqlmodels.AccountTeamPayload, error)
+	Delete(ctx context.Context, id uint64) (*gqlmodels.AccountTeamPayload, error)
+	AddMembers(ctx context.Context, id uint64, memberIDs []uint64) (*gqlmodels.AccountTeamPayload, error)
+	RemoveMembers(ctx context.Context, id uint64, memberIDs []uint64) (*gqlmodels.AccountTeamPayload, error)
+}
+
+// DomainQueryHandler is the method set required for account domain GraphQL resolvers.
+type DomainQueryHandler interface {
+	Domain(ctx context.Context, id uint64) (*gqlmodels.AccountDomainPayload, error)
+	List(ctx context.Context, filter *gqlmodels.AccountDomainListFilter, order []*gqlmodels.AccountDomainListOrder, page *gqlmodels.Page) (*DomainConnection, error)
+	Add(ctx context.Context, accountID uint64, domain string) (*gqlmodels.AccountDomainPayload, error)
+	Verify(ctx context.Context, id uint64) (*gqlmodels.AccountDomainPayload, error)
+	SetPolicy(ctx context.Context, id uint64, input *gqlmodels.AccountDomainPolicyInput) (*gqlmodels.AccountDomainPayload, error)
+	Delete(ctx context.Context, id uint64) (*gqlmodels.AccountDomainPayload, error)
+}
+
+// ImpersonationQueryHandler is the method set required for the user impersonation GraphQL resolvers.
+type ImpersonationQueryHandler interface {
+	Impersonate(ctx context.Context, userID, accountID uint64, reason string) (*gqlmodels.SessionToken, error)
 }
 
 // ModelWithID returns a new model instance with primary key set (fallback when repo lookup is unavailable).
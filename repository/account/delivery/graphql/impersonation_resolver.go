@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// DefaultImpersonationLifetime of the impersonation token
+const DefaultImpersonationLifetime = 15 * time.Minute
+
+// ImpersonationResolver issues the tokens acting on behalf of the users for the support staff
+type ImpersonationResolver[TUser user.Model, TAccount account.Model] struct {
+	provider *jwt.Provider
+	loader   *accauth.Loader[TUser, TAccount]
+	lifetime time.Duration
+}
+
+// ImpersonationResolverConfig of the impersonation resolver
+type ImpersonationResolverConfig[TUser user.Model, TAccount account.Model] struct {
+	Provider *jwt.Provider
+	Loader   *accauth.Loader[TUser, TAccount]
+	Lifetime time.Duration // DefaultImpersonationLifetime if not set
+}
+
+// NewImpersonationResolver creates the impersonation resolver
+func NewImpersonationResolver[TUser user.Model, TAccount account.Model](
+	cfg ImpersonationResolverConfig[TUser, TAccount],
+) *ImpersonationResolver[TUser, TAccount] {
+	return &ImpersonationResolver[TUser, TAccount]{
+		provider: cfg.Provider,
+		loader:   cfg.Loader,
+		lifetime: gocast.IfThen(cfg.Lifetime > 0, cfg.Lifetime, DefaultImpersonationLifetime),
+	}
+}
+
+// Impersonate is the resolver for the impersonateUser field.
+func (r *ImpersonationResolver[TUser, TAccount]) Impersonate(ctx context.Context, userID, accountID uint64, reason string) (*gqlmodels.SessionToken, error) {
+	actor := session.User(ctx)
+	switch {
+	case actor == nil || actor.IsAnonymous():
+		return nil, errUserIsNotAuthorized
+	case session.IsImpersonated(ctx):
+		return nil, account.ErrImpersonatedSession
+	case actor.GetID() == userID:
+		return nil, account.ErrImpersonateSelf
+	}
+	if reason = strings.TrimSpace(reason); reason == "" {
+		return nil, account.ErrImpersonationReason
+	}
+
+	userObj, err := r.loader.Users.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveAccessImpersonate(ctx, userObj) {
+		return nil, acl.ErrNoPermissions.WithMessage("impersonate user")
+	}
+
+	// Checks the membership and the suspension the same way as the session authorization
+	var zeroAcc TAccount
+	userObj, acc, err := r.loader.UserAccountByID(ctx, userID, accountID, userObj, zeroAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The impersonation can't give the actor the rights which were never granted
+	if err = r.loader.CheckImpersonationTarget(ctx, userObj, acc); err != nil {
+		return nil, err
+	}
+
+	token, expiresAt, err := r.provider.CreateImpersonationToken(userID, accountID, actor.GetID(), time.Now().Add(r.lifetime))
+	if err != nil {
+		return nil, err
+	}
+
+	// The token isn't returned without the audit record
+	err = historylog.Write(historylog.WithMessage(ctx, reason), "user.impersonate", "user", userID, map[string]any{
+		"account_id": accountID,
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessToken := sessionTokenFromAccount(userObj, acc, token, expiresAt)
+	sessToken.IsImpersonated = true
+	sessToken.ImpersonatorID = gocast.Ptr(actor.GetID())
+	return sessToken, nil
+}
@@ -0,0 +1,166 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/demdxx/rbac"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/permissions"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	"github.com/geniusrabbit/blaze-api/repository/account/delivery/graphql"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	accountModels "github.com/geniusrabbit/blaze-api/repository/account/models"
+	"github.com/geniusrabbit/blaze-api/repository/testsuite"
+	userMocks "github.com/geniusrabbit/blaze-api/repository/user/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type testAccount struct {
+	accountModels.AccountBase
+}
+
+func (a *testAccount) TableName() string { return "account_base" }
+
+func (a *testAccount) NewWithIDs(id uint64, adminUserIDs ...uint64) account.Model {
+	return &testAccount{AccountBase: accountModels.AccountBase{ID: id, Admins: adminUserIDs}}
+}
+
+type testImpersonationSuite struct {
+	testsuite.DatabaseSuite
+
+	users    *userMocks.MockRepository[*testutil.User]
+	accounts *mocks.MockSessionRepository[*testutil.User, *testAccount]
+	members  *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	resolver *graphql.ImpersonationResolver[*testutil.User, *testAccount]
+}
+
+func (s *testImpersonationSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.users = userMocks.NewMockRepository[*testutil.User](ctrl)
+	s.accounts = mocks.NewMockSessionRepository[*testutil.User, *testAccount](ctrl)
+	s.members = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.resolver = graphql.NewImpersonationResolver(graphql.ImpersonationResolverConfig[*testutil.User, *testAccount]{
+		Provider: jwt.NewDefaultProvider("secret", time.Hour, false),
+		Loader:   accauth.NewLoader(s.users, s.accounts, s.members),
+	})
+}
+
+// actorContext of the support user 1 in the account 1 with the impersonation permission and the roles
+func (s *testImpersonationSuite) actorContext(roles ...rbac.Role) context.Context {
+	acc := &testAccount{AccountBase: accountModels.AccountBase{ID: 1}}
+	acc.SetPermissions(rbac.MustNewRole("support",
+		rbac.WithPermissions(rbac.MustNewResourcePermission("impersonate.all", &testutil.User{})),
+		rbac.WithChildRoles(roles...)))
+	return session.WithUserAccount(s.Ctx, testutil.Stub(1), acc)
+}
+
+// expectTarget loads the user 5 in the account 2, the permissions of the member are set by the setup
+func (s *testImpersonationSuite) expectTarget(setup func(acc *testAccount)) {
+	acc := &testAccount{AccountBase: accountModels.AccountBase{ID: 2}}
+	s.users.EXPECT().Get(gomock.Any(), uint64(5)).Return(testutil.Stub(5), nil)
+	s.accounts.EXPECT().Get(gomock.Any(), uint64(2)).Return(acc, nil)
+	s.members.EXPECT().IsMember(gomock.Any(), uint64(5), uint64(2)).Return(true)
+	s.accounts.EXPECT().LoadPermissions(gomock.Any(), acc, gomock.Any()).
+		DoAndReturn(func(_ context.Context, acc *testAccount, _ *testutil.User) error {
+			acc.SetPermissions(rbac.MustNewRole("member", rbac.WithChildRoles(rbac.MustNewRole("reader"))))
+			if setup != nil {
+				setup(acc)
+			}
+			return nil
+		})
+	s.accounts.EXPECT().SubAccountIDs(gomock.Any(), uint64(2), true).Return(nil, nil)
+}
+
+func (s *testImpersonationSuite) TestImpersonate() {
+	s.expectTarget(nil)
+	s.Mock.ExpectExec(`INSERT INTO "history_actions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	token, err := s.resolver.Impersonate(s.actorContext(), 5, 2, "support ticket")
+	s.Require().NoError(err)
+	s.NotEmpty(token.Token)
+	s.True(token.IsImpersonated)
+	s.Equal(uint64(1), *token.ImpersonatorID)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *testImpersonationSuite) TestImpersonateNoPermission() {
+	s.users.EXPECT().Get(gomock.Any(), uint64(5)).Return(testutil.Stub(5), nil)
+	acc := &testAccount{AccountBase: accountModels.AccountBase{ID: 1}}
+	acc.SetPermissions(rbac.MustNewRole("member"))
+
+	_, err := s.resolver.Impersonate(session.WithUserAccount(s.Ctx, testutil.Stub(1), acc), 5, 2, "support ticket")
+	s.ErrorIs(err, acl.ErrNoPermissions)
+}
+
+func (s *testImpersonationSuite) TestImpersonateSessionChecks() {
+	ctx := s.actorContext()
+	_, err := s.resolver.Impersonate(ctx, 1, 2, "support ticket")
+	s.ErrorIs(err, account.ErrImpersonateSelf)
+
+	_, err = s.resolver.Impersonate(ctx, 5, 2, " ")
+	s.ErrorIs(err, account.ErrImpersonationReason)
+
+	_, err = s.resolver.Impersonate(session.WithImpersonation(ctx, &session.Impersonation{ActorID: 3}), 5, 2, "support ticket")
+	s.ErrorIs(err, account.ErrImpersonatedSession)
+}
+
+func (s *testImpersonationSuite) TestImpersonateAccountAdmin() {
+	s.Run("Support", func() {
+		s.expectTarget(func(acc *testAccount) { acc.ExtendAdminUsers(5) })
+		_, err := s.resolver.Impersonate(s.actorContext(), 5, 2, "support ticket")
+		s.ErrorIs(err, account.ErrImpersonatePrivileged)
+	})
+	s.Run("SystemAdmin", func() {
+		s.expectTarget(func(acc *testAccount) { acc.ExtendAdminUsers(5) })
+		s.Mock.ExpectExec(`INSERT INTO "history_actions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := s.resolver.Impersonate(s.actorContext(rbac.MustNewRole("system:admin")), 5, 2, "support ticket")
+		s.NoError(err)
+	})
+	s.Run("AccountRole", func() {
+		s.expectTarget(func(acc *testAccount) { acc.ExtendAdminUsers(5) })
+		customRole := rbac.MustNewRole("system:admin",
+			rbac.WithExtData(&permissions.ExtData{ID: 9, AccountID: 1}))
+		_, err := s.resolver.Impersonate(s.actorContext(customRole), 5, 2, "support ticket")
+		s.ErrorIs(err, account.ErrImpersonatePrivileged)
+	})
+}
+
+func (s *testImpersonationSuite) TestImpersonateSystemRole() {
+	systemRole := func(acc *testAccount) {
+		acc.ExtendPermissions(rbac.MustNewRole("", rbac.WithChildRoles(rbac.MustNewRole("system:billing"))))
+	}
+	s.Run("Lacks", func() {
+		s.expectTarget(systemRole)
+		_, err := s.resolver.Impersonate(s.actorContext(), 5, 2, "support ticket")
+		s.ErrorIs(err, account.ErrImpersonatePrivileged)
+	})
+	s.Run("Granted", func() {
+		s.expectTarget(systemRole)
+		s.Mock.ExpectExec(`INSERT INTO "history_actions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := s.resolver.Impersonate(s.actorContext(rbac.MustNewRole("system:billing")), 5, 2, "support ticket")
+		s.NoError(err)
+	})
+	s.Run("AccountRole", func() {
+		s.expectTarget(func(acc *testAccount) {
+			acc.ExtendPermissions(rbac.MustNewRole("billing",
+				rbac.WithExtData(&permissions.ExtData{ID: 7, AccessLevel: 3})))
+		})
+		customRole := rbac.MustNewRole("billing",
+			rbac.WithExtData(&permissions.ExtData{ID: 9, AccountID: 1}))
+		_, err := s.resolver.Impersonate(s.actorContext(customRole), 5, 2, "support ticket")
+		s.ErrorIs(err, account.ErrImpersonatePrivileged)
+	})
+}
+
+func TestImpersonationResolverSuite(t *testing.T) {
+	suite.Run(t, new(testImpersonationSuite))
+}
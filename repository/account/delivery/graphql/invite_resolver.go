@@ -0,0 +1,160 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/messanger"
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// MessageMemberInvite is the name of the messanger template with the invite link.
+// Template variables: account, email, roles, invited_by, invite_token, invite_link, expires_at
+const MessageMemberInvite = "account.member-invite"
+
+var errInviteEmailDisabled = errors.New(`email service is not configured`)
+
+// InviteQueryResolver of the member invitations by email
+type InviteQueryResolver[TUser user.Model, TAccount account.Model] struct {
+	accounts  account.Usecase[TUser, TAccount]
+	invites   account.InviteUsecase[TUser, TAccount]
+	users     user.Repository[TUser]
+	acceptURL string
+}
+
+// InviteQueryResolverConfig of the member invitations resolver.
+// AcceptURL is the template of the link with {token} and {email} placeholders,
+// e.g. https://example.com/invite?token={token}
+type InviteQueryResolverConfig[TUser user.Model, TAccount account.Model] struct {
+	Accounts  account.Usecase[TUser, TAccount]
+	Invites   account.InviteUsecase[TUser, TAccount]
+	UserRepo  user.Repository[TUser]
+	AcceptURL string
+}
+
+// NewInviteQueryResolver creates the member invitations resolver
+func NewInviteQueryResolver[TUser user.Model, TAccount account.Model](
+	cfg InviteQueryResolverConfig[TUser, TAccount],
+) *InviteQueryResolver[TUser, TAccount] {
+	return &InviteQueryResolver[TUser, TAccount]{
+		accounts:  cfg.Accounts,
+		invites:   cfg.Invites,
+		users:     cfg.UserRepo,
+		acceptURL: cfg.AcceptURL,
+	}
+}
+
+// Invite is the resolver for the inviteAccountMemberByEmail field.
+func (r *InviteQueryResolver[TUser, TAccount]) Invite(ctx context.Context, accountID uint64, input *models.InviteMemberByEmailInput) (*models.MemberInvitePayload, error) {
+	if !messanger.Get(ctx).IsEnabled() {
+		ctxlogger.Get(ctx).Error("Email service not configured")
+		return nil, errInviteEmailDisabled
+	}
+	invite, err := r.invites.Invite(ctx, accountID, input.Email, InviteMemberByEmailAllRoles(input)...)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.send(ctx, invite); err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, invite), nil
+}
+
+// Resend is the resolver for the resendMemberInvite field.
+func (r *InviteQueryResolver[TUser, TAccount]) Resend(ctx context.Context, inviteID uint64) (*models.MemberInvitePayload, error) {
+	if !messanger.Get(ctx).IsEnabled() {
+		ctxlogger.Get(ctx).Error("Email service not configured")
+		return nil, errInviteEmailDisabled
+	}
+	invite, err := r.invites.Resend(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.send(ctx, invite); err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, invite), nil
+}
+
+// Revoke is the resolver for the revokeMemberInvite field.
+func (r *InviteQueryResolver[TUser, TAccount]) Revoke(ctx context.Context, inviteID uint64) (*models.MemberInvitePayload, error) {
+	invite, err := r.invites.Revoke(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, invite), nil
+}
+
+// Accept is the resolver for the acceptMemberInvite field.
+func (r *InviteQueryResolver[TUser, TAccount]) Accept(ctx context.Context, token, password string) (*models.MemberPayload, error) {
+	member, err := r.invites.Accept(ctx, token, password)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MemberPayload{
+		ClientMutationID: requestid.Get(ctx),
+		MemberID:         member.ID,
+		Member:           FromMemberModel(ctx, member, r.accounts, r.users),
+	}, nil
+}
+
+// Decline is the resolver for the declineMemberInvite field.
+func (r *InviteQueryResolver[TUser, TAccount]) Decline(ctx context.Context, token string) (*models.MemberInvitePayload, error) {
+	invite, err := r.invites.Decline(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, invite), nil
+}
+
+// InviteByToken is the resolver for the memberInvite field.
+func (r *InviteQueryResolver[TUser, TAccount]) InviteByToken(ctx context.Context, token string) (*models.MemberInvite, error) {
+	invite, err := r.invites.InviteByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return FromMemberInviteModel(invite), nil
+}
+
+// List is the resolver for the listMemberInvites field.
+func (r *InviteQueryResolver[TUser, TAccount]) List(ctx context.Context, filter *models.MemberInviteListFilter, order []*models.MemberInviteListOrder, page *models.Page) (*MemberInviteConnection, error) {
+	return NewMemberInviteConnection(ctx, r.invites, filter, order, page), nil
+}
+
+func (r *InviteQueryResolver[TUser, TAccount]) send(ctx context.Context, invite *account.MemberInvite) error {
+	accountObj, err := r.accounts.Get(ctx, invite.AccountID)
+	if err != nil {
+		return err
+	}
+	err = messanger.Get(ctx).Send(ctx, MessageMemberInvite, []string{invite.Email}, map[string]any{
+		"account":      accountObj,
+		"email":        invite.Email,
+		"roles":        []string(invite.Roles),
+		"invited_by":   session.User(ctx),
+		"invite_token": invite.Token,
+		"invite_link":  messanger.Link(r.acceptURL, map[string]string{"token": invite.Token, "email": invite.Email}),
+		"expires_at":   invite.ExpiresAt,
+	})
+	if err != nil {
+		ctxlogger.Get(ctx).Error("Error sending member invite email",
+			zap.String("msgname", MessageMemberInvite),
+			zap.Uint64("invite_id", invite.ID),
+			zap.Error(err))
+	}
+	return err
+}
+
+func (r *InviteQueryResolver[TUser, TAccount]) payload(ctx context.Context, invite *account.MemberInvite) *models.MemberInvitePayload {
+	return &models.MemberInvitePayload{
+		ClientMutationID: requestid.Get(ctx),
+		InviteID:         invite.ID,
+		Invite:           FromMemberInviteModel(invite),
+	}
+}
@@ -2,6 +2,8 @@ package graphql
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/demdxx/gocast/v2"
 	"github.com/demdxx/xtypes"
@@ -29,8 +31,14 @@ func FromMemberModel[TUser user.Model, TAccount account.Model](
 		AccountID: member.AccountID,
 		UserID:    member.UserID,
 		IsAdmin:   member.IsAdmin,
+		IsOwner:   member.IsOwner,
 		Status:    gqlmodels.ApproveStatusFrom(member.Approve),
 		Roles:     rbac_graphql.FromRBACRoleModelList(ctx, member.Roles),
+
+		LastActiveAt:  gocast.IfThen(member.LastActiveAt.Valid, &member.LastActiveAt.V, nil),
+		SuspendedAt:   gocast.IfThen(member.SuspendedAt.Valid, &member.SuspendedAt.V, nil),
+		SuspendReason: gocast.IfThen(member.SuspendReason != "", &member.SuspendReason, nil),
+
 		CreatedAt: member.CreatedAt,
 		UpdatedAt: member.UpdatedAt,
 	}
@@ -55,7 +63,10 @@ func FromMemberGQLFilter(fl *gqlmodels.MemberListFilter) *account.MemberFilter {
 		ID:        fl.ID,
 		AccountID: fl.AccountID,
 		UserID:    fl.UserID,
+		TeamID:    fl.TeamID,
 		IsAdmin:   gocast.IfThen(fl.IsAdmin != nil, null.BoolFromPtr(fl.IsAdmin), null.Bool{}),
+		IsOwner:   gocast.IfThen(fl.IsOwner != nil, null.BoolFromPtr(fl.IsOwner), null.Bool{}),
+		Suspended: gocast.IfThen(fl.Suspended != nil, null.BoolFromPtr(fl.Suspended), null.Bool{}),
 	}
 }
 
@@ -64,13 +75,14 @@ func FromMemberGQLOrder(ord *gqlmodels.MemberListOrder) *account.MemberListOrder
 		return nil
 	}
 	return &account.MemberListOrder{
-		ID:        pkgModels.Order(ord.ID.AsOrder()),
-		AccountID: pkgModels.Order(ord.AccountID.AsOrder()),
-		UserID:    pkgModels.Order(ord.UserID.AsOrder()),
-		Status:    pkgModels.Order(ord.Status.AsOrder()),
-		IsAdmin:   pkgModels.Order(ord.IsAdmin.AsOrder()),
-		CreatedAt: pkgModels.Order(ord.CreatedAt.AsOrder()),
-		UpdatedAt: pkgModels.Order(ord.UpdatedAt.AsOrder()),
+		ID:           pkgModels.Order(ord.ID.AsOrder()),
+		AccountID:    pkgModels.Order(ord.AccountID.AsOrder()),
+		UserID:       pkgModels.Order(ord.UserID.AsOrder()),
+		Status:       pkgModels.Order(ord.Status.AsOrder()),
+		IsAdmin:      pkgModels.Order(ord.IsAdmin.AsOrder()),
+		LastActiveAt: pkgModels.Order(ord.LastActiveAt.AsOrder()),
+		CreatedAt:    pkgModels.Order(ord.CreatedAt.AsOrder()),
+		UpdatedAt:    pkgModels.Order(ord.UpdatedAt.AsOrder()),
 	}
 }
 
@@ -87,3 +99,232 @@ func MemberAllRoles(mem *gqlmodels.MemberInput) []string {
 	}
 	return mem.Roles
 }
+
+func InviteMemberByEmailAllRoles(invite *gqlmodels.InviteMemberByEmailInput) []string {
+	if invite.IsAdmin {
+		return xtypes.SliceUnique(append(invite.Roles, account.RoleAdmin))
+	}
+	return invite.Roles
+}
+
+// FromMemberInviteModel to local graphql model
+func FromMemberInviteModel(invite *account.MemberInvite) *gqlmodels.MemberInvite {
+	if invite == nil {
+		return nil
+	}
+	return &gqlmodels.MemberInvite{
+		ID:        invite.ID,
+		AccountID: invite.AccountID,
+		Email:     invite.Email,
+		Roles:     invite.Roles,
+		IsAdmin:   slices.Contains(invite.Roles, account.RoleAdmin),
+		Status:    fromInviteStatus(invite),
+		InvitedBy: invite.InvitedBy,
+		UserID:    gocast.IfThen(invite.UserID > 0, &invite.UserID, nil),
+		SentCount: invite.SentCount,
+		SentAt:    invite.SentAt,
+		ExpiresAt: invite.ExpiresAt,
+		CreatedAt: invite.CreatedAt,
+		UpdatedAt: invite.UpdatedAt,
+	}
+}
+
+func FromMemberInviteModelList(list []*account.MemberInvite) []*gqlmodels.MemberInvite {
+	return xtypes.SliceApply(list, FromMemberInviteModel)
+}
+
+func fromInviteStatus(invite *account.MemberInvite) gqlmodels.MemberInviteStatus {
+	switch invite.Status {
+	case account.InviteStatusAccepted:
+		return gqlmodels.MemberInviteStatusAccepted
+	case account.InviteStatusDeclined:
+		return gqlmodels.MemberInviteStatusDeclined
+	case account.InviteStatusRevoked:
+		return gqlmodels.MemberInviteStatusRevoked
+	}
+	if !invite.IsPending(time.Now()) {
+		return gqlmodels.MemberInviteStatusExpired
+	}
+	return gqlmodels.MemberInviteStatusPending
+}
+
+// FromMemberInviteGQLFilter converts the filter, the EXPIRED status
+// is the pending invite after the expiration time
+func FromMemberInviteGQLFilter(fl *gqlmodels.MemberInviteListFilter) *account.InviteFilter {
+	if fl == nil {
+		// The empty filter is narrowed by the permissions of the session
+		return &account.InviteFilter{}
+	}
+	filter := &account.InviteFilter{
+		ID:        fl.ID,
+		AccountID: fl.AccountID,
+		Email:     fl.Email,
+	}
+	pending := slices.Contains(fl.Status, gqlmodels.MemberInviteStatusPending)
+	expired := slices.Contains(fl.Status, gqlmodels.MemberInviteStatusExpired)
+	if pending != expired {
+		filter.Expired = null.BoolFrom(expired)
+	}
+	for _, status := range fl.Status {
+		switch status {
+		case gqlmodels.MemberInviteStatusAccepted:
+			filter.Status = append(filter.Status, account.InviteStatusAccepted)
+		case gqlmodels.MemberInviteStatusDeclined:
+			filter.Status = append(filter.Status, account.InviteStatusDeclined)
+		case gqlmodels.MemberInviteStatusRevoked:
+			filter.Status = append(filter.Status, account.InviteStatusRevoked)
+		}
+	}
+	if pending || expired {
+		filter.Status = append(filter.Status, account.InviteStatusPending)
+	}
+	return filter
+}
+
+func FromMemberInviteGQLOrder(ord *gqlmodels.MemberInviteListOrder) *account.InviteListOrder {
+	if ord == nil {
+		return nil
+	}
+	return &account.InviteListOrder{
+		ID:        pkgModels.Order(ord.ID.AsOrder()),
+		Email:     pkgModels.Order(ord.Email.AsOrder()),
+		Status:    pkgModels.Order(ord.Status.AsOrder()),
+		CreatedAt: pkgModels.Order(ord.CreatedAt.AsOrder()),
+		ExpiresAt: pkgModels.Order(ord.ExpiresAt.AsOrder()),
+	}
+}
+
+func FromOwnershipTransferModel(transfer *account.OwnershipTransfer) *gqlmodels.AccountOwnershipTransfer {
+	if transfer == nil {
+		return nil
+	}
+	return &gqlmodels.AccountOwnershipTransfer{
+		ID:         transfer.ID,
+		AccountID:  transfer.AccountID,
+		FromUserID: transfer.FromUserID,
+		ToUserID:   transfer.ToUserID,
+		Status:     fromTransferStatus(transfer),
+		ExpiresAt:  transfer.ExpiresAt,
+		CreatedAt:  transfer.CreatedAt,
+		UpdatedAt:  transfer.UpdatedAt,
+	}
+}
+
+func FromOwnershipTransferModelList(list []*account.OwnershipTransfer) []*gqlmodels.AccountOwnershipTransfer {
+	return xtypes.SliceApply(list, FromOwnershipTransferModel)
+}
+
+func fromTransferStatus(transfer *account.OwnershipTransfer) gqlmodels.AccountOwnershipTransferStatus {
+	switch transfer.Status {
+	case account.TransferStatusConfirmed:
+		return gqlmodels.AccountOwnershipTransferStatusConfirmed
+	case account.TransferStatusDeclined:
+		return gqlmodels.AccountOwnershipTransferStatusDeclined
+	case account.TransferStatusCancelled:
+		return gqlmodels.AccountOwnershipTransferStatusCancelled
+	}
+	if !transfer.IsPending(time.Now()) {
+		return gqlmodels.AccountOwnershipTransferStatusExpired
+	}
+	return gqlmodels.AccountOwnershipTransferStatusPending
+}
+
+// FromTeamModel to the graphql model
+func FromTeamModel(ctx context.Context, team *account.Team) *gqlmodels.AccountTeam {
+	if team == nil {
+		return nil
+	}
+	return &gqlmodels.AccountTeam{
+		ID:          team.ID,
+		AccountID:   team.AccountID,
+		Name:        team.Name,
+		Description: team.Description,
+		Roles:       rbac_graphql.FromRBACRoleModelList(ctx, team.Roles),
+		CreatedAt:   team.CreatedAt,
+		UpdatedAt:   team.UpdatedAt,
+	}
+}
+
+// FromTeamModelList to the graphql models
+func FromTeamModelList(ctx context.Context, list []*account.Team) []*gqlmodels.AccountTeam {
+	return xtypes.SliceApply(list, func(team *account.Team) *gqlmodels.AccountTeam {
+		return FromTeamModel(ctx, team)
+	})
+}
+
+// FromTeamGQLFilter converts the filter of the teams list
+func FromTeamGQLFilter(fl *gqlmodels.AccountTeamListFilter) *account.TeamFilter {
+	if fl == nil {
+		// The empty filter is narrowed by the permissions of the session
+		return &account.TeamFilter{}
+	}
+	return &account.TeamFilter{
+		ID:        fl.ID,
+		AccountID: fl.AccountID,
+		MemberID:  fl.MemberID,
+		Name:      fl.Name,
+	}
+}
+
+// FromTeamGQLOrder converts the order of the teams list
+func FromTeamGQLOrder(ord *gqlmodels.AccountTeamListOrder) *account.TeamListOrder {
+	if ord == nil {
+		return nil
+	}
+	return &account.TeamListOrder{
+		ID:        pkgModels.Order(ord.ID.AsOrder()),
+		Name:      pkgModels.Order(ord.Name.AsOrder()),
+		CreatedAt: pkgModels.Order(ord.CreatedAt.AsOrder()),
+		UpdatedAt: pkgModels.Order(ord.UpdatedAt.AsOrder()),
+	}
+}
+
+// FromDomainModel to the graphql model
+func FromDomainModel(domain *account.Domain) *gqlmodels.AccountDomain {
+	if domain == nil {
+		return nil
+	}
+	return &gqlmodels.AccountDomain{
+		ID:                 domain.ID,
+		AccountID:          domain.AccountID,
+		Domain:             domain.Domain,
+		VerificationRecord: domain.VerificationRecord(),
+		VerifiedAt:         gocast.IfThen(domain.VerifiedAt.Valid, &domain.VerifiedAt.V, nil),
+		AutoJoin:           domain.AutoJoin,
+		DefaultRoles:       domain.DefaultRoles,
+		SsoRequired:        domain.SSORequired,
+		CreatedAt:          domain.CreatedAt,
+		UpdatedAt:          domain.UpdatedAt,
+	}
+}
+
+// FromDomainModelList to the graphql models
+func FromDomainModelList(list []*account.Domain) []*gqlmodels.AccountDomain {
+	return xtypes.SliceApply(list, FromDomainModel)
+}
+
+// FromDomainGQLFilter converts the filter of the domains list
+func FromDomainGQLFilter(fl *gqlmodels.AccountDomainListFilter) *account.DomainFilter {
+	if fl == nil {
+		// The empty filter is narrowed by the permissions of the session
+		return &account.DomainFilter{}
+	}
+	return &account.DomainFilter{
+		ID:        fl.ID,
+		AccountID: fl.AccountID,
+		Domain:    fl.Domain,
+		Verified:  null.BoolFromPtr(fl.Verified),
+	}
+}
+
+// FromDomainGQLOrder converts the order of the domains list
+func FromDomainGQLOrder(ord *gqlmodels.AccountDomainListOrder) *account.DomainListOrder {
+	if ord == nil {
+		return nil
+	}
+	return &account.DomainListOrder{
+		ID:        pkgModels.Order(ord.ID.AsOrder()),
+		Domain:    pkgModels.Order(ord.Domain.AsOrder()),
+		CreatedAt: pkgModels.Order(ord.CreatedAt.AsOrder()),
+	}
+}
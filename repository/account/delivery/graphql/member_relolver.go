@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/geniusrabbit/blaze-api/pkg/requestid"
@@ -10,15 +11,20 @@ import (
 	"github.com/geniusrabbit/blaze-api/server/graphql/models"
 )
 
+// errActivityUnsupported is returned by the activity reports without the activity usecase
+var errActivityUnsupported = errors.New("member activity reports are not configured")
+
 type MemberQueryResolver[TUser user.Model, TAccount account.Model, TGQLAccount any] struct {
 	accounts account.Usecase[TUser, TAccount]
 	members  account.MemberUsecase[TUser, TAccount]
+	activity account.ActivityUsecase[TUser, TAccount]
 	users    user.Repository[TUser]
 }
 
 type MemberQueryResolverConfig[TUser user.Model, TAccount account.Model, TGQLAccount any] struct {
 	Accounts account.Usecase[TUser, TAccount]
 	Members  account.MemberUsecase[TUser, TAccount]
+	Activity account.ActivityUsecase[TUser, TAccount]
 	UserRepo user.Repository[TUser]
 }
 
@@ -28,6 +34,7 @@ func NewMemberQueryResolver[TUser user.Model, TAccount account.Model, TGQLAccoun
 	return &MemberQueryResolver[TUser, TAccount, TGQLAccount]{
 		accounts: cfg.Accounts,
 		members:  cfg.Members,
+		activity: cfg.Activity,
 		users:    cfg.UserRepo,
 	}
 }
@@ -84,3 +91,53 @@ func (r *MemberQueryResolver[TUser, TAccount, TGQLAccount]) Reject(ctx context.C
 func (r *MemberQueryResolver[TUser, TAccount, TGQLAccount]) List(ctx context.Context, filter *models.MemberListFilter, order []*models.MemberListOrder, page *models.Page) (*MemberConnection, error) {
 	return NewMemberConnection(ctx, r.members, r.accounts, r.users, filter, order, page), nil
 }
+
+// Suspend is the resolver for the suspendAccountMember field.
+func (r *MemberQueryResolver[TUser, TAccount, TGQLAccount]) Suspend(ctx context.Context, memberID uint64, reason string) (*models.MemberPayload, error) {
+	accountMember, err := r.members.SuspendMember(ctx, memberID, reason)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MemberPayload{
+		ClientMutationID: requestid.Get(ctx),
+		MemberID:         memberID,
+		Member:           FromMemberModel(ctx, accountMember, r.accounts, r.users),
+	}, nil
+}
+
+// Unsuspend is the resolver for the unsuspendAccountMember field.
+func (r *MemberQueryResolver[TUser, TAccount, TGQLAccount]) Unsuspend(ctx context.Context, memberID uint64) (*models.MemberPayload, error) {
+	accountMember, err := r.members.UnsuspendMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MemberPayload{
+		ClientMutationID: requestid.Get(ctx),
+		MemberID:         memberID,
+		Member:           FromMemberModel(ctx, accountMember, r.accounts, r.users),
+	}, nil
+}
+
+// Inactive is the resolver for the listInactiveMembers field.
+func (r *MemberQueryResolver[TUser, TAccount, TGQLAccount]) Inactive(ctx context.Context, accountID uint64, days int) ([]*models.Member, error) {
+	if r.activity == nil {
+		return nil, errActivityUnsupported
+	}
+	list, err := r.activity.FetchListInactiveMembers(ctx, accountID, days)
+	if err != nil {
+		return nil, err
+	}
+	return FromMemberModelList(ctx, list, r.accounts, r.users), nil
+}
+
+// SuspendInactive is the resolver for the suspendInactiveMembers field.
+func (r *MemberQueryResolver[TUser, TAccount, TGQLAccount]) SuspendInactive(ctx context.Context, accountID uint64) ([]*models.Member, error) {
+	if r.activity == nil {
+		return nil, errActivityUnsupported
+	}
+	list, err := r.activity.SuspendInactiveMembers(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return FromMemberModelList(ctx, list, r.accounts, r.users), nil
+}
@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// OwnershipQueryResolver of the account ownership transfers
+type OwnershipQueryResolver struct {
+	transfers account.OwnershipUsecase
+}
+
+// NewOwnershipQueryResolver creates the ownership transfers resolver
+func NewOwnershipQueryResolver(transfers account.OwnershipUsecase) *OwnershipQueryResolver {
+	return &OwnershipQueryResolver{transfers: transfers}
+}
+
+// Request is the resolver for the requestAccountOwnershipTransfer field.
+func (r *OwnershipQueryResolver) Request(ctx context.Context, accountID, userID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	transfer, err := r.transfers.RequestTransfer(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, transfer), nil
+}
+
+// Confirm is the resolver for the confirmAccountOwnershipTransfer field.
+func (r *OwnershipQueryResolver) Confirm(ctx context.Context, transferID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	transfer, err := r.transfers.Confirm(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, transfer), nil
+}
+
+// Decline is the resolver for the declineAccountOwnershipTransfer field.
+func (r *OwnershipQueryResolver) Decline(ctx context.Context, transferID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	transfer, err := r.transfers.Decline(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, transfer), nil
+}
+
+// Cancel is the resolver for the cancelAccountOwnershipTransfer field.
+func (r *OwnershipQueryResolver) Cancel(ctx context.Context, transferID uint64) (*models.AccountOwnershipTransferPayload, error) {
+	transfer, err := r.transfers.Cancel(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, transfer), nil
+}
+
+// List is the resolver for the listAccountOwnershipTransfers field.
+// The expired status is computed, so the list is filtered after the conversion.
+func (r *OwnershipQueryResolver) List(ctx context.Context, status []models.AccountOwnershipTransferStatus) ([]*models.AccountOwnershipTransfer, error) {
+	list, err := r.transfers.FetchListTransfers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := FromOwnershipTransferModelList(list)
+	if len(status) == 0 {
+		return result, nil
+	}
+	return slices.DeleteFunc(result, func(transfer *models.AccountOwnershipTransfer) bool {
+		return !slices.Contains(status, transfer.Status)
+	}), nil
+}
+
+func (r *OwnershipQueryResolver) payload(ctx context.Context, transfer *account.OwnershipTransfer) *models.AccountOwnershipTransferPayload {
+	return &models.AccountOwnershipTransferPayload{
+		ClientMutationID: requestid.Get(ctx),
+		TransferID:       transfer.ID,
+		Transfer:         FromOwnershipTransferModel(transfer),
+	}
+}
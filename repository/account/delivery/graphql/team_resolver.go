@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// TeamQueryResolver of the account teams
+type TeamQueryResolver struct {
+	teams account.TeamUsecase
+}
+
+// NewTeamQueryResolver creates the account teams resolver
+func NewTeamQueryResolver(teams account.TeamUsecase) *TeamQueryResolver {
+	return &TeamQueryResolver{teams: teams}
+}
+
+// Team is the resolver for the accountTeam field.
+func (r *TeamQueryResolver) Team(ctx context.Context, id uint64) (*models.AccountTeamPayload, error) {
+	team, err := r.teams.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, team), nil
+}
+
+// List is the resolver for the listAccountTeams field.
+func (r *TeamQueryResolver) List(ctx context.Context, filter *models.AccountTeamListFilter, order []*models.AccountTeamListOrder, page *models.Page) (*TeamConnection, error) {
+	return NewTeamConnection(ctx, r.teams, filter, order, page), nil
+}
+
+// Create is the resolver for the createAccountTeam field.
+func (r *TeamQueryResolver) Create(ctx context.Context, input *models.AccountTeamInput) (*models.AccountTeamPayload, error) {
+	team, err := r.teams.Create(ctx, &account.Team{
+		AccountID:   gocast.PtrAsValue(input.AccountID, 0),
+		Name:        input.Name,
+		Description: gocast.PtrAsValue(input.Description, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.setRoles(ctx, team, input)
+}
+
+// Update is the resolver for the updateAccountTeam field.
+func (r *TeamQueryResolver) Update(ctx context.Context, id uint64, input *models.AccountTeamInput) (*models.AccountTeamPayload, error) {
+	team, err := r.teams.Update(ctx, &account.Team{
+		ID:          id,
+		AccountID:   gocast.PtrAsValue(input.AccountID, 0),
+		Name:        input.Name,
+		Description: gocast.PtrAsValue(input.Description, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.setRoles(ctx, team, input)
+}
+
+// Delete is the resolver for the deleteAccountTeam field.
+func (r *TeamQueryResolver) Delete(ctx context.Context, id uint64) (*models.AccountTeamPayload, error) {
+	team, err := r.teams.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, team), nil
+}
+
+// AddMembers is the resolver for the addAccountTeamMembers field.
+func (r *TeamQueryResolver) AddMembers(ctx context.Context, id uint64, memberIDs []uint64) (*models.AccountTeamPayload, error) {
+	team, err := r.teams.AddMembers(ctx, id, memberIDs...)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, team), nil
+}
+
+// RemoveMembers is the resolver for the removeAccountTeamMembers field.
+func (r *TeamQueryResolver) RemoveMembers(ctx context.Context, id uint64, memberIDs []uint64) (*models.AccountTeamPayload, error) {
+	team, err := r.teams.RemoveMembers(ctx, id, memberIDs...)
+	if err != nil {
+		return nil, err
+	}
+	return r.payload(ctx, team), nil
+}
+
+// setRoles replaces the roles of the team if they are passed in the input
+func (r *TeamQueryResolver) setRoles(ctx context.Context, team *account.Team, input *models.AccountTeamInput) (*models.AccountTeamPayload, error) {
+	if input.Roles != nil {
+		var err error
+		if team, err = r.teams.SetRoles(ctx, team.ID, input.Roles...); err != nil {
+			return nil, err
+		}
+	}
+	return r.payload(ctx, team), nil
+}
+
+func (r *TeamQueryResolver) payload(ctx context.Context, team *account.Team) *models.AccountTeamPayload {
+	return &models.AccountTeamPayload{
+		ClientMutationID: requestid.Get(ctx),
+		TeamID:           team.ID,
+		Team:             FromTeamModel(ctx, team),
+	}
+}
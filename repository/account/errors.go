@@ -0,0 +1,98 @@
+package account
+
+import "errors"
+
+var (
+	// ErrInvalidInviteEmail is returned if the invited email is not a valid address
+	ErrInvalidInviteEmail = errors.New("invalid invite email")
+
+	// ErrInvalidInviteToken is returned if the invite token doesn't exist or expired
+	ErrInvalidInviteToken = errors.New("invalid or expired invite token")
+
+	// ErrInviteNotPending is returned if the invite was already accepted, declined, revoked or expired
+	ErrInviteNotPending = errors.New("the invite is not pending")
+
+	// ErrInviteEmailMismatch is returned if the logged in user accepts the invite sent to another email
+	ErrInviteEmailMismatch = errors.New("the invite was sent to another email")
+
+	// ErrInvalidInviteRoles is returned if the role doesn't exist or can't be given by the invite
+	ErrInvalidInviteRoles = errors.New("the role can't be given by the invite")
+
+	// ErrAlreadyMember is returned if the invited email belongs to the member of the account
+	ErrAlreadyMember = errors.New("the user is already a member of the account")
+
+	// ErrLastAdmin is returned if the operation leaves the account without admins
+	ErrLastAdmin = errors.New("account must have at least one admin")
+
+	// ErrOwnerProtected is returned on the removal or the demotion of the account owner
+	ErrOwnerProtected = errors.New("the owner can't be removed or demoted, transfer the ownership first")
+
+	// ErrNotOwner is returned if the ownership transfer is requested not by the owner
+	ErrNotOwner = errors.New("only the owner can transfer the account")
+
+	// ErrInvalidTransferTarget is returned if the new owner isn't an approved member of the account
+	ErrInvalidTransferTarget = errors.New("the new owner must be another approved member of the account")
+
+	// ErrTransferNotPending is returned if the transfer was already confirmed, declined, cancelled or expired
+	ErrTransferNotPending = errors.New("the ownership transfer is not pending")
+
+	// ErrAccountCycle is returned if the account is moved under itself or its own sub-account
+	ErrAccountCycle = errors.New("the account can't be nested into itself or its sub-account")
+
+	// ErrUserSuspended is returned if the suspended user tries to authenticate
+	ErrUserSuspended = errors.New("the user is suspended")
+
+	// ErrAccountSuspended is returned if somebody tries to act in the suspended account
+	ErrAccountSuspended = errors.New("the account is suspended")
+
+	// ErrInvalidTeamName is returned if the team name is empty
+	ErrInvalidTeamName = errors.New("the team name is required")
+
+	// ErrTeamAccountChange is returned if the team is moved to another account
+	ErrTeamAccountChange = errors.New("the team can't be moved to another account")
+
+	// ErrInvalidTeamMember is returned if the member doesn't belong to the account of the team
+	ErrInvalidTeamMember = errors.New("the team member must be a member of the team account")
+
+	// ErrInvalidTeamRole is returned if the role doesn't exist or belongs to another account
+	ErrInvalidTeamRole = errors.New("the role can't be assigned to the team")
+
+	// ErrInvalidDomain is returned if the domain name is malformed
+	ErrInvalidDomain = errors.New("invalid domain name")
+
+	// ErrDomainTaken is returned if the domain is already verified by another account
+	ErrDomainTaken = errors.New("the domain is verified by another account")
+
+	// ErrDomainNotVerified is returned on the policy change of the domain which isn't verified yet
+	ErrDomainNotVerified = errors.New("the domain is not verified")
+
+	// ErrDomainVerificationFailed is returned if the TXT record of the domain isn't found
+	ErrDomainVerificationFailed = errors.New("the domain verification record is not found")
+
+	// ErrInvalidDomainRoles is returned if the auto-join roles grant the account administration
+	ErrInvalidDomainRoles = errors.New("the auto-join roles can't include the admin role")
+
+	// ErrSSORequired is returned on the password or the link login with the email of the SSO enforced domain
+	ErrSSORequired = errors.New("the email domain requires the single sign-on")
+
+	// ErrImpersonatedSession is returned if the impersonated session tries to impersonate
+	// another user or switch the account of the token
+	ErrImpersonatedSession = errors.New("the operation is not allowed in the impersonated session")
+
+	// ErrImpersonateSelf is returned on the impersonation of the session user
+	ErrImpersonateSelf = errors.New("the user can't impersonate itself")
+
+	// ErrImpersonationReason is returned if the reason of the impersonation is empty
+	ErrImpersonationReason = errors.New("the reason of the impersonation is required")
+
+	// ErrImpersonatePrivileged is returned if the impersonated user has the admin
+	// or the system rights which the actor doesn't have
+	ErrImpersonatePrivileged = errors.New("the user has more rights than the impersonator")
+
+	// ErrMemberSuspended is returned if the suspended member tries to act in the account
+	ErrMemberSuspended = errors.New("the account member is suspended")
+
+	// ErrInactivityDisabled is returned on the inactive members suspension
+	// if the inactivity period isn't defined for the account
+	ErrInactivityDisabled = errors.New("the member inactivity period is not defined for the account")
+)
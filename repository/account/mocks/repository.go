@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	account "github.com/geniusrabbit/blaze-api/repository/account"
 	user "github.com/geniusrabbit/blaze-api/repository/user"
@@ -140,6 +141,49 @@ func (mr *MockRepositoryMockRecorder[T]) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository[T])(nil).Get), ctx, id)
 }
 
+// SetParent mocks base method.
+func (m *MockRepository[T]) SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParent", ctx, id, parentID, inheritPermissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetParent indicates an expected call of SetParent.
+func (mr *MockRepositoryMockRecorder[T]) SetParent(ctx, id, parentID, inheritPermissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockRepository[T])(nil).SetParent), ctx, id, parentID, inheritPermissions)
+}
+
+// SetSuspended mocks base method.
+func (m *MockRepository[T]) SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSuspended", ctx, id, suspended, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSuspended indicates an expected call of SetSuspended.
+func (mr *MockRepositoryMockRecorder[T]) SetSuspended(ctx, id, suspended, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSuspended", reflect.TypeOf((*MockRepository[T])(nil).SetSuspended), ctx, id, suspended, reason)
+}
+
+// SubAccountIDs mocks base method.
+func (m *MockRepository[T]) SubAccountIDs(ctx context.Context, id uint64, inheritedOnly bool) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubAccountIDs", ctx, id, inheritedOnly)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubAccountIDs indicates an expected call of SubAccountIDs.
+func (mr *MockRepositoryMockRecorder[T]) SubAccountIDs(ctx, id, inheritedOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubAccountIDs", reflect.TypeOf((*MockRepository[T])(nil).SubAccountIDs), ctx, id, inheritedOnly)
+}
+
 // Update mocks base method.
 func (m *MockRepository[T]) Update(ctx context.Context, id uint64, arg2 T) error {
 	m.ctrl.T.Helper()
@@ -306,6 +350,49 @@ func (mr *MockSessionRepositoryMockRecorder[TUser, TAccount]) LoadPermissions(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPermissions", reflect.TypeOf((*MockSessionRepository[TUser, TAccount])(nil).LoadPermissions), ctx, arg1, userObj)
 }
 
+// SetParent mocks base method.
+func (m *MockSessionRepository[TUser, TAccount]) SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParent", ctx, id, parentID, inheritPermissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetParent indicates an expected call of SetParent.
+func (mr *MockSessionRepositoryMockRecorder[TUser, TAccount]) SetParent(ctx, id, parentID, inheritPermissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockSessionRepository[TUser, TAccount])(nil).SetParent), ctx, id, parentID, inheritPermissions)
+}
+
+// SetSuspended mocks base method.
+func (m *MockSessionRepository[TUser, TAccount]) SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSuspended", ctx, id, suspended, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSuspended indicates an expected call of SetSuspended.
+func (mr *MockSessionRepositoryMockRecorder[TUser, TAccount]) SetSuspended(ctx, id, suspended, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSuspended", reflect.TypeOf((*MockSessionRepository[TUser, TAccount])(nil).SetSuspended), ctx, id, suspended, reason)
+}
+
+// SubAccountIDs mocks base method.
+func (m *MockSessionRepository[TUser, TAccount]) SubAccountIDs(ctx context.Context, id uint64, inheritedOnly bool) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubAccountIDs", ctx, id, inheritedOnly)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubAccountIDs indicates an expected call of SubAccountIDs.
+func (mr *MockSessionRepositoryMockRecorder[TUser, TAccount]) SubAccountIDs(ctx, id, inheritedOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubAccountIDs", reflect.TypeOf((*MockSessionRepository[TUser, TAccount])(nil).SubAccountIDs), ctx, id, inheritedOnly)
+}
+
 // Update mocks base method.
 func (m *MockSessionRepository[TUser, TAccount]) Update(ctx context.Context, id uint64, arg2 TAccount) error {
 	m.ctrl.T.Helper()
@@ -494,6 +581,34 @@ func (mr *MockMemberRepositoryMockRecorder[TUser, TAccount]) SetMemberRoles(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRoles", reflect.TypeOf((*MockMemberRepository[TUser, TAccount])(nil).SetMemberRoles), varargs...)
 }
 
+// SetMembersSuspended mocks base method.
+func (m *MockMemberRepository[TUser, TAccount]) SetMembersSuspended(ctx context.Context, accountID uint64, memberIDs []uint64, suspended bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMembersSuspended", ctx, accountID, memberIDs, suspended, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMembersSuspended indicates an expected call of SetMembersSuspended.
+func (mr *MockMemberRepositoryMockRecorder[TUser, TAccount]) SetMembersSuspended(ctx, accountID, memberIDs, suspended, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMembersSuspended", reflect.TypeOf((*MockMemberRepository[TUser, TAccount])(nil).SetMembersSuspended), ctx, accountID, memberIDs, suspended, reason)
+}
+
+// SetOwner mocks base method.
+func (m *MockMemberRepository[TUser, TAccount]) SetOwner(ctx context.Context, accountID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOwner", ctx, accountID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOwner indicates an expected call of SetOwner.
+func (mr *MockMemberRepositoryMockRecorder[TUser, TAccount]) SetOwner(ctx, accountID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwner", reflect.TypeOf((*MockMemberRepository[TUser, TAccount])(nil).SetOwner), ctx, accountID, userID)
+}
+
 // UnlinkMember mocks base method.
 func (m *MockMemberRepository[TUser, TAccount]) UnlinkMember(ctx context.Context, arg1 TAccount, members ...TUser) error {
 	m.ctrl.T.Helper()
@@ -512,3 +627,606 @@ func (mr *MockMemberRepositoryMockRecorder[TUser, TAccount]) UnlinkMember(ctx, a
 	varargs := append([]any{ctx, arg1}, members...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkMember", reflect.TypeOf((*MockMemberRepository[TUser, TAccount])(nil).UnlinkMember), varargs...)
 }
+
+// MockInviteRepository is a mock of InviteRepository interface.
+type MockInviteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInviteRepositoryMockRecorder is the mock recorder for MockInviteRepository.
+type MockInviteRepositoryMockRecorder struct {
+	mock *MockInviteRepository
+}
+
+// NewMockInviteRepository creates a new mock instance.
+func NewMockInviteRepository(ctrl *gomock.Controller) *MockInviteRepository {
+	mock := &MockInviteRepository{ctrl: ctrl}
+	mock.recorder = &MockInviteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInviteRepository) EXPECT() *MockInviteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountInvites mocks base method.
+func (m *MockInviteRepository) CountInvites(ctx context.Context, opts ...account.QOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountInvites", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInvites indicates an expected call of CountInvites.
+func (mr *MockInviteRepositoryMockRecorder) CountInvites(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInvites", reflect.TypeOf((*MockInviteRepository)(nil).CountInvites), varargs...)
+}
+
+// CreateInvite mocks base method.
+func (m *MockInviteRepository) CreateInvite(ctx context.Context, invite *account.MemberInvite, lifetime time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvite", ctx, invite, lifetime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvite indicates an expected call of CreateInvite.
+func (mr *MockInviteRepositoryMockRecorder) CreateInvite(ctx, invite, lifetime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvite", reflect.TypeOf((*MockInviteRepository)(nil).CreateInvite), ctx, invite, lifetime)
+}
+
+// FetchListInvites mocks base method.
+func (m *MockInviteRepository) FetchListInvites(ctx context.Context, opts ...account.QOption) ([]*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListInvites", varargs...)
+	ret0, _ := ret[0].([]*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListInvites indicates an expected call of FetchListInvites.
+func (mr *MockInviteRepositoryMockRecorder) FetchListInvites(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListInvites", reflect.TypeOf((*MockInviteRepository)(nil).FetchListInvites), varargs...)
+}
+
+// Invite mocks base method.
+func (m *MockInviteRepository) Invite(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, id)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockInviteRepositoryMockRecorder) Invite(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockInviteRepository)(nil).Invite), ctx, id)
+}
+
+// InviteByToken mocks base method.
+func (m *MockInviteRepository) InviteByToken(ctx context.Context, token string) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteByToken", ctx, token)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteByToken indicates an expected call of InviteByToken.
+func (mr *MockInviteRepositoryMockRecorder) InviteByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteByToken", reflect.TypeOf((*MockInviteRepository)(nil).InviteByToken), ctx, token)
+}
+
+// RenewInviteToken mocks base method.
+func (m *MockInviteRepository) RenewInviteToken(ctx context.Context, invite *account.MemberInvite, lifetime time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewInviteToken", ctx, invite, lifetime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewInviteToken indicates an expected call of RenewInviteToken.
+func (mr *MockInviteRepositoryMockRecorder) RenewInviteToken(ctx, invite, lifetime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewInviteToken", reflect.TypeOf((*MockInviteRepository)(nil).RenewInviteToken), ctx, invite, lifetime)
+}
+
+// SetInviteStatus mocks base method.
+func (m *MockInviteRepository) SetInviteStatus(ctx context.Context, id uint64, status account.InviteStatus, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInviteStatus", ctx, id, status, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetInviteStatus indicates an expected call of SetInviteStatus.
+func (mr *MockInviteRepositoryMockRecorder) SetInviteStatus(ctx, id, status, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInviteStatus", reflect.TypeOf((*MockInviteRepository)(nil).SetInviteStatus), ctx, id, status, userID)
+}
+
+// MockOwnershipRepository is a mock of OwnershipRepository interface.
+type MockOwnershipRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOwnershipRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOwnershipRepositoryMockRecorder is the mock recorder for MockOwnershipRepository.
+type MockOwnershipRepositoryMockRecorder struct {
+	mock *MockOwnershipRepository
+}
+
+// NewMockOwnershipRepository creates a new mock instance.
+func NewMockOwnershipRepository(ctrl *gomock.Controller) *MockOwnershipRepository {
+	mock := &MockOwnershipRepository{ctrl: ctrl}
+	mock.recorder = &MockOwnershipRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOwnershipRepository) EXPECT() *MockOwnershipRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteOwnershipTransfer mocks base method.
+func (m *MockOwnershipRepository) CompleteOwnershipTransfer(ctx context.Context, transfer *account.OwnershipTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOwnershipTransfer", ctx, transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteOwnershipTransfer indicates an expected call of CompleteOwnershipTransfer.
+func (mr *MockOwnershipRepositoryMockRecorder) CompleteOwnershipTransfer(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOwnershipTransfer", reflect.TypeOf((*MockOwnershipRepository)(nil).CompleteOwnershipTransfer), ctx, transfer)
+}
+
+// CreateOwnershipTransfer mocks base method.
+func (m *MockOwnershipRepository) CreateOwnershipTransfer(ctx context.Context, transfer *account.OwnershipTransfer, lifetime time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOwnershipTransfer", ctx, transfer, lifetime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOwnershipTransfer indicates an expected call of CreateOwnershipTransfer.
+func (mr *MockOwnershipRepositoryMockRecorder) CreateOwnershipTransfer(ctx, transfer, lifetime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOwnershipTransfer", reflect.TypeOf((*MockOwnershipRepository)(nil).CreateOwnershipTransfer), ctx, transfer, lifetime)
+}
+
+// FetchListOwnershipTransfers mocks base method.
+func (m *MockOwnershipRepository) FetchListOwnershipTransfers(ctx context.Context, opts ...account.QOption) ([]*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListOwnershipTransfers", varargs...)
+	ret0, _ := ret[0].([]*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListOwnershipTransfers indicates an expected call of FetchListOwnershipTransfers.
+func (mr *MockOwnershipRepositoryMockRecorder) FetchListOwnershipTransfers(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListOwnershipTransfers", reflect.TypeOf((*MockOwnershipRepository)(nil).FetchListOwnershipTransfers), varargs...)
+}
+
+// OwnershipTransfer mocks base method.
+func (m *MockOwnershipRepository) OwnershipTransfer(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnershipTransfer", ctx, id)
+	ret0, _ := ret[0].(*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OwnershipTransfer indicates an expected call of OwnershipTransfer.
+func (mr *MockOwnershipRepositoryMockRecorder) OwnershipTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnershipTransfer", reflect.TypeOf((*MockOwnershipRepository)(nil).OwnershipTransfer), ctx, id)
+}
+
+// SetOwnershipTransferStatus mocks base method.
+func (m *MockOwnershipRepository) SetOwnershipTransferStatus(ctx context.Context, id uint64, status account.TransferStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOwnershipTransferStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOwnershipTransferStatus indicates an expected call of SetOwnershipTransferStatus.
+func (mr *MockOwnershipRepositoryMockRecorder) SetOwnershipTransferStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwnershipTransferStatus", reflect.TypeOf((*MockOwnershipRepository)(nil).SetOwnershipTransferStatus), ctx, id, status)
+}
+
+// MockTeamRepository is a mock of TeamRepository interface.
+type MockTeamRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamRepositoryMockRecorder is the mock recorder for MockTeamRepository.
+type MockTeamRepositoryMockRecorder struct {
+	mock *MockTeamRepository
+}
+
+// NewMockTeamRepository creates a new mock instance.
+func NewMockTeamRepository(ctrl *gomock.Controller) *MockTeamRepository {
+	mock := &MockTeamRepository{ctrl: ctrl}
+	mock.recorder = &MockTeamRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamRepository) EXPECT() *MockTeamRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddTeamMembers mocks base method.
+func (m *MockTeamRepository) AddTeamMembers(ctx context.Context, teamID uint64, memberIDs ...uint64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamID}
+	for _, a := range memberIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddTeamMembers", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTeamMembers indicates an expected call of AddTeamMembers.
+func (mr *MockTeamRepositoryMockRecorder) AddTeamMembers(ctx, teamID any, memberIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamID}, memberIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMembers", reflect.TypeOf((*MockTeamRepository)(nil).AddTeamMembers), varargs...)
+}
+
+// CountTeams mocks base method.
+func (m *MockTeamRepository) CountTeams(ctx context.Context, opts ...account.QOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountTeams", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTeams indicates an expected call of CountTeams.
+func (mr *MockTeamRepositoryMockRecorder) CountTeams(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTeams", reflect.TypeOf((*MockTeamRepository)(nil).CountTeams), varargs...)
+}
+
+// CreateTeam mocks base method.
+func (m *MockTeamRepository) CreateTeam(ctx context.Context, team *account.Team) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", ctx, team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockTeamRepositoryMockRecorder) CreateTeam(ctx, team any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeamRepository)(nil).CreateTeam), ctx, team)
+}
+
+// DeleteTeam mocks base method.
+func (m *MockTeamRepository) DeleteTeam(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockTeamRepositoryMockRecorder) DeleteTeam(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockTeamRepository)(nil).DeleteTeam), ctx, id)
+}
+
+// FetchListTeams mocks base method.
+func (m *MockTeamRepository) FetchListTeams(ctx context.Context, opts ...account.QOption) ([]*account.Team, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListTeams", varargs...)
+	ret0, _ := ret[0].([]*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListTeams indicates an expected call of FetchListTeams.
+func (mr *MockTeamRepositoryMockRecorder) FetchListTeams(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListTeams", reflect.TypeOf((*MockTeamRepository)(nil).FetchListTeams), varargs...)
+}
+
+// RemoveTeamMembers mocks base method.
+func (m *MockTeamRepository) RemoveTeamMembers(ctx context.Context, teamID uint64, memberIDs ...uint64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamID}
+	for _, a := range memberIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveTeamMembers", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTeamMembers indicates an expected call of RemoveTeamMembers.
+func (mr *MockTeamRepositoryMockRecorder) RemoveTeamMembers(ctx, teamID any, memberIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamID}, memberIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamMembers", reflect.TypeOf((*MockTeamRepository)(nil).RemoveTeamMembers), varargs...)
+}
+
+// SetTeamRoles mocks base method.
+func (m *MockTeamRepository) SetTeamRoles(ctx context.Context, team *account.Team, roles ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, team}
+	for _, a := range roles {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetTeamRoles", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTeamRoles indicates an expected call of SetTeamRoles.
+func (mr *MockTeamRepositoryMockRecorder) SetTeamRoles(ctx, team any, roles ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, team}, roles...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTeamRoles", reflect.TypeOf((*MockTeamRepository)(nil).SetTeamRoles), varargs...)
+}
+
+// Team mocks base method.
+func (m *MockTeamRepository) Team(ctx context.Context, id uint64) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Team", ctx, id)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Team indicates an expected call of Team.
+func (mr *MockTeamRepositoryMockRecorder) Team(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Team", reflect.TypeOf((*MockTeamRepository)(nil).Team), ctx, id)
+}
+
+// UpdateTeam mocks base method.
+func (m *MockTeamRepository) UpdateTeam(ctx context.Context, team *account.Team) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeam", ctx, team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTeam indicates an expected call of UpdateTeam.
+func (mr *MockTeamRepositoryMockRecorder) UpdateTeam(ctx, team any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockTeamRepository)(nil).UpdateTeam), ctx, team)
+}
+
+// MockDomainRepository is a mock of DomainRepository interface.
+type MockDomainRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainRepositoryMockRecorder is the mock recorder for MockDomainRepository.
+type MockDomainRepositoryMockRecorder struct {
+	mock *MockDomainRepository
+}
+
+// NewMockDomainRepository creates a new mock instance.
+func NewMockDomainRepository(ctrl *gomock.Controller) *MockDomainRepository {
+	mock := &MockDomainRepository{ctrl: ctrl}
+	mock.recorder = &MockDomainRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainRepository) EXPECT() *MockDomainRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountDomains mocks base method.
+func (m *MockDomainRepository) CountDomains(ctx context.Context, opts ...account.QOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountDomains", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDomains indicates an expected call of CountDomains.
+func (mr *MockDomainRepositoryMockRecorder) CountDomains(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDomains", reflect.TypeOf((*MockDomainRepository)(nil).CountDomains), varargs...)
+}
+
+// CreateDomain mocks base method.
+func (m *MockDomainRepository) CreateDomain(ctx context.Context, domain *account.Domain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomain", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDomain indicates an expected call of CreateDomain.
+func (mr *MockDomainRepositoryMockRecorder) CreateDomain(ctx, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomain", reflect.TypeOf((*MockDomainRepository)(nil).CreateDomain), ctx, domain)
+}
+
+// DeleteDomain mocks base method.
+func (m *MockDomainRepository) DeleteDomain(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomain", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDomain indicates an expected call of DeleteDomain.
+func (mr *MockDomainRepositoryMockRecorder) DeleteDomain(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomain", reflect.TypeOf((*MockDomainRepository)(nil).DeleteDomain), ctx, id)
+}
+
+// Domain mocks base method.
+func (m *MockDomainRepository) Domain(ctx context.Context, id uint64) (*account.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Domain", ctx, id)
+	ret0, _ := ret[0].(*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Domain indicates an expected call of Domain.
+func (mr *MockDomainRepositoryMockRecorder) Domain(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Domain", reflect.TypeOf((*MockDomainRepository)(nil).Domain), ctx, id)
+}
+
+// FetchListDomains mocks base method.
+func (m *MockDomainRepository) FetchListDomains(ctx context.Context, opts ...account.QOption) ([]*account.Domain, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListDomains", varargs...)
+	ret0, _ := ret[0].([]*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListDomains indicates an expected call of FetchListDomains.
+func (mr *MockDomainRepositoryMockRecorder) FetchListDomains(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListDomains", reflect.TypeOf((*MockDomainRepository)(nil).FetchListDomains), varargs...)
+}
+
+// SetDomainVerified mocks base method.
+func (m *MockDomainRepository) SetDomainVerified(ctx context.Context, domain *account.Domain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDomainVerified", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDomainVerified indicates an expected call of SetDomainVerified.
+func (mr *MockDomainRepositoryMockRecorder) SetDomainVerified(ctx, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDomainVerified", reflect.TypeOf((*MockDomainRepository)(nil).SetDomainVerified), ctx, domain)
+}
+
+// UpdateDomain mocks base method.
+func (m *MockDomainRepository) UpdateDomain(ctx context.Context, domain *account.Domain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDomain", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDomain indicates an expected call of UpdateDomain.
+func (mr *MockDomainRepositoryMockRecorder) UpdateDomain(ctx, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomain", reflect.TypeOf((*MockDomainRepository)(nil).UpdateDomain), ctx, domain)
+}
+
+// VerifiedDomain mocks base method.
+func (m *MockDomainRepository) VerifiedDomain(ctx context.Context, name string) (*account.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifiedDomain", ctx, name)
+	ret0, _ := ret[0].(*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifiedDomain indicates an expected call of VerifiedDomain.
+func (mr *MockDomainRepositoryMockRecorder) VerifiedDomain(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifiedDomain", reflect.TypeOf((*MockDomainRepository)(nil).VerifiedDomain), ctx, name)
+}
+
+// MockActivityRepository is a mock of ActivityRepository interface.
+type MockActivityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityRepositoryMockRecorder is the mock recorder for MockActivityRepository.
+type MockActivityRepositoryMockRecorder struct {
+	mock *MockActivityRepository
+}
+
+// NewMockActivityRepository creates a new mock instance.
+func NewMockActivityRepository(ctrl *gomock.Controller) *MockActivityRepository {
+	mock := &MockActivityRepository{ctrl: ctrl}
+	mock.recorder = &MockActivityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityRepository) EXPECT() *MockActivityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// TouchActivity mocks base method.
+func (m *MockActivityRepository) TouchActivity(ctx context.Context, activity *account.MemberActivity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchActivity", ctx, activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchActivity indicates an expected call of TouchActivity.
+func (mr *MockActivityRepositoryMockRecorder) TouchActivity(ctx, activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchActivity", reflect.TypeOf((*MockActivityRepository)(nil).TouchActivity), ctx, activity)
+}
@@ -140,6 +140,51 @@ func (mr *MockUsecaseMockRecorder[TUser, TAccount]) Register(ctx, ownerObj, acco
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUsecase[TUser, TAccount])(nil).Register), ctx, ownerObj, accountObj)
 }
 
+// SetParent mocks base method.
+func (m *MockUsecase[TUser, TAccount]) SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) (TAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParent", ctx, id, parentID, inheritPermissions)
+	ret0, _ := ret[0].(TAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetParent indicates an expected call of SetParent.
+func (mr *MockUsecaseMockRecorder[TUser, TAccount]) SetParent(ctx, id, parentID, inheritPermissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockUsecase[TUser, TAccount])(nil).SetParent), ctx, id, parentID, inheritPermissions)
+}
+
+// Suspend mocks base method.
+func (m *MockUsecase[TUser, TAccount]) Suspend(ctx context.Context, id uint64, reason string) (TAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, id, reason)
+	ret0, _ := ret[0].(TAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockUsecaseMockRecorder[TUser, TAccount]) Suspend(ctx, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockUsecase[TUser, TAccount])(nil).Suspend), ctx, id, reason)
+}
+
+// Unsuspend mocks base method.
+func (m *MockUsecase[TUser, TAccount]) Unsuspend(ctx context.Context, id uint64) (TAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, id)
+	ret0, _ := ret[0].(TAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockUsecaseMockRecorder[TUser, TAccount]) Unsuspend(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockUsecase[TUser, TAccount])(nil).Unsuspend), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockUsecase[TUser, TAccount]) Update(ctx context.Context, arg1 TAccount) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -312,6 +357,21 @@ func (mr *MockMemberUsecaseMockRecorder[TUser, TAccount]) SetMemberRoles(ctx, me
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRoles", reflect.TypeOf((*MockMemberUsecase[TUser, TAccount])(nil).SetMemberRoles), varargs...)
 }
 
+// SuspendMember mocks base method.
+func (m *MockMemberUsecase[TUser, TAccount]) SuspendMember(ctx context.Context, memberID uint64, reason string) (*account.Member[TUser, TAccount], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendMember", ctx, memberID, reason)
+	ret0, _ := ret[0].(*account.Member[TUser, TAccount])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendMember indicates an expected call of SuspendMember.
+func (mr *MockMemberUsecaseMockRecorder[TUser, TAccount]) SuspendMember(ctx, memberID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendMember", reflect.TypeOf((*MockMemberUsecase[TUser, TAccount])(nil).SuspendMember), ctx, memberID, reason)
+}
+
 // UnlinkAccountMember mocks base method.
 func (m *MockMemberUsecase[TUser, TAccount]) UnlinkAccountMember(ctx context.Context, memberID uint64) error {
 	m.ctrl.T.Helper()
@@ -344,3 +404,733 @@ func (mr *MockMemberUsecaseMockRecorder[TUser, TAccount]) UnlinkMember(ctx, arg1
 	varargs := append([]any{ctx, arg1}, members...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkMember", reflect.TypeOf((*MockMemberUsecase[TUser, TAccount])(nil).UnlinkMember), varargs...)
 }
+
+// UnsuspendMember mocks base method.
+func (m *MockMemberUsecase[TUser, TAccount]) UnsuspendMember(ctx context.Context, memberID uint64) (*account.Member[TUser, TAccount], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsuspendMember", ctx, memberID)
+	ret0, _ := ret[0].(*account.Member[TUser, TAccount])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsuspendMember indicates an expected call of UnsuspendMember.
+func (mr *MockMemberUsecaseMockRecorder[TUser, TAccount]) UnsuspendMember(ctx, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsuspendMember", reflect.TypeOf((*MockMemberUsecase[TUser, TAccount])(nil).UnsuspendMember), ctx, memberID)
+}
+
+// MockInviteUsecase is a mock of InviteUsecase interface.
+type MockInviteUsecase[TUser user.Model, TAccount account.Model] struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteUsecaseMockRecorder[TUser, TAccount]
+	isgomock struct{}
+}
+
+// MockInviteUsecaseMockRecorder is the mock recorder for MockInviteUsecase.
+type MockInviteUsecaseMockRecorder[TUser user.Model, TAccount account.Model] struct {
+	mock *MockInviteUsecase[TUser, TAccount]
+}
+
+// NewMockInviteUsecase creates a new mock instance.
+func NewMockInviteUsecase[TUser user.Model, TAccount account.Model](ctrl *gomock.Controller) *MockInviteUsecase[TUser, TAccount] {
+	mock := &MockInviteUsecase[TUser, TAccount]{ctrl: ctrl}
+	mock.recorder = &MockInviteUsecaseMockRecorder[TUser, TAccount]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInviteUsecase[TUser, TAccount]) EXPECT() *MockInviteUsecaseMockRecorder[TUser, TAccount] {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) Accept(ctx context.Context, token, password string) (*account.Member[TUser, TAccount], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, token, password)
+	ret0, _ := ret[0].(*account.Member[TUser, TAccount])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) Accept(ctx, token, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).Accept), ctx, token, password)
+}
+
+// CountInvites mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) CountInvites(ctx context.Context, opts ...account.QOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountInvites", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInvites indicates an expected call of CountInvites.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) CountInvites(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInvites", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).CountInvites), varargs...)
+}
+
+// Decline mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) Decline(ctx context.Context, token string) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", ctx, token)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) Decline(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).Decline), ctx, token)
+}
+
+// FetchListInvites mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) FetchListInvites(ctx context.Context, opts ...account.QOption) ([]*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListInvites", varargs...)
+	ret0, _ := ret[0].([]*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListInvites indicates an expected call of FetchListInvites.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) FetchListInvites(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListInvites", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).FetchListInvites), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) Get(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).Get), ctx, id)
+}
+
+// Invite mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) Invite(ctx context.Context, accountID uint64, email string, roles ...string) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, accountID, email}
+	for _, a := range roles {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Invite", varargs...)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) Invite(ctx, accountID, email any, roles ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, accountID, email}, roles...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).Invite), varargs...)
+}
+
+// InviteByToken mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) InviteByToken(ctx context.Context, token string) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteByToken", ctx, token)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteByToken indicates an expected call of InviteByToken.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) InviteByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteByToken", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).InviteByToken), ctx, token)
+}
+
+// Resend mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) Resend(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resend", ctx, id)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resend indicates an expected call of Resend.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) Resend(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resend", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).Resend), ctx, id)
+}
+
+// Revoke mocks base method.
+func (m *MockInviteUsecase[TUser, TAccount]) Revoke(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(*account.MemberInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockInviteUsecaseMockRecorder[TUser, TAccount]) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockInviteUsecase[TUser, TAccount])(nil).Revoke), ctx, id)
+}
+
+// MockOwnershipUsecase is a mock of OwnershipUsecase interface.
+type MockOwnershipUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOwnershipUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockOwnershipUsecaseMockRecorder is the mock recorder for MockOwnershipUsecase.
+type MockOwnershipUsecaseMockRecorder struct {
+	mock *MockOwnershipUsecase
+}
+
+// NewMockOwnershipUsecase creates a new mock instance.
+func NewMockOwnershipUsecase(ctrl *gomock.Controller) *MockOwnershipUsecase {
+	mock := &MockOwnershipUsecase{ctrl: ctrl}
+	mock.recorder = &MockOwnershipUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOwnershipUsecase) EXPECT() *MockOwnershipUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockOwnershipUsecase) Cancel(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOwnershipUsecaseMockRecorder) Cancel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOwnershipUsecase)(nil).Cancel), ctx, id)
+}
+
+// Confirm mocks base method.
+func (m *MockOwnershipUsecase) Confirm(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, id)
+	ret0, _ := ret[0].(*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockOwnershipUsecaseMockRecorder) Confirm(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockOwnershipUsecase)(nil).Confirm), ctx, id)
+}
+
+// Decline mocks base method.
+func (m *MockOwnershipUsecase) Decline(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", ctx, id)
+	ret0, _ := ret[0].(*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockOwnershipUsecaseMockRecorder) Decline(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockOwnershipUsecase)(nil).Decline), ctx, id)
+}
+
+// FetchListTransfers mocks base method.
+func (m *MockOwnershipUsecase) FetchListTransfers(ctx context.Context, opts ...account.QOption) ([]*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListTransfers", varargs...)
+	ret0, _ := ret[0].([]*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListTransfers indicates an expected call of FetchListTransfers.
+func (mr *MockOwnershipUsecaseMockRecorder) FetchListTransfers(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListTransfers", reflect.TypeOf((*MockOwnershipUsecase)(nil).FetchListTransfers), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockOwnershipUsecase) Get(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOwnershipUsecaseMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOwnershipUsecase)(nil).Get), ctx, id)
+}
+
+// RequestTransfer mocks base method.
+func (m *MockOwnershipUsecase) RequestTransfer(ctx context.Context, accountID, toUserID uint64) (*account.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestTransfer", ctx, accountID, toUserID)
+	ret0, _ := ret[0].(*account.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestTransfer indicates an expected call of RequestTransfer.
+func (mr *MockOwnershipUsecaseMockRecorder) RequestTransfer(ctx, accountID, toUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestTransfer", reflect.TypeOf((*MockOwnershipUsecase)(nil).RequestTransfer), ctx, accountID, toUserID)
+}
+
+// MockTeamUsecase is a mock of TeamUsecase interface.
+type MockTeamUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamUsecaseMockRecorder is the mock recorder for MockTeamUsecase.
+type MockTeamUsecaseMockRecorder struct {
+	mock *MockTeamUsecase
+}
+
+// NewMockTeamUsecase creates a new mock instance.
+func NewMockTeamUsecase(ctrl *gomock.Controller) *MockTeamUsecase {
+	mock := &MockTeamUsecase{ctrl: ctrl}
+	mock.recorder = &MockTeamUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamUsecase) EXPECT() *MockTeamUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddMembers mocks base method.
+func (m *MockTeamUsecase) AddMembers(ctx context.Context, id uint64, memberIDs ...uint64) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range memberIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddMembers", varargs...)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMembers indicates an expected call of AddMembers.
+func (mr *MockTeamUsecaseMockRecorder) AddMembers(ctx, id any, memberIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, memberIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembers", reflect.TypeOf((*MockTeamUsecase)(nil).AddMembers), varargs...)
+}
+
+// CountTeams mocks base method.
+func (m *MockTeamUsecase) CountTeams(ctx context.Context, opts ...account.QOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountTeams", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTeams indicates an expected call of CountTeams.
+func (mr *MockTeamUsecaseMockRecorder) CountTeams(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTeams", reflect.TypeOf((*MockTeamUsecase)(nil).CountTeams), varargs...)
+}
+
+// Create mocks base method.
+func (m *MockTeamUsecase) Create(ctx context.Context, team *account.Team) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, team)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTeamUsecaseMockRecorder) Create(ctx, team any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTeamUsecase)(nil).Create), ctx, team)
+}
+
+// Delete mocks base method.
+func (m *MockTeamUsecase) Delete(ctx context.Context, id uint64) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTeamUsecaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTeamUsecase)(nil).Delete), ctx, id)
+}
+
+// FetchListTeams mocks base method.
+func (m *MockTeamUsecase) FetchListTeams(ctx context.Context, opts ...account.QOption) ([]*account.Team, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListTeams", varargs...)
+	ret0, _ := ret[0].([]*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListTeams indicates an expected call of FetchListTeams.
+func (mr *MockTeamUsecaseMockRecorder) FetchListTeams(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListTeams", reflect.TypeOf((*MockTeamUsecase)(nil).FetchListTeams), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockTeamUsecase) Get(ctx context.Context, id uint64) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTeamUsecaseMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTeamUsecase)(nil).Get), ctx, id)
+}
+
+// RemoveMembers mocks base method.
+func (m *MockTeamUsecase) RemoveMembers(ctx context.Context, id uint64, memberIDs ...uint64) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range memberIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveMembers", varargs...)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMembers indicates an expected call of RemoveMembers.
+func (mr *MockTeamUsecaseMockRecorder) RemoveMembers(ctx, id any, memberIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, memberIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMembers", reflect.TypeOf((*MockTeamUsecase)(nil).RemoveMembers), varargs...)
+}
+
+// SetRoles mocks base method.
+func (m *MockTeamUsecase) SetRoles(ctx context.Context, id uint64, roles ...string) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range roles {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetRoles", varargs...)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRoles indicates an expected call of SetRoles.
+func (mr *MockTeamUsecaseMockRecorder) SetRoles(ctx, id any, roles ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, roles...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoles", reflect.TypeOf((*MockTeamUsecase)(nil).SetRoles), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockTeamUsecase) Update(ctx context.Context, team *account.Team) (*account.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, team)
+	ret0, _ := ret[0].(*account.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTeamUsecaseMockRecorder) Update(ctx, team any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTeamUsecase)(nil).Update), ctx, team)
+}
+
+// MockDomainUsecase is a mock of DomainUsecase interface.
+type MockDomainUsecase[TUser user.Model, TAccount account.Model] struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainUsecaseMockRecorder[TUser, TAccount]
+	isgomock struct{}
+}
+
+// MockDomainUsecaseMockRecorder is the mock recorder for MockDomainUsecase.
+type MockDomainUsecaseMockRecorder[TUser user.Model, TAccount account.Model] struct {
+	mock *MockDomainUsecase[TUser, TAccount]
+}
+
+// NewMockDomainUsecase creates a new mock instance.
+func NewMockDomainUsecase[TUser user.Model, TAccount account.Model](ctrl *gomock.Controller) *MockDomainUsecase[TUser, TAccount] {
+	mock := &MockDomainUsecase[TUser, TAccount]{ctrl: ctrl}
+	mock.recorder = &MockDomainUsecaseMockRecorder[TUser, TAccount]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainUsecase[TUser, TAccount]) EXPECT() *MockDomainUsecaseMockRecorder[TUser, TAccount] {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) Add(ctx context.Context, accountID uint64, domain string) (*account.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, accountID, domain)
+	ret0, _ := ret[0].(*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) Add(ctx, accountID, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).Add), ctx, accountID, domain)
+}
+
+// AutoJoin mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) AutoJoin(ctx context.Context, userObj TUser, email string) (*account.Member[TUser, TAccount], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoJoin", ctx, userObj, email)
+	ret0, _ := ret[0].(*account.Member[TUser, TAccount])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoJoin indicates an expected call of AutoJoin.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) AutoJoin(ctx, userObj, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoJoin", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).AutoJoin), ctx, userObj, email)
+}
+
+// CountDomains mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) CountDomains(ctx context.Context, opts ...account.QOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountDomains", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDomains indicates an expected call of CountDomains.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) CountDomains(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDomains", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).CountDomains), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) Delete(ctx context.Context, id uint64) (*account.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).Delete), ctx, id)
+}
+
+// FetchListDomains mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) FetchListDomains(ctx context.Context, opts ...account.QOption) ([]*account.Domain, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchListDomains", varargs...)
+	ret0, _ := ret[0].([]*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListDomains indicates an expected call of FetchListDomains.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) FetchListDomains(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListDomains", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).FetchListDomains), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) Get(ctx context.Context, id uint64) (*account.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).Get), ctx, id)
+}
+
+// SSORequired mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) SSORequired(ctx context.Context, email string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSORequired", ctx, email)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSORequired indicates an expected call of SSORequired.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) SSORequired(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSORequired", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).SSORequired), ctx, email)
+}
+
+// SetPolicy mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) SetPolicy(ctx context.Context, id uint64, autoJoin bool, roles []string, ssoRequired bool) (*account.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPolicy", ctx, id, autoJoin, roles, ssoRequired)
+	ret0, _ := ret[0].(*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPolicy indicates an expected call of SetPolicy.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) SetPolicy(ctx, id, autoJoin, roles, ssoRequired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPolicy", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).SetPolicy), ctx, id, autoJoin, roles, ssoRequired)
+}
+
+// Verify mocks base method.
+func (m *MockDomainUsecase[TUser, TAccount]) Verify(ctx context.Context, id uint64) (*account.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, id)
+	ret0, _ := ret[0].(*account.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockDomainUsecaseMockRecorder[TUser, TAccount]) Verify(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockDomainUsecase[TUser, TAccount])(nil).Verify), ctx, id)
+}
+
+// MockActivityUsecase is a mock of ActivityUsecase interface.
+type MockActivityUsecase[TUser user.Model, TAccount account.Model] struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityUsecaseMockRecorder[TUser, TAccount]
+	isgomock struct{}
+}
+
+// MockActivityUsecaseMockRecorder is the mock recorder for MockActivityUsecase.
+type MockActivityUsecaseMockRecorder[TUser user.Model, TAccount account.Model] struct {
+	mock *MockActivityUsecase[TUser, TAccount]
+}
+
+// NewMockActivityUsecase creates a new mock instance.
+func NewMockActivityUsecase[TUser user.Model, TAccount account.Model](ctrl *gomock.Controller) *MockActivityUsecase[TUser, TAccount] {
+	mock := &MockActivityUsecase[TUser, TAccount]{ctrl: ctrl}
+	mock.recorder = &MockActivityUsecaseMockRecorder[TUser, TAccount]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityUsecase[TUser, TAccount]) EXPECT() *MockActivityUsecaseMockRecorder[TUser, TAccount] {
+	return m.recorder
+}
+
+// FetchListInactiveMembers mocks base method.
+func (m *MockActivityUsecase[TUser, TAccount]) FetchListInactiveMembers(ctx context.Context, accountID uint64, days int) ([]*account.Member[TUser, TAccount], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchListInactiveMembers", ctx, accountID, days)
+	ret0, _ := ret[0].([]*account.Member[TUser, TAccount])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchListInactiveMembers indicates an expected call of FetchListInactiveMembers.
+func (mr *MockActivityUsecaseMockRecorder[TUser, TAccount]) FetchListInactiveMembers(ctx, accountID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchListInactiveMembers", reflect.TypeOf((*MockActivityUsecase[TUser, TAccount])(nil).FetchListInactiveMembers), ctx, accountID, days)
+}
+
+// InactivityDays mocks base method.
+func (m *MockActivityUsecase[TUser, TAccount]) InactivityDays(ctx context.Context, accountID uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InactivityDays", ctx, accountID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InactivityDays indicates an expected call of InactivityDays.
+func (mr *MockActivityUsecaseMockRecorder[TUser, TAccount]) InactivityDays(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InactivityDays", reflect.TypeOf((*MockActivityUsecase[TUser, TAccount])(nil).InactivityDays), ctx, accountID)
+}
+
+// SuspendInactiveMembers mocks base method.
+func (m *MockActivityUsecase[TUser, TAccount]) SuspendInactiveMembers(ctx context.Context, accountID uint64) ([]*account.Member[TUser, TAccount], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendInactiveMembers", ctx, accountID)
+	ret0, _ := ret[0].([]*account.Member[TUser, TAccount])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendInactiveMembers indicates an expected call of SuspendInactiveMembers.
+func (mr *MockActivityUsecaseMockRecorder[TUser, TAccount]) SuspendInactiveMembers(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendInactiveMembers", reflect.TypeOf((*MockActivityUsecase[TUser, TAccount])(nil).SuspendInactiveMembers), ctx, accountID)
+}
@@ -5,6 +5,34 @@ import "github.com/geniusrabbit/blaze-api/repository/account/models"
 type (
 	M2MAccountMemberRole = models.M2MAccountMemberRole
 	PermissionChecker    = models.PermissionChecker
+	MemberInvite         = models.MemberInvite
+	InviteStatus         = models.InviteStatus
+	OwnershipTransfer    = models.OwnershipTransfer
+	TransferStatus       = models.TransferStatus
+	Team                 = models.Team
+	M2MAccountTeamMember = models.M2MAccountTeamMember
+	M2MAccountTeamRole   = models.M2MAccountTeamRole
+	Domain               = models.Domain
+	MemberActivity       = models.MemberActivity
+)
+
+// DomainVerificationPrefix of the DNS TXT record value which confirms the domain ownership
+const DomainVerificationPrefix = models.DomainVerificationPrefix
+
+// Statuses of the member invitation
+const (
+	InviteStatusPending  = models.InviteStatusPending
+	InviteStatusAccepted = models.InviteStatusAccepted
+	InviteStatusDeclined = models.InviteStatusDeclined
+	InviteStatusRevoked  = models.InviteStatusRevoked
+)
+
+// Statuses of the ownership transfer
+const (
+	TransferStatusPending   = models.TransferStatusPending
+	TransferStatusConfirmed = models.TransferStatusConfirmed
+	TransferStatusDeclined  = models.TransferStatusDeclined
+	TransferStatusCancelled = models.TransferStatusCancelled
 )
 
 // CtxPermissionCheckAccount is the context key for account permission checks.
@@ -19,8 +19,25 @@ type AccountBase struct {
 	UpdatedAt time.Time               `json:"updated_at"`
 	DeletedAt gorm.DeletedAt          `json:"deleted_at"`
 
+	// ParentID of the organization account, 0 for the top level account
+	ParentID uint64 `json:"parent_id" db:"parent_id"`
+
+	// InheritPermissions allows the members of the parent account
+	// to act in this account with their own permissions
+	InheritPermissions bool `json:"inherit_permissions" db:"inherit_permissions"`
+
+	// Suspended account keeps the data but nobody can act in it
+	pkgModels.Suspension
+
 	Permissions PermissionChecker `json:"-" gorm:"-"`
 	Admins      []uint64          `json:"-" gorm:"-"`
+
+	// SubAccounts inheriting the permissions of this account at any depth,
+	// loaded together with the session permissions
+	SubAccounts []uint64 `json:"-" gorm:"-"`
+
+	// Every set of scope patterns limits all permissions of the account
+	scopes [][]string
 }
 
 // GetID returns account ID.
@@ -67,6 +84,34 @@ func (acc *AccountBase) ExtendAdminUsers(ids ...uint64) {
 	acc.Admins = xtypes.SliceUnique(append(acc.Admins, ids...))
 }
 
+// GetParentID returns the parent account ID.
+func (acc *AccountBase) GetParentID() uint64 {
+	if acc == nil {
+		return 0
+	}
+	return acc.ParentID
+}
+
+// InheritsPermissions reports whether the members of the parent account act in this account.
+func (acc *AccountBase) InheritsPermissions() bool {
+	return acc != nil && acc.ParentID > 0 && acc.InheritPermissions
+}
+
+// SetSubAccounts which inherit the permissions of the account.
+func (acc *AccountBase) SetSubAccounts(ids ...uint64) {
+	if acc != nil {
+		acc.SubAccounts = ids
+	}
+}
+
+// IsSubAccount reports whether the account inherits the permissions of this account.
+func (acc *AccountBase) IsSubAccount(id uint64) bool {
+	if acc == nil || id == 0 {
+		return false
+	}
+	return xtypes.Slice[uint64](acc.SubAccounts).Has(func(v uint64) bool { return v == id })
+}
+
 // CheckPermissions for some specific resource.
 func (acc *AccountBase) CheckPermissions(ctx context.Context, resource any, patterns ...string) bool {
 	if acc == nil || acc.Permissions == nil {
@@ -128,7 +173,7 @@ func (acc *AccountBase) SetPermissions(perm PermissionChecker) {
 		acc.Permissions = nil
 		return
 	}
-	acc.Permissions = perm
+	acc.Permissions = acc.restrict(perm)
 }
 
 // ExtendPermissions of the account for the user.
@@ -136,6 +181,7 @@ func (acc *AccountBase) ExtendPermissions(perm PermissionChecker) {
 	if acc == nil || perm == nil {
 		return
 	}
+	perm = acc.restrict(perm)
 	switch prev := acc.Permissions.(type) {
 	case groupPermissionChecker:
 		prev = append(prev, perm)
@@ -147,6 +193,32 @@ func (acc *AccountBase) ExtendPermissions(perm PermissionChecker) {
 	}
 }
 
+// RestrictPermissions limits the current and all further permissions
+// of the account by the scope patterns. Restrictions can't be removed
+// and every next restriction narrows the previous ones.
+func (acc *AccountBase) RestrictPermissions(scopes ...string) {
+	if acc == nil || len(scopes) == 0 {
+		return
+	}
+	acc.scopes = append(acc.scopes, scopes)
+	acc.Permissions = NewScopedPermissionChecker(acc.Permissions, scopes...)
+}
+
+// PermissionRestrictions returns the sets of scope patterns limiting the account permissions.
+func (acc *AccountBase) PermissionRestrictions() [][]string {
+	if acc == nil {
+		return nil
+	}
+	return acc.scopes
+}
+
+func (acc *AccountBase) restrict(perm PermissionChecker) PermissionChecker {
+	for _, scopes := range acc.scopes {
+		perm = NewScopedPermissionChecker(perm, scopes...)
+	}
+	return perm
+}
+
 // SetApprove sets approval status.
 func (acc *AccountBase) SetApprove(status pkgModels.ApproveStatus) {
 	if acc != nil {
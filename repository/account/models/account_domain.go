@@ -0,0 +1,73 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+)
+
+// DomainVerificationPrefix of the DNS TXT record value which confirms the domain ownership
+const DomainVerificationPrefix = "blaze-verification="
+
+// Domain is the email domain of the account. The verified domain can enforce
+// the single sign-on and join the new users with the emails of the domain into the account.
+type Domain struct {
+	ID        uint64 `db:"id" gorm:"primaryKey"`
+	AccountID uint64 `db:"account_id"`
+	Domain    string `db:"domain"`
+
+	// Token is the expected value of the TXT record after the DomainVerificationPrefix
+	Token      string              `db:"token"`
+	VerifiedAt sql.Null[time.Time] `db:"verified_at" gorm:"column:verified_at"`
+
+	// AutoJoin links the new users with the verified email of the domain
+	// to the account with the default roles
+	AutoJoin     bool                      `db:"auto_join"`
+	DefaultRoles gosql.NullableStringArray `db:"default_roles" gorm:"type:text[]"`
+
+	// SSORequired rejects the password and the login link authorization
+	// for the emails of the domain
+	SSORequired bool `db:"sso_required" gorm:"column:sso_required"`
+
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	DeletedAt gorm.DeletedAt `db:"deleted_at"`
+}
+
+// TableName in database
+func (m *Domain) TableName() string {
+	return "account_domain"
+}
+
+// GetID returns domain ID
+func (m *Domain) GetID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.ID
+}
+
+// OwnerAccountID returns the account of the domain
+func (m *Domain) OwnerAccountID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.AccountID
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (m *Domain) RBACResourceName() string {
+	return "account.domain"
+}
+
+// IsVerified returns true if the domain ownership is confirmed
+func (m *Domain) IsVerified() bool {
+	return m != nil && m.VerifiedAt.Valid
+}
+
+// VerificationRecord returns the value of the TXT record to confirm the domain
+func (m *Domain) VerificationRecord() string {
+	return DomainVerificationPrefix + m.Token
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// InviteStatus of the member invitation
+type InviteStatus string
+
+// Statuses of the member invitation, only the pending invite can be accepted
+const (
+	InviteStatusPending  InviteStatus = "pending"
+	InviteStatusAccepted InviteStatus = "accepted"
+	InviteStatusDeclined InviteStatus = "declined"
+	InviteStatusRevoked  InviteStatus = "revoked"
+)
+
+// MemberInvite is the invitation of the new member of the account by email
+type MemberInvite struct {
+	ID        uint64                    `db:"id" gorm:"primaryKey"`
+	AccountID uint64                    `db:"account_id"`
+	Email     string                    `db:"email"`
+	Roles     gosql.NullableStringArray `db:"roles" gorm:"type:text[]"`
+
+	// Token contains the hash of the token in the database,
+	// the plain token is available only right after the creation or the renewal
+	Token  string       `db:"token"`
+	Status InviteStatus `db:"status"`
+
+	InvitedBy uint64 `db:"invited_by"`
+	UserID    uint64 `db:"user_id"` // the user who accepted or declined the invite
+	SentCount int    `db:"sent_count"`
+
+	SentAt    time.Time `db:"sent_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TableName in database
+func (m *MemberInvite) TableName() string {
+	return "account_member_invite"
+}
+
+// GetID returns invite ID
+func (m *MemberInvite) GetID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.ID
+}
+
+// OwnerAccountID returns the account of the invite
+func (m *MemberInvite) OwnerAccountID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.AccountID
+}
+
+// IsPending returns true if the invite can be accepted or declined
+func (m *MemberInvite) IsPending(now time.Time) bool {
+	return m.Status == InviteStatusPending && now.Before(m.ExpiresAt)
+}
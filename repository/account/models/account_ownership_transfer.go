@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// TransferStatus of the account ownership transfer
+type TransferStatus string
+
+// Statuses of the ownership transfer, only the pending transfer can be confirmed
+const (
+	TransferStatusPending   TransferStatus = "pending"
+	TransferStatusConfirmed TransferStatus = "confirmed"
+	TransferStatusDeclined  TransferStatus = "declined"
+	TransferStatusCancelled TransferStatus = "cancelled"
+)
+
+// OwnershipTransfer is the request of the owner to hand the account to another member
+type OwnershipTransfer struct {
+	ID         uint64         `db:"id" gorm:"primaryKey"`
+	AccountID  uint64         `db:"account_id"`
+	FromUserID uint64         `db:"from_user_id"`
+	ToUserID   uint64         `db:"to_user_id"`
+	Status     TransferStatus `db:"status"`
+
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TableName in database
+func (m *OwnershipTransfer) TableName() string {
+	return "account_ownership_transfer"
+}
+
+// GetID returns transfer ID
+func (m *OwnershipTransfer) GetID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.ID
+}
+
+// OwnerAccountID returns the account of the transfer
+func (m *OwnershipTransfer) OwnerAccountID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.AccountID
+}
+
+// IsPending returns true if the transfer can be confirmed or declined
+func (m *OwnershipTransfer) IsPending(now time.Time) bool {
+	return m.Status == TransferStatusPending && now.Before(m.ExpiresAt)
+}
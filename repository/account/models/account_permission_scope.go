@@ -0,0 +1,84 @@
+package models
+
+import (
+	"context"
+
+	"github.com/demdxx/rbac"
+)
+
+// scopedPermissionChecker limits the permissions of the base checker
+// to the permissions which names match the scope patterns.
+// The result is the intersection of the base rights and the scopes.
+type scopedPermissionChecker struct {
+	base   PermissionChecker
+	scopes []string
+}
+
+// NewScopedPermissionChecker returns the checker which allows only the permissions
+// of the base checker matching any of the scope patterns (like `user.view.*`).
+// Empty scopes don't restrict the base checker.
+func NewScopedPermissionChecker(base PermissionChecker, scopes ...string) PermissionChecker {
+	if base == nil || len(scopes) == 0 {
+		return base
+	}
+	return &scopedPermissionChecker{base: base, scopes: scopes}
+}
+
+// CheckPermissions verifies the patterns only against the permissions in the scopes.
+func (c *scopedPermissionChecker) CheckPermissions(ctx context.Context, resource any, patterns ...string) bool {
+	return c.CheckedPermissions(ctx, resource, patterns...) != nil
+}
+
+// CheckedPermissions returns the allowed permission from the scopes, or nil.
+func (c *scopedPermissionChecker) CheckedPermissions(ctx context.Context, resource any, patterns ...string) rbac.Permission {
+	if len(patterns) == 0 {
+		return nil
+	}
+	for _, perm := range c.base.Permissions(c.scopes...) {
+		if checked := perm.CheckedPermissions(ctx, resource, patterns...); checked != nil && c.inScope(checked) {
+			return checked
+		}
+	}
+	return nil
+}
+
+// ChildRoles returns roles of the base checker, the permissions of the roles are still limited by the scopes.
+func (c *scopedPermissionChecker) ChildRoles() []rbac.Role {
+	return c.base.ChildRoles()
+}
+
+// ChildPermissions returns the child permissions of the base checker in the scopes.
+func (c *scopedPermissionChecker) ChildPermissions() []rbac.Permission {
+	return c.filter(c.base.ChildPermissions())
+}
+
+// Permissions returns the permissions matching the patterns in the scopes.
+func (c *scopedPermissionChecker) Permissions(patterns ...string) []rbac.Permission {
+	return c.filter(c.base.Permissions(patterns...))
+}
+
+// HasPermission checks if any permission matching the patterns is in the scopes.
+func (c *scopedPermissionChecker) HasPermission(patterns ...string) bool {
+	for _, perm := range c.base.Permissions(patterns...) {
+		if c.inScope(perm) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *scopedPermissionChecker) filter(perms []rbac.Permission) []rbac.Permission {
+	var res []rbac.Permission
+	for _, perm := range perms {
+		if c.inScope(perm) {
+			res = append(res, perm)
+		}
+	}
+	return res
+}
+
+func (c *scopedPermissionChecker) inScope(perm rbac.Permission) bool {
+	return perm.MatchPermissionPattern(c.scopes...)
+}
+
+var _ PermissionChecker = (*scopedPermissionChecker)(nil)
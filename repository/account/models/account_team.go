@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	rbacModels "github.com/geniusrabbit/blaze-api/repository/rbac/models"
+)
+
+// Team is the named group of the account members with its own set of roles,
+// the members get the roles of all their teams in addition to the direct ones
+type Team struct {
+	ID          uint64             `db:"id" gorm:"primaryKey"`
+	AccountID   uint64             `db:"account_id"`
+	Name        string             `db:"name"`
+	Description string             `db:"description"`
+	Roles       []*rbacModels.Role `gorm:"many2many:m2m_account_team_role;foreignKey:ID;joinForeignKey:TeamID;references:ID;joinReferences:RoleID"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	DeletedAt   gorm.DeletedAt     `db:"deleted_at"`
+}
+
+// TableName in database
+func (m *Team) TableName() string {
+	return "account_team"
+}
+
+// GetID returns team ID
+func (m *Team) GetID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.ID
+}
+
+// OwnerAccountID returns the account of the team
+func (m *Team) OwnerAccountID() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.AccountID
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (m *Team) RBACResourceName() string {
+	return "account.team"
+}
+
+// M2MAccountTeamMember m2m link between teams and account members.
+type M2MAccountTeamMember struct {
+	TeamID    uint64    `db:"team_id" gorm:"primaryKey"`
+	MemberID  uint64    `db:"member_id" gorm:"primaryKey"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TableName of the model in the database.
+func (m *M2MAccountTeamMember) TableName() string {
+	return `m2m_account_team_member`
+}
+
+// M2MAccountTeamRole m2m link between teams and roles.
+type M2MAccountTeamRole struct {
+	TeamID    uint64    `db:"team_id" gorm:"primaryKey"`
+	RoleID    uint64    `db:"role_id" gorm:"primaryKey"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TableName of the model in the database.
+func (m *M2MAccountTeamRole) TableName() string {
+	return `m2m_account_team_role`
+}
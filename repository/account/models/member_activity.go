@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// MemberActivity is the last time the user acted in the account through the authorizer.
+// The account ID is 0 for the sessions without the account.
+type MemberActivity struct {
+	UserID     uint64    `db:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	AccountID  uint64    `db:"account_id" gorm:"primaryKey;autoIncrement:false"`
+	Authorizer string    `db:"authorizer" gorm:"primaryKey"`
+	LastSeenAt time.Time `db:"last_seen_at"`
+}
+
+// TableName in the database
+func (a *MemberActivity) TableName() string {
+	return "account_member_activity"
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,10 +17,17 @@ type MemberBase struct {
 	AccountID uint64                  `db:"account_id"`
 	UserID    uint64                  `db:"user_id"`
 	IsAdmin   bool                    `db:"is_admin"`
+	IsOwner   bool                    `db:"is_owner"`
 	Roles     []*rbacModels.Role      `gorm:"many2many:m2m_account_member_role;foreignKey:ID;joinForeignKey:MemberID;references:ID;joinReferences:RoleID"`
 	CreatedAt time.Time               `db:"created_at"`
 	UpdatedAt time.Time               `db:"updated_at"`
 	DeletedAt gorm.DeletedAt          `db:"deleted_at"`
+
+	// LastActiveAt is updated by the activity tracker only
+	LastActiveAt sql.Null[time.Time] `db:"last_active_at" gorm:"column:last_active_at;->"`
+
+	// Suspended member keeps the roles but can't act in the account
+	pkgModels.Suspension
 }
 
 // GetID returns member ID.
@@ -1,8 +1,11 @@
 package models_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/demdxx/rbac"
+
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	"github.com/geniusrabbit/blaze-api/repository/account/models"
 )
@@ -26,3 +29,74 @@ func TestAccountBaseDefaults(t *testing.T) {
 		t.Fatalf("RBACResourceName() = %q, want account", got)
 	}
 }
+
+type testResource struct{}
+
+func (r *testResource) RBACResourceName() string { return "testres" }
+
+func TestRestrictPermissions(t *testing.T) {
+	ctx := context.Background()
+	newRole := func() rbac.Role {
+		return rbac.MustNewRole("owner", rbac.WithPermissions(
+			rbac.MustNewResourcePermission("view.all", (*testResource)(nil)),
+			rbac.MustNewResourcePermission("update.all", (*testResource)(nil)),
+		))
+	}
+
+	acc := &testAccount{}
+	acc.SetPermissions(newRole())
+	if !acc.CheckPermissions(ctx, &testResource{}, "update.*") {
+		t.Fatal("update should be allowed before the restriction")
+	}
+
+	acc.RestrictPermissions("testres.view.*", "other.*")
+	if !acc.CheckPermissions(ctx, &testResource{}, "view.*") {
+		t.Error("view should be allowed by the scope")
+	}
+	if acc.CheckPermissions(ctx, &testResource{}, "update.*") {
+		t.Error("update should be denied by the scope")
+	}
+	if acc.HasPermission("testres.update.*") {
+		t.Error("update should be hidden by the scope")
+	}
+	if acc.HasPermission("other.*") {
+		t.Error("scope can't add rights which the owner doesn't have")
+	}
+
+	// Reloaded permissions are still restricted
+	acc.SetPermissions(newRole())
+	if acc.CheckPermissions(ctx, &testResource{}, "update.*") {
+		t.Error("update should be denied after the permissions reload")
+	}
+
+	// Every next restriction narrows the previous one
+	acc.RestrictPermissions("testres.update.*")
+	if acc.CheckPermissions(ctx, &testResource{}, "view.*") || acc.CheckPermissions(ctx, &testResource{}, "update.*") {
+		t.Error("intersection of the restrictions should be empty")
+	}
+	if got := len(acc.PermissionRestrictions()); got != 2 {
+		t.Errorf("PermissionRestrictions() = %d sets, want 2", got)
+	}
+}
+
+func TestAccountHierarchy(t *testing.T) {
+	var _ account.HierarchyModel = (*testAccount)(nil)
+
+	acc := &testAccount{AccountBase: models.AccountBase{ID: 1}}
+	if acc.IsSubAccount(2) {
+		t.Error("sub-accounts are not loaded")
+	}
+	acc.SetSubAccounts(2, 3)
+	if !acc.IsSubAccount(3) || acc.IsSubAccount(1) || acc.IsSubAccount(0) {
+		t.Error("IsSubAccount should match only the loaded sub-accounts")
+	}
+
+	child := &testAccount{AccountBase: models.AccountBase{ID: 2, InheritPermissions: true}}
+	if child.InheritsPermissions() {
+		t.Error("top level account can't inherit permissions")
+	}
+	child.ParentID = 1
+	if !child.InheritsPermissions() || child.GetParentID() != 1 {
+		t.Error("child should inherit the permissions of the parent")
+	}
+}
@@ -3,7 +3,10 @@ package account
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/demdxx/xtypes"
 	"github.com/guregu/null"
 	"gorm.io/gorm"
 
@@ -19,14 +22,38 @@ import (
 var (
 	errListFilterTooWide   = errors.New("list account (too wide filter)")
 	errMemberFilterTooWide = errors.New("member account for that account")
+	errInviteFilterTooWide = errors.New("member invites for that account")
+	errTeamFilterTooWide   = errors.New("teams for that account")
+	errDomainFilterTooWide = errors.New("domains for that account")
 )
 
+// SubAccountsQuery returns the recursive query of all sub-accounts of the accounts
+// passed as the single parameter. With inheritedOnly the tree is walked only
+// through the accounts which inherit the permissions of their parents.
+func SubAccountsQuery(inheritedOnly bool) string {
+	root, next := ``, ``
+	if inheritedOnly {
+		root, next = ` AND inherit_permissions`, ` AND acc.inherit_permissions`
+	}
+	table := (*models.AccountBase)(nil).TableName()
+	return `WITH RECURSIVE sub_accounts AS (` +
+		`SELECT id FROM ` + table + ` WHERE parent_id IN (?) AND deleted_at IS NULL` + root +
+		` UNION SELECT acc.id FROM ` + table + ` acc JOIN sub_accounts ON acc.parent_id = sub_accounts.id` +
+		` WHERE acc.deleted_at IS NULL` + next +
+		`) SELECT id FROM sub_accounts`
+}
+
 // Filter of the objects list
 type Filter struct {
-	ID     []uint64
-	UserID []uint64
-	Title  []string
-	Status []pkgModels.ApproveStatus
+	ID         []uint64
+	UserID     []uint64
+	Title      []string
+	Status     []pkgModels.ApproveStatus
+	ParentID   []uint64
+	AncestorID []uint64 // the whole subtree of the accounts
+
+	// inheritedOnly limits the children to the accounts inheriting permissions
+	inheritedOnly bool
 }
 
 func (fl *Filter) PrepareQuery(query *gorm.DB) *gorm.DB {
@@ -46,13 +73,31 @@ func (fl *Filter) PrepareQuery(query *gorm.DB) *gorm.DB {
 	if len(fl.Status) > 0 {
 		query = query.Where(`approve_status IN (?)`, fl.Status)
 	}
+	if len(fl.ParentID) > 0 {
+		query = query.Where(`parent_id IN (?)`, fl.ParentID)
+		if fl.inheritedOnly {
+			query = query.Where(`inherit_permissions`)
+		}
+	}
+	if len(fl.AncestorID) > 0 {
+		query = query.Where(`id IN (`+SubAccountsQuery(fl.inheritedOnly)+`)`, fl.AncestorID)
+	}
 	return query
 }
 
 // AdjustPermissions narrows the filter to the current user's accounts.
+// The subtree of the session account is available to its members
+// if the sub-accounts inherit the permissions.
 // Cannot import pkg/acl or pkg/context/session due to an import cycle;
 // uses the lower-level context sub-packages instead.
 func (fl *Filter) AdjustPermissions(ctx context.Context) error {
+	if len(fl.ParentID) > 0 || len(fl.AncestorID) > 0 {
+		if len(fl.UserID) > 0 || !inSessionScope(ctx, fl.ParentID) || !inSessionScope(ctx, fl.AncestorID) {
+			return errListFilterTooWide
+		}
+		fl.inheritedOnly = true
+		return nil
+	}
 	usr := userCtx.SessionUser(ctx)
 	if usr == nil {
 		return errListFilterTooWide
@@ -95,7 +140,14 @@ type MemberFilter struct {
 	UserID    []uint64
 	NotUserID []uint64
 	Status    []pkgModels.ApproveStatus
+	TeamID    []uint64
 	IsAdmin   null.Bool
+	IsOwner   null.Bool
+	Suspended null.Bool
+
+	// InactiveSince selects the members without any activity after the time,
+	// the members who never acted are inactive since the creation
+	InactiveSince time.Time
 }
 
 func (fl *MemberFilter) PrepareQuery(query *gorm.DB) *gorm.DB {
@@ -117,9 +169,26 @@ func (fl *MemberFilter) PrepareQuery(query *gorm.DB) *gorm.DB {
 	if len(fl.Status) > 0 {
 		query = query.Where(`approve_status IN (?)`, fl.Status)
 	}
+	if len(fl.TeamID) > 0 {
+		query = query.Where(`id IN (SELECT member_id FROM `+
+			(*models.M2MAccountTeamMember)(nil).TableName()+` WHERE team_id IN (?))`, fl.TeamID)
+	}
 	if fl.IsAdmin.Bool && fl.IsAdmin.Valid {
 		query = query.Where(`is_admin = ?`, fl.IsAdmin.Bool)
 	}
+	if fl.IsOwner.Valid {
+		query = query.Where(`is_owner = ?`, fl.IsOwner.Bool)
+	}
+	if fl.Suspended.Valid {
+		if fl.Suspended.Bool {
+			query = query.Where(`suspended_at IS NOT NULL`)
+		} else {
+			query = query.Where(`suspended_at IS NULL`)
+		}
+	}
+	if !fl.InactiveSince.IsZero() {
+		query = query.Where(`COALESCE(last_active_at, created_at) < ?`, fl.InactiveSince)
+	}
 	return query
 }
 
@@ -127,23 +196,25 @@ func (fl *MemberFilter) PrepareQuery(query *gorm.DB) *gorm.DB {
 // Cannot import pkg/acl or pkg/context/session due to an import cycle;
 // uses the lower-level context sub-packages instead.
 func (fl *MemberFilter) AdjustPermissions(ctx context.Context) error {
-	accID := accountCtx.SessionAccount(ctx).GetID()
-	if l := len(fl.AccountID); l > 1 || (l == 1 && fl.AccountID[0] != accID) {
+	if len(fl.AccountID) == 0 {
+		fl.AccountID = []uint64{accountCtx.SessionAccount(ctx).GetID()}
+	}
+	if !inSessionScope(ctx, fl.AccountID) {
 		return errMemberFilterTooWide
 	}
-	fl.AccountID = []uint64{accID}
 	return nil
 }
 
 // MemberListOrder of the objects list
 type MemberListOrder struct {
-	ID        pkgModels.Order
-	AccountID pkgModels.Order
-	UserID    pkgModels.Order
-	Status    pkgModels.Order
-	IsAdmin   pkgModels.Order
-	CreatedAt pkgModels.Order
-	UpdatedAt pkgModels.Order
+	ID           pkgModels.Order
+	AccountID    pkgModels.Order
+	UserID       pkgModels.Order
+	Status       pkgModels.Order
+	IsAdmin      pkgModels.Order
+	LastActiveAt pkgModels.Order
+	CreatedAt    pkgModels.Order
+	UpdatedAt    pkgModels.Order
 }
 
 func (ord *MemberListOrder) PrepareQuery(query *gorm.DB) *gorm.DB {
@@ -155,11 +226,237 @@ func (ord *MemberListOrder) PrepareQuery(query *gorm.DB) *gorm.DB {
 	query = ord.UserID.PrepareQuery(query, `user_id`)
 	query = ord.Status.PrepareQuery(query, `approve_status`)
 	query = ord.IsAdmin.PrepareQuery(query, `is_admin`)
+	query = ord.LastActiveAt.PrepareQuery(query, `last_active_at`)
 	query = ord.CreatedAt.PrepareQuery(query, `created_at`)
 	query = ord.UpdatedAt.PrepareQuery(query, `updated_at`)
 	return query
 }
 
+// InviteFilter of the member invitations list
+type InviteFilter struct {
+	ID        []uint64
+	AccountID []uint64
+	Email     []string
+	Status    []models.InviteStatus
+
+	// Expired limits the pending invites by the expiration time,
+	// the invites with other statuses are not affected
+	Expired null.Bool
+}
+
+func (fl *InviteFilter) PrepareQuery(query *gorm.DB) *gorm.DB {
+	if fl == nil {
+		return query
+	}
+	if len(fl.ID) > 0 {
+		query = query.Where(`id IN (?)`, fl.ID)
+	}
+	if len(fl.AccountID) > 0 {
+		query = query.Where(`account_id IN (?)`, fl.AccountID)
+	}
+	if len(fl.Email) > 0 {
+		query = query.Where(`LOWER(email) IN (?)`, xtypes.SliceApply(fl.Email, strings.ToLower))
+	}
+	if len(fl.Status) > 0 {
+		query = query.Where(`status IN (?)`, fl.Status)
+	}
+	if fl.Expired.Valid {
+		if fl.Expired.Bool {
+			query = query.Where(`(status<>? OR expires_at<NOW())`, models.InviteStatusPending)
+		} else {
+			query = query.Where(`(status<>? OR expires_at>=NOW())`, models.InviteStatusPending)
+		}
+	}
+	return query
+}
+
+// AdjustPermissions narrows the invite filter to the current session account.
+func (fl *InviteFilter) AdjustPermissions(ctx context.Context) error {
+	accID := accountCtx.SessionAccount(ctx).GetID()
+	if l := len(fl.AccountID); l > 1 || (l == 1 && fl.AccountID[0] != accID) {
+		return errInviteFilterTooWide
+	}
+	fl.AccountID = []uint64{accID}
+	return nil
+}
+
+// InviteListOrder of the member invitations list
+type InviteListOrder struct {
+	ID        pkgModels.Order
+	Email     pkgModels.Order
+	Status    pkgModels.Order
+	CreatedAt pkgModels.Order
+	ExpiresAt pkgModels.Order
+}
+
+func (ord *InviteListOrder) PrepareQuery(query *gorm.DB) *gorm.DB {
+	if ord == nil {
+		return query
+	}
+	query = ord.ID.PrepareQuery(query, `id`)
+	query = ord.Email.PrepareQuery(query, `email`)
+	query = ord.Status.PrepareQuery(query, `status`)
+	query = ord.CreatedAt.PrepareQuery(query, `created_at`)
+	query = ord.ExpiresAt.PrepareQuery(query, `expires_at`)
+	return query
+}
+
+// TransferFilter of the ownership transfers list
+type TransferFilter struct {
+	ID        []uint64
+	AccountID []uint64
+	UserID    []uint64 // the current or the new owner
+	Status    []models.TransferStatus
+}
+
+func (fl *TransferFilter) PrepareQuery(query *gorm.DB) *gorm.DB {
+	if fl == nil {
+		return query
+	}
+	if len(fl.ID) > 0 {
+		query = query.Where(`id IN (?)`, fl.ID)
+	}
+	if len(fl.AccountID) > 0 {
+		query = query.Where(`account_id IN (?)`, fl.AccountID)
+	}
+	if len(fl.UserID) > 0 {
+		query = query.Where(`(from_user_id IN (?) OR to_user_id IN (?))`, fl.UserID, fl.UserID)
+	}
+	if len(fl.Status) > 0 {
+		query = query.Where(`status IN (?)`, fl.Status)
+	}
+	return query
+}
+
+// TeamFilter of the account teams list
+type TeamFilter struct {
+	ID        []uint64
+	AccountID []uint64
+	MemberID  []uint64
+	Name      []string
+}
+
+func (fl *TeamFilter) PrepareQuery(query *gorm.DB) *gorm.DB {
+	if fl == nil {
+		return query
+	}
+	if len(fl.ID) > 0 {
+		query = query.Where(`id IN (?)`, fl.ID)
+	}
+	if len(fl.AccountID) > 0 {
+		query = query.Where(`account_id IN (?)`, fl.AccountID)
+	}
+	if len(fl.MemberID) > 0 {
+		query = query.Where(`id IN (SELECT team_id FROM `+
+			(*models.M2MAccountTeamMember)(nil).TableName()+` WHERE member_id IN (?))`, fl.MemberID)
+	}
+	if len(fl.Name) > 0 {
+		query = query.Where(`name IN (?)`, fl.Name)
+	}
+	return query
+}
+
+// AdjustPermissions narrows the team filter to the current session account.
+func (fl *TeamFilter) AdjustPermissions(ctx context.Context) error {
+	if len(fl.AccountID) == 0 {
+		fl.AccountID = []uint64{accountCtx.SessionAccount(ctx).GetID()}
+	}
+	if !inSessionScope(ctx, fl.AccountID) {
+		return errTeamFilterTooWide
+	}
+	return nil
+}
+
+// TeamListOrder of the account teams list
+type TeamListOrder struct {
+	ID        pkgModels.Order
+	AccountID pkgModels.Order
+	Name      pkgModels.Order
+	CreatedAt pkgModels.Order
+	UpdatedAt pkgModels.Order
+}
+
+func (ord *TeamListOrder) PrepareQuery(query *gorm.DB) *gorm.DB {
+	if ord == nil {
+		return query
+	}
+	query = ord.ID.PrepareQuery(query, `id`)
+	query = ord.AccountID.PrepareQuery(query, `account_id`)
+	query = ord.Name.PrepareQuery(query, `name`)
+	query = ord.CreatedAt.PrepareQuery(query, `created_at`)
+	query = ord.UpdatedAt.PrepareQuery(query, `updated_at`)
+	return query
+}
+
+// DomainFilter of the account domains list
+type DomainFilter struct {
+	ID        []uint64
+	AccountID []uint64
+	Domain    []string
+	Verified  null.Bool
+}
+
+func (fl *DomainFilter) PrepareQuery(query *gorm.DB) *gorm.DB {
+	if fl == nil {
+		return query
+	}
+	if len(fl.ID) > 0 {
+		query = query.Where(`id IN (?)`, fl.ID)
+	}
+	if len(fl.AccountID) > 0 {
+		query = query.Where(`account_id IN (?)`, fl.AccountID)
+	}
+	if len(fl.Domain) > 0 {
+		query = query.Where(`domain IN (?)`, xtypes.SliceApply(fl.Domain, strings.ToLower))
+	}
+	if fl.Verified.Valid {
+		if fl.Verified.Bool {
+			query = query.Where(`verified_at IS NOT NULL`)
+		} else {
+			query = query.Where(`verified_at IS NULL`)
+		}
+	}
+	return query
+}
+
+// AdjustPermissions narrows the domain filter to the current session account.
+func (fl *DomainFilter) AdjustPermissions(ctx context.Context) error {
+	if len(fl.AccountID) == 0 {
+		fl.AccountID = []uint64{accountCtx.SessionAccount(ctx).GetID()}
+	}
+	if !inSessionScope(ctx, fl.AccountID) {
+		return errDomainFilterTooWide
+	}
+	return nil
+}
+
+// DomainListOrder of the account domains list
+type DomainListOrder struct {
+	ID        pkgModels.Order
+	Domain    pkgModels.Order
+	CreatedAt pkgModels.Order
+}
+
+func (ord *DomainListOrder) PrepareQuery(query *gorm.DB) *gorm.DB {
+	if ord == nil {
+		return query
+	}
+	query = ord.ID.PrepareQuery(query, `id`)
+	query = ord.Domain.PrepareQuery(query, `domain`)
+	query = ord.CreatedAt.PrepareQuery(query, `created_at`)
+	return query
+}
+
+// inSessionScope reports whether all accounts are the session account or its inherited sub-accounts
+func inSessionScope(ctx context.Context, ids []uint64) bool {
+	for _, id := range ids {
+		if !accountCtx.InSessionScope(ctx, id) {
+			return false
+		}
+	}
+	return true
+}
+
 // Pagination of the objects list
 type Pagination = repository.Pagination
 
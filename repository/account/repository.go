@@ -2,6 +2,7 @@ package account
 
 import (
 	"context"
+	"time"
 
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
@@ -36,6 +37,17 @@ type Repository[T Model] interface {
 	// Delete deletes an account by ID.
 	// Returns an error if the account could not be deleted.
 	Delete(ctx context.Context, id uint64) error
+
+	// SubAccountIDs returns the IDs of all sub-accounts of the account at any depth.
+	// With inheritedOnly the tree is walked only through the accounts inheriting permissions.
+	SubAccountIDs(ctx context.Context, id uint64, inheritedOnly bool) ([]uint64, error)
+
+	// SetParent moves the account under the parent account, parentID 0 detaches it.
+	// Returns ErrAccountCycle if the parent is the account itself or its sub-account.
+	SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) error
+
+	// SetSuspended suspends the account with the reason or removes the suspension.
+	SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error
 }
 
 // SessionRepository extends account repository with auth session helpers.
@@ -78,8 +90,132 @@ type MemberRepository[TUser user.Model, TAccount Model] interface {
 	LinkMember(ctx context.Context, account TAccount, isAdmin bool, members ...TUser) error
 
 	// UnlinkMember unlinks users from the account.
+	// Returns ErrOwnerProtected for the owner and ErrLastAdmin if no other admin is left.
 	UnlinkMember(ctx context.Context, account TAccount, members ...TUser) error
 
+	// SetOwner makes the member the owner and the admin of the account,
+	// the previous owner stays the admin.
+	SetOwner(ctx context.Context, accountID, userID uint64) error
+
 	// UnlinkAccountMember unlinks a member from the account by member ID.
 	SetMemberRoles(ctx context.Context, account TAccount, member TUser, roles ...string) error
+
+	// SetMembersSuspended suspends the members of the account with the reason or removes
+	// the suspension, the owner of the account is never suspended.
+	SetMembersSuspended(ctx context.Context, accountID uint64, memberIDs []uint64, suspended bool, reason string) error
+}
+
+// InviteRepository of the member invitations by email.
+// The tokens are stored as SHA-256 hashes, the plain token is returned only
+// by CreateInvite and RenewInviteToken in the Token field of the invite.
+type InviteRepository interface {
+	// Invite retrieves the invite by ID.
+	Invite(ctx context.Context, id uint64) (*MemberInvite, error)
+
+	// InviteByToken retrieves the invite by the plain token.
+	InviteByToken(ctx context.Context, token string) (*MemberInvite, error)
+
+	// FetchListInvites retrieves the invites by the query options.
+	FetchListInvites(ctx context.Context, opts ...QOption) ([]*MemberInvite, error)
+
+	// CountInvites returns the number of the invites by the query options.
+	CountInvites(ctx context.Context, opts ...QOption) (int64, error)
+
+	// CreateInvite creates the pending invite with the new token which expires after the lifetime,
+	// the previous pending invite of the same email into the account is revoked.
+	CreateInvite(ctx context.Context, invite *MemberInvite, lifetime time.Duration) error
+
+	// RenewInviteToken replaces the token of the pending invite and extends its lifetime.
+	RenewInviteToken(ctx context.Context, invite *MemberInvite, lifetime time.Duration) error
+
+	// SetInviteStatus changes the status of the pending invite.
+	// Returns ErrInviteNotPending if the invite isn't pending anymore.
+	SetInviteStatus(ctx context.Context, id uint64, status InviteStatus, userID uint64) error
+}
+
+// OwnershipRepository of the account ownership transfers
+type OwnershipRepository interface {
+	// OwnershipTransfer retrieves the transfer by ID.
+	OwnershipTransfer(ctx context.Context, id uint64) (*OwnershipTransfer, error)
+
+	// FetchListOwnershipTransfers retrieves the transfers by the query options.
+	FetchListOwnershipTransfers(ctx context.Context, opts ...QOption) ([]*OwnershipTransfer, error)
+
+	// CreateOwnershipTransfer creates the pending transfer which expires after the lifetime,
+	// the previous pending transfer of the account is cancelled.
+	CreateOwnershipTransfer(ctx context.Context, transfer *OwnershipTransfer, lifetime time.Duration) error
+
+	// SetOwnershipTransferStatus changes the status of the pending transfer.
+	// Returns ErrTransferNotPending if the transfer isn't pending anymore.
+	SetOwnershipTransferStatus(ctx context.Context, id uint64, status TransferStatus) error
+
+	// CompleteOwnershipTransfer confirms the pending transfer and moves the owner flag
+	// to the target member in one transaction.
+	CompleteOwnershipTransfer(ctx context.Context, transfer *OwnershipTransfer) error
+}
+
+// TeamRepository of the account teams
+type TeamRepository interface {
+	// Team retrieves the team with its roles by ID.
+	Team(ctx context.Context, id uint64) (*Team, error)
+
+	// FetchListTeams retrieves the teams with their roles by the query options.
+	FetchListTeams(ctx context.Context, opts ...QOption) ([]*Team, error)
+
+	// CountTeams returns the number of the teams by the query options.
+	CountTeams(ctx context.Context, opts ...QOption) (int64, error)
+
+	// CreateTeam creates the team without the roles and the members.
+	CreateTeam(ctx context.Context, team *Team) error
+
+	// UpdateTeam updates the name and the description of the team.
+	UpdateTeam(ctx context.Context, team *Team) error
+
+	// DeleteTeam deletes the team and unlinks its members and roles.
+	DeleteTeam(ctx context.Context, id uint64) error
+
+	// SetTeamRoles replaces the roles of the team by names.
+	// Returns ErrInvalidTeamRole if some role isn't available for the team account.
+	SetTeamRoles(ctx context.Context, team *Team, roles ...string) error
+
+	// AddTeamMembers links the account members to the team, the existing links are kept.
+	AddTeamMembers(ctx context.Context, teamID uint64, memberIDs ...uint64) error
+
+	// RemoveTeamMembers unlinks the account members from the team.
+	RemoveTeamMembers(ctx context.Context, teamID uint64, memberIDs ...uint64) error
+}
+
+// DomainRepository of the account domains
+type DomainRepository interface {
+	// Domain retrieves the domain by ID.
+	Domain(ctx context.Context, id uint64) (*Domain, error)
+
+	// VerifiedDomain retrieves the verified domain by the name.
+	VerifiedDomain(ctx context.Context, name string) (*Domain, error)
+
+	// FetchListDomains retrieves the domains by the query options.
+	FetchListDomains(ctx context.Context, opts ...QOption) ([]*Domain, error)
+
+	// CountDomains returns the number of the domains by the query options.
+	CountDomains(ctx context.Context, opts ...QOption) (int64, error)
+
+	// CreateDomain creates the not verified domain with the new verification token.
+	CreateDomain(ctx context.Context, domain *Domain) error
+
+	// UpdateDomain updates the auto-join and the SSO policy of the domain.
+	UpdateDomain(ctx context.Context, domain *Domain) error
+
+	// DeleteDomain deletes the domain by ID.
+	DeleteDomain(ctx context.Context, id uint64) error
+
+	// SetDomainVerified marks the domain as verified.
+	// Returns ErrDomainTaken if another account verified the same domain.
+	SetDomainVerified(ctx context.Context, domain *Domain) error
+}
+
+// ActivityRepository of the last activity of the users in the accounts
+type ActivityRepository interface {
+	// TouchActivity stores the last activity time of the user through the authorizer
+	// and copies it to the user and to the member of the account.
+	TouchActivity(ctx context.Context, activity *MemberActivity) error
 }
@@ -66,8 +66,8 @@ func (s *testSuite) TestLoadPermissions() {
 			sqlmock.NewRows([]string{"id", "approve_status", "account_id", "user_id", "is_admin", "created_at", "updated_at", "deleted_at"}).
 				AddRow(1, 1, 1, 1, true, time.Now(), time.Now(), nil),
 		)
-	s.Mock.ExpectQuery(`SELECT role_id FROM "?`).
-		WithArgs(uint64(1)).
+	s.Mock.ExpectQuery(`SELECT role_id FROM m2m_account_member_role WHERE member_id=\$1 UNION SELECT tr.role_id FROM m2m_account_team_role`).
+		WithArgs(uint64(1), uint64(1)).
 		WillReturnRows(sqlmock.NewRows([]string{"role_id"}))
 
 	accountObj := testAccountStub(1)
@@ -113,6 +113,10 @@ func (s *testSuite) TestCreate() {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			nil,
+			uint64(0),
+			false,
+			nil,
+			"",
 			"test",
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
@@ -145,6 +149,30 @@ func (s *testSuite) TestDelete() {
 	s.NoError(err)
 }
 
+func (s *testSuite) TestSetParent() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery("WITH RECURSIVE sub_accounts").
+		WithArgs(uint64(101)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(102))
+	s.Mock.ExpectExec("UPDATE").
+		WithArgs(true, uint64(100), sqlmock.AnyArg(), uint64(101)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectCommit()
+	err := s.accountRepo.SetParent(s.Ctx, 101, 100, true)
+	s.NoError(err)
+}
+
+func (s *testSuite) TestSetParentCycle() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery("WITH RECURSIVE sub_accounts").
+		WithArgs(uint64(101)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(102).AddRow(103))
+	s.Mock.ExpectRollback()
+	err := s.accountRepo.SetParent(s.Ctx, 101, 103, true)
+	s.ErrorIs(err, account.ErrAccountCycle)
+	s.ErrorIs(s.accountRepo.SetParent(s.Ctx, 101, 101, false), account.ErrAccountCycle)
+}
+
 func TestAccountSuite(t *testing.T) {
 	suite.Run(t, &testSuite{})
 }
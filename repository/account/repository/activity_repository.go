@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	baseRepo "github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/account/models"
+)
+
+const defaultUserTable = "account_user"
+
+// ActivityRepository of the last activity of the users in the accounts
+type ActivityRepository struct {
+	baseRepo.Repository
+	userTable string
+}
+
+// NewActivityRepository creates the activity repository, the user table
+// keeps the last activity of the user and is "account_user" by default
+func NewActivityRepository(userTable string) *ActivityRepository {
+	if userTable == "" {
+		userTable = defaultUserTable
+	}
+	return &ActivityRepository{userTable: userTable}
+}
+
+// TouchActivity of the user through the authorizer. The user and the member
+// are updated by the table to skip the history log and the update hooks.
+func (r *ActivityRepository) TouchActivity(ctx context.Context, activity *account.MemberActivity) error {
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: `user_id`}, {Name: `account_id`}, {Name: `authorizer`}},
+			DoUpdates: clause.AssignmentColumns([]string{`last_seen_at`}),
+		}).Create(activity).Error
+		if err != nil {
+			return err
+		}
+		if activity.AccountID > 0 {
+			err = tx.Table((*models.MemberBase)(nil).TableName()).
+				Where(`account_id=? AND user_id=? AND deleted_at IS NULL`, activity.AccountID, activity.UserID).
+				UpdateColumn(`last_active_at`, activity.LastSeenAt).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Table(r.userTable).
+			Where(`id=?`, activity.UserID).
+			UpdateColumn(`last_active_at`, activity.LastSeenAt).Error
+	})
+}
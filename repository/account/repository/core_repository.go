@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"slices"
+	"time"
 
+	"github.com/demdxx/gocast/v2"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
@@ -78,6 +82,46 @@ func (r *coreAccountRepository[T]) Delete(ctx context.Context, id uint64) error
 	return r.Master(ctx).Model(r.newModel()).Delete(`id=?`, id).Error
 }
 
+func (r *coreAccountRepository[T]) SubAccountIDs(ctx context.Context, id uint64, inheritedOnly bool) ([]uint64, error) {
+	return subAccountIDs(r.Slave(ctx), id, inheritedOnly)
+}
+
+func (r *coreAccountRepository[T]) SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) error {
+	if parentID == id {
+		return account.ErrAccountCycle
+	}
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if parentID > 0 {
+			subIDs, err := subAccountIDs(tx, id, false)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(subIDs, parentID) {
+				return account.ErrAccountCycle
+			}
+		}
+		// Map update to be able to reset the parent and the flag to the zero values
+		return tx.Model(r.newModel()).Where(`id=?`, id).Updates(map[string]any{
+			`parent_id`:           parentID,
+			`inherit_permissions`: parentID > 0 && inheritPermissions,
+		}).Error
+	})
+}
+
+func (r *coreAccountRepository[T]) SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error {
+	// Map update to be able to reset the suspension to the NULL value
+	return r.Master(ctx).Model(r.newModel()).Where(`id=?`, id).Updates(map[string]any{
+		`suspended_at`:   sql.Null[time.Time]{V: time.Now(), Valid: suspended},
+		`suspend_reason`: gocast.IfThen(suspended, reason, ""),
+	}).Error
+}
+
+func subAccountIDs(query *gorm.DB, id uint64, inheritedOnly bool) ([]uint64, error) {
+	var ids []uint64
+	err := query.Raw(account.SubAccountsQuery(inheritedOnly), []uint64{id}).Scan(&ids).Error
+	return ids, err
+}
+
 type accountApprove interface {
 	SetApprove(pkgModels.ApproveStatus)
 }
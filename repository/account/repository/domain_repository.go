@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	baseRepo "github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/user/password"
+)
+
+const domainTokenSize = 32
+
+// DomainRepository of the account domains
+type DomainRepository struct {
+	baseRepo.Repository
+}
+
+// NewDomainRepository creates the account domains repository
+func NewDomainRepository() *DomainRepository {
+	return &DomainRepository{}
+}
+
+// Domain by ID
+func (r *DomainRepository) Domain(ctx context.Context, id uint64) (*account.Domain, error) {
+	object := new(account.Domain)
+	if err := r.Slave(ctx).First(object, `id=?`, id).Error; err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// VerifiedDomain by the name, only one account can verify the domain
+func (r *DomainRepository) VerifiedDomain(ctx context.Context, name string) (*account.Domain, error) {
+	object := new(account.Domain)
+	err := r.Slave(ctx).
+		First(object, `domain=? AND verified_at IS NOT NULL`, name).Error
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// FetchListDomains by the query options
+func (r *DomainRepository) FetchListDomains(ctx context.Context, opts ...account.QOption) ([]*account.Domain, error) {
+	var list []*account.Domain
+	query := account.ListOptions(opts).PrepareQuery(r.Slave(ctx).Model((*account.Domain)(nil)))
+	if err := query.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CountDomains by the query options
+func (r *DomainRepository) CountDomains(ctx context.Context, opts ...account.QOption) (int64, error) {
+	var count int64
+	query := account.ListOptions(opts).PrepareQuery(r.Slave(ctx).Model((*account.Domain)(nil)))
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CreateDomain not verified with the new verification token
+func (r *DomainRepository) CreateDomain(ctx context.Context, domain *account.Domain) error {
+	domain.Token = password.GenerateResetToken(domainTokenSize)
+	domain.VerifiedAt.Valid = false
+	return r.Master(ctx).Create(domain).Error
+}
+
+// UpdateDomain policy
+func (r *DomainRepository) UpdateDomain(ctx context.Context, domain *account.Domain) error {
+	return r.Master(ctx).Model(domain).
+		Select(`auto_join`, `default_roles`, `sso_required`).
+		Updates(domain).Error
+}
+
+// DeleteDomain by ID
+func (r *DomainRepository) DeleteDomain(ctx context.Context, id uint64) error {
+	return r.Master(ctx).Delete(&account.Domain{}, `id=?`, id).Error
+}
+
+// SetDomainVerified marks the domain as verified if no other account verified it before
+func (r *DomainRepository) SetDomainVerified(ctx context.Context, domain *account.Domain) error {
+	now := time.Now()
+	res := r.Master(ctx).Model((*account.Domain)(nil)).
+		Where(`id=?`, domain.ID).
+		Where(`NOT EXISTS (SELECT 1 FROM `+domain.TableName()+` AS d`+
+			` WHERE d.domain=? AND d.id<>? AND d.verified_at IS NOT NULL AND d.deleted_at IS NULL)`,
+			domain.Domain, domain.ID).
+		Update(`verified_at`, now)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return account.ErrDomainTaken
+	}
+	domain.VerifiedAt.V = now
+	domain.VerifiedAt.Valid = true
+	return nil
+}
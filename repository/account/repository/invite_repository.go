@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	baseRepo "github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/user/password"
+)
+
+const inviteTokenSize = 32
+
+// InviteRepository of the member invitations
+type InviteRepository struct {
+	baseRepo.Repository
+}
+
+// NewInviteRepository creates the member invitations repository
+func NewInviteRepository() *InviteRepository {
+	return &InviteRepository{}
+}
+
+// Invite by ID
+func (r *InviteRepository) Invite(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	object := new(account.MemberInvite)
+	if err := r.Slave(ctx).First(object, `id=?`, id).Error; err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// InviteByToken returns the invite by the plain token
+func (r *InviteRepository) InviteByToken(ctx context.Context, token string) (*account.MemberInvite, error) {
+	object := new(account.MemberInvite)
+	if err := r.Master(ctx).First(object, `token=?`, password.HashResetToken(token)).Error; err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// FetchListInvites by the query options
+func (r *InviteRepository) FetchListInvites(ctx context.Context, opts ...account.QOption) ([]*account.MemberInvite, error) {
+	var list []*account.MemberInvite
+	query := account.ListOptions(opts).PrepareQuery(r.Slave(ctx).Model((*account.MemberInvite)(nil)))
+	if err := query.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CountInvites by the query options
+func (r *InviteRepository) CountInvites(ctx context.Context, opts ...account.QOption) (int64, error) {
+	var count int64
+	query := account.ListOptions(opts).PrepareQuery(r.Slave(ctx).Model((*account.MemberInvite)(nil)))
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CreateInvite with the new token, the previous pending invite of the email is revoked
+func (r *InviteRepository) CreateInvite(ctx context.Context, invite *account.MemberInvite, lifetime time.Duration) error {
+	token := password.GenerateResetToken(inviteTokenSize)
+	now := time.Now()
+	invite.Token = password.HashResetToken(token)
+	invite.Status = account.InviteStatusPending
+	invite.SentCount = 1
+	invite.SentAt = now
+	invite.ExpiresAt = now.Add(lifetime)
+	err := r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		err := tx.Model((*account.MemberInvite)(nil)).
+			Where(`account_id=? AND LOWER(email)=LOWER(?) AND status=?`,
+				invite.AccountID, invite.Email, account.InviteStatusPending).
+			Update(`status`, account.InviteStatusRevoked).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(invite).Error
+	})
+	if err != nil {
+		return err
+	}
+	invite.Token = token
+	return nil
+}
+
+// RenewInviteToken of the pending invite and extends its lifetime
+func (r *InviteRepository) RenewInviteToken(ctx context.Context, invite *account.MemberInvite, lifetime time.Duration) error {
+	token := password.GenerateResetToken(inviteTokenSize)
+	now := time.Now()
+	res := r.Master(ctx).Model((*account.MemberInvite)(nil)).
+		Where(`id=? AND status=?`, invite.ID, account.InviteStatusPending).
+		Updates(map[string]any{
+			`token`:      password.HashResetToken(token),
+			`sent_count`: gorm.Expr(`sent_count + 1`),
+			`sent_at`:    now,
+			`expires_at`: now.Add(lifetime),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return account.ErrInviteNotPending
+	}
+	invite.Token = token
+	invite.SentCount++
+	invite.SentAt = now
+	invite.ExpiresAt = now.Add(lifetime)
+	return nil
+}
+
+// SetInviteStatus of the pending invite
+func (r *InviteRepository) SetInviteStatus(ctx context.Context, id uint64, status account.InviteStatus, userID uint64) error {
+	res := r.Master(ctx).Model((*account.MemberInvite)(nil)).
+		Where(`id=? AND status=?`, id, account.InviteStatusPending).
+		Updates(map[string]any{`status`: status, `user_id`: userID})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return account.ErrInviteNotPending
+	}
+	return nil
+}
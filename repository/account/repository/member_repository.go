@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"slices"
 	"time"
 
+	"github.com/demdxx/gocast/v2"
 	"github.com/demdxx/xtypes"
 	"github.com/guregu/null"
 	"github.com/pkg/errors"
@@ -21,7 +24,7 @@ import (
 
 var (
 	ErrInvalidRoleList        = errors.New(`invalid role list, check your permissions`)
-	ErrAccountHaveToHaveAdmin = errors.New(`account must have at least one admin`)
+	ErrAccountHaveToHaveAdmin = account.ErrLastAdmin
 )
 
 type memberRepository[TUser user.Model, TAccount account.Model] struct {
@@ -72,16 +75,16 @@ func (r *memberRepository[TUser, TAccount]) CountMembers(ctx context.Context, op
 }
 
 func (r *memberRepository[TUser, TAccount]) Member(ctx context.Context, userID, accountID uint64) (*account.Member[TUser, TAccount], error) {
-	return r.memberByQuery(ctx, `account_id=? AND user_id=?`, accountID, userID)
+	return r.memberByQuery(r.Slave(ctx), `account_id=? AND user_id=?`, accountID, userID)
 }
 
 func (r *memberRepository[TUser, TAccount]) MemberByID(ctx context.Context, id uint64) (*account.Member[TUser, TAccount], error) {
-	return r.memberByQuery(ctx, `id=?`, id)
+	return r.memberByQuery(r.Slave(ctx), `id=?`, id)
 }
 
-func (r *memberRepository[TUser, TAccount]) memberByQuery(ctx context.Context, query ...any) (*account.Member[TUser, TAccount], error) {
+func (r *memberRepository[TUser, TAccount]) memberByQuery(db *gorm.DB, query ...any) (*account.Member[TUser, TAccount], error) {
 	var base models.MemberBase
-	err := r.Slave(ctx).
+	err := db.
 		Model(&models.MemberBase{}).
 		Preload("Roles").
 		Where(query[0], query[1:]...).
@@ -122,6 +125,12 @@ func (r *memberRepository[TUser, TAccount]) IsAdmin(ctx context.Context, userID,
 
 func (r *memberRepository[TUser, TAccount]) LinkMember(ctx context.Context, accountObj TAccount, isAdmin bool, members ...TUser) error {
 	return r.Master(ctx).Transaction(func(tx *gorm.DB) error {
+		// Relinking of the existing admins without the admin flag demotes them
+		if !isAdmin {
+			if err := checkDemotion(tx, accountObj.GetID(), userIDs(members)); err != nil {
+				return err
+			}
+		}
 		query := tx.Model(&models.MemberBase{}).Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "account_id"}, {Name: "user_id"}},
 			DoUpdates: clause.AssignmentColumns([]string{"approve_status", "is_admin"}),
@@ -142,54 +151,82 @@ func (r *memberRepository[TUser, TAccount]) LinkMember(ctx context.Context, acco
 }
 
 func (r *memberRepository[TUser, TAccount]) UnlinkMember(ctx context.Context, accountObj TAccount, users ...TUser) error {
-	ids := make([]uint64, 0, len(users))
-	for _, u := range users {
-		ids = append(ids, u.GetID())
+	ids := userIDs(users)
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if err := checkDemotion(tx, accountObj.GetID(), ids); err != nil {
+			return err
+		}
+		return tx.Where(`account_id=? AND user_id IN ?`, accountObj.GetID(), ids).
+			Delete(&models.MemberBase{}).Error
+	})
+}
+
+// SetOwner of the account, the previous owner stays the admin of the account
+func (r *memberRepository[TUser, TAccount]) SetOwner(ctx context.Context, accountID, userID uint64) error {
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		return switchOwner(tx, accountID, userID)
+	})
+}
+
+// SetMembersSuspended of the account, the owner is skipped on the suspension
+func (r *memberRepository[TUser, TAccount]) SetMembersSuspended(ctx context.Context, accountID uint64, memberIDs []uint64, suspended bool, reason string) error {
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	query := r.Master(ctx).Model(&models.MemberBase{}).
+		Where(`account_id=? AND id IN ?`, accountID, memberIDs)
+	if suspended {
+		query = query.Where(`NOT is_owner`)
 	}
-	return r.Master(ctx).
-		Where(`account_id=? AND user_id IN ?`, accountObj.GetID(), ids).
-		Delete(&models.MemberBase{}).Error
+	// Map update to be able to reset the suspension to the NULL value
+	return query.Updates(map[string]any{
+		`suspended_at`:   sql.Null[time.Time]{V: time.Now(), Valid: suspended},
+		`suspend_reason`: gocast.IfThen(suspended, reason, ""),
+	}).Error
 }
 
 func (r *memberRepository[TUser, TAccount]) SetMemberRoles(ctx context.Context, accountObj TAccount, userObj TUser, roles ...string) error {
+	// The member can be linked in the same transaction, so it isn't read from the replica
 	var (
 		listRoles   []*prbac.Role
-		member, err = r.Member(ctx, userObj.GetID(), accountObj.GetID())
+		member, err = r.memberByQuery(r.Master(ctx), `account_id=? AND user_id=?`, accountObj.GetID(), userObj.GetID())
 	)
 	if err != nil {
 		return err
 	}
+	if member == nil {
+		return gorm.ErrRecordNotFound
+	}
 
 	if len(roles) > 0 {
-		if listRoles, err = r.rbacUse.FetchList(ctx, &prbac.Filter{Names: roles}); err != nil {
+		// The custom roles of the account are assignable only within the account
+		listRoles, err = r.rbacUse.FetchList(ctx, &prbac.Filter{
+			Names:     roles,
+			AccountID: []uint64{accountObj.GetID()},
+			Global:    true,
+		})
+		if err != nil {
 			return err
 		}
-		if len(listRoles) != len(roles) {
+		if listRoles = uniqueRoleNames(listRoles); len(listRoles) != len(roles) {
 			return ErrInvalidRoleList
 		}
 	}
 
 	wasAdmin := member.IsAdmin
 	member.Roles = listRoles
-	member.IsAdmin = xtypes.Slice[string](roles).Has(
-		func(v string) bool { return v == "admin" || v == "account:admin" || v == "system:admin" })
+	member.IsAdmin = slices.ContainsFunc(listRoles, isAdminRole)
 
-	if wasAdmin != member.IsAdmin && !member.IsAdmin {
-		cnt, err := r.CountMembers(ctx, &account.MemberFilter{
-			AccountID: []uint64{accountObj.GetID()},
-			NotUserID: []uint64{userObj.GetID()},
-			IsAdmin:   null.BoolFrom(true),
-			Status:    []pkgModels.ApproveStatus{pkgModels.ApprovedApproveStatus},
-		})
-		if err != nil {
-			return err
-		}
-		if cnt == 0 {
-			return ErrAccountHaveToHaveAdmin
-		}
+	if member.IsOwner && !member.IsAdmin {
+		return account.ErrOwnerProtected
 	}
 
 	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if wasAdmin && !member.IsAdmin {
+			if err := checkDemotion(tx, accountObj.GetID(), []uint64{userObj.GetID()}); err != nil {
+				return err
+			}
+		}
 		err := tx.Omit(clause.Associations).Save(&member.MemberBase).Error
 		if err != nil {
 			return err
@@ -211,3 +248,99 @@ func (r *memberRepository[TUser, TAccount]) SetMemberRoles(ctx context.Context,
 		})).Error
 	})
 }
+
+// isAdminRole of the account, only the global roles count
+// as the custom roles of the account can't define the admin rights
+func isAdminRole(role *prbac.Role) bool {
+	if !role.IsGlobal() {
+		return false
+	}
+	return role.Name == account.RoleAdmin || role.Name == "account:admin" || role.Name == "system:admin"
+}
+
+// uniqueRoleNames keeps one role per name, the global role overrides the custom role of the account
+func uniqueRoleNames(roles []*prbac.Role) []*prbac.Role {
+	byName := make(map[string]int, len(roles))
+	list := make([]*prbac.Role, 0, len(roles))
+	for _, role := range roles {
+		idx, ok := byName[role.Name]
+		switch {
+		case !ok:
+			byName[role.Name] = len(list)
+			list = append(list, role)
+		case role.IsGlobal():
+			list[idx] = role
+		}
+	}
+	return list
+}
+
+func userIDs[TUser user.Model](users []TUser) []uint64 {
+	ids := make([]uint64, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.GetID())
+	}
+	return ids
+}
+
+// checkDemotion refuses to remove the admin rights of the users
+// if one of them is the owner or no other approved admin is left.
+// The admin rows of the account are locked till the end of the transaction,
+// so the concurrent demotions can't remove the last admins together.
+func checkDemotion(tx *gorm.DB, accountID uint64, ids []uint64) error {
+	var admins []models.MemberBase
+	err := tx.Model(&models.MemberBase{}).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where(`account_id=? AND is_admin`, accountID).
+		Find(&admins).Error
+	if err != nil {
+		return err
+	}
+	demoted := false
+	for _, member := range admins {
+		if !slices.Contains(ids, member.UserID) {
+			continue
+		}
+		if member.IsOwner {
+			return account.ErrOwnerProtected
+		}
+		demoted = true
+	}
+	if !demoted {
+		return nil
+	}
+	var count int64
+	query := account.ListOptions{&account.MemberFilter{
+		AccountID: []uint64{accountID},
+		NotUserID: ids,
+		IsAdmin:   null.BoolFrom(true),
+		Status:    []pkgModels.ApproveStatus{pkgModels.ApprovedApproveStatus},
+	}}.PrepareQuery(tx.Model(&models.MemberBase{}))
+	if err = query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return account.ErrLastAdmin
+	}
+	return nil
+}
+
+// switchOwner moves the owner flag to the member, the new owner becomes the admin
+func switchOwner(tx *gorm.DB, accountID, userID uint64) error {
+	err := tx.Model(&models.MemberBase{}).
+		Where(`account_id=? AND is_owner AND user_id<>?`, accountID, userID).
+		Update(`is_owner`, false).Error
+	if err != nil {
+		return err
+	}
+	res := tx.Model(&models.MemberBase{}).
+		Where(`account_id=? AND user_id=?`, accountID, userID).
+		Updates(map[string]any{`is_owner`: true, `is_admin`: true})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return account.ErrInvalidTransferTarget
+	}
+	return nil
+}
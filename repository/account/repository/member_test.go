@@ -83,10 +83,10 @@ func (s *testMemberSuite) TestIsAdmin() {
 func (s *testMemberSuite) TestLinkMember() {
 	s.Mock.ExpectBegin()
 	s.Mock.ExpectQuery("INSERT INTO").
-		WithArgs(pkgModels.ApprovedApproveStatus, uint64(101), uint64(101), true, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(pkgModels.ApprovedApproveStatus, uint64(101), uint64(101), true, false, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(101))
 	s.Mock.ExpectQuery("INSERT INTO").
-		WithArgs(pkgModels.ApprovedApproveStatus, uint64(101), uint64(102), true, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(pkgModels.ApprovedApproveStatus, uint64(101), uint64(102), true, false, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(102))
 	s.Mock.ExpectCommit()
 
@@ -99,9 +99,17 @@ func (s *testMemberSuite) TestLinkMember() {
 
 func (s *testMemberSuite) TestUnlinkMember() {
 	ctx := s.Ctx
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`SELECT \* FROM "account_member" WHERE \(account_id=\$1 AND is_admin\) .* FOR UPDATE`).
+		WithArgs(uint64(101)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "account_id", "user_id", "is_admin", "is_owner"}).
+				AddRow(3, 101, 103, true, true),
+		)
 	s.Mock.ExpectExec(`UPDATE "account_member" SET "deleted_at"`).
 		WithArgs(sqlmock.AnyArg(), uint64(101), uint64(101), uint64(102)).
 		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.Mock.ExpectCommit()
 	accountObj := testAccountStub(101)
 	accountObj.Title = "test"
 	users := []*testutil.User{testutil.Stub(101), testutil.Stub(102)}
@@ -109,6 +117,74 @@ func (s *testMemberSuite) TestUnlinkMember() {
 	s.NoError(err)
 }
 
+func (s *testMemberSuite) TestUnlinkMemberOwner() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`SELECT \* FROM "account_member" .* FOR UPDATE`).
+		WithArgs(uint64(101)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "account_id", "user_id", "is_admin", "is_owner"}).
+				AddRow(1, 101, 101, true, true),
+		)
+	s.Mock.ExpectRollback()
+	err := s.memberRepo.UnlinkMember(s.Ctx, testAccountStub(101), testutil.Stub(101))
+	s.ErrorIs(err, account.ErrOwnerProtected)
+}
+
+func (s *testMemberSuite) TestUnlinkMemberLastAdmin() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`SELECT \* FROM "account_member" .* FOR UPDATE`).
+		WithArgs(uint64(101)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "account_id", "user_id", "is_admin", "is_owner"}).
+				AddRow(2, 101, 102, true, false),
+		)
+	s.Mock.ExpectQuery(`SELECT count\(\*\) FROM "account_member"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(0)))
+	s.Mock.ExpectRollback()
+	err := s.memberRepo.UnlinkMember(s.Ctx, testAccountStub(101), testutil.Stub(102))
+	s.ErrorIs(err, account.ErrLastAdmin)
+}
+
+func (s *testMemberSuite) TestLinkMemberDemoteOwner() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`SELECT \* FROM "account_member" .* FOR UPDATE`).
+		WithArgs(uint64(101)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "account_id", "user_id", "is_admin", "is_owner"}).
+				AddRow(1, 101, 101, true, true),
+		)
+	s.Mock.ExpectRollback()
+	err := s.memberRepo.LinkMember(s.Ctx, testAccountStub(101), false, testutil.Stub(101))
+	s.ErrorIs(err, account.ErrOwnerProtected)
+}
+
+func (s *testMemberSuite) TestSetMembersSuspended() {
+	s.Mock.ExpectExec(`UPDATE "account_member" SET .* WHERE \(account_id=\$\d+ AND id IN \(\$\d+,\$\d+\)\) AND NOT is_owner`).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	err := s.memberRepo.SetMembersSuspended(s.Ctx, 101, []uint64{2, 3}, true, "inactive")
+	s.NoError(err)
+}
+
+func (s *testMemberSuite) TestTouchActivity() {
+	repo := accountrepo.NewActivityRepository("")
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec(`INSERT INTO "account_member_activity" .* ON CONFLICT .* DO UPDATE SET "last_seen_at"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectExec(`UPDATE "account_member" SET "last_active_at"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectExec(`UPDATE "account_user" SET "last_active_at"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectCommit()
+	err := repo.TouchActivity(s.Ctx, &account.MemberActivity{
+		UserID:     101,
+		AccountID:  1,
+		Authorizer: "jwt",
+		LastSeenAt: time.Now(),
+	})
+	s.NoError(err)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
 func TestMemberSuite(t *testing.T) {
 	suite.Run(t, &testMemberSuite{})
 }
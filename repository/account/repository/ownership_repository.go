@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	baseRepo "github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+)
+
+// OwnershipRepository of the account ownership transfers
+type OwnershipRepository struct {
+	baseRepo.Repository
+}
+
+// NewOwnershipRepository creates the ownership transfers repository
+func NewOwnershipRepository() *OwnershipRepository {
+	return &OwnershipRepository{}
+}
+
+// OwnershipTransfer by ID
+func (r *OwnershipRepository) OwnershipTransfer(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	object := new(account.OwnershipTransfer)
+	if err := r.Master(ctx).First(object, `id=?`, id).Error; err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// FetchListOwnershipTransfers by the query options
+func (r *OwnershipRepository) FetchListOwnershipTransfers(ctx context.Context, opts ...account.QOption) ([]*account.OwnershipTransfer, error) {
+	var list []*account.OwnershipTransfer
+	query := account.ListOptions(opts).PrepareQuery(r.Slave(ctx).Model((*account.OwnershipTransfer)(nil)))
+	if err := query.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateOwnershipTransfer in the pending state, the previous pending transfer of the account is cancelled
+func (r *OwnershipRepository) CreateOwnershipTransfer(ctx context.Context, transfer *account.OwnershipTransfer, lifetime time.Duration) error {
+	transfer.Status = account.TransferStatusPending
+	transfer.ExpiresAt = time.Now().Add(lifetime)
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		err := tx.Model((*account.OwnershipTransfer)(nil)).
+			Where(`account_id=? AND status=?`, transfer.AccountID, account.TransferStatusPending).
+			Update(`status`, account.TransferStatusCancelled).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(transfer).Error
+	})
+}
+
+// SetOwnershipTransferStatus of the pending transfer
+func (r *OwnershipRepository) SetOwnershipTransferStatus(ctx context.Context, id uint64, status account.TransferStatus) error {
+	return setTransferStatus(r.Master(ctx), id, status)
+}
+
+// CompleteOwnershipTransfer confirms the pending transfer and switches the owner of the account
+func (r *OwnershipRepository) CompleteOwnershipTransfer(ctx context.Context, transfer *account.OwnershipTransfer) error {
+	err := r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if err := setTransferStatus(tx, transfer.ID, account.TransferStatusConfirmed); err != nil {
+			return err
+		}
+		return switchOwner(tx, transfer.AccountID, transfer.ToUserID)
+	})
+	if err != nil {
+		return err
+	}
+	transfer.Status = account.TransferStatusConfirmed
+	return nil
+}
+
+func setTransferStatus(tx *gorm.DB, id uint64, status account.TransferStatus) error {
+	res := tx.Model((*account.OwnershipTransfer)(nil)).
+		Where(`id=? AND status=?`, id, account.TransferStatusPending).
+		Update(`status`, status)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return account.ErrTransferNotPending
+	}
+	return nil
+}
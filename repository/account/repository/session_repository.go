@@ -17,6 +17,14 @@ import (
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
 
+// memberRolesQuery selects the direct roles of the member and the roles of all its teams,
+// takes the member ID twice
+var memberRolesQuery = `SELECT role_id FROM ` + (*models.M2MAccountMemberRole)(nil).TableName() + ` WHERE member_id=?` +
+	` UNION SELECT tr.role_id FROM ` + (*models.M2MAccountTeamRole)(nil).TableName() + ` AS tr` +
+	` INNER JOIN ` + (*models.M2MAccountTeamMember)(nil).TableName() + ` AS tm ON tm.team_id=tr.team_id` +
+	` INNER JOIN ` + (*models.Team)(nil).TableName() + ` AS t ON t.id=tr.team_id AND t.deleted_at IS NULL` +
+	` WHERE tm.member_id=?`
+
 type sessionRepository[TUser user.Model, TAccount account.Model] struct {
 	baseRepo.Repository
 	core         account.Repository[TAccount]
@@ -65,6 +73,18 @@ func (r *sessionRepository[TUser, TAccount]) Delete(ctx context.Context, id uint
 	return r.core.Delete(ctx, id)
 }
 
+func (r *sessionRepository[TUser, TAccount]) SubAccountIDs(ctx context.Context, id uint64, inheritedOnly bool) ([]uint64, error) {
+	return r.core.SubAccountIDs(ctx, id, inheritedOnly)
+}
+
+func (r *sessionRepository[TUser, TAccount]) SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) error {
+	return r.core.SetParent(ctx, id, parentID, inheritPermissions)
+}
+
+func (r *sessionRepository[TUser, TAccount]) SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error {
+	return r.core.SetSuspended(ctx, id, suspended, reason)
+}
+
 func (r *sessionRepository[TUser, TAccount]) LoadPermissions(ctx context.Context, accountObj TAccount, userObj TUser) error {
 	var zeroAcc TAccount
 	var zeroUser TUser
@@ -88,9 +108,11 @@ func (r *sessionRepository[TUser, TAccount]) LoadPermissions(ctx context.Context
 	if err := query.Find(&member, `account_id=? AND user_id=?`, accountObj.GetID(), userObj.GetID()).Error; err != nil {
 		return errors.WithStack(err)
 	}
+	if member.IsSuspended() {
+		return account.ErrMemberSuspended
+	}
 
-	err := r.Slave(ctx).Table((*models.M2MAccountMemberRole)(nil).TableName()).
-		Where(`member_id=?`, member.ID).Select(`role_id`).Find(&roles).Error
+	err := r.Slave(ctx).Raw(memberRolesQuery, member.ID, member.ID).Scan(&roles).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, sql.ErrNoRows) {
 		return errors.WithStack(err)
 	}
@@ -103,7 +125,7 @@ func (r *sessionRepository[TUser, TAccount]) LoadPermissions(ctx context.Context
 	accApprove := getApprove(accountObj)
 
 	if !accApprove.IsRejected() && !userApprove.IsRejected() {
-		perm, err := r.PermissionManager(ctx).AsOneRole(ctx, member.IsAdmin, nil, roles...)
+		perm, err := r.PermissionManager(ctx).AsOneAccountRole(ctx, accountObj.GetID(), member.IsAdmin, nil, roles...)
 		if err != nil {
 			return err
 		}
@@ -111,7 +133,7 @@ func (r *sessionRepository[TUser, TAccount]) LoadPermissions(ctx context.Context
 		return nil
 	}
 
-	perm, err := r.PermissionManager(ctx).AsOneRole(ctx, false, func(_ context.Context, role rbac.Role) bool {
+	perm, err := r.PermissionManager(ctx).AsOneAccountRole(ctx, accountObj.GetID(), false, func(_ context.Context, role rbac.Role) bool {
 		return !strings.HasPrefix(role.Name(), "system:") || !strings.HasPrefix(role.Name(), "account:")
 	}, roles...)
 	if err != nil {
@@ -123,18 +145,22 @@ func (r *sessionRepository[TUser, TAccount]) LoadPermissions(ctx context.Context
 
 func (r *sessionRepository[TUser, TAccount]) GetByToken(ctx context.Context, token string) (TUser, TAccount, error) {
 	var (
-		err           error
-		roles         []uint64
-		db            = r.Slave(ctx)
-		userObj       = r.newUserModel()
-		accObj        = r.EmptyObject()
-		zeroUser      TUser
-		zeroAcc       TAccount
-		member        = r.newMember()
+		err      error
+		roles    []uint64
+		db       = r.Slave(ctx)
+		userObj  = r.newUserModel()
+		accObj   = r.EmptyObject()
+		zeroUser TUser
+		zeroAcc  TAccount
+		member   = r.newMember()
+		// The session belongs to the user who authorized the client,
+		// tokens of the client credentials grant belong to the client owner
 		memberRequest = `WITH auth_client AS (` +
-			`  SELECT user_id, account_id FROM ` + (*authclientModels.AuthClient)(nil).TableName() + ` WHERE id = (` +
-			`    SELECT client_id FROM ` + (*authclientModels.AuthSession)(nil).TableName() + ` WHERE deleted_at IS NULL AND access_token=?` +
-			`  )` +
+			`  SELECT COALESCE(NULLIF(s.user_id, 0), c.user_id) AS user_id,` +
+			`         COALESCE(NULLIF(s.account_id, 0), c.account_id) AS account_id` +
+			`  FROM ` + (*authclientModels.AuthSession)(nil).TableName() + ` AS s` +
+			`  INNER JOIN ` + (*authclientModels.AuthClient)(nil).TableName() + ` AS c ON c.id = s.client_id` +
+			`  WHERE s.deleted_at IS NULL AND s.access_token=?` +
 			`)` +
 			`SELECT am.* FROM ` + member.TableName() + ` AS am, auth_client AS ac` +
 			` WHERE am.deleted_at IS NULL AND am.account_id=ac.account_id AND am.user_id=ac.user_id`
@@ -143,6 +169,9 @@ func (r *sessionRepository[TUser, TAccount]) GetByToken(ctx context.Context, tok
 	if err = db.Raw(memberRequest, token).Scan(member).Error; err != nil {
 		return zeroUser, zeroAcc, errors.WithStack(err)
 	}
+	if member.IsSuspended() {
+		return zeroUser, zeroAcc, account.ErrMemberSuspended
+	}
 	if err = db.First(userObj, member.UserID).Error; err != nil {
 		return zeroUser, zeroAcc, errors.WithStack(err)
 	}
@@ -150,8 +179,7 @@ func (r *sessionRepository[TUser, TAccount]) GetByToken(ctx context.Context, tok
 		return zeroUser, zeroAcc, errors.WithStack(err)
 	}
 
-	err = db.Model(&models.M2MAccountMemberRole{}).
-		Select("role_id").Where(`member_id=?`, member.ID).Scan(&roles).Error
+	err = db.Raw(memberRolesQuery, member.ID, member.ID).Scan(&roles).Error
 	if err != nil && err != gorm.ErrRecordNotFound {
 		return zeroUser, zeroAcc, errors.WithStack(err)
 	}
@@ -160,13 +188,13 @@ func (r *sessionRepository[TUser, TAccount]) GetByToken(ctx context.Context, tok
 		userApprove := getApprove(userObj)
 		accApprove := getApprove(accObj)
 		if accApprove.IsApproved() && userApprove.IsApproved() {
-			perm, perr := r.PermissionManager(ctx).AsOneRole(ctx, member.IsAdmin, nil, roles...)
+			perm, perr := r.PermissionManager(ctx).AsOneAccountRole(ctx, accObj.GetID(), member.IsAdmin, nil, roles...)
 			if perr != nil {
 				return zeroUser, zeroAcc, perr
 			}
 			accObj.SetPermissions(perm)
 		} else {
-			perm, perr := r.PermissionManager(ctx).AsOneRole(ctx, false,
+			perm, perr := r.PermissionManager(ctx).AsOneAccountRole(ctx, accObj.GetID(), false,
 				func(_ context.Context, role rbac.Role) bool {
 					return !strings.HasPrefix(role.Name(), "system:")
 				}, roles...)
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/demdxx/xtypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	baseRepo "github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	prbac "github.com/geniusrabbit/blaze-api/repository/rbac"
+	userbac "github.com/geniusrabbit/blaze-api/repository/rbac/usecase"
+)
+
+// TeamRepository of the account teams
+type TeamRepository struct {
+	baseRepo.Repository
+	rbacUse prbac.Usecase
+}
+
+// NewTeamRepository creates the account teams repository
+func NewTeamRepository() *TeamRepository {
+	return &TeamRepository{rbacUse: userbac.NewDefault()}
+}
+
+// Team by ID with the roles
+func (r *TeamRepository) Team(ctx context.Context, id uint64) (*account.Team, error) {
+	object := new(account.Team)
+	if err := r.Slave(ctx).Preload("Roles").First(object, `id=?`, id).Error; err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// FetchListTeams by the query options with the roles
+func (r *TeamRepository) FetchListTeams(ctx context.Context, opts ...account.QOption) ([]*account.Team, error) {
+	var list []*account.Team
+	query := account.ListOptions(opts).PrepareQuery(r.Slave(ctx).Model((*account.Team)(nil)))
+	if err := query.Preload("Roles").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CountTeams by the query options
+func (r *TeamRepository) CountTeams(ctx context.Context, opts ...account.QOption) (int64, error) {
+	var count int64
+	query := account.ListOptions(opts).PrepareQuery(r.Slave(ctx).Model((*account.Team)(nil)))
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CreateTeam without the roles and the members
+func (r *TeamRepository) CreateTeam(ctx context.Context, team *account.Team) error {
+	return r.Master(ctx).Omit(clause.Associations).Create(team).Error
+}
+
+// UpdateTeam name and description
+func (r *TeamRepository) UpdateTeam(ctx context.Context, team *account.Team) error {
+	return r.Master(ctx).Omit(clause.Associations).Save(team).Error
+}
+
+// DeleteTeam with all its links to the members and the roles,
+// the members lose the team roles immediately
+func (r *TeamRepository) DeleteTeam(ctx context.Context, id uint64) error {
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.Where(`team_id=?`, id).Delete(&account.M2MAccountTeamMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(`team_id=?`, id).Delete(&account.M2MAccountTeamRole{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&account.Team{}, `id=?`, id).Error
+	})
+}
+
+// SetTeamRoles replaces the roles of the team by names,
+// the custom roles of the team account override the global roles with the same name
+func (r *TeamRepository) SetTeamRoles(ctx context.Context, team *account.Team, roles ...string) error {
+	var listRoles []*prbac.Role
+	if len(roles) > 0 {
+		var err error
+		listRoles, err = r.rbacUse.FetchList(ctx, &prbac.Filter{
+			Names:     roles,
+			AccountID: []uint64{team.AccountID},
+			Global:    true,
+		})
+		if err != nil {
+			return err
+		}
+		if listRoles = uniqueRoleNames(listRoles); len(listRoles) != len(roles) {
+			return account.ErrInvalidTeamRole
+		}
+	}
+	err := r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.Where(`team_id=?`, team.ID).Delete(&account.M2MAccountTeamRole{}).Error; err != nil {
+			return err
+		}
+		if len(listRoles) == 0 {
+			return nil
+		}
+		return tx.Create(xtypes.SliceApply(listRoles, func(v *prbac.Role) *account.M2MAccountTeamRole {
+			return &account.M2MAccountTeamRole{
+				TeamID:    team.ID,
+				RoleID:    v.ID,
+				CreatedAt: time.Now(),
+			}
+		})).Error
+	})
+	if err != nil {
+		return err
+	}
+	team.Roles = listRoles
+	return nil
+}
+
+// AddTeamMembers links the members to the team, the existing links are kept
+func (r *TeamRepository) AddTeamMembers(ctx context.Context, teamID uint64, memberIDs ...uint64) error {
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	return r.Master(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(xtypes.SliceApply(memberIDs, func(id uint64) *account.M2MAccountTeamMember {
+			return &account.M2MAccountTeamMember{
+				TeamID:    teamID,
+				MemberID:  id,
+				CreatedAt: time.Now(),
+			}
+		})).Error
+}
+
+// RemoveTeamMembers unlinks the members from the team
+func (r *TeamRepository) RemoveTeamMembers(ctx context.Context, teamID uint64, memberIDs ...uint64) error {
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	return r.Master(ctx).
+		Where(`team_id=? AND member_id IN (?)`, teamID, memberIDs).
+		Delete(&account.M2MAccountTeamMember{}).Error
+}
@@ -30,6 +30,15 @@ type Usecase[TUser user.Model, TAccount Model] interface {
 
 	// Delete removes an account by ID.
 	Delete(ctx context.Context, id uint64) error
+
+	// SetParent moves the account under the parent account, parentID 0 detaches it.
+	SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) (TAccount, error)
+
+	// Suspend blocks any session in the account keeping all the data.
+	Suspend(ctx context.Context, id uint64, reason string) (TAccount, error)
+
+	// Unsuspend restores the access to the suspended account.
+	Unsuspend(ctx context.Context, id uint64) (TAccount, error)
 }
 
 // MemberUsecase of the account members
@@ -60,4 +69,144 @@ type MemberUsecase[TUser user.Model, TAccount Model] interface {
 
 	// SetMemberRoles sets the roles for a member based on the member's ID.
 	SetMemberRoles(ctx context.Context, memberID uint64, roles ...string) (*Member[TUser, TAccount], error)
+
+	// SuspendMember blocks the member in the account keeping its roles, the owner can't be suspended.
+	SuspendMember(ctx context.Context, memberID uint64, reason string) (*Member[TUser, TAccount], error)
+
+	// UnsuspendMember restores the access of the suspended member to the account.
+	UnsuspendMember(ctx context.Context, memberID uint64) (*Member[TUser, TAccount], error)
+}
+
+// InviteUsecase of the member invitations by email
+type InviteUsecase[TUser user.Model, TAccount Model] interface {
+	// Invite creates the invite of the email into the account with the roles.
+	// The returned invite contains the plain token to send to the email.
+	Invite(ctx context.Context, accountID uint64, email string, roles ...string) (*MemberInvite, error)
+
+	// Get retrieves the invite by ID.
+	Get(ctx context.Context, id uint64) (*MemberInvite, error)
+
+	// FetchListInvites retrieves the invites based on the provided query options.
+	FetchListInvites(ctx context.Context, opts ...QOption) ([]*MemberInvite, error)
+
+	// CountInvites returns the number of the invites based on the provided query options.
+	CountInvites(ctx context.Context, opts ...QOption) (int64, error)
+
+	// Resend renews the token of the pending invite, the returned invite contains the new plain token.
+	Resend(ctx context.Context, id uint64) (*MemberInvite, error)
+
+	// Revoke cancels the pending invite.
+	Revoke(ctx context.Context, id uint64) (*MemberInvite, error)
+
+	// InviteByToken returns the pending invite by the plain token.
+	InviteByToken(ctx context.Context, token string) (*MemberInvite, error)
+
+	// Accept links the invitee to the account, the user is created if it doesn't exist.
+	// The password is used only for the new user.
+	Accept(ctx context.Context, token, password string) (*Member[TUser, TAccount], error)
+
+	// Decline refuses the invite.
+	Decline(ctx context.Context, token string) (*MemberInvite, error)
+}
+
+// OwnershipUsecase of the account ownership transfers.
+// The transfer is requested by the owner and confirmed by the new owner.
+type OwnershipUsecase interface {
+	// RequestTransfer creates the pending transfer of the account to another approved member.
+	// Only the current owner can request the transfer.
+	RequestTransfer(ctx context.Context, accountID, toUserID uint64) (*OwnershipTransfer, error)
+
+	// Get retrieves the transfer by ID, available for the current and the new owner.
+	Get(ctx context.Context, id uint64) (*OwnershipTransfer, error)
+
+	// FetchListTransfers retrieves the transfers of the current user.
+	FetchListTransfers(ctx context.Context, opts ...QOption) ([]*OwnershipTransfer, error)
+
+	// Confirm accepts the transfer by the new owner and switches the owner of the account.
+	Confirm(ctx context.Context, id uint64) (*OwnershipTransfer, error)
+
+	// Decline refuses the transfer by the new owner.
+	Decline(ctx context.Context, id uint64) (*OwnershipTransfer, error)
+
+	// Cancel withdraws the transfer by the current owner.
+	Cancel(ctx context.Context, id uint64) (*OwnershipTransfer, error)
+}
+
+// TeamUsecase of the account teams.
+// The members of the team get the team roles in addition to their own roles.
+type TeamUsecase interface {
+	// Get retrieves the team by ID.
+	Get(ctx context.Context, id uint64) (*Team, error)
+
+	// FetchListTeams retrieves the teams based on the provided query options.
+	FetchListTeams(ctx context.Context, opts ...QOption) ([]*Team, error)
+
+	// CountTeams returns the number of the teams based on the provided query options.
+	CountTeams(ctx context.Context, opts ...QOption) (int64, error)
+
+	// Create creates the team in the account, the session account is used by default.
+	Create(ctx context.Context, team *Team) (*Team, error)
+
+	// Update changes the name and the description of the team.
+	Update(ctx context.Context, team *Team) (*Team, error)
+
+	// Delete removes the team, its members lose the team roles.
+	Delete(ctx context.Context, id uint64) (*Team, error)
+
+	// SetRoles replaces the roles of the team by names.
+	SetRoles(ctx context.Context, id uint64, roles ...string) (*Team, error)
+
+	// AddMembers links the members of the team account to the team.
+	AddMembers(ctx context.Context, id uint64, memberIDs ...uint64) (*Team, error)
+
+	// RemoveMembers unlinks the members from the team.
+	RemoveMembers(ctx context.Context, id uint64, memberIDs ...uint64) (*Team, error)
+}
+
+// DomainUsecase of the account domains.
+// The ownership of the domain is confirmed by the DNS TXT record.
+type DomainUsecase[TUser user.Model, TAccount Model] interface {
+	// Get retrieves the domain by ID.
+	Get(ctx context.Context, id uint64) (*Domain, error)
+
+	// FetchListDomains retrieves the domains based on the provided query options.
+	FetchListDomains(ctx context.Context, opts ...QOption) ([]*Domain, error)
+
+	// CountDomains returns the number of the domains based on the provided query options.
+	CountDomains(ctx context.Context, opts ...QOption) (int64, error)
+
+	// Add creates the not verified domain of the account,
+	// the returned domain contains the token for the TXT record.
+	Add(ctx context.Context, accountID uint64, domain string) (*Domain, error)
+
+	// Verify checks the TXT record of the domain and marks it as verified.
+	Verify(ctx context.Context, id uint64) (*Domain, error)
+
+	// SetPolicy changes the auto-join with the default roles and the SSO enforcement of the verified domain.
+	SetPolicy(ctx context.Context, id uint64, autoJoin bool, roles []string, ssoRequired bool) (*Domain, error)
+
+	// Delete removes the domain.
+	Delete(ctx context.Context, id uint64) (*Domain, error)
+
+	// SSORequired reports whether the email belongs to the verified domain with the SSO enforcement.
+	SSORequired(ctx context.Context, email string) (bool, error)
+
+	// AutoJoin links the user to the account of the verified email domain with the auto-join policy.
+	// Returns nil if there is no such domain or the user is already the member.
+	AutoJoin(ctx context.Context, userObj TUser, email string) (*Member[TUser, TAccount], error)
+}
+
+// ActivityUsecase of the inactive members reports.
+// The inactivity period is defined by the OptionMemberInactivityDays option.
+type ActivityUsecase[TUser user.Model, TAccount Model] interface {
+	// InactivityDays returns the inactivity period of the account, 0 if it's not defined.
+	InactivityDays(ctx context.Context, accountID uint64) (int, error)
+
+	// FetchListInactiveMembers returns the not suspended members of the account without
+	// any activity for the days, 0 days uses the inactivity period of the account.
+	FetchListInactiveMembers(ctx context.Context, accountID uint64, days int) ([]*Member[TUser, TAccount], error)
+
+	// SuspendInactiveMembers suspends the inactive members of the account except the owner
+	// and returns them. Returns ErrInactivityDisabled if the inactivity period isn't defined.
+	SuspendInactiveMembers(ctx context.Context, accountID uint64) ([]*Member[TUser, TAccount], error)
 }
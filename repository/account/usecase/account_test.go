@@ -120,6 +120,47 @@ func (s *testSuite) TestDeleteNotFound() {
 	s.EqualError(err, sql.ErrNoRows.Error())
 }
 
+func (s *testSuite) TestSetParent() {
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(2)).Return(testAccountStub(2), nil).Times(2)
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.accountRepo.EXPECT().SetParent(s.ctx, uint64(2), uint64(1), true).Return(nil)
+
+	accountObj, err := s.accountUsecase.SetParent(s.ctx, 2, 1, true)
+	s.NoError(err)
+	s.Equal(uint64(2), accountObj.ID)
+}
+
+func (s *testSuite) TestSetParentCycle() {
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(2)).Return(testAccountStub(2), nil)
+	s.accountRepo.EXPECT().SetParent(s.ctx, uint64(1), uint64(2), false).Return(account.ErrAccountCycle)
+
+	_, err := s.accountUsecase.SetParent(s.ctx, 1, 2, false)
+	s.ErrorIs(err, account.ErrAccountCycle)
+}
+
+func (s *testSuite) TestSuspend() {
+	suspended := testAccountStub(2)
+	suspended.Suspend("fraud")
+	gomock.InOrder(
+		s.accountRepo.EXPECT().Get(s.ctx, uint64(2)).Return(testAccountStub(2), nil),
+		s.accountRepo.EXPECT().SetSuspended(s.ctx, uint64(2), true, "fraud").Return(nil),
+		s.accountRepo.EXPECT().Get(s.ctx, uint64(2)).Return(suspended, nil),
+	)
+
+	accountObj, err := s.accountUsecase.Suspend(s.ctx, 2, "fraud")
+	s.NoError(err)
+	s.True(accountObj.IsSuspended())
+
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(2)).Return(suspended, nil)
+	s.accountRepo.EXPECT().SetSuspended(s.ctx, uint64(2), false, "").Return(nil)
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(2)).Return(testAccountStub(2), nil)
+
+	accountObj, err = s.accountUsecase.Unsuspend(s.ctx, 2)
+	s.NoError(err)
+	s.False(accountObj.IsSuspended())
+}
+
 func TestAccountSuite(t *testing.T) {
 	suite.Run(t, &testSuite{})
 }
@@ -5,10 +5,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/demdxx/gocast/v2"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/pkg/context/database"
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/repository/account"
@@ -155,7 +158,10 @@ func (a *AccountUsecase[TUser, TAccount]) Register(ctx context.Context, ownerObj
 		setAccountID(accountObj, aid)
 
 		// Link owner as member with admin role
-		return a.memberRepo.LinkMember(txCtx, accountObj, true, ownerObj)
+		if err = a.memberRepo.LinkMember(txCtx, accountObj, true, ownerObj); err != nil {
+			return err
+		}
+		return a.memberRepo.SetOwner(txCtx, aid, ownerObj.GetID())
 	})
 	return accountObj.GetID(), err
 }
@@ -171,3 +177,66 @@ func (a *AccountUsecase[TUser, TAccount]) Delete(ctx context.Context, id uint64)
 	}
 	return a.accountRepo.Delete(historylog.WithPK(ctx, id), id)
 }
+
+// SetParent moves the account under the parent account, parentID 0 detaches it.
+// The update access is required to the account and to the new parent.
+func (a *AccountUsecase[TUser, TAccount]) SetParent(ctx context.Context, id, parentID uint64, inheritPermissions bool) (TAccount, error) {
+	var zero TAccount
+	accountObj, err := a.accountRepo.Get(ctx, id)
+	if err != nil {
+		return zero, err
+	}
+	if !acl.HaveAccessUpdate(ctx, accountObj) {
+		return zero, acl.ErrNoPermissions.WithMessage("update account")
+	}
+	if parentID > 0 {
+		parentObj, err := a.accountRepo.Get(ctx, parentID)
+		if err != nil {
+			return zero, err
+		}
+		if !acl.HaveAccessUpdate(ctx, parentObj) {
+			return zero, acl.ErrNoPermissions.WithMessage("update parent account")
+		}
+	}
+	if err = a.accountRepo.SetParent(ctx, id, parentID, inheritPermissions); err != nil {
+		return zero, err
+	}
+	err = historylog.Write(ctx, "account.parent", "account", id, map[string]any{
+		"parent_id":           parentID,
+		"inherit_permissions": parentID > 0 && inheritPermissions,
+	})
+	if err != nil {
+		ctxlogger.Get(ctx).Error("log account parent", zap.Uint64("account_id", id), zap.Error(err))
+	}
+	return a.accountRepo.Get(ctx, id)
+}
+
+// Suspend blocks any session in the account keeping all the data.
+func (a *AccountUsecase[TUser, TAccount]) Suspend(ctx context.Context, id uint64, reason string) (TAccount, error) {
+	return a.setSuspended(ctx, id, true, reason)
+}
+
+// Unsuspend restores the access to the suspended account.
+func (a *AccountUsecase[TUser, TAccount]) Unsuspend(ctx context.Context, id uint64) (TAccount, error) {
+	return a.setSuspended(ctx, id, false, "")
+}
+
+func (a *AccountUsecase[TUser, TAccount]) setSuspended(ctx context.Context, id uint64, suspended bool, reason string) (TAccount, error) {
+	var zero TAccount
+	accountObj, err := a.accountRepo.Get(ctx, id)
+	if err != nil {
+		return zero, err
+	}
+	if !acl.HaveAccessSuspend(ctx, accountObj) {
+		return zero, acl.ErrNoPermissions.WithMessage("suspend account")
+	}
+	if err = a.accountRepo.SetSuspended(ctx, id, suspended, reason); err != nil {
+		return zero, err
+	}
+	action := gocast.IfThen(suspended, "account.suspend", "account.unsuspend")
+	err = historylog.Write(historylog.WithMessage(ctx, reason), action, "account", id, nil)
+	if err != nil {
+		ctxlogger.Get(ctx).Error("log account suspension", zap.Uint64("account_id", id), zap.Error(err))
+	}
+	return a.accountRepo.Get(ctx, id)
+}
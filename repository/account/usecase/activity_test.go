@@ -0,0 +1,118 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/account/usecase"
+	"github.com/geniusrabbit/blaze-api/repository/option"
+	optionmocks "github.com/geniusrabbit/blaze-api/repository/option/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type testActivitySuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	memberRepo      *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	optionRepo      *optionmocks.MockRepository
+	activityUsecase *usecase.ActivityUsecase[*testutil.User, *testAccount]
+}
+
+func (s *testActivitySuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.memberRepo = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.optionRepo = optionmocks.NewMockRepository(ctrl)
+	s.activityUsecase = usecase.NewActivityUsecase(s.memberRepo, s.optionRepo)
+}
+
+func (s *testActivitySuite) expectDays(accountDays, systemDays any) {
+	opt := func(value any) *option.Option {
+		obj := &option.Option{}
+		if value != nil {
+			obj.Value = *gosql.MustNullableJSON[any](value)
+		}
+		return obj
+	}
+	s.optionRepo.EXPECT().
+		Get(s.ctx, account.OptionMemberInactivityDays, option.AccountOptionType, uint64(1)).
+		Return(opt(accountDays), nil)
+	if accountDays == nil {
+		s.optionRepo.EXPECT().
+			Get(s.ctx, account.OptionMemberInactivityDays, option.SystemOptionType, uint64(0)).
+			Return(opt(systemDays), nil)
+	}
+}
+
+func (s *testActivitySuite) TestInactivityDaysAccountOverride() {
+	s.expectDays(0, nil)
+	days, err := s.activityUsecase.InactivityDays(s.ctx, 1)
+	s.NoError(err)
+	s.Equal(0, days)
+}
+
+func (s *testActivitySuite) TestInactivityDaysSystemDefault() {
+	s.expectDays(nil, 90)
+	days, err := s.activityUsecase.InactivityDays(s.ctx, 1)
+	s.NoError(err)
+	s.Equal(90, days)
+}
+
+func (s *testActivitySuite) TestFetchListInactiveMembers() {
+	s.memberRepo.EXPECT().
+		FetchListMembers(s.ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, opts ...account.QOption) ([]*account.Member[*testutil.User, *testAccount], error) {
+			filter := opts[0].(*account.MemberFilter)
+			s.Equal([]uint64{1}, filter.AccountID)
+			s.False(filter.IsOwner.Bool)
+			s.True(filter.IsOwner.Valid)
+			s.WithinDuration(time.Now().AddDate(0, 0, -30), filter.InactiveSince, time.Minute)
+			return []*account.Member[*testutil.User, *testAccount]{
+				account.MemberStub[*testutil.User, *testAccount](2, 1, 102),
+			}, nil
+		})
+
+	members, err := s.activityUsecase.FetchListInactiveMembers(s.ctx, 1, 30)
+	s.NoError(err)
+	s.Len(members, 1)
+}
+
+func (s *testActivitySuite) TestSuspendInactiveMembersDisabled() {
+	s.expectDays(nil, nil)
+	_, err := s.activityUsecase.SuspendInactiveMembers(s.ctx, 1)
+	s.ErrorIs(err, account.ErrInactivityDisabled)
+}
+
+func (s *testActivitySuite) TestSuspendInactiveMembers() {
+	s.expectDays(30, nil)
+	s.memberRepo.EXPECT().
+		FetchListMembers(s.ctx, gomock.Any(), gomock.Any()).
+		Return([]*account.Member[*testutil.User, *testAccount]{
+			account.MemberStub[*testutil.User, *testAccount](2, 1, 102),
+			account.MemberStub[*testutil.User, *testAccount](3, 1, 103),
+		}, nil)
+	s.memberRepo.EXPECT().
+		SetMembersSuspended(s.ctx, uint64(1), []uint64{2, 3}, true, "inactive for 30 days").
+		Return(nil)
+
+	members, err := s.activityUsecase.SuspendInactiveMembers(s.ctx, 1)
+	s.NoError(err)
+	s.Len(members, 2)
+	for _, member := range members {
+		s.True(member.IsSuspended())
+	}
+}
+
+func TestActivitySuite(t *testing.T) {
+	suite.Run(t, &testActivitySuite{})
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+	"github.com/guregu/null"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+	"github.com/geniusrabbit/blaze-api/repository/option"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// ActivityUsecase reports and suspends the members inactive longer
+// than the inactivity period of the account options
+type ActivityUsecase[TUser user.Model, TAccount account.Model] struct {
+	memberRepo account.MemberRepository[TUser, TAccount]
+	options    option.Repository
+}
+
+// NewActivityUsecase object controller
+func NewActivityUsecase[TUser user.Model, TAccount account.Model](
+	memberRepo account.MemberRepository[TUser, TAccount],
+	options option.Repository,
+) *ActivityUsecase[TUser, TAccount] {
+	return &ActivityUsecase[TUser, TAccount]{
+		memberRepo: memberRepo,
+		options:    options,
+	}
+}
+
+// InactivityDays of the account option, the system option is the default for all accounts
+func (a *ActivityUsecase[TUser, TAccount]) InactivityDays(ctx context.Context, accountID uint64) (int, error) {
+	for _, src := range []struct {
+		otype    option.OptionType
+		targetID uint64
+	}{
+		{otype: option.AccountOptionType, targetID: accountID},
+		{otype: option.SystemOptionType},
+	} {
+		opt, err := a.options.Get(ctx, account.OptionMemberInactivityDays, src.otype, src.targetID)
+		if err != nil {
+			return 0, err
+		}
+		if value := opt.Value.DataOr(nil); value != nil {
+			return max(gocast.Number[int](value), 0), nil
+		}
+	}
+	return 0, nil
+}
+
+// FetchListInactiveMembers of the account, the session user is never inactive
+func (a *ActivityUsecase[TUser, TAccount]) FetchListInactiveMembers(ctx context.Context, accountID uint64, days int) ([]*account.Member[TUser, TAccount], error) {
+	if !acl.HaveAccessList(ctx, account.MemberStub[TUser, TAccount](0, accountID, 0)) {
+		return nil, acl.ErrNoPermissions.WithMessage("list inactive members")
+	}
+	if days <= 0 {
+		var err error
+		if days, err = a.InactivityDays(ctx, accountID); err != nil {
+			return nil, err
+		}
+	}
+	if days <= 0 {
+		return nil, account.ErrInactivityDisabled
+	}
+	return a.inactiveMembers(ctx, accountID, days)
+}
+
+// SuspendInactiveMembers of the account by the inactivity period of the account options
+func (a *ActivityUsecase[TUser, TAccount]) SuspendInactiveMembers(ctx context.Context, accountID uint64) ([]*account.Member[TUser, TAccount], error) {
+	if !acl.HaveAccessSuspend(ctx, account.MemberStub[TUser, TAccount](0, accountID, 0)) {
+		return nil, acl.ErrNoPermissions.WithMessage("suspend inactive members")
+	}
+	days, err := a.InactivityDays(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if days <= 0 {
+		return nil, account.ErrInactivityDisabled
+	}
+	members, err := a.inactiveMembers(ctx, accountID, days)
+	if err != nil || len(members) == 0 {
+		return members, err
+	}
+	var (
+		reason = fmt.Sprintf("inactive for %d days", days)
+		ids    = make([]uint64, 0, len(members))
+	)
+	for _, member := range members {
+		ids = append(ids, member.ID)
+	}
+	if err = a.memberRepo.SetMembersSuspended(ctx, accountID, ids, true, reason); err != nil {
+		return nil, err
+	}
+	writeMemberSuspension(historylog.WithMessage(ctx, reason), true, accountID, ids...)
+	for _, member := range members {
+		member.Suspend(reason)
+	}
+	return members, nil
+}
+
+// inactiveMembers of the account except the owner and the session user
+func (a *ActivityUsecase[TUser, TAccount]) inactiveMembers(ctx context.Context, accountID uint64, days int) ([]*account.Member[TUser, TAccount], error) {
+	return a.memberRepo.FetchListMembers(ctx, &account.MemberFilter{
+		AccountID:     []uint64{accountID},
+		NotUserID:     []uint64{session.UserID(ctx)},
+		IsOwner:       null.BoolFrom(false),
+		Suspended:     null.BoolFrom(false),
+		InactiveSince: time.Now().AddDate(0, 0, -days),
+	}, &account.MemberListOrder{LastActiveAt: pkgModels.OrderAsc})
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/socialauth"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// DomainProvisioner of the social login users which joins the new users into the account
+// owning the verified domain of their email with the auto-join policy.
+// The email is trusted only if the provider marks it as verified.
+type DomainProvisioner[TUser user.EmailCapableModel, TAccount account.Model] struct {
+	userRepo user.Repository[TUser]
+	domains  account.DomainUsecase[TUser, TAccount]
+}
+
+var _ socialauth.SocialUserProvisioner[user.EmailCapableModel] = (*DomainProvisioner[user.EmailCapableModel, account.Model])(nil)
+
+// NewDomainProvisioner of the social login users
+func NewDomainProvisioner[TUser user.EmailCapableModel, TAccount account.Model](
+	userRepo user.Repository[TUser],
+	domains account.DomainUsecase[TUser, TAccount],
+) *DomainProvisioner[TUser, TAccount] {
+	return &DomainProvisioner[TUser, TAccount]{userRepo: userRepo, domains: domains}
+}
+
+// EnsureUser returns the logged in user or creates the new one and joins it
+// into the account of the email domain
+func (p *DomainProvisioner[TUser, TAccount]) EnsureUser(ctx context.Context, sessionUser TUser, _ string, data *elogin.UserData) (TUser, error) {
+	if !gocast.IsNil(sessionUser) && !sessionUser.IsAnonymous() {
+		return sessionUser, nil
+	}
+	var (
+		zero     TUser
+		usr      = p.userRepo.EmptyObject()
+		verified = data.Email != "" && gocast.Bool(data.Ext["email_verified"])
+	)
+	usr.SetEmail(data.Email)
+	if v, ok := any(usr).(user.EmailVerificationModel); ok {
+		v.SetEmailVerified(verified)
+	}
+	id, err := p.userRepo.Create(ctx, usr)
+	if err != nil {
+		return zero, err
+	}
+	setUserID(usr, id)
+	if verified {
+		if _, err = p.domains.AutoJoin(ctx, usr, data.Email); err != nil {
+			return zero, err
+		}
+	}
+	return usr, nil
+}
@@ -0,0 +1,191 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/account/usecase"
+	usermocks "github.com/geniusrabbit/blaze-api/repository/user/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type stubTXTResolver map[string][]string
+
+func (r stubTXTResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	return r[name], nil
+}
+
+type testDomainSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	userRepo      *usermocks.MockRepository[*testutil.User]
+	accountRepo   *mocks.MockSessionRepository[*testutil.User, *testAccount]
+	memberRepo    *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	domainRepo    *mocks.MockDomainRepository
+	txt           stubTXTResolver
+	domainUsecase account.DomainUsecase[*testutil.User, *testAccount]
+}
+
+func (s *testDomainSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.userRepo = usermocks.NewMockRepository[*testutil.User](ctrl)
+	s.accountRepo = mocks.NewMockSessionRepository[*testutil.User, *testAccount](ctrl)
+	s.memberRepo = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.domainRepo = mocks.NewMockDomainRepository(ctrl)
+	s.txt = stubTXTResolver{}
+	s.domainUsecase = usecase.NewDomainUsecase(s.accountRepo, s.memberRepo, s.domainRepo,
+		usecase.WithTXTResolver(s.txt))
+}
+
+func (s *testDomainSuite) domain(verified bool) *account.Domain {
+	domain := &account.Domain{ID: 7, AccountID: 1, Domain: "example.com", Token: "secret"}
+	domain.VerifiedAt.V, domain.VerifiedAt.Valid = time.Now(), verified
+	return domain
+}
+
+func (s *testDomainSuite) TestAdd() {
+	s.domainRepo.EXPECT().CreateDomain(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, domain *account.Domain) error {
+			s.Equal("example.com", domain.Domain)
+			s.Equal(uint64(1), domain.AccountID)
+			return nil
+		})
+	_, err := s.domainUsecase.Add(s.ctx, 1, " Example.COM. ")
+	s.NoError(err)
+}
+
+func (s *testDomainSuite) TestAddInvalid() {
+	for _, name := range []string{"", "localhost", "exa mple.com", "-example.com", "example..com"} {
+		_, err := s.domainUsecase.Add(s.ctx, 1, name)
+		s.ErrorIs(err, account.ErrInvalidDomain, name)
+	}
+}
+
+func (s *testDomainSuite) TestVerify() {
+	domain := s.domain(false)
+	s.txt["example.com"] = []string{"v=spf1 -all", account.DomainVerificationPrefix + "secret"}
+	s.domainRepo.EXPECT().Domain(s.ctx, uint64(7)).Return(domain, nil)
+	s.domainRepo.EXPECT().SetDomainVerified(s.ctx, domain).Return(nil)
+	_, err := s.domainUsecase.Verify(s.ctx, 7)
+	s.NoError(err)
+}
+
+func (s *testDomainSuite) TestVerifyNoRecord() {
+	s.txt["example.com"] = []string{account.DomainVerificationPrefix + "other"}
+	s.domainRepo.EXPECT().Domain(s.ctx, uint64(7)).Return(s.domain(false), nil)
+	_, err := s.domainUsecase.Verify(s.ctx, 7)
+	s.ErrorIs(err, account.ErrDomainVerificationFailed)
+}
+
+func (s *testDomainSuite) TestSetPolicyNotVerified() {
+	s.domainRepo.EXPECT().Domain(s.ctx, uint64(7)).Return(s.domain(false), nil)
+	_, err := s.domainUsecase.SetPolicy(s.ctx, 7, true, nil, false)
+	s.ErrorIs(err, account.ErrDomainNotVerified)
+}
+
+func (s *testDomainSuite) TestSetPolicyAdminRole() {
+	s.domainRepo.EXPECT().Domain(s.ctx, uint64(7)).Return(s.domain(true), nil)
+	_, err := s.domainUsecase.SetPolicy(s.ctx, 7, true, []string{"reader", "account:admin"}, false)
+	s.ErrorIs(err, account.ErrInvalidDomainRoles)
+}
+
+func (s *testDomainSuite) TestSetPolicyDomainUpdateOnly() {
+	ctx := testContextWithPermissions(s.ctx, &account.Domain{}, "update.all")
+	s.Run("Roles", func() {
+		s.domainRepo.EXPECT().Domain(ctx, uint64(7)).Return(s.domain(true), nil)
+		_, err := s.domainUsecase.SetPolicy(ctx, 7, true, []string{"custom:manager"}, false)
+		s.ErrorIs(err, acl.ErrNoPermissions)
+	})
+	s.Run("NoRoles", func() {
+		s.domainRepo.EXPECT().Domain(ctx, uint64(7)).Return(s.domain(true), nil)
+		s.domainRepo.EXPECT().UpdateDomain(ctx, gomock.Any()).Return(nil)
+		_, err := s.domainUsecase.SetPolicy(ctx, 7, true, nil, true)
+		s.NoError(err)
+	})
+}
+
+func (s *testDomainSuite) TestSetPolicy() {
+	s.domainRepo.EXPECT().Domain(s.ctx, uint64(7)).Return(s.domain(true), nil)
+	s.domainRepo.EXPECT().UpdateDomain(s.ctx, gomock.Any()).Return(nil)
+	domain, err := s.domainUsecase.SetPolicy(s.ctx, 7, true, []string{"writer", "reader"}, true)
+	s.NoError(err)
+	s.True(domain.AutoJoin)
+	s.True(domain.SSORequired)
+	s.Equal([]string{"reader", "writer"}, []string(domain.DefaultRoles))
+}
+
+func (s *testDomainSuite) TestSSORequired() {
+	domain := s.domain(true)
+	domain.SSORequired = true
+	s.domainRepo.EXPECT().VerifiedDomain(s.ctx, "example.com").Return(domain, nil)
+	s.domainRepo.EXPECT().VerifiedDomain(s.ctx, "other.com").Return(nil, gorm.ErrRecordNotFound)
+
+	required, err := s.domainUsecase.SSORequired(s.ctx, "User@Example.com")
+	s.NoError(err)
+	s.True(required)
+
+	required, err = s.domainUsecase.SSORequired(s.ctx, "user@other.com")
+	s.NoError(err)
+	s.False(required)
+}
+
+func (s *testDomainSuite) TestProvisionerAutoJoin() {
+	domain := s.domain(true)
+	domain.AutoJoin = true
+	domain.DefaultRoles = []string{"reader"}
+	acc := testAccountStub(1)
+	member := account.MemberStub[*testutil.User, *testAccount](3, 1, 5)
+
+	s.userRepo.EXPECT().EmptyObject().Return(&testutil.User{})
+	s.userRepo.EXPECT().Create(s.ctx, gomock.Any()).Return(uint64(5), nil)
+	s.domainRepo.EXPECT().VerifiedDomain(s.ctx, "example.com").Return(domain, nil)
+	s.memberRepo.EXPECT().IsMember(s.ctx, uint64(5), uint64(1)).Return(false)
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(1)).Return(acc, nil)
+	s.memberRepo.EXPECT().LinkMember(s.ctx, acc, false, gomock.Any()).Return(nil)
+	s.memberRepo.EXPECT().SetMemberRoles(s.ctx, acc, gomock.Any(), "reader").Return(nil)
+	s.memberRepo.EXPECT().Member(s.ctx, uint64(5), uint64(1)).Return(member, nil)
+
+	provisioner := usecase.NewDomainProvisioner(s.userRepo, s.domainUsecase)
+	usr, err := provisioner.EnsureUser(s.ctx, nil, "google", &elogin.UserData{
+		Email: "user@example.com",
+		Ext:   map[string]any{"email_verified": true},
+	})
+	s.NoError(err)
+	s.Equal(uint64(5), usr.GetID())
+	s.True(usr.IsEmailVerified())
+}
+
+func (s *testDomainSuite) TestProvisionerNotVerifiedEmail() {
+	s.userRepo.EXPECT().EmptyObject().Return(&testutil.User{})
+	s.userRepo.EXPECT().Create(s.ctx, gomock.Any()).Return(uint64(5), nil)
+
+	provisioner := usecase.NewDomainProvisioner(s.userRepo, s.domainUsecase)
+	usr, err := provisioner.EnsureUser(s.ctx, nil, "github", &elogin.UserData{Email: "user@example.com"})
+	s.NoError(err)
+	s.Equal(uint64(5), usr.GetID())
+}
+
+func (s *testDomainSuite) TestVerifiedDomainNotFound() {
+	s.domainRepo.EXPECT().VerifiedDomain(s.ctx, "example.com").Return(nil, sql.ErrNoRows)
+	member, err := s.domainUsecase.AutoJoin(s.ctx, testutil.Stub(5), "user@example.com")
+	s.NoError(err)
+	s.Nil(member)
+}
+
+func TestDomainSuite(t *testing.T) {
+	suite.Run(t, new(testDomainSuite))
+}
@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// TXTResolver looks up the DNS TXT records of the domain, implemented by *net.Resolver
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type domainOptions struct {
+	resolver TXTResolver
+}
+
+// DomainOption configures the domain usecase.
+type DomainOption func(*domainOptions)
+
+// WithTXTResolver replaces the DNS resolver used for the domain verification
+func WithTXTResolver(resolver TXTResolver) DomainOption {
+	return func(opts *domainOptions) {
+		if resolver != nil {
+			opts.resolver = resolver
+		}
+	}
+}
+
+// DomainUsecase provides business logic of the account domains
+type DomainUsecase[TUser user.Model, TAccount account.Model] struct {
+	accountRepo account.Repository[TAccount]
+	memberRepo  account.MemberRepository[TUser, TAccount]
+	domainRepo  account.DomainRepository
+	opts        domainOptions
+}
+
+// NewDomainUsecase object controller
+func NewDomainUsecase[TUser user.Model, TAccount account.Model](
+	accountRepo account.Repository[TAccount],
+	memberRepo account.MemberRepository[TUser, TAccount],
+	domainRepo account.DomainRepository,
+	opts ...DomainOption,
+) *DomainUsecase[TUser, TAccount] {
+	options := domainOptions{resolver: net.DefaultResolver}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &DomainUsecase[TUser, TAccount]{
+		accountRepo: accountRepo,
+		memberRepo:  memberRepo,
+		domainRepo:  domainRepo,
+		opts:        options,
+	}
+}
+
+// Get the domain by ID
+func (a *DomainUsecase[TUser, TAccount]) Get(ctx context.Context, id uint64) (*account.Domain, error) {
+	domain, err := a.domainRepo.Domain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveAccessView(ctx, domain) {
+		return nil, acl.ErrNoPermissions.WithMessage("view account domain")
+	}
+	return domain, nil
+}
+
+// FetchListDomains returns the domains, without the global permission
+// the list is limited by the current account
+func (a *DomainUsecase[TUser, TAccount]) FetchListDomains(ctx context.Context, opts ...account.QOption) (_ []*account.Domain, err error) {
+	if !acl.HaveAccessList(ctx, &account.Domain{}) {
+		if opts, err = account.ListOptions(opts).WithPermissions(ctx, &account.DomainFilter{}); err != nil {
+			return nil, errors.Wrap(acl.ErrNoPermissions, err.Error())
+		}
+	}
+	return a.domainRepo.FetchListDomains(ctx, opts...)
+}
+
+// CountDomains returns the count of the domains
+func (a *DomainUsecase[TUser, TAccount]) CountDomains(ctx context.Context, opts ...account.QOption) (_ int64, err error) {
+	if !acl.HaveAccessCount(ctx, &account.Domain{}) {
+		if opts, err = account.ListOptions(opts).WithPermissions(ctx, &account.DomainFilter{}); err != nil {
+			return 0, errors.Wrap(acl.ErrNoPermissions, err.Error())
+		}
+	}
+	return a.domainRepo.CountDomains(ctx, opts...)
+}
+
+// Add the domain to the account, the session account is used by default
+func (a *DomainUsecase[TUser, TAccount]) Add(ctx context.Context, accountID uint64, name string) (*account.Domain, error) {
+	if accountID == 0 {
+		accountID = session.AccountID(ctx)
+	}
+	domain := &account.Domain{AccountID: accountID}
+	// The create permission doesn't check the owner, so the target account
+	// must be visible for the user as well
+	if !acl.HaveAccessCreate(ctx, domain) || !acl.HaveAccessView(ctx, domain) {
+		return nil, acl.ErrNoPermissions.WithMessage("create account domain")
+	}
+	var err error
+	if domain.Domain, err = normalizeDomain(name); err != nil {
+		return nil, err
+	}
+	if err = a.domainRepo.CreateDomain(ctx, domain); err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// Verify the domain by the TXT record with the verification token
+func (a *DomainUsecase[TUser, TAccount]) Verify(ctx context.Context, id uint64) (*account.Domain, error) {
+	domain, err := a.updatable(ctx, id)
+	if err != nil || domain.IsVerified() {
+		return domain, err
+	}
+	records, err := a.opts.resolver.LookupTXT(ctx, domain.Domain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, account.ErrDomainVerificationFailed
+		}
+		return nil, err
+	}
+	if !slices.Contains(records, domain.VerificationRecord()) {
+		return nil, account.ErrDomainVerificationFailed
+	}
+	if err = a.domainRepo.SetDomainVerified(ctx, domain); err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// SetPolicy of the verified domain, the auto-joined members can't be admins.
+// The default roles are given to every auto-joined member, so they can be set
+// only by the users who can set the member roles.
+func (a *DomainUsecase[TUser, TAccount]) SetPolicy(ctx context.Context, id uint64, autoJoin bool, roles []string, ssoRequired bool) (*account.Domain, error) {
+	domain, err := a.updatable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if (autoJoin || ssoRequired) && !domain.IsVerified() {
+		return nil, account.ErrDomainNotVerified
+	}
+	roles = slices.Compact(slices.Sorted(slices.Values(roles)))
+	if len(roles) > 0 && !acl.HaveObjectPermissions(ctx, account.MemberStub[TUser, TAccount](0, domain.AccountID, 0), `roles.set.*`) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, "update member roles")
+	}
+	if slices.ContainsFunc(roles, isAdminRole) {
+		return nil, account.ErrInvalidDomainRoles
+	}
+	domain.AutoJoin = autoJoin
+	domain.DefaultRoles = roles
+	domain.SSORequired = ssoRequired
+	if err = a.domainRepo.UpdateDomain(ctx, domain); err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// Delete the domain, the members joined by the domain stay in the account
+func (a *DomainUsecase[TUser, TAccount]) Delete(ctx context.Context, id uint64) (*account.Domain, error) {
+	domain, err := a.domainRepo.Domain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveAccessDelete(ctx, domain) {
+		return nil, acl.ErrNoPermissions.WithMessage("delete account domain")
+	}
+	if err = a.domainRepo.DeleteDomain(ctx, id); err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// SSORequired reports whether the email must authorize only through the single sign-on.
+// It's checked before the login, so no permissions are required.
+func (a *DomainUsecase[TUser, TAccount]) SSORequired(ctx context.Context, email string) (bool, error) {
+	domain, err := a.verifiedDomain(ctx, email)
+	if err != nil || domain == nil {
+		return false, err
+	}
+	return domain.SSORequired, nil
+}
+
+// AutoJoin links the user to the account of the verified email domain with the default roles.
+// The caller is responsible for the email to be confirmed.
+func (a *DomainUsecase[TUser, TAccount]) AutoJoin(ctx context.Context, userObj TUser, email string) (*account.Member[TUser, TAccount], error) {
+	domain, err := a.verifiedDomain(ctx, email)
+	if err != nil || domain == nil || !domain.AutoJoin {
+		return nil, err
+	}
+	if a.memberRepo.IsMember(ctx, userObj.GetID(), domain.AccountID) {
+		return nil, nil
+	}
+	if err = quota.CheckObject(ctx, account.MemberStub[TUser, TAccount](0, domain.AccountID, 0), 1); err != nil {
+		return nil, err
+	}
+	accountObj, err := a.accountRepo.Get(ctx, domain.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.memberRepo.LinkMember(ctx, accountObj, false, userObj); err != nil {
+		return nil, err
+	}
+	if roles := []string(domain.DefaultRoles); len(roles) > 0 {
+		if err = a.memberRepo.SetMemberRoles(ctx, accountObj, userObj, roles...); err != nil {
+			return nil, err
+		}
+	}
+	return a.memberRepo.Member(ctx, userObj.GetID(), domain.AccountID)
+}
+
+func (a *DomainUsecase[TUser, TAccount]) verifiedDomain(ctx context.Context, email string) (*account.Domain, error) {
+	name := emailDomain(email)
+	if name == "" {
+		return nil, nil
+	}
+	domain, err := a.domainRepo.VerifiedDomain(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return domain, err
+}
+
+func (a *DomainUsecase[TUser, TAccount]) updatable(ctx context.Context, id uint64) (*account.Domain, error) {
+	domain, err := a.domainRepo.Domain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveAccessUpdate(ctx, domain) {
+		return nil, acl.ErrNoPermissions.WithMessage("update account domain")
+	}
+	return domain, nil
+}
+
+// normalizeDomain returns the lower-case domain name without the trailing dot
+func normalizeDomain(name string) (string, error) {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	if len(name) == 0 || len(name) > 253 || !strings.Contains(name, ".") {
+		return "", account.ErrInvalidDomain
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return "", account.ErrInvalidDomain
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return "", account.ErrInvalidDomain
+			}
+		}
+	}
+	return name, nil
+}
+
+// emailDomain returns the lower-case domain of the email address
+func emailDomain(email string) string {
+	idx := strings.LastIndexByte(email, '@')
+	if idx < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[idx+1:]))
+}
+
+func isAdminRole(role string) bool {
+	return role == account.RoleAdmin || role == "account:admin" || strings.HasPrefix(role, "system:")
+}
@@ -0,0 +1,249 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/account/usecase"
+	prbac "github.com/geniusrabbit/blaze-api/repository/rbac"
+	rbacmocks "github.com/geniusrabbit/blaze-api/repository/rbac/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/testsuite"
+	usermocks "github.com/geniusrabbit/blaze-api/repository/user/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type testInviteSuite struct {
+	testsuite.DatabaseSuite
+
+	ctx context.Context
+
+	userRepo      *usermocks.MockRepository[*testutil.User]
+	emailRepo     *usermocks.MockEmailRepository[*testutil.User]
+	accountRepo   *mocks.MockSessionRepository[*testutil.User, *testAccount]
+	memberRepo    *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	inviteRepo    *mocks.MockInviteRepository
+	roleUsecase   *rbacmocks.MockUsecase
+	inviteUsecase account.InviteUsecase[*testutil.User, *testAccount]
+}
+
+func (s *testInviteSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(s.Ctx)
+	s.userRepo = usermocks.NewMockRepository[*testutil.User](ctrl)
+	s.emailRepo = usermocks.NewMockEmailRepository[*testutil.User](ctrl)
+	s.accountRepo = mocks.NewMockSessionRepository[*testutil.User, *testAccount](ctrl)
+	s.memberRepo = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.inviteRepo = mocks.NewMockInviteRepository(ctrl)
+	s.roleUsecase = rbacmocks.NewMockUsecase(ctrl)
+	s.inviteUsecase = usecase.NewInviteUsecase(s.userRepo, s.emailRepo,
+		s.accountRepo, s.memberRepo, s.inviteRepo,
+		usecase.WithInviteRoleUsecase[*testutil.User](s.roleUsecase))
+}
+
+func (s *testInviteSuite) pendingInvite(email string) *account.MemberInvite {
+	return &account.MemberInvite{
+		ID:        10,
+		AccountID: 1,
+		Email:     email,
+		Roles:     []string{account.RoleAdmin, "viewer"},
+		Status:    account.InviteStatusPending,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+// expectRoles found by the names
+func (s *testInviteSuite) expectRoles(ctx context.Context, names ...string) {
+	s.roleUsecase.EXPECT().FetchList(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, opts ...prbac.QOption) ([]*prbac.Role, error) {
+			s.Equal(&prbac.Filter{Names: []string{"admin", "viewer"}, AccountID: []uint64{1}, Global: true}, opts[0])
+			var list []*prbac.Role
+			for _, name := range names {
+				list = append(list, &prbac.Role{Name: name})
+			}
+			return list, nil
+		})
+}
+
+func (s *testInviteSuite) TestInvite() {
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.expectRoles(s.ctx, "admin", "viewer")
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "New@Example.com").Return(nil, nil)
+	s.inviteRepo.EXPECT().
+		CreateInvite(s.ctx, gomock.Any(), usecase.DefaultInviteLifetime).
+		DoAndReturn(func(_ context.Context, invite *account.MemberInvite, _ time.Duration) error {
+			invite.ID = 10
+			invite.Token = "token"
+			return nil
+		})
+
+	invite, err := s.inviteUsecase.Invite(s.ctx, 1, " New@Example.com ", "viewer", "admin", "viewer")
+	s.NoError(err)
+	s.Equal("new@example.com", invite.Email)
+	s.Equal([]string{"admin", "viewer"}, []string(invite.Roles))
+	s.Equal(uint64(1), invite.InvitedBy)
+	s.Equal("token", invite.Token)
+}
+
+func (s *testInviteSuite) TestInviteInvalidRoles() {
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.expectRoles(s.ctx, "viewer")
+
+	_, err := s.inviteUsecase.Invite(s.ctx, 1, "new@example.com", "viewer", "admin")
+	s.ErrorIs(err, account.ErrInvalidInviteRoles)
+}
+
+// TestInviteOnly checks that the invite permission doesn't allow to give the roles
+func (s *testInviteSuite) TestInviteOnly() {
+	ctx := testContextWithPermissions(s.ctx, account.MemberStub[*testutil.User, *testAccount](0, 1, 0), "invite")
+	s.Run("Roles", func() {
+		s.accountRepo.EXPECT().Get(ctx, uint64(1)).Return(testAccountStub(1), nil)
+		_, err := s.inviteUsecase.Invite(ctx, 1, "new@example.com", "admin")
+		s.ErrorIs(err, acl.ErrNoPermissions)
+	})
+	s.Run("ResendRoles", func() {
+		s.inviteRepo.EXPECT().Invite(ctx, uint64(10)).Return(s.pendingInvite("new@example.com"), nil)
+		_, err := s.inviteUsecase.Resend(ctx, 10)
+		s.ErrorIs(err, acl.ErrNoPermissions)
+	})
+	s.Run("NoRoles", func() {
+		s.accountRepo.EXPECT().Get(ctx, uint64(1)).Return(testAccountStub(1), nil)
+		s.emailRepo.EXPECT().GetByEmail(ctx, "new@example.com").Return(nil, nil)
+		s.inviteRepo.EXPECT().CreateInvite(ctx, gomock.Any(), usecase.DefaultInviteLifetime).Return(nil)
+		_, err := s.inviteUsecase.Invite(ctx, 1, "new@example.com")
+		s.NoError(err)
+	})
+}
+
+func (s *testInviteSuite) TestInviteInvalidEmail() {
+	_, err := s.inviteUsecase.Invite(s.ctx, 1, "not an email")
+	s.ErrorIs(err, account.ErrInvalidInviteEmail)
+}
+
+func (s *testInviteSuite) TestInviteExistingMember() {
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "member@example.com").Return(testutil.Stub(5), nil)
+	s.memberRepo.EXPECT().IsMember(s.ctx, uint64(5), uint64(1)).Return(true)
+
+	_, err := s.inviteUsecase.Invite(s.ctx, 1, "member@example.com")
+	s.ErrorIs(err, account.ErrAlreadyMember)
+}
+
+// TestAcceptCreatesUser with the roles, the anonymous invitee has no permission to list the roles
+func (s *testInviteSuite) TestAcceptCreatesUser() {
+	ctx := session.WithAnonymousUserAccount(s.Ctx)
+	invite := s.pendingInvite("new@example.com")
+	member := account.MemberStub[*testutil.User, *testAccount](7, 1, 5)
+
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectCommit()
+	s.inviteRepo.EXPECT().InviteByToken(ctx, "token").Return(invite, nil)
+	s.accountRepo.EXPECT().Get(ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.emailRepo.EXPECT().GetByEmail(gomock.Any(), "new@example.com").Return(nil, nil)
+	s.userRepo.EXPECT().EmptyObject().Return(&testutil.User{})
+	s.userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, usr *testutil.User) (uint64, error) {
+			s.Equal("new@example.com", usr.GetEmail())
+			s.True(usr.IsEmailVerified())
+			return 5, nil
+		})
+	s.memberRepo.EXPECT().IsMember(gomock.Any(), uint64(5), uint64(1)).Return(false)
+	gomock.InOrder(
+		s.inviteRepo.EXPECT().SetInviteStatus(gomock.Any(), uint64(10), account.InviteStatusAccepted, uint64(5)).Return(nil),
+		s.memberRepo.EXPECT().LinkMember(gomock.Any(), gomock.Any(), true, gomock.Any()).Return(nil),
+		s.memberRepo.EXPECT().SetMemberRoles(gomock.Any(), gomock.Any(), gomock.Any(), account.RoleAdmin, "viewer").
+			DoAndReturn(func(ctx context.Context, _ *testAccount, _ *testutil.User, _ ...string) error {
+				s.True(acl.IsNoPermCheck(ctx), "the roles are resolved without the permissions of the invitee")
+				return nil
+			}),
+	)
+	s.memberRepo.EXPECT().Member(ctx, uint64(5), uint64(1)).Return(member, nil)
+
+	res, err := s.inviteUsecase.Accept(ctx, "token", "")
+	s.NoError(err)
+	s.Equal(member, res)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+// TestAcceptClaimed checks that the invite accepted concurrently doesn't link the member
+func (s *testInviteSuite) TestAcceptClaimed() {
+	ctx := session.WithAnonymousUserAccount(s.Ctx)
+
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectRollback()
+	s.inviteRepo.EXPECT().InviteByToken(ctx, "token").Return(s.pendingInvite("user@example.com"), nil)
+	s.accountRepo.EXPECT().Get(ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.emailRepo.EXPECT().GetByEmail(gomock.Any(), "user@example.com").Return(testutil.Stub(5), nil)
+	s.memberRepo.EXPECT().IsMember(gomock.Any(), uint64(5), uint64(1)).Return(false)
+	s.inviteRepo.EXPECT().SetInviteStatus(gomock.Any(), uint64(10), account.InviteStatusAccepted, uint64(5)).
+		Return(account.ErrInviteNotPending)
+
+	_, err := s.inviteUsecase.Accept(ctx, "token", "")
+	s.ErrorIs(err, account.ErrInviteNotPending)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+// TestAcceptRollback checks that the failed roles don't leave the half-applied accept
+func (s *testInviteSuite) TestAcceptRollback() {
+	ctx := session.WithAnonymousUserAccount(s.Ctx)
+
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectRollback()
+	s.inviteRepo.EXPECT().InviteByToken(ctx, "token").Return(s.pendingInvite("user@example.com"), nil)
+	s.accountRepo.EXPECT().Get(ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.emailRepo.EXPECT().GetByEmail(gomock.Any(), "user@example.com").Return(testutil.Stub(5), nil)
+	s.memberRepo.EXPECT().IsMember(gomock.Any(), uint64(5), uint64(1)).Return(false)
+	s.inviteRepo.EXPECT().SetInviteStatus(gomock.Any(), uint64(10), account.InviteStatusAccepted, uint64(5)).Return(nil)
+	s.memberRepo.EXPECT().LinkMember(gomock.Any(), gomock.Any(), true, gomock.Any()).Return(nil)
+	s.memberRepo.EXPECT().SetMemberRoles(gomock.Any(), gomock.Any(), gomock.Any(), account.RoleAdmin, "viewer").
+		Return(account.ErrInvalidInviteRoles)
+
+	_, err := s.inviteUsecase.Accept(ctx, "token", "")
+	s.ErrorIs(err, account.ErrInvalidInviteRoles)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *testInviteSuite) TestAcceptEmailMismatch() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectRollback()
+	s.inviteRepo.EXPECT().InviteByToken(s.ctx, "token").Return(s.pendingInvite("new@example.com"), nil)
+	s.accountRepo.EXPECT().Get(s.ctx, uint64(1)).Return(testAccountStub(1), nil)
+	s.userRepo.EXPECT().Get(gomock.Any(), uint64(1)).
+		Return(testutil.StubWithEmail("other@example.com", models.ApprovedApproveStatus), nil)
+
+	_, err := s.inviteUsecase.Accept(s.ctx, "token", "")
+	s.ErrorIs(err, account.ErrInviteEmailMismatch)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *testInviteSuite) TestDeclineExpired() {
+	invite := s.pendingInvite("new@example.com")
+	invite.ExpiresAt = time.Now().Add(-time.Minute)
+	s.inviteRepo.EXPECT().InviteByToken(s.ctx, "token").Return(invite, nil)
+
+	_, err := s.inviteUsecase.Decline(s.ctx, "token")
+	s.ErrorIs(err, account.ErrInvalidInviteToken)
+}
+
+func (s *testInviteSuite) TestRevokeNotPending() {
+	invite := s.pendingInvite("new@example.com")
+	s.inviteRepo.EXPECT().Invite(s.ctx, uint64(10)).Return(invite, nil)
+	s.inviteRepo.EXPECT().
+		SetInviteStatus(s.ctx, uint64(10), account.InviteStatusRevoked, uint64(0)).
+		Return(account.ErrInviteNotPending)
+
+	_, err := s.inviteUsecase.Revoke(s.ctx, 10)
+	s.ErrorIs(err, account.ErrInviteNotPending)
+}
+
+func TestAccountInviteSuite(t *testing.T) {
+	suite.Run(t, new(testInviteSuite))
+}
@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/database"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	prbac "github.com/geniusrabbit/blaze-api/repository/rbac"
+	rbacuc "github.com/geniusrabbit/blaze-api/repository/rbac/usecase"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// DefaultInviteLifetime of the invite token
+const DefaultInviteLifetime = 7 * 24 * time.Hour
+
+// InviteUserCreator creates the user who accepted the invite and had no account yet.
+// The password can be empty if the invitee didn't set it.
+type InviteUserCreator[TUser user.Model] func(ctx context.Context, userObj TUser, password string) (uint64, error)
+
+type inviteOptions[TUser user.Model] struct {
+	lifetime   time.Duration
+	createUser InviteUserCreator[TUser]
+	roles      prbac.Usecase
+}
+
+// InviteOption configures the invite usecase.
+type InviteOption[TUser user.Model] func(*inviteOptions[TUser])
+
+// WithInviteLifetime changes the lifetime of the invite token, zero keeps the default.
+func WithInviteLifetime[TUser user.Model](lifetime time.Duration) InviteOption[TUser] {
+	return func(opts *inviteOptions[TUser]) {
+		if lifetime > 0 {
+			opts.lifetime = lifetime
+		}
+	}
+}
+
+// WithInviteUserCreator replaces the creation of the new users,
+// e.g. to store the password with the password repository.
+func WithInviteUserCreator[TUser user.Model](create InviteUserCreator[TUser]) InviteOption[TUser] {
+	return func(opts *inviteOptions[TUser]) { opts.createUser = create }
+}
+
+// WithInviteRoleUsecase replaces the lookup of the roles given by the invites.
+func WithInviteRoleUsecase[TUser user.Model](roles prbac.Usecase) InviteOption[TUser] {
+	return func(opts *inviteOptions[TUser]) { opts.roles = roles }
+}
+
+// InviteUsecase provides business logic of the member invitations by email
+type InviteUsecase[TUser user.EmailCapableModel, TAccount account.Model] struct {
+	userRepo    user.Repository[TUser]
+	emailRepo   user.EmailRepository[TUser]
+	accountRepo account.Repository[TAccount]
+	memberRepo  account.MemberRepository[TUser, TAccount]
+	inviteRepo  account.InviteRepository
+	opts        inviteOptions[TUser]
+}
+
+// NewInviteUsecase object controller
+func NewInviteUsecase[TUser user.EmailCapableModel, TAccount account.Model](
+	userRepo user.Repository[TUser],
+	emailRepo user.EmailRepository[TUser],
+	accountRepo account.Repository[TAccount],
+	memberRepo account.MemberRepository[TUser, TAccount],
+	inviteRepo account.InviteRepository,
+	opts ...InviteOption[TUser],
+) *InviteUsecase[TUser, TAccount] {
+	options := inviteOptions[TUser]{
+		lifetime: DefaultInviteLifetime,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.createUser == nil {
+		options.createUser = func(ctx context.Context, userObj TUser, _ string) (uint64, error) {
+			return userRepo.Create(ctx, userObj)
+		}
+	}
+	if options.roles == nil {
+		options.roles = rbacuc.NewDefault()
+	}
+	return &InviteUsecase[TUser, TAccount]{
+		userRepo:    userRepo,
+		emailRepo:   emailRepo,
+		accountRepo: accountRepo,
+		memberRepo:  memberRepo,
+		inviteRepo:  inviteRepo,
+		opts:        options,
+	}
+}
+
+func (a *InviteUsecase[TUser, TAccount]) canManage(ctx context.Context, accountID uint64) bool {
+	return acl.HaveObjectPermissions(ctx, account.MemberStub[TUser, TAccount](0, accountID, 0), `invite`)
+}
+
+// checkRoles of the invite. The roles are given to the invitee on the accept,
+// so they can be set only by the users who can set the member roles.
+func (a *InviteUsecase[TUser, TAccount]) checkRoles(ctx context.Context, accountID uint64, roles []string) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	if !acl.HaveObjectPermissions(ctx, account.MemberStub[TUser, TAccount](0, accountID, 0), `roles.set.*`) {
+		return errors.Wrap(acl.ErrNoPermissions, "update member roles")
+	}
+	// The custom roles of the account are assignable only within the account
+	list, err := a.opts.roles.FetchList(ctx, &prbac.Filter{
+		Names:     roles,
+		AccountID: []uint64{accountID},
+		Global:    true,
+	})
+	if err != nil {
+		return err
+	}
+	names := make(map[string]struct{}, len(list))
+	for _, role := range list {
+		names[role.Name] = struct{}{}
+	}
+	if len(names) != len(roles) {
+		return account.ErrInvalidInviteRoles
+	}
+	return nil
+}
+
+// Invite the email into the account with the roles.
+// The previous pending invite of the email is revoked.
+func (a *InviteUsecase[TUser, TAccount]) Invite(ctx context.Context, accountID uint64, email string, roles ...string) (*account.MemberInvite, error) {
+	if !a.canManage(ctx, accountID) {
+		return nil, acl.ErrNoPermissions.WithMessage("invite member account")
+	}
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return nil, account.ErrInvalidInviteEmail
+	}
+	if _, err := a.accountRepo.Get(ctx, accountID); err != nil {
+		return nil, err
+	}
+	roles = slices.Compact(slices.Sorted(slices.Values(roles)))
+	if err := a.checkRoles(ctx, accountID, roles); err != nil {
+		return nil, err
+	}
+	usr, err := a.emailRepo.GetByEmail(ctx, addr.Address)
+	if err != nil {
+		return nil, err
+	}
+	if !gocast.IsNil(usr) && a.memberRepo.IsMember(ctx, usr.GetID(), accountID) {
+		return nil, account.ErrAlreadyMember
+	}
+	invite := &account.MemberInvite{
+		AccountID: accountID,
+		Email:     strings.ToLower(addr.Address),
+		Roles:     roles,
+		InvitedBy: session.UserID(ctx),
+	}
+	if err := a.inviteRepo.CreateInvite(ctx, invite, a.opts.lifetime); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// Get the invite by ID
+func (a *InviteUsecase[TUser, TAccount]) Get(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	invite, err := a.inviteRepo.Invite(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !a.canManage(ctx, invite.AccountID) {
+		return nil, acl.ErrNoPermissions.WithMessage("view member invite")
+	}
+	return invite, nil
+}
+
+// FetchListInvites returns the invites, without the global permission
+// the list is limited by the current account
+func (a *InviteUsecase[TUser, TAccount]) FetchListInvites(ctx context.Context, opts ...account.QOption) (_ []*account.MemberInvite, err error) {
+	if opts, err = a.listOptions(ctx, opts); err != nil {
+		return nil, err
+	}
+	return a.inviteRepo.FetchListInvites(ctx, opts...)
+}
+
+// CountInvites returns the count of the invites
+func (a *InviteUsecase[TUser, TAccount]) CountInvites(ctx context.Context, opts ...account.QOption) (_ int64, err error) {
+	if opts, err = a.listOptions(ctx, opts); err != nil {
+		return 0, err
+	}
+	return a.inviteRepo.CountInvites(ctx, opts...)
+}
+
+func (a *InviteUsecase[TUser, TAccount]) listOptions(ctx context.Context, opts []account.QOption) ([]account.QOption, error) {
+	if a.canManage(ctx, 0) {
+		return opts, nil
+	}
+	if !a.canManage(ctx, session.AccountID(ctx)) {
+		return nil, acl.ErrNoPermissions.WithMessage("list member invites")
+	}
+	opts, err := account.ListOptions(opts).WithPermissions(ctx, &account.InviteFilter{})
+	if err != nil {
+		return nil, errors.Wrap(acl.ErrNoPermissions, err.Error())
+	}
+	return opts, nil
+}
+
+// Resend renews the token and the lifetime of the pending invite
+func (a *InviteUsecase[TUser, TAccount]) Resend(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	invite, err := a.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invite.Status != account.InviteStatusPending {
+		return nil, account.ErrInviteNotPending
+	}
+	if err = a.checkRoles(ctx, invite.AccountID, invite.Roles); err != nil {
+		return nil, err
+	}
+	if err = a.inviteRepo.RenewInviteToken(ctx, invite, a.opts.lifetime); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// Revoke the pending invite
+func (a *InviteUsecase[TUser, TAccount]) Revoke(ctx context.Context, id uint64) (*account.MemberInvite, error) {
+	invite, err := a.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.inviteRepo.SetInviteStatus(ctx, invite.ID, account.InviteStatusRevoked, 0); err != nil {
+		return nil, err
+	}
+	invite.Status = account.InviteStatusRevoked
+	return invite, nil
+}
+
+// InviteByToken returns the pending invite by the plain token.
+// The token itself grants the access to the invite.
+func (a *InviteUsecase[TUser, TAccount]) InviteByToken(ctx context.Context, token string) (*account.MemberInvite, error) {
+	invite, err := a.inviteRepo.InviteByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, sql.ErrNoRows) {
+			return nil, account.ErrInvalidInviteToken
+		}
+		return nil, err
+	}
+	if invite.Status != account.InviteStatusPending {
+		return nil, account.ErrInviteNotPending
+	}
+	if !invite.IsPending(time.Now()) {
+		return nil, account.ErrInvalidInviteToken
+	}
+	return invite, nil
+}
+
+// Accept the invite and link the invitee to the account.
+// The logged in user can accept only the invite sent to the own email,
+// otherwise the user is found by the email or created with the password.
+func (a *InviteUsecase[TUser, TAccount]) Accept(ctx context.Context, token, password string) (*account.Member[TUser, TAccount], error) {
+	invite, err := a.InviteByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err = quota.CheckObject(ctx, account.MemberStub[TUser, TAccount](0, invite.AccountID, 0), 1); err != nil {
+		return nil, err
+	}
+	accountObj, err := a.accountRepo.Get(ctx, invite.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	var usr TUser
+	err = database.ContextTransactionExec(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		if usr, err = a.invitee(ctx, invite, password); err != nil {
+			return err
+		}
+		if a.memberRepo.IsMember(ctx, usr.GetID(), invite.AccountID) {
+			return account.ErrAlreadyMember
+		}
+		// The invite is claimed before the link, the concurrent accept stops here
+		err := a.inviteRepo.SetInviteStatus(ctx, invite.ID, account.InviteStatusAccepted, usr.GetID())
+		if err != nil {
+			return err
+		}
+		roles := []string(invite.Roles)
+		if err = a.memberRepo.LinkMember(ctx, accountObj, slices.Contains(roles, account.RoleAdmin), usr); err != nil {
+			return err
+		}
+		if len(roles) == 0 {
+			return nil
+		}
+		// The roles were checked with the permissions of the inviter,
+		// the invitee usually can't even list them
+		return a.memberRepo.SetMemberRoles(acl.WithNoPermCheck(ctx), accountObj, usr, roles...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.memberRepo.Member(ctx, usr.GetID(), invite.AccountID)
+}
+
+// invitee returns the user who accepts the invite
+func (a *InviteUsecase[TUser, TAccount]) invitee(ctx context.Context, invite *account.MemberInvite, password string) (TUser, error) {
+	var zero TUser
+	if current := session.User(ctx); current != nil && !current.IsAnonymous() {
+		usr, err := a.userRepo.Get(ctx, current.GetID())
+		if err != nil {
+			return zero, err
+		}
+		if !strings.EqualFold(usr.GetEmail(), invite.Email) {
+			return zero, account.ErrInviteEmailMismatch
+		}
+		return usr, nil
+	}
+	usr, err := a.emailRepo.GetByEmail(ctx, invite.Email)
+	if err != nil || !gocast.IsNil(usr) {
+		return usr, err
+	}
+
+	// The invite link confirms the ownership of the email
+	usr = a.userRepo.EmptyObject()
+	usr.SetEmail(invite.Email)
+	if verified, ok := any(usr).(user.EmailVerificationModel); ok {
+		verified.SetEmailVerified(true)
+	}
+	id, err := a.opts.createUser(ctx, usr, password)
+	if err != nil {
+		return zero, err
+	}
+	setUserID(usr, id)
+	return usr, nil
+}
+
+// Decline the invite by the token
+func (a *InviteUsecase[TUser, TAccount]) Decline(ctx context.Context, token string) (*account.MemberInvite, error) {
+	invite, err := a.InviteByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.inviteRepo.SetInviteStatus(ctx, invite.ID, account.InviteStatusDeclined, session.UserID(ctx)); err != nil {
+		return nil, err
+	}
+	invite.Status = account.InviteStatusDeclined
+	return invite, nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
 	"github.com/geniusrabbit/blaze-api/repository/account/usecase"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	quotamocks "github.com/geniusrabbit/blaze-api/repository/quota/mocks"
 	usermocks "github.com/geniusrabbit/blaze-api/repository/user/mocks"
 	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
 )
@@ -65,6 +67,17 @@ func (s *testMemberSuite) TestLinkMember() {
 	s.NoError(err)
 }
 
+func (s *testMemberSuite) TestLinkMemberQuotaExceeded() {
+	quotaUsecase := quotamocks.NewMockUsecase(gomock.NewController(s.T()))
+	ctx := quota.WithUsecase(s.ctx, quotaUsecase)
+	s.memberRepo.EXPECT().EmptyObject().Return(&account.Member[*testutil.User, *testAccount]{})
+	quotaUsecase.EXPECT().Check(ctx, uint64(1), "account.member", int64(2)).
+		Return(&quota.ExceededError{AccountID: 1, Resource: "account.member", Limit: 2, Used: 1})
+
+	err := s.memberUsecase.LinkMember(ctx, testAccountStub(1), false, testutil.Stub(101), testutil.Stub(102))
+	s.ErrorIs(err, quota.ErrQuotaExceeded)
+}
+
 func (s *testMemberSuite) TestUnlinkMember() {
 	s.memberRepo.EXPECT().
 		UnlinkMember(s.ctx, gomock.AssignableToTypeOf(&testAccount{}),
@@ -77,6 +90,30 @@ func (s *testMemberSuite) TestUnlinkMember() {
 	s.NoError(err)
 }
 
+func (s *testMemberSuite) TestSuspendMember() {
+	member := account.MemberStub[*testutil.User, *testAccount](5, 1, 101)
+	suspended := account.MemberStub[*testutil.User, *testAccount](5, 1, 101)
+	suspended.Suspend("spam")
+	gomock.InOrder(
+		s.memberRepo.EXPECT().MemberByID(s.ctx, uint64(5)).Return(member, nil),
+		s.memberRepo.EXPECT().SetMembersSuspended(s.ctx, uint64(1), []uint64{5}, true, "spam").Return(nil),
+		s.memberRepo.EXPECT().MemberByID(s.ctx, uint64(5)).Return(suspended, nil),
+	)
+
+	res, err := s.memberUsecase.SuspendMember(s.ctx, 5, "spam")
+	s.NoError(err)
+	s.True(res.IsSuspended())
+}
+
+func (s *testMemberSuite) TestSuspendMemberOwner() {
+	member := account.MemberStub[*testutil.User, *testAccount](5, 1, 101)
+	member.IsOwner = true
+	s.memberRepo.EXPECT().MemberByID(s.ctx, uint64(5)).Return(member, nil)
+
+	_, err := s.memberUsecase.SuspendMember(s.ctx, 5, "spam")
+	s.ErrorIs(err, account.ErrOwnerProtected)
+}
+
 func TestAccountMemberSuite(t *testing.T) {
 	suite.Run(t, &testMemberSuite{})
 }
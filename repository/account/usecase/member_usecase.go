@@ -4,10 +4,17 @@ import (
 	"context"
 	"slices"
 
+	"github.com/demdxx/gocast/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
 	"github.com/geniusrabbit/blaze-api/repository/user"
-	"github.com/pkg/errors"
 )
 
 // MemberUsecase provides bussiness logic for account members
@@ -68,6 +75,9 @@ func (a *MemberUsecase[TUser, TAccount]) LinkMember(ctx context.Context, account
 	if !acl.HaveAccessCreate(ctx, a.EmptyObject()) {
 		return errors.Wrap(acl.ErrNoPermissions, "create member account")
 	}
+	if err := quota.CheckObject(ctx, a.aclMember(accountObj.GetID(), 0), int64(len(members))); err != nil {
+		return err
+	}
 	return a.memberRepo.LinkMember(ctx, accountObj, isAdmin, members...)
 }
 
@@ -90,6 +100,9 @@ func (a *MemberUsecase[TUser, TAccount]) UnlinkAccountMember(ctx context.Context
 	if err != nil {
 		return err
 	}
+	if !acl.HaveAccessDelete(ctx, member) {
+		return errors.Wrap(acl.ErrNoPermissions, "delete member account")
+	}
 	return a.memberRepo.UnlinkMember(ctx, member.Account, member.User)
 }
 
@@ -111,6 +124,9 @@ func (a *MemberUsecase[TUser, TAccount]) InviteMember(ctx context.Context, accou
 	}
 
 	// Link member to account and set roles
+	if err = quota.CheckObject(ctx, a.aclMember(accountID, 0), 1); err != nil {
+		return nil, err
+	}
 	if err = a.memberRepo.LinkMember(ctx, accountObj, slices.Contains(roles, "admin"), usr); err != nil {
 		return nil, err
 	}
@@ -150,3 +166,46 @@ func (a *MemberUsecase[TUser, TAccount]) SetMemberRoles(ctx context.Context, mem
 	}
 	return member, a.memberRepo.SetMemberRoles(ctx, member.Account, member.User, roles...)
 }
+
+// SuspendMember of the account keeping its roles, the owner can't be suspended
+func (a *MemberUsecase[TUser, TAccount]) SuspendMember(ctx context.Context, memberID uint64, reason string) (*account.Member[TUser, TAccount], error) {
+	return a.setMemberSuspended(ctx, memberID, true, reason)
+}
+
+// UnsuspendMember restores the access of the member to the account
+func (a *MemberUsecase[TUser, TAccount]) UnsuspendMember(ctx context.Context, memberID uint64) (*account.Member[TUser, TAccount], error) {
+	return a.setMemberSuspended(ctx, memberID, false, "")
+}
+
+func (a *MemberUsecase[TUser, TAccount]) setMemberSuspended(ctx context.Context, memberID uint64, suspended bool, reason string) (*account.Member[TUser, TAccount], error) {
+	member, err := a.memberRepo.MemberByID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if !acl.HaveAccessSuspend(ctx, member) {
+		return nil, acl.ErrNoPermissions.WithMessage("suspend member account")
+	}
+	if suspended && member.IsOwner {
+		return nil, account.ErrOwnerProtected
+	}
+	err = a.memberRepo.SetMembersSuspended(ctx, member.AccountID, []uint64{member.ID}, suspended, reason)
+	if err != nil {
+		return nil, err
+	}
+	writeMemberSuspension(historylog.WithMessage(ctx, reason), suspended, member.AccountID, member.ID)
+	return a.memberRepo.MemberByID(ctx, memberID)
+}
+
+func writeMemberSuspension(ctx context.Context, suspended bool, accountID uint64, memberIDs ...uint64) {
+	action := gocast.IfThen(suspended, "account.member.suspend", "account.member.unsuspend")
+	err := historylog.Write(ctx, action, "account", accountID, map[string]any{"member_id": memberIDs})
+	if err != nil {
+		ctxlogger.Get(ctx).Error("log member suspension",
+			zap.Uint64("account_id", accountID),
+			zap.Uint64s("member_id", memberIDs),
+			zap.Error(err))
+	}
+}
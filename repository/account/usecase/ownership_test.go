@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/account/usecase"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type testOwnershipSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	memberRepo       *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	ownershipRepo    *mocks.MockOwnershipRepository
+	ownershipUsecase account.OwnershipUsecase
+}
+
+func (s *testOwnershipSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.memberRepo = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.ownershipRepo = mocks.NewMockOwnershipRepository(ctrl)
+	s.ownershipUsecase = usecase.NewOwnershipUsecase(s.memberRepo, s.ownershipRepo)
+}
+
+func (s *testOwnershipSuite) member(userID uint64, owner bool) *account.Member[*testutil.User, *testAccount] {
+	member := account.MemberStub[*testutil.User, *testAccount](userID, 1, userID)
+	member.Approve = models.ApprovedApproveStatus
+	member.IsAdmin = owner
+	member.IsOwner = owner
+	return member
+}
+
+func (s *testOwnershipSuite) transfer(from, to uint64) *account.OwnershipTransfer {
+	return &account.OwnershipTransfer{
+		ID:         5,
+		AccountID:  1,
+		FromUserID: from,
+		ToUserID:   to,
+		Status:     account.TransferStatusPending,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func (s *testOwnershipSuite) TestRequestTransfer() {
+	s.memberRepo.EXPECT().Member(s.ctx, uint64(1), uint64(1)).Return(s.member(1, true), nil)
+	s.memberRepo.EXPECT().Member(s.ctx, uint64(2), uint64(1)).Return(s.member(2, false), nil)
+	s.ownershipRepo.EXPECT().
+		CreateOwnershipTransfer(s.ctx, gomock.Any(), usecase.DefaultTransferLifetime).
+		DoAndReturn(func(_ context.Context, transfer *account.OwnershipTransfer, _ time.Duration) error {
+			s.Equal(uint64(1), transfer.FromUserID)
+			s.Equal(uint64(2), transfer.ToUserID)
+			transfer.ID = 5
+			return nil
+		})
+	transfer, err := s.ownershipUsecase.RequestTransfer(s.ctx, 1, 2)
+	s.NoError(err)
+	s.Equal(uint64(5), transfer.ID)
+}
+
+func (s *testOwnershipSuite) TestRequestTransferNotOwner() {
+	s.memberRepo.EXPECT().Member(s.ctx, uint64(1), uint64(1)).Return(s.member(1, false), nil)
+	_, err := s.ownershipUsecase.RequestTransfer(s.ctx, 1, 2)
+	s.ErrorIs(err, account.ErrNotOwner)
+}
+
+func (s *testOwnershipSuite) TestRequestTransferNotApproved() {
+	target := s.member(2, false)
+	target.Approve = models.PendingApproveStatus
+	s.memberRepo.EXPECT().Member(s.ctx, uint64(1), uint64(1)).Return(s.member(1, true), nil)
+	s.memberRepo.EXPECT().Member(s.ctx, uint64(2), uint64(1)).Return(target, nil)
+	_, err := s.ownershipUsecase.RequestTransfer(s.ctx, 1, 2)
+	s.ErrorIs(err, account.ErrInvalidTransferTarget)
+}
+
+func (s *testOwnershipSuite) TestConfirm() {
+	transfer := s.transfer(2, 1)
+	s.ownershipRepo.EXPECT().OwnershipTransfer(s.ctx, uint64(5)).Return(transfer, nil)
+	s.memberRepo.EXPECT().IsMember(s.ctx, uint64(1), uint64(1)).Return(true)
+	s.ownershipRepo.EXPECT().CompleteOwnershipTransfer(s.ctx, transfer).Return(nil)
+	_, err := s.ownershipUsecase.Confirm(s.ctx, 5)
+	s.NoError(err)
+}
+
+func (s *testOwnershipSuite) TestConfirmByOwner() {
+	s.ownershipRepo.EXPECT().OwnershipTransfer(s.ctx, uint64(5)).Return(s.transfer(1, 2), nil)
+	_, err := s.ownershipUsecase.Confirm(s.ctx, 5)
+	s.ErrorIs(err, acl.ErrNoPermissions)
+}
+
+func (s *testOwnershipSuite) TestConfirmExpired() {
+	transfer := s.transfer(2, 1)
+	transfer.ExpiresAt = time.Now().Add(-time.Minute)
+	s.ownershipRepo.EXPECT().OwnershipTransfer(s.ctx, uint64(5)).Return(transfer, nil)
+	_, err := s.ownershipUsecase.Confirm(s.ctx, 5)
+	s.ErrorIs(err, account.ErrTransferNotPending)
+}
+
+func (s *testOwnershipSuite) TestDecline() {
+	s.ownershipRepo.EXPECT().OwnershipTransfer(s.ctx, uint64(5)).Return(s.transfer(2, 1), nil)
+	s.ownershipRepo.EXPECT().
+		SetOwnershipTransferStatus(s.ctx, uint64(5), account.TransferStatusDeclined).Return(nil)
+	transfer, err := s.ownershipUsecase.Decline(s.ctx, 5)
+	s.NoError(err)
+	s.Equal(account.TransferStatusDeclined, transfer.Status)
+}
+
+func (s *testOwnershipSuite) TestCancel() {
+	s.ownershipRepo.EXPECT().OwnershipTransfer(s.ctx, uint64(5)).Return(s.transfer(1, 2), nil)
+	s.ownershipRepo.EXPECT().
+		SetOwnershipTransferStatus(s.ctx, uint64(5), account.TransferStatusCancelled).Return(nil)
+	transfer, err := s.ownershipUsecase.Cancel(s.ctx, 5)
+	s.NoError(err)
+	s.Equal(account.TransferStatusCancelled, transfer.Status)
+}
+
+func TestOwnershipSuite(t *testing.T) {
+	suite.Run(t, &testOwnershipSuite{})
+}
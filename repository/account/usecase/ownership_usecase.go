@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// DefaultTransferLifetime of the pending ownership transfer
+const DefaultTransferLifetime = 72 * time.Hour
+
+type ownershipOptions struct {
+	lifetime time.Duration
+}
+
+// OwnershipOption configures the ownership usecase.
+type OwnershipOption func(*ownershipOptions)
+
+// WithTransferLifetime changes the time for the new owner to confirm the transfer, zero keeps the default.
+func WithTransferLifetime(lifetime time.Duration) OwnershipOption {
+	return func(opts *ownershipOptions) {
+		if lifetime > 0 {
+			opts.lifetime = lifetime
+		}
+	}
+}
+
+// OwnershipUsecase provides business logic of the account ownership transfers
+type OwnershipUsecase[TUser user.Model, TAccount account.Model] struct {
+	memberRepo    account.MemberRepository[TUser, TAccount]
+	ownershipRepo account.OwnershipRepository
+	opts          ownershipOptions
+}
+
+// NewOwnershipUsecase object controller
+func NewOwnershipUsecase[TUser user.Model, TAccount account.Model](
+	memberRepo account.MemberRepository[TUser, TAccount],
+	ownershipRepo account.OwnershipRepository,
+	opts ...OwnershipOption,
+) *OwnershipUsecase[TUser, TAccount] {
+	options := ownershipOptions{lifetime: DefaultTransferLifetime}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &OwnershipUsecase[TUser, TAccount]{
+		memberRepo:    memberRepo,
+		ownershipRepo: ownershipRepo,
+		opts:          options,
+	}
+}
+
+// RequestTransfer of the account to another approved member, the previous pending transfer is cancelled
+func (a *OwnershipUsecase[TUser, TAccount]) RequestTransfer(ctx context.Context, accountID, toUserID uint64) (*account.OwnershipTransfer, error) {
+	fromUserID := session.UserID(ctx)
+	if fromUserID == 0 {
+		return nil, acl.ErrNoPermissions.WithMessage("transfer account ownership")
+	}
+	owner, err := a.memberRepo.Member(ctx, fromUserID, accountID)
+	if err != nil || !owner.IsOwner {
+		return nil, account.ErrNotOwner
+	}
+	if toUserID == fromUserID {
+		return nil, account.ErrInvalidTransferTarget
+	}
+	target, err := a.memberRepo.Member(ctx, toUserID, accountID)
+	if err != nil || target.Approve != pkgModels.ApprovedApproveStatus {
+		return nil, account.ErrInvalidTransferTarget
+	}
+	transfer := &account.OwnershipTransfer{
+		AccountID:  accountID,
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+	}
+	if err = a.ownershipRepo.CreateOwnershipTransfer(ctx, transfer, a.opts.lifetime); err != nil {
+		return nil, err
+	}
+	writeTransferHistory(ctx, "account.ownership.request", transfer)
+	return transfer, nil
+}
+
+// Get the transfer by ID
+func (a *OwnershipUsecase[TUser, TAccount]) Get(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	transfer, err := a.ownershipRepo.OwnershipTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if userID := session.UserID(ctx); userID == 0 ||
+		(userID != transfer.FromUserID && userID != transfer.ToUserID) {
+		return nil, acl.ErrNoPermissions.WithMessage("view ownership transfer")
+	}
+	return transfer, nil
+}
+
+// FetchListTransfers of the current user, sent and received
+func (a *OwnershipUsecase[TUser, TAccount]) FetchListTransfers(ctx context.Context, opts ...account.QOption) ([]*account.OwnershipTransfer, error) {
+	userID := session.UserID(ctx)
+	if userID == 0 {
+		return nil, acl.ErrNoPermissions.WithMessage("list ownership transfers")
+	}
+	opts = append([]account.QOption{&account.TransferFilter{UserID: []uint64{userID}}}, opts...)
+	return a.ownershipRepo.FetchListOwnershipTransfers(ctx, opts...)
+}
+
+// Confirm the transfer by the new owner, the previous owner stays the admin of the account
+func (a *OwnershipUsecase[TUser, TAccount]) Confirm(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	transfer, err := a.pendingFor(ctx, id, func(t *account.OwnershipTransfer) uint64 { return t.ToUserID })
+	if err != nil {
+		return nil, err
+	}
+	if !a.memberRepo.IsMember(ctx, transfer.ToUserID, transfer.AccountID) {
+		return nil, account.ErrInvalidTransferTarget
+	}
+	if err = a.ownershipRepo.CompleteOwnershipTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+	writeTransferHistory(ctx, "account.ownership.confirm", transfer)
+	return transfer, nil
+}
+
+// Decline the transfer by the new owner
+func (a *OwnershipUsecase[TUser, TAccount]) Decline(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	transfer, err := a.pendingFor(ctx, id, func(t *account.OwnershipTransfer) uint64 { return t.ToUserID })
+	if err != nil {
+		return nil, err
+	}
+	return a.setStatus(ctx, "account.ownership.decline", transfer, account.TransferStatusDeclined)
+}
+
+// Cancel the transfer by the current owner
+func (a *OwnershipUsecase[TUser, TAccount]) Cancel(ctx context.Context, id uint64) (*account.OwnershipTransfer, error) {
+	transfer, err := a.pendingFor(ctx, id, func(t *account.OwnershipTransfer) uint64 { return t.FromUserID })
+	if err != nil {
+		return nil, err
+	}
+	return a.setStatus(ctx, "account.ownership.cancel", transfer, account.TransferStatusCancelled)
+}
+
+// pendingFor returns the pending transfer if the current user is the expected side of it
+func (a *OwnershipUsecase[TUser, TAccount]) pendingFor(ctx context.Context, id uint64, side func(*account.OwnershipTransfer) uint64) (*account.OwnershipTransfer, error) {
+	transfer, err := a.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if side(transfer) != session.UserID(ctx) {
+		return nil, acl.ErrNoPermissions.WithMessage("change ownership transfer")
+	}
+	if !transfer.IsPending(time.Now()) {
+		return nil, account.ErrTransferNotPending
+	}
+	return transfer, nil
+}
+
+func (a *OwnershipUsecase[TUser, TAccount]) setStatus(ctx context.Context, name string, transfer *account.OwnershipTransfer, status account.TransferStatus) (*account.OwnershipTransfer, error) {
+	if err := a.ownershipRepo.SetOwnershipTransferStatus(ctx, transfer.ID, status); err != nil {
+		return nil, err
+	}
+	transfer.Status = status
+	writeTransferHistory(ctx, name, transfer)
+	return transfer, nil
+}
+
+// writeTransferHistory logs the step of the transfer, the failure of the log doesn't break the flow
+func writeTransferHistory(ctx context.Context, name string, transfer *account.OwnershipTransfer) {
+	err := historylog.Write(ctx, name, "account", transfer.AccountID, map[string]any{
+		"transfer_id":  transfer.ID,
+		"from_user_id": transfer.FromUserID,
+		"to_user_id":   transfer.ToUserID,
+		"status":       transfer.Status,
+	})
+	if err != nil {
+		ctxlogger.Get(ctx).Error("log ownership transfer",
+			zap.String("action", name),
+			zap.Uint64("transfer_id", transfer.ID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/account/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/account/usecase"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+type testTeamSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	memberRepo  *mocks.MockMemberRepository[*testutil.User, *testAccount]
+	teamRepo    *mocks.MockTeamRepository
+	teamUsecase account.TeamUsecase
+}
+
+func (s *testTeamSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.memberRepo = mocks.NewMockMemberRepository[*testutil.User, *testAccount](ctrl)
+	s.teamRepo = mocks.NewMockTeamRepository(ctrl)
+	s.teamUsecase = usecase.NewTeamUsecase(s.memberRepo, s.teamRepo)
+}
+
+func (s *testTeamSuite) team() *account.Team {
+	return &account.Team{ID: 3, AccountID: 1, Name: "devops"}
+}
+
+func (s *testTeamSuite) TestCreate() {
+	s.teamRepo.EXPECT().CreateTeam(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, team *account.Team) error {
+			s.Equal("devops", team.Name)
+			team.ID = 3
+			return nil
+		})
+	team, err := s.teamUsecase.Create(s.ctx, &account.Team{AccountID: 1, Name: " devops "})
+	s.NoError(err)
+	s.Equal(uint64(3), team.ID)
+}
+
+func (s *testTeamSuite) TestCreateEmptyName() {
+	_, err := s.teamUsecase.Create(s.ctx, &account.Team{AccountID: 1, Name: " "})
+	s.ErrorIs(err, account.ErrInvalidTeamName)
+}
+
+func (s *testTeamSuite) TestUpdateAccountChange() {
+	s.teamRepo.EXPECT().Team(s.ctx, uint64(3)).Return(s.team(), nil)
+	_, err := s.teamUsecase.Update(s.ctx, &account.Team{ID: 3, AccountID: 2, Name: "ops"})
+	s.ErrorIs(err, account.ErrTeamAccountChange)
+}
+
+func (s *testTeamSuite) TestSetRoles() {
+	s.teamRepo.EXPECT().Team(s.ctx, uint64(3)).Return(s.team(), nil)
+	s.teamRepo.EXPECT().SetTeamRoles(s.ctx, gomock.Any(), "reader", "writer").Return(nil)
+	_, err := s.teamUsecase.SetRoles(s.ctx, 3, "writer", "reader", "writer")
+	s.NoError(err)
+}
+
+func (s *testTeamSuite) TestAddMembers() {
+	s.teamRepo.EXPECT().Team(s.ctx, uint64(3)).Return(s.team(), nil)
+	s.memberRepo.EXPECT().CountMembers(s.ctx, &account.MemberFilter{
+		ID:        []uint64{10, 11},
+		AccountID: []uint64{1},
+	}).Return(int64(2), nil)
+	s.teamRepo.EXPECT().AddTeamMembers(s.ctx, uint64(3), uint64(10), uint64(11)).Return(nil)
+	_, err := s.teamUsecase.AddMembers(s.ctx, 3, 11, 10, 11)
+	s.NoError(err)
+}
+
+func (s *testTeamSuite) TestTeamUpdateOnly() {
+	ctx := testContextWithPermissions(s.ctx, &account.Team{}, "update.all")
+	s.Run("SetRoles", func() {
+		s.teamRepo.EXPECT().Team(ctx, uint64(3)).Return(s.team(), nil)
+		_, err := s.teamUsecase.SetRoles(ctx, 3, "admin")
+		s.ErrorIs(err, acl.ErrNoPermissions)
+	})
+	s.Run("AddMembers", func() {
+		s.teamRepo.EXPECT().Team(ctx, uint64(3)).Return(s.team(), nil)
+		_, err := s.teamUsecase.AddMembers(ctx, 3, 10)
+		s.ErrorIs(err, acl.ErrNoPermissions)
+	})
+	s.Run("RemoveMembers", func() {
+		s.teamRepo.EXPECT().Team(ctx, uint64(3)).Return(s.team(), nil)
+		s.teamRepo.EXPECT().RemoveTeamMembers(ctx, uint64(3), uint64(10)).Return(nil)
+		_, err := s.teamUsecase.RemoveMembers(ctx, 3, 10)
+		s.NoError(err)
+	})
+}
+
+func (s *testTeamSuite) TestAddMembersOtherAccount() {
+	s.teamRepo.EXPECT().Team(s.ctx, uint64(3)).Return(s.team(), nil)
+	s.memberRepo.EXPECT().CountMembers(s.ctx, gomock.Any()).Return(int64(1), nil)
+	_, err := s.teamUsecase.AddMembers(s.ctx, 3, 10, 20)
+	s.ErrorIs(err, account.ErrInvalidTeamMember)
+}
+
+func (s *testTeamSuite) TestRemoveMembers() {
+	s.teamRepo.EXPECT().Team(s.ctx, uint64(3)).Return(s.team(), nil)
+	s.teamRepo.EXPECT().RemoveTeamMembers(s.ctx, uint64(3), uint64(10)).Return(nil)
+	_, err := s.teamUsecase.RemoveMembers(s.ctx, 3, 10)
+	s.NoError(err)
+}
+
+func (s *testTeamSuite) TestDelete() {
+	s.teamRepo.EXPECT().Team(s.ctx, uint64(3)).Return(s.team(), nil)
+	s.teamRepo.EXPECT().DeleteTeam(s.ctx, uint64(3)).Return(nil)
+	team, err := s.teamUsecase.Delete(s.ctx, 3)
+	s.NoError(err)
+	s.Equal("devops", team.Name)
+}
+
+func TestTeamSuite(t *testing.T) {
+	suite.Run(t, new(testTeamSuite))
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+// TeamUsecase provides business logic of the account teams
+type TeamUsecase[TUser user.Model, TAccount account.Model] struct {
+	memberRepo account.MemberRepository[TUser, TAccount]
+	teamRepo   account.TeamRepository
+}
+
+// NewTeamUsecase object controller
+func NewTeamUsecase[TUser user.Model, TAccount account.Model](
+	memberRepo account.MemberRepository[TUser, TAccount],
+	teamRepo account.TeamRepository,
+) *TeamUsecase[TUser, TAccount] {
+	return &TeamUsecase[TUser, TAccount]{
+		memberRepo: memberRepo,
+		teamRepo:   teamRepo,
+	}
+}
+
+// Get the team by ID
+func (a *TeamUsecase[TUser, TAccount]) Get(ctx context.Context, id uint64) (*account.Team, error) {
+	team, err := a.teamRepo.Team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveAccessView(ctx, team) {
+		return nil, acl.ErrNoPermissions.WithMessage("view account team")
+	}
+	return team, nil
+}
+
+// FetchListTeams returns the teams, without the global permission
+// the list is limited by the current account
+func (a *TeamUsecase[TUser, TAccount]) FetchListTeams(ctx context.Context, opts ...account.QOption) (_ []*account.Team, err error) {
+	if !acl.HaveAccessList(ctx, &account.Team{}) {
+		if opts, err = account.ListOptions(opts).WithPermissions(ctx, &account.TeamFilter{}); err != nil {
+			return nil, errors.Wrap(acl.ErrNoPermissions, err.Error())
+		}
+	}
+	return a.teamRepo.FetchListTeams(ctx, opts...)
+}
+
+// CountTeams returns the count of the teams
+func (a *TeamUsecase[TUser, TAccount]) CountTeams(ctx context.Context, opts ...account.QOption) (_ int64, err error) {
+	if !acl.HaveAccessCount(ctx, &account.Team{}) {
+		if opts, err = account.ListOptions(opts).WithPermissions(ctx, &account.TeamFilter{}); err != nil {
+			return 0, errors.Wrap(acl.ErrNoPermissions, err.Error())
+		}
+	}
+	return a.teamRepo.CountTeams(ctx, opts...)
+}
+
+// Create the team in the account, the team is created in the session account by default
+func (a *TeamUsecase[TUser, TAccount]) Create(ctx context.Context, team *account.Team) (*account.Team, error) {
+	if team.AccountID == 0 {
+		team.AccountID = session.AccountID(ctx)
+	}
+	// The create permission doesn't check the owner, so the target account
+	// must be visible for the user as well
+	if !acl.HaveAccessCreate(ctx, team) || !acl.HaveAccessView(ctx, team) {
+		return nil, acl.ErrNoPermissions.WithMessage("create account team")
+	}
+	if team.Name = strings.TrimSpace(team.Name); team.Name == "" {
+		return nil, account.ErrInvalidTeamName
+	}
+	team.ID = 0
+	team.Roles = nil
+	if err := a.teamRepo.CreateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	writeTeamHistory(ctx, "account.team.create", team, nil)
+	return team, nil
+}
+
+// Update the name and the description of the team
+func (a *TeamUsecase[TUser, TAccount]) Update(ctx context.Context, team *account.Team) (*account.Team, error) {
+	current, err := a.updatable(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	if team.AccountID != 0 && team.AccountID != current.AccountID {
+		return nil, account.ErrTeamAccountChange
+	}
+	if team.Name = strings.TrimSpace(team.Name); team.Name == "" {
+		return nil, account.ErrInvalidTeamName
+	}
+	current.Name = team.Name
+	current.Description = team.Description
+	if err = a.teamRepo.UpdateTeam(ctx, current); err != nil {
+		return nil, err
+	}
+	writeTeamHistory(ctx, "account.team.update", current, nil)
+	return current, nil
+}
+
+// Delete the team, its members lose the team roles
+func (a *TeamUsecase[TUser, TAccount]) Delete(ctx context.Context, id uint64) (*account.Team, error) {
+	team, err := a.teamRepo.Team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveAccessDelete(ctx, team) {
+		return nil, acl.ErrNoPermissions.WithMessage("delete account team")
+	}
+	if err = a.teamRepo.DeleteTeam(ctx, id); err != nil {
+		return nil, err
+	}
+	writeTeamHistory(ctx, "account.team.delete", team, nil)
+	return team, nil
+}
+
+// SetRoles of the team by names, the roles must be global or belong to the team account
+func (a *TeamUsecase[TUser, TAccount]) SetRoles(ctx context.Context, id uint64, roles ...string) (*account.Team, error) {
+	team, err := a.rolesUpdatable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	roles = slices.Compact(slices.Sorted(slices.Values(roles)))
+	if err = a.teamRepo.SetTeamRoles(ctx, team, roles...); err != nil {
+		return nil, err
+	}
+	writeTeamHistory(ctx, "account.team.roles", team, map[string]any{"roles": roles})
+	return team, nil
+}
+
+// AddMembers to the team, all the members must belong to the team account.
+// The members get the team roles, so it's allowed only to the users who can set the member roles.
+func (a *TeamUsecase[TUser, TAccount]) AddMembers(ctx context.Context, id uint64, memberIDs ...uint64) (*account.Team, error) {
+	team, err := a.rolesUpdatable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs = slices.Compact(slices.Sorted(slices.Values(memberIDs)))
+	if len(memberIDs) == 0 {
+		return team, nil
+	}
+	count, err := a.memberRepo.CountMembers(ctx, &account.MemberFilter{
+		ID:        memberIDs,
+		AccountID: []uint64{team.AccountID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(memberIDs)) {
+		return nil, account.ErrInvalidTeamMember
+	}
+	if err = a.teamRepo.AddTeamMembers(ctx, team.ID, memberIDs...); err != nil {
+		return nil, err
+	}
+	writeTeamHistory(ctx, "account.team.members.add", team, map[string]any{"member_ids": memberIDs})
+	return team, nil
+}
+
+// RemoveMembers from the team
+func (a *TeamUsecase[TUser, TAccount]) RemoveMembers(ctx context.Context, id uint64, memberIDs ...uint64) (*account.Team, error) {
+	team, err := a.updatable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs = slices.Compact(slices.Sorted(slices.Values(memberIDs)))
+	if len(memberIDs) == 0 {
+		return team, nil
+	}
+	if err = a.teamRepo.RemoveTeamMembers(ctx, team.ID, memberIDs...); err != nil {
+		return nil, err
+	}
+	writeTeamHistory(ctx, "account.team.members.remove", team, map[string]any{"member_ids": memberIDs})
+	return team, nil
+}
+
+func (a *TeamUsecase[TUser, TAccount]) updatable(ctx context.Context, id uint64) (*account.Team, error) {
+	team, err := a.teamRepo.Team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveAccessUpdate(ctx, team) {
+		return nil, acl.ErrNoPermissions.WithMessage("update account team")
+	}
+	return team, nil
+}
+
+// rolesUpdatable returns the team if the user can change the roles of the team members
+func (a *TeamUsecase[TUser, TAccount]) rolesUpdatable(ctx context.Context, id uint64) (*account.Team, error) {
+	team, err := a.updatable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !acl.HaveObjectPermissions(ctx, account.MemberStub[TUser, TAccount](0, team.AccountID, 0), `roles.set.*`) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, "update member roles")
+	}
+	return team, nil
+}
+
+func writeTeamHistory(ctx context.Context, name string, team *account.Team, data map[string]any) {
+	if data == nil {
+		data = map[string]any{}
+	}
+	data["team_id"] = team.ID
+	data["name"] = team.Name
+	err := historylog.Write(ctx, name, "account", team.AccountID, data)
+	if err != nil {
+		ctxlogger.Get(ctx).Error("log account team",
+			zap.String("action", name),
+			zap.Uint64("team_id", team.ID),
+			zap.Error(err))
+	}
+}
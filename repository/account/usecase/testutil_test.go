@@ -1,10 +1,15 @@
 package usecase_test
 
 import (
+	"context"
+
+	"github.com/demdxx/rbac"
 	"github.com/geniusrabbit/gosql/v2"
 
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	accountModels "github.com/geniusrabbit/blaze-api/repository/account/models"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
 )
 
 type testAccount struct {
@@ -35,3 +40,15 @@ func testAccountStub(id uint64) *testAccount {
 	a.ID = id
 	return a
 }
+
+// testContextWithPermissions returns the session of the user 1 in the account 1
+// limited by the permissions on the resources
+func testContextWithPermissions(ctx context.Context, resource any, permissions ...string) context.Context {
+	perms := make([]any, 0, len(permissions))
+	for _, name := range permissions {
+		perms = append(perms, rbac.MustNewResourcePermission(name, resource))
+	}
+	acc := testAccountStub(1)
+	acc.SetPermissions(rbac.MustNewRole("test", rbac.WithPermissions(perms...)))
+	return session.WithUserAccount(ctx, testutil.Stub(1), acc)
+}
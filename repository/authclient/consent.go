@@ -0,0 +1,18 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrConsentRequestNotFound = errors.New(`consent request not found`)
+	ErrConsentRequestExpired  = errors.New(`consent request is expired`)
+	ErrConsentRequestDecided  = errors.New(`consent request is already decided`)
+	ErrConsentScopeNotAllowed = errors.New(`scope was not requested by the client`)
+)
+
+// SessionRevoker kills the tokens which were issued to the client on behalf of the user
+type SessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID uint64, clientID string) error
+}
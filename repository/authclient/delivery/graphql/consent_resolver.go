@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	authclientrepo "github.com/geniusrabbit/blaze-api/repository/authclient/repository"
+	authclientusecase "github.com/geniusrabbit/blaze-api/repository/authclient/usecase"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// ConsentQueryResolver implements GQL API methods of the OAuth2 consent
+type ConsentQueryResolver struct {
+	consents authclient.ConsentUsecase
+}
+
+// NewConsentQueryResolver returns new API resolver
+func NewConsentQueryResolver(uc authclient.ConsentUsecase) *ConsentQueryResolver {
+	return &ConsentQueryResolver{consents: uc}
+}
+
+// NewDefaultConsentQueryResolver returns new API resolver with default usecase,
+// the revoker kills the tokens of the revoked apps
+func NewDefaultConsentQueryResolver(revoker authclient.SessionRevoker) *ConsentQueryResolver {
+	return &ConsentQueryResolver{
+		consents: authclientusecase.NewConsentUsecase(
+			authclientrepo.NewConsentRepository(),
+			revoker,
+		),
+	}
+}
+
+// AuthConsentRequest is the resolver for the authConsentRequest field.
+func (r *ConsentQueryResolver) AuthConsentRequest(ctx context.Context, challenge string) (*gqlmodels.AuthConsentRequest, error) {
+	req, err := r.consents.ConsentRequest(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	return FromAuthConsentRequestModel(req), nil
+}
+
+// MyAuthorizedApps is the resolver for the myAuthorizedApps field.
+func (r *ConsentQueryResolver) MyAuthorizedApps(ctx context.Context) ([]*gqlmodels.AuthorizedApp, error) {
+	grants, err := r.consents.AuthorizedApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FromAuthGrantModelList(grants), nil
+}
+
+// AcceptAuthConsentRequest is the resolver for the acceptAuthConsentRequest field.
+func (r *ConsentQueryResolver) AcceptAuthConsentRequest(ctx context.Context, challenge string, scope []string, rememberFor *int) (*gqlmodels.AuthConsentPayload, error) {
+	rememberDuration := time.Duration(gocast.PtrAsValue(rememberFor, 0)) * time.Second
+	req, err := r.consents.AcceptConsentRequest(ctx, challenge, scope, rememberDuration)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlmodels.AuthConsentPayload{
+		ClientMutationID: requestid.Get(ctx),
+		RedirectTo:       req.RedirectURL(),
+	}, nil
+}
+
+// RejectAuthConsentRequest is the resolver for the rejectAuthConsentRequest field.
+func (r *ConsentQueryResolver) RejectAuthConsentRequest(ctx context.Context, challenge string) (*gqlmodels.AuthConsentPayload, error) {
+	req, err := r.consents.RejectConsentRequest(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlmodels.AuthConsentPayload{
+		ClientMutationID: requestid.Get(ctx),
+		RedirectTo:       req.RedirectURL(),
+	}, nil
+}
+
+// RevokeAuthorizedApp is the resolver for the revokeAuthorizedApp field.
+func (r *ConsentQueryResolver) RevokeAuthorizedApp(ctx context.Context, clientID string) (*gqlmodels.StatusResponse, error) {
+	if err := r.consents.RevokeAuthorizedApp(ctx, clientID); err != nil {
+		return nil, err
+	}
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           gqlmodels.ResponseStatusSuccess,
+		Message:          gocast.Ptr("application access revoked"),
+	}, nil
+}
@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	authclientrepo "github.com/geniusrabbit/blaze-api/repository/authclient/repository"
+	authclientusecase "github.com/geniusrabbit/blaze-api/repository/authclient/usecase"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// DeviceQueryResolver implements GQL API methods of the OAuth2 device authorization
+type DeviceQueryResolver struct {
+	devices authclient.DeviceUsecase
+}
+
+// NewDeviceQueryResolver returns new API resolver
+func NewDeviceQueryResolver(uc authclient.DeviceUsecase) *DeviceQueryResolver {
+	return &DeviceQueryResolver{devices: uc}
+}
+
+// NewDefaultDeviceQueryResolver returns new API resolver with default usecase
+func NewDefaultDeviceQueryResolver() *DeviceQueryResolver {
+	return &DeviceQueryResolver{
+		devices: authclientusecase.NewDeviceUsecase(authclientrepo.NewDeviceRepository()),
+	}
+}
+
+// AuthDeviceRequest is the resolver for the authDeviceRequest field.
+func (r *DeviceQueryResolver) AuthDeviceRequest(ctx context.Context, userCode string) (*gqlmodels.AuthDeviceRequest, error) {
+	req, err := r.devices.DeviceRequest(ctx, userCode)
+	if err != nil {
+		return nil, err
+	}
+	return FromAuthDeviceRequestModel(req), nil
+}
+
+// ApproveAuthDeviceRequest is the resolver for the approveAuthDeviceRequest field.
+func (r *DeviceQueryResolver) ApproveAuthDeviceRequest(ctx context.Context, userCode string) (*gqlmodels.StatusResponse, error) {
+	if _, err := r.devices.ApproveDeviceRequest(ctx, userCode); err != nil {
+		return nil, err
+	}
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           gqlmodels.ResponseStatusSuccess,
+		Message:          gocast.Ptr("device approved"),
+	}, nil
+}
+
+// DenyAuthDeviceRequest is the resolver for the denyAuthDeviceRequest field.
+func (r *DeviceQueryResolver) DenyAuthDeviceRequest(ctx context.Context, userCode string) (*gqlmodels.StatusResponse, error) {
+	if _, err := r.devices.DenyDeviceRequest(ctx, userCode); err != nil {
+		return nil, err
+	}
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           gqlmodels.ResponseStatusSuccess,
+		Message:          gocast.Ptr("device denied"),
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/demdxx/gocast/v2"
 	"github.com/demdxx/xtypes"
 
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
 	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
 	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
 )
@@ -15,7 +16,7 @@ func FromAuthClientModel(acc *models.AuthClient) *gqlmodels.AuthClient {
 	if acc == nil {
 		return nil
 	}
-	return &gqlmodels.AuthClient{
+	obj := &gqlmodels.AuthClient{
 		ID:                 acc.ID,
 		AccountID:          acc.AccountID,
 		UserID:             acc.UserID,
@@ -29,10 +30,22 @@ func FromAuthClientModel(acc *models.AuthClient) *gqlmodels.AuthClient {
 		SubjectType:        acc.SubjectType,
 		AllowedCORSOrigins: acc.AllowedCORSOrigins,
 		Public:             acc.Public,
-		CreatedAt:          acc.CreatedAt,
-		UpdatedAt:          acc.UpdatedAt,
-		DeletedAt:          gqlmodels.DeletedAt(acc.DeletedAt),
+		RequirePkce:        acc.RequirePKCE,
+
+		TokenEndpointAuthMethod:     acc.TokenEndpointAuthMethod,
+		TokenEndpointAuthSigningAlg: acc.TokenEndpointAuthSigningAlg,
+		JwksURI:                     acc.JWKSURI,
+		Jwks:                        acc.JWKS,
+
+		ExpiresAt: acc.ExpiresAt,
+		CreatedAt: acc.CreatedAt,
+		UpdatedAt: acc.UpdatedAt,
+		DeletedAt: gqlmodels.DeletedAt(acc.DeletedAt),
+	}
+	if acc.PreviousSecretExpiresAt.Valid {
+		obj.PreviousSecretExpiresAt = &acc.PreviousSecretExpiresAt.V
 	}
+	return obj
 }
 
 // FromAuthClientModelList converts model list to local model list
@@ -40,6 +53,68 @@ func FromAuthClientModelList(list []*models.AuthClient) []*gqlmodels.AuthClient
 	return xtypes.SliceApply(list, FromAuthClientModel)
 }
 
+// FromAuthConsentRequestModel to local graphql model
+func FromAuthConsentRequestModel(req *models.AuthConsentRequest) *gqlmodels.AuthConsentRequest {
+	if req == nil {
+		return nil
+	}
+	obj := &gqlmodels.AuthConsentRequest{
+		Challenge:         req.Challenge,
+		ClientID:          req.ClientID,
+		RequestedScope:    req.RequestedScope,
+		RequestedAudience: req.RequestedAudience,
+		ExpiresAt:         req.ExpiresAt,
+	}
+	if req.Client != nil {
+		obj.ClientTitle = req.Client.Title
+	}
+	return obj
+}
+
+// FromAuthDeviceRequestModel to local graphql model
+func FromAuthDeviceRequestModel(req *models.AuthDeviceRequest) *gqlmodels.AuthDeviceRequest {
+	if req == nil {
+		return nil
+	}
+	obj := &gqlmodels.AuthDeviceRequest{
+		UserCode:          authclient.FormatUserCode(req.UserCode),
+		ClientID:          req.ClientID,
+		RequestedScope:    req.RequestedScope,
+		RequestedAudience: req.RequestedAudience,
+		ExpiresAt:         req.ExpiresAt,
+	}
+	if req.Client != nil {
+		obj.ClientTitle = req.Client.Title
+	}
+	return obj
+}
+
+// FromAuthGrantModel to local graphql model
+func FromAuthGrantModel(grant *models.AuthGrant) *gqlmodels.AuthorizedApp {
+	if grant == nil {
+		return nil
+	}
+	obj := &gqlmodels.AuthorizedApp{
+		ClientID:  grant.ClientID,
+		Scope:     grant.Scope,
+		Audience:  grant.Audience,
+		CreatedAt: grant.CreatedAt,
+		UpdatedAt: grant.UpdatedAt,
+	}
+	if grant.ExpiresAt.Valid {
+		obj.ExpiresAt = &grant.ExpiresAt.V
+	}
+	if grant.Client != nil {
+		obj.Title = grant.Client.Title
+	}
+	return obj
+}
+
+// FromAuthGrantModelList converts model list to local model list
+func FromAuthGrantModelList(list []*models.AuthGrant) []*gqlmodels.AuthorizedApp {
+	return xtypes.SliceApply(list, FromAuthGrantModel)
+}
+
 // CreateFillModel fills adasset model from create input.
 func CreateFillModel(inp *gqlmodels.AuthClientCreateInput, obj *models.AuthClient) *models.AuthClient {
 	obj.ID = ""
@@ -55,6 +130,11 @@ func CreateFillModel(inp *gqlmodels.AuthClientCreateInput, obj *models.AuthClien
 	obj.SubjectType = inp.SubjectType
 	obj.AllowedCORSOrigins = inp.AllowedCORSOrigins
 	obj.Public = gocast.PtrAsValue(inp.Public, false)
+	obj.RequirePKCE = gocast.PtrAsValue(inp.RequirePkce, false)
+	obj.TokenEndpointAuthMethod = gocast.PtrAsValue(inp.TokenEndpointAuthMethod, "")
+	obj.TokenEndpointAuthSigningAlg = gocast.PtrAsValue(inp.TokenEndpointAuthSigningAlg, "")
+	obj.JWKSURI = gocast.PtrAsValue(inp.JwksURI, "")
+	obj.JWKS = gocast.PtrAsValue(inp.Jwks, "")
 	obj.ExpiresAt = gocast.PtrAsValue(inp.ExpiresAt, time.Time{})
 	return obj
 }
@@ -83,5 +163,33 @@ func UpdateFillModel(inp *gqlmodels.AuthClientUpdateInput, obj *models.AuthClien
 		obj.AllowedCORSOrigins = inp.AllowedCORSOrigins
 	}
 	obj.Public = gocast.PtrAsValue(inp.Public, obj.Public)
+	obj.RequirePKCE = gocast.PtrAsValue(inp.RequirePkce, obj.RequirePKCE)
+	obj.TokenEndpointAuthMethod = gocast.PtrAsValue(inp.TokenEndpointAuthMethod, obj.TokenEndpointAuthMethod)
+	obj.TokenEndpointAuthSigningAlg = gocast.PtrAsValue(inp.TokenEndpointAuthSigningAlg, obj.TokenEndpointAuthSigningAlg)
+	obj.JWKSURI = gocast.PtrAsValue(inp.JwksURI, obj.JWKSURI)
+	obj.JWKS = gocast.PtrAsValue(inp.Jwks, obj.JWKS)
 	obj.ExpiresAt = gocast.PtrAsValue(inp.ExpiresAt, obj.ExpiresAt)
 }
+
+// FromAuthInitialAccessTokenModel to local graphql model
+func FromAuthInitialAccessTokenModel(token *models.AuthInitialAccessToken) *gqlmodels.AuthInitialAccessToken {
+	if token == nil {
+		return nil
+	}
+	return &gqlmodels.AuthInitialAccessToken{
+		ID:          token.ID,
+		AccountID:   token.AccountID,
+		UserID:      token.UserID,
+		Description: token.Description,
+		Scope:       token.Scope,
+		MaxClients:  token.MaxClients,
+		ClientCount: token.ClientCount,
+		ExpiresAt:   token.ExpiresAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+// FromAuthInitialAccessTokenModelList converts model list to local model list
+func FromAuthInitialAccessTokenModelList(list []*models.AuthInitialAccessToken) []*gqlmodels.AuthInitialAccessToken {
+	return xtypes.SliceApply(list, FromAuthInitialAccessTokenModel)
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/demdxx/gocast/v2"
+
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
+	authclientrepo "github.com/geniusrabbit/blaze-api/repository/authclient/repository"
+	authclientusecase "github.com/geniusrabbit/blaze-api/repository/authclient/usecase"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// RegistrationQueryResolver implements GQL API methods of the initial access tokens
+// of the dynamic client registration
+type RegistrationQueryResolver struct {
+	registrations authclient.RegistrationUsecase
+}
+
+// NewRegistrationQueryResolver returns new API resolver
+func NewRegistrationQueryResolver(uc authclient.RegistrationUsecase) *RegistrationQueryResolver {
+	return &RegistrationQueryResolver{registrations: uc}
+}
+
+// NewDefaultRegistrationQueryResolver returns new API resolver with default usecase
+func NewDefaultRegistrationQueryResolver() *RegistrationQueryResolver {
+	return &RegistrationQueryResolver{
+		registrations: authclientusecase.NewRegistrationUsecase(authclientrepo.NewRegistrationRepository()),
+	}
+}
+
+// ListAuthInitialAccessTokens is the resolver for the listAuthInitialAccessTokens field.
+func (r *RegistrationQueryResolver) ListAuthInitialAccessTokens(ctx context.Context) ([]*gqlmodels.AuthInitialAccessToken, error) {
+	list, err := r.registrations.InitialAccessTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FromAuthInitialAccessTokenModelList(list), nil
+}
+
+// CreateAuthInitialAccessToken is the resolver for the createAuthInitialAccessToken field.
+func (r *RegistrationQueryResolver) CreateAuthInitialAccessToken(ctx context.Context, input *gqlmodels.AuthInitialAccessTokenCreateInput) (*gqlmodels.AuthInitialAccessTokenPayload, error) {
+	token := &models.AuthInitialAccessToken{
+		Description: gocast.PtrAsValue(input.Description, ""),
+		Scope:       gocast.PtrAsValue(input.Scope, ""),
+		MaxClients:  gocast.PtrAsValue(input.MaxClients, 0),
+		ExpiresAt:   input.ExpiresAt,
+	}
+	value, err := r.registrations.CreateInitialAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlmodels.AuthInitialAccessTokenPayload{
+		ClientMutationID:   requestid.Get(ctx),
+		Token:              value,
+		InitialAccessToken: FromAuthInitialAccessTokenModel(token),
+	}, nil
+}
+
+// RevokeAuthInitialAccessToken is the resolver for the revokeAuthInitialAccessToken field.
+func (r *RegistrationQueryResolver) RevokeAuthInitialAccessToken(ctx context.Context, id uint64) (*gqlmodels.StatusResponse, error) {
+	if err := r.registrations.RevokeInitialAccessToken(ctx, id); err != nil {
+		return nil, err
+	}
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           gqlmodels.ResponseStatusSuccess,
+		Message:          gocast.Ptr("initial access token revoked"),
+	}, nil
+}
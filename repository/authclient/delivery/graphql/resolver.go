@@ -2,6 +2,9 @@ package graphql
 
 import (
 	"context"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
 
 	"github.com/geniusrabbit/blaze-api/pkg/requestid"
 	"github.com/geniusrabbit/blaze-api/repository/authclient"
@@ -102,3 +105,22 @@ func (r *QueryResolver) DeleteAuthClient(ctx context.Context, id string, msg *st
 		AuthClientID:     id,
 	}, nil
 }
+
+// RotateAuthClientSecret is the resolver for the rotateAuthClientSecret field.
+func (r *QueryResolver) RotateAuthClientSecret(ctx context.Context, id string, gracePeriod *int) (*gqlmodels.AuthClientSecretPayload, error) {
+	secret, err := r.authClients.RotateSecret(ctx, id,
+		time.Duration(gocast.PtrAsValue(gracePeriod, 0))*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	client, err := r.authClients.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlmodels.AuthClientSecretPayload{
+		ClientMutationID:        requestid.Get(ctx),
+		AuthClientID:            id,
+		Secret:                  secret,
+		PreviousSecretExpiresAt: FromAuthClientModel(client).PreviousSecretExpiresAt,
+	}, nil
+}
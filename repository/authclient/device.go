@@ -0,0 +1,41 @@
+package authclient
+
+import (
+	"errors"
+	"strings"
+)
+
+// UserCodeLength is the number of characters of the user code without the separator
+const UserCodeLength = 8
+
+var (
+	ErrDeviceRequestNotFound = errors.New(`device request not found`)
+	ErrDeviceRequestExpired  = errors.New(`device request is expired`)
+	ErrDeviceRequestDecided  = errors.New(`device request is already decided`)
+
+	// ErrDeviceRequestImpersonated is returned on the decision in the impersonated session,
+	// the approved device would get the regular tokens of the impersonated user
+	ErrDeviceRequestImpersonated = errors.New(`device request can't be decided in the impersonated session`)
+)
+
+// NormalizeUserCode returns the user code as it's stored:
+// the case and the separators typed by the user are ignored
+func NormalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return -1
+	}, code)
+}
+
+// FormatUserCode splits the user code into two halves to make it easier to read
+func FormatUserCode(code string) string {
+	if len(code) != UserCodeLength {
+		return code
+	}
+	return code[:UserCodeLength/2] + "-" + code[UserCodeLength/2:]
+}
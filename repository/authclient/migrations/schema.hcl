@@ -60,6 +60,34 @@ table "auth_client" {
     null = true
     type = boolean
   }
+  column "require_pkce" {
+    null = true
+    type = boolean
+  }
+  column "previous_secret" {
+    null = false
+    type = text
+  }
+  column "previous_secret_expires_at" {
+    null = true
+    type = timestamptz
+  }
+  column "token_endpoint_auth_method" {
+    null = false
+    type = text
+  }
+  column "token_endpoint_auth_signing_alg" {
+    null = false
+    type = text
+  }
+  column "jwks_uri" {
+    null = false
+    type = text
+  }
+  column "jwks" {
+    null = false
+    type = text
+  }
   column "expires_at" {
     null = true
     type = timestamptz
@@ -104,6 +132,14 @@ table "auth_session" {
     null = true
     type = text
   }
+  column "user_id" {
+    null = true
+    type = bigint
+  }
+  column "account_id" {
+    null = true
+    type = bigint
+  }
   column "request_id" {
     null = true
     type = text
@@ -144,6 +180,90 @@ table "auth_session" {
     null = true
     type = sql("text[]")
   }
+  column "data" {
+    null = true
+    type = jsonb
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "deleted_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+}
+
+table "auth_request" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "kind" {
+    null = false
+    type = text
+  }
+  column "active" {
+    null = true
+    type = boolean
+  }
+  column "signature" {
+    null = false
+    type = text
+  }
+  column "request_id" {
+    null = true
+    type = text
+  }
+  column "client_id" {
+    null = true
+    type = text
+  }
+  column "user_id" {
+    null = true
+    type = bigint
+  }
+  column "account_id" {
+    null = true
+    type = bigint
+  }
+  column "form" {
+    null = true
+    type = text
+  }
+  column "requested_scope" {
+    null = true
+    type = sql("text[]")
+  }
+  column "granted_scope" {
+    null = true
+    type = sql("text[]")
+  }
+  column "requested_audience" {
+    null = true
+    type = sql("text[]")
+  }
+  column "granted_audience" {
+    null = true
+    type = sql("text[]")
+  }
+  column "data" {
+    null = true
+    type = jsonb
+  }
+  column "requested_at" {
+    null = true
+    type = timestamptz
+  }
+  column "expires_at" {
+    null = true
+    type = timestamptz
+  }
   column "created_at" {
     null = true
     type = timestamptz
@@ -155,4 +275,298 @@ table "auth_session" {
   primary_key {
     columns = [column.id]
   }
+  index "idx_auth_request_kind_signature" {
+    columns = [column.kind, column.signature]
+  }
+}
+
+table "auth_client_assertion" {
+  schema = schema.public
+
+  column "jti" {
+    null = false
+    type = text
+  }
+  column "expires_at" {
+    null = false
+    type = timestamptz
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.jti]
+  }
+  index "idx_auth_client_assertion_expires_at" {
+    columns = [column.expires_at]
+  }
+}
+
+table "auth_grant" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "user_id" {
+    null = false
+    type = bigint
+  }
+  column "client_id" {
+    null = false
+    type = text
+  }
+  column "scope" {
+    null = true
+    type = sql("text[]")
+  }
+  column "audience" {
+    null = true
+    type = sql("text[]")
+  }
+  column "expires_at" {
+    null = true
+    type = timestamptz
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  column "deleted_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_auth_grant_user_client" {
+    columns = [column.user_id, column.client_id]
+  }
+}
+
+table "auth_consent_request" {
+  schema = schema.public
+
+  column "challenge" {
+    null = false
+    type = text
+  }
+  column "status" {
+    null = false
+    type = text
+  }
+  column "user_id" {
+    null = false
+    type = bigint
+  }
+  column "account_id" {
+    null = true
+    type = bigint
+  }
+  column "client_id" {
+    null = false
+    type = text
+  }
+  column "requested_scope" {
+    null = true
+    type = sql("text[]")
+  }
+  column "requested_audience" {
+    null = true
+    type = sql("text[]")
+  }
+  column "granted_scope" {
+    null = true
+    type = sql("text[]")
+  }
+  column "granted_audience" {
+    null = true
+    type = sql("text[]")
+  }
+  column "request_url" {
+    null = false
+    type = text
+  }
+  column "expires_at" {
+    null = false
+    type = timestamptz
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.challenge]
+  }
+  index "idx_auth_consent_request_expires_at" {
+    columns = [column.expires_at]
+  }
+}
+
+table "auth_device_request" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "status" {
+    null = false
+    type = text
+  }
+  column "device_code" {
+    null = false
+    type = text
+  }
+  column "user_code" {
+    null = false
+    type = text
+  }
+  column "request_id" {
+    null = false
+    type = text
+  }
+  column "client_id" {
+    null = false
+    type = text
+  }
+  column "user_id" {
+    null = true
+    type = bigint
+  }
+  column "account_id" {
+    null = true
+    type = bigint
+  }
+  column "form" {
+    null = true
+    type = text
+  }
+  column "requested_scope" {
+    null = true
+    type = sql("text[]")
+  }
+  column "granted_scope" {
+    null = true
+    type = sql("text[]")
+  }
+  column "requested_audience" {
+    null = true
+    type = sql("text[]")
+  }
+  column "granted_audience" {
+    null = true
+    type = sql("text[]")
+  }
+  column "poll_interval" {
+    null = false
+    type = integer
+  }
+  column "last_polled_at" {
+    null = true
+    type = timestamptz
+  }
+  column "expires_at" {
+    null = false
+    type = timestamptz
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  column "deleted_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_auth_device_request_device_code" {
+    unique  = true
+    columns = [column.device_code]
+  }
+  index "idx_auth_device_request_user_code" {
+    unique  = true
+    columns = [column.user_code]
+  }
+  index "idx_auth_device_request_expires_at" {
+    columns = [column.expires_at]
+  }
+}
+
+table "auth_initial_access_token" {
+  schema = schema.public
+
+  column "id" {
+    null = false
+    type = bigserial
+  }
+  column "token" {
+    null = false
+    type = text
+  }
+  column "description" {
+    null = false
+    type = text
+  }
+  column "account_id" {
+    null = false
+    type = bigint
+  }
+  column "user_id" {
+    null = false
+    type = bigint
+  }
+  column "scope" {
+    null = false
+    type = text
+  }
+  column "max_clients" {
+    null = false
+    type = integer
+  }
+  column "client_count" {
+    null = false
+    type = integer
+  }
+  column "expires_at" {
+    null = false
+    type = timestamptz
+  }
+  column "created_at" {
+    null = true
+    type = timestamptz
+  }
+  column "updated_at" {
+    null = true
+    type = timestamptz
+  }
+  column "deleted_at" {
+    null = true
+    type = timestamptz
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_auth_initial_access_token_token" {
+    unique  = true
+    columns = [column.token]
+  }
+  index "idx_auth_initial_access_token_account_id" {
+    columns = [column.account_id]
+  }
 }
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	authclient "github.com/geniusrabbit/blaze-api/repository/authclient"
 	gomock "go.uber.org/mock/gomock"
@@ -135,6 +136,20 @@ func (mr *MockRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, id)
 }
 
+// RotateSecret mocks base method.
+func (m *MockRepository) RotateSecret(ctx context.Context, id, secretHash string, previousExpiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSecret", ctx, id, secretHash, previousExpiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSecret indicates an expected call of RotateSecret.
+func (mr *MockRepositoryMockRecorder) RotateSecret(ctx, id, secretHash, previousExpiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MockRepository)(nil).RotateSecret), ctx, id, secretHash, previousExpiresAt)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, id string, authClient *authclient.AuthClient, opts ...authclient.QOption) error {
 	m.ctrl.T.Helper()
@@ -153,3 +168,307 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, id, authClient any, opts ...an
 	varargs := append([]any{ctx, id, authClient}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), varargs...)
 }
+
+// MockConsentRepository is a mock of ConsentRepository interface.
+type MockConsentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentRepositoryMockRecorder is the mock recorder for MockConsentRepository.
+type MockConsentRepositoryMockRecorder struct {
+	mock *MockConsentRepository
+}
+
+// NewMockConsentRepository creates a new mock instance.
+func NewMockConsentRepository(ctrl *gomock.Controller) *MockConsentRepository {
+	mock := &MockConsentRepository{ctrl: ctrl}
+	mock.recorder = &MockConsentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentRepository) EXPECT() *MockConsentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateConsentRequest mocks base method.
+func (m *MockConsentRepository) CreateConsentRequest(ctx context.Context, req *authclient.AuthConsentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConsentRequest", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateConsentRequest indicates an expected call of CreateConsentRequest.
+func (mr *MockConsentRepositoryMockRecorder) CreateConsentRequest(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConsentRequest", reflect.TypeOf((*MockConsentRepository)(nil).CreateConsentRequest), ctx, req)
+}
+
+// DecideConsentRequest mocks base method.
+func (m *MockConsentRepository) DecideConsentRequest(ctx context.Context, req *authclient.AuthConsentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideConsentRequest", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecideConsentRequest indicates an expected call of DecideConsentRequest.
+func (mr *MockConsentRepositoryMockRecorder) DecideConsentRequest(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideConsentRequest", reflect.TypeOf((*MockConsentRepository)(nil).DecideConsentRequest), ctx, req)
+}
+
+// DeleteGrant mocks base method.
+func (m *MockConsentRepository) DeleteGrant(ctx context.Context, userID uint64, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGrant", ctx, userID, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGrant indicates an expected call of DeleteGrant.
+func (mr *MockConsentRepositoryMockRecorder) DeleteGrant(ctx, userID, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGrant", reflect.TypeOf((*MockConsentRepository)(nil).DeleteGrant), ctx, userID, clientID)
+}
+
+// FetchGrants mocks base method.
+func (m *MockConsentRepository) FetchGrants(ctx context.Context, userID uint64) ([]*authclient.AuthGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchGrants", ctx, userID)
+	ret0, _ := ret[0].([]*authclient.AuthGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchGrants indicates an expected call of FetchGrants.
+func (mr *MockConsentRepositoryMockRecorder) FetchGrants(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchGrants", reflect.TypeOf((*MockConsentRepository)(nil).FetchGrants), ctx, userID)
+}
+
+// GetConsentRequest mocks base method.
+func (m *MockConsentRepository) GetConsentRequest(ctx context.Context, challenge string) (*authclient.AuthConsentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsentRequest", ctx, challenge)
+	ret0, _ := ret[0].(*authclient.AuthConsentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsentRequest indicates an expected call of GetConsentRequest.
+func (mr *MockConsentRepositoryMockRecorder) GetConsentRequest(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsentRequest", reflect.TypeOf((*MockConsentRepository)(nil).GetConsentRequest), ctx, challenge)
+}
+
+// GetGrant mocks base method.
+func (m *MockConsentRepository) GetGrant(ctx context.Context, userID uint64, clientID string) (*authclient.AuthGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrant", ctx, userID, clientID)
+	ret0, _ := ret[0].(*authclient.AuthGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrant indicates an expected call of GetGrant.
+func (mr *MockConsentRepositoryMockRecorder) GetGrant(ctx, userID, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrant", reflect.TypeOf((*MockConsentRepository)(nil).GetGrant), ctx, userID, clientID)
+}
+
+// SaveGrant mocks base method.
+func (m *MockConsentRepository) SaveGrant(ctx context.Context, grant *authclient.AuthGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGrant", ctx, grant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGrant indicates an expected call of SaveGrant.
+func (mr *MockConsentRepositoryMockRecorder) SaveGrant(ctx, grant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGrant", reflect.TypeOf((*MockConsentRepository)(nil).SaveGrant), ctx, grant)
+}
+
+// UseConsentRequest mocks base method.
+func (m *MockConsentRepository) UseConsentRequest(ctx context.Context, challenge string) (*authclient.AuthConsentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseConsentRequest", ctx, challenge)
+	ret0, _ := ret[0].(*authclient.AuthConsentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseConsentRequest indicates an expected call of UseConsentRequest.
+func (mr *MockConsentRepositoryMockRecorder) UseConsentRequest(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseConsentRequest", reflect.TypeOf((*MockConsentRepository)(nil).UseConsentRequest), ctx, challenge)
+}
+
+// MockDeviceRepository is a mock of DeviceRepository interface.
+type MockDeviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceRepositoryMockRecorder is the mock recorder for MockDeviceRepository.
+type MockDeviceRepositoryMockRecorder struct {
+	mock *MockDeviceRepository
+}
+
+// NewMockDeviceRepository creates a new mock instance.
+func NewMockDeviceRepository(ctrl *gomock.Controller) *MockDeviceRepository {
+	mock := &MockDeviceRepository{ctrl: ctrl}
+	mock.recorder = &MockDeviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceRepository) EXPECT() *MockDeviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DecideDeviceRequest mocks base method.
+func (m *MockDeviceRepository) DecideDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideDeviceRequest", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecideDeviceRequest indicates an expected call of DecideDeviceRequest.
+func (mr *MockDeviceRepositoryMockRecorder) DecideDeviceRequest(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideDeviceRequest", reflect.TypeOf((*MockDeviceRepository)(nil).DecideDeviceRequest), ctx, req)
+}
+
+// GetDeviceRequest mocks base method.
+func (m *MockDeviceRepository) GetDeviceRequest(ctx context.Context, userCode string) (*authclient.AuthDeviceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceRequest", ctx, userCode)
+	ret0, _ := ret[0].(*authclient.AuthDeviceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceRequest indicates an expected call of GetDeviceRequest.
+func (mr *MockDeviceRepositoryMockRecorder) GetDeviceRequest(ctx, userCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceRequest", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceRequest), ctx, userCode)
+}
+
+// MockRegistrationRepository is a mock of RegistrationRepository interface.
+type MockRegistrationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistrationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRegistrationRepositoryMockRecorder is the mock recorder for MockRegistrationRepository.
+type MockRegistrationRepositoryMockRecorder struct {
+	mock *MockRegistrationRepository
+}
+
+// NewMockRegistrationRepository creates a new mock instance.
+func NewMockRegistrationRepository(ctrl *gomock.Controller) *MockRegistrationRepository {
+	mock := &MockRegistrationRepository{ctrl: ctrl}
+	mock.recorder = &MockRegistrationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistrationRepository) EXPECT() *MockRegistrationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateInitialAccessToken mocks base method.
+func (m *MockRegistrationRepository) CreateInitialAccessToken(ctx context.Context, token *authclient.AuthInitialAccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInitialAccessToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInitialAccessToken indicates an expected call of CreateInitialAccessToken.
+func (mr *MockRegistrationRepositoryMockRecorder) CreateInitialAccessToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInitialAccessToken", reflect.TypeOf((*MockRegistrationRepository)(nil).CreateInitialAccessToken), ctx, token)
+}
+
+// DeleteInitialAccessToken mocks base method.
+func (m *MockRegistrationRepository) DeleteInitialAccessToken(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInitialAccessToken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInitialAccessToken indicates an expected call of DeleteInitialAccessToken.
+func (mr *MockRegistrationRepositoryMockRecorder) DeleteInitialAccessToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInitialAccessToken", reflect.TypeOf((*MockRegistrationRepository)(nil).DeleteInitialAccessToken), ctx, id)
+}
+
+// FetchInitialAccessTokens mocks base method.
+func (m *MockRegistrationRepository) FetchInitialAccessTokens(ctx context.Context, accountID uint64) ([]*authclient.AuthInitialAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchInitialAccessTokens", ctx, accountID)
+	ret0, _ := ret[0].([]*authclient.AuthInitialAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchInitialAccessTokens indicates an expected call of FetchInitialAccessTokens.
+func (mr *MockRegistrationRepositoryMockRecorder) FetchInitialAccessTokens(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInitialAccessTokens", reflect.TypeOf((*MockRegistrationRepository)(nil).FetchInitialAccessTokens), ctx, accountID)
+}
+
+// GetInitialAccessToken mocks base method.
+func (m *MockRegistrationRepository) GetInitialAccessToken(ctx context.Context, id uint64) (*authclient.AuthInitialAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitialAccessToken", ctx, id)
+	ret0, _ := ret[0].(*authclient.AuthInitialAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInitialAccessToken indicates an expected call of GetInitialAccessToken.
+func (mr *MockRegistrationRepositoryMockRecorder) GetInitialAccessToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitialAccessToken", reflect.TypeOf((*MockRegistrationRepository)(nil).GetInitialAccessToken), ctx, id)
+}
+
+// GetInitialAccessTokenByHash mocks base method.
+func (m *MockRegistrationRepository) GetInitialAccessTokenByHash(ctx context.Context, tokenHash string) (*authclient.AuthInitialAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitialAccessTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*authclient.AuthInitialAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInitialAccessTokenByHash indicates an expected call of GetInitialAccessTokenByHash.
+func (mr *MockRegistrationRepositoryMockRecorder) GetInitialAccessTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitialAccessTokenByHash", reflect.TypeOf((*MockRegistrationRepository)(nil).GetInitialAccessTokenByHash), ctx, tokenHash)
+}
+
+// RegisterClient mocks base method.
+func (m *MockRegistrationRepository) RegisterClient(ctx context.Context, tokenID uint64, client *authclient.AuthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClient", ctx, tokenID, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterClient indicates an expected call of RegisterClient.
+func (mr *MockRegistrationRepositoryMockRecorder) RegisterClient(ctx, tokenID, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClient", reflect.TypeOf((*MockRegistrationRepository)(nil).RegisterClient), ctx, tokenID, client)
+}
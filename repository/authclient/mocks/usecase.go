@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	authclient "github.com/geniusrabbit/blaze-api/repository/authclient"
 	gomock "go.uber.org/mock/gomock"
@@ -135,6 +136,21 @@ func (mr *MockUsecaseMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsecase)(nil).Get), ctx, id)
 }
 
+// RotateSecret mocks base method.
+func (m *MockUsecase) RotateSecret(ctx context.Context, id string, gracePeriod time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSecret", ctx, id, gracePeriod)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSecret indicates an expected call of RotateSecret.
+func (mr *MockUsecaseMockRecorder) RotateSecret(ctx, id, gracePeriod any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MockUsecase)(nil).RotateSecret), ctx, id, gracePeriod)
+}
+
 // Update mocks base method.
 func (m *MockUsecase) Update(ctx context.Context, id string, authClient *authclient.AuthClient, opts ...authclient.QOption) error {
 	m.ctrl.T.Helper()
@@ -153,3 +169,253 @@ func (mr *MockUsecaseMockRecorder) Update(ctx, id, authClient any, opts ...any)
 	varargs := append([]any{ctx, id, authClient}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUsecase)(nil).Update), varargs...)
 }
+
+// MockConsentUsecase is a mock of ConsentUsecase interface.
+type MockConsentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentUsecaseMockRecorder is the mock recorder for MockConsentUsecase.
+type MockConsentUsecaseMockRecorder struct {
+	mock *MockConsentUsecase
+}
+
+// NewMockConsentUsecase creates a new mock instance.
+func NewMockConsentUsecase(ctrl *gomock.Controller) *MockConsentUsecase {
+	mock := &MockConsentUsecase{ctrl: ctrl}
+	mock.recorder = &MockConsentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentUsecase) EXPECT() *MockConsentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AcceptConsentRequest mocks base method.
+func (m *MockConsentUsecase) AcceptConsentRequest(ctx context.Context, challenge string, scopes []string, rememberFor time.Duration) (*authclient.AuthConsentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptConsentRequest", ctx, challenge, scopes, rememberFor)
+	ret0, _ := ret[0].(*authclient.AuthConsentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptConsentRequest indicates an expected call of AcceptConsentRequest.
+func (mr *MockConsentUsecaseMockRecorder) AcceptConsentRequest(ctx, challenge, scopes, rememberFor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptConsentRequest", reflect.TypeOf((*MockConsentUsecase)(nil).AcceptConsentRequest), ctx, challenge, scopes, rememberFor)
+}
+
+// AuthorizedApps mocks base method.
+func (m *MockConsentUsecase) AuthorizedApps(ctx context.Context) ([]*authclient.AuthGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizedApps", ctx)
+	ret0, _ := ret[0].([]*authclient.AuthGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizedApps indicates an expected call of AuthorizedApps.
+func (mr *MockConsentUsecaseMockRecorder) AuthorizedApps(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizedApps", reflect.TypeOf((*MockConsentUsecase)(nil).AuthorizedApps), ctx)
+}
+
+// ConsentRequest mocks base method.
+func (m *MockConsentUsecase) ConsentRequest(ctx context.Context, challenge string) (*authclient.AuthConsentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsentRequest", ctx, challenge)
+	ret0, _ := ret[0].(*authclient.AuthConsentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsentRequest indicates an expected call of ConsentRequest.
+func (mr *MockConsentUsecaseMockRecorder) ConsentRequest(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsentRequest", reflect.TypeOf((*MockConsentUsecase)(nil).ConsentRequest), ctx, challenge)
+}
+
+// RejectConsentRequest mocks base method.
+func (m *MockConsentUsecase) RejectConsentRequest(ctx context.Context, challenge string) (*authclient.AuthConsentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectConsentRequest", ctx, challenge)
+	ret0, _ := ret[0].(*authclient.AuthConsentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectConsentRequest indicates an expected call of RejectConsentRequest.
+func (mr *MockConsentUsecaseMockRecorder) RejectConsentRequest(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectConsentRequest", reflect.TypeOf((*MockConsentUsecase)(nil).RejectConsentRequest), ctx, challenge)
+}
+
+// RevokeAuthorizedApp mocks base method.
+func (m *MockConsentUsecase) RevokeAuthorizedApp(ctx context.Context, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAuthorizedApp", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAuthorizedApp indicates an expected call of RevokeAuthorizedApp.
+func (mr *MockConsentUsecaseMockRecorder) RevokeAuthorizedApp(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAuthorizedApp", reflect.TypeOf((*MockConsentUsecase)(nil).RevokeAuthorizedApp), ctx, clientID)
+}
+
+// MockDeviceUsecase is a mock of DeviceUsecase interface.
+type MockDeviceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceUsecaseMockRecorder is the mock recorder for MockDeviceUsecase.
+type MockDeviceUsecaseMockRecorder struct {
+	mock *MockDeviceUsecase
+}
+
+// NewMockDeviceUsecase creates a new mock instance.
+func NewMockDeviceUsecase(ctrl *gomock.Controller) *MockDeviceUsecase {
+	mock := &MockDeviceUsecase{ctrl: ctrl}
+	mock.recorder = &MockDeviceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceUsecase) EXPECT() *MockDeviceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ApproveDeviceRequest mocks base method.
+func (m *MockDeviceUsecase) ApproveDeviceRequest(ctx context.Context, userCode string) (*authclient.AuthDeviceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveDeviceRequest", ctx, userCode)
+	ret0, _ := ret[0].(*authclient.AuthDeviceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveDeviceRequest indicates an expected call of ApproveDeviceRequest.
+func (mr *MockDeviceUsecaseMockRecorder) ApproveDeviceRequest(ctx, userCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveDeviceRequest", reflect.TypeOf((*MockDeviceUsecase)(nil).ApproveDeviceRequest), ctx, userCode)
+}
+
+// DenyDeviceRequest mocks base method.
+func (m *MockDeviceUsecase) DenyDeviceRequest(ctx context.Context, userCode string) (*authclient.AuthDeviceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyDeviceRequest", ctx, userCode)
+	ret0, _ := ret[0].(*authclient.AuthDeviceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DenyDeviceRequest indicates an expected call of DenyDeviceRequest.
+func (mr *MockDeviceUsecaseMockRecorder) DenyDeviceRequest(ctx, userCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyDeviceRequest", reflect.TypeOf((*MockDeviceUsecase)(nil).DenyDeviceRequest), ctx, userCode)
+}
+
+// DeviceRequest mocks base method.
+func (m *MockDeviceUsecase) DeviceRequest(ctx context.Context, userCode string) (*authclient.AuthDeviceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeviceRequest", ctx, userCode)
+	ret0, _ := ret[0].(*authclient.AuthDeviceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeviceRequest indicates an expected call of DeviceRequest.
+func (mr *MockDeviceUsecaseMockRecorder) DeviceRequest(ctx, userCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceRequest", reflect.TypeOf((*MockDeviceUsecase)(nil).DeviceRequest), ctx, userCode)
+}
+
+// MockRegistrationUsecase is a mock of RegistrationUsecase interface.
+type MockRegistrationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistrationUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockRegistrationUsecaseMockRecorder is the mock recorder for MockRegistrationUsecase.
+type MockRegistrationUsecaseMockRecorder struct {
+	mock *MockRegistrationUsecase
+}
+
+// NewMockRegistrationUsecase creates a new mock instance.
+func NewMockRegistrationUsecase(ctrl *gomock.Controller) *MockRegistrationUsecase {
+	mock := &MockRegistrationUsecase{ctrl: ctrl}
+	mock.recorder = &MockRegistrationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistrationUsecase) EXPECT() *MockRegistrationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateInitialAccessToken mocks base method.
+func (m *MockRegistrationUsecase) CreateInitialAccessToken(ctx context.Context, token *authclient.AuthInitialAccessToken) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInitialAccessToken", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInitialAccessToken indicates an expected call of CreateInitialAccessToken.
+func (mr *MockRegistrationUsecaseMockRecorder) CreateInitialAccessToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInitialAccessToken", reflect.TypeOf((*MockRegistrationUsecase)(nil).CreateInitialAccessToken), ctx, token)
+}
+
+// InitialAccessTokens mocks base method.
+func (m *MockRegistrationUsecase) InitialAccessTokens(ctx context.Context) ([]*authclient.AuthInitialAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitialAccessTokens", ctx)
+	ret0, _ := ret[0].([]*authclient.AuthInitialAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InitialAccessTokens indicates an expected call of InitialAccessTokens.
+func (mr *MockRegistrationUsecaseMockRecorder) InitialAccessTokens(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitialAccessTokens", reflect.TypeOf((*MockRegistrationUsecase)(nil).InitialAccessTokens), ctx)
+}
+
+// RegisterClient mocks base method.
+func (m *MockRegistrationUsecase) RegisterClient(ctx context.Context, initialAccessToken string, client *authclient.AuthClient) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClient", ctx, initialAccessToken, client)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterClient indicates an expected call of RegisterClient.
+func (mr *MockRegistrationUsecaseMockRecorder) RegisterClient(ctx, initialAccessToken, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClient", reflect.TypeOf((*MockRegistrationUsecase)(nil).RegisterClient), ctx, initialAccessToken, client)
+}
+
+// RevokeInitialAccessToken mocks base method.
+func (m *MockRegistrationUsecase) RevokeInitialAccessToken(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInitialAccessToken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInitialAccessToken indicates an expected call of RevokeInitialAccessToken.
+func (mr *MockRegistrationUsecaseMockRecorder) RevokeInitialAccessToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInitialAccessToken", reflect.TypeOf((*MockRegistrationUsecase)(nil).RevokeInitialAccessToken), ctx, id)
+}
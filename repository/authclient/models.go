@@ -5,4 +5,52 @@ import "github.com/geniusrabbit/blaze-api/repository/authclient/models"
 type (
 	AuthClient  = models.AuthClient
 	AuthSession = models.AuthSession
+	AuthRequest = models.AuthRequest
+
+	AuthClientAssertion = models.AuthClientAssertion
+	AuthGrant           = models.AuthGrant
+	AuthConsentRequest  = models.AuthConsentRequest
+	AuthDeviceRequest   = models.AuthDeviceRequest
+
+	AuthInitialAccessToken = models.AuthInitialAccessToken
+)
+
+// Token endpoint authentication methods of the clients
+const (
+	TokenEndpointAuthSecretBasic = models.TokenEndpointAuthSecretBasic
+	TokenEndpointAuthSecretPost  = models.TokenEndpointAuthSecretPost
+	TokenEndpointAuthPrivateKey  = models.TokenEndpointAuthPrivateKey
+	TokenEndpointAuthNone        = models.TokenEndpointAuthNone
+)
+
+// Kinds of the authorization requests
+const (
+	AuthRequestKindCode   = models.AuthRequestKindCode
+	AuthRequestKindPKCE   = models.AuthRequestKindPKCE
+	AuthRequestKindOpenID = models.AuthRequestKindOpenID
+)
+
+// Subject types of the OpenID Connect clients
+const (
+	SubjectTypePublic   = models.SubjectTypePublic
+	SubjectTypePairwise = models.SubjectTypePairwise
+)
+
+// Statuses of the consent request
+const (
+	ConsentStatusPending  = models.ConsentStatusPending
+	ConsentStatusAccepted = models.ConsentStatusAccepted
+	ConsentStatusRejected = models.ConsentStatusRejected
+	ConsentStatusUsed     = models.ConsentStatusUsed
+)
+
+// ConsentChallengeParam is the query parameter of the consent challenge
+const ConsentChallengeParam = models.ConsentChallengeParam
+
+// Statuses of the device authorization request
+const (
+	DeviceStatusPending  = models.DeviceStatusPending
+	DeviceStatusApproved = models.DeviceStatusApproved
+	DeviceStatusDenied   = models.DeviceStatusDenied
+	DeviceStatusUsed     = models.DeviceStatusUsed
 )
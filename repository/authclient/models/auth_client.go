@@ -1,12 +1,28 @@
 package models
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/geniusrabbit/gosql/v2"
 	"gorm.io/gorm"
 )
 
+// Subject types of the OpenID Connect clients
+const (
+	SubjectTypePublic   = "public"
+	SubjectTypePairwise = "pairwise"
+)
+
+// Token endpoint authentication methods of the clients,
+// the empty method accepts any secret based method
+const (
+	TokenEndpointAuthSecretBasic = "client_secret_basic"
+	TokenEndpointAuthSecretPost  = "client_secret_post"
+	TokenEndpointAuthPrivateKey  = "private_key_jwt"
+	TokenEndpointAuthNone        = "none"
+)
+
 // AuthClient object represents an OAuth 2.0 client
 type AuthClient struct {
 	// ClientID is the client ID which represents unique connection indentificator
@@ -24,6 +40,21 @@ type AuthClient struct {
 	// that they need to write the secret down as it will not be made available again.
 	Secret string `db:"secret"`
 
+	// PreviousSecret is the hash of the rotated secret which is accepted
+	// together with the new one until PreviousSecretExpiresAt
+	PreviousSecret          string              `db:"previous_secret"`
+	PreviousSecretExpiresAt sql.Null[time.Time] `db:"previous_secret_expires_at"`
+
+	// TokenEndpointAuthMethod requested for the token endpoint (RFC 7591),
+	// `private_key_jwt` requires the JWKS URI or the inline JWKS of the client
+	TokenEndpointAuthMethod     string `db:"token_endpoint_auth_method"`
+	TokenEndpointAuthSigningAlg string `db:"token_endpoint_auth_signing_alg"`
+
+	// JWKSURI is the URL of the public keys of the client, JWKS is the inline
+	// JSON Web Key Set, only one of them can be used
+	JWKSURI string `db:"jwks_uri" gorm:"column:jwks_uri"`
+	JWKS    string `db:"jwks" gorm:"column:jwks"`
+
 	// RedirectURIs is an array of allowed redirect urls for the client, for example http://mydomain/oauth/callback .
 	RedirectURIs gosql.NullableStringArray `db:"redirect_uris" gorm:"type:text[]"`
 
@@ -63,6 +94,10 @@ type AuthClient struct {
 	// Public flag tells that the client is public
 	Public bool `db:"public"`
 
+	// RequirePKCE forces the authorization code flow with PKCE (S256),
+	// public clients require it regardless of the flag
+	RequirePKCE bool `db:"require_pkce"`
+
 	// ExpiresAt contins the time of expiration of the client
 	ExpiresAt time.Time `db:"expires_at"`
 
@@ -81,6 +116,24 @@ func (m *AuthClient) OwnerAccountID() uint64 {
 	return m.AccountID
 }
 
+// IsPairwiseSubject returns true if the client receives pairwise subject identifiers
+func (m *AuthClient) IsPairwiseSubject() bool {
+	return m.SubjectType == SubjectTypePairwise
+}
+
+// IsPKCERequired returns true if the client has to use PKCE in the authorization code flow
+func (m *AuthClient) IsPKCERequired() bool {
+	return m.Public || m.RequirePKCE
+}
+
+// RotatedSecrets returns the hashes of the previous secrets which are still accepted
+func (m *AuthClient) RotatedSecrets(now time.Time) []string {
+	if m.PreviousSecret == "" || !m.PreviousSecretExpiresAt.Valid || !m.PreviousSecretExpiresAt.V.After(now) {
+		return nil
+	}
+	return []string{m.PreviousSecret}
+}
+
 // RBACResourceName returns the name of the resource for the RBAC
 func (m *AuthClient) RBACResourceName() string {
 	return `auth_client`
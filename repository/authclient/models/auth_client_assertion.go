@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AuthClientAssertion keeps the JWT ID of the used client assertion
+// until the assertion expires, so it can't be replayed
+type AuthClientAssertion struct {
+	JTI       string    `db:"jti" gorm:"column:jti;primaryKey"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TableName in database
+func (m *AuthClientAssertion) TableName() string {
+	return `auth_client_assertion`
+}
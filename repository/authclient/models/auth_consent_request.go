@@ -0,0 +1,76 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// Statuses of the consent request
+const (
+	ConsentStatusPending  = "pending"
+	ConsentStatusAccepted = "accepted"
+	ConsentStatusRejected = "rejected"
+	ConsentStatusUsed     = "used"
+)
+
+// ConsentChallengeParam is the query parameter which passes the consent challenge
+// to the consent page and back to the authorization endpoint
+const ConsentChallengeParam = "consent_challenge"
+
+// AuthConsentRequest is the authorization request waiting for the user decision.
+// The consent page renders it by the challenge and the authorization endpoint
+// continues the request after it's accepted or rejected.
+type AuthConsentRequest struct {
+	Challenge string `db:"challenge" gorm:"primaryKey"`
+	Status    string `db:"status"`
+
+	UserID    uint64 `db:"user_id"`
+	AccountID uint64 `db:"account_id"`
+	ClientID  string `db:"client_id"`
+
+	Client *AuthClient `db:"-" gorm:"foreignKey:ClientID"`
+
+	RequestedScope    gosql.NullableStringArray `db:"requested_scope" gorm:"type:text[]"`
+	RequestedAudience gosql.NullableStringArray `db:"requested_audience" gorm:"type:text[]"`
+	GrantedScope      gosql.NullableStringArray `db:"granted_scope" gorm:"type:text[]"`
+	GrantedAudience   gosql.NullableStringArray `db:"granted_audience" gorm:"type:text[]"`
+
+	// RequestURL of the authorization endpoint to continue the request
+	RequestURL string `db:"request_url"`
+
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TableName in database
+func (m *AuthConsentRequest) TableName() string {
+	return `auth_consent_request`
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (m *AuthConsentRequest) RBACResourceName() string {
+	return `auth_consent`
+}
+
+// CreatorUserID returns the user who has to decide
+func (m *AuthConsentRequest) CreatorUserID() uint64 {
+	return m.UserID
+}
+
+// IsExpired returns true if the request can't be decided or continued anymore
+func (m *AuthConsentRequest) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.After(now)
+}
+
+// RedirectURL returns the authorization URL which continues the request with the decision
+func (m *AuthConsentRequest) RedirectURL() string {
+	sep := "?"
+	if strings.Contains(m.RequestURL, "?") {
+		sep = "&"
+	}
+	return m.RequestURL + sep + ConsentChallengeParam + "=" + url.QueryEscape(m.Challenge)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+)
+
+// Statuses of the device authorization request
+const (
+	DeviceStatusPending  = "pending"
+	DeviceStatusApproved = "approved"
+	DeviceStatusDenied   = "denied"
+	DeviceStatusUsed     = "used"
+)
+
+// AuthDeviceRequest keeps the device authorization (RFC 8628) of the client.
+// The client polls the token endpoint with the device code
+// until the user enters the user code and approves the request.
+type AuthDeviceRequest struct {
+	ID     uint64 `db:"id"`
+	Status string `db:"status"`
+
+	// DeviceCode is the signature of the code the client polls the token with
+	DeviceCode string `db:"device_code"`
+
+	// UserCode is the normalized code the user enters on the verification page
+	UserCode string `db:"user_code"`
+
+	RequestID string `db:"request_id"`
+	ClientID  string `db:"client_id"`
+
+	Client *AuthClient `db:"-" gorm:"foreignKey:ClientID"`
+
+	// UserID and AccountID of the user who approved the request
+	UserID    uint64 `db:"user_id"`
+	AccountID uint64 `db:"account_id"`
+
+	Form              string                    `db:"form"`
+	RequestedScope    gosql.NullableStringArray `db:"requested_scope" gorm:"type:text[]"`
+	GrantedScope      gosql.NullableStringArray `db:"granted_scope" gorm:"type:text[]"`
+	RequestedAudience gosql.NullableStringArray `db:"requested_audience" gorm:"type:text[]"`
+	GrantedAudience   gosql.NullableStringArray `db:"granted_audience" gorm:"type:text[]"`
+
+	// PollInterval is the minimal time between the token requests in seconds
+	PollInterval int                 `db:"poll_interval"`
+	LastPolledAt sql.Null[time.Time] `db:"last_polled_at"`
+
+	ExpiresAt time.Time      `db:"expires_at"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	DeletedAt gorm.DeletedAt `db:"deleted_at"`
+}
+
+// TableName in database
+func (m *AuthDeviceRequest) TableName() string {
+	return `auth_device_request`
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (m *AuthDeviceRequest) RBACResourceName() string {
+	return `auth_device`
+}
+
+// CreatorUserID returns the user who approved the request
+func (m *AuthDeviceRequest) CreatorUserID() uint64 {
+	return m.UserID
+}
+
+// IsExpired returns true if the request can't be approved or exchanged anymore
+func (m *AuthDeviceRequest) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.After(now)
+}
+
+// IsPolledTooFast returns true if the client doesn't wait the poll interval
+func (m *AuthDeviceRequest) IsPolledTooFast(now time.Time) bool {
+	return m.LastPolledAt.Valid &&
+		now.Before(m.LastPolledAt.V.Add(time.Duration(m.PollInterval)*time.Second))
+}
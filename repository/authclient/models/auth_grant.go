@@ -0,0 +1,65 @@
+package models
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+)
+
+// AuthGrant remembers the scopes the user granted to the client,
+// the consent step is skipped while the grant covers the requested scopes
+type AuthGrant struct {
+	ID       uint64 `db:"id"`
+	UserID   uint64 `db:"user_id"`
+	ClientID string `db:"client_id"`
+
+	Client *AuthClient `db:"-" gorm:"foreignKey:ClientID"`
+
+	Scope    gosql.NullableStringArray `db:"scope" gorm:"type:text[]"`
+	Audience gosql.NullableStringArray `db:"audience" gorm:"type:text[]"`
+
+	// ExpiresAt of the grant, NULL means the grant never expires
+	ExpiresAt sql.Null[time.Time] `db:"expires_at"`
+
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	DeletedAt gorm.DeletedAt `db:"deleted_at"`
+}
+
+// TableName in database
+func (m *AuthGrant) TableName() string {
+	return `auth_grant`
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (m *AuthGrant) RBACResourceName() string {
+	return `auth_grant`
+}
+
+// CreatorUserID returns the user who granted the access
+func (m *AuthGrant) CreatorUserID() uint64 {
+	return m.UserID
+}
+
+// IsExpired returns true if the grant can't be used anymore
+func (m *AuthGrant) IsExpired(now time.Time) bool {
+	return m.ExpiresAt.Valid && !m.ExpiresAt.V.After(now)
+}
+
+// Covers returns true if all scopes and audiences are granted
+func (m *AuthGrant) Covers(scopes, audience []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(m.Scope, scope) {
+			return false
+		}
+	}
+	for _, aud := range audience {
+		if !slices.Contains(m.Audience, aud) {
+			return false
+		}
+	}
+	return true
+}
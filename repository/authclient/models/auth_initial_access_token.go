@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuthInitialAccessToken authorizes the dynamic client registration (RFC 7591).
+// The registered clients belong to the account of the token.
+type AuthInitialAccessToken struct {
+	ID uint64 `db:"id"`
+
+	// Token contains the SHA-256 hash of the token, the plain value is
+	// returned only once on the creation
+	Token string `db:"token"`
+
+	Description string `db:"description"`
+
+	// Owner and creator of the token and of the registered clients
+	AccountID uint64 `db:"account_id"`
+	UserID    uint64 `db:"user_id"`
+
+	// Scope is the space-separated list of the scopes the registered clients
+	// can request, the empty scope doesn't restrict them
+	Scope string `db:"scope"`
+
+	// MaxClients is the number of the clients which can be registered by the token (zero - unlimited)
+	MaxClients  int `db:"max_clients"`
+	ClientCount int `db:"client_count"`
+
+	ExpiresAt time.Time      `db:"expires_at"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	DeletedAt gorm.DeletedAt `db:"deleted_at"`
+}
+
+// TableName in database
+func (m *AuthInitialAccessToken) TableName() string {
+	return `auth_initial_access_token`
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (m *AuthInitialAccessToken) RBACResourceName() string {
+	return `auth_initial_access_token`
+}
+
+// OwnerAccountID returns the account ID which belongs the object
+func (m *AuthInitialAccessToken) OwnerAccountID() uint64 {
+	return m.AccountID
+}
+
+// CreatorUserID returns the user who created the token
+func (m *AuthInitialAccessToken) CreatorUserID() uint64 {
+	return m.UserID
+}
+
+// IsExpired returns true if the token can't register clients anymore
+func (m *AuthInitialAccessToken) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.After(now)
+}
+
+// IsExhausted returns true if all allowed clients are registered
+func (m *AuthInitialAccessToken) IsExhausted() bool {
+	return m.MaxClients > 0 && m.ClientCount >= m.MaxClients
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+)
+
+// Kinds of the authorization requests
+const (
+	AuthRequestKindCode   = "code"
+	AuthRequestKindPKCE   = "pkce"
+	AuthRequestKindOpenID = "oidc"
+)
+
+// AuthRequest keeps the short-living state of the authorization request
+// (authorization code, PKCE challenge, OpenID Connect session)
+// until it is exchanged for the access token
+type AuthRequest struct {
+	ID     uint64 `db:"id"`
+	Kind   string `db:"kind"`
+	Active bool   `db:"active"`
+
+	// Signature of the code the request is stored for
+	Signature string `db:"signature"`
+	RequestID string `db:"request_id"`
+	ClientID  string `db:"client_id"`
+
+	UserID    uint64 `db:"user_id"`
+	AccountID uint64 `db:"account_id"`
+
+	Form              string                    `db:"form"`
+	RequestedScope    gosql.NullableStringArray `db:"requested_scope" gorm:"type:text[]"`
+	GrantedScope      gosql.NullableStringArray `db:"granted_scope" gorm:"type:text[]"`
+	RequestedAudience gosql.NullableStringArray `db:"requested_audience" gorm:"type:text[]"`
+	GrantedAudience   gosql.NullableStringArray `db:"granted_audience" gorm:"type:text[]"`
+
+	// Data keeps the serialized provider session
+	Data gosql.NullableJSON[map[string]any] `db:"data" gorm:"type:jsonb"`
+
+	RequestedAt time.Time      `db:"requested_at"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	DeletedAt   gorm.DeletedAt `db:"deleted_at"`
+}
+
+// TableName in database
+func (m *AuthRequest) TableName() string {
+	return `auth_request`
+}
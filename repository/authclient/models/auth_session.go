@@ -18,6 +18,11 @@ type AuthSession struct {
 	Username string `db:"username"`
 	Subject  string `db:"subject"`
 
+	// UserID and AccountID of the resource owner who authorized the client,
+	// empty for the client credentials grant where the client acts on its own behalf
+	UserID    uint64 `db:"user_id"`
+	AccountID uint64 `db:"account_id"`
+
 	RequestID string `db:"request_id"`
 
 	// AccessToken is the main access token for the session
@@ -32,6 +37,9 @@ type AuthSession struct {
 	RequestedAudience gosql.NullableStringArray `db:"requested_audience" gorm:"type:text[]"`
 	GrantedAudience   gosql.NullableStringArray `db:"granted_audience" gorm:"type:text[]"`
 
+	// Data keeps the serialized provider session (ID token claims and headers)
+	Data gosql.NullableJSON[map[string]any] `db:"data" gorm:"type:jsonb"`
+
 	CreatedAt time.Time      `db:"created_at"`
 	DeletedAt gorm.DeletedAt `db:"deleted_at"`
 }
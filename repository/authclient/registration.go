@@ -0,0 +1,60 @@
+package authclient
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// InitialAccessTokenPrefix marks the initial access tokens of the client registration
+const InitialAccessTokenPrefix = "iat_"
+
+// secretSize is the number of the random bytes of the generated secrets and tokens
+const secretSize = 32
+
+var (
+	ErrInitialAccessTokenInvalid = errors.New(`initial access token is invalid or expired`)
+	ErrScopeNotAllowed           = errors.New(`scope is not allowed by the initial access token`)
+	ErrInvalidGracePeriod        = errors.New(`grace period of the secret rotation can't be negative`)
+	ErrPublicClientSecret        = errors.New(`public client has no secret`)
+	ErrInvalidExpiration         = errors.New(`expiration time must be in the future`)
+)
+
+// GenerateSecret returns the new random client secret and its hash to store
+func GenerateSecret() (secret, hash string, err error) {
+	if secret, err = randomString(); err != nil {
+		return "", "", err
+	}
+	data, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", err
+	}
+	return secret, string(data), nil
+}
+
+// GenerateInitialAccessToken returns the new random initial access token
+func GenerateInitialAccessToken() (string, error) {
+	token, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	return InitialAccessTokenPrefix + token, nil
+}
+
+// HashInitialAccessToken returns the SHA-256 hash of the token which is stored in the database
+func HashInitialAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString() (string, error) {
+	data := make([]byte, secretSize)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
@@ -3,6 +3,7 @@ package authclient
 
 import (
 	"context"
+	"time"
 )
 
 // Repository defines the interface for AuthClient data access operations.
@@ -26,4 +27,67 @@ type Repository interface {
 
 	// Delete removes an AuthClient by ID.
 	Delete(ctx context.Context, id string, opts ...QOption) error
+
+	// RotateSecret replaces the secret hash of the AuthClient, the current hash
+	// is kept as the previous one until previousExpiresAt
+	RotateSecret(ctx context.Context, id, secretHash string, previousExpiresAt time.Time) error
+}
+
+// ConsentRepository keeps the consent requests and the grants of the users
+type ConsentRepository interface {
+	// CreateConsentRequest stores the new pending consent request
+	CreateConsentRequest(ctx context.Context, req *AuthConsentRequest) error
+
+	// GetConsentRequest returns the consent request by the challenge with the client
+	GetConsentRequest(ctx context.Context, challenge string) (*AuthConsentRequest, error)
+
+	// DecideConsentRequest saves the status and the granted scopes of the pending request
+	DecideConsentRequest(ctx context.Context, req *AuthConsentRequest) error
+
+	// UseConsentRequest marks the decided request as used and returns it,
+	// every request continues the authorization only once
+	UseConsentRequest(ctx context.Context, challenge string) (*AuthConsentRequest, error)
+
+	// GetGrant returns the active grant of the user to the client or nil
+	GetGrant(ctx context.Context, userID uint64, clientID string) (*AuthGrant, error)
+
+	// FetchGrants returns all active grants of the user with the clients
+	FetchGrants(ctx context.Context, userID uint64) ([]*AuthGrant, error)
+
+	// SaveGrant adds the scopes and the audience to the grant of the user to the client
+	SaveGrant(ctx context.Context, grant *AuthGrant) error
+
+	// DeleteGrant removes the grant of the user to the client
+	DeleteGrant(ctx context.Context, userID uint64, clientID string) error
+}
+
+// DeviceRepository keeps the device authorization requests waiting for the user decision
+type DeviceRepository interface {
+	// GetDeviceRequest returns the device request by the user code with the client
+	GetDeviceRequest(ctx context.Context, userCode string) (*AuthDeviceRequest, error)
+
+	// DecideDeviceRequest saves the status, the user and the granted scopes of the pending request
+	DecideDeviceRequest(ctx context.Context, req *AuthDeviceRequest) error
+}
+
+// RegistrationRepository keeps the initial access tokens of the dynamic client registration
+type RegistrationRepository interface {
+	// GetInitialAccessToken returns the token by ID
+	GetInitialAccessToken(ctx context.Context, id uint64) (*AuthInitialAccessToken, error)
+
+	// GetInitialAccessTokenByHash returns the token by the hash of its value
+	GetInitialAccessTokenByHash(ctx context.Context, tokenHash string) (*AuthInitialAccessToken, error)
+
+	// FetchInitialAccessTokens returns the tokens of the account (all if zero)
+	FetchInitialAccessTokens(ctx context.Context, accountID uint64) ([]*AuthInitialAccessToken, error)
+
+	// CreateInitialAccessToken stores the new token
+	CreateInitialAccessToken(ctx context.Context, token *AuthInitialAccessToken) error
+
+	// DeleteInitialAccessToken removes the token by ID
+	DeleteInitialAccessToken(ctx context.Context, id uint64) error
+
+	// RegisterClient counts the client in the token and creates it in the same transaction,
+	// returns ErrInitialAccessTokenInvalid if the token is expired or exhausted
+	RegisterClient(ctx context.Context, tokenID uint64, client *AuthClient) error
 }
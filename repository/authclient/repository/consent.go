@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// ConsentRepository DAO of the consent requests and the user grants
+type ConsentRepository struct {
+	repository.Repository
+}
+
+// NewConsentRepository creates a new instance of the consent repository
+func NewConsentRepository() *ConsentRepository {
+	return &ConsentRepository{}
+}
+
+// CreateConsentRequest stores the new pending consent request
+func (r *ConsentRepository) CreateConsentRequest(ctx context.Context, req *authclient.AuthConsentRequest) error {
+	if req.Challenge == "" {
+		req.Challenge = newID()
+	}
+	req.Status = authclient.ConsentStatusPending
+	req.CreatedAt = time.Now()
+	req.UpdatedAt = req.CreatedAt
+	return r.Master(ctx).Omit("Client").Create(req).Error
+}
+
+// GetConsentRequest returns the consent request by the challenge with the client
+func (r *ConsentRepository) GetConsentRequest(ctx context.Context, challenge string) (*authclient.AuthConsentRequest, error) {
+	req := new(authclient.AuthConsentRequest)
+	err := r.Slave(ctx).Preload("Client").First(req, `challenge=?`, challenge).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, authclient.ErrConsentRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecideConsentRequest saves the status and the granted scopes of the pending request
+func (r *ConsentRepository) DecideConsentRequest(ctx context.Context, req *authclient.AuthConsentRequest) error {
+	res := r.Master(ctx).Model((*authclient.AuthConsentRequest)(nil)).
+		Where(`challenge=? AND status=?`, req.Challenge, authclient.ConsentStatusPending).
+		Updates(map[string]any{
+			`status`:           req.Status,
+			`granted_scope`:    req.GrantedScope,
+			`granted_audience`: req.GrantedAudience,
+			`updated_at`:       time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return authclient.ErrConsentRequestDecided
+	}
+	return nil
+}
+
+// UseConsentRequest marks the decided request as used and returns it
+func (r *ConsentRepository) UseConsentRequest(ctx context.Context, challenge string) (*authclient.AuthConsentRequest, error) {
+	req := new(authclient.AuthConsentRequest)
+	err := r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.First(req, `challenge=?`, challenge).Error; err != nil {
+			return err
+		}
+		res := tx.Model(req).
+			Where(`status IN (?)`, []string{authclient.ConsentStatusAccepted, authclient.ConsentStatusRejected}).
+			Updates(map[string]any{`status`: authclient.ConsentStatusUsed, `updated_at`: time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, authclient.ErrConsentRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetGrant returns the active grant of the user to the client or nil
+func (r *ConsentRepository) GetGrant(ctx context.Context, userID uint64, clientID string) (*authclient.AuthGrant, error) {
+	var grants []*authclient.AuthGrant
+	err := r.Slave(ctx).
+		Where(`user_id=? AND client_id=?`, userID, clientID).
+		Limit(1).Find(&grants).Error
+	if err != nil || len(grants) == 0 {
+		return nil, err
+	}
+	return grants[0], nil
+}
+
+// FetchGrants returns all active grants of the user with the clients
+func (r *ConsentRepository) FetchGrants(ctx context.Context, userID uint64) ([]*authclient.AuthGrant, error) {
+	var grants []*authclient.AuthGrant
+	err := r.Slave(ctx).Preload("Client").
+		Where(`user_id=?`, userID).
+		Order(`updated_at DESC`).
+		Find(&grants).Error
+	return grants, err
+}
+
+// SaveGrant adds the scopes and the audience to the grant of the user to the client.
+// The expiration is replaced by the new one.
+func (r *ConsentRepository) SaveGrant(ctx context.Context, grant *authclient.AuthGrant) error {
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		var current []*authclient.AuthGrant
+		err := tx.Where(`user_id=? AND client_id=?`, grant.UserID, grant.ClientID).
+			Limit(1).Find(&current).Error
+		if err != nil {
+			return err
+		}
+		grant.UpdatedAt = time.Now()
+		if len(current) == 0 {
+			grant.CreatedAt = grant.UpdatedAt
+			return tx.Omit("Client").Create(grant).Error
+		}
+		grant.ID = current[0].ID
+		grant.CreatedAt = current[0].CreatedAt
+		grant.Scope = mergeValues(current[0].Scope, grant.Scope)
+		grant.Audience = mergeValues(current[0].Audience, grant.Audience)
+		return tx.Model(current[0]).Updates(map[string]any{
+			`scope`:      grant.Scope,
+			`audience`:   grant.Audience,
+			`expires_at`: grant.ExpiresAt,
+			`updated_at`: grant.UpdatedAt,
+		}).Error
+	})
+}
+
+// DeleteGrant removes the grant of the user to the client
+func (r *ConsentRepository) DeleteGrant(ctx context.Context, userID uint64, clientID string) error {
+	return r.Master(ctx).
+		Where(`user_id=? AND client_id=?`, userID, clientID).
+		Delete(&authclient.AuthGrant{}).Error
+}
+
+func mergeValues(current, values []string) []string {
+	res := slices.Clone(current)
+	for _, v := range values {
+		if !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
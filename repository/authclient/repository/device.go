@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+)
+
+// DeviceRepository DAO of the device authorization requests
+type DeviceRepository struct {
+	repository.Repository
+}
+
+// NewDeviceRepository creates a new instance of the device repository
+func NewDeviceRepository() *DeviceRepository {
+	return &DeviceRepository{}
+}
+
+// GetDeviceRequest returns the device request by the user code with the client
+func (r *DeviceRepository) GetDeviceRequest(ctx context.Context, userCode string) (*authclient.AuthDeviceRequest, error) {
+	req := new(authclient.AuthDeviceRequest)
+	err := r.Slave(ctx).Preload("Client").
+		First(req, `user_code=?`, authclient.NormalizeUserCode(userCode)).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, authclient.ErrDeviceRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecideDeviceRequest saves the status, the user and the granted scopes of the pending request
+func (r *DeviceRepository) DecideDeviceRequest(ctx context.Context, req *authclient.AuthDeviceRequest) error {
+	res := r.Master(ctx).Model((*authclient.AuthDeviceRequest)(nil)).
+		Where(`id=? AND status=?`, req.ID, authclient.DeviceStatusPending).
+		Updates(map[string]any{
+			`status`:           req.Status,
+			`user_id`:          req.UserID,
+			`account_id`:       req.AccountID,
+			`granted_scope`:    req.GrantedScope,
+			`granted_audience`: req.GrantedAudience,
+			`updated_at`:       time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return authclient.ErrDeviceRequestDecided
+	}
+	return nil
+}
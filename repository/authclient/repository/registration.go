@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+)
+
+// RegistrationRepository DAO of the initial access tokens of the client registration
+type RegistrationRepository struct {
+	repository.Repository
+}
+
+// NewRegistrationRepository creates a new instance of the registration repository
+func NewRegistrationRepository() *RegistrationRepository {
+	return &RegistrationRepository{}
+}
+
+// GetInitialAccessToken returns the token by ID
+func (r *RegistrationRepository) GetInitialAccessToken(ctx context.Context, id uint64) (*authclient.AuthInitialAccessToken, error) {
+	token := new(authclient.AuthInitialAccessToken)
+	if err := r.Slave(ctx).First(token, id).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// GetInitialAccessTokenByHash returns the token by the hash of its value
+func (r *RegistrationRepository) GetInitialAccessTokenByHash(ctx context.Context, tokenHash string) (*authclient.AuthInitialAccessToken, error) {
+	token := new(authclient.AuthInitialAccessToken)
+	err := r.Master(ctx).First(token, `token=?`, tokenHash).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, authclient.ErrInitialAccessTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// FetchInitialAccessTokens returns the tokens of the account (all if zero)
+func (r *RegistrationRepository) FetchInitialAccessTokens(ctx context.Context, accountID uint64) ([]*authclient.AuthInitialAccessToken, error) {
+	var (
+		list  []*authclient.AuthInitialAccessToken
+		query = r.Slave(ctx).Order(`id DESC`)
+	)
+	if accountID > 0 {
+		query = query.Where(`account_id=?`, accountID)
+	}
+	if err := query.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateInitialAccessToken stores the new token
+func (r *RegistrationRepository) CreateInitialAccessToken(ctx context.Context, token *authclient.AuthInitialAccessToken) error {
+	token.CreatedAt = time.Now()
+	token.UpdatedAt = token.CreatedAt
+	return r.Master(ctx).Create(token).Error
+}
+
+// DeleteInitialAccessToken removes the token by ID
+func (r *RegistrationRepository) DeleteInitialAccessToken(ctx context.Context, id uint64) error {
+	return r.Master(historylog.WithPK(ctx, id)).
+		Delete(&authclient.AuthInitialAccessToken{}, id).Error
+}
+
+// RegisterClient counts the client in the token and creates it in the same transaction.
+// The counter is increased only if the token is still valid, so the concurrent
+// registrations can't exceed the limit of the clients.
+func (r *RegistrationRepository) RegisterClient(ctx context.Context, tokenID uint64, client *authclient.AuthClient) error {
+	if client.ID == "" {
+		client.ID = newID()
+	}
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		res := tx.Model((*authclient.AuthInitialAccessToken)(nil)).
+			Where(`id=? AND expires_at>? AND (max_clients=0 OR client_count<max_clients)`, tokenID, time.Now()).
+			Updates(map[string]any{
+				`client_count`: gorm.Expr(`client_count+1`),
+				`updated_at`:   time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return authclient.ErrInitialAccessTokenInvalid
+		}
+		client.CreatedAt = time.Now()
+		client.UpdatedAt = client.CreatedAt
+		return tx.WithContext(historylog.WithPK(ctx, client.ID)).Create(client).Error
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/geniusrabbit/blaze-api/repository/authclient"
 	"github.com/geniusrabbit/blaze-api/repository/generated"
 	"github.com/geniusrabbit/blaze-api/repository/historylog"
@@ -54,3 +56,23 @@ func (r *Repository) Update(ctx context.Context, id string, roleObj *authclient.
 func (r *Repository) Delete(ctx context.Context, id string, opts ...authclient.QOption) error {
 	return r.Repository.Delete(historylog.WithPK(ctx, id), id, opts...)
 }
+
+// RotateSecret replaces the secret hash, the current hash becomes the previous one
+// which is accepted until previousExpiresAt
+func (r *Repository) RotateSecret(ctx context.Context, id, secretHash string, previousExpiresAt time.Time) error {
+	res := r.Master(historylog.WithPK(ctx, id)).Model((*authclient.AuthClient)(nil)).
+		Where(`id=?`, id).
+		Updates(map[string]any{
+			`previous_secret`:            gorm.Expr(`secret`),
+			`previous_secret_expires_at`: previousExpiresAt,
+			`secret`:                     secretHash,
+			`updated_at`:                 time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
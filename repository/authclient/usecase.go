@@ -2,6 +2,7 @@ package authclient
 
 import (
 	"context"
+	"time"
 )
 
 // Usecase defines the business logic operations for AuthClient management.
@@ -25,4 +26,57 @@ type Usecase interface {
 
 	// Delete removes an AuthClient by ID with a change message.
 	Delete(ctx context.Context, id string, opts ...QOption) error
+
+	// RotateSecret generates the new secret of the AuthClient and returns it in plain,
+	// the previous secret keeps working for the grace period
+	RotateSecret(ctx context.Context, id string, gracePeriod time.Duration) (string, error)
+}
+
+// ConsentUsecase provides the consent decisions and the authorized apps of the current user
+type ConsentUsecase interface {
+	// ConsentRequest returns the pending consent request of the current user
+	ConsentRequest(ctx context.Context, challenge string) (*AuthConsentRequest, error)
+
+	// AcceptConsentRequest grants the scopes (all requested if empty) to the client
+	// and remembers the grant for the given time (forever if zero)
+	AcceptConsentRequest(ctx context.Context, challenge string, scopes []string, rememberFor time.Duration) (*AuthConsentRequest, error)
+
+	// RejectConsentRequest denies the access of the client
+	RejectConsentRequest(ctx context.Context, challenge string) (*AuthConsentRequest, error)
+
+	// AuthorizedApps returns the grants of the current user
+	AuthorizedApps(ctx context.Context) ([]*AuthGrant, error)
+
+	// RevokeAuthorizedApp removes the grant of the current user and kills the issued tokens
+	RevokeAuthorizedApp(ctx context.Context, clientID string) error
+}
+
+// DeviceUsecase provides the decisions of the current user on the device authorization requests
+type DeviceUsecase interface {
+	// DeviceRequest returns the pending device request by the user code
+	DeviceRequest(ctx context.Context, userCode string) (*AuthDeviceRequest, error)
+
+	// ApproveDeviceRequest grants the requested scopes to the client on behalf of the current user
+	ApproveDeviceRequest(ctx context.Context, userCode string) (*AuthDeviceRequest, error)
+
+	// DenyDeviceRequest denies the access of the client
+	DenyDeviceRequest(ctx context.Context, userCode string) (*AuthDeviceRequest, error)
+}
+
+// RegistrationUsecase provides the dynamic client registration (RFC 7591)
+// and the management of the initial access tokens
+type RegistrationUsecase interface {
+	// InitialAccessTokens returns the tokens of the current account
+	InitialAccessTokens(ctx context.Context) ([]*AuthInitialAccessToken, error)
+
+	// CreateInitialAccessToken stores the token for the current user and account
+	// and returns its plain value which is not available later
+	CreateInitialAccessToken(ctx context.Context, token *AuthInitialAccessToken) (string, error)
+
+	// RevokeInitialAccessToken removes the token, the registered clients are kept
+	RevokeInitialAccessToken(ctx context.Context, id uint64) error
+
+	// RegisterClient creates the client authorized by the initial access token
+	// and returns the plain secret of the confidential client
+	RegisterClient(ctx context.Context, initialAccessToken string, client *AuthClient) (string, error)
 }
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
+)
+
+// ConsentUsecase provides the consent decisions of the current user
+type ConsentUsecase struct {
+	consentRepo authclient.ConsentRepository
+	revoker     authclient.SessionRevoker
+}
+
+// NewConsentUsecase object controller, the revoker kills the tokens of the revoked apps
+func NewConsentUsecase(repo authclient.ConsentRepository, revoker authclient.SessionRevoker) *ConsentUsecase {
+	return &ConsentUsecase{
+		consentRepo: repo,
+		revoker:     revoker,
+	}
+}
+
+// ConsentRequest returns the pending consent request of the current user
+func (a *ConsentUsecase) ConsentRequest(ctx context.Context, challenge string) (*models.AuthConsentRequest, error) {
+	return a.pendingRequest(ctx, challenge, acl.HaveAccessView, "view consent request")
+}
+
+// AcceptConsentRequest grants the scopes (all requested if empty) to the client
+// and remembers the grant for the given time (forever if zero)
+func (a *ConsentUsecase) AcceptConsentRequest(ctx context.Context, challenge string, scopes []string, rememberFor time.Duration) (*models.AuthConsentRequest, error) {
+	req, err := a.pendingRequest(ctx, challenge, acl.HaveAccessUpdate, "decide consent request")
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) == 0 {
+		scopes = req.RequestedScope
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(req.RequestedScope, scope) {
+			return nil, errors.Wrap(authclient.ErrConsentScopeNotAllowed, scope)
+		}
+	}
+	req.Status = models.ConsentStatusAccepted
+	req.GrantedScope = scopes
+	req.GrantedAudience = req.RequestedAudience
+	if err = a.consentRepo.DecideConsentRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	grant := &models.AuthGrant{
+		UserID:   req.UserID,
+		ClientID: req.ClientID,
+		Scope:    req.GrantedScope,
+		Audience: req.GrantedAudience,
+	}
+	if rememberFor > 0 {
+		grant.ExpiresAt = sql.Null[time.Time]{V: time.Now().Add(rememberFor), Valid: true}
+	}
+	if err = a.consentRepo.SaveGrant(ctx, grant); err != nil {
+		return nil, errors.Wrap(err, "save grant")
+	}
+	return req, nil
+}
+
+// RejectConsentRequest denies the access of the client
+func (a *ConsentUsecase) RejectConsentRequest(ctx context.Context, challenge string) (*models.AuthConsentRequest, error) {
+	req, err := a.pendingRequest(ctx, challenge, acl.HaveAccessUpdate, "decide consent request")
+	if err != nil {
+		return nil, err
+	}
+	req.Status = models.ConsentStatusRejected
+	req.GrantedScope = nil
+	req.GrantedAudience = nil
+	if err = a.consentRepo.DecideConsentRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// AuthorizedApps returns the grants of the current user
+func (a *ConsentUsecase) AuthorizedApps(ctx context.Context) ([]*models.AuthGrant, error) {
+	userID := session.User(ctx).GetID()
+	if !acl.HaveAccessList(ctx, &models.AuthGrant{UserID: userID}) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, "list authorized apps")
+	}
+	return a.consentRepo.FetchGrants(ctx, userID)
+}
+
+// RevokeAuthorizedApp removes the grant of the current user and kills the issued tokens
+func (a *ConsentUsecase) RevokeAuthorizedApp(ctx context.Context, clientID string) error {
+	userID := session.User(ctx).GetID()
+	if !acl.HaveAccessDelete(ctx, &models.AuthGrant{UserID: userID, ClientID: clientID}) {
+		return errors.Wrap(acl.ErrNoPermissions, "revoke authorized app")
+	}
+	if err := a.consentRepo.DeleteGrant(ctx, userID, clientID); err != nil {
+		return err
+	}
+	if a.revoker == nil {
+		return nil
+	}
+	return errors.Wrap(a.revoker.RevokeUserSessions(ctx, userID, clientID), "revoke sessions")
+}
+
+// pendingRequest returns the consent request which belongs to the current user and waits for the decision
+func (a *ConsentUsecase) pendingRequest(ctx context.Context, challenge string, access func(context.Context, any) bool, action string) (*models.AuthConsentRequest, error) {
+	req, err := a.consentRepo.GetConsentRequest(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserID != session.User(ctx).GetID() || !access(ctx, req) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, action)
+	}
+	if req.IsExpired(time.Now()) {
+		return nil, authclient.ErrConsentRequestExpired
+	}
+	if req.Status != models.ConsentStatusPending {
+		return nil, authclient.ErrConsentRequestDecided
+	}
+	return req, nil
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
+)
+
+type revokerFunc func(ctx context.Context, userID uint64, clientID string) error
+
+func (f revokerFunc) RevokeUserSessions(ctx context.Context, userID uint64, clientID string) error {
+	return f(ctx, userID, clientID)
+}
+
+type consentTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	consentRepo    *mocks.MockConsentRepository
+	consentUsecase authclient.ConsentUsecase
+	revoked        []string
+}
+
+func (s *consentTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.consentRepo = mocks.NewMockConsentRepository(ctrl)
+	s.revoked = nil
+	s.consentUsecase = NewConsentUsecase(s.consentRepo, revokerFunc(func(_ context.Context, userID uint64, clientID string) error {
+		s.revoked = append(s.revoked, clientID)
+		return nil
+	}))
+}
+
+func (s *consentTestSuite) pendingRequest(userID uint64) *models.AuthConsentRequest {
+	return &models.AuthConsentRequest{
+		Challenge:      "challenge",
+		Status:         models.ConsentStatusPending,
+		UserID:         userID,
+		ClientID:       "client",
+		RequestedScope: []string{"openid", "email"},
+		RequestURL:     "https://auth.example.com/oauth2/auth?client_id=client",
+		ExpiresAt:      time.Now().Add(time.Minute),
+	}
+}
+
+func (s *consentTestSuite) TestConsentRequestOfOtherUser() {
+	s.consentRepo.EXPECT().GetConsentRequest(s.ctx, "challenge").
+		Return(s.pendingRequest(2), nil)
+
+	_, err := s.consentUsecase.ConsentRequest(s.ctx, "challenge")
+	s.ErrorIs(err, acl.ErrNoPermissions)
+}
+
+func (s *consentTestSuite) TestConsentRequestExpired() {
+	req := s.pendingRequest(1)
+	req.ExpiresAt = time.Now().Add(-time.Second)
+	s.consentRepo.EXPECT().GetConsentRequest(s.ctx, "challenge").Return(req, nil)
+
+	_, err := s.consentUsecase.ConsentRequest(s.ctx, "challenge")
+	s.ErrorIs(err, authclient.ErrConsentRequestExpired)
+}
+
+func (s *consentTestSuite) TestAccept() {
+	s.consentRepo.EXPECT().GetConsentRequest(s.ctx, "challenge").
+		Return(s.pendingRequest(1), nil)
+	s.consentRepo.EXPECT().DecideConsentRequest(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *models.AuthConsentRequest) error {
+			s.Equal(models.ConsentStatusAccepted, req.Status)
+			s.Equal([]string{"openid"}, []string(req.GrantedScope))
+			return nil
+		})
+	s.consentRepo.EXPECT().SaveGrant(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, grant *models.AuthGrant) error {
+			s.Equal(uint64(1), grant.UserID)
+			s.Equal("client", grant.ClientID)
+			s.True(grant.ExpiresAt.Valid)
+			return nil
+		})
+
+	req, err := s.consentUsecase.AcceptConsentRequest(s.ctx, "challenge", []string{"openid"}, time.Hour)
+	s.NoError(err)
+	s.Equal("https://auth.example.com/oauth2/auth?client_id=client&consent_challenge=challenge", req.RedirectURL())
+}
+
+func (s *consentTestSuite) TestAcceptNotRequestedScope() {
+	s.consentRepo.EXPECT().GetConsentRequest(s.ctx, "challenge").
+		Return(s.pendingRequest(1), nil)
+
+	_, err := s.consentUsecase.AcceptConsentRequest(s.ctx, "challenge", []string{"admin"}, 0)
+	s.ErrorIs(err, authclient.ErrConsentScopeNotAllowed)
+}
+
+func (s *consentTestSuite) TestRejectDecided() {
+	req := s.pendingRequest(1)
+	req.Status = models.ConsentStatusAccepted
+	s.consentRepo.EXPECT().GetConsentRequest(s.ctx, "challenge").Return(req, nil)
+
+	_, err := s.consentUsecase.RejectConsentRequest(s.ctx, "challenge")
+	s.ErrorIs(err, authclient.ErrConsentRequestDecided)
+}
+
+func (s *consentTestSuite) TestRevokeAuthorizedApp() {
+	s.consentRepo.EXPECT().DeleteGrant(s.ctx, uint64(1), "client").Return(nil)
+
+	s.NoError(s.consentUsecase.RevokeAuthorizedApp(s.ctx, "client"))
+	s.Equal([]string{"client"}, s.revoked)
+}
+
+func TestConsentSuite(t *testing.T) {
+	suite.Run(t, &consentTestSuite{})
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
+)
+
+// DeviceUsecase provides the decisions of the current user on the device authorization requests
+type DeviceUsecase struct {
+	deviceRepo authclient.DeviceRepository
+}
+
+// NewDeviceUsecase object controller
+func NewDeviceUsecase(repo authclient.DeviceRepository) *DeviceUsecase {
+	return &DeviceUsecase{deviceRepo: repo}
+}
+
+// DeviceRequest returns the pending device request by the user code
+func (a *DeviceUsecase) DeviceRequest(ctx context.Context, userCode string) (*models.AuthDeviceRequest, error) {
+	return a.pendingRequest(ctx, userCode, acl.HaveAccessView, "view device request")
+}
+
+// ApproveDeviceRequest grants the requested scopes to the client on behalf of the current user
+func (a *DeviceUsecase) ApproveDeviceRequest(ctx context.Context, userCode string) (*models.AuthDeviceRequest, error) {
+	req, err := a.decidableRequest(ctx, userCode, "approve device request")
+	if err != nil {
+		return nil, err
+	}
+	req.Status = models.DeviceStatusApproved
+	req.GrantedScope = req.RequestedScope
+	req.GrantedAudience = req.RequestedAudience
+	if err = a.deviceRepo.DecideDeviceRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DenyDeviceRequest denies the access of the client
+func (a *DeviceUsecase) DenyDeviceRequest(ctx context.Context, userCode string) (*models.AuthDeviceRequest, error) {
+	req, err := a.decidableRequest(ctx, userCode, "deny device request")
+	if err != nil {
+		return nil, err
+	}
+	req.Status = models.DeviceStatusDenied
+	req.GrantedScope = nil
+	req.GrantedAudience = nil
+	if err = a.deviceRepo.DecideDeviceRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decidableRequest returns the pending device request which the current user can decide
+func (a *DeviceUsecase) decidableRequest(ctx context.Context, userCode, action string) (*models.AuthDeviceRequest, error) {
+	if session.IsImpersonated(ctx) {
+		return nil, authclient.ErrDeviceRequestImpersonated
+	}
+	return a.pendingRequest(ctx, userCode, acl.HaveAccessUpdate, action)
+}
+
+// pendingRequest returns the device request which waits for the decision.
+// The request doesn't belong to anyone before the decision,
+// so it's checked as it would be owned by the current user.
+func (a *DeviceUsecase) pendingRequest(ctx context.Context, userCode string, access func(context.Context, any) bool, action string) (*models.AuthDeviceRequest, error) {
+	req, err := a.deviceRepo.GetDeviceRequest(ctx, userCode)
+	if err != nil {
+		return nil, err
+	}
+	if req.IsExpired(time.Now()) {
+		return nil, authclient.ErrDeviceRequestExpired
+	}
+	if req.Status != models.DeviceStatusPending {
+		return nil, authclient.ErrDeviceRequestDecided
+	}
+	usr, acc := session.UserAccount(ctx)
+	req.UserID = usr.GetID()
+	if acc != nil && !acc.IsAnonymous() {
+		req.AccountID = acc.GetID()
+	}
+	if usr.IsAnonymous() || !access(ctx, req) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, action)
+	}
+	return req, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
+)
+
+type deviceTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	deviceRepo    *mocks.MockDeviceRepository
+	deviceUsecase authclient.DeviceUsecase
+}
+
+func (s *deviceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.deviceRepo = mocks.NewMockDeviceRepository(ctrl)
+	s.deviceUsecase = NewDeviceUsecase(s.deviceRepo)
+}
+
+func (s *deviceTestSuite) pendingRequest() *models.AuthDeviceRequest {
+	return &models.AuthDeviceRequest{
+		ID:                1,
+		Status:            models.DeviceStatusPending,
+		UserCode:          "BCDFGHJK",
+		ClientID:          "cli",
+		RequestedScope:    []string{"openid", "offline"},
+		RequestedAudience: []string{"api"},
+		ExpiresAt:         time.Now().Add(time.Minute),
+	}
+}
+
+func (s *deviceTestSuite) TestDeviceRequestExpired() {
+	req := s.pendingRequest()
+	req.ExpiresAt = time.Now().Add(-time.Second)
+	s.deviceRepo.EXPECT().GetDeviceRequest(s.ctx, "bcdf-ghjk").Return(req, nil)
+
+	_, err := s.deviceUsecase.DeviceRequest(s.ctx, "bcdf-ghjk")
+	s.ErrorIs(err, authclient.ErrDeviceRequestExpired)
+}
+
+func (s *deviceTestSuite) TestApprove() {
+	s.deviceRepo.EXPECT().GetDeviceRequest(s.ctx, "BCDF-GHJK").Return(s.pendingRequest(), nil)
+	s.deviceRepo.EXPECT().DecideDeviceRequest(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *models.AuthDeviceRequest) error {
+			s.Equal(models.DeviceStatusApproved, req.Status)
+			s.Equal(uint64(1), req.UserID)
+			s.Equal([]string{"openid", "offline"}, []string(req.GrantedScope))
+			s.Equal([]string{"api"}, []string(req.GrantedAudience))
+			return nil
+		})
+
+	_, err := s.deviceUsecase.ApproveDeviceRequest(s.ctx, "BCDF-GHJK")
+	s.NoError(err)
+}
+
+func (s *deviceTestSuite) TestDenyDecided() {
+	req := s.pendingRequest()
+	req.Status = models.DeviceStatusApproved
+	s.deviceRepo.EXPECT().GetDeviceRequest(s.ctx, "BCDF-GHJK").Return(req, nil)
+
+	_, err := s.deviceUsecase.DenyDeviceRequest(s.ctx, "BCDF-GHJK")
+	s.ErrorIs(err, authclient.ErrDeviceRequestDecided)
+}
+
+func (s *deviceTestSuite) TestDecideImpersonated() {
+	ctx := session.WithImpersonation(s.ctx, &session.Impersonation{ActorID: 3, ExpiresAt: time.Now().Add(time.Minute)})
+
+	_, err := s.deviceUsecase.ApproveDeviceRequest(ctx, "BCDF-GHJK")
+	s.ErrorIs(err, authclient.ErrDeviceRequestImpersonated)
+
+	_, err = s.deviceUsecase.DenyDeviceRequest(ctx, "BCDF-GHJK")
+	s.ErrorIs(err, authclient.ErrDeviceRequestImpersonated)
+}
+
+func TestDeviceSuite(t *testing.T) {
+	suite.Run(t, &deviceTestSuite{})
+}
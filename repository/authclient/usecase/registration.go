@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+)
+
+// RegistrationUsecase provides the dynamic client registration
+// and the management of the initial access tokens
+type RegistrationUsecase struct {
+	registrationRepo authclient.RegistrationRepository
+}
+
+// NewRegistrationUsecase object controller
+func NewRegistrationUsecase(repo authclient.RegistrationRepository) *RegistrationUsecase {
+	return &RegistrationUsecase{registrationRepo: repo}
+}
+
+// InitialAccessTokens returns the tokens of the current account,
+// the users with the full list access see the tokens of all accounts
+func (a *RegistrationUsecase) InitialAccessTokens(ctx context.Context) ([]*models.AuthInitialAccessToken, error) {
+	var accountID uint64
+	if !acl.HaveAccessList(ctx, &models.AuthInitialAccessToken{}) {
+		accountID = session.AccountID(ctx)
+		if !acl.HaveAccessList(ctx, &models.AuthInitialAccessToken{AccountID: accountID}) {
+			return nil, errors.Wrap(acl.ErrNoPermissions, "list initial access tokens")
+		}
+	}
+	return a.registrationRepo.FetchInitialAccessTokens(ctx, accountID)
+}
+
+// CreateInitialAccessToken stores the token for the current user and account
+// and returns its plain value which is not available later
+func (a *RegistrationUsecase) CreateInitialAccessToken(ctx context.Context, token *models.AuthInitialAccessToken) (string, error) {
+	if token.UserID == 0 {
+		token.UserID = session.UserID(ctx)
+	}
+	if token.AccountID == 0 {
+		token.AccountID = session.AccountID(ctx)
+	}
+	if !acl.HaveAccessCreate(ctx, token) {
+		return "", errors.Wrap(acl.ErrNoPermissions, "create initial access token")
+	}
+	if token.IsExpired(time.Now()) {
+		return "", authclient.ErrInvalidExpiration
+	}
+	value, err := authclient.GenerateInitialAccessToken()
+	if err != nil {
+		return "", errors.Wrap(err, "generate initial access token")
+	}
+	token.ID = 0
+	token.Token = authclient.HashInitialAccessToken(value)
+	token.ClientCount = 0
+	if err = a.registrationRepo.CreateInitialAccessToken(ctx, token); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// RevokeInitialAccessToken removes the token, the registered clients are kept
+func (a *RegistrationUsecase) RevokeInitialAccessToken(ctx context.Context, id uint64) error {
+	token, err := a.registrationRepo.GetInitialAccessToken(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !acl.HaveAccessDelete(ctx, token) {
+		return errors.Wrap(acl.ErrNoPermissions, "revoke initial access token")
+	}
+	return a.registrationRepo.DeleteInitialAccessToken(ctx, id)
+}
+
+// RegisterClient creates the client authorized by the initial access token.
+// The client belongs to the owner of the token and can request only the scopes
+// allowed by the token. The secret is generated for the confidential clients
+// which authenticate with it and returned in plain.
+func (a *RegistrationUsecase) RegisterClient(ctx context.Context, initialAccessToken string, client *models.AuthClient) (string, error) {
+	if !strings.HasPrefix(initialAccessToken, authclient.InitialAccessTokenPrefix) {
+		return "", authclient.ErrInitialAccessTokenInvalid
+	}
+	token, err := a.registrationRepo.GetInitialAccessTokenByHash(ctx,
+		authclient.HashInitialAccessToken(initialAccessToken))
+	if err != nil {
+		return "", err
+	}
+	if token.IsExpired(time.Now()) || token.IsExhausted() {
+		return "", authclient.ErrInitialAccessTokenInvalid
+	}
+
+	if allowed := strings.Fields(token.Scope); len(allowed) > 0 {
+		if client.Scope == "" {
+			client.Scope = token.Scope
+		}
+		for _, scope := range strings.Fields(client.Scope) {
+			if !slices.Contains(allowed, scope) {
+				return "", errors.Wrap(authclient.ErrScopeNotAllowed, scope)
+			}
+		}
+	}
+
+	var secret string
+	client.ID = ""
+	client.AccountID = token.AccountID
+	client.UserID = token.UserID
+	client.Secret = ""
+	if err = quota.CheckObject(ctx, client, 1); err != nil {
+		return "", err
+	}
+	if !client.Public && client.TokenEndpointAuthMethod != models.TokenEndpointAuthPrivateKey {
+		if secret, client.Secret, err = authclient.GenerateSecret(); err != nil {
+			return "", errors.Wrap(err, "generate secret")
+		}
+	}
+	if err = a.registrationRepo.RegisterClient(ctx, token.ID, client); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
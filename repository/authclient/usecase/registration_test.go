@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/authclient"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	quotamocks "github.com/geniusrabbit/blaze-api/repository/quota/mocks"
+)
+
+type registrationTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	registrationRepo    *mocks.MockRegistrationRepository
+	registrationUsecase authclient.RegistrationUsecase
+}
+
+func (s *registrationTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.registrationRepo = mocks.NewMockRegistrationRepository(ctrl)
+	s.registrationUsecase = NewRegistrationUsecase(s.registrationRepo)
+}
+
+func (s *registrationTestSuite) initialAccessToken(value string) *models.AuthInitialAccessToken {
+	return &models.AuthInitialAccessToken{
+		ID:        5,
+		Token:     authclient.HashInitialAccessToken(value),
+		AccountID: 3,
+		UserID:    7,
+		Scope:     "openid profile",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func (s *registrationTestSuite) TestCreateInitialAccessToken() {
+	s.registrationRepo.EXPECT().CreateInitialAccessToken(s.ctx, gomock.Any()).Return(nil)
+
+	token := &models.AuthInitialAccessToken{ExpiresAt: time.Now().Add(time.Hour)}
+	value, err := s.registrationUsecase.CreateInitialAccessToken(s.ctx, token)
+	s.NoError(err)
+	s.Contains(value, authclient.InitialAccessTokenPrefix)
+	s.Equal(authclient.HashInitialAccessToken(value), token.Token, "only the hash has to be stored")
+	s.Equal(uint64(1), token.UserID)
+}
+
+func (s *registrationTestSuite) TestCreateInitialAccessTokenExpired() {
+	_, err := s.registrationUsecase.CreateInitialAccessToken(s.ctx,
+		&models.AuthInitialAccessToken{ExpiresAt: time.Now().Add(-time.Second)})
+	s.ErrorIs(err, authclient.ErrInvalidExpiration)
+}
+
+func (s *registrationTestSuite) TestRegisterClient() {
+	const value = authclient.InitialAccessTokenPrefix + "token"
+	s.registrationRepo.EXPECT().
+		GetInitialAccessTokenByHash(s.ctx, authclient.HashInitialAccessToken(value)).
+		Return(s.initialAccessToken(value), nil)
+	s.registrationRepo.EXPECT().RegisterClient(s.ctx, uint64(5), gomock.Any()).Return(nil)
+
+	client := &models.AuthClient{Title: "cli", TokenEndpointAuthMethod: models.TokenEndpointAuthSecretBasic}
+	secret, err := s.registrationUsecase.RegisterClient(s.ctx, value, client)
+	s.NoError(err)
+	s.NotEmpty(secret)
+	s.NoError(bcrypt.CompareHashAndPassword([]byte(client.Secret), []byte(secret)))
+	s.Equal("openid profile", client.Scope, "the scope of the token is used by default")
+	s.Equal(uint64(3), client.AccountID)
+	s.Equal(uint64(7), client.UserID)
+}
+
+func (s *registrationTestSuite) TestRegisterClientPrivateKey() {
+	const value = authclient.InitialAccessTokenPrefix + "token"
+	s.registrationRepo.EXPECT().
+		GetInitialAccessTokenByHash(s.ctx, gomock.Any()).
+		Return(s.initialAccessToken(value), nil)
+	s.registrationRepo.EXPECT().RegisterClient(s.ctx, uint64(5), gomock.Any()).Return(nil)
+
+	client := &models.AuthClient{Scope: "openid", TokenEndpointAuthMethod: models.TokenEndpointAuthPrivateKey}
+	secret, err := s.registrationUsecase.RegisterClient(s.ctx, value, client)
+	s.NoError(err)
+	s.Empty(secret, "the client authenticates with the keys")
+	s.Empty(client.Secret)
+}
+
+func (s *registrationTestSuite) TestRegisterClientScopeNotAllowed() {
+	const value = authclient.InitialAccessTokenPrefix + "token"
+	s.registrationRepo.EXPECT().
+		GetInitialAccessTokenByHash(s.ctx, gomock.Any()).
+		Return(s.initialAccessToken(value), nil)
+
+	_, err := s.registrationUsecase.RegisterClient(s.ctx, value, &models.AuthClient{Scope: "openid admin"})
+	s.ErrorIs(err, authclient.ErrScopeNotAllowed)
+}
+
+func (s *registrationTestSuite) TestRegisterClientExhausted() {
+	const value = authclient.InitialAccessTokenPrefix + "token"
+	token := s.initialAccessToken(value)
+	token.MaxClients, token.ClientCount = 1, 1
+	s.registrationRepo.EXPECT().GetInitialAccessTokenByHash(s.ctx, gomock.Any()).Return(token, nil)
+
+	_, err := s.registrationUsecase.RegisterClient(s.ctx, value, &models.AuthClient{})
+	s.ErrorIs(err, authclient.ErrInitialAccessTokenInvalid)
+}
+
+func (s *registrationTestSuite) TestRegisterClientQuotaExceeded() {
+	const value = authclient.InitialAccessTokenPrefix + "token"
+	quotaUsecase := quotamocks.NewMockUsecase(gomock.NewController(s.T()))
+	ctx := quota.WithUsecase(s.ctx, quotaUsecase)
+	s.registrationRepo.EXPECT().GetInitialAccessTokenByHash(ctx, gomock.Any()).
+		Return(s.initialAccessToken(value), nil)
+	quotaUsecase.EXPECT().Check(ctx, uint64(3), "auth_client", int64(1)).
+		Return(&quota.ExceededError{AccountID: 3, Resource: "auth_client", Limit: 1, Used: 1})
+
+	_, err := s.registrationUsecase.RegisterClient(ctx, value, &models.AuthClient{Scope: "openid"})
+	s.ErrorIs(err, quota.ErrQuotaExceeded)
+}
+
+func (s *registrationTestSuite) TestRegisterClientInvalidToken() {
+	_, err := s.registrationUsecase.RegisterClient(s.ctx, "dat_token", &models.AuthClient{})
+	s.ErrorIs(err, authclient.ErrInitialAccessTokenInvalid)
+}
+
+func TestRegistrationSuite(t *testing.T) {
+	suite.Run(t, &registrationTestSuite{})
+}
@@ -3,11 +3,13 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
 	"github.com/geniusrabbit/blaze-api/repository/authclient"
 	"github.com/geniusrabbit/blaze-api/repository/authclient/models"
 	"github.com/geniusrabbit/blaze-api/repository/historylog"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
 	"github.com/pkg/errors"
 )
 
@@ -63,6 +65,9 @@ func (a *AuthclientUsecase) Create(ctx context.Context, authclientObj *models.Au
 	if !acl.HaveAccessCreate(ctx, authclientObj) {
 		return "", errors.Wrap(acl.ErrNoPermissions, "create authclient")
 	}
+	if err = quota.CheckObject(ctx, authclientObj, 1); err != nil {
+		return "", err
+	}
 	authclientObj.ID, err = a.authclientRepo.Create(ctx, authclientObj, opts...)
 	return authclientObj.ID, err
 }
@@ -86,3 +91,30 @@ func (a *AuthclientUsecase) Delete(ctx context.Context, id string, opts ...authc
 	}
 	return a.authclientRepo.Delete(historylog.WithPK(ctx, id), id, opts...)
 }
+
+// RotateSecret generates the new secret of the client, the previous one
+// is accepted together with the new one during the grace period
+func (a *AuthclientUsecase) RotateSecret(ctx context.Context, id string, gracePeriod time.Duration) (string, error) {
+	if gracePeriod < 0 {
+		return "", authclient.ErrInvalidGracePeriod
+	}
+	authclientObj, err := a.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if !acl.HaveAccessUpdate(ctx, authclientObj) {
+		return "", errors.Wrap(acl.ErrNoPermissions, "rotate authclient secret")
+	}
+	if authclientObj.Public {
+		return "", authclient.ErrPublicClientSecret
+	}
+	secret, secretHash, err := authclient.GenerateSecret()
+	if err != nil {
+		return "", errors.Wrap(err, "generate secret")
+	}
+	err = a.authclientRepo.RotateSecret(ctx, id, secretHash, time.Now().Add(gracePeriod))
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -115,6 +116,32 @@ func (s *testSuite) TestDeleteNotFound() {
 	s.EqualError(err, sql.ErrNoRows.Error())
 }
 
+func (s *testSuite) TestRotateSecret() {
+	s.authclientRepo.EXPECT().
+		Get(s.ctx, "1").
+		Return(&models.AuthClient{ID: "1"}, nil)
+	s.authclientRepo.EXPECT().
+		RotateSecret(s.ctx, "1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, secretHash string, previousExpiresAt time.Time) error {
+			s.NotEmpty(secretHash)
+			s.WithinDuration(time.Now().Add(time.Hour), previousExpiresAt, time.Minute)
+			return nil
+		})
+
+	secret, err := s.authclientUsecase.RotateSecret(s.ctx, "1", time.Hour)
+	s.NoError(err)
+	s.NotEmpty(secret)
+}
+
+func (s *testSuite) TestRotateSecretPublic() {
+	s.authclientRepo.EXPECT().
+		Get(s.ctx, "2").
+		Return(&models.AuthClient{ID: "2", Public: true}, nil)
+
+	_, err := s.authclientUsecase.RotateSecret(s.ctx, "2", time.Hour)
+	s.ErrorIs(err, authclient.ErrPublicClientSecret)
+}
+
 func TestRoleSuite(t *testing.T) {
 	suite.Run(t, &testSuite{})
 }
@@ -0,0 +1,86 @@
+// Package auth checks the direct access tokens and restricts the sessions
+// opened by them according to the token settings
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+)
+
+var (
+	ErrInvalidToken      = errors.New("invalid direct access token")
+	ErrTokenNotFound     = fmt.Errorf("%w: not found", ErrInvalidToken)
+	ErrIPNotAllowed      = errors.New("direct access token is not allowed from the address")
+	ErrScopesUnsupported = errors.New("account doesn't support the token permission scopes")
+)
+
+// usageUpdateInterval is the minimal interval between the updates of the token
+// last usage, the token is checked on every request and the update goes to the master
+const usageUpdateInterval = time.Minute
+
+// Authenticator resolves the user and account of the direct access token
+type Authenticator[TUser user.Model, TAccount account.Model] struct {
+	tokens directaccesstoken.Repository
+	loader *accauth.Loader[TUser, TAccount]
+}
+
+// NewAuthenticator creates the direct access token authenticator
+func NewAuthenticator[TUser user.Model, TAccount account.Model](tokens directaccesstoken.Repository, loader *accauth.Loader[TUser, TAccount]) *Authenticator[TUser, TAccount] {
+	return &Authenticator[TUser, TAccount]{tokens: tokens, loader: loader}
+}
+
+// Authenticate returns the user and the account of the token used from the IP address.
+// The account permissions are loaded and limited by the token scopes.
+func (a *Authenticator[TUser, TAccount]) Authenticate(ctx context.Context, token, ip string) (TUser, TAccount, error) {
+	var (
+		zeroUser TUser
+		zeroAcc  TAccount
+	)
+
+	tokenObj, err := a.tokens.GetByToken(ctx, token)
+	if err != nil {
+		ctxlogger.Get(ctx).Info("direct access token not found", zap.Error(err))
+		return zeroUser, zeroAcc, ErrTokenNotFound
+	}
+
+	if !tokenObj.IsIPAllowed(ip) {
+		ctxlogger.Get(ctx).Info("direct access token used from not allowed address",
+			zap.Uint64("token_id", tokenObj.ID),
+			zap.String("ip", ip))
+		return zeroUser, zeroAcc, ErrIPNotAllowed
+	}
+
+	userObj, accObj, err := a.loader.UserAccountByID(ctx, tokenObj.UserID.V, tokenObj.AccountID, zeroUser, zeroAcc)
+	if err != nil {
+		return zeroUser, zeroAcc, err
+	}
+	if any(accObj) == any(zeroAcc) {
+		return zeroUser, zeroAcc, ErrInvalidToken
+	}
+
+	if len(tokenObj.Scopes) > 0 {
+		restrictor, ok := any(accObj).(account.PermissionRestrictor)
+		if !ok {
+			return zeroUser, zeroAcc, ErrScopesUnsupported
+		}
+		restrictor.RestrictPermissions(tokenObj.Scopes...)
+	}
+
+	if tokenObj.IsUsageOutdated(ip, usageUpdateInterval) {
+		if err = a.tokens.MarkUsed(ctx, tokenObj.ID, ip); err != nil {
+			ctxlogger.Get(ctx).Error("mark direct access token used",
+				zap.Uint64("token_id", tokenObj.ID), zap.Error(err))
+		}
+	}
+	return userObj, accObj, nil
+}
@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"strings"
 	"time"
 
 	"github.com/demdxx/gocast/v2"
@@ -10,6 +11,9 @@ import (
 	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
 )
 
+// maskedTokenSuffix is the number of the mask symbols after the visible token prefix
+const maskedTokenSuffix = 8
+
 func FromDirectAccessToken(token *directaccesstoken.DirectAccessToken) *gqlmodels.DirectAccessToken {
 	if token == nil {
 		return nil
@@ -17,14 +21,30 @@ func FromDirectAccessToken(token *directaccesstoken.DirectAccessToken) *gqlmodel
 	return &gqlmodels.DirectAccessToken{
 		ID:          token.ID,
 		Token:       token.Token,
+		TokenPrefix: token.TokenPrefix,
 		UserID:      gocast.IfThen(token.UserID.Valid, &token.UserID.V, nil),
 		AccountID:   token.AccountID,
 		Description: token.Description,
+		Scopes:      token.Scopes,
+		AllowedIPs:  token.AllowedIPs,
+		LastUsedAt:  gocast.IfThen(token.LastUsedAt.Valid, &token.LastUsedAt.V, nil),
+		LastUsedIP:  token.LastUsedIP,
 		CreatedAt:   token.CreatedAt,
 		ExpiresAt:   token.ExpiresAt,
 	}
 }
 
+// maskedDirectAccessToken replaces the stored hash of the token with the masked value
+func maskedDirectAccessToken(dat *directaccesstoken.DirectAccessToken) *directaccesstoken.DirectAccessToken {
+	if dat == nil {
+		return nil
+	}
+	m := new(directaccesstoken.DirectAccessToken)
+	*m = *dat
+	m.Token = m.TokenPrefix + strings.Repeat("*", maskedTokenSuffix)
+	return m
+}
+
 func FromDirectAccessTokenModelList(list []*directaccesstoken.DirectAccessToken) []*gqlmodels.DirectAccessToken {
 	return xtypes.SliceApply(list, FromDirectAccessToken)
 }
@@ -3,7 +3,6 @@ package graphql
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/demdxx/gocast/v2"
@@ -11,7 +10,6 @@ import (
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/pkg/requestid"
 	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken"
-	datModels "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/models"
 	datokenrepo "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/repository"
 	datokenusecase "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/usecase"
 	"github.com/geniusrabbit/blaze-api/server/graphql/models"
@@ -33,7 +31,8 @@ func NewDefaultQueryResolver() *QueryResolver {
 }
 
 // Generate creates a new direct access token with the specified parameters.
-func (r *QueryResolver) Generate(ctx context.Context, userID *uint64, description string, expiresAt *time.Time) (*models.DirectAccessTokenPayload, error) {
+// The plain token is returned only in the response of the generation.
+func (r *QueryResolver) Generate(ctx context.Context, userID *uint64, description string, expiresAt *time.Time, scopes, allowedIPs []string) (*models.DirectAccessTokenPayload, error) {
 	// Initialize expiration time if nil
 	if expiresAt == nil {
 		expiresAt = &time.Time{}
@@ -55,6 +54,8 @@ func (r *QueryResolver) Generate(ctx context.Context, userID *uint64, descriptio
 		session.AccountID(ctx),
 		description,
 		*expiresAt,
+		directaccesstoken.WithScopes(scopes...),
+		directaccesstoken.WithAllowedIPs(allowedIPs...),
 	)
 	if err != nil {
 		return nil, err
@@ -89,24 +90,12 @@ func (r *QueryResolver) Get(ctx context.Context, id uint64) (*models.DirectAcces
 
 	return &models.DirectAccessTokenPayload{
 		ClientMutationID: requestid.Get(ctx),
-		Token:            FromDirectAccessToken(token),
+		Token:            FromDirectAccessToken(maskedDirectAccessToken(token)),
 	}, nil
 }
 
 // List retrieves a paginated collection of direct access tokens with optional filtering and ordering.
-// Tokens created within the last 5 minutes are returned as-is; older tokens have their values masked.
+// Only the hashes of the tokens are stored, so the values are always masked.
 func (r *QueryResolver) List(ctx context.Context, filter *models.DirectAccessTokenListFilter, order []*models.DirectAccessTokenListOrder, page *models.Page) (*DirectAccessTokenConnection, error) {
-	return NewDirectAccessTokenConnection(ctx, r.uc, filter, order, page,
-		func(dat *datModels.DirectAccessToken) *datModels.DirectAccessToken {
-			// Return token unmasked if recently created
-			if dat.CreatedAt.After(time.Now().Add(-time.Minute * 5)) {
-				return dat
-			}
-
-			// Mask token value for older tokens
-			m := new(datModels.DirectAccessToken)
-			*m = *dat
-			m.Token = strings.Repeat("*", len(m.Token))
-			return m
-		}), nil
+	return NewDirectAccessTokenConnection(ctx, r.uc, filter, order, page, maskedDirectAccessToken), nil
 }
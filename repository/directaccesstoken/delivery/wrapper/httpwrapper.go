@@ -5,10 +5,12 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/geniusrabbit/blaze-api/pkg/context/clientip"
 	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/repository/account"
 	accauth "github.com/geniusrabbit/blaze-api/repository/account/auth"
+	datauth "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/auth"
 	directAccRepository "github.com/geniusrabbit/blaze-api/repository/directaccesstoken/repository"
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
@@ -31,6 +33,7 @@ func (ts TokenSource) Extract(r *http.Request) string {
 }
 
 // HTTPWrapper is middleware that validates direct access tokens and injects user/account context.
+// The token address allow-list is checked and the account permissions are limited by the token scopes.
 func HTTPWrapper[TUser user.Model, TAccount account.Model](
 	h http.Handler,
 	loader *accauth.Loader[TUser, TAccount],
@@ -40,7 +43,7 @@ func HTTPWrapper[TUser user.Model, TAccount account.Model](
 		sources = append(sources, TokenSource{Type: "header", Name: "D-Access-Token"})
 	}
 
-	actokens := directAccRepository.NewDirectAccessTokenRepository()
+	authenticator := datauth.NewAuthenticator(directAccRepository.NewDirectAccessTokenRepository(), loader)
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token := ""
@@ -57,36 +60,14 @@ func HTTPWrapper[TUser user.Model, TAccount account.Model](
 			return
 		}
 
-		tokenObj, err := actokens.GetByToken(ctx, token)
-		if err != nil {
-			ctxlogger.Get(ctx).Error("invalid token load", zap.Error(err))
-			unauthorized(w)
-			return
+		ip := clientip.Get(ctx)
+		if ip == "" {
+			ip = clientip.Normalize(r.RemoteAddr)
 		}
 
-		var zeroUser TUser
-		var zeroAcc TAccount
-		userObj, acc, err := loader.UserAccountByID(ctx, tokenObj.UserID.V, tokenObj.AccountID, zeroUser, zeroAcc)
+		userObj, acc, err := authenticator.Authenticate(ctx, token, ip)
 		if err != nil {
-			ctxlogger.Get(ctx).Error("invalid user load", zap.Error(err))
-			unauthorized(w)
-			return
-		}
-
-		if any(acc) == any(zeroAcc) {
-			ctxlogger.Get(ctx).Info("user and account not found")
-			unauthorized(w)
-			return
-		}
-
-		if any(userObj) != any(zeroUser) && !loader.Members.IsMember(ctx, userObj.GetID(), acc.GetID()) {
-			ctxlogger.Get(ctx).Error("user is not a member of the account")
-			unauthorized(w)
-			return
-		}
-
-		if err = loader.Accounts.LoadPermissions(ctx, acc, userObj); err != nil {
-			ctxlogger.Get(ctx).Error("load permissions", zap.Error(err))
+			ctxlogger.Get(ctx).Error("direct access token authentication", zap.Error(err))
 			unauthorized(w)
 			return
 		}
@@ -17,6 +17,11 @@ table "direct_access_tokens" {
     null = true
     type = text
   }
+  column "token_prefix" {
+    null    = false
+    type    = varchar(16)
+    default = ""
+  }
   column "description" {
     null = true
     type = text
@@ -29,6 +34,23 @@ table "direct_access_tokens" {
     null = true
     type = bigint
   }
+  column "scopes" {
+    null = true
+    type = sql("text[]")
+  }
+  column "allowed_ips" {
+    null = true
+    type = sql("text[]")
+  }
+  column "last_used_at" {
+    null = true
+    type = timestamptz
+  }
+  column "last_used_ip" {
+    null    = false
+    type    = varchar(64)
+    default = ""
+  }
   column "created_at" {
     null = true
     type = timestamptz
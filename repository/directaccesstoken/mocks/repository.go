@@ -83,18 +83,23 @@ func (mr *MockRepositoryMockRecorder) FetchList(ctx any, opts ...any) *gomock.Ca
 }
 
 // Generate mocks base method.
-func (m *MockRepository) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time) (*directaccesstoken.DirectAccessToken, error) {
+func (m *MockRepository) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time, opts ...directaccesstoken.GenerateOption) (*directaccesstoken.DirectAccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", ctx, userID, accountID, description, expiresAt)
+	varargs := []any{ctx, userID, accountID, description, expiresAt}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Generate", varargs...)
 	ret0, _ := ret[0].(*directaccesstoken.DirectAccessToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Generate indicates an expected call of Generate.
-func (mr *MockRepositoryMockRecorder) Generate(ctx, userID, accountID, description, expiresAt any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Generate(ctx, userID, accountID, description, expiresAt any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRepository)(nil).Generate), ctx, userID, accountID, description, expiresAt)
+	varargs := append([]any{ctx, userID, accountID, description, expiresAt}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRepository)(nil).Generate), varargs...)
 }
 
 // Get mocks base method.
@@ -127,6 +132,20 @@ func (mr *MockRepositoryMockRecorder) GetByToken(ctx, token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockRepository)(nil).GetByToken), ctx, token)
 }
 
+// MarkUsed mocks base method.
+func (m *MockRepository) MarkUsed(ctx context.Context, id uint64, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRepositoryMockRecorder) MarkUsed(ctx, id, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRepository)(nil).MarkUsed), ctx, id, ip)
+}
+
 // Revoke mocks base method.
 func (m *MockRepository) Revoke(ctx context.Context, opts ...directaccesstoken.QOption) error {
 	m.ctrl.T.Helper()
@@ -83,18 +83,23 @@ func (mr *MockUsecaseMockRecorder) FetchList(ctx any, opts ...any) *gomock.Call
 }
 
 // Generate mocks base method.
-func (m *MockUsecase) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time) (*directaccesstoken.DirectAccessToken, error) {
+func (m *MockUsecase) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time, opts ...directaccesstoken.GenerateOption) (*directaccesstoken.DirectAccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", ctx, userID, accountID, description, expiresAt)
+	varargs := []any{ctx, userID, accountID, description, expiresAt}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Generate", varargs...)
 	ret0, _ := ret[0].(*directaccesstoken.DirectAccessToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Generate indicates an expected call of Generate.
-func (mr *MockUsecaseMockRecorder) Generate(ctx, userID, accountID, description, expiresAt any) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) Generate(ctx, userID, accountID, description, expiresAt any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockUsecase)(nil).Generate), ctx, userID, accountID, description, expiresAt)
+	varargs := append([]any{ctx, userID, accountID, description, expiresAt}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockUsecase)(nil).Generate), varargs...)
 }
 
 // Get mocks base method.
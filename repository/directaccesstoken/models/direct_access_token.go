@@ -2,17 +2,37 @@ package models
 
 import (
 	"database/sql"
+	"net"
+	"strings"
 	"time"
+
+	"github.com/geniusrabbit/gosql/v2"
 )
 
 // DirectAccessToken represents a direct access token entity.
 type DirectAccessToken struct {
-	ID          uint64           `json:"id"`
-	Token       string           `json:"token"`
+	ID uint64 `json:"id"`
+
+	// Token contains the SHA-256 hash of the token, the plain value is
+	// available only in the object returned by the generation
+	Token string `json:"token"`
+
+	// TokenPrefix is the visible beginning of the token to identify it
+	TokenPrefix string `json:"token_prefix"`
+
 	Description string           `json:"description"`
 	UserID      sql.Null[uint64] `json:"user_id"`
 	AccountID   uint64           `json:"account_id"`
 
+	// Scopes is the list of RBAC permission patterns allowed for the token (empty - all rights of the owner)
+	Scopes gosql.NullableStringArray `json:"scopes,omitempty" gorm:"type:text[]"`
+
+	// AllowedIPs is the list of IP addresses or CIDR networks allowed to use the token (empty - any address)
+	AllowedIPs gosql.NullableStringArray `json:"allowed_ips,omitempty" gorm:"column:allowed_ips;type:text[]"`
+
+	LastUsedAt sql.Null[time.Time] `json:"last_used_at"`
+	LastUsedIP string              `json:"last_used_ip" gorm:"column:last_used_ip"`
+
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
@@ -26,3 +46,31 @@ func (m *DirectAccessToken) TableName() string {
 func (m *DirectAccessToken) RBACResourceName() string {
 	return "directaccesstoken"
 }
+
+// IsIPAllowed returns true if the token can be used from the IP address.
+func (m *DirectAccessToken) IsIPAllowed(ip string) bool {
+	if len(m.AllowedIPs) == 0 {
+		return true
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, allowed := range m.AllowedIPs {
+		if strings.Contains(allowed, "/") {
+			if _, network, err := net.ParseCIDR(allowed); err == nil && network.Contains(addr) {
+				return true
+			}
+		} else if allowedAddr := net.ParseIP(allowed); allowedAddr != nil && allowedAddr.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUsageOutdated returns true if the stored last usage is older than the interval
+// or was made from another IP address.
+func (m *DirectAccessToken) IsUsageOutdated(ip string, interval time.Duration) bool {
+	return !m.LastUsedAt.Valid || m.LastUsedIP != ip ||
+		time.Since(m.LastUsedAt.V) >= interval
+}
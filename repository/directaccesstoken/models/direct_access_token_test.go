@@ -0,0 +1,55 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestIsIPAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		ip      string
+		want    bool
+	}{
+		{name: "no list", ip: "10.0.0.1", want: true},
+		{name: "exact", allowed: []string{"10.0.0.1"}, ip: "10.0.0.1", want: true},
+		{name: "other address", allowed: []string{"10.0.0.1"}, ip: "10.0.0.2", want: false},
+		{name: "network", allowed: []string{"192.168.0.1", "10.0.0.0/8"}, ip: "10.1.2.3", want: true},
+		{name: "ipv6 network", allowed: []string{"2001:db8::/32"}, ip: "2001:db8::1", want: true},
+		{name: "empty address", allowed: []string{"10.0.0.0/8"}, ip: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &DirectAccessToken{AllowedIPs: tt.allowed}
+			if got := token.IsIPAllowed(tt.ip); got != tt.want {
+				t.Errorf("IsIPAllowed(%q) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUsageOutdated(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		lastUsed sql.Null[time.Time]
+		lastIP   string
+		ip       string
+		want     bool
+	}{
+		{name: "never used", ip: "10.0.0.1", want: true},
+		{name: "recent", lastUsed: sql.Null[time.Time]{V: now, Valid: true}, lastIP: "10.0.0.1", ip: "10.0.0.1", want: false},
+		{name: "recent other address", lastUsed: sql.Null[time.Time]{V: now, Valid: true}, lastIP: "10.0.0.1", ip: "10.0.0.2", want: true},
+		{name: "old", lastUsed: sql.Null[time.Time]{V: now.Add(-2 * time.Minute), Valid: true}, lastIP: "10.0.0.1", ip: "10.0.0.1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &DirectAccessToken{LastUsedAt: tt.lastUsed, LastUsedIP: tt.lastIP}
+			if got := token.IsUsageOutdated(tt.ip, time.Minute); got != tt.want {
+				t.Errorf("IsUsageOutdated(%q) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package directaccesstoken
+
+import (
+	"net"
+	"strings"
+
+	"github.com/demdxx/rbac"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidScope     = errors.New(`invalid permission scope`)
+	ErrScopeNotGranted  = errors.New(`permission scope is out of the owner rights`)
+	ErrScopeRequired    = errors.New(`permission scope is required in the session of the scoped token`)
+	ErrInvalidAllowedIP = errors.New(`invalid allowed IP address or network`)
+)
+
+// GenerateOptions contains the restrictions of the new token
+type GenerateOptions struct {
+	// Scopes is the list of RBAC permission patterns (empty - all rights of the owner)
+	Scopes []string
+
+	// AllowedIPs is the list of IP addresses or CIDR networks (empty - any address)
+	AllowedIPs []string
+}
+
+// GenerateOption configures the new token
+type GenerateOption func(*GenerateOptions)
+
+// WithScopes limits the token rights by the RBAC permission patterns.
+// The token never has more rights than its owner.
+func WithScopes(scopes ...string) GenerateOption {
+	return func(opts *GenerateOptions) { opts.Scopes = append(opts.Scopes, scopes...) }
+}
+
+// WithAllowedIPs limits the addresses which can use the token
+func WithAllowedIPs(ips ...string) GenerateOption {
+	return func(opts *GenerateOptions) { opts.AllowedIPs = append(opts.AllowedIPs, ips...) }
+}
+
+// NewGenerateOptions applies the options
+func NewGenerateOptions(opts ...GenerateOption) *GenerateOptions {
+	options := &GenerateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// Validate the scope patterns and the allowed addresses
+func (opts *GenerateOptions) Validate() error {
+	for _, scope := range opts.Scopes {
+		if strings.TrimSpace(scope) == "" {
+			return errors.Wrap(ErrInvalidScope, `empty scope`)
+		}
+		if _, err := rbac.MatchName(scope, "validate"); err != nil {
+			return errors.Wrap(ErrInvalidScope, scope)
+		}
+	}
+	for _, ip := range opts.AllowedIPs {
+		if _, _, err := net.ParseCIDR(ip); err == nil {
+			continue
+		}
+		if net.ParseIP(ip) == nil {
+			return errors.Wrap(ErrInvalidAllowedIP, ip)
+		}
+	}
+	return nil
+}
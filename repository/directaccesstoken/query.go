@@ -17,7 +17,7 @@ type Order = models.Order
 // Filter defines query filters for direct access tokens
 type Filter struct {
 	ID           []uint64  // Filter by token IDs
-	Token        []string  // Filter by token strings
+	Token        []string  // Filter by plain token strings
 	UserID       []uint64  // Filter by user IDs
 	AccountID    []uint64  // Filter by account IDs
 	MinExpiresAt time.Time // Minimum expiration time
@@ -33,7 +33,11 @@ func (fl *Filter) PrepareQuery(query *gorm.DB) *gorm.DB {
 		query = query.Where(`id IN (?)`, fl.ID)
 	}
 	if len(fl.Token) > 0 {
-		query = query.Where(`token IN (?)`, fl.Token)
+		hashes := make([]string, 0, len(fl.Token))
+		for _, token := range fl.Token {
+			hashes = append(hashes, HashToken(token))
+		}
+		query = query.Where(`token IN (?)`, hashes)
 	}
 	if len(fl.UserID) > 0 {
 		query = query.Where(`user_id IN (?)`, fl.UserID)
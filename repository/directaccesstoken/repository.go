@@ -12,7 +12,7 @@ type Repository interface {
 	// Get retrieves a direct access token by its ID.
 	Get(ctx context.Context, id uint64) (*DirectAccessToken, error)
 
-	// GetByToken retrieves a direct access token by its token string.
+	// GetByToken retrieves a non-expired direct access token by its plain token string.
 	GetByToken(ctx context.Context, token string) (*DirectAccessToken, error)
 
 	// FetchList retrieves a paginated list of direct access tokens matching the filter and order criteria.
@@ -22,7 +22,11 @@ type Repository interface {
 	Count(ctx context.Context, opts ...QOption) (int64, error)
 
 	// Generate creates and stores a new direct access token for the specified user and account.
-	Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time) (*DirectAccessToken, error)
+	// The returned object contains the plain token, only its hash is stored.
+	Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time, opts ...GenerateOption) (*DirectAccessToken, error)
+
+	// MarkUsed stores the time and the address of the last token usage.
+	MarkUsed(ctx context.Context, id uint64, ip string) error
 
 	// Revoke invalidates direct access tokens matching the filter criteria.
 	Revoke(ctx context.Context, opts ...QOption) error
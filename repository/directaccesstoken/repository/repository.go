@@ -31,11 +31,12 @@ func (r *Repository) Get(ctx context.Context, id uint64) (*models.DirectAccessTo
 	return object, nil
 }
 
-// GetByToken retrieves a non-expired direct access token by its token value.
+// GetByToken retrieves a non-expired direct access token by its plain token value.
+// Returns gorm.ErrRecordNotFound if the token doesn't exist or expired.
 func (r *Repository) GetByToken(ctx context.Context, token string) (*models.DirectAccessToken, error) {
 	object := new(models.DirectAccessToken)
 	err := r.Slave(ctx).Model(object).
-		Find(object, "token=? AND expires_at>NOW()", token).Error
+		First(object, "token=? AND expires_at>NOW()", directaccesstoken.HashToken(token)).Error
 	if err != nil {
 		return nil, err
 	}
@@ -67,17 +68,23 @@ func (r *Repository) Count(ctx context.Context, opts ...directaccesstoken.QOptio
 }
 
 // Generate creates and stores a new direct access token with the specified parameters.
-func (r *Repository) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time) (*models.DirectAccessToken, error) {
+// Only the hash of the token is stored, the returned object contains the plain token.
+func (r *Repository) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time, opts ...directaccesstoken.GenerateOption) (*models.DirectAccessToken, error) {
 	token, err := directaccesstoken.GenerateToken(32)
 	if err != nil {
 		return nil, err
 	}
+	token = directaccesstoken.TokenPrefix + token
+	options := directaccesstoken.NewGenerateOptions(opts...)
 
 	object := &models.DirectAccessToken{
-		Token:       token,
+		Token:       directaccesstoken.HashToken(token),
+		TokenPrefix: directaccesstoken.VisiblePrefix(token),
 		Description: description,
 		UserID:      sql.Null[uint64]{V: userID, Valid: userID > 0},
 		AccountID:   accountID,
+		Scopes:      options.Scopes,
+		AllowedIPs:  options.AllowedIPs,
 		CreatedAt:   time.Now(),
 		ExpiresAt:   expiresAt,
 	}
@@ -86,9 +93,20 @@ func (r *Repository) Generate(ctx context.Context, userID, accountID uint64, des
 		return nil, err
 	}
 
+	object.Token = token
 	return object, nil
 }
 
+// MarkUsed stores the time and the address of the last token usage.
+func (r *Repository) MarkUsed(ctx context.Context, id uint64, ip string) error {
+	return r.Master(ctx).Model(&models.DirectAccessToken{}).
+		Where(`id=?`, id).
+		UpdateColumns(map[string]any{
+			"last_used_at": time.Now(),
+			"last_used_ip": ip,
+		}).Error
+}
+
 // Revoke invalidates direct access tokens by setting their expiration to the past.
 func (r *Repository) Revoke(ctx context.Context, opts ...directaccesstoken.QOption) error {
 	query := r.Master(ctx).Model(&models.DirectAccessToken{})
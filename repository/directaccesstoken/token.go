@@ -2,6 +2,17 @@ package directaccesstoken
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// TokenPrefix marks the direct access tokens to distinguish them from other tokens
+	TokenPrefix = "dat_"
+
+	// visiblePrefixSize is the number of the random symbols stored in plain with the token hash
+	visiblePrefixSize = 8
 )
 
 // GenerateToken generates a random token of specified length in bytes
@@ -19,6 +30,29 @@ func GenerateToken(length int) (string, error) {
 	return base86(token), nil
 }
 
+// HashToken returns the SHA-256 hash of the token which is stored in the database
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// VisiblePrefix returns the part of the token which is safe to show for identification
+func VisiblePrefix(token string) string {
+	size := len(TokenPrefix) + visiblePrefixSize
+	if !strings.HasPrefix(token, TokenPrefix) {
+		size = visiblePrefixSize
+	}
+	if len(token) < size {
+		return token
+	}
+	return token[:size]
+}
+
+// IsDirectAccessToken returns true if the token has the direct access token format
+func IsDirectAccessToken(token string) bool {
+	return strings.HasPrefix(token, TokenPrefix)
+}
+
 func base86(data []byte) string {
 	const base86Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
 	const base86CharsLen = byte(len(base86Chars))
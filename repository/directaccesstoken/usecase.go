@@ -19,7 +19,7 @@ type Usecase interface {
 	Count(ctx context.Context, opts ...QOption) (int64, error)
 
 	// Generate creates a new direct access token for the specified user and account.
-	Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time) (*DirectAccessToken, error)
+	Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time, opts ...GenerateOption) (*DirectAccessToken, error)
 
 	// Revoke deactivates direct access tokens matching the query options.
 	Revoke(ctx context.Context, opts ...QOption) error
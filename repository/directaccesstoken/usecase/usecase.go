@@ -3,14 +3,18 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"slices"
 	"time"
 
+	"github.com/demdxx/rbac"
 	"github.com/pkg/errors"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
 	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken"
 	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken/models"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
 )
 
 // Usecase handles business logic for direct access tokens.
@@ -58,14 +62,31 @@ func (u *Usecase) Count(ctx context.Context, opts ...directaccesstoken.QOption)
 }
 
 // Generate creates a new direct access token.
-func (u *Usecase) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time) (*models.DirectAccessToken, error) {
+// Every scope of the token has to match some permission of the current account,
+// in the session of the scoped token the scopes have to be inside its restrictions.
+func (u *Usecase) Generate(ctx context.Context, userID, accountID uint64, description string, expiresAt time.Time, opts ...directaccesstoken.GenerateOption) (*models.DirectAccessToken, error) {
 	if !acl.HaveAccessCreate(ctx, &models.DirectAccessToken{
 		UserID:    sql.Null[uint64]{V: userID, Valid: userID > 0},
 		AccountID: accountID,
 	}) {
 		return nil, errors.Wrap(acl.ErrNoPermissions, "generate access token")
 	}
-	return u.repo.Generate(ctx, userID, accountID, description, expiresAt)
+	options := directaccesstoken.NewGenerateOptions(opts...)
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	for _, scope := range options.Scopes {
+		if !acl.HasPermission(ctx, scope) {
+			return nil, errors.Wrap(directaccesstoken.ErrScopeNotGranted, scope)
+		}
+	}
+	if err := checkRestrictions(ctx, options.Scopes); err != nil {
+		return nil, err
+	}
+	if err := quota.Check(ctx, accountID, (*models.DirectAccessToken)(nil).RBACResourceName(), 1); err != nil {
+		return nil, err
+	}
+	return u.repo.Generate(ctx, userID, accountID, description, expiresAt, opts...)
 }
 
 // Revoke revokes direct access tokens matching the filter criteria.
@@ -78,3 +99,27 @@ func (u *Usecase) Revoke(ctx context.Context, opts ...directaccesstoken.QOption)
 	}
 	return u.repo.Revoke(ctx, opts...)
 }
+
+// checkRestrictions of the current session, the new token can't be wider than the token
+// which opened the session, so every scope has to match the patterns of each restriction
+func checkRestrictions(ctx context.Context, scopes []string) error {
+	restrictor, ok := session.Account(ctx).(account.PermissionRestrictor)
+	if !ok || len(restrictor.PermissionRestrictions()) == 0 {
+		return nil
+	}
+	if len(scopes) == 0 {
+		return directaccesstoken.ErrScopeRequired
+	}
+	for _, patterns := range restrictor.PermissionRestrictions() {
+		for _, scope := range scopes {
+			inScope := slices.ContainsFunc(patterns, func(pattern string) bool {
+				ok, _ := rbac.MatchName(pattern, scope)
+				return ok
+			})
+			if !inScope {
+				return errors.Wrap(directaccesstoken.ErrScopeNotGranted, scope)
+			}
+		}
+	}
+	return nil
+}
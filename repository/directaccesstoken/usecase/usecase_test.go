@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/demdxx/rbac"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/account"
+	accountModels "github.com/geniusrabbit/blaze-api/repository/account/models"
+	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken"
+	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/directaccesstoken/models"
+)
+
+type testUsecaseSuite struct {
+	suite.Suite
+
+	ctx  context.Context
+	repo *mocks.MockRepository
+	uc   *Usecase
+}
+
+func (s *testUsecaseSuite) SetupTest() {
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.repo = mocks.NewMockRepository(gomock.NewController(s.T()))
+	s.uc = New(s.repo)
+}
+
+// scopedContext of the session opened by the token with the scopes,
+// the owner can view and update the users and generate the tokens
+func (s *testUsecaseSuite) scopedContext(scopes ...string) context.Context {
+	ctx := session.WithUserAccountDevelop(context.TODO())
+	acc := session.Account(ctx).(interface {
+		account.PermissionRestrictor
+		SetPermissions(perm accountModels.PermissionChecker)
+	})
+	acc.SetPermissions(rbac.MustNewRole("owner", rbac.WithPermissions(
+		rbac.MustNewSimplePermission("user.view.all"),
+		rbac.MustNewSimplePermission("user.update.all"),
+		rbac.MustNewResourcePermission("create.all", &models.DirectAccessToken{}),
+	)))
+	acc.RestrictPermissions(scopes...)
+	return ctx
+}
+
+func (s *testUsecaseSuite) TestGenerate() {
+	expiresAt := time.Now().Add(time.Hour)
+	s.repo.EXPECT().Generate(s.ctx, uint64(1), uint64(1), "ci", expiresAt, gomock.Any()).
+		Return(&models.DirectAccessToken{ID: 3}, nil)
+
+	token, err := s.uc.Generate(s.ctx, 1, 1, "ci", expiresAt)
+	s.NoError(err)
+	s.Equal(uint64(3), token.ID)
+}
+
+func (s *testUsecaseSuite) TestGenerateScopedSession() {
+	ctx := s.scopedContext("user.view.*", "directaccesstoken.create.*")
+	expiresAt := time.Now().Add(time.Hour)
+
+	s.Run("Unscoped", func() {
+		_, err := s.uc.Generate(ctx, 1, 1, "ci", expiresAt)
+		s.ErrorIs(err, directaccesstoken.ErrScopeRequired)
+	})
+	s.Run("Wider", func() {
+		_, err := s.uc.Generate(ctx, 1, 1, "ci", expiresAt, directaccesstoken.WithScopes("user.**"))
+		s.ErrorIs(err, directaccesstoken.ErrScopeNotGranted)
+	})
+	s.Run("Inside", func() {
+		s.repo.EXPECT().Generate(ctx, uint64(1), uint64(1), "ci", expiresAt, gomock.Any()).
+			Return(&models.DirectAccessToken{ID: 4}, nil)
+		_, err := s.uc.Generate(ctx, 1, 1, "ci", expiresAt, directaccesstoken.WithScopes("user.view.*"))
+		s.NoError(err)
+	})
+}
+
+func TestUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(testUsecaseSuite))
+}
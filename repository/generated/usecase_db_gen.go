@@ -5,6 +5,7 @@ import (
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
 	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
 	"github.com/go-faster/errors"
 )
 
@@ -68,6 +69,10 @@ func (u *Usecase[T, TID]) Create(ctx context.Context, obj *T, opts ...Option) (i
 	if !acl.HaveAccessCreate(ctx, obj) {
 		return id, acl.ErrNoPermissions.WithMessage("create")
 	}
+	// Check the quota of the owning account (no-op for models without the owner account).
+	if err = quota.CheckObject(ctx, obj, 1); err != nil {
+		return id, err
+	}
 	// New entities start in Pending status (no-op for models without approval workflow).
 	setModelApproveStatus(obj, pkgModels.PendingApproveStatus)
 	return u.Repo.Create(ctx, obj, opts...)
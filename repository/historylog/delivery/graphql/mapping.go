@@ -22,6 +22,7 @@ func FromHistoryAction(action *historylogModels.HistoryAction) *gqlmodels.Histor
 
 		UserID:    action.UserID,
 		AccountID: action.AccountID,
+		ActorID:   action.ActorID,
 
 		ObjectID:   action.ObjectID,
 		ObjectIDs:  action.ObjectIDs,
@@ -47,6 +48,7 @@ func HistoryActionFilter(filter *gqlmodels.HistoryActionListFilter) *historylog.
 		RequestID:   filter.RequestID,
 		UserID:      filter.UserID,
 		AccountID:   filter.AccountID,
+		ActorID:     filter.ActorID,
 		ObjectID:    filter.ObjectID,
 		ObjectIDStr: filter.ObjectIDs,
 		ObjectType:  filter.ObjectType,
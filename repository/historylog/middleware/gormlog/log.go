@@ -94,6 +94,7 @@ func Log(db *gorm.DB, name string) func(*gorm.DB) {
 			Message:    historylog.MessageFromContext(ctx),
 			UserID:     user.GetID(),
 			AccountID:  acc.GetID(),
+			ActorID:    session.ImpersonatorID(ctx),
 			ObjectType: cdb.Statement.Schema.Name,
 			ObjectID:   gocast.Uint64(pkVal),
 			ObjectIDs:  gocast.Str(pkVal),
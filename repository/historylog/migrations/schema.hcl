@@ -24,6 +24,11 @@ table "history_actions" {
     null = false
     type = bigint
   }
+  column "actor_id" {
+    null    = false
+    type    = bigint
+    default = 0
+  }
   column "name" {
     null = false
     type = character_varying(255)
@@ -58,6 +63,9 @@ table "history_actions" {
   index "idx_history_actions_account_id" {
     columns = [column.account_id]
   }
+  index "idx_history_actions_actor_id" {
+    columns = [column.actor_id]
+  }
   index "idx_history_actions_at" {
     columns = [column.action_at]
   }
@@ -16,6 +16,9 @@ type HistoryAction struct {
 	UserID    uint64 `json:"user_id" gorm:"index:idx_history_actions_user_id;not null;"`
 	AccountID uint64 `json:"account_id" gorm:"index:idx_history_actions_account_id;not null;"`
 
+	// ActorID is the real user who acted on behalf of the user by the impersonation (0 - none)
+	ActorID uint64 `json:"actor_id" gorm:"index:idx_history_actions_actor_id;not null;default:0;"`
+
 	Name    string `gorm:"type:varchar(255);not null;index:idx_history_actions_name;"`
 	Message string `gorm:"type:text;not null;"`
 
@@ -1,13 +1,19 @@
 package historylog
 
 import (
+	"context"
+	"errors"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/geniusrabbit/blaze-api/repository"
+	accountCtx "github.com/geniusrabbit/blaze-api/repository/account/context"
 	"github.com/geniusrabbit/blaze-api/repository/option/models"
 )
 
+var errFilterTooWide = errors.New("list log items (too wide filter)")
+
 // Filter represents query filters for history log objects.
 type Filter struct {
 	ID          []uuid.UUID // Filter by history log IDs
@@ -15,6 +21,7 @@ type Filter struct {
 	Name        []string    // Filter by names
 	UserID      []uint64    // Filter by user IDs
 	AccountID   []uint64    // Filter by account IDs
+	ActorID     []uint64    // Filter by the real actors of the impersonated sessions
 	ObjectID    []uint64    // Filter by numeric object IDs
 	ObjectIDStr []string    // Filter by string object IDs
 	ObjectType  []string    // Filter by object types
@@ -40,6 +47,9 @@ func (filter *Filter) PrepareQuery(query *gorm.DB) *gorm.DB {
 	if len(filter.AccountID) > 0 {
 		query = query.Where(`account_id IN (?)`, filter.AccountID)
 	}
+	if len(filter.ActorID) > 0 {
+		query = query.Where(`actor_id IN (?)`, filter.ActorID)
+	}
 	if len(filter.ObjectID) > 0 {
 		query = query.Where(`object_id IN (?)`, filter.ObjectID)
 	}
@@ -52,6 +62,24 @@ func (filter *Filter) PrepareQuery(query *gorm.DB) *gorm.DB {
 	return query
 }
 
+// AdjustPermissions narrows the filter to the session account,
+// the sub-accounts inheriting the permissions can be requested explicitly.
+func (filter *Filter) AdjustPermissions(ctx context.Context) error {
+	if len(filter.AccountID) == 0 {
+		acc := accountCtx.SessionAccount(ctx)
+		if acc == nil {
+			return errFilterTooWide
+		}
+		filter.AccountID = []uint64{acc.GetID()}
+	}
+	for _, id := range filter.AccountID {
+		if !accountCtx.InSessionScope(ctx, id) {
+			return errFilterTooWide
+		}
+	}
+	return nil
+}
+
 // Order defines sorting options for history log queries.
 type Order struct {
 	ID          models.Order // Sort by ID
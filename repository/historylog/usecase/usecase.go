@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/repository/historylog"
 	historylogModels "github.com/geniusrabbit/blaze-api/repository/historylog/models"
 )
@@ -25,16 +26,18 @@ func NewUsecase(repo historylog.Repository) *HistoryUsecase {
 
 // Count of roles by filter
 func (a *HistoryUsecase) Count(ctx context.Context, opts ...historylog.QOption) (int64, error) {
-	if !acl.HaveAccessList(ctx, &historylogModels.HistoryAction{}) {
-		return 0, errors.Wrap(acl.ErrNoPermissions, "list log items")
+	opts, err := a.listOptions(ctx, opts)
+	if err != nil {
+		return 0, err
 	}
 	return a.repo.Count(ctx, opts...)
 }
 
 // FetchList of roles by filter
 func (a *HistoryUsecase) FetchList(ctx context.Context, opts ...historylog.QOption) ([]*historylogModels.HistoryAction, error) {
-	if !acl.HaveAccessList(ctx, &historylogModels.HistoryAction{}) {
-		return nil, errors.Wrap(acl.ErrNoPermissions, "list log items")
+	opts, err := a.listOptions(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
 	list, err := a.repo.FetchList(ctx, opts...)
 	for _, link := range list {
@@ -44,3 +47,19 @@ func (a *HistoryUsecase) FetchList(ctx context.Context, opts ...historylog.QOpti
 	}
 	return list, err
 }
+
+// listOptions narrows the query to the session account and its sub-accounts
+// if the user has no access to the whole history
+func (a *HistoryUsecase) listOptions(ctx context.Context, opts []historylog.QOption) ([]historylog.QOption, error) {
+	if acl.HaveAccessList(ctx, &historylogModels.HistoryAction{}) {
+		return opts, nil
+	}
+	if !acl.HaveAccessList(ctx, &historylogModels.HistoryAction{AccountID: session.AccountID(ctx)}) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, "list log items")
+	}
+	opts, err := historylog.ListOptions(opts).WithPermissions(ctx, &historylog.Filter{})
+	if err != nil {
+		return nil, errors.Wrap(acl.ErrNoPermissions, err.Error())
+	}
+	return opts, nil
+}
@@ -0,0 +1,47 @@
+package historylog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/google/uuid"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/database"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	historylogModels "github.com/geniusrabbit/blaze-api/repository/historylog/models"
+)
+
+var errNoDatabase = errors.New(`history log: database is not defined in the context`)
+
+// Write custom action to the history log.
+// It's used for the events which are not linked to the object modification
+// (security events, business actions, etc.), the message is taken from the context.
+func Write(ctx context.Context, name, objectType string, objectID any, data map[string]any) error {
+	db := database.Master(ctx)
+	if db == nil {
+		return errNoDatabase
+	}
+	user, acc := session.UserAccount(ctx)
+	jdata, _ := gosql.NewNullableJSON[map[string]any](data)
+	if jdata == nil {
+		jdata = &gosql.NullableJSON[map[string]any]{}
+	}
+	return db.WithContext(ctx).Create(&historylogModels.HistoryAction{
+		ID:         uuid.New(),
+		RequestID:  requestid.Get(ctx),
+		Name:       name,
+		Message:    MessageFromContext(ctx),
+		UserID:     user.GetID(),
+		AccountID:  acc.GetID(),
+		ActorID:    session.ImpersonatorID(ctx),
+		ObjectType: objectType,
+		ObjectID:   gocast.Uint64(objectID),
+		ObjectIDs:  gocast.Str(objectID),
+		Data:       *jdata,
+		ActionAt:   time.Now(),
+	}).Error
+}
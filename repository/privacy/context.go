@@ -0,0 +1,16 @@
+package privacy
+
+import "context"
+
+var ctxUsecaseKey = &struct{ s string }{"privacy"}
+
+// WithUsecase puts the privacy usecase to the context
+func WithUsecase(ctx context.Context, uc Usecase) context.Context {
+	return context.WithValue(ctx, ctxUsecaseKey, uc)
+}
+
+// Get the privacy usecase from the context, nil if the data lifecycle is not enabled
+func Get(ctx context.Context) Usecase {
+	uc, _ := ctx.Value(ctxUsecaseKey).(Usecase)
+	return uc
+}
@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	"github.com/geniusrabbit/blaze-api/repository/privacy"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+	"github.com/geniusrabbit/blaze-api/server/graphql/types"
+)
+
+// QueryResolver implements GQL API methods
+type QueryResolver struct {
+	uc privacy.Usecase
+}
+
+// NewQueryResolver returns new API resolver, without usecase it uses the one from the context
+func NewQueryResolver(uc privacy.Usecase) *QueryResolver {
+	return &QueryResolver{uc: uc}
+}
+
+// Export is the resolver for the exportUserData field.
+func (r *QueryResolver) Export(ctx context.Context, userID *uint64) (*types.JSON, error) {
+	uc, err := r.usecase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := session.UserID(ctx)
+	if userID != nil && *userID > 0 {
+		id = *userID
+	}
+	archive, err := uc.Export(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return types.JSONFrom(archive)
+}
+
+// Erase is the resolver for the eraseUserData field.
+func (r *QueryResolver) Erase(ctx context.Context, userID uint64) (*gqlmodels.StatusResponse, error) {
+	uc, err := r.usecase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.Erase(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           gqlmodels.ResponseStatusSuccess,
+	}, nil
+}
+
+func (r *QueryResolver) usecase(ctx context.Context) (privacy.Usecase, error) {
+	if r.uc != nil {
+		return r.uc, nil
+	}
+	if uc := privacy.Get(ctx); uc != nil {
+		return uc, nil
+	}
+	return nil, privacy.ErrNotConfigured
+}
@@ -0,0 +1,6 @@
+package privacy
+
+import "errors"
+
+// ErrNotConfigured is returned if the personal data sources are not configured
+var ErrNotConfigured = errors.New("personal data export and erasure is not configured")
@@ -0,0 +1,46 @@
+// Package privacy implements the lifecycle of the personal data of the users:
+// the export of everything tied to the user into the JSON archive
+// and the erasure which anonymizes the personal data keeping the referential integrity.
+//
+// The data is collected and erased by the list of the sources,
+// every source is responsible for one section of the archive (user row, members, tokens, etc.)
+package privacy
+
+import (
+	"context"
+	"time"
+)
+
+// Source of the personal data of the user
+type Source interface {
+	// Name of the archive section
+	Name() string
+
+	// Export returns the data of the user for the archive
+	Export(ctx context.Context, userID uint64) (any, error)
+
+	// Erase removes or anonymizes the data of the user
+	Erase(ctx context.Context, userID uint64) error
+}
+
+// Archive with all the data tied to the user
+type Archive struct {
+	UserID     uint64         `json:"user_id"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Sections   map[string]any `json:"sections"`
+}
+
+// Subject of the data lifecycle operations, used for the access check
+type Subject struct {
+	UserID uint64
+}
+
+// CreatorUserID returns the user which owns the data
+func (s *Subject) CreatorUserID() uint64 {
+	return s.UserID
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (s *Subject) RBACResourceName() string {
+	return "privacy"
+}
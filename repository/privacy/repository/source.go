@@ -0,0 +1,99 @@
+// Package repository implements the table sources of the personal data
+package repository
+
+import (
+	"context"
+	"reflect"
+
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/repository"
+)
+
+// Option of the table source
+type Option func(*TableSource)
+
+// WithScopes narrows the rows of the user
+func WithScopes(scopes ...func(*gorm.DB) *gorm.DB) Option {
+	return func(s *TableSource) {
+		s.scopes = append(s.scopes, scopes...)
+	}
+}
+
+// WithOmit excludes the secret columns (password hashes, tokens) from the export
+func WithOmit(columns ...string) Option {
+	return func(s *TableSource) {
+		s.omit = append(s.omit, columns...)
+	}
+}
+
+// WithAnonymize replaces the values of the columns on the erasure,
+// the values can be the SQL expressions (gorm.Expr)
+func WithAnonymize(values map[string]any) Option {
+	return func(s *TableSource) {
+		s.anonymize = values
+	}
+}
+
+// WithDelete removes the rows of the user on the erasure
+// (soft delete for the models with the DeletedAt field)
+func WithDelete() Option {
+	return func(s *TableSource) {
+		s.delete = true
+	}
+}
+
+// TableSource of the user data stored in the table of the model
+type TableSource struct {
+	repository.Repository
+	name      string
+	model     any
+	condition string
+	scopes    []func(*gorm.DB) *gorm.DB
+	omit      []string
+	anonymize map[string]any
+	delete    bool
+}
+
+// NewTableSource of the model rows selected by the condition with the user ID placeholder,
+// e.g. `user_id=?`. Without the erasure options the rows are kept as is.
+func NewTableSource(name string, model any, condition string, opts ...Option) *TableSource {
+	s := &TableSource{name: name, model: model, condition: condition}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Name of the archive section
+func (s *TableSource) Name() string {
+	return s.name
+}
+
+// Export returns the list of the model rows of the user
+func (s *TableSource) Export(ctx context.Context, userID uint64) (any, error) {
+	list := reflect.New(reflect.SliceOf(reflect.TypeOf(s.model)))
+	query := s.Slave(ctx).Model(s.model).Scopes(s.scopes...).Where(s.condition, userID)
+	if len(s.omit) > 0 {
+		query = query.Omit(s.omit...)
+	}
+	if err := query.Find(list.Interface()).Error; err != nil {
+		return nil, err
+	}
+	return list.Elem().Interface(), nil
+}
+
+// Erase anonymizes and removes the model rows of the user
+func (s *TableSource) Erase(ctx context.Context, userID uint64) error {
+	if len(s.anonymize) > 0 {
+		err := s.Master(ctx).Model(s.model).Scopes(s.scopes...).
+			Where(s.condition, userID).Updates(s.anonymize).Error
+		if err != nil {
+			return err
+		}
+	}
+	if s.delete {
+		return s.Master(ctx).Scopes(s.scopes...).Where(s.condition, userID).Delete(s.model).Error
+	}
+	return nil
+}
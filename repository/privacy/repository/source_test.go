@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/repository/testsuite"
+)
+
+type testToken struct {
+	ID        uint64
+	UserID    uint64
+	Token     string
+	Note      string
+	DeletedAt gorm.DeletedAt
+}
+
+func (t *testToken) TableName() string { return "test_token" }
+
+type testSuite struct {
+	testsuite.DatabaseSuite
+}
+
+func (s *testSuite) TestExport() {
+	s.Mock.ExpectQuery(`SELECT "test_token"."id","test_token"."user_id","test_token"."note","test_token"."deleted_at" FROM "test_token"`).
+		WithArgs(uint64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "note"}).
+			AddRow(1, 10, "first").
+			AddRow(2, 10, "second"))
+
+	source := NewTableSource("tokens", &testToken{}, `user_id=?`, WithOmit("token"))
+	data, err := source.Export(s.Ctx, 10)
+	s.NoError(err)
+	s.Equal("tokens", source.Name())
+	if s.IsType([]*testToken{}, data) {
+		s.Len(data.([]*testToken), 2)
+		s.Equal("second", data.([]*testToken)[1].Note)
+	}
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *testSuite) TestErase() {
+	s.Mock.ExpectExec(`UPDATE "test_token" SET "note"=\$1 WHERE user_id=\$2`).
+		WithArgs("", uint64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.Mock.ExpectExec(`UPDATE "test_token" SET "deleted_at"=\$1 WHERE user_id=\$2`).
+		WithArgs(sqlmock.AnyArg(), uint64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	source := NewTableSource("tokens", &testToken{}, `user_id=?`,
+		WithAnonymize(map[string]any{"note": ""}), WithDelete())
+	s.NoError(source.Erase(s.Ctx, 10))
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *testSuite) TestEraseKeep() {
+	source := NewTableSource("history", &testToken{}, `user_id=?`)
+	s.NoError(source.Erase(s.Ctx, 10))
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func TestTableSourceSuite(t *testing.T) {
+	suite.Run(t, &testSuite{})
+}
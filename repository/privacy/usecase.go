@@ -0,0 +1,14 @@
+package privacy
+
+import "context"
+
+// Usecase of the personal data export and erasure
+//
+//go:generate mockgen -source $GOFILE -package mocks -destination mocks/usecase.go
+type Usecase interface {
+	// Export collects all the data of the user from every source into the archive.
+	Export(ctx context.Context, userID uint64) (*Archive, error)
+
+	// Erase anonymizes the personal data of the user in every source in one transaction.
+	Erase(ctx context.Context, userID uint64) error
+}
@@ -0,0 +1,74 @@
+// Package usecase implements the export and the erasure jobs of the personal data
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/context/database"
+	"github.com/geniusrabbit/blaze-api/repository/historylog"
+	"github.com/geniusrabbit/blaze-api/repository/privacy"
+)
+
+// Usecase runs the data lifecycle jobs over the list of the sources
+type Usecase struct {
+	sources []privacy.Source
+}
+
+// New privacy usecase, the sources are erased in the given order
+func New(sources ...privacy.Source) *Usecase {
+	return &Usecase{sources: sources}
+}
+
+// Export collects all the data of the user from every source into the archive
+func (u *Usecase) Export(ctx context.Context, userID uint64) (*privacy.Archive, error) {
+	if !acl.HaveObjectPermissions(ctx, &privacy.Subject{UserID: userID}, `export.*`) {
+		return nil, acl.ErrNoPermissions.WithMessage("export user data")
+	}
+	archive := &privacy.Archive{
+		UserID:     userID,
+		ExportedAt: time.Now(),
+		Sections:   make(map[string]any, len(u.sources)),
+	}
+	for _, source := range u.sources {
+		data, err := source.Export(ctx, userID)
+		if err != nil {
+			return nil, errors.Wrap(err, "export "+source.Name())
+		}
+		archive.Sections[source.Name()] = data
+	}
+	u.log(ctx, "privacy.export", userID)
+	return archive, nil
+}
+
+// Erase anonymizes the personal data of the user in every source in one transaction
+func (u *Usecase) Erase(ctx context.Context, userID uint64) error {
+	if !acl.HaveObjectPermissions(ctx, &privacy.Subject{UserID: userID}, `erase.*`) {
+		return acl.ErrNoPermissions.WithMessage("erase user data")
+	}
+	err := database.ContextTransactionExec(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		for _, source := range u.sources {
+			if err := source.Erase(ctx, userID); err != nil {
+				return errors.Wrap(err, "erase "+source.Name())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	u.log(ctx, "privacy.erase", userID)
+	return nil
+}
+
+func (u *Usecase) log(ctx context.Context, action string, userID uint64) {
+	if err := historylog.Write(ctx, action, "user", userID, nil); err != nil {
+		ctxlogger.Get(ctx).Error("log "+action, zap.Uint64("user_id", userID), zap.Error(err))
+	}
+}
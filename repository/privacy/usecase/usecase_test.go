@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/testsuite"
+)
+
+type testSource struct {
+	name   string
+	data   any
+	err    error
+	erased []uint64
+}
+
+func (s *testSource) Name() string { return s.name }
+
+func (s *testSource) Export(context.Context, uint64) (any, error) {
+	return s.data, s.err
+}
+
+func (s *testSource) Erase(_ context.Context, userID uint64) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.erased = append(s.erased, userID)
+	return nil
+}
+
+type testSuite struct {
+	testsuite.DatabaseSuite
+}
+
+func (s *testSuite) SetupSuite() {
+	s.DatabaseSuite.SetupSuite()
+	s.Ctx = session.WithUserAccountDevelop(s.Ctx)
+}
+
+func (s *testSuite) TestExport() {
+	uc := New(
+		&testSource{name: "user", data: map[string]any{"id": 10}},
+		&testSource{name: "tokens", data: []string{}},
+	)
+	archive, err := uc.Export(s.Ctx, 10)
+	s.NoError(err)
+	s.Equal(uint64(10), archive.UserID)
+	s.Len(archive.Sections, 2)
+	s.Equal(map[string]any{"id": 10}, archive.Sections["user"])
+
+	_, err = New(&testSource{name: "user", err: errors.New("broken")}).Export(s.Ctx, 10)
+	s.ErrorContains(err, "export user")
+}
+
+func (s *testSuite) TestErase() {
+	user, tokens := &testSource{name: "user"}, &testSource{name: "tokens"}
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectCommit()
+	s.NoError(New(user, tokens).Erase(s.Ctx, 10))
+	s.Equal([]uint64{10}, user.erased)
+	s.Equal([]uint64{10}, tokens.erased)
+}
+
+func (s *testSuite) TestEraseRollback() {
+	user := &testSource{name: "user"}
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectRollback()
+	err := New(user, &testSource{name: "tokens", err: errors.New("broken")}).Erase(s.Ctx, 10)
+	s.ErrorContains(err, "erase tokens")
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func TestPrivacySuite(t *testing.T) {
+	suite.Run(t, &testSuite{})
+}
@@ -0,0 +1,38 @@
+package quota
+
+import "context"
+
+var ctxUsecaseKey = &struct{ s string }{"quota"}
+
+// WithUsecase puts the quota usecase to the context
+func WithUsecase(ctx context.Context, uc Usecase) context.Context {
+	return context.WithValue(ctx, ctxUsecaseKey, uc)
+}
+
+// Get the quota usecase from the context, nil if quotas are not enabled
+func Get(ctx context.Context) Usecase {
+	uc, _ := ctx.Value(ctxUsecaseKey).(Usecase)
+	return uc
+}
+
+// Check the account quota of the resource, no-op if quotas are not enabled.
+// The usage is counted before the objects are created and nothing is reserved,
+// so the concurrent requests can exceed the limit by the number of the requests.
+func Check(ctx context.Context, accountID uint64, resource string, count int64) error {
+	uc := Get(ctx)
+	if uc == nil || accountID == 0 || count <= 0 {
+		return nil
+	}
+	return uc.Check(ctx, accountID, resource, count)
+}
+
+// CheckObject checks the quota of the object owning account,
+// the objects which don't belong to any account are not limited
+func CheckObject(ctx context.Context, obj any, count int64) error {
+	own, _ := obj.(interface{ OwnerAccountID() uint64 })
+	res, _ := obj.(interface{ RBACResourceName() string })
+	if own == nil || res == nil {
+		return nil
+	}
+	return Check(ctx, own.OwnerAccountID(), res.RBACResourceName(), count)
+}
@@ -0,0 +1,30 @@
+package graphql
+
+import (
+	"github.com/demdxx/xtypes"
+
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// FromUsage converts a quota.Usage to a gqlmodels.QuotaUsage.
+func FromUsage(usage *quota.Usage) *gqlmodels.QuotaUsage {
+	if usage == nil {
+		return nil
+	}
+	obj := &gqlmodels.QuotaUsage{
+		AccountID: usage.AccountID,
+		Resource:  usage.Resource,
+		Used:      int(usage.Used),
+	}
+	if !usage.IsUnlimited() {
+		limit, remaining := int(usage.Limit), int(usage.Remaining())
+		obj.Limit, obj.Remaining = &limit, &remaining
+	}
+	return obj
+}
+
+// FromUsageList converts a slice of quota.Usage to a slice of gqlmodels.QuotaUsage.
+func FromUsageList(list []*quota.Usage) []*gqlmodels.QuotaUsage {
+	return xtypes.SliceApply(list, FromUsage)
+}
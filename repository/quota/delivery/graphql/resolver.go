@@ -0,0 +1,38 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// QueryResolver implements GQL API methods
+type QueryResolver struct {
+	uc quota.Usecase
+}
+
+// NewQueryResolver returns new API resolver, without usecase it uses the one from the context
+func NewQueryResolver(uc quota.Usecase) *QueryResolver {
+	return &QueryResolver{uc: uc}
+}
+
+// Usage is the resolver for the accountQuotaUsage field.
+func (r *QueryResolver) Usage(ctx context.Context, accountID *uint64) ([]*gqlmodels.QuotaUsage, error) {
+	uc := r.uc
+	if uc == nil {
+		if uc = quota.Get(ctx); uc == nil {
+			return []*gqlmodels.QuotaUsage{}, nil
+		}
+	}
+	id := session.AccountID(ctx)
+	if accountID != nil && *accountID > 0 {
+		id = *accountID
+	}
+	list, err := uc.Usage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return FromUsageList(list), nil
+}
@@ -0,0 +1,26 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrQuotaExceeded is matched by errors.Is on every ExceededError
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// ExceededError is returned if the account reached the limit of the resource
+type ExceededError struct {
+	AccountID uint64
+	Resource  string
+	Limit     int64
+	Used      int64
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota exceeded: %s limit %d reached (used %d)", e.Resource, e.Limit, e.Used)
+}
+
+// Is reports whether the target is ErrQuotaExceeded
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
@@ -0,0 +1,73 @@
+// Package quota limits the number of objects which an account may create.
+//
+// The limits are held in the option rows:
+//
+//	account option "quota.plan"          - the plan of the account, "default" if not set
+//	system option  "quota.plan.<plan>"   - {"<resource>": limit, ...} defaults of the plan
+//	account option "quota.limits"        - {"<resource>": limit, ...} overrides of the account
+//
+// The resource is the RBAC resource name of the object, the negative limit means unlimited.
+package quota
+
+import "context"
+
+const (
+	// OptionPlan is the account option with the plan name
+	OptionPlan = "quota.plan"
+
+	// OptionPlanPrefix of the system options with the limits of the plan
+	OptionPlanPrefix = "quota.plan."
+
+	// OptionLimits is the account option with the limits overriding the plan
+	OptionLimits = "quota.limits"
+
+	// DefaultPlan of the accounts without the plan option
+	DefaultPlan = "default"
+
+	// Unlimited resource limit
+	Unlimited int64 = -1
+)
+
+// Usage of the resource by the account
+type Usage struct {
+	AccountID uint64
+	Resource  string
+	Used      int64
+	Limit     int64
+}
+
+// IsUnlimited returns true if the resource has no limit
+func (u *Usage) IsUnlimited() bool {
+	return u.Limit < 0
+}
+
+// Remaining number of objects which can be created, -1 if unlimited
+func (u *Usage) Remaining() int64 {
+	if u.IsUnlimited() {
+		return Unlimited
+	}
+	return max(u.Limit-u.Used, 0)
+}
+
+// OwnerAccountID returns the account of the usage
+func (u *Usage) OwnerAccountID() uint64 {
+	return u.AccountID
+}
+
+// RBACResourceName returns the name of the resource for the RBAC
+func (u *Usage) RBACResourceName() string {
+	return "quota"
+}
+
+// Counter returns the number of the resource objects of the account
+type Counter interface {
+	CountUsage(ctx context.Context, accountID uint64) (int64, error)
+}
+
+// CounterFunc wraps the function as the Counter
+type CounterFunc func(ctx context.Context, accountID uint64) (int64, error)
+
+// CountUsage of the resource by the account
+func (f CounterFunc) CountUsage(ctx context.Context, accountID uint64) (int64, error) {
+	return f(ctx, accountID)
+}
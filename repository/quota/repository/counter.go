@@ -0,0 +1,33 @@
+// Package repository implements the resource counters of the account quotas
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/repository"
+)
+
+// Counter of the objects of the model table which belong to the account
+type Counter struct {
+	repository.Repository
+	model  any
+	scopes []func(*gorm.DB) *gorm.DB
+}
+
+// NewCounter of the model objects, the table has to contain the account_id column.
+// The scopes narrow the counted objects, soft deleted objects are skipped by the model.
+func NewCounter(model any, scopes ...func(*gorm.DB) *gorm.DB) *Counter {
+	return &Counter{model: model, scopes: scopes}
+}
+
+// CountUsage of the resource by the account
+func (c *Counter) CountUsage(ctx context.Context, accountID uint64) (int64, error) {
+	var count int64
+	err := c.Slave(ctx).Model(c.model).
+		Scopes(c.scopes...).
+		Where(`account_id=?`, accountID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,15 @@
+package quota
+
+import "context"
+
+// Usecase checks and reports the resource usage of the accounts
+//
+//go:generate mockgen -source $GOFILE -package mocks -destination mocks/usecase.go
+type Usecase interface {
+	// Check returns ExceededError if the account can't create count more objects of the resource.
+	// It is count-then-create: the check and the creation are not atomic, the limit is soft.
+	Check(ctx context.Context, accountID uint64, resource string, count int64) error
+
+	// Usage of all counted resources by the account.
+	Usage(ctx context.Context, accountID uint64) ([]*Usage, error)
+}
@@ -0,0 +1,124 @@
+// Package usecase implements the account quotas based on the option rows
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/demdxx/gocast/v2"
+	"github.com/pkg/errors"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/repository/option"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+)
+
+// Option configures the quota usecase
+type Option func(*Usecase)
+
+// WithCounter of the resource objects, the limits of resources without the counter are ignored
+func WithCounter(resource string, counter quota.Counter) Option {
+	return func(u *Usecase) {
+		u.counters[resource] = counter
+	}
+}
+
+// Usecase checks the account quotas
+type Usecase struct {
+	options  option.Repository
+	counters map[string]quota.Counter
+}
+
+// New quota usecase with the limits stored in the options
+func New(options option.Repository, opts ...Option) *Usecase {
+	u := &Usecase{options: options, counters: map[string]quota.Counter{}}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Check returns ExceededError if the account can't create count more objects of the resource
+func (u *Usecase) Check(ctx context.Context, accountID uint64, resource string, count int64) error {
+	counter := u.counters[resource]
+	if counter == nil {
+		return nil
+	}
+	limits, err := u.limits(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	limit, ok := limits[resource]
+	if !ok || limit < 0 {
+		return nil
+	}
+	used, err := counter.CountUsage(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if used+count > limit {
+		return &quota.ExceededError{AccountID: accountID, Resource: resource, Limit: limit, Used: used}
+	}
+	return nil
+}
+
+// Usage of all counted resources by the account
+func (u *Usecase) Usage(ctx context.Context, accountID uint64) ([]*quota.Usage, error) {
+	if !acl.HaveAccessView(ctx, &quota.Usage{AccountID: accountID}) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, "view quota usage")
+	}
+	limits, err := u.limits(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	resources := slices.Sorted(maps.Keys(u.counters))
+	list := make([]*quota.Usage, 0, len(resources))
+	for _, resource := range resources {
+		used, err := u.counters[resource].CountUsage(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		limit, ok := limits[resource]
+		if !ok || limit < 0 {
+			limit = quota.Unlimited
+		}
+		list = append(list, &quota.Usage{
+			AccountID: accountID,
+			Resource:  resource,
+			Used:      used,
+			Limit:     limit,
+		})
+	}
+	return list, nil
+}
+
+// limits of the account plan overridden by the account limits
+func (u *Usecase) limits(ctx context.Context, accountID uint64) (map[string]int64, error) {
+	plan := quota.DefaultPlan
+	opt, err := u.options.Get(ctx, quota.OptionPlan, option.AccountOptionType, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if name := gocast.Str(opt.Value.DataOr(nil)); name != "" {
+		plan = name
+	}
+	limits := map[string]int64{}
+	for _, src := range []struct {
+		name     string
+		otype    option.OptionType
+		targetID uint64
+	}{
+		{name: quota.OptionPlanPrefix + plan, otype: option.SystemOptionType},
+		{name: quota.OptionLimits, otype: option.AccountOptionType, targetID: accountID},
+	} {
+		if opt, err = u.options.Get(ctx, src.name, src.otype, src.targetID); err != nil {
+			return nil, err
+		}
+		values, _ := opt.Value.DataOr(nil).(map[string]any)
+		for resource, limit := range values {
+			limits[resource] = gocast.Number[int64](limit)
+		}
+	}
+	return limits, nil
+}
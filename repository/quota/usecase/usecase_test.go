@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/option"
+	"github.com/geniusrabbit/blaze-api/repository/option/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/quota"
+)
+
+type testSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	optionRepo  *mocks.MockRepository
+	members     int64
+	testUsecase *Usecase
+}
+
+func (s *testSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.optionRepo = mocks.NewMockRepository(ctrl)
+	s.members = 0
+	s.testUsecase = New(s.optionRepo,
+		WithCounter("account.member", quota.CounterFunc(func(context.Context, uint64) (int64, error) {
+			return s.members, nil
+		})),
+	)
+}
+
+func (s *testSuite) expectOptions(plan any, planLimits, accountLimits map[string]any) {
+	opt := func(value any) *option.Option {
+		obj := &option.Option{}
+		if value != nil {
+			obj.Value = *gosql.MustNullableJSON[any](value)
+		}
+		return obj
+	}
+	planName := quota.DefaultPlan
+	if plan != nil {
+		planName = plan.(string)
+	}
+	s.optionRepo.EXPECT().Get(s.ctx, quota.OptionPlan, option.AccountOptionType, uint64(1)).Return(opt(plan), nil)
+	s.optionRepo.EXPECT().Get(s.ctx, quota.OptionPlanPrefix+planName, option.SystemOptionType, uint64(0)).
+		Return(opt(planLimits), nil)
+	s.optionRepo.EXPECT().Get(s.ctx, quota.OptionLimits, option.AccountOptionType, uint64(1)).
+		Return(opt(accountLimits), nil)
+}
+
+func (s *testSuite) TestCheckUnlimited() {
+	s.expectOptions(nil, nil, nil)
+	s.members = 100
+	s.NoError(s.testUsecase.Check(s.ctx, 1, "account.member", 1))
+}
+
+func (s *testSuite) TestCheckWithoutCounter() {
+	s.NoError(s.testUsecase.Check(s.ctx, 1, "auth_client", 1))
+}
+
+func (s *testSuite) TestCheckPlanExceeded() {
+	s.expectOptions("free", map[string]any{"account.member": 3}, nil)
+	s.members = 3
+	err := s.testUsecase.Check(s.ctx, 1, "account.member", 1)
+	s.ErrorIs(err, quota.ErrQuotaExceeded)
+
+	var exceeded *quota.ExceededError
+	s.ErrorAs(err, &exceeded)
+	s.Equal(int64(3), exceeded.Limit)
+}
+
+func (s *testSuite) TestCheckAccountOverride() {
+	s.expectOptions("free", map[string]any{"account.member": 3}, map[string]any{"account.member": 10})
+	s.members = 3
+	s.NoError(s.testUsecase.Check(s.ctx, 1, "account.member", 2))
+}
+
+func (s *testSuite) TestUsage() {
+	s.expectOptions(nil, map[string]any{"account.member": 5}, nil)
+	s.members = 2
+	usage, err := s.testUsecase.Usage(s.ctx, 1)
+	s.NoError(err)
+	s.Require().Len(usage, 1)
+	s.Equal(int64(2), usage[0].Used)
+	s.Equal(int64(3), usage[0].Remaining())
+}
+
+func (s *testSuite) TestContextCheck() {
+	s.NoError(quota.Check(s.ctx, 1, "account.member", 1), "quotas are disabled without the usecase")
+
+	s.ctx = quota.WithUsecase(s.ctx, s.testUsecase)
+	s.expectOptions(nil, map[string]any{"account.member": 1}, nil)
+	s.members = 1
+	s.ErrorIs(quota.Check(s.ctx, 1, "account.member", 1), quota.ErrQuotaExceeded)
+}
+
+func TestUsecaseSuite(t *testing.T) {
+	suite.Run(t, &testSuite{})
+}
@@ -0,0 +1,16 @@
+package rbac
+
+import (
+	"context"
+
+	mrbac "github.com/demdxx/rbac"
+)
+
+// CheckGlobalRole is the custom access check of the roles for the accounts.
+// The global roles are shared by all accounts, so they can be viewed by any account
+// but changed only with the `all` cover permissions.
+func CheckGlobalRole(ctx context.Context, resource any, perm mrbac.Permission) bool {
+	role, _ := resource.(*Role)
+	return role != nil && role.IsGlobal() &&
+		perm.MatchPermissionPattern(`*.{view|list|count}.*`)
+}
@@ -69,6 +69,7 @@ func FromRBACRoleModel(ctx context.Context, role *models.Role) *gqlmodels.RBACRo
 		Title: role.Title,
 
 		Description: gocast.Ptr(role.Description),
+		AccountID:   gocast.IfThen(role.AccountID.Valid, &role.AccountID.V, nil),
 
 		Context: types.MustNullableJSONFrom(role.Context.Data),
 
@@ -94,8 +95,10 @@ func FromGQLFilter(fl *gqlmodels.RBACRoleListFilter) *rbac.Filter {
 		return nil
 	}
 	return &rbac.Filter{
-		ID:    fl.ID,
-		Names: fl.Name,
+		ID:        fl.ID,
+		Names:     fl.Name,
+		AccountID: fl.AccountID,
+		Global:    gocast.PtrAsValue(fl.Global, false),
 	}
 }
 
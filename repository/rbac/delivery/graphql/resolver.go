@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 
 	"github.com/demdxx/gocast/v2"
@@ -118,8 +119,12 @@ func (r *QueryResolver) ListRoles(ctx context.Context, filter *gqlmodels.RBACRol
 // CreateRole is the resolver for the createRole field.
 func (r *QueryResolver) CreateRole(ctx context.Context, input *gqlmodels.RBACRoleInput) (*gqlmodels.RBACRolePayload, error) {
 	roleObj := &rbac.Role{
-		Name:  gocast.PtrAsValue(input.Name, ""),
-		Title: gocast.PtrAsValue(input.Title, ""),
+		Name:               gocast.PtrAsValue(input.Name, ""),
+		Title:              gocast.PtrAsValue(input.Title, ""),
+		PermissionPatterns: input.Permissions,
+	}
+	if input.AccountID != nil {
+		roleObj.AccountID = sql.Null[uint64]{V: *input.AccountID, Valid: true}
 	}
 	if input.Context != nil {
 		if err := roleObj.Context.SetValue(input.Context.Data); err != nil {
@@ -130,6 +135,11 @@ func (r *QueryResolver) CreateRole(ctx context.Context, input *gqlmodels.RBACRol
 	if err != nil {
 		return nil, err
 	}
+	if input.ChildRoles != nil {
+		if err = r.roles.SetChildRoles(ctx, id, input.ChildRoles); err != nil {
+			return nil, err
+		}
+	}
 	// role, err := r.roles.Get(ctx, id)
 	// if err != nil {
 	// 	return nil, err
@@ -150,6 +160,12 @@ func (r *QueryResolver) UpdateRole(ctx context.Context, id uint64, input *gqlmod
 	// Update object fields
 	role.Name = gocast.PtrAsValue(input.Name, role.Name)
 	role.Title = gocast.PtrAsValue(input.Title, role.Title)
+	if input.Permissions != nil {
+		role.PermissionPatterns = input.Permissions
+	}
+	if input.AccountID != nil {
+		role.AccountID = sql.Null[uint64]{V: *input.AccountID, Valid: true}
+	}
 	if input.Context != nil {
 		if err := role.Context.SetValue(input.Context.Data); err != nil {
 			return nil, err
@@ -158,6 +174,11 @@ func (r *QueryResolver) UpdateRole(ctx context.Context, id uint64, input *gqlmod
 	if err := r.roles.Update(ctx, id, role); err != nil {
 		return nil, err
 	}
+	if input.ChildRoles != nil {
+		if err := r.roles.SetChildRoles(ctx, id, input.ChildRoles); err != nil {
+			return nil, err
+		}
+	}
 	return &gqlmodels.RBACRolePayload{
 		ClientMutationID: requestid.Get(ctx),
 		RoleID:           id,
@@ -0,0 +1,14 @@
+package rbac
+
+import "errors"
+
+var (
+	// ErrInvalidChildRole if the role can't include the child role
+	ErrInvalidChildRole = errors.New("invalid child role")
+
+	// ErrRoleAccountChange if the role moves to another account
+	ErrRoleAccountChange = errors.New("the role can't be moved to another account")
+
+	// ErrReservedRoleName if the custom role of the account takes the name of the global role
+	ErrReservedRoleName = errors.New("the role name is reserved")
+)
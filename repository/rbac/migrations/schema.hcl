@@ -36,6 +36,10 @@ table "rbac_role" {
     null = true
     type = bigint
   }
+  column "account_id" {
+    null = true
+    type = bigint
+  }
   column "created_at" {
     null = true
     type = timestamptz
@@ -51,6 +55,15 @@ table "rbac_role" {
   primary_key {
     columns = [column.id]
   }
+  index "idx_rbac_role_account_id_name" {
+    unique = true
+    on {
+      expr = "COALESCE(account_id, 0)"
+    }
+    on {
+      column = column.name
+    }
+  }
 }
 
 table "m2m_rbac_role" {
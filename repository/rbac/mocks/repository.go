@@ -142,18 +142,32 @@ func (mr *MockRepositoryMockRecorder) Get(ctx, id any, qops ...any) *gomock.Call
 }
 
 // GetByName mocks base method.
-func (m *MockRepository) GetByName(ctx context.Context, name string) (*rbac.Role, error) {
+func (m *MockRepository) GetByName(ctx context.Context, name string, accountID uint64) (*rbac.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret := m.ctrl.Call(m, "GetByName", ctx, name, accountID)
 	ret0, _ := ret[0].(*rbac.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByName indicates an expected call of GetByName.
-func (mr *MockRepositoryMockRecorder) GetByName(ctx, name any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetByName(ctx, name, accountID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockRepository)(nil).GetByName), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockRepository)(nil).GetByName), ctx, name, accountID)
+}
+
+// SetChildRoles mocks base method.
+func (m *MockRepository) SetChildRoles(ctx context.Context, id uint64, childIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChildRoles", ctx, id, childIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChildRoles indicates an expected call of SetChildRoles.
+func (mr *MockRepositoryMockRecorder) SetChildRoles(ctx, id, childIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChildRoles", reflect.TypeOf((*MockRepository)(nil).SetChildRoles), ctx, id, childIDs)
 }
 
 // Update mocks base method.
@@ -156,6 +156,20 @@ func (mr *MockUsecaseMockRecorder) GetByName(ctx, title any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockUsecase)(nil).GetByName), ctx, title)
 }
 
+// SetChildRoles mocks base method.
+func (m *MockUsecase) SetChildRoles(ctx context.Context, id uint64, childIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChildRoles", ctx, id, childIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChildRoles indicates an expected call of SetChildRoles.
+func (mr *MockUsecaseMockRecorder) SetChildRoles(ctx, id, childIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChildRoles", reflect.TypeOf((*MockUsecase)(nil).SetChildRoles), ctx, id, childIDs)
+}
+
 // Update mocks base method.
 func (m *MockUsecase) Update(ctx context.Context, id uint64, obj *rbac.Role, opts ...generated.Option) error {
 	m.ctrl.T.Helper()
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/demdxx/gocast/v2"
@@ -31,6 +32,9 @@ type Role struct {
 
 	AccessLevel int `db:"access_level"` // 0 - any, 1 - no anonymous, 2 - account, >=3 - system
 
+	// The account which owns the custom role, the global roles are shared by all accounts
+	AccountID sql.Null[uint64] `db:"account_id" gorm:"column:account_id"`
+
 	CreatedAt time.Time      `db:"created_at"`
 	UpdatedAt time.Time      `db:"updated_at"`
 	DeletedAt gorm.DeletedAt `db:"deleted_at"`
@@ -52,6 +56,16 @@ func (role *Role) RBACResourceName() string {
 	return "role"
 }
 
+// OwnerAccountID returns the account which owns the role, 0 for the global roles
+func (role *Role) OwnerAccountID() uint64 {
+	return role.AccountID.V
+}
+
+// IsGlobal returns `true` if the role is shared by all accounts
+func (role *Role) IsGlobal() bool {
+	return !role.AccountID.Valid || role.AccountID.V == 0
+}
+
 // ContextMap returns the map from the context
 func (role *Role) ContextMap() map[string]any {
 	if role == nil || role.Context.Data == nil {
@@ -13,6 +13,11 @@ type Filter struct {
 	Names          []string
 	MinAccessLevel int
 	MaxAccessLevel int
+
+	// AccountID selects the custom roles of the accounts,
+	// together with Global the global roles are selected as well
+	AccountID []uint64
+	Global    bool
 }
 
 func (fl *Filter) PrepareQuery(query *gorm.DB) *gorm.DB {
@@ -25,6 +30,14 @@ func (fl *Filter) PrepareQuery(query *gorm.DB) *gorm.DB {
 	if len(fl.ID) > 0 {
 		query = query.Where(`id IN (?)`, fl.ID)
 	}
+	switch {
+	case len(fl.AccountID) > 0 && fl.Global:
+		query = query.Where(`(account_id IS NULL OR account_id IN (?))`, fl.AccountID)
+	case len(fl.AccountID) > 0:
+		query = query.Where(`account_id IN (?)`, fl.AccountID)
+	case fl.Global:
+		query = query.Where(`account_id IS NULL`)
+	}
 	// Here we can have logic error but it's not critical. Example `access_level BETWEEN 2 AND 1`
 	if fl.MinAccessLevel > 0 && fl.MaxAccessLevel > 0 {
 		query = query.Where(`access_level BETWEEN ? AND ?`, fl.MinAccessLevel, fl.MaxAccessLevel)
@@ -12,5 +12,6 @@ import (
 //go:generate mockgen -source $GOFILE -package mocks -destination mocks/repository.go
 type Repository interface {
 	generated.RepositoryIface[Role, uint64]
-	GetByName(ctx context.Context, name string) (*Role, error)
+	GetByName(ctx context.Context, name string, accountID uint64) (*Role, error)
+	SetChildRoles(ctx context.Context, id uint64, childIDs []uint64) error
 }
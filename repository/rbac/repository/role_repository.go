@@ -3,6 +3,9 @@ package repository
 
 import (
 	"context"
+	"time"
+
+	"gorm.io/gorm"
 
 	"github.com/geniusrabbit/blaze-api/repository/generated"
 	"github.com/geniusrabbit/blaze-api/repository/rbac"
@@ -20,11 +23,37 @@ func New() *Repository {
 	}
 }
 
-// GetByName returns RBAC role model by name
-func (r *Repository) GetByName(ctx context.Context, name string) (*rbac.Role, error) {
+// GetByName returns RBAC role model by name,
+// the global role overrides the custom role of the account with the same name
+func (r *Repository) GetByName(ctx context.Context, name string, accountID uint64) (*rbac.Role, error) {
 	object := new(rbac.Role)
-	if err := r.Slave(ctx).Find(object, `name=?`, name).Error; err != nil {
+	err := r.Slave(ctx).
+		Where(`name=? AND (account_id IS NULL OR account_id=?)`, name, accountID).
+		Order(`account_id NULLS FIRST`).
+		Limit(1).Find(object).Error
+	if err != nil {
 		return nil, err
 	}
 	return object, nil
 }
+
+// SetChildRoles replaces the child roles of the role
+func (r *Repository) SetChildRoles(ctx context.Context, id uint64, childIDs []uint64) error {
+	return r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		query := tx.Where(`parent_role_id=?`, id)
+		if len(childIDs) > 0 {
+			query = query.Where(`child_role_id NOT IN (?)`, childIDs)
+		}
+		if err := query.Delete(&rbac.M2MRole{}).Error; err != nil {
+			return err
+		}
+		if len(childIDs) == 0 {
+			return nil
+		}
+		links := make([]*rbac.M2MRole, 0, len(childIDs))
+		for _, childID := range childIDs {
+			links = append(links, &rbac.M2MRole{ParentRoleID: id, ChildRoleID: childID, CreatedAt: time.Now()})
+		}
+		return tx.Save(links).Error
+	})
+}
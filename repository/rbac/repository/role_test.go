@@ -38,12 +38,12 @@ func (s *testSuite) TestGet() {
 
 func (s *testSuite) TestGetByName() {
 	s.Mock.ExpectQuery("SELECT *").
-		WithArgs("system:manager").
+		WithArgs("system:manager", uint64(10), 1).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "name", "title", "created_at"}).
 				AddRow(1, "system:manager", "manager", time.Now()),
 		)
-	role, err := s.roleRepo.GetByName(s.Ctx, "system:manager")
+	role, err := s.roleRepo.GetByName(s.Ctx, "system:manager", 10)
 	s.NoError(err)
 	s.Equal(uint64(1), role.ID)
 	s.Equal("manager", role.Title)
@@ -73,7 +73,7 @@ func (s *testSuite) TestCount() {
 
 func (s *testSuite) TestCreate() {
 	s.Mock.ExpectQuery("INSERT INTO").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(101))
 	testRole := &models.Role{
 		ID:    101,
@@ -101,6 +101,18 @@ func (s *testSuite) TestDelete() {
 	s.NoError(err)
 }
 
+func (s *testSuite) TestSetChildRoles() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec("DELETE FROM \"m2m_rbac_role\"").
+		WithArgs(uint64(101), uint64(1), uint64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectExec("INSERT INTO \"m2m_rbac_role\"").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.Mock.ExpectCommit()
+	err := s.roleRepo.SetChildRoles(s.Ctx, 101, []uint64{1, 2})
+	s.NoError(err)
+}
+
 func TestRoleSuite(t *testing.T) {
 	suite.Run(t, &testSuite{})
 }
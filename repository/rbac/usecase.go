@@ -12,4 +12,5 @@ import (
 type Usecase interface {
 	generated.UsecaseIface[Role, uint64]
 	GetByName(ctx context.Context, title string) (*Role, error)
+	SetChildRoles(ctx context.Context, id uint64, childIDs []uint64) error
 }
@@ -42,7 +42,7 @@ func (s *testSuite) TestGet() {
 
 func (s *testSuite) TestGetByName() {
 	const name = "test"
-	s.roleRepo.EXPECT().GetByName(s.ctx, name).
+	s.roleRepo.EXPECT().GetByName(s.ctx, name, session.AccountID(s.ctx)).
 		Return(&rbacModels.Role{ID: 2, Name: name}, nil)
 
 	role, err := s.roleUsecase.GetByName(s.ctx, name)
@@ -90,6 +90,30 @@ func (s *testSuite) TestCreate() {
 	s.Equal(id, uint64(101))
 }
 
+func (s *testSuite) TestCreateReservedName() {
+	accountID := sql.Null[uint64]{V: 1, Valid: true}
+	for _, name := range []string{"admin", "account:admin", "system:admin", "system:billing"} {
+		_, err := s.roleUsecase.Create(s.ctx, &rbacModels.Role{Name: name, AccountID: accountID})
+		s.ErrorIs(err, rbac.ErrReservedRoleName, name)
+	}
+
+	s.roleRepo.EXPECT().
+		Count(s.ctx, &rbac.Filter{Names: []string{"manager"}, Global: true}).
+		Return(int64(1), nil)
+	_, err := s.roleUsecase.Create(s.ctx, &rbacModels.Role{Name: "manager", AccountID: accountID})
+	s.ErrorIs(err, rbac.ErrReservedRoleName)
+
+	s.roleRepo.EXPECT().
+		Count(s.ctx, &rbac.Filter{Names: []string{"accountant"}, Global: true}).
+		Return(int64(0), nil)
+	s.roleRepo.EXPECT().
+		Create(s.ctx, gomock.AssignableToTypeOf(&rbacModels.Role{})).
+		Return(uint64(102), nil)
+	id, err := s.roleUsecase.Create(s.ctx, &rbacModels.Role{Name: "accountant", AccountID: accountID})
+	s.NoError(err)
+	s.Equal(uint64(102), id)
+}
+
 func (s *testSuite) TestUpdate() {
 	s.roleRepo.EXPECT().
 		Get(gomock.AssignableToTypeOf(s.ctx), uint64(101)).
@@ -103,6 +127,41 @@ func (s *testSuite) TestUpdate() {
 	s.NoError(err)
 }
 
+func (s *testSuite) TestUpdateAccountChange() {
+	s.roleRepo.EXPECT().
+		Get(gomock.AssignableToTypeOf(s.ctx), uint64(102)).
+		Return(&rbacModels.Role{ID: 102, AccountID: sql.Null[uint64]{V: 1, Valid: true}}, nil)
+
+	err := s.roleUsecase.Update(s.ctx, 102, &rbacModels.Role{
+		Title: "test", AccountID: sql.Null[uint64]{V: 2, Valid: true}})
+	s.ErrorIs(err, rbac.ErrRoleAccountChange)
+}
+
+func (s *testSuite) TestSetChildRoles() {
+	accountRole := &rbacModels.Role{ID: 103, AccountID: sql.Null[uint64]{V: 1, Valid: true}}
+	s.roleRepo.EXPECT().Get(s.ctx, uint64(103)).Return(accountRole, nil).Times(3)
+
+	s.Run("global", func() {
+		s.roleRepo.EXPECT().
+			FetchList(s.ctx, gomock.AssignableToTypeOf(&rbac.Filter{})).
+			Return([]*rbacModels.Role{{ID: 1, AccessLevel: rbac.AccessLevelAccount}}, nil)
+		s.roleRepo.EXPECT().SetChildRoles(s.ctx, uint64(103), []uint64{1}).Return(nil)
+		s.NoError(s.roleUsecase.SetChildRoles(s.ctx, 103, []uint64{1}))
+	})
+	s.Run("system", func() {
+		s.roleRepo.EXPECT().
+			FetchList(s.ctx, gomock.AssignableToTypeOf(&rbac.Filter{})).
+			Return([]*rbacModels.Role{{ID: 2, AccessLevel: rbac.AccessLevelSystem}}, nil)
+		s.ErrorIs(s.roleUsecase.SetChildRoles(s.ctx, 103, []uint64{2}), rbac.ErrInvalidChildRole)
+	})
+	s.Run("another_account", func() {
+		s.roleRepo.EXPECT().
+			FetchList(s.ctx, gomock.AssignableToTypeOf(&rbac.Filter{})).
+			Return([]*rbacModels.Role{{ID: 3, AccountID: sql.Null[uint64]{V: 2, Valid: true}}}, nil)
+		s.ErrorIs(s.roleUsecase.SetChildRoles(s.ctx, 103, []uint64{3}), rbac.ErrInvalidChildRole)
+	})
+}
+
 func (s *testSuite) TestDelete() {
 	s.roleRepo.EXPECT().
 		Get(gomock.AssignableToTypeOf(s.ctx), gomock.AssignableToTypeOf(uint64(101))).
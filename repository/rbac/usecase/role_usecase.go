@@ -3,16 +3,29 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/permissions"
 	"github.com/geniusrabbit/blaze-api/repository/generated"
 	"github.com/geniusrabbit/blaze-api/repository/rbac"
 	rbacrepo "github.com/geniusrabbit/blaze-api/repository/rbac/repository"
 )
 
+// reservedRoleNames of the global roles which are checked by name
+var reservedRoleNames = []string{
+	"admin", permissions.DefaultAdminRole, "system:admin",
+	permissions.DefaultRole, permissions.AnonymousDefaultRole,
+}
+
+// reservedRolePrefixes of the global account and system roles
+var reservedRolePrefixes = []string{"system:", "account:"}
+
 // RoleUsecase provides business logic for role access control
 type RoleUsecase struct {
 	generated.Usecase[rbac.Role, uint64]
@@ -32,7 +45,7 @@ func NewDefault() *RoleUsecase {
 
 // GetByName retrieves a role by name with access control validation
 func (a *RoleUsecase) GetByName(ctx context.Context, name string) (*rbac.Role, error) {
-	roleObj, err := a.Usecase.Repo.(rbac.Repository).GetByName(ctx, name)
+	roleObj, err := a.Usecase.Repo.(rbac.Repository).GetByName(ctx, name, session.AccountID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +78,148 @@ func (a *RoleUsecase) Count(ctx context.Context, qops ...rbac.QOption) (int64, e
 	return a.Usecase.Repo.(rbac.Repository).Count(ctx, prepareQueryOptions(ctx, qops, `count`)...)
 }
 
+// Create the role, without the `all` cover permission the role belongs to the session account
+func (a *RoleUsecase) Create(ctx context.Context, role *rbac.Role, opts ...rbac.QOption) (uint64, error) {
+	if !acl.HasPermission(ctx, `role.create.all`) {
+		if role.IsGlobal() {
+			role.AccountID = sql.Null[uint64]{V: session.AccountID(ctx), Valid: true}
+		} else if role.AccountID.V != session.AccountID(ctx) {
+			return 0, acl.ErrNoPermissions.WithMessage("create role of another account")
+		}
+	}
+	if !role.IsGlobal() {
+		if err := a.checkAccountRoleName(ctx, role.Name); err != nil {
+			return 0, err
+		}
+		role.AccessLevel = min(role.AccessLevel, rbac.AccessLevelAccount)
+	}
+	id, err := a.Usecase.Create(ctx, role, opts...)
+	if err == nil {
+		resetAccountRoles(ctx, role)
+	}
+	return id, err
+}
+
+// Update the role, the role can't be moved to another account
+func (a *RoleUsecase) Update(ctx context.Context, id uint64, role *rbac.Role, opts ...rbac.QOption) error {
+	current, err := a.Usecase.Repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !acl.HaveAccessUpdate(ctx, current) {
+		return acl.ErrNoPermissions.WithMessage("update")
+	}
+	if role.AccountID.Valid && role.AccountID != current.AccountID {
+		return rbac.ErrRoleAccountChange
+	}
+	role.AccountID = current.AccountID
+	if !role.IsGlobal() {
+		if role.Name != current.Name {
+			if err = a.checkAccountRoleName(ctx, role.Name); err != nil {
+				return err
+			}
+		}
+		role.AccessLevel = min(role.AccessLevel, rbac.AccessLevelAccount)
+	}
+	if err = a.Usecase.Repo.Update(ctx, id, role, opts...); err == nil {
+		resetAccountRoles(ctx, current)
+	}
+	return err
+}
+
+// checkAccountRoleName refuses the reserved names and the names of the global roles
+// for the custom roles of the account, so they can't shadow the global ones
+func (a *RoleUsecase) checkAccountRoleName(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+	if slices.Contains(reservedRoleNames, name) ||
+		slices.ContainsFunc(reservedRolePrefixes, func(p string) bool { return strings.HasPrefix(name, p) }) {
+		return errors.Wrap(rbac.ErrReservedRoleName, name)
+	}
+	cnt, err := a.Usecase.Repo.(rbac.Repository).Count(ctx, &rbac.Filter{Names: []string{name}, Global: true})
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return errors.Wrap(rbac.ErrReservedRoleName, name)
+	}
+	return nil
+}
+
+// Delete the role
+func (a *RoleUsecase) Delete(ctx context.Context, id uint64, opts ...rbac.QOption) error {
+	current, err := a.Usecase.Repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !acl.HaveAccessDelete(ctx, current) {
+		return acl.ErrNoPermissions.WithMessage("delete")
+	}
+	if err = a.Usecase.Repo.Delete(ctx, id, opts...); err == nil {
+		resetAccountRoles(ctx, current)
+	}
+	return err
+}
+
+// SetChildRoles of the role. The account roles can include the roles of the same account
+// and the global roles up to the account access level.
+func (a *RoleUsecase) SetChildRoles(ctx context.Context, id uint64, childIDs []uint64) error {
+	repo := a.Usecase.Repo.(rbac.Repository)
+	role, err := repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !acl.HaveAccessUpdate(ctx, role) {
+		return acl.ErrNoPermissions.WithMessage("update role")
+	}
+	if slices.Contains(childIDs, id) {
+		return errors.Wrap(rbac.ErrInvalidChildRole, "the role can't include itself")
+	}
+	if len(childIDs) > 0 {
+		children, err := repo.FetchList(ctx, &rbac.Filter{ID: childIDs})
+		if err != nil {
+			return err
+		}
+		if len(children) != len(childIDs) {
+			return errors.Wrap(rbac.ErrInvalidChildRole, "undefined role")
+		}
+		for _, child := range children {
+			if err = checkChildRole(role, child); err != nil {
+				return err
+			}
+		}
+	}
+	if err = repo.SetChildRoles(ctx, id, childIDs); err == nil {
+		resetAccountRoles(ctx, role)
+	}
+	return err
+}
+
+func checkChildRole(role, child *rbac.Role) error {
+	switch {
+	case role.IsGlobal() && !child.IsGlobal():
+		return errors.Wrap(rbac.ErrInvalidChildRole, "the global role can't include the account role "+child.Name)
+	case role.IsGlobal():
+		return nil
+	case child.IsGlobal() && child.AccessLevel > rbac.AccessLevelAccount:
+		return errors.Wrap(rbac.ErrInvalidChildRole, "the account role can't include the system role "+child.Name)
+	case !child.IsGlobal() && child.AccountID.V != role.AccountID.V:
+		return errors.Wrap(rbac.ErrInvalidChildRole, "the role of another account "+child.Name)
+	}
+	return nil
+}
+
+// resetAccountRoles drops the cached roles of the account to apply the changes
+func resetAccountRoles(ctx context.Context, role *rbac.Role) {
+	if role.IsGlobal() {
+		return
+	}
+	if mng, _ := ctx.Value(permissions.CtxPermissionManagerObject).(*permissions.Manager); mng != nil {
+		mng.ResetAccountRoles(role.AccountID.V)
+	}
+}
+
 func prepareQueryOptions(ctx context.Context, qops []rbac.QOption, accessName string) []rbac.QOption {
 	var filter *rbac.Filter
 	for _, ops := range qops {
@@ -99,5 +254,15 @@ func prepareFilter(ctx context.Context, filter *rbac.Filter, accessName string)
 	} else {
 		filter.MaxAccessLevel = rbac.AccessLevelNoAnonymous
 	}
+	// The custom roles of the other accounts are hidden
+	accountID := session.AccountID(ctx)
+	switch {
+	case len(filter.AccountID) == 0 && !filter.Global:
+		filter.AccountID, filter.Global = []uint64{accountID}, true
+	case len(filter.AccountID) > 0 && !slices.Contains(filter.AccountID, accountID):
+		filter.AccountID = []uint64{0}
+	case len(filter.AccountID) > 0:
+		filter.AccountID = []uint64{accountID}
+	}
 	return filter
 }
@@ -3,6 +3,7 @@ package graphql
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/pkg/requestid"
@@ -14,17 +15,22 @@ import (
 
 // QueryResolver handles GraphQL queries for social accounts.
 type QueryResolver struct {
-	accounts socialaccount.Usecase
+	accounts  socialaccount.Usecase
+	providers []string
 }
 
-// NewQueryResolver creates a new QueryResolver instance.
-func NewQueryResolver(uc socialaccount.Usecase) *QueryResolver {
-	return &QueryResolver{accounts: uc}
+// NewQueryResolver creates a new QueryResolver instance,
+// the providers are the names of the configured login providers.
+func NewQueryResolver(uc socialaccount.Usecase, providers ...string) *QueryResolver {
+	return &QueryResolver{accounts: uc, providers: providers}
 }
 
 // NewDefaultQueryResolver creates a default QueryResolver with a new social account usecase.
-func NewDefaultQueryResolver() *QueryResolver {
-	return &QueryResolver{accounts: socusecase.NewSocaccUsecase(socrepo.NewSocaccRepository())}
+// The password checker reports whether the user can log in without the social accounts.
+func NewDefaultQueryResolver(checker socialaccount.PasswordChecker, providers ...string) *QueryResolver {
+	return NewQueryResolver(
+		socusecase.NewSocaccUsecase(socrepo.NewSocaccRepository(), socusecase.WithPasswordChecker(checker)),
+		providers...)
 }
 
 // Get retrieves a social account by ID.
@@ -56,6 +62,37 @@ func (r *QueryResolver) ListCurrent(
 	return NewSocialAccountConnection(ctx, r.accounts, filter, order, nil), nil
 }
 
+// ListCurrentProviders returns the configured login providers with the social accounts
+// of the current user, the connected providers which aren't configured anymore are included too.
+func (r *QueryResolver) ListCurrentProviders(ctx context.Context) ([]*gqlmodels.SocialProvider, error) {
+	userID := session.User(ctx).GetID()
+	accounts, err := r.accounts.FetchList(ctx, &socialaccount.Filter{UserID: []uint64{userID}})
+	if err != nil {
+		return nil, err
+	}
+	canDisconnect, err := r.accounts.CanDisconnect(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*gqlmodels.SocialProvider, 0, len(r.providers)+len(accounts))
+	for _, name := range r.providers {
+		list = append(list, &gqlmodels.SocialProvider{Provider: name})
+	}
+	for _, acc := range accounts {
+		idx := slices.IndexFunc(list, func(p *gqlmodels.SocialProvider) bool {
+			return p.Provider == acc.Provider && p.SocialAccount == nil
+		})
+		if idx < 0 {
+			list = append(list, &gqlmodels.SocialProvider{Provider: acc.Provider})
+			idx = len(list) - 1
+		}
+		list[idx].Connected = true
+		list[idx].SocialAccount = FromSocialAccountModel(acc)
+		list[idx].CanDisconnect = canDisconnect
+	}
+	return list, nil
+}
+
 // List returns paginated social accounts with optional filtering and ordering.
 func (r *QueryResolver) List(
 	ctx context.Context,
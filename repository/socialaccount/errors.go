@@ -0,0 +1,15 @@
+package socialaccount
+
+import "errors"
+
+var (
+	// ErrLastLoginMethod is returned if the user can't log in without the social account
+	ErrLastLoginMethod = errors.New("the last login method of the user can't be disconnected")
+
+	// ErrTokenInvalid is returned if the provider token is expired or revoked and can't be refreshed,
+	// the user has to log in by the provider again
+	ErrTokenInvalid = errors.New("social account token is invalid")
+
+	// ErrProviderNotConfigured is returned if the token can't be refreshed without the provider OAuth2 config
+	ErrProviderNotConfigured = errors.New("social provider isn't configured to refresh the token")
+)
@@ -108,6 +108,10 @@ table "account_social_session" {
     null = true
     type = timestamptz
   }
+  column "invalidated_at" {
+    null = true
+    type = timestamptz
+  }
   column "deleted_at" {
     null = true
     type = timestamptz
@@ -39,10 +39,19 @@ type AccountSocialSession struct {
 	// ExpiresAt is when the access token expires (nullable).
 	ExpiresAt null.Time `db:"expires_at" json:"expires_at,omitempty"`
 
+	// InvalidatedAt is set if the provider refused to refresh the token,
+	// the user has to log in by the provider again to renew the session.
+	InvalidatedAt null.Time `db:"invalidated_at" json:"invalidated_at,omitempty"`
+
 	// DeletedAt is the soft delete timestamp.
 	DeletedAt gorm.DeletedAt `db:"deleted_at" json:"deleted_at,omitempty"`
 }
 
+// IsValid returns true if the session wasn't invalidated
+func (m *AccountSocialSession) IsValid() bool {
+	return m != nil && !m.InvalidatedAt.Valid
+}
+
 // TableName returns the database table name for AccountSocialSession.
 func (m *AccountSocialSession) TableName() string {
 	return `account_social_session`
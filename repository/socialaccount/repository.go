@@ -28,4 +28,13 @@ type Repository interface {
 	// FetchSessionList retrieves all sessions for the given social account IDs.
 	// Returns a slice of sessions or an error if the operation fails.
 	FetchSessionList(ctx context.Context, socialAccountID []uint64) ([]*models.AccountSocialSession, error)
+
+	// Session retrieves the session of the social account by its name.
+	Session(ctx context.Context, socialAccountID uint64, name string) (*models.AccountSocialSession, error)
+
+	// UpdateSessionToken saves the refreshed token of the session and makes it valid again.
+	UpdateSessionToken(ctx context.Context, sess *models.AccountSocialSession) error
+
+	// InvalidateSession marks the session as invalid if its token can't be refreshed.
+	InvalidateSession(ctx context.Context, socialAccountID uint64, name string) error
 }
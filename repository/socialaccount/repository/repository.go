@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/tokencrypt"
 	"github.com/geniusrabbit/blaze-api/pkg/context/database"
 	"github.com/geniusrabbit/blaze-api/repository"
 	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
@@ -16,11 +17,27 @@ import (
 // Repository for social account
 type Repository struct {
 	repository.Repository
+	cipher tokencrypt.Cipher
+}
+
+// Option of the social account repository
+type Option func(*Repository)
+
+// WithTokenCipher decrypts the tokens of the sessions stored by the social auth
+// and encrypts the refreshed ones
+func WithTokenCipher(cipher tokencrypt.Cipher) Option {
+	return func(r *Repository) {
+		r.cipher = cipher
+	}
 }
 
 // NewSocaccRepository social account repository
-func NewSocaccRepository() *Repository {
-	return &Repository{}
+func NewSocaccRepository(opts ...Option) *Repository {
+	r := &Repository{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Get social account by ID
@@ -32,6 +49,9 @@ func (r *Repository) Get(ctx context.Context, id uint64) (*models.AccountSocial,
 	if err := res.Error; err != nil {
 		return nil, err
 	}
+	if err := r.decryptSessions(object.Sessions...); err != nil {
+		return nil, err
+	}
 	return object, nil
 }
 
@@ -47,6 +67,11 @@ func (r *Repository) FetchList(ctx context.Context, opts ...socialaccount.QOptio
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		err = nil
 	}
+	for _, obj := range list {
+		if err == nil {
+			err = r.decryptSessions(obj.Sessions...)
+		}
+	}
 	return list, err
 }
 
@@ -87,5 +112,75 @@ func (r *Repository) FetchSessionList(ctx context.Context, socialAccountID []uin
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		err = nil
 	}
+	if err == nil {
+		err = r.decryptSessions(list...)
+	}
 	return list, err
 }
+
+// Session of the social account by name.
+// It's read from the master, the refreshed token is not replicated yet
+// when the waiting refresh re-reads the session.
+func (r *Repository) Session(ctx context.Context, socialAccountID uint64, name string) (*models.AccountSocialSession, error) {
+	sess := &models.AccountSocialSession{}
+	err := r.Master(ctx).Model(sess).
+		Where(`account_social_id=? AND name=?`, socialAccountID, name).
+		First(sess).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptSessions(sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// UpdateSessionToken saves the refreshed token of the session
+func (r *Repository) UpdateSessionToken(ctx context.Context, sess *models.AccountSocialSession) error {
+	accessToken, err := r.encrypt(sess.AccessToken)
+	if err != nil {
+		return err
+	}
+	refreshToken, err := r.encrypt(sess.RefreshToken)
+	if err != nil {
+		return err
+	}
+	return r.Master(ctx).Model((*models.AccountSocialSession)(nil)).
+		Where(`account_social_id=? AND name=?`, sess.AccountSocialID, sess.Name).
+		Updates(map[string]any{
+			"token_type":     sess.TokenType,
+			"access_token":   accessToken,
+			"refresh_token":  refreshToken,
+			"expires_at":     sess.ExpiresAt,
+			"invalidated_at": nil,
+		}).Error
+}
+
+// InvalidateSession marks the session as invalid
+func (r *Repository) InvalidateSession(ctx context.Context, socialAccountID uint64, name string) error {
+	return r.Master(ctx).Model((*models.AccountSocialSession)(nil)).
+		Where(`account_social_id=? AND name=?`, socialAccountID, name).
+		Update("invalidated_at", gorm.Expr("NOW()")).Error
+}
+
+func (r *Repository) decryptSessions(list ...*models.AccountSocialSession) (err error) {
+	if r.cipher == nil {
+		return nil
+	}
+	for _, sess := range list {
+		if sess.AccessToken, err = r.cipher.Decrypt(sess.AccessToken); err != nil {
+			return err
+		}
+		if sess.RefreshToken, err = r.cipher.Decrypt(sess.RefreshToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) encrypt(token string) (string, error) {
+	if r.cipher == nil {
+		return token, nil
+	}
+	return r.cipher.Encrypt(token)
+}
@@ -3,9 +3,14 @@ package socialaccount
 import (
 	"context"
 
+	"golang.org/x/oauth2"
+
 	"github.com/geniusrabbit/blaze-api/repository/socialaccount/models"
 )
 
+// PasswordChecker reports whether the user can log in by the password
+type PasswordChecker func(ctx context.Context, userID uint64) (bool, error)
+
 // Usecase defines the business logic operations for social accounts.
 type Usecase interface {
 	// Get retrieves a single social account by ID.
@@ -18,8 +23,16 @@ type Usecase interface {
 	Count(ctx context.Context, opts ...QOption) (int64, error)
 
 	// Disconnect removes a social account connection by ID.
+	// It refuses to remove the last login method of the user.
 	Disconnect(ctx context.Context, id uint64) (*models.AccountSocial, error)
 
+	// CanDisconnect reports whether the user keeps any login method after disconnecting one social account.
+	CanDisconnect(ctx context.Context, userID uint64) (bool, error)
+
+	// Token returns the valid provider token of the user session (the "default" one if the name is empty),
+	// the token is refreshed before it expires. It returns ErrTokenInvalid if the user has to log in again.
+	Token(ctx context.Context, userID uint64, provider, sessionName string) (*oauth2.Token, error)
+
 	// FetchSessionList retrieves sessions for the given social account IDs.
 	FetchSessionList(ctx context.Context, socialAccountID []uint64) ([]*models.AccountSocialSession, error)
 }
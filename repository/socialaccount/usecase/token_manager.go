@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/guregu/null"
+	"golang.org/x/oauth2"
+
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount/models"
+)
+
+// DefaultRefreshBefore is the time before the token expiration when the token is refreshed
+const DefaultRefreshBefore = time.Minute
+
+// refreshLockCount is the number of the locks shared by the refreshed sessions
+const refreshLockCount = 64
+
+// TokenManagerOption configures the TokenManager
+type TokenManagerOption func(*TokenManager)
+
+// WithProviderConfig sets the OAuth2 config of the provider to refresh its tokens
+func WithProviderConfig(provider string, conf *oauth2.Config) TokenManagerOption {
+	return func(m *TokenManager) {
+		m.configs[provider] = conf
+	}
+}
+
+// WithRefreshBefore sets the time before the expiration when the token is refreshed
+func WithRefreshBefore(d time.Duration) TokenManagerOption {
+	return func(m *TokenManager) {
+		m.refreshBefore = d
+	}
+}
+
+// TokenManager keeps the provider tokens of the social account sessions valid.
+// The token is refreshed by the refresh token before it expires, the session
+// is invalidated if the provider refuses the refresh token.
+type TokenManager struct {
+	repo          socialaccount.Repository
+	configs       map[string]*oauth2.Config
+	refreshBefore time.Duration
+
+	// locks serialize the refresh of the same session, the providers rotating
+	// the refresh token accept it only once. The sessions are striped over
+	// the fixed set of locks to keep the memory bounded.
+	locks [refreshLockCount]sync.Mutex
+}
+
+// NewTokenManager returns the token manager of the social account sessions
+func NewTokenManager(repo socialaccount.Repository, opts ...TokenManagerOption) *TokenManager {
+	m := &TokenManager{
+		repo:          repo,
+		configs:       map[string]*oauth2.Config{},
+		refreshBefore: DefaultRefreshBefore,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Token returns the valid token of the session, it's refreshed if it expires soon
+func (m *TokenManager) Token(ctx context.Context, provider string, sess *models.AccountSocialSession) (*oauth2.Token, error) {
+	if !sess.IsValid() {
+		return nil, socialaccount.ErrTokenInvalid
+	}
+	if !m.needsRefresh(sess) {
+		return sessionToken(sess), nil
+	}
+
+	lock := m.sessionLock(sess)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// The session could be refreshed while waiting for the lock
+	sess, err := m.repo.Session(ctx, sess.AccountSocialID, sess.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !sess.IsValid() {
+		return nil, socialaccount.ErrTokenInvalid
+	}
+	if !m.needsRefresh(sess) {
+		return sessionToken(sess), nil
+	}
+	return m.refresh(ctx, provider, sess)
+}
+
+func (m *TokenManager) refresh(ctx context.Context, provider string, sess *models.AccountSocialSession) (*oauth2.Token, error) {
+	expired := time.Now().After(sess.ExpiresAt.Time)
+	if sess.RefreshToken == "" {
+		if !expired {
+			return sessionToken(sess), nil
+		}
+		if err := m.repo.InvalidateSession(ctx, sess.AccountSocialID, sess.Name); err != nil {
+			return nil, err
+		}
+		return nil, socialaccount.ErrTokenInvalid
+	}
+	conf := m.configs[provider]
+	if conf == nil {
+		if !expired {
+			return sessionToken(sess), nil
+		}
+		return nil, socialaccount.ErrProviderNotConfigured
+	}
+
+	// The token without the access token is always refreshed by the source
+	token, err := conf.TokenSource(ctx, &oauth2.Token{RefreshToken: sess.RefreshToken}).Token()
+	if err != nil {
+		// Only the refusal of the provider invalidates the session, the network errors are temporary
+		var retrieveErr *oauth2.RetrieveError
+		if !errors.As(err, &retrieveErr) {
+			return nil, err
+		}
+		if err := m.repo.InvalidateSession(ctx, sess.AccountSocialID, sess.Name); err != nil {
+			return nil, err
+		}
+		return nil, errors.Join(socialaccount.ErrTokenInvalid, err)
+	}
+
+	sess.TokenType = token.TokenType
+	sess.AccessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		sess.RefreshToken = token.RefreshToken
+	}
+	sess.ExpiresAt = null.NewTime(token.Expiry, !token.Expiry.IsZero())
+	if err := m.repo.UpdateSessionToken(ctx, sess); err != nil {
+		return nil, err
+	}
+	return sessionToken(sess), nil
+}
+
+func (m *TokenManager) sessionLock(sess *models.AccountSocialSession) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strconv.FormatUint(sess.AccountSocialID, 10) + "/" + sess.Name))
+	return &m.locks[h.Sum32()%refreshLockCount]
+}
+
+func (m *TokenManager) needsRefresh(sess *models.AccountSocialSession) bool {
+	return sess.ExpiresAt.Valid && time.Until(sess.ExpiresAt.Time) < m.refreshBefore
+}
+
+func sessionToken(sess *models.AccountSocialSession) *oauth2.Token {
+	return &oauth2.Token{
+		TokenType:    sess.TokenType,
+		AccessToken:  sess.AccessToken,
+		RefreshToken: sess.RefreshToken,
+		Expiry:       sess.ExpiresAt.Time,
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount/models"
+)
+
+// sessionRepository keeps the single session of the social account
+type sessionRepository struct {
+	socialaccount.Repository
+	sess *models.AccountSocialSession
+}
+
+func (r *sessionRepository) Session(ctx context.Context, socialAccountID uint64, name string) (*models.AccountSocialSession, error) {
+	sess := *r.sess
+	return &sess, nil
+}
+
+func (r *sessionRepository) UpdateSessionToken(ctx context.Context, sess *models.AccountSocialSession) error {
+	r.sess = sess
+	return nil
+}
+
+func (r *sessionRepository) InvalidateSession(ctx context.Context, socialAccountID uint64, name string) error {
+	r.sess.InvalidatedAt = null.TimeFrom(time.Now())
+	return nil
+}
+
+func TestTokenManager(t *testing.T) {
+	ctx := context.Background()
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostFormValue("refresh_token") != "refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "new-access",
+			"refresh_token": "new-refresh",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	}))
+	defer provider.Close()
+
+	newSession := func(expiresIn time.Duration, refreshToken string) *sessionRepository {
+		return &sessionRepository{sess: &models.AccountSocialSession{
+			Name:            "default",
+			AccountSocialID: 1,
+			TokenType:       "Bearer",
+			AccessToken:     "access",
+			RefreshToken:    refreshToken,
+			ExpiresAt:       null.TimeFrom(time.Now().Add(expiresIn)),
+		}}
+	}
+	newManager := func(repo *sessionRepository) *TokenManager {
+		return NewTokenManager(repo, WithProviderConfig("test", &oauth2.Config{
+			ClientID: "client",
+			Endpoint: oauth2.Endpoint{TokenURL: provider.URL, AuthStyle: oauth2.AuthStyleInParams},
+		}))
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		repo := newSession(time.Hour, "refresh")
+		token, err := newManager(repo).Token(ctx, "test", repo.sess)
+		require.NoError(t, err)
+		assert.Equal(t, "access", token.AccessToken)
+	})
+
+	t.Run("refresh_before_expiry", func(t *testing.T) {
+		repo := newSession(30*time.Second, "refresh")
+		token, err := newManager(repo).Token(ctx, "test", repo.sess)
+		require.NoError(t, err)
+		assert.Equal(t, "new-access", token.AccessToken)
+		assert.Equal(t, "new-refresh", repo.sess.RefreshToken, "the rotated refresh token is stored")
+		assert.True(t, repo.sess.ExpiresAt.Time.After(time.Now().Add(time.Minute)))
+	})
+
+	t.Run("refused", func(t *testing.T) {
+		repo := newSession(-time.Minute, "revoked")
+		_, err := newManager(repo).Token(ctx, "test", repo.sess)
+		assert.ErrorIs(t, err, socialaccount.ErrTokenInvalid)
+		assert.False(t, repo.sess.IsValid())
+
+		_, err = newManager(repo).Token(ctx, "test", repo.sess)
+		assert.ErrorIs(t, err, socialaccount.ErrTokenInvalid, "the invalid session isn't refreshed again")
+	})
+
+	t.Run("expired_without_refresh_token", func(t *testing.T) {
+		repo := newSession(-time.Minute, "")
+		_, err := newManager(repo).Token(ctx, "test", repo.sess)
+		assert.ErrorIs(t, err, socialaccount.ErrTokenInvalid)
+	})
+
+	t.Run("unknown_provider", func(t *testing.T) {
+		repo := newSession(-time.Minute, "refresh")
+		_, err := newManager(repo).Token(ctx, "other", repo.sess)
+		assert.ErrorIs(t, err, socialaccount.ErrProviderNotConfigured)
+		assert.True(t, repo.sess.IsValid())
+	})
+}
@@ -3,19 +3,52 @@ package usecase
 import (
 	"context"
 
+	"github.com/demdxx/gocast/v2"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
 	"github.com/geniusrabbit/blaze-api/pkg/context/session"
 	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
 	"github.com/geniusrabbit/blaze-api/repository/socialaccount/models"
 )
 
+// Option configures the social account Usecase
+type Option func(*Usecase)
+
+// WithPasswordChecker sets the check of the password login of the user.
+// Without the checker the social accounts are the only known login method,
+// so the last one can't be disconnected.
+func WithPasswordChecker(checker socialaccount.PasswordChecker) Option {
+	return func(u *Usecase) {
+		u.hasPassword = checker
+	}
+}
+
+// WithTokenManager sets the manager which refreshes the provider tokens,
+// without the configs of the providers the expired tokens aren't refreshed
+func WithTokenManager(tokens *TokenManager) Option {
+	return func(u *Usecase) {
+		u.tokens = tokens
+	}
+}
+
 // Usecase for social account
 type Usecase struct {
-	repo socialaccount.Repository
+	repo        socialaccount.Repository
+	hasPassword socialaccount.PasswordChecker
+	tokens      *TokenManager
 }
 
-func NewSocaccUsecase(repo socialaccount.Repository) *Usecase {
-	return &Usecase{repo: repo}
+func NewSocaccUsecase(repo socialaccount.Repository, opts ...Option) *Usecase {
+	u := &Usecase{repo: repo}
+	for _, opt := range opts {
+		opt(u)
+	}
+	if u.tokens == nil {
+		u.tokens = NewTokenManager(repo)
+	}
+	return u
 }
 
 // Get social account by ID
@@ -65,9 +98,53 @@ func (u *Usecase) Disconnect(ctx context.Context, id uint64) (*models.AccountSoc
 	if !acl.HaveAccessDelete(ctx, obj) {
 		return nil, acl.ErrNoPermissions.WithMessage("disconnect social account")
 	}
+	canDisconnect, err := u.CanDisconnect(ctx, obj.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !canDisconnect {
+		return nil, socialaccount.ErrLastLoginMethod
+	}
 	return obj, u.repo.Disconnect(ctx, id)
 }
 
+// CanDisconnect checks if the user has the password or another social account to log in
+func (u *Usecase) CanDisconnect(ctx context.Context, userID uint64) (bool, error) {
+	count, err := u.repo.Count(ctx, &socialaccount.Filter{UserID: []uint64{userID}})
+	if err != nil {
+		return false, err
+	}
+	if count > 1 {
+		return true, nil
+	}
+	if u.hasPassword == nil {
+		return false, nil
+	}
+	return u.hasPassword(ctx, userID)
+}
+
+// Token returns the valid provider token of the user session, the expiring token is refreshed
+func (u *Usecase) Token(ctx context.Context, userID uint64, provider, sessionName string) (*oauth2.Token, error) {
+	list, err := u.repo.FetchList(ctx, &socialaccount.Filter{
+		UserID:   []uint64{userID},
+		Provider: []string{provider},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if !acl.HaveAccessView(ctx, list[0]) {
+		return nil, acl.ErrNoPermissions.WithMessage("view social account token")
+	}
+	sess, err := u.repo.Session(ctx, list[0].ID, gocast.Or(sessionName, "default"))
+	if err != nil {
+		return nil, err
+	}
+	return u.tokens.Token(ctx, provider, sess)
+}
+
 // FetchSessionList of social accounts
 func (u *Usecase) FetchSessionList(ctx context.Context, socialAccountID []uint64) ([]*models.AccountSocialSession, error) {
 	return u.repo.FetchSessionList(ctx, socialAccountID)
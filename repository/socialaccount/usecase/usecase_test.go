@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/blaze-api/repository/socialaccount"
+)
+
+// countRepository returns the number of the social accounts of the user
+type countRepository struct {
+	socialaccount.Repository
+	count int64
+}
+
+func (r *countRepository) Count(ctx context.Context, opts ...socialaccount.QOption) (int64, error) {
+	return r.count, nil
+}
+
+func TestCanDisconnect(t *testing.T) {
+	ctx := context.Background()
+	hasPassword := func(ok bool) socialaccount.PasswordChecker {
+		return func(ctx context.Context, userID uint64) (bool, error) { return ok, nil }
+	}
+	tests := []struct {
+		name    string
+		count   int64
+		checker socialaccount.PasswordChecker
+		want    bool
+	}{
+		{name: "other_social_account", count: 2, want: true},
+		{name: "last_social_account", count: 1, want: false},
+		{name: "last_social_account_with_password", count: 1, checker: hasPassword(true), want: true},
+		{name: "last_social_account_without_password", count: 1, checker: hasPassword(false), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewSocaccUsecase(&countRepository{count: tt.count}, WithPasswordChecker(tt.checker))
+			ok, err := uc.CanDisconnect(ctx, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
@@ -30,6 +30,7 @@ var errUserDateEmpty = errors.New("user data is empty")
 const (
 	connectNameKey = "cn"
 	redirectKey    = "r"
+	linkKey        = "lk"
 )
 
 // Oauth2Wrapper provides a wrapper for oauth2 authentication.
@@ -41,6 +42,15 @@ type Oauth2Wrapper struct {
 	resolveAccountID   func(ctx context.Context, userID uint64) (uint64, error)
 	errorRedirectURL   string
 	successRedirectURL string
+	stateStore         elogin.StateStore
+	stateLifetime      time.Duration
+	linkAuthAge        time.Duration
+
+	// emailOwner returns the ID of the user with the email, it's set by WithEmailConflictCheck
+	emailOwner func(ctx context.Context, email string) (uint64, error)
+
+	// loginCheck rejects the session of the user, it's set by WithApprovedOnly
+	loginCheck func(ctx context.Context, userID uint64) error
 
 	// Optional hooks — set via With* options.
 	socialAccountFactory  func(provider string, data *elogin.UserData) *socialAccountModels.AccountSocial
@@ -55,6 +65,8 @@ type Oauth2Wrapper struct {
 // NewWrapper creates a new instance of Oauth2Wrapper
 func NewWrapper(auth elogin.AuthAccessor, options ...Option) *Oauth2Wrapper {
 	wr := &Oauth2Wrapper{
+		stateStore:    elogin.NewSessionStateStore(),
+		stateLifetime: elogin.DefaultStateLifetime,
 		sessionUserFn: func(ctx context.Context) user.Model {
 			return session.UserModel(ctx)
 		},
@@ -65,7 +77,7 @@ func NewWrapper(auth elogin.AuthAccessor, options ...Option) *Oauth2Wrapper {
 	if wr.socialAuthUsecase == nil {
 		panic("socialauth usecase is required: use rest.WithSocialAuthUsecase")
 	}
-	wr.wrapper = elogin.NewWrapper(auth, wr, wr, wr)
+	wr.wrapper = elogin.NewWrapper(auth, wr, wr, wr, wr)
 	return wr
 }
 
@@ -112,12 +124,44 @@ func (wr *Oauth2Wrapper) RedirectParams(w http.ResponseWriter, r *http.Request,
 	if connectionName != "" {
 		res = append(res, elogin.URLParam{Key: connectNameKey, Value: connectionName})
 	}
+	if query.Get("link") != "" {
+		res = append(res, elogin.URLParam{Key: linkKey, Value: "1"})
+	}
 	if scopes != "" {
 		res = append(res, elogin.URLParam{Key: "scope", Value: scopes})
 	}
 	return res
 }
 
+// NewLoginState creates the random state of the login attempt and binds it to the browser
+func (wr *Oauth2Wrapper) NewLoginState(w http.ResponseWriter, r *http.Request, params []elogin.URLParam) (*elogin.LoginState, error) {
+	state, err := elogin.NewLoginState(params, wr.stateLifetime)
+	if err != nil {
+		return nil, err
+	}
+	if err := wr.stateStore.Save(w, r, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// VerifyLoginState checks the state of the callback against the state stored for the browser.
+// The state is removed from the store, so the callback can't be replayed.
+func (wr *Oauth2Wrapper) VerifyLoginState(w http.ResponseWriter, r *http.Request) (*elogin.LoginState, error) {
+	stateID := elogin.StateIDFromRequest(r)
+	if stateID == "" {
+		return nil, elogin.ErrInvalidState
+	}
+	state, err := wr.stateStore.Pop(w, r, stateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.Verify(r.FormValue("state"), time.Now()); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
 // Error handles the error occurred during the oauth2 authentication
 func (wr *Oauth2Wrapper) Error(w http.ResponseWriter, r *http.Request, err error) {
 	state := utils.DecodeState(r.URL.Query().Get("state"))
@@ -167,11 +211,20 @@ func (wr *Oauth2Wrapper) Success(w http.ResponseWriter, r *http.Request, token *
 	// Get session connection name
 	connectName := gocast.Or(state.Get(connectNameKey), "default")
 
-	// Check if user already exists (awoid permission check)
+	// The logged in user links the social account to itself
+	linking := state.Get(linkKey) != "" || !session.User(ctx).IsAnonymous()
+	if linking {
+		if err := wr.checkLink(ctx); err != nil {
+			wr.Error(w, r, err)
+			return
+		}
+	}
+
+	// Check if user already exists (awoid permission check),
+	// the disconnected social accounts don't log in their former users
 	list, err := wr.socialAuthUsecase.List(ctx, &socialauth.Filter{
-		SocialID:        []string{userData.ID},
-		Provider:        []string{wr.Provider()},
-		RetrieveDeleted: true,
+		SocialID: []string{userData.ID},
+		Provider: []string{wr.Provider()},
 	})
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, sql.ErrNoRows) {
 		wr.Error(w, r, err)
@@ -181,13 +234,25 @@ func (wr *Oauth2Wrapper) Success(w http.ResponseWriter, r *http.Request, token *
 	// If user already exists, update the token
 	if len(list) > 0 {
 		accSocial = list[0]
+		if linking && accSocial.UserID != session.User(ctx).GetID() {
+			wr.Error(w, r, socialauth.ErrSocialAccountLinked)
+			return
+		}
 		if err := wr.updateSocialAccount(ctx, list[0], userData); err != nil {
 			wr.Error(w, r, err)
 			return
 		}
-	} else if accSocial, err = wr.createSocialAccountAndUser(r.Context(), userData); err != nil {
-		wr.Error(w, r, err)
-		return
+	} else {
+		if !linking {
+			if err := wr.checkEmailConflict(ctx, userData); err != nil {
+				wr.Error(w, r, err)
+				return
+			}
+		}
+		if accSocial, err = wr.createSocialAccountAndUser(r.Context(), userData); err != nil {
+			wr.Error(w, r, err)
+			return
+		}
 	}
 
 	// Update token if provided
@@ -203,6 +268,13 @@ func (wr *Oauth2Wrapper) Success(w http.ResponseWriter, r *http.Request, token *
 
 	// Create internal session if provided
 	if sessToken == "" && wr.sessProvider != nil && session.User(ctx).IsAnonymous() {
+		if wr.loginCheck != nil {
+			if err := wr.loginCheck(ctx, accSocial.UserID); err != nil {
+				wr.Error(w, r, err)
+				return
+			}
+		}
+
 		// Get preoritized user account
 		accountID := uint64(0)
 		if wr.resolveAccountID != nil {
@@ -236,6 +308,42 @@ func (wr *Oauth2Wrapper) Success(w http.ResponseWriter, r *http.Request, token *
 	})
 }
 
+// checkLink checks that the user is logged in and the session is fresh enough to link the social account
+func (wr *Oauth2Wrapper) checkLink(ctx context.Context) error {
+	if session.User(ctx).IsAnonymous() {
+		return socialauth.ErrLinkRequiresLogin
+	}
+	if session.IsImpersonated(ctx) {
+		return socialauth.ErrLinkImpersonated
+	}
+	if wr.linkAuthAge <= 0 || wr.sessProvider == nil {
+		return nil
+	}
+	data, err := wr.sessProvider.ParseToken(session.Token(ctx))
+	if err != nil || data.AuthTime == 0 || time.Since(time.Unix(data.AuthTime, 0)) > wr.linkAuthAge {
+		return socialauth.ErrReauthenticationRequired
+	}
+	return nil
+}
+
+// checkEmailConflict refuses to create the duplicate of the user with the same verified email,
+// the user has to log in and link the social account instead
+func (wr *Oauth2Wrapper) checkEmailConflict(ctx context.Context, userData *elogin.UserData) error {
+	if wr.emailOwner == nil || userData.Email == "" || !gocast.Bool(userData.Ext["email_verified"]) {
+		return nil
+	}
+	userID, err := wr.emailOwner(ctx, userData.Email)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return err
+	case userID != 0:
+		return socialauth.ErrEmailAccountExists
+	}
+	return nil
+}
+
 // createSocialAccountAndUser builds an AccountSocial record and registers the
 // owner user via the usecase.  The oauthUser internal type is intentionally
 // absent — owner resolution is delegated to the usecase's provisioner or
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/repository/socialauth"
+)
+
+func TestVerifyLoginState(t *testing.T) {
+	wr := &Oauth2Wrapper{
+		stateStore:    elogin.NewCookieStateStore([]byte("secret"), false),
+		stateLifetime: time.Minute,
+	}
+
+	// login returns the state of the new login attempt and the cookie which binds it to the browser
+	login := func() (*elogin.LoginState, *http.Cookie) {
+		w := httptest.NewRecorder()
+		state, err := wr.NewLoginState(w, httptest.NewRequest(http.MethodGet, "/auth/test/login", nil),
+			[]elogin.URLParam{{Key: redirectKey, Value: "/home"}})
+		require.NoError(t, err)
+		require.Len(t, w.Result().Cookies(), 1)
+		return state, w.Result().Cookies()[0]
+	}
+	callback := func(state string, cookie *http.Cookie) (*elogin.LoginState, error) {
+		r := httptest.NewRequest(http.MethodGet, "/auth/test/callback?"+url.Values{"state": {state}, "code": {"code"}}.Encode(), nil)
+		if cookie != nil {
+			r.AddCookie(cookie)
+		}
+		return wr.VerifyLoginState(httptest.NewRecorder(), r)
+	}
+
+	state, cookie := login()
+	verified, err := callback(state.State, cookie)
+	require.NoError(t, err)
+	assert.Equal(t, state.CodeVerifier, verified.CodeVerifier)
+
+	_, err = callback(state.State, nil)
+	assert.ErrorIs(t, err, elogin.ErrInvalidState, "the callback of the other browser")
+
+	other, _ := login()
+	_, err = callback(other.State, cookie)
+	assert.ErrorIs(t, err, elogin.ErrInvalidState, "the state of the other login attempt")
+
+	_, err = callback("", cookie)
+	assert.ErrorIs(t, err, elogin.ErrInvalidState)
+}
+
+func TestCheckLink(t *testing.T) {
+	wr := &Oauth2Wrapper{}
+	assert.ErrorIs(t, wr.checkLink(context.Background()), socialauth.ErrLinkRequiresLogin,
+		"the anonymous user can't link the social account")
+
+	ctx := session.WithImpersonation(session.WithUserAccountDevelop(context.Background()),
+		&session.Impersonation{ActorID: 2, ExpiresAt: time.Now().Add(time.Minute)})
+	assert.ErrorIs(t, wr.checkLink(ctx), socialauth.ErrLinkImpersonated,
+		"the support staff can't link the social account to the impersonated user")
+
+	provider := jwt.NewDefaultProvider("secret", time.Hour, false)
+	wr = &Oauth2Wrapper{sessProvider: provider, linkAuthAge: 15 * time.Minute}
+	sessionCtx := func(authTime time.Time) context.Context {
+		token, _, err := provider.ReissueToken(authTime, 1, 1, 0)
+		require.NoError(t, err)
+		return session.WithToken(session.WithUserAccountDevelop(context.Background()), token)
+	}
+	assert.NoError(t, wr.checkLink(sessionCtx(time.Now().Add(-time.Minute))))
+	assert.ErrorIs(t, wr.checkLink(sessionCtx(time.Now().Add(-time.Hour))), socialauth.ErrReauthenticationRequired,
+		"the reissued token of the old login is not fresh")
+}
+
+func TestCheckEmailConflict(t *testing.T) {
+	ctx := context.Background()
+	owners := map[string]uint64{"jane@example.com": 1}
+	wr := &Oauth2Wrapper{emailOwner: func(ctx context.Context, email string) (uint64, error) {
+		if id, ok := owners[email]; ok {
+			return id, nil
+		}
+		return 0, gorm.ErrRecordNotFound
+	}}
+	userData := func(email string, verified bool) *elogin.UserData {
+		return &elogin.UserData{ID: "social-1", Email: email, Ext: map[string]any{"email_verified": verified}}
+	}
+
+	assert.ErrorIs(t, wr.checkEmailConflict(ctx, userData("jane@example.com", true)), socialauth.ErrEmailAccountExists)
+	assert.NoError(t, wr.checkEmailConflict(ctx, userData("jane@example.com", false)),
+		"the unverified email doesn't prove the ownership of the account")
+	assert.NoError(t, wr.checkEmailConflict(ctx, userData("john@example.com", true)))
+	assert.NoError(t, (&Oauth2Wrapper{}).checkEmailConflict(ctx, userData("jane@example.com", true)),
+		"the check is disabled")
+}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"time"
 
 	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
 	"github.com/geniusrabbit/blaze-api/pkg/auth/jwt"
@@ -27,6 +28,54 @@ func WithSuccessRedirectURL(url string) Option {
 	}
 }
 
+// WithStateStore sets the store of the login attempt states, the states
+// are kept in the `scs` session of the request by default
+func WithStateStore(store elogin.StateStore, lifetime time.Duration) Option {
+	return func(w *Oauth2Wrapper) {
+		w.stateStore = store
+		if lifetime > 0 {
+			w.stateLifetime = lifetime
+		}
+	}
+}
+
+// WithLinkAuthAge limits the age of the session which links the social account,
+// the user has to log in again if the session is older
+func WithLinkAuthAge(age time.Duration) Option {
+	return func(w *Oauth2Wrapper) {
+		w.linkAuthAge = age
+	}
+}
+
+// WithEmailConflictCheck refuses the social login which would create the duplicate
+// of the user with the same verified email. The user has to log in and link
+// the social account by the `?link=1` login to merge them.
+func WithEmailConflictCheck[TUser user.EmailCapableModel](repo user.EmailRepository[TUser]) Option {
+	return func(w *Oauth2Wrapper) {
+		w.emailOwner = func(ctx context.Context, email string) (uint64, error) {
+			obj, err := repo.GetByEmail(ctx, email)
+			if err != nil {
+				return 0, err
+			}
+			return obj.GetID(), nil
+		}
+	}
+}
+
+// WithApprovedOnly rejects the social login of users which are not approved yet,
+// the same way as the password login, see user.CheckLoginApproved
+func WithApprovedOnly[TUser user.Model](repo user.Repository[TUser]) Option {
+	return func(w *Oauth2Wrapper) {
+		w.loginCheck = func(ctx context.Context, userID uint64) error {
+			obj, err := repo.Get(ctx, userID)
+			if err != nil {
+				return err
+			}
+			return user.CheckLoginApproved(obj)
+		}
+	}
+}
+
 // WithSocialAuthUsecase sets the social auth usecase
 func WithSocialAuthUsecase(usecase socialauth.Usecase) Option {
 	return func(w *Oauth2Wrapper) {
@@ -0,0 +1,21 @@
+package socialauth
+
+import "errors"
+
+var (
+	// ErrLinkRequiresLogin is returned if the anonymous user tries to link the social account
+	ErrLinkRequiresLogin = errors.New("login is required to link the social account")
+
+	// ErrReauthenticationRequired is returned if the session is too old to link the social account
+	ErrReauthenticationRequired = errors.New("re-authentication is required to link the social account")
+
+	// ErrLinkImpersonated is returned if the social account is linked from the impersonated session
+	ErrLinkImpersonated = errors.New("social account can't be linked from the impersonated session")
+
+	// ErrSocialAccountLinked is returned if the social account belongs to another user
+	ErrSocialAccountLinked = errors.New("social account is linked to another user")
+
+	// ErrEmailAccountExists is returned if the verified email of the social account belongs
+	// to the existing user, the user has to log in and link the social account to merge them
+	ErrEmailAccountExists = errors.New("user with the social account email exists, log in to link the social account")
+)
@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/elogin/tokencrypt"
 	"github.com/geniusrabbit/blaze-api/repository"
 	"github.com/geniusrabbit/blaze-api/repository/socialauth"
 	socialAccountModels "github.com/geniusrabbit/blaze-api/repository/socialaccount/models"
@@ -15,10 +16,25 @@ import (
 
 type Repository struct {
 	repository.Repository
+	cipher tokencrypt.Cipher
 }
 
-func New() *Repository {
-	return &Repository{}
+// Option of the social auth repository
+type Option func(*Repository)
+
+// WithTokenCipher encrypts the tokens of the social account sessions
+func WithTokenCipher(cipher tokencrypt.Cipher) Option {
+	return func(r *Repository) {
+		r.cipher = cipher
+	}
+}
+
+func New(opts ...Option) *Repository {
+	r := &Repository{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Get account by ID
@@ -75,10 +91,18 @@ func (r *Repository) Token(ctx context.Context, name string, id uint64) (*elogin
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
+	accessToken, err := r.decrypt(sess.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := r.decrypt(sess.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
 	return &elogin.Token{
 		TokenType:    sess.TokenType,
-		AccessToken:  sess.AccessToken,
-		RefreshToken: sess.RefreshToken,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 		ExpiresAt:    sess.ExpiresAt.Time,
 		Scopes:       sess.Scopes,
 	}, nil
@@ -94,16 +118,39 @@ func (r *Repository) SetToken(ctx context.Context, name string, id uint64, token
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
 	}
+	accessToken, err := r.encrypt(token.AccessToken)
+	if err != nil {
+		return err
+	}
+	refreshToken, err := r.encrypt(token.RefreshToken)
+	if err != nil {
+		return err
+	}
 
+	// The new token of the login renews the invalidated session
 	return r.Master(ctx).Unscoped().
 		Save(&socialAccountModels.AccountSocialSession{
 			AccountSocialID: id,
 			Name:            name,
 			TokenType:       token.TokenType,
-			AccessToken:     token.AccessToken,
-			RefreshToken:    token.RefreshToken,
+			AccessToken:     accessToken,
+			RefreshToken:    refreshToken,
 			Scopes:          token.Scopes,
 			ExpiresAt:       null.NewTime(token.ExpiresAt, !token.ExpiresAt.IsZero()),
 			DeletedAt:       gorm.DeletedAt{Valid: false},
 		}).Error
 }
+
+func (r *Repository) encrypt(token string) (string, error) {
+	if r.cipher == nil {
+		return token, nil
+	}
+	return r.cipher.Encrypt(token)
+}
+
+func (r *Repository) decrypt(token string) (string, error) {
+	if r.cipher == nil {
+		return token, nil
+	}
+	return r.cipher.Decrypt(token)
+}
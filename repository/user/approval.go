@@ -0,0 +1,33 @@
+package user
+
+import (
+	"errors"
+
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+)
+
+// ErrUserNotApproved returned if only approved users can login
+var ErrUserNotApproved = errors.New(`user is not approved`)
+
+// CheckLoginApproved returns ErrUserNotApproved if the user can't log in when only approved
+// users are allowed. The pending user with the verified email is allowed as well, the email
+// verification approves such users (see usecase.WithApproveAfterVerification).
+func CheckLoginApproved(obj any) error {
+	approve, ok := obj.(approveGetter)
+	if !ok {
+		return ErrUserNotApproved
+	}
+	switch approve.GetApprove() {
+	case pkgModels.ApprovedApproveStatus:
+		return nil
+	case pkgModels.UndefinedApproveStatus:
+		if verified, ok := obj.(EmailVerificationModel); ok && verified.IsEmailVerified() {
+			return nil
+		}
+	}
+	return ErrUserNotApproved
+}
+
+type approveGetter interface {
+	GetApprove() pkgModels.ApproveStatus
+}
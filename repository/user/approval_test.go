@@ -0,0 +1,24 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+)
+
+func TestCheckLoginApproved(t *testing.T) {
+	stub := func(approve pkgModels.ApproveStatus, verified bool) *testutil.User {
+		u := testutil.StubWithEmail("user@example.com", approve)
+		u.SetEmailVerified(verified)
+		return u
+	}
+	assert.NoError(t, user.CheckLoginApproved(stub(pkgModels.ApprovedApproveStatus, false)))
+	assert.NoError(t, user.CheckLoginApproved(stub(pkgModels.UndefinedApproveStatus, true)))
+	assert.ErrorIs(t, user.CheckLoginApproved(stub(pkgModels.UndefinedApproveStatus, false)), user.ErrUserNotApproved)
+	assert.ErrorIs(t, user.CheckLoginApproved(stub(pkgModels.DisapprovedApproveStatus, true)), user.ErrUserNotApproved)
+	assert.ErrorIs(t, user.CheckLoginApproved(nil), user.ErrUserNotApproved)
+}
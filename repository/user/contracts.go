@@ -21,6 +21,13 @@ type EmailModel interface {
 	SetEmail(string)
 }
 
+// EmailVerificationModel is the optional part of the email trait
+// which keeps the confirmation of the email ownership.
+type EmailVerificationModel interface {
+	IsEmailVerified() bool
+	SetEmailVerified(bool)
+}
+
 // PasswordModel is the password authentication trait (orthogonal to Model and EmailModel).
 type PasswordModel interface {
 	GetPasswordHash() string
@@ -21,6 +21,8 @@ type UserBaseQueryResolver[
 	UpdateUser(ctx context.Context, id uint64, input TGQLUserUpdateInput) (TGQLUserPayload, error)
 	ApproveUser(ctx context.Context, id uint64, msg *string) (TGQLUserPayload, error)
 	RejectUser(ctx context.Context, id uint64, msg *string) (TGQLUserPayload, error)
+	SuspendUser(ctx context.Context, id uint64, reason string) (TGQLUserPayload, error)
+	UnsuspendUser(ctx context.Context, id uint64) (TGQLUserPayload, error)
 	ListUsers(ctx context.Context, filter TGQLUserListFilter, order []TGQLUserListOrder, page *gqlmodels.Page) (*UserConnection[TGQLUser], error)
 	UserFromInput(input TGQLUserCreateInput) TDomain
 	ToGraphQL(userObj TDomain) TGQLUser
@@ -35,6 +37,8 @@ type UserEmailQueryResolver[
 	TGQLUserPayload any,
 ] interface {
 	User(ctx context.Context, id uint64, email string) (TGQLUserPayload, error)
+	RequestEmailVerification(ctx context.Context, userID uint64) (*gqlmodels.StatusResponse, error)
+	VerifyEmail(ctx context.Context, token string) (*gqlmodels.StatusResponse, error)
 }
 
 // UserPasswordQueryResolver defines the interface for user password operations.
@@ -105,6 +105,26 @@ func (r *QueryResolverBase[TDomain, TGQLUser, TGQLUserCreateInput, TGQLUserUpdat
 	return r.updateApproveStatus(ctx, id, pkgModels.DisapprovedApproveStatus, msg)
 }
 
+// SuspendUser is the resolver for the suspendUser field.
+func (r *QueryResolverBase[TDomain, TGQLUser, TGQLUserCreateInput, TGQLUserUpdateInput, TGQLUserPayload, TGQLUserListFilter, TGQLUserListOrder]) SuspendUser(ctx context.Context, id uint64, reason string) (TGQLUserPayload, error) {
+	userObj, err := r.core.Suspend(ctx, id, reason)
+	if err != nil {
+		var zero TGQLUserPayload
+		return zero, err
+	}
+	return r.mapper.NewPayload(requestid.Get(ctx), id, r.mapper.ToGQL(userObj)), nil
+}
+
+// UnsuspendUser is the resolver for the unsuspendUser field.
+func (r *QueryResolverBase[TDomain, TGQLUser, TGQLUserCreateInput, TGQLUserUpdateInput, TGQLUserPayload, TGQLUserListFilter, TGQLUserListOrder]) UnsuspendUser(ctx context.Context, id uint64) (TGQLUserPayload, error) {
+	userObj, err := r.core.Unsuspend(ctx, id)
+	if err != nil {
+		var zero TGQLUserPayload
+		return zero, err
+	}
+	return r.mapper.NewPayload(requestid.Get(ctx), id, r.mapper.ToGQL(userObj)), nil
+}
+
 func (r *QueryResolverBase[TDomain, TGQLUser, TGQLUserCreateInput, TGQLUserUpdateInput, TGQLUserPayload, TGQLUserListFilter, TGQLUserListOrder]) updateApproveStatus(ctx context.Context, id uint64, status pkgModels.ApproveStatus, msg *string) (TGQLUserPayload, error) {
 	var zero TGQLUserPayload
 	userObj, err := r.core.Get(ctx, id)
@@ -23,6 +23,8 @@ type QueryResolverEmail[
 	email      user.EmailUsecase[TDomain]
 	toGraphQL  graphql.UserGraphQLConverter[TDomain, TGQLUser]
 	newPayload graphql.UserPayloadFactory[TGQLUserPayload, TGQLUser]
+
+	verifyLinkURL string
 }
 
 // QueryResolverEmailConfig wires email lookup resolver.
@@ -35,6 +37,10 @@ type QueryResolverEmailConfig[
 	Email      user.EmailUsecase[TDomain]
 	ToGraphQL  graphql.UserGraphQLConverter[TDomain, TGQLUser]
 	NewPayload graphql.UserPayloadFactory[TGQLUserPayload, TGQLUser]
+
+	// VerifyLinkURL is the template of the verification link with {token} and {email} placeholders,
+	// e.g. https://example.com/verify-email?token={token}
+	VerifyLinkURL string
 }
 
 // NewQueryResolverEmail returns email lookup resolver.
@@ -48,6 +54,8 @@ func NewQueryResolverEmail[
 		email:      cfg.Email,
 		toGraphQL:  cfg.ToGraphQL,
 		newPayload: cfg.NewPayload,
+
+		verifyLinkURL: cfg.VerifyLinkURL,
 	}
 }
 
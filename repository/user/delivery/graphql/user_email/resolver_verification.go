@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/demdxx/gocast/v2"
+	"go.uber.org/zap"
+
+	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	"github.com/geniusrabbit/blaze-api/pkg/messanger"
+	"github.com/geniusrabbit/blaze-api/pkg/requestid"
+	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
+)
+
+// MessageVerifyEmail is the name of the messanger template with the verification link.
+// Template variables: user, email, verify_token, verify_link, expires_at
+const MessageVerifyEmail = "user.verify-email"
+
+// RequestEmailVerification sends the verification link to the user email.
+// Zero userID means the current session user.
+func (r *QueryResolverEmail[TDomain, TGQLUser, TGQLUserPayload]) RequestEmailVerification(ctx context.Context, userID uint64) (*gqlmodels.StatusResponse, error) {
+	if !messanger.Get(ctx).IsEnabled() {
+		ctxlogger.Get(ctx).Error("Email service not configured")
+		return statusResponse(ctx, gqlmodels.ResponseStatusError, "Internal service problem. Request again later."), nil
+	}
+	if userID == 0 {
+		userID = session.UserID(ctx)
+	}
+	if userID == 0 {
+		return nil, acl.ErrNoPermissions
+	}
+
+	userObj, err := r.core.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if gocast.IsNil(userObj) {
+		return nil, acl.ErrNoPermissions
+	}
+
+	token, err := r.email.RequestVerification(ctx, userObj)
+	if err != nil {
+		return nil, err
+	}
+
+	email := userObj.GetEmail()
+	err = messanger.Get(ctx).Send(ctx, MessageVerifyEmail, []string{email}, map[string]any{
+		"user":         userObj,
+		"email":        email,
+		"verify_token": token.Token,
+		"verify_link":  messanger.Link(r.verifyLinkURL, map[string]string{"token": token.Token, "email": email}),
+		"expires_at":   token.ExpiresAt,
+	})
+	if err != nil {
+		ctxlogger.Get(ctx).Error("Error sending verification email",
+			zap.String("msgname", MessageVerifyEmail),
+			zap.Error(err))
+		return statusResponse(ctx, gqlmodels.ResponseStatusError, "Error sending verification email"), nil
+	}
+	return statusResponse(ctx, gqlmodels.ResponseStatusSuccess, "Verification link sent to "+email), nil
+}
+
+// VerifyEmail confirms the email by the token from the verification link.
+func (r *QueryResolverEmail[TDomain, TGQLUser, TGQLUserPayload]) VerifyEmail(ctx context.Context, token string) (*gqlmodels.StatusResponse, error) {
+	if _, err := r.email.VerifyEmail(ctx, token); err != nil {
+		return nil, err
+	}
+	return statusResponse(ctx, gqlmodels.ResponseStatusSuccess, "Email verified"), nil
+}
+
+func statusResponse(ctx context.Context, status gqlmodels.ResponseStatus, msg string) *gqlmodels.StatusResponse {
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           status,
+		Message:          &msg,
+	}
+}
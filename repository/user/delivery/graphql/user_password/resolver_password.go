@@ -83,8 +83,6 @@ func (r *QueryResolverPassword[TDomain, TGQLUser, TGQLUserInput, TGQLUserPayload
 		switch {
 		case errors.Is(err, userusecase.ErrInvalidCurrentPassword):
 			return nil, userusecase.ErrInvalidCurrentPassword
-		case errors.Is(err, userusecase.ErrPasswordTooShort):
-			return nil, userusecase.ErrPasswordTooShort
 		default:
 			return nil, err
 		}
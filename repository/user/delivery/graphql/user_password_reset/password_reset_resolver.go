@@ -2,9 +2,11 @@ package graphql
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/zap"
 
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
 	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/pkg/messanger"
 	"github.com/geniusrabbit/blaze-api/pkg/requestid"
@@ -13,23 +15,33 @@ import (
 	gqlmodels "github.com/geniusrabbit/blaze-api/server/graphql/models"
 )
 
+// MessageResetPassword is the name of the messanger template with the reset link.
+// Template variables: user, email, reset, reset_token, reset_link, expires_at
+const MessageResetPassword = "user.reset-password"
+
 // PasswordResetQueryResolver handles password reset GraphQL mutations (email → userID).
 type PasswordResetQueryResolver[T user.AuthCapableModel] struct {
-	email    user.EmailUsecase[T]
-	password user.PasswordUsecase[T]
+	email        user.EmailUsecase[T]
+	password     user.PasswordUsecase[T]
+	resetLinkURL string
 }
 
 // PasswordResetQueryResolverConfig wires password reset resolver.
 type PasswordResetQueryResolverConfig[T user.AuthCapableModel] struct {
 	Email    user.EmailUsecase[T]
 	Password user.PasswordUsecase[T]
+
+	// ResetLinkURL is the template of the reset link with {token} and {email} placeholders,
+	// e.g. https://example.com/reset-password?token={token}&email={email}
+	ResetLinkURL string
 }
 
 // NewPasswordResetQueryResolver returns password reset resolver.
 func NewPasswordResetQueryResolver[T user.AuthCapableModel](cfg PasswordResetQueryResolverConfig[T]) *PasswordResetQueryResolver[T] {
 	return &PasswordResetQueryResolver[T]{
-		email:    cfg.Email,
-		password: cfg.Password,
+		email:        cfg.Email,
+		password:     cfg.Password,
+		resetLinkURL: cfg.ResetLinkURL,
 	}
 }
 
@@ -51,29 +63,31 @@ func (r *PasswordResetQueryResolver[T]) ResetUserPassword(ctx context.Context, e
 	var zero T
 	if any(userObj) == any(zero) || userObj.GetID() == 0 {
 		ctxlogger.Get(ctx).Info("User not found for reset password", zap.String("email", email))
-		return &gqlmodels.StatusResponse{
-			ClientMutationID: requestid.Get(ctx),
-			Status:           gqlmodels.ResponseStatusSuccess,
-			Message:          &[]string{"Password reset link sent to " + email}[0],
-		}, nil
+		return resetResponse(ctx, email), nil
 	}
 
 	pswReset, userObj, err := r.password.ResetPassword(ctx, userObj.GetID())
+	if errors.Is(err, bruteforce.ErrTooManyAttempts) {
+		// The limited request is answered like the unknown email to not reveal the registered ones
+		ctxlogger.Get(ctx).Info("Reset password is limited", zap.String("email", email), zap.Error(err))
+		return resetResponse(ctx, email), nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if pswReset != nil && pswReset.UserID > 0 {
-		const msgName = "user.reset-password"
-		err = messanger.Get(ctx).Send(ctx, msgName, []string{email}, map[string]any{
+		err = messanger.Get(ctx).Send(ctx, MessageResetPassword, []string{email}, map[string]any{
 			"user":        userObj,
 			"email":       email,
 			"reset":       pswReset,
 			"reset_token": pswReset.Token,
+			"reset_link":  r.resetLink(pswReset.Token, email),
+			"expires_at":  pswReset.ExpiresAt,
 		})
 		if err != nil {
 			ctxlogger.Get(ctx).Error("Error sending reset password email",
-				zap.String("msgname", msgName),
+				zap.String("msgname", MessageResetPassword),
 				zap.Error(err))
 			return &gqlmodels.StatusResponse{
 				ClientMutationID: requestid.Get(ctx),
@@ -83,11 +97,7 @@ func (r *PasswordResetQueryResolver[T]) ResetUserPassword(ctx context.Context, e
 		}
 	}
 
-	return &gqlmodels.StatusResponse{
-		ClientMutationID: requestid.Get(ctx),
-		Status:           gqlmodels.ResponseStatusSuccess,
-		Message:          &[]string{"Password reset link sent to " + email}[0],
-	}, nil
+	return resetResponse(ctx, email), nil
 }
 
 // UpdateUserPassword is the resolver for the updateUserPassword field.
@@ -114,3 +124,15 @@ func (r *PasswordResetQueryResolver[T]) UpdateResetedUserPassword(ctx context.Co
 		Message:          &[]string{"Password updated"}[0],
 	}, nil
 }
+
+func (r *PasswordResetQueryResolver[T]) resetLink(token, email string) string {
+	return messanger.Link(r.resetLinkURL, map[string]string{"token": token, "email": email})
+}
+
+func resetResponse(ctx context.Context, email string) *gqlmodels.StatusResponse {
+	return &gqlmodels.StatusResponse{
+		ClientMutationID: requestid.Get(ctx),
+		Status:           gqlmodels.ResponseStatusSuccess,
+		Message:          &[]string{"Password reset link sent to " + email}[0],
+	}
+}
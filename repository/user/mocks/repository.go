@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	user "github.com/geniusrabbit/blaze-api/repository/user"
 	gomock "go.uber.org/mock/gomock"
@@ -139,6 +140,20 @@ func (mr *MockRepositoryMockRecorder[T]) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository[T])(nil).Get), ctx, id)
 }
 
+// SetSuspended mocks base method.
+func (m *MockRepository[T]) SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSuspended", ctx, id, suspended, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSuspended indicates an expected call of SetSuspended.
+func (mr *MockRepositoryMockRecorder[T]) SetSuspended(ctx, id, suspended, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSuspended", reflect.TypeOf((*MockRepository[T])(nil).SetSuspended), ctx, id, suspended, reason)
+}
+
 // Update mocks base method.
 func (m *MockRepository[T]) Update(ctx context.Context, arg1 T) error {
 	m.ctrl.T.Helper()
@@ -192,6 +207,89 @@ func (mr *MockEmailRepositoryMockRecorder[T]) GetByEmail(ctx, email any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockEmailRepository[T])(nil).GetByEmail), ctx, email)
 }
 
+// MockEmailTokenRepository is a mock of EmailTokenRepository interface.
+type MockEmailTokenRepository[T user.EmailCapableModel] struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailTokenRepositoryMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockEmailTokenRepositoryMockRecorder is the mock recorder for MockEmailTokenRepository.
+type MockEmailTokenRepositoryMockRecorder[T user.EmailCapableModel] struct {
+	mock *MockEmailTokenRepository[T]
+}
+
+// NewMockEmailTokenRepository creates a new mock instance.
+func NewMockEmailTokenRepository[T user.EmailCapableModel](ctrl *gomock.Controller) *MockEmailTokenRepository[T] {
+	mock := &MockEmailTokenRepository[T]{ctrl: ctrl}
+	mock.recorder = &MockEmailTokenRepositoryMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailTokenRepository[T]) EXPECT() *MockEmailTokenRepositoryMockRecorder[T] {
+	return m.recorder
+}
+
+// ConsumeEmailToken mocks base method.
+func (m *MockEmailTokenRepository[T]) ConsumeEmailToken(ctx context.Context, purpose, token string) (*user.UserEmailToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeEmailToken", ctx, purpose, token)
+	ret0, _ := ret[0].(*user.UserEmailToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeEmailToken indicates an expected call of ConsumeEmailToken.
+func (mr *MockEmailTokenRepositoryMockRecorder[T]) ConsumeEmailToken(ctx, purpose, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeEmailToken", reflect.TypeOf((*MockEmailTokenRepository[T])(nil).ConsumeEmailToken), ctx, purpose, token)
+}
+
+// CreateEmailToken mocks base method.
+func (m *MockEmailTokenRepository[T]) CreateEmailToken(ctx context.Context, userID uint64, email, purpose string, lifetime time.Duration) (*user.UserEmailToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailToken", ctx, userID, email, purpose, lifetime)
+	ret0, _ := ret[0].(*user.UserEmailToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailToken indicates an expected call of CreateEmailToken.
+func (mr *MockEmailTokenRepositoryMockRecorder[T]) CreateEmailToken(ctx, userID, email, purpose, lifetime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailToken", reflect.TypeOf((*MockEmailTokenRepository[T])(nil).CreateEmailToken), ctx, userID, email, purpose, lifetime)
+}
+
+// LastEmailToken mocks base method.
+func (m *MockEmailTokenRepository[T]) LastEmailToken(ctx context.Context, userID uint64, purpose string) (*user.UserEmailToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastEmailToken", ctx, userID, purpose)
+	ret0, _ := ret[0].(*user.UserEmailToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastEmailToken indicates an expected call of LastEmailToken.
+func (mr *MockEmailTokenRepositoryMockRecorder[T]) LastEmailToken(ctx, userID, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastEmailToken", reflect.TypeOf((*MockEmailTokenRepository[T])(nil).LastEmailToken), ctx, userID, purpose)
+}
+
+// SetEmailVerified mocks base method.
+func (m *MockEmailTokenRepository[T]) SetEmailVerified(ctx context.Context, arg1 T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerified", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerified indicates an expected call of SetEmailVerified.
+func (mr *MockEmailTokenRepositoryMockRecorder[T]) SetEmailVerified(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerified", reflect.TypeOf((*MockEmailTokenRepository[T])(nil).SetEmailVerified), ctx, arg1)
+}
+
 // MockPasswordRepository is a mock of PasswordRepository interface.
 type MockPasswordRepository[T user.PasswordCapableModel] struct {
 	ctrl     *gomock.Controller
@@ -216,6 +314,20 @@ func (m *MockPasswordRepository[T]) EXPECT() *MockPasswordRepositoryMockRecorder
 	return m.recorder
 }
 
+// ConsumeResetPassword mocks base method.
+func (m *MockPasswordRepository[T]) ConsumeResetPassword(ctx context.Context, userID uint64, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeResetPassword", ctx, userID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeResetPassword indicates an expected call of ConsumeResetPassword.
+func (mr *MockPasswordRepositoryMockRecorder[T]) ConsumeResetPassword(ctx, userID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeResetPassword", reflect.TypeOf((*MockPasswordRepository[T])(nil).ConsumeResetPassword), ctx, userID, token)
+}
+
 // CreateResetPassword mocks base method.
 func (m *MockPasswordRepository[T]) CreateResetPassword(ctx context.Context, userID uint64) (*user.UserPasswordReset, error) {
 	m.ctrl.T.Helper()
@@ -303,3 +415,56 @@ func (mr *MockPasswordRepositoryMockRecorder[T]) SetPassword(ctx, arg1, password
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockPasswordRepository[T])(nil).SetPassword), ctx, arg1, password)
 }
+
+// MockPasswordHistoryRepository is a mock of PasswordHistoryRepository interface.
+type MockPasswordHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordHistoryRepositoryMockRecorder is the mock recorder for MockPasswordHistoryRepository.
+type MockPasswordHistoryRepositoryMockRecorder struct {
+	mock *MockPasswordHistoryRepository
+}
+
+// NewMockPasswordHistoryRepository creates a new mock instance.
+func NewMockPasswordHistoryRepository(ctrl *gomock.Controller) *MockPasswordHistoryRepository {
+	mock := &MockPasswordHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHistoryRepository) EXPECT() *MockPasswordHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPasswordHistory mocks base method.
+func (m *MockPasswordHistoryRepository) AddPasswordHistory(ctx context.Context, userID uint64, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPasswordHistory", ctx, userID, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPasswordHistory indicates an expected call of AddPasswordHistory.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) AddPasswordHistory(ctx, userID, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPasswordHistory", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).AddPasswordHistory), ctx, userID, hash)
+}
+
+// PasswordHistory mocks base method.
+func (m *MockPasswordHistoryRepository) PasswordHistory(ctx context.Context, userID uint64, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordHistory", ctx, userID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PasswordHistory indicates an expected call of PasswordHistory.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) PasswordHistory(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordHistory", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).PasswordHistory), ctx, userID, limit)
+}
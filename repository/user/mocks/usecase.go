@@ -139,6 +139,36 @@ func (mr *MockUsecaseMockRecorder[T]) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsecase[T])(nil).Get), ctx, id)
 }
 
+// Suspend mocks base method.
+func (m *MockUsecase[T]) Suspend(ctx context.Context, id uint64, reason string) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, id, reason)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockUsecaseMockRecorder[T]) Suspend(ctx, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockUsecase[T])(nil).Suspend), ctx, id, reason)
+}
+
+// Unsuspend mocks base method.
+func (m *MockUsecase[T]) Unsuspend(ctx context.Context, id uint64) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, id)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockUsecaseMockRecorder[T]) Unsuspend(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockUsecase[T])(nil).Unsuspend), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockUsecase[T]) Update(ctx context.Context, arg1 T) error {
 	m.ctrl.T.Helper()
@@ -192,6 +222,67 @@ func (mr *MockEmailUsecaseMockRecorder[T]) GetByEmail(ctx, email any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockEmailUsecase[T])(nil).GetByEmail), ctx, email)
 }
 
+// LoginByLink mocks base method.
+func (m *MockEmailUsecase[T]) LoginByLink(ctx context.Context, token string) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginByLink", ctx, token)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginByLink indicates an expected call of LoginByLink.
+func (mr *MockEmailUsecaseMockRecorder[T]) LoginByLink(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginByLink", reflect.TypeOf((*MockEmailUsecase[T])(nil).LoginByLink), ctx, token)
+}
+
+// RequestLoginLink mocks base method.
+func (m *MockEmailUsecase[T]) RequestLoginLink(ctx context.Context, email string) (*user.UserEmailToken, T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestLoginLink", ctx, email)
+	ret0, _ := ret[0].(*user.UserEmailToken)
+	ret1, _ := ret[1].(T)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RequestLoginLink indicates an expected call of RequestLoginLink.
+func (mr *MockEmailUsecaseMockRecorder[T]) RequestLoginLink(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestLoginLink", reflect.TypeOf((*MockEmailUsecase[T])(nil).RequestLoginLink), ctx, email)
+}
+
+// RequestVerification mocks base method.
+func (m *MockEmailUsecase[T]) RequestVerification(ctx context.Context, arg1 T) (*user.UserEmailToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestVerification", ctx, arg1)
+	ret0, _ := ret[0].(*user.UserEmailToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestVerification indicates an expected call of RequestVerification.
+func (mr *MockEmailUsecaseMockRecorder[T]) RequestVerification(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestVerification", reflect.TypeOf((*MockEmailUsecase[T])(nil).RequestVerification), ctx, arg1)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockEmailUsecase[T]) VerifyEmail(ctx context.Context, token string) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockEmailUsecaseMockRecorder[T]) VerifyEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockEmailUsecase[T])(nil).VerifyEmail), ctx, token)
+}
+
 // MockPasswordUsecase is a mock of PasswordUsecase interface.
 type MockPasswordUsecase[T user.PasswordCapableModel] struct {
 	ctrl     *gomock.Controller
@@ -287,3 +378,17 @@ func (mr *MockPasswordUsecaseMockRecorder[T]) UpdatePassword(ctx, userID, token,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockPasswordUsecase[T])(nil).UpdatePassword), ctx, userID, token, password)
 }
+
+// ValidatePassword mocks base method.
+func (m *MockPasswordUsecase[T]) ValidatePassword(ctx context.Context, arg1 T, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePassword", ctx, arg1, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePassword indicates an expected call of ValidatePassword.
+func (mr *MockPasswordUsecaseMockRecorder[T]) ValidatePassword(ctx, arg1, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePassword", reflect.TypeOf((*MockPasswordUsecase[T])(nil).ValidatePassword), ctx, arg1, password)
+}
@@ -5,6 +5,9 @@ import "github.com/geniusrabbit/blaze-api/repository/user/models"
 // UserPasswordReset is the model for password reset tokens.
 type UserPasswordReset = models.UserPasswordReset
 
+// UserEmailToken is the model for email verification and login link tokens.
+type UserEmailToken = models.UserEmailToken
+
 // Model trait type aliases — compose these in your consumer user struct.
 type (
 	// Base is the minimal user identity (ID, approve status, timestamps).
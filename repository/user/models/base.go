@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,6 +16,12 @@ type UserBase struct {
 	CreatedAt time.Time               `json:"created_at"`
 	UpdatedAt time.Time               `json:"updated_at"`
 	DeletedAt gorm.DeletedAt          `json:"deleted_at"`
+
+	// LastActiveAt in any account, updated by the activity tracker only
+	LastActiveAt sql.Null[time.Time] `json:"last_active_at" gorm:"column:last_active_at;->"`
+
+	// Suspended user keeps the data but can't login
+	pkgModels.Suspension
 }
 
 // GetID returns user ID.
@@ -47,3 +47,17 @@ func TestExtendedUserCustomTable(t *testing.T) {
 		t.Fatalf("GetEmail() = %q", u.GetEmail())
 	}
 }
+
+func TestEmailChangeResetsVerification(t *testing.T) {
+	u := &ExtendedUser{}
+	u.SetEmail("a@b.c")
+	u.SetEmailVerified(true)
+	u.SetEmail("a@b.c")
+	if !u.IsEmailVerified() {
+		t.Fatal("verification must be kept for the same email")
+	}
+	u.SetEmail("x@b.c")
+	if u.IsEmailVerified() {
+		t.Fatal("verification must be dropped after the email change")
+	}
+}
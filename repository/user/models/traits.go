@@ -2,7 +2,8 @@ package models
 
 // UserEmail optional trait: email identity.
 type UserEmail struct {
-	Email string `json:"email" gorm:"column:email;not null;default:'';"`
+	Email         string `json:"email" gorm:"column:email;not null;default:'';"`
+	EmailVerified bool   `json:"email_verified" gorm:"column:email_verified;not null;default:false"`
 }
 
 // GetEmail returns the user email.
@@ -14,17 +15,41 @@ func (u *UserEmail) GetEmail() string {
 }
 
 // SetEmail sets the user email.
+// The verification flag is dropped if the email is changed.
 func (u *UserEmail) SetEmail(email string) {
 	if u != nil {
+		if u.Email != email {
+			u.EmailVerified = false
+		}
 		u.Email = email
 	}
 }
 
+// IsEmailVerified reports whether the user confirmed the email.
+func (u *UserEmail) IsEmailVerified() bool {
+	if u == nil {
+		return false
+	}
+	return u.EmailVerified
+}
+
+// SetEmailVerified sets the email verification flag.
+func (u *UserEmail) SetEmailVerified(verified bool) {
+	if u != nil {
+		u.EmailVerified = verified
+	}
+}
+
 // EmailColumn returns the database column name.
 func (u *UserEmail) EmailColumn() string {
 	return "email"
 }
 
+// EmailVerifiedColumn returns the database column name of the verification flag.
+func (u *UserEmail) EmailVerifiedColumn() string {
+	return "email_verified"
+}
+
 // UserUsername optional trait: separate username (GraphQL compatibility).
 type UserUsername struct {
 	Username string `json:"username" gorm:"column:username"`
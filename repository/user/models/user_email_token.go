@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// Purposes of the email tokens
+const (
+	EmailTokenPurposeVerify = "verify"
+	EmailTokenPurposeLogin  = "login"
+)
+
+// UserEmailToken is the single-use token sent to the user email
+// to confirm the address or to login by the link
+type UserEmailToken struct {
+	ID      uint64 `json:"id" gorm:"primaryKey"`
+	UserID  uint64 `json:"user_id" gorm:"index"`
+	Email   string `json:"email"`
+	Purpose string `json:"purpose"`
+
+	// Token contains the hash of the token in the database,
+	// the plain token is available only right after the creation
+	Token string `json:"token" gorm:"index:,unique" limit:"128"`
+
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TableName returns the name in database
+func (u *UserEmailToken) TableName() string {
+	return "account_user_email_token"
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// UserPasswordHistory keeps previous password hashes of the user
+// to prevent the reuse of the recent passwords
+type UserPasswordHistory struct {
+	ID       uint64 `json:"id" gorm:"primaryKey"`
+	UserID   uint64 `json:"user_id" gorm:"index"`
+	Password string `json:"-" gorm:"column:password"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the name in database
+func (u *UserPasswordHistory) TableName() string {
+	return "account_user_password_history"
+}
@@ -7,7 +7,10 @@ import (
 // UserPasswordReset direct defenition
 type UserPasswordReset struct {
 	UserID uint64 `json:"user_id" gorm:"primaryKey"`
-	Token  string `json:"token" gorm:"index:,unique" limit:"128"`
+
+	// Token contains the hash of the token in the database,
+	// the plain token is available only right after the creation
+	Token string `json:"token" gorm:"index:,unique" limit:"128"`
 
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
@@ -0,0 +1,90 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+)
+
+// hashPrefixSize is the size of the SHA-1 prefix sent to the range source
+const hashPrefixSize = 5
+
+// RangeSource returns the list of uppercase SHA-1 suffixes of the breached
+// passwords by the 5 symbol prefix (k-anonymity model of the Have I Been Pwned range API)
+type RangeSource interface {
+	Range(ctx context.Context, prefix string) ([]string, error)
+}
+
+// KAnonymityChecker checks the password by the hash prefix
+// so the password or its full hash never leaves the checker
+type KAnonymityChecker struct {
+	source RangeSource
+}
+
+// NewKAnonymityChecker returns the breached password checker over the range source
+func NewKAnonymityChecker(source RangeSource) *KAnonymityChecker {
+	return &KAnonymityChecker{source: source}
+}
+
+// IsBreached returns true if the password is found in the source
+func (c *KAnonymityChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := c.source.Range(ctx, hash[:hashPrefixSize])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if suffix == hash[hashPrefixSize:] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LocalRangeSource keeps the breached password hashes in memory
+type LocalRangeSource struct {
+	ranges map[string][]string
+}
+
+// NewLocalRangeSource reads the list of breached passwords in the format
+// of the HIBP dump `SHA1HEX[:COUNT]`, one hash per line
+func NewLocalRangeSource(r io.Reader) (*LocalRangeSource, error) {
+	src := &LocalRangeSource{ranges: map[string][]string{}}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.IndexByte(line, ':'); idx >= 0 {
+			line = line[:idx]
+		}
+		if len(line) != sha1.Size*2 {
+			continue
+		}
+		line = strings.ToUpper(line)
+		prefix := line[:hashPrefixSize]
+		src.ranges[prefix] = append(src.ranges[prefix], line[hashPrefixSize:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// NewLocalRangeSourceFromFile reads the list of breached passwords from the file
+func NewLocalRangeSourceFromFile(filename string) (*LocalRangeSource, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return NewLocalRangeSource(file)
+}
+
+// Range returns suffixes of the hashes with the prefix
+func (s *LocalRangeSource) Range(_ context.Context, prefix string) ([]string, error) {
+	return s.ranges[strings.ToUpper(prefix)], nil
+}
@@ -1,45 +1,148 @@
 package password
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidHash returned if the hash has unsupported format
+var ErrInvalidHash = errors.New(`invalid password hash format`)
+
+// ErrMismatchedPassword returned if the password doesn't match the hash
+var ErrMismatchedPassword = errors.New(`password does not match the hash`)
+
+// bcryptMaxInput is the maximal input size which bcrypt takes into account
+const bcryptMaxInput = 72
+
+const argon2idPrefix = "$argon2id$"
+
+// Argon2Params of the argon2id hashing
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params is the second recommended option from RFC 9106
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
 // salt is the additional noise in the password to improve cryptographic strength
 var (
-	salt []byte
-	cost = bcrypt.DefaultCost
+	salt         []byte
+	argon2Params = DefaultArgon2Params
 )
 
-// SetSalt for passowrd
-func SetSalt(s []byte, c int) {
+// SetSalt for passowrd.
+// The bcrypt cost is not used anymore as new hashes are argon2id,
+// the argument is kept for compatibility (see SetArgon2Params).
+func SetSalt(s []byte, _ int) {
 	salt = s
-	if c < 1 {
-		c = bcrypt.DefaultCost
-	}
-	cost = c
 }
 
-// ComparePasswords between income password and generated hash
+// SetArgon2Params changes the parameters of the new argon2id hashes.
+// Existing hashes with other parameters are marked by NeedsRehash.
+func SetArgon2Params(params Argon2Params) {
+	argon2Params = params
+}
+
+// ComparePasswords between income password and generated hash.
+// Supports argon2id hashes and the legacy bcrypt hashes.
 func ComparePasswords(hashedPwd string, plainPwd []byte) error {
-	// Since we'll be getting the hashed password from the DB it
-	// will be a string so we'll need to convert it to a byte slice
-	byteHash := []byte(hashedPwd)
-	return bcrypt.CompareHashAndPassword(byteHash, append(plainPwd, salt...))
+	if strings.HasPrefix(hashedPwd, argon2idPrefix) {
+		return compareArgon2id(hashedPwd, plainPwd)
+	}
+	// Legacy bcrypt hash of the password with appended salt.
+	// Older versions of bcrypt were silently truncating the input to 72 bytes
+	// so we need to do the same to keep such passwords valid.
+	input := append(append([]byte{}, plainPwd...), salt...)
+	if len(input) > bcryptMaxInput {
+		input = input[:bcryptMaxInput]
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hashedPwd), input)
 }
 
-// PasswordHash process and return processed password to bcrypt hash
+// PasswordHash process and return processed password to argon2id hash
+// in the PHC string format: $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
 func PasswordHash(pwd []byte) (string, error) {
-	// Use GenerateFromPassword to hash & salt pwd
-	// MinCost is just an integer constant provided by the bcrypt
-	// package along with DefaultCost & MaxCost.
-	// The cost can be any value you want provided it isn't lower
-	// than the MinCost (4)
-	hash, err := bcrypt.GenerateFromPassword(append(pwd, salt...), cost)
-	if err != nil {
+	params := argon2Params
+	pwdSalt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(pwdSalt); err != nil {
 		return "", err
 	}
+	key := argon2.IDKey(append(append([]byte{}, pwd...), salt...), pwdSalt,
+		params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(pwdSalt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
 
-	// GenerateFromPassword returns a byte slice so we need to
-	// convert the bytes to a string and return it
-	return string(hash), nil
+// NeedsRehash returns true if the hash was generated by the legacy algorithm
+// or with the parameters different from the current ones
+func NeedsRehash(hashedPwd string) bool {
+	params, _, key, err := decodeArgon2id(hashedPwd)
+	if err != nil {
+		return true
+	}
+	return params.Memory != argon2Params.Memory ||
+		params.Iterations != argon2Params.Iterations ||
+		params.Parallelism != argon2Params.Parallelism ||
+		uint32(len(key)) != argon2Params.KeyLength
+}
+
+func compareArgon2id(hashedPwd string, plainPwd []byte) error {
+	params, pwdSalt, key, err := decodeArgon2id(hashedPwd)
+	if err != nil {
+		return err
+	}
+	otherKey := argon2.IDKey(append(append([]byte{}, plainPwd...), salt...), pwdSalt,
+		params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrMismatchedPassword
+	}
+	return nil
+}
+
+func decodeArgon2id(hashedPwd string) (params Argon2Params, pwdSalt, key []byte, err error) {
+	if !strings.HasPrefix(hashedPwd, argon2idPrefix) {
+		return params, nil, nil, ErrInvalidHash
+	}
+	// ["", "argon2id", "v=19", "m=65536,t=3,p=4", "<salt>", "<hash>"]
+	parts := strings.Split(hashedPwd, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidHash
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if pwdSalt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+	params.SaltLength = uint32(len(pwdSalt))
+	params.KeyLength = uint32(len(key))
+	return params, pwdSalt, key, nil
 }
@@ -1,18 +1,106 @@
 package password
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
+var testArgon2Params = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
 func Test_Password(t *testing.T) {
 	SetSalt([]byte("cm2REbMsJfzVBN8vtd1NPw3UI75ef-zucSdZqqQQMo0"), 0)
+	SetArgon2Params(testArgon2Params)
 
 	var (
 		testPassword = []byte("test")
 		hash, err    = PasswordHash(testPassword)
 	)
 	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
 	assert.NoError(t, ComparePasswords(hash, testPassword))
+	assert.ErrorIs(t, ComparePasswords(hash, []byte("test2")), ErrMismatchedPassword)
+	assert.False(t, NeedsRehash(hash))
+
+	SetArgon2Params(Argon2Params{Memory: 2048, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	assert.True(t, NeedsRehash(hash))
+	assert.NoError(t, ComparePasswords(hash, testPassword), "old parameters must be still valid")
+	SetArgon2Params(testArgon2Params)
+
+	longPassword := []byte(strings.Repeat("long-password", 10))
+	hash, err = PasswordHash(longPassword)
+	assert.NoError(t, err)
+	assert.NoError(t, ComparePasswords(hash, longPassword))
+	assert.Error(t, ComparePasswords(hash, longPassword[:72]), "whole password must be used")
+}
+
+func Test_PasswordLegacyBcrypt(t *testing.T) {
+	SetSalt([]byte("salt"), 0)
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte("testsalt"), bcrypt.MinCost)
+	require.NoError(t, err)
+	assert.NoError(t, ComparePasswords(string(legacyHash), []byte("test")))
+	assert.Error(t, ComparePasswords(string(legacyHash), []byte("test1")))
+	assert.True(t, NeedsRehash(string(legacyHash)))
+}
+
+func Test_Policy(t *testing.T) {
+	SetSalt(nil, 0)
+	SetArgon2Params(testArgon2Params)
+	ctx := context.Background()
+
+	source, err := NewLocalRangeSource(strings.NewReader(
+		// SHA-1 of "password1" and "qwerty"
+		"E38AD214943DAAD1D64C102FAEC29DE4AFE9DA3D:100\nb1b3773a05c0ed0176787a4f1574ff0075f7521e\n"))
+	require.NoError(t, err)
+
+	policy := &Policy{
+		MinLength:      8,
+		MaxLength:      20,
+		RequireLower:   true,
+		RequireUpper:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		HistorySize:    2,
+		Breached:       NewKAnonymityChecker(source),
+	}
+	oldHash, _ := PasswordHash([]byte("Old-Passw0rd"))
+
+	assert.NoError(t, policy.Validate(ctx, "Good-Passw0rd", oldHash))
+	assert.ErrorIs(t, policy.Validate(ctx, "Old-Passw0rd", oldHash), ErrPasswordReused)
+	assert.NoError(t, policy.Validate(ctx, "Old-Passw0rd", "", "", oldHash), "history is limited by HistorySize")
+
+	err = policy.Validate(ctx, "short")
+	var policyErr *PolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.ErrorIs(t, err, ErrPasswordTooShort)
+	assert.ErrorIs(t, err, ErrPasswordNoUpper)
+	assert.ErrorIs(t, err, ErrPasswordNoDigit)
+	assert.ErrorIs(t, err, ErrPasswordNoSpecial)
+	assert.NotErrorIs(t, err, ErrPasswordNoLower)
+	assert.Equal(t, []string{"TOO_SHORT", "NO_UPPERCASE", "NO_DIGIT", "NO_SPECIAL"}, policyErr.Extensions()["violations"])
+
+	assert.ErrorIs(t, policy.Validate(ctx, strings.Repeat("Aa1!", 6)), ErrPasswordTooLong)
+
+	policy = &Policy{MinLength: 1, Breached: policy.Breached}
+	assert.ErrorIs(t, policy.Validate(ctx, "password1"), ErrPasswordBreached)
+	assert.ErrorIs(t, policy.Validate(ctx, "qwerty"), ErrPasswordBreached)
+	assert.NoError(t, policy.Validate(ctx, "qwerty1"))
+
+	assert.NoError(t, (*Policy)(nil).Validate(ctx, ""))
+}
+
+func Test_ResetToken(t *testing.T) {
+	token := GenerateResetToken(64)
+	assert.Len(t, token, 64)
+	assert.NotEqual(t, token, GenerateResetToken(64))
+	for _, c := range token {
+		assert.Contains(t, tokenAlphabet, string(c))
+	}
+	assert.Len(t, HashResetToken(token), 64)
+	assert.Equal(t, HashResetToken(token), HashResetToken(token))
+	assert.NotEqual(t, token, HashResetToken(token))
 }
@@ -0,0 +1,163 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Policy violation errors
+var (
+	ErrPasswordTooShort  = errors.New(`password is too short`)
+	ErrPasswordTooLong   = errors.New(`password is too long`)
+	ErrPasswordNoLower   = errors.New(`password must contain a lowercase letter`)
+	ErrPasswordNoUpper   = errors.New(`password must contain an uppercase letter`)
+	ErrPasswordNoDigit   = errors.New(`password must contain a digit`)
+	ErrPasswordNoSpecial = errors.New(`password must contain a special character`)
+	ErrPasswordBreached  = errors.New(`password is found in the list of breached passwords`)
+	ErrPasswordReused    = errors.New(`password was used recently`)
+)
+
+var violationCodes = map[error]string{
+	ErrPasswordTooShort:  "TOO_SHORT",
+	ErrPasswordTooLong:   "TOO_LONG",
+	ErrPasswordNoLower:   "NO_LOWERCASE",
+	ErrPasswordNoUpper:   "NO_UPPERCASE",
+	ErrPasswordNoDigit:   "NO_DIGIT",
+	ErrPasswordNoSpecial: "NO_SPECIAL",
+	ErrPasswordBreached:  "BREACHED",
+	ErrPasswordReused:    "REUSED",
+}
+
+// BreachedChecker verifies the password against the known leaks
+type BreachedChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// Policy of the password strength
+type Policy struct {
+	// MinLength of the password in characters
+	MinLength int `json:"min_length" yaml:"min_length"`
+
+	// MaxLength of the password in characters (0 - unlimited)
+	MaxLength int `json:"max_length" yaml:"max_length"`
+
+	RequireLower   bool `json:"require_lower" yaml:"require_lower"`
+	RequireUpper   bool `json:"require_upper" yaml:"require_upper"`
+	RequireDigit   bool `json:"require_digit" yaml:"require_digit"`
+	RequireSpecial bool `json:"require_special" yaml:"require_special"`
+
+	// HistorySize is the number of the last passwords which can't be reused (0 - disabled)
+	HistorySize int `json:"history_size" yaml:"history_size"`
+
+	// Breached passwords checker (nil - disabled)
+	Breached BreachedChecker `json:"-" yaml:"-"`
+}
+
+// DefaultPolicy keeps the minimal requirements only
+func DefaultPolicy() *Policy {
+	return &Policy{MinLength: 8, MaxLength: 256}
+}
+
+// PolicyError contains all violations of the policy
+type PolicyError struct {
+	Violations []error
+}
+
+func (err *PolicyError) Error() string {
+	msgs := make([]string, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		msgs = append(msgs, v.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the list of violations
+func (err *PolicyError) Unwrap() []error {
+	return err.Violations
+}
+
+// Extensions returns the error details for the API response
+func (err *PolicyError) Extensions() map[string]any {
+	codes := make([]string, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		codes = append(codes, violationCodes[v])
+	}
+	return map[string]any{
+		"code":       "PASSWORD_POLICY",
+		"violations": codes,
+	}
+}
+
+// Validate the password and returns *PolicyError with all violations.
+// The history is the list of the previous password hashes of the user.
+func (p *Policy) Validate(ctx context.Context, password string, history ...string) error {
+	if p == nil {
+		return nil
+	}
+	var violations []error
+	if length := utf8.RuneCountInString(password); length < p.MinLength {
+		violations = append(violations, ErrPasswordTooShort)
+	} else if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, ErrPasswordTooLong)
+	}
+	violations = append(violations, p.validateClasses(password)...)
+
+	if p.Breached != nil && password != "" {
+		breached, err := p.Breached.IsBreached(ctx, password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			violations = append(violations, ErrPasswordBreached)
+		}
+	}
+
+	if p.HistorySize > 0 {
+		if len(history) > p.HistorySize {
+			history = history[:p.HistorySize]
+		}
+		for _, hash := range history {
+			if hash != "" && ComparePasswords(hash, []byte(password)) == nil {
+				violations = append(violations, ErrPasswordReused)
+				break
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (p *Policy) validateClasses(password string) (violations []error) {
+	var hasLower, hasUpper, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, ErrPasswordNoLower)
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, ErrPasswordNoUpper)
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, ErrPasswordNoDigit)
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, ErrPasswordNoSpecial)
+	}
+	return violations
+}
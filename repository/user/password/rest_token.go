@@ -1,17 +1,31 @@
 package password
 
 import (
-	"bytes"
-	"math/rand"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 )
 
-const tokenAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-$@"
+// tokenAlphabet contains only URL safe symbols, the size is 64 so
+// every random byte maps to the symbol without the modulo bias
+const tokenAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"
 
-// GenerateResetToken generates a random token for password reset
+// GenerateResetToken generates a cryptographically secure random token for password reset
 func GenerateResetToken(size int) string {
-	var randomString bytes.Buffer
-	for i := 0; i < size; i++ {
-		randomString.WriteByte(tokenAlphabet[rand.Intn(len(tokenAlphabet))])
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand never returns an error on the supported platforms
+		panic(err)
 	}
-	return randomString.String()
+	for i, b := range buf {
+		buf[i] = tokenAlphabet[int(b)&(len(tokenAlphabet)-1)]
+	}
+	return string(buf)
+}
+
+// HashResetToken returns the hash of the token which is stored in the database
+// instead of the token itself. The token has enough entropy so the fast hash is sufficient.
+func HashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -3,6 +3,7 @@ package user
 
 import (
 	"context"
+	"time"
 )
 
 // Repository is the core CRUD repository parameterized by user model type.
@@ -17,6 +18,7 @@ type Repository[T Model] interface {
 	Create(ctx context.Context, user T) (uint64, error)
 	Update(ctx context.Context, user T) error
 	Delete(ctx context.Context, id uint64) error
+	SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error
 }
 
 // EmailRepository provides email lookup for models with UserEmail trait.
@@ -24,6 +26,15 @@ type EmailRepository[T EmailCapableModel] interface {
 	GetByEmail(ctx context.Context, email string) (T, error)
 }
 
+// EmailTokenRepository keeps single-use tokens sent to the user email
+// (verification of the address and login by the link).
+type EmailTokenRepository[T EmailCapableModel] interface {
+	CreateEmailToken(ctx context.Context, userID uint64, email, purpose string, lifetime time.Duration) (*UserEmailToken, error)
+	LastEmailToken(ctx context.Context, userID uint64, purpose string) (*UserEmailToken, error)
+	ConsumeEmailToken(ctx context.Context, purpose, token string) (*UserEmailToken, error)
+	SetEmailVerified(ctx context.Context, user T) error
+}
+
 // PasswordRepository provides password auth and reset token operations.
 type PasswordRepository[T PasswordCapableModel] interface {
 	GetByPassword(ctx context.Context, userID uint64, password string) (T, error)
@@ -31,5 +42,13 @@ type PasswordRepository[T PasswordCapableModel] interface {
 	SetPassword(ctx context.Context, user T, password string) error
 	CreateResetPassword(ctx context.Context, userID uint64) (*UserPasswordReset, error)
 	GetResetPassword(ctx context.Context, userID uint64, token string) (*UserPasswordReset, error)
+	ConsumeResetPassword(ctx context.Context, userID uint64, token string) error
 	EliminateResetPassword(ctx context.Context, userID uint64) error
 }
+
+// PasswordHistoryRepository keeps the previous password hashes of users.
+// It's optional and used only if the password policy limits the reuse of passwords.
+type PasswordHistoryRepository interface {
+	AddPasswordHistory(ctx context.Context, userID uint64, hash string) error
+	PasswordHistory(ctx context.Context, userID uint64, limit int) ([]string, error)
+}
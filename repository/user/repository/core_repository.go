@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
+	"github.com/demdxx/gocast/v2"
 	"github.com/pkg/errors"
 
 	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
@@ -86,3 +88,18 @@ func (r *coreRepository[T]) Delete(ctx context.Context, id uint64) error {
 	}
 	return nil
 }
+
+func (r *coreRepository[T]) SetSuspended(ctx context.Context, id uint64, suspended bool, reason string) error {
+	// Map update to be able to reset the suspension to the NULL value
+	res := r.Master(ctx).Model(r.newModel()).Where(`id=?`, id).Updates(map[string]any{
+		`suspended_at`:   sql.Null[time.Time]{V: time.Now(), Valid: suspended},
+		`suspend_reason`: gocast.IfThen(suspended, reason, ""),
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -4,21 +4,38 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 
-	baseRepo "github.com/geniusrabbit/blaze-api/repository"
-	"github.com/geniusrabbit/blaze-api/repository/user"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+	baseRepo "github.com/geniusrabbit/blaze-api/repository"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/repository/user/models"
+	"github.com/geniusrabbit/blaze-api/repository/user/password"
 )
 
+// ErrEmailVerificationUnsupported returned if the user model has no verification flag
+var ErrEmailVerificationUnsupported = errors.New(`email verification is not supported by the user model`)
+
+// emailTokenSize is the length of the plain token in the email links
+const emailTokenSize = 64
+
 type emailRepository[T user.EmailCapableModel] struct {
 	baseRepo.Repository
 	core     user.Repository[T]
 	newModel func() T
 }
 
+// EmailRepositoryWithTokens combines email lookup with the email tokens storage
+type EmailRepositoryWithTokens[T user.EmailCapableModel] interface {
+	user.EmailRepository[T]
+	user.EmailTokenRepository[T]
+}
+
 // NewEmailRepository creates email lookup repository.
-func NewEmailRepository[T user.EmailCapableModel](core user.Repository[T], newModel func() T) user.EmailRepository[T] {
+func NewEmailRepository[T user.EmailCapableModel](core user.Repository[T], newModel func() T) EmailRepositoryWithTokens[T] {
 	return &emailRepository[T]{core: core, newModel: newModel}
 }
 
@@ -34,3 +51,82 @@ func (r *emailRepository[T]) GetByEmail(ctx context.Context, email string) (T, e
 	}
 	return object, nil
 }
+
+// CreateEmailToken creates a new token for the email of the user.
+// The previous tokens of the user with the same purpose are removed, only the hash
+// of the token is stored and the returned object contains the plain token.
+func (r *emailRepository[T]) CreateEmailToken(ctx context.Context, userID uint64, email, purpose string, lifetime time.Duration) (*models.UserEmailToken, error) {
+	token := password.GenerateResetToken(emailTokenSize)
+	now := time.Now()
+	object := &models.UserEmailToken{
+		UserID:    userID,
+		Email:     email,
+		Purpose:   purpose,
+		Token:     password.HashResetToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(lifetime),
+	}
+	err := r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.Delete(&models.UserEmailToken{}, `user_id=? AND purpose=?`, userID, purpose).Error; err != nil {
+			return err
+		}
+		return tx.Create(object).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	object.Token = token
+	return object, nil
+}
+
+// LastEmailToken returns the latest token of the user with the purpose.
+// The token field contains the hash of the token.
+func (r *emailRepository[T]) LastEmailToken(ctx context.Context, userID uint64, purpose string) (*models.UserEmailToken, error) {
+	object := new(models.UserEmailToken)
+	err := r.Slave(ctx).Order(`created_at DESC`).
+		First(object, `user_id=? AND purpose=?`, userID, purpose).Error
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// ConsumeEmailToken finds the token and removes it, so the token can be used only once.
+// Returns gorm.ErrRecordNotFound if the token doesn't exist or was already used.
+// The expiration of the token is checked by the caller.
+func (r *emailRepository[T]) ConsumeEmailToken(ctx context.Context, purpose, token string) (*models.UserEmailToken, error) {
+	object := new(models.UserEmailToken)
+	hash := password.HashResetToken(token)
+	if err := r.Master(ctx).First(object, `token=? AND purpose=?`, hash, purpose).Error; err != nil {
+		return nil, err
+	}
+	res := r.Master(ctx).Delete(&models.UserEmailToken{}, `id=? AND token=?`, object.ID, hash)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return object, nil
+}
+
+// SetEmailVerified stores the email verification flag and the approve status of the user.
+func (r *emailRepository[T]) SetEmailVerified(ctx context.Context, userObj T) error {
+	verified, ok := any(userObj).(user.EmailVerificationModel)
+	if !ok {
+		return ErrEmailVerificationUnsupported
+	}
+	column := "email_verified"
+	if col, ok := any(userObj).(interface{ EmailVerifiedColumn() string }); ok {
+		column = col.EmailVerifiedColumn()
+	}
+	columns := map[string]any{column: verified.IsEmailVerified()}
+	if approve, ok := any(userObj).(approveGetter); ok {
+		columns["approve_status"] = approve.GetApprove()
+	}
+	return r.Master(ctx).Model(userObj).UpdateColumns(columns).Error
+}
+
+type approveGetter interface {
+	GetApprove() pkgModels.ApproveStatus
+}
@@ -6,7 +6,10 @@ import (
 
 	"github.com/demdxx/gocast/v2"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
 	baseRepo "github.com/geniusrabbit/blaze-api/repository"
 	"github.com/geniusrabbit/blaze-api/repository/user"
@@ -16,19 +19,31 @@ import (
 
 var ErrInvalidPassword = errors.New(`invalid password`)
 
+const (
+	resetTokenSize     = 128
+	resetTokenLifetime = time.Hour
+)
+
 type passwordRepository[T user.PasswordCapableModel] struct {
 	baseRepo.Repository
 	core     user.Repository[T]
 	newModel func() T
 }
 
+// PasswordRepositoryWithHistory combines password repository with the password history
+type PasswordRepositoryWithHistory[T user.PasswordCapableModel] interface {
+	user.PasswordRepository[T]
+	user.PasswordHistoryRepository
+}
+
 // NewPasswordRepository creates password auth repository.
-func NewPasswordRepository[T user.PasswordCapableModel](core user.Repository[T], newModel func() T) user.PasswordRepository[T] {
+func NewPasswordRepository[T user.PasswordCapableModel](core user.Repository[T], newModel func() T) PasswordRepositoryWithHistory[T] {
 	return &passwordRepository[T]{core: core, newModel: newModel}
 }
 
 // GetByPassword retrieves user by ID and checks password.
 // Returns ErrInvalidPassword if password is incorrect.
+// Hashes of the legacy format are transparently replaced after the successful check.
 func (r *passwordRepository[T]) GetByPassword(ctx context.Context, userID uint64, plainPassword string) (T, error) {
 	var zero T
 	object, err := r.core.Get(ctx, userID)
@@ -41,9 +56,31 @@ func (r *passwordRepository[T]) GetByPassword(ctx context.Context, userID uint64
 	if object.GetPasswordHash() == "" || !comparePasswords(object.GetPasswordHash(), []byte(plainPassword)) {
 		return zero, ErrInvalidPassword
 	}
+	if password.NeedsRehash(object.GetPasswordHash()) {
+		if err := r.rehashPassword(ctx, object, plainPassword); err != nil {
+			ctxlogger.Get(ctx).Error("rehash password",
+				zap.Uint64("user_id", object.GetID()), zap.Error(err))
+		}
+	}
 	return object, nil
 }
 
+func (r *passwordRepository[T]) rehashPassword(ctx context.Context, object T, plainPassword string) error {
+	hash, err := password.PasswordHash([]byte(plainPassword))
+	if err != nil {
+		return err
+	}
+	column := "password"
+	if col, ok := any(object).(interface{ PasswordColumn() string }); ok {
+		column = col.PasswordColumn()
+	}
+	err = r.Master(ctx).Model(object).UpdateColumn(column, hash).Error
+	if err == nil {
+		object.SetPasswordHash(hash)
+	}
+	return err
+}
+
 // CreateWithPassword creates a new user with the given password.
 // If the password is empty, the user will be created with an empty password hash.
 func (r *passwordRepository[T]) CreateWithPassword(ctx context.Context, userObj T, pwd string) (uint64, error) {
@@ -67,35 +104,80 @@ func (r *passwordRepository[T]) SetPassword(ctx context.Context, userObj T, pwd
 	return r.core.Update(ctx, userObj)
 }
 
-// SetPasswordHash sets a new password hash for the user and updates the user in the database.
+// CreateResetPassword creates a new password reset token for the user.
+// The previous tokens of the user are removed, only the hash of the token is stored
+// and the returned object contains the plain token which has to be sent to the user.
 func (r *passwordRepository[T]) CreateResetPassword(ctx context.Context, userID uint64) (*models.UserPasswordReset, error) {
-	token := password.GenerateResetToken(128)
+	token := password.GenerateResetToken(resetTokenSize)
 	reset := &models.UserPasswordReset{
 		UserID:    userID,
-		Token:     token,
+		Token:     password.HashResetToken(token),
 		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(time.Hour),
+		ExpiresAt: time.Now().Add(resetTokenLifetime),
 	}
-	if err := r.Master(ctx).Create(reset).Error; err != nil {
+	err := r.TransactionExec(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		if err := tx.Delete(&models.UserPasswordReset{}, `user_id=?`, userID).Error; err != nil {
+			return err
+		}
+		return tx.Create(reset).Error
+	})
+	if err != nil {
 		return nil, err
 	}
+	reset.Token = token
 	return reset, nil
 }
 
-// GetResetPassword retrieves a password reset record by user ID and token.
+// GetResetPassword retrieves a password reset record by user ID and plain token.
 func (r *passwordRepository[T]) GetResetPassword(ctx context.Context, userID uint64, token string) (*models.UserPasswordReset, error) {
 	reset := new(models.UserPasswordReset)
-	if err := r.Slave(ctx).First(reset, `token=? AND user_id=?`, token, userID).Error; err != nil {
+	err := r.Slave(ctx).First(reset, `token=? AND user_id=?`, password.HashResetToken(token), userID).Error
+	if err != nil {
 		return nil, err
 	}
 	return reset, nil
 }
 
-// EliminateResetPassword deletes a password reset record by user ID.
+// ConsumeResetPassword removes the reset token of the user.
+// Returns gorm.ErrRecordNotFound if the token was already used,
+// so the token can't be applied twice by the concurrent requests.
+func (r *passwordRepository[T]) ConsumeResetPassword(ctx context.Context, userID uint64, token string) error {
+	res := r.Master(ctx).Delete(&models.UserPasswordReset{},
+		`token=? AND user_id=?`, password.HashResetToken(token), userID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// EliminateResetPassword deletes all password reset records of the user.
 func (r *passwordRepository[T]) EliminateResetPassword(ctx context.Context, userID uint64) error {
 	return r.Master(ctx).Delete(&models.UserPasswordReset{}, `user_id=?`, userID).Error
 }
 
+// AddPasswordHistory stores the password hash in the user history
+func (r *passwordRepository[T]) AddPasswordHistory(ctx context.Context, userID uint64, hash string) error {
+	return r.Master(ctx).Create(&models.UserPasswordHistory{
+		UserID:    userID,
+		Password:  hash,
+		CreatedAt: time.Now(),
+	}).Error
+}
+
+// PasswordHistory returns the last password hashes of the user
+func (r *passwordRepository[T]) PasswordHistory(ctx context.Context, userID uint64, limit int) ([]string, error) {
+	var hashes []string
+	err := r.Slave(ctx).Model((*models.UserPasswordHistory)(nil)).
+		Where(`user_id=?`, userID).
+		Order(`created_at DESC`).
+		Limit(limit).
+		Pluck(`password`, &hashes).Error
+	return hashes, err
+}
+
 type timestampModel interface {
 	SetCreatedAt(time.Time)
 	SetUpdatedAt(time.Time)
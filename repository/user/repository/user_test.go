@@ -1,16 +1,21 @@
 package repository
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"strings"
 	"testing"
 	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 
 	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
 	"github.com/geniusrabbit/blaze-api/repository"
 	"github.com/geniusrabbit/blaze-api/repository/testsuite"
 	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/repository/user/models"
 	"github.com/geniusrabbit/blaze-api/repository/user/password"
 	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
 )
@@ -26,7 +31,7 @@ type testSuite struct {
 	testsuite.DatabaseSuite
 
 	coreRepo  user.Repository[*testutil.User]
-	emailRepo user.EmailRepository[*testutil.User]
+	emailRepo EmailRepositoryWithTokens[*testutil.User]
 	passRepo  user.PasswordRepository[*testutil.User]
 }
 
@@ -105,15 +110,20 @@ func (s *testSuite) TestGetByPassword() {
 			sqlmock.NewRows([]string{"id", "status", "email", "password", "created_at"}).
 				AddRow(1, 1, "email1", defaultPasswordHash, time.Now()),
 		)
+	// Legacy bcrypt hash is replaced by argon2id after the successful check
+	s.Mock.ExpectExec("UPDATE").
+		WithArgs(sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	userObj, err := s.passRepo.GetByPassword(s.Ctx, 1, defaultPassword)
 	s.Assert().NoError(err)
 	s.Assert().Equal(uint64(1), userObj.GetID())
+	s.Assert().True(strings.HasPrefix(userObj.GetPasswordHash(), "$argon2id$"))
 }
 
 func (s *testSuite) TestCreateWithPassword() {
 	s.Mock.ExpectQuery("INSERT INTO").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(101))
 	u := testutil.StubWithEmail("test", pkgModels.UndefinedApproveStatus)
 	u.SetID(101)
@@ -122,9 +132,68 @@ func (s *testSuite) TestCreateWithPassword() {
 	s.Assert().Equal(uint64(101), id)
 }
 
+// hashedTokenArg matches SHA-256 hex hash of the token
+type hashedTokenArg struct{}
+
+func (hashedTokenArg) Match(v driver.Value) bool {
+	str, ok := v.(string)
+	return ok && len(str) == 64 && strings.Trim(str, "0123456789abcdef") == ""
+}
+
+func (s *testSuite) TestCreateResetPassword() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec("DELETE FROM \"account_user_password_reset\"").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectQuery("INSERT INTO \"account_user_password_reset\"").
+		WithArgs(hashedTokenArg{}, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+	s.Mock.ExpectCommit()
+
+	reset, err := s.passRepo.CreateResetPassword(s.Ctx, 1)
+	s.Require().NoError(err)
+	s.Assert().Len(reset.Token, 128)
+	s.Assert().NoError(s.Mock.ExpectationsWereMet())
+
+	s.Mock.ExpectExec("DELETE FROM \"account_user_password_reset\"").
+		WithArgs(password.HashResetToken(reset.Token), 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.Assert().ErrorIs(s.passRepo.ConsumeResetPassword(s.Ctx, 1, reset.Token), gorm.ErrRecordNotFound)
+}
+
+func (s *testSuite) TestEmailToken() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec("DELETE FROM \"account_user_email_token\"").
+		WithArgs(1, models.EmailTokenPurposeVerify).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectQuery("INSERT INTO \"account_user_email_token\"").
+		WithArgs(1, "test@mail.com", models.EmailTokenPurposeVerify, hashedTokenArg{}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	s.Mock.ExpectCommit()
+
+	token, err := s.emailRepo.CreateEmailToken(s.Ctx, 1, "test@mail.com", models.EmailTokenPurposeVerify, time.Hour)
+	s.Require().NoError(err)
+	s.Assert().Len(token.Token, emailTokenSize)
+	s.Assert().NoError(s.Mock.ExpectationsWereMet())
+
+	hash := password.HashResetToken(token.Token)
+	s.Mock.ExpectQuery("SELECT \\* FROM \"account_user_email_token\"").
+		WithArgs(hash, models.EmailTokenPurposeVerify, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "email", "purpose", "token"}).
+			AddRow(7, 1, "test@mail.com", models.EmailTokenPurposeVerify, hash))
+	s.Mock.ExpectExec("DELETE FROM \"account_user_email_token\"").
+		WithArgs(7, hash).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// The token was consumed by the concurrent request
+	_, err = s.emailRepo.ConsumeEmailToken(s.Ctx, models.EmailTokenPurposeVerify, token.Token)
+	s.Assert().ErrorIs(err, gorm.ErrRecordNotFound)
+	s.Assert().NoError(s.Mock.ExpectationsWereMet())
+}
+
 func (s *testSuite) TestUpdate() {
 	s.Mock.ExpectExec("UPDATE").
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(101, 1))
 	u := testutil.StubWithEmail("test", pkgModels.UndefinedApproveStatus)
 	u.SetID(101)
@@ -140,6 +209,18 @@ func (s *testSuite) TestDeleteByID() {
 	s.Assert().NoError(err)
 }
 
+func (s *testSuite) TestSetSuspended() {
+	s.Mock.ExpectExec("UPDATE").
+		WithArgs("spam", sqlmock.AnyArg(), sqlmock.AnyArg(), uint64(101)).
+		WillReturnResult(sqlmock.NewResult(101, 1))
+	s.Assert().NoError(s.coreRepo.SetSuspended(s.Ctx, 101, true, "spam"))
+
+	s.Mock.ExpectExec("UPDATE").
+		WithArgs("", sqlmock.AnyArg(), sqlmock.AnyArg(), uint64(102)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.Assert().ErrorIs(s.coreRepo.SetSuspended(s.Ctx, 102, false, ""), sql.ErrNoRows)
+}
+
 func TestUserRepositorySuite(t *testing.T) {
 	suite.Run(t, &testSuite{})
 }
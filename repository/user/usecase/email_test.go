@@ -0,0 +1,203 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
+	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/repository/user/mocks"
+	"github.com/geniusrabbit/blaze-api/repository/user/models"
+	"github.com/geniusrabbit/blaze-api/repository/user/testutil"
+	"github.com/geniusrabbit/blaze-api/repository/user/usecase"
+)
+
+// testEmailRepo supports the email tokens as the default email repository
+type testEmailRepo struct {
+	*mocks.MockEmailRepository[*testutil.User]
+	*mocks.MockEmailTokenRepository[*testutil.User]
+}
+
+type testEmailSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	emailRepo *mocks.MockEmailRepository[*testutil.User]
+	tokenRepo *mocks.MockEmailTokenRepository[*testutil.User]
+}
+
+func (s *testEmailSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.ctx = session.WithUserAccountDevelop(context.TODO())
+	s.emailRepo = mocks.NewMockEmailRepository[*testutil.User](ctrl)
+	s.tokenRepo = mocks.NewMockEmailTokenRepository[*testutil.User](ctrl)
+}
+
+func (s *testEmailSuite) usecase(opts ...usecase.EmailOption) user.EmailUsecase[*testutil.User] {
+	return usecase.NewEmailUsecase[*testutil.User](&testEmailRepo{
+		MockEmailRepository:      s.emailRepo,
+		MockEmailTokenRepository: s.tokenRepo,
+	}, nil, opts...)
+}
+
+func (s *testEmailSuite) user() *testutil.User {
+	u := testutil.StubWithEmail("user@example.com", pkgModels.UndefinedApproveStatus)
+	u.SetID(5)
+	return u
+}
+
+func (s *testEmailSuite) emailToken(purpose string, expiresAt time.Time) *user.UserEmailToken {
+	return &user.UserEmailToken{ID: 1, UserID: 5, Email: "user@example.com", Purpose: purpose, ExpiresAt: expiresAt}
+}
+
+func (s *testEmailSuite) TestRequestLoginLink() {
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "user@example.com").Return(s.user(), nil)
+	s.tokenRepo.EXPECT().LastEmailToken(s.ctx, uint64(5), models.EmailTokenPurposeLogin).
+		Return(nil, gorm.ErrRecordNotFound)
+	s.tokenRepo.EXPECT().CreateEmailToken(s.ctx, uint64(5), "user@example.com", models.EmailTokenPurposeLogin, 15*time.Minute).
+		Return(&user.UserEmailToken{Token: "plain"}, nil)
+
+	token, userObj, err := s.usecase().RequestLoginLink(s.ctx, "user@example.com")
+	s.NoError(err)
+	s.Equal("plain", token.Token)
+	s.Equal(uint64(5), userObj.GetID())
+}
+
+// TestRequestLoginLinkCooldown checks that the repeated request is answered
+// the same way as the request of the unknown email
+func (s *testEmailSuite) TestRequestLoginLinkCooldown() {
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "unknown@example.com").Return(nil, nil)
+	unknownToken, unknownUser, unknownErr := s.usecase().RequestLoginLink(s.ctx, "unknown@example.com")
+
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "user@example.com").Return(s.user(), nil)
+	s.tokenRepo.EXPECT().LastEmailToken(s.ctx, uint64(5), models.EmailTokenPurposeLogin).
+		Return(&user.UserEmailToken{CreatedAt: time.Now()}, nil)
+	token, userObj, err := s.usecase().RequestLoginLink(s.ctx, "user@example.com")
+
+	s.NoError(unknownErr)
+	s.Nil(unknownToken)
+	s.Nil(unknownUser)
+	s.Equal(unknownErr, err)
+	s.Equal(unknownToken, token)
+	s.Equal(unknownUser, userObj)
+}
+
+func (s *testEmailSuite) TestRequestVerificationCooldown() {
+	s.tokenRepo.EXPECT().LastEmailToken(s.ctx, uint64(5), models.EmailTokenPurposeVerify).
+		Return(&user.UserEmailToken{CreatedAt: time.Now()}, nil)
+	_, err := s.usecase().RequestVerification(s.ctx, s.user())
+	var limitErr *bruteforce.Error
+	s.ErrorAs(err, &limitErr)
+	s.Positive(limitErr.RetryAfter)
+}
+
+// TestRequestVerificationApprovesVerified checks that the user verified before
+// the approve after the verification was enabled is not left pending forever
+func (s *testEmailSuite) TestRequestVerificationApprovesVerified() {
+	userObj := s.user()
+	userObj.SetEmailVerified(true)
+	s.tokenRepo.EXPECT().SetEmailVerified(s.ctx, userObj).Return(nil)
+
+	_, err := s.usecase(usecase.WithApproveAfterVerification(true)).RequestVerification(s.ctx, userObj)
+	s.ErrorIs(err, usecase.ErrEmailAlreadyVerified)
+	s.Equal(pkgModels.ApprovedApproveStatus, userObj.Approve)
+}
+
+func (s *testEmailSuite) TestVerifyEmailExpired() {
+	s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeVerify, "plain").
+		Return(s.emailToken(models.EmailTokenPurposeVerify, time.Now().Add(-time.Minute)), nil)
+	_, err := s.usecase().VerifyEmail(s.ctx, "plain")
+	s.ErrorIs(err, usecase.ErrInvalidEmailToken)
+}
+
+func (s *testEmailSuite) TestVerifyEmailApprove() {
+	s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeVerify, "plain").
+		Return(s.emailToken(models.EmailTokenPurposeVerify, time.Now().Add(time.Hour)), nil)
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "user@example.com").Return(s.user(), nil)
+	s.tokenRepo.EXPECT().SetEmailVerified(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, u *testutil.User) error {
+			s.True(u.IsEmailVerified())
+			s.Equal(pkgModels.ApprovedApproveStatus, u.Approve)
+			return nil
+		})
+
+	userObj, err := s.usecase(usecase.WithApproveAfterVerification(true)).VerifyEmail(s.ctx, "plain")
+	s.NoError(err)
+	s.Equal(uint64(5), userObj.GetID())
+}
+
+func (s *testEmailSuite) TestVerifyEmailNoApprove() {
+	s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeVerify, "plain").
+		Return(s.emailToken(models.EmailTokenPurposeVerify, time.Now().Add(time.Hour)), nil)
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "user@example.com").Return(s.user(), nil)
+	s.tokenRepo.EXPECT().SetEmailVerified(s.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, u *testutil.User) error {
+			s.True(u.IsEmailVerified())
+			s.Equal(pkgModels.UndefinedApproveStatus, u.Approve)
+			return nil
+		})
+
+	_, err := s.usecase().VerifyEmail(s.ctx, "plain")
+	s.NoError(err)
+}
+
+func (s *testEmailSuite) TestLoginByLink() {
+	s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeLogin, "plain").
+		Return(s.emailToken(models.EmailTokenPurposeLogin, time.Now().Add(time.Minute)), nil)
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "user@example.com").Return(s.user(), nil)
+	s.tokenRepo.EXPECT().SetEmailVerified(s.ctx, gomock.Any()).Return(nil)
+
+	userObj, err := s.usecase().LoginByLink(s.ctx, "plain")
+	s.NoError(err)
+	s.Equal(uint64(5), userObj.GetID())
+	s.True(userObj.IsEmailVerified(), "login by the link confirms the email")
+}
+
+func (s *testEmailSuite) TestLoginByLinkApprovesVerified() {
+	userObj := s.user()
+	userObj.SetEmailVerified(true)
+	s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeLogin, "plain").
+		Return(s.emailToken(models.EmailTokenPurposeLogin, time.Now().Add(time.Minute)), nil)
+	s.emailRepo.EXPECT().GetByEmail(s.ctx, "user@example.com").Return(userObj, nil)
+	s.tokenRepo.EXPECT().SetEmailVerified(s.ctx, userObj).Return(nil)
+
+	_, err := s.usecase(usecase.WithApproveAfterVerification(true)).LoginByLink(s.ctx, "plain")
+	s.NoError(err)
+	s.Equal(pkgModels.ApprovedApproveStatus, userObj.Approve)
+}
+
+func (s *testEmailSuite) TestLoginByLinkInvalid() {
+	s.Run("NotFound", func() {
+		s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeLogin, "plain").
+			Return(nil, gorm.ErrRecordNotFound)
+		_, err := s.usecase().LoginByLink(s.ctx, "plain")
+		s.ErrorIs(err, usecase.ErrInvalidEmailToken)
+	})
+	s.Run("Expired", func() {
+		s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeLogin, "plain").
+			Return(s.emailToken(models.EmailTokenPurposeLogin, time.Now().Add(-time.Minute)), nil)
+		_, err := s.usecase().LoginByLink(s.ctx, "plain")
+		s.ErrorIs(err, usecase.ErrInvalidEmailToken)
+	})
+	s.Run("EmailChanged", func() {
+		other := s.user()
+		other.SetID(6)
+		s.tokenRepo.EXPECT().ConsumeEmailToken(s.ctx, models.EmailTokenPurposeLogin, "plain").
+			Return(s.emailToken(models.EmailTokenPurposeLogin, time.Now().Add(time.Minute)), nil)
+		s.emailRepo.EXPECT().GetByEmail(s.ctx, "user@example.com").Return(other, nil)
+		_, err := s.usecase().LoginByLink(s.ctx, "plain")
+		s.ErrorIs(err, usecase.ErrInvalidEmailToken)
+	})
+}
+
+func TestEmailSuite(t *testing.T) {
+	suite.Run(t, new(testEmailSuite))
+}
@@ -2,19 +2,96 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
+	"github.com/geniusrabbit/blaze-api/pkg/context/clientip"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
+	pkgModels "github.com/geniusrabbit/blaze-api/pkg/models"
 	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/repository/user/models"
+)
+
+var (
+	ErrInvalidEmailToken      = errors.New(`invalid or expired email token`)
+	ErrEmailAlreadyVerified   = errors.New(`email is already verified`)
+	ErrEmailNotDefined        = errors.New(`user email is not defined`)
+	ErrEmailTokensUnsupported = errors.New(`email tokens are not supported by the repository`)
 )
 
+// Names of the email actions in the brute-force limiter
+const (
+	LimiterActionLoginLink  = "login.link"
+	LimiterActionEmailToken = "email.token"
+)
+
+type emailOptions struct {
+	limiter           *bruteforce.Limiter
+	verifyLifetime    time.Duration
+	loginLifetime     time.Duration
+	resendCooldown    time.Duration
+	approveOnVerified bool
+}
+
+// EmailOption configures email usecase.
+type EmailOption func(*emailOptions)
+
+// WithEmailLimiter limits login link requests and email token guessing.
+func WithEmailLimiter(limiter *bruteforce.Limiter) EmailOption {
+	return func(opts *emailOptions) { opts.limiter = limiter }
+}
+
+// WithEmailTokenLifetime changes the lifetime of the verification and login link tokens.
+// Zero values keep the defaults.
+func WithEmailTokenLifetime(verify, login time.Duration) EmailOption {
+	return func(opts *emailOptions) {
+		if verify > 0 {
+			opts.verifyLifetime = verify
+		}
+		if login > 0 {
+			opts.loginLifetime = login
+		}
+	}
+}
+
+// WithEmailResendCooldown sets the minimal interval between two emails with tokens of the same kind.
+func WithEmailResendCooldown(cooldown time.Duration) EmailOption {
+	return func(opts *emailOptions) { opts.resendCooldown = cooldown }
+}
+
+// WithApproveAfterVerification keeps new users in the pending status
+// until the email is verified and approves them after the verification.
+func WithApproveAfterVerification(enable bool) EmailOption {
+	return func(opts *emailOptions) { opts.approveOnVerified = enable }
+}
+
 // EmailUsecase provides email lookup business logic.
 type EmailUsecase[T user.EmailCapableModel] struct {
 	emailRepo user.EmailRepository[T]
+	tokenRepo user.EmailTokenRepository[T]
+	options   emailOptions
 }
 
 // NewEmailUsecase creates email usecase.
-func NewEmailUsecase[T user.EmailCapableModel](emailRepo user.EmailRepository[T], _ func() T) user.EmailUsecase[T] {
-	return &EmailUsecase[T]{emailRepo: emailRepo}
+// Verification and login links are available if the repository implements user.EmailTokenRepository.
+func NewEmailUsecase[T user.EmailCapableModel](emailRepo user.EmailRepository[T], _ func() T, opts ...EmailOption) user.EmailUsecase[T] {
+	options := emailOptions{
+		verifyLifetime: 24 * time.Hour,
+		loginLifetime:  15 * time.Minute,
+		resendCooldown: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	tokenRepo, _ := emailRepo.(user.EmailTokenRepository[T])
+	return &EmailUsecase[T]{emailRepo: emailRepo, tokenRepo: tokenRepo, options: options}
 }
 
 // GetByEmail retrieves user by email (case-insensitive) with access control check.
@@ -39,6 +116,198 @@ func (a *EmailUsecase[T]) GetByEmail(ctx context.Context, email string) (T, erro
 	return userObj, nil
 }
 
+// RequestVerification creates the email verification token for the user with access control check.
+// The returned object contains the plain token which has to be sent to the user email.
+func (a *EmailUsecase[T]) RequestVerification(ctx context.Context, userObj T) (*user.UserEmailToken, error) {
+	if a.tokenRepo == nil {
+		return nil, ErrEmailTokensUnsupported
+	}
+	if gocast.IsNil(userObj) || userObj.GetID() == 0 {
+		return nil, acl.ErrNoPermissions
+	}
+	if !acl.HaveObjectPermissions(ctx, userObj, `email.verify.*`) {
+		return nil, errors.Wrap(acl.ErrNoPermissions, `request email verification`)
+	}
+	if verified, ok := any(userObj).(user.EmailVerificationModel); ok && verified.IsEmailVerified() {
+		// The email could be verified before the approve after the verification was enabled
+		if a.isPendingApprove(userObj) {
+			if err := a.markVerified(ctx, userObj); err != nil {
+				return nil, err
+			}
+		}
+		return nil, ErrEmailAlreadyVerified
+	}
+	if userObj.GetEmail() == "" {
+		return nil, ErrEmailNotDefined
+	}
+	if err := a.checkCooldown(ctx, userObj.GetID(), models.EmailTokenPurposeVerify); err != nil {
+		return nil, err
+	}
+	return a.tokenRepo.CreateEmailToken(ctx, userObj.GetID(), userObj.GetEmail(),
+		models.EmailTokenPurposeVerify, a.options.verifyLifetime)
+}
+
+// VerifyEmail confirms the email of the user by the token from the verification email.
+func (a *EmailUsecase[T]) VerifyEmail(ctx context.Context, token string) (T, error) {
+	var zero T
+	userObj, err := a.useToken(ctx, models.EmailTokenPurposeVerify, token)
+	if err != nil {
+		return zero, err
+	}
+	if err := a.markVerified(ctx, userObj); err != nil {
+		return zero, err
+	}
+	return userObj, nil
+}
+
+// RequestLoginLink creates the login token for the user with the email.
+// Returns nil token without error if the user is not found or the link was sent
+// recently, so the caller can respond the same way and don't disclose registered emails.
+func (a *EmailUsecase[T]) RequestLoginLink(ctx context.Context, email string) (*user.UserEmailToken, T, error) {
+	var zero T
+	if a.tokenRepo == nil {
+		return nil, zero, ErrEmailTokensUnsupported
+	}
+	limitKeys := []bruteforce.Key{
+		bruteforce.EmailKey(email),
+		bruteforce.IPKey(clientip.Get(ctx)),
+	}
+	if err := a.options.limiter.Check(ctx, LimiterActionLoginLink, limitKeys...); err != nil {
+		return nil, zero, err
+	}
+
+	// Every request is counted by the limiter to prevent the mailbox flooding
+	_ = a.options.limiter.Fail(ctx, LimiterActionLoginLink, limitKeys...)
+
+	userObj, err := a.emailRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, zero, err
+	}
+	if gocast.IsNil(userObj) || userObj.GetID() == 0 {
+		return nil, zero, nil
+	}
+	if err := a.checkCooldown(ctx, userObj.GetID(), models.EmailTokenPurposeLogin); err != nil {
+		var limitErr *bruteforce.Error
+		if errors.As(err, &limitErr) {
+			ctxlogger.Get(ctx).Info("Login link cooldown",
+				zap.Uint64("user_id", userObj.GetID()),
+				zap.Duration("retry_after", limitErr.RetryAfter))
+			return nil, zero, nil
+		}
+		return nil, zero, err
+	}
+	token, err := a.tokenRepo.CreateEmailToken(ctx, userObj.GetID(), userObj.GetEmail(),
+		models.EmailTokenPurposeLogin, a.options.loginLifetime)
+	if err != nil {
+		return nil, zero, err
+	}
+	return token, userObj, nil
+}
+
+// LoginByLink returns the user by the single-use login token.
+// The successful login by the link confirms the email as well.
+func (a *EmailUsecase[T]) LoginByLink(ctx context.Context, token string) (T, error) {
+	var zero T
+	userObj, err := a.useToken(ctx, models.EmailTokenPurposeLogin, token)
+	if err != nil {
+		return zero, err
+	}
+	if verified, ok := any(userObj).(user.EmailVerificationModel); ok && (!verified.IsEmailVerified() || a.isPendingApprove(userObj)) {
+		if err := a.markVerified(ctx, userObj); err != nil {
+			ctxlogger.Get(ctx).Error("Error marking email as verified",
+				zap.Uint64("user_id", userObj.GetID()), zap.Error(err))
+		}
+	}
+	return userObj, nil
+}
+
+// useToken consumes the token and returns its owner,
+// the invalid tokens are counted by the limiter
+func (a *EmailUsecase[T]) useToken(ctx context.Context, purpose, token string) (T, error) {
+	var zero T
+	if a.tokenRepo == nil {
+		return zero, ErrEmailTokensUnsupported
+	}
+	limitKeys := []bruteforce.Key{bruteforce.IPKey(clientip.Get(ctx))}
+	if err := a.options.limiter.Check(ctx, LimiterActionEmailToken, limitKeys...); err != nil {
+		return zero, err
+	}
+	userObj, err := a.consumeToken(ctx, purpose, token)
+	if errors.Is(err, ErrInvalidEmailToken) {
+		if limErr := a.options.limiter.Fail(ctx, LimiterActionEmailToken, limitKeys...); limErr != nil {
+			return zero, limErr
+		}
+	}
+	return userObj, err
+}
+
+func (a *EmailUsecase[T]) consumeToken(ctx context.Context, purpose, token string) (T, error) {
+	var zero T
+	emailToken, err := a.tokenRepo.ConsumeEmailToken(ctx, purpose, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, sql.ErrNoRows) {
+			return zero, ErrInvalidEmailToken
+		}
+		return zero, err
+	}
+	if emailToken.ExpiresAt.Before(time.Now()) {
+		ctxlogger.Get(ctx).Info("Email token expired",
+			zap.Uint64("user_id", emailToken.UserID),
+			zap.String("purpose", purpose),
+			zap.Time("expires_at", emailToken.ExpiresAt))
+		return zero, ErrInvalidEmailToken
+	}
+	userObj, err := a.emailRepo.GetByEmail(ctx, emailToken.Email)
+	if err != nil {
+		return zero, err
+	}
+	// The email could be changed after the token was sent
+	if gocast.IsNil(userObj) || userObj.GetID() != emailToken.UserID {
+		return zero, ErrInvalidEmailToken
+	}
+	return userObj, nil
+}
+
+func (a *EmailUsecase[T]) markVerified(ctx context.Context, userObj T) error {
+	verified, ok := any(userObj).(user.EmailVerificationModel)
+	if !ok {
+		return nil
+	}
+	if a.isPendingApprove(userObj) {
+		any(userObj).(approveModel).SetApprove(pkgModels.ApprovedApproveStatus)
+	}
+	verified.SetEmailVerified(true)
+	return a.tokenRepo.SetEmailVerified(ctx, userObj)
+}
+
+// isPendingApprove reports whether the user waits for the approve by the email verification
+func (a *EmailUsecase[T]) isPendingApprove(userObj T) bool {
+	approve, ok := any(userObj).(approveModel)
+	return a.options.approveOnVerified && ok && approve.GetApprove() == pkgModels.UndefinedApproveStatus
+}
+
+func (a *EmailUsecase[T]) checkCooldown(ctx context.Context, userID uint64, purpose string) error {
+	if a.options.resendCooldown <= 0 {
+		return nil
+	}
+	last, err := a.tokenRepo.LastEmailToken(ctx, userID, purpose)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if wait := a.options.resendCooldown - time.Since(last.CreatedAt); wait > 0 {
+		return &bruteforce.Error{Action: "email." + purpose, RetryAfter: wait}
+	}
+	return nil
+}
+
+type approveModel interface {
+	GetApprove() pkgModels.ApproveStatus
+	SetApprove(pkgModels.ApproveStatus)
+}
+
 func sessionUserEmail(ctx context.Context) (string, bool) {
 	u := sessionUserModel(ctx)
 	if u == nil {
@@ -11,27 +11,63 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/auth/bruteforce"
+	"github.com/geniusrabbit/blaze-api/pkg/context/clientip"
 	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/repository/user"
+	"github.com/geniusrabbit/blaze-api/repository/user/password"
 )
 
 var (
 	ErrInvalidPasswordResetCode = errors.New(`invalid password reset code`)
 	ErrInvalidCurrentPassword   = errors.New(`current password is incorrect`)
-	ErrPasswordTooShort         = errors.New(`password must be at least 8 characters`)
+	ErrPasswordTooShort         = password.ErrPasswordTooShort
 )
 
+// Names of the password actions in the brute-force limiter
+const (
+	LimiterActionResetPassword  = "password.reset"
+	LimiterActionUpdatePassword = "password.update"
+)
+
+type passwordOptions struct {
+	limiter *bruteforce.Limiter
+	policy  *password.Policy
+}
+
+// PasswordOption configures password usecase.
+type PasswordOption func(*passwordOptions)
+
+// WithPasswordLimiter limits reset requests and reset code guessing.
+func WithPasswordLimiter(limiter *bruteforce.Limiter) PasswordOption {
+	return func(opts *passwordOptions) { opts.limiter = limiter }
+}
+
+// WithPasswordPolicy replaces the default password policy (nil disables the validation).
+// The password history is used if the repository implements user.PasswordHistoryRepository.
+func WithPasswordPolicy(policy *password.Policy) PasswordOption {
+	return func(opts *passwordOptions) { opts.policy = policy }
+}
+
 // PasswordUsecase provides password business logic.
 type PasswordUsecase[T user.PasswordCapableModel] struct {
 	core     user.Usecase[T]
 	passRepo user.PasswordRepository[T]
+	limiter  *bruteforce.Limiter
+	policy   *password.Policy
 }
 
 // NewPasswordUsecase creates password usecase.
-func NewPasswordUsecase[T user.PasswordCapableModel](core user.Usecase[T], passRepo user.PasswordRepository[T]) user.PasswordUsecase[T] {
+func NewPasswordUsecase[T user.PasswordCapableModel](core user.Usecase[T], passRepo user.PasswordRepository[T], opts ...PasswordOption) user.PasswordUsecase[T] {
+	options := passwordOptions{policy: password.DefaultPolicy()}
+	for _, opt := range opts {
+		opt(&options)
+	}
 	return &PasswordUsecase[T]{
 		core:     core,
 		passRepo: passRepo,
+		limiter:  options.limiter,
+		policy:   options.policy,
 	}
 }
 
@@ -45,7 +81,58 @@ func (a *PasswordUsecase[T]) SetPassword(ctx context.Context, userObj T, passwor
 	if !acl.HaveObjectPermissions(ctx, userObj, `password.set.*`) {
 		return errors.Wrap(acl.ErrNoPermissions, `set password`)
 	}
-	return a.passRepo.SetPassword(ctx, userObj, password)
+	return a.setPassword(ctx, userObj, password)
+}
+
+// ValidatePassword checks the password by the policy, including the history of the user.
+func (a *PasswordUsecase[T]) ValidatePassword(ctx context.Context, userObj T, password string) error {
+	if a.policy == nil {
+		return nil
+	}
+	var history []string
+	if histRepo := a.historyRepo(); histRepo != nil && !gocast.IsNil(userObj) && userObj.GetID() > 0 {
+		// The current password is the latest in the history even if it was set before the history was enabled
+		history = append(history, userObj.GetPasswordHash())
+		prev, err := histRepo.PasswordHistory(ctx, userObj.GetID(), a.policy.HistorySize)
+		if err != nil {
+			return err
+		}
+		history = append(history, prev...)
+	}
+	return a.policy.Validate(ctx, password, history...)
+}
+
+func (a *PasswordUsecase[T]) setPassword(ctx context.Context, userObj T, password string) error {
+	if err := a.ValidatePassword(ctx, userObj, password); err != nil {
+		return err
+	}
+	return a.storePassword(ctx, userObj, password)
+}
+
+// storePassword saves the validated password and invalidates all pending reset tokens
+func (a *PasswordUsecase[T]) storePassword(ctx context.Context, userObj T, password string) error {
+	if err := a.passRepo.SetPassword(ctx, userObj, password); err != nil {
+		return err
+	}
+	if histRepo := a.historyRepo(); histRepo != nil {
+		if err := histRepo.AddPasswordHistory(ctx, userObj.GetID(), userObj.GetPasswordHash()); err != nil {
+			ctxlogger.Get(ctx).Error("Error saving password history",
+				zap.Uint64("user_id", userObj.GetID()), zap.Error(err))
+		}
+	}
+	if err := a.passRepo.EliminateResetPassword(ctx, userObj.GetID()); err != nil {
+		ctxlogger.Get(ctx).Error("Error eliminating reset password",
+			zap.Uint64("user_id", userObj.GetID()), zap.Error(err))
+	}
+	return nil
+}
+
+func (a *PasswordUsecase[T]) historyRepo() user.PasswordHistoryRepository {
+	if a.policy == nil || a.policy.HistorySize <= 0 {
+		return nil
+	}
+	histRepo, _ := a.passRepo.(user.PasswordHistoryRepository)
+	return histRepo
 }
 
 // ChangePassword changes the password for the current user with access control check.
@@ -55,10 +142,6 @@ func (a *PasswordUsecase[T]) ChangePassword(ctx context.Context, currentPassword
 		return acl.ErrNoPermissions
 	}
 
-	if len(newPassword) < 8 {
-		return ErrPasswordTooShort
-	}
-
 	if _, err := a.passRepo.GetByPassword(ctx, userObj.GetID(), currentPassword); err != nil {
 		return ErrInvalidCurrentPassword
 	}
@@ -67,8 +150,14 @@ func (a *PasswordUsecase[T]) ChangePassword(ctx context.Context, currentPassword
 }
 
 // ResetPassword generates a password reset token for the user with access control check.
+// Every reset request is counted by the limiter to prevent the mailbox flooding.
 func (a *PasswordUsecase[T]) ResetPassword(ctx context.Context, userID uint64) (*user.UserPasswordReset, T, error) {
 	var zero T
+	limitKeys := limiterKeys(ctx, userID)
+	if err := a.limiter.Check(ctx, LimiterActionResetPassword, limitKeys...); err != nil {
+		return nil, zero, err
+	}
+
 	userObj, err := a.core.Get(ctx, userID)
 	if err != nil {
 		return nil, zero, err
@@ -87,11 +176,34 @@ func (a *PasswordUsecase[T]) ResetPassword(ctx context.Context, userID uint64) (
 		return nil, zero, err
 	}
 
+	// The result is ignored as the current request is already allowed
+	_ = a.limiter.Fail(ctx, LimiterActionResetPassword, limitKeys...)
+
 	return reset, userObj, nil
 }
 
 // UpdatePassword updates the password for the user using a reset token with access control check.
 func (a *PasswordUsecase[T]) UpdatePassword(ctx context.Context, userID uint64, token, password string) error {
+	limitKeys := limiterKeys(ctx, userID)
+	if err := a.limiter.Check(ctx, LimiterActionUpdatePassword, limitKeys...); err != nil {
+		return err
+	}
+
+	err := a.updatePassword(ctx, userID, token, password)
+	if errors.Is(err, ErrInvalidPasswordResetCode) {
+		if limErr := a.limiter.Fail(ctx, LimiterActionUpdatePassword, limitKeys...); limErr != nil {
+			return limErr
+		}
+		return err
+	}
+	if err == nil {
+		_ = a.limiter.Reset(ctx, LimiterActionUpdatePassword, limitKeys[0])
+		_ = a.limiter.Reset(ctx, LimiterActionResetPassword, limitKeys[0])
+	}
+	return err
+}
+
+func (a *PasswordUsecase[T]) updatePassword(ctx context.Context, userID uint64, token, password string) error {
 	userObj, err := a.core.Get(ctx, userID)
 	if err != nil {
 		if err == sql.ErrNoRows || err == gorm.ErrRecordNotFound {
@@ -115,19 +227,28 @@ func (a *PasswordUsecase[T]) UpdatePassword(ctx context.Context, userID uint64,
 	if reset.ExpiresAt.Before(time.Now()) {
 		ctxlogger.Get(ctx).Info("Reset password token expired",
 			zap.Uint64("user_id", reset.UserID),
-			zap.Time("expires_at", reset.ExpiresAt),
-			zap.String("token", token))
+			zap.Time("expires_at", reset.ExpiresAt))
 		return ErrInvalidPasswordResetCode
 	}
 
-	if err := a.passRepo.SetPassword(ctx, userObj, password); err != nil {
+	// Validate before the token is consumed so the user can retry with another password
+	if err := a.ValidatePassword(ctx, userObj, password); err != nil {
 		return err
 	}
 
-	if err := a.passRepo.EliminateResetPassword(ctx, reset.UserID); err != nil {
-		ctxlogger.Get(ctx).Error("Error eliminating reset password",
-			zap.Uint64("user_id", reset.UserID), zap.Error(err))
+	if err := a.passRepo.ConsumeResetPassword(ctx, userObj.GetID(), token); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrInvalidPasswordResetCode
+		}
+		return err
 	}
 
-	return nil
+	return a.storePassword(ctx, userObj, password)
+}
+
+func limiterKeys(ctx context.Context, userID uint64) []bruteforce.Key {
+	return []bruteforce.Key{
+		bruteforce.UserKey(userID),
+		bruteforce.IPKey(clientip.Get(ctx)),
+	}
 }
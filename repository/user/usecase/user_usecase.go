@@ -3,13 +3,21 @@ package usecase
 
 import (
 	"context"
+	"errors"
+
+	"github.com/demdxx/gocast/v2"
+	"go.uber.org/zap"
 
 	"github.com/geniusrabbit/blaze-api/pkg/acl"
+	"github.com/geniusrabbit/blaze-api/pkg/context/ctxlogger"
 	"github.com/geniusrabbit/blaze-api/repository"
 	"github.com/geniusrabbit/blaze-api/repository/historylog"
 	"github.com/geniusrabbit/blaze-api/repository/user"
 )
 
+// ErrSelfSuspend returned if the user tries to suspend the own user
+var ErrSelfSuspend = errors.New(`users can't suspend themselves`)
+
 // UserUsecase provides business logic for user access.
 type UserUsecase[T user.Model] struct {
 	userRepo user.Repository[T]
@@ -98,6 +106,39 @@ func (a *UserUsecase[T]) Delete(ctx context.Context, id uint64) error {
 	return a.userRepo.Delete(historylog.WithPK(ctx, id), id)
 }
 
+// Suspend blocks the authentication of the user keeping all the data
+func (a *UserUsecase[T]) Suspend(ctx context.Context, id uint64, reason string) (T, error) {
+	return a.setSuspended(ctx, id, true, reason)
+}
+
+// Unsuspend restores the access of the suspended user
+func (a *UserUsecase[T]) Unsuspend(ctx context.Context, id uint64) (T, error) {
+	return a.setSuspended(ctx, id, false, "")
+}
+
+func (a *UserUsecase[T]) setSuspended(ctx context.Context, id uint64, suspended bool, reason string) (T, error) {
+	var zero T
+	userObj, err := a.userRepo.Get(ctx, id)
+	if err != nil {
+		return zero, err
+	}
+	if !acl.HaveAccessSuspend(ctx, userObj) {
+		return zero, acl.ErrNoPermissions
+	}
+	if current := sessionUserModel(ctx); suspended && current != nil && current.GetID() == id {
+		return zero, ErrSelfSuspend
+	}
+	if err = a.userRepo.SetSuspended(ctx, id, suspended, reason); err != nil {
+		return zero, err
+	}
+	action := gocast.IfThen(suspended, "user.suspend", "user.unsuspend")
+	err = historylog.Write(historylog.WithMessage(ctx, reason), action, "user", id, nil)
+	if err != nil {
+		ctxlogger.Get(ctx).Error("log user suspension", zap.Uint64("user_id", id), zap.Error(err))
+	}
+	return a.userRepo.Get(ctx, id)
+}
+
 func adjustFetchListPermissions(ctx context.Context, opts ...user.QOption) error {
 	adjusted := false
 	for _, opt := range opts {
@@ -16,17 +16,24 @@ type Usecase[T Model] interface {
 	Create(ctx context.Context, user T) (uint64, error)
 	Update(ctx context.Context, user T) error
 	Delete(ctx context.Context, id uint64) error
+	Suspend(ctx context.Context, id uint64, reason string) (T, error)
+	Unsuspend(ctx context.Context, id uint64) (T, error)
 }
 
-// EmailUsecase provides email lookup operations.
+// EmailUsecase provides email lookup, verification and login link operations.
 type EmailUsecase[T EmailCapableModel] interface {
 	GetByEmail(ctx context.Context, email string) (T, error)
+	RequestVerification(ctx context.Context, user T) (*UserEmailToken, error)
+	VerifyEmail(ctx context.Context, token string) (T, error)
+	RequestLoginLink(ctx context.Context, email string) (*UserEmailToken, T, error)
+	LoginByLink(ctx context.Context, token string) (T, error)
 }
 
 // PasswordUsecase provides password management operations (no email lookup).
 type PasswordUsecase[T PasswordCapableModel] interface {
 	Repo() PasswordRepository[T]
 	SetPassword(ctx context.Context, user T, password string) error
+	ValidatePassword(ctx context.Context, user T, password string) error
 	ChangePassword(ctx context.Context, currentPassword, newPassword string) error
 	ResetPassword(ctx context.Context, userID uint64) (*UserPasswordReset, T, error)
 	UpdatePassword(ctx context.Context, userID uint64, token, password string) error
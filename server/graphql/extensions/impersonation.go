@@ -0,0 +1,82 @@
+// Package extensions provides the GraphQL handler extensions
+package extensions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+)
+
+// ErrImpersonationRestricted is returned for the mutations blocked in the impersonated session
+var ErrImpersonationRestricted = errors.New("the operation is not allowed in the impersonated session")
+
+// DefaultImpersonationBlocked mutations which can change the credentials
+// or issue the new tokens of the impersonated user
+var DefaultImpersonationBlocked = []string{
+	"impersonateUser",
+	"switchAccount",
+	"changeUserPassword",
+	"updateUserPassword",
+	"resetUserPassword",
+	"changeUserEmail",
+	"generateDirectAccessToken",
+	"createAuthInitialAccessToken",
+	"rotateAuthClientSecret",
+	"acceptAuthConsentRequest",
+	"approveAuthDeviceRequest",
+	"denyAuthDeviceRequest",
+	"revokeAuthorizedApp",
+	"requestEmailVerification",
+	"verifyEmail",
+	"inviteAccountMemberByEmail",
+	"acceptMemberInvite",
+	"requestAccountOwnershipTransfer",
+	"confirmAccountOwnershipTransfer",
+	"eraseUserData",
+}
+
+// ImpersonationGuard rejects the blocked mutations of the impersonated sessions
+type ImpersonationGuard struct {
+	blocked map[string]struct{}
+}
+
+var (
+	_ graphql.HandlerExtension = (*ImpersonationGuard)(nil)
+	_ graphql.FieldInterceptor = (*ImpersonationGuard)(nil)
+)
+
+// NewImpersonationGuard creates the guard of the mutation names,
+// the DefaultImpersonationBlocked list is used if no names are provided
+func NewImpersonationGuard(mutations ...string) *ImpersonationGuard {
+	if len(mutations) == 0 {
+		mutations = DefaultImpersonationBlocked
+	}
+	blocked := make(map[string]struct{}, len(mutations))
+	for _, name := range mutations {
+		blocked[name] = struct{}{}
+	}
+	return &ImpersonationGuard{blocked: blocked}
+}
+
+// ExtensionName returns the name of the extension
+func (g *ImpersonationGuard) ExtensionName() string {
+	return "ImpersonationGuard"
+}
+
+// Validate the extension for the schema
+func (g *ImpersonationGuard) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptField rejects the root mutation fields from the blocked list
+func (g *ImpersonationGuard) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	if fc := graphql.GetFieldContext(ctx); fc != nil && fc.Object == "Mutation" && session.IsImpersonated(ctx) {
+		if _, ok := g.blocked[fc.Field.Name]; ok {
+			return nil, ErrImpersonationRestricted
+		}
+	}
+	return next(ctx)
+}
@@ -0,0 +1,41 @@
+package extensions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/geniusrabbit/blaze-api/pkg/context/session"
+)
+
+func TestImpersonationGuard(t *testing.T) {
+	guard := NewImpersonationGuard("switchAccount")
+	resolver := func(ctx context.Context) (any, error) { return true, nil }
+	field := func(ctx context.Context, object, name string) context.Context {
+		return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Object: object,
+			Field:  graphql.CollectedField{Field: &ast.Field{Name: name, Alias: "alias"}},
+		})
+	}
+	impersonated := session.WithImpersonation(context.Background(),
+		&session.Impersonation{ActorID: 1, ExpiresAt: time.Now().Add(time.Minute)})
+
+	_, err := guard.InterceptField(field(impersonated, "Mutation", "switchAccount"), resolver)
+	assert.ErrorIs(t, err, ErrImpersonationRestricted)
+
+	_, err = guard.InterceptField(field(impersonated, "Mutation", "updateAccount"), resolver)
+	assert.NoError(t, err, "the mutation is not in the list")
+
+	_, err = guard.InterceptField(field(impersonated, "Query", "switchAccount"), resolver)
+	assert.NoError(t, err, "only the root mutation fields are checked")
+
+	_, err = guard.InterceptField(field(context.Background(), "Mutation", "switchAccount"), resolver)
+	assert.NoError(t, err, "the regular session is not restricted")
+
+	assert.Contains(t, NewImpersonationGuard().blocked, "impersonateUser", "the default list is used")
+	assert.Contains(t, NewImpersonationGuard().blocked, "approveAuthDeviceRequest", "the device approval issues the regular tokens")
+}
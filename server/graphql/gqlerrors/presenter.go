@@ -0,0 +1,38 @@
+// Package gqlerrors provides GraphQL error presentation helpers
+package gqlerrors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ExtendedError is implemented by the errors which provide additional
+// details for the client in the `extensions` section of the GraphQL error
+type ExtendedError interface {
+	error
+	Extensions() map[string]any
+}
+
+// Presenter converts the resolver error into the GraphQL error
+// and copies extensions of the ExtendedError if present in the chain
+func Presenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if gqlErr == nil {
+		return nil
+	}
+	var extErr ExtendedError
+	if errors.As(err, &extErr) {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]any{}
+		}
+		for key, val := range extErr.Extensions() {
+			if _, ok := gqlErr.Extensions[key]; !ok {
+				gqlErr.Extensions[key] = val
+			}
+		}
+	}
+	return gqlErr
+}
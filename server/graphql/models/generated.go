@@ -13,6 +13,156 @@ import (
 	"github.com/google/uuid"
 )
 
+// AccountDomain is the email domain owned by the account.
+// The ownership is confirmed by the DNS TXT record, the verified domain
+// can auto-join new users and enforce the SSO login for its emails.
+type AccountDomain struct {
+	// The primary key of the domain
+	ID uint64 `json:"ID"`
+	// Account ID of the domain
+	AccountID uint64 `json:"accountID"`
+	// Domain name in lower case
+	Domain string `json:"domain"`
+	// Value of the TXT record which must be published to verify the domain
+	VerificationRecord string `json:"verificationRecord"`
+	// Time of the domain verification, null if the domain is not verified
+	VerifiedAt *time.Time `json:"verifiedAt,omitempty"`
+	// New users with the verified email of the domain join the account automatically
+	AutoJoin bool `json:"autoJoin"`
+	// Roles granted to the auto-joined members
+	DefaultRoles []string `json:"defaultRoles,omitempty"`
+	// Users of the domain can login only with SSO providers
+	SsoRequired bool      `json:"ssoRequired"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type AccountDomainConnection struct {
+	// The total number of domains
+	TotalCount int `json:"totalCount"`
+	// A list of the domains, as a convenience when edges are not needed.
+	List []*AccountDomain `json:"list,omitempty"`
+	// Information for paginating this connection
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+type AccountDomainListFilter struct {
+	ID        []uint64 `json:"ID,omitempty"`
+	AccountID []uint64 `json:"accountID,omitempty"`
+	Domain    []string `json:"domain,omitempty"`
+	Verified  *bool    `json:"verified,omitempty"`
+}
+
+type AccountDomainListOrder struct {
+	ID        *Ordering `json:"ID,omitempty"`
+	Domain    *Ordering `json:"domain,omitempty"`
+	CreatedAt *Ordering `json:"createdAt,omitempty"`
+}
+
+type AccountDomainPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID string `json:"clientMutationID"`
+	// Domain ID operation result
+	DomainID uint64 `json:"domainID"`
+	// Domain object accessor
+	Domain *AccountDomain `json:"domain,omitempty"`
+}
+
+type AccountDomainPolicyInput struct {
+	// Join new users with the verified email of the domain to the account
+	AutoJoin bool `json:"autoJoin"`
+	// Names of the roles granted to the auto-joined members
+	DefaultRoles []string `json:"defaultRoles,omitempty"`
+	// Reject the password and login link authorization for the domain users
+	SsoRequired bool `json:"ssoRequired"`
+}
+
+// Request of the account owner to hand the account to another member
+type AccountOwnershipTransfer struct {
+	// The primary key of the transfer
+	ID uint64 `json:"ID"`
+	// Account ID of the transfer
+	AccountID uint64 `json:"accountID"`
+	// The current owner of the account
+	FromUserID uint64 `json:"fromUserID"`
+	// The member who becomes the owner
+	ToUserID uint64 `json:"toUserID"`
+	// Status of the transfer
+	Status    AccountOwnershipTransferStatus `json:"status"`
+	ExpiresAt time.Time                      `json:"expiresAt"`
+	CreatedAt time.Time                      `json:"createdAt"`
+	UpdatedAt time.Time                      `json:"updatedAt"`
+}
+
+type AccountOwnershipTransferPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID string `json:"clientMutationID"`
+	// Transfer ID operation result
+	TransferID uint64 `json:"transferID"`
+	// Transfer object accessor
+	Transfer *AccountOwnershipTransfer `json:"transfer,omitempty"`
+}
+
+// AccountTeam is the named group of the account members with its own roles.
+// The members of the team get the team roles in addition to their own roles.
+type AccountTeam struct {
+	// The primary key of the team
+	ID uint64 `json:"ID"`
+	// Account ID of the team
+	AccountID uint64 `json:"accountID"`
+	// Name of the team, unique in the account
+	Name string `json:"name"`
+	// Description of the team
+	Description string `json:"description"`
+	// Roles granted to the team members
+	Roles     []*RBACRole `json:"roles,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+type AccountTeamConnection struct {
+	// The total number of teams
+	TotalCount int `json:"totalCount"`
+	// A list of the teams, as a convenience when edges are not needed.
+	List []*AccountTeam `json:"list,omitempty"`
+	// Information for paginating this connection
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+type AccountTeamInput struct {
+	// The account of the team, the current account is used by default
+	AccountID *uint64 `json:"accountID,omitempty"`
+	// Name of the team
+	Name string `json:"name"`
+	// Description of the team
+	Description *string `json:"description,omitempty"`
+	// Names of the roles granted to the team members, the roles are kept if not set
+	Roles []string `json:"roles,omitempty"`
+}
+
+type AccountTeamListFilter struct {
+	ID        []uint64 `json:"ID,omitempty"`
+	AccountID []uint64 `json:"accountID,omitempty"`
+	MemberID  []uint64 `json:"memberID,omitempty"`
+	Name      []string `json:"name,omitempty"`
+}
+
+type AccountTeamListOrder struct {
+	ID        *Ordering `json:"ID,omitempty"`
+	Name      *Ordering `json:"name,omitempty"`
+	CreatedAt *Ordering `json:"createdAt,omitempty"`
+	UpdatedAt *Ordering `json:"updatedAt,omitempty"`
+}
+
+type AccountTeamPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID string `json:"clientMutationID"`
+	// Team ID operation result
+	TeamID uint64 `json:"teamID"`
+	// Team object accessor
+	Team *AccountTeam `json:"team,omitempty"`
+}
+
 // AuthClient object represents an OAuth 2.0 client
 type AuthClient struct {
 	// ClientID is the client ID which represents unique connection indentificator
@@ -56,6 +206,21 @@ type AuthClient struct {
 	AllowedCORSOrigins []string `json:"allowedCORSOrigins,omitempty"`
 	// Public flag tells that the client is public
 	Public bool `json:"public"`
+	// RequirePKCE forces the client to use PKCE with the S256 challenge method,
+	// public clients always require it
+	RequirePkce bool `json:"requirePKCE"`
+	// PreviousSecretExpiresAt is the end of the grace period of the rotated secret
+	PreviousSecretExpiresAt *time.Time `json:"previousSecretExpiresAt,omitempty"`
+	// TokenEndpointAuthMethod enforced on the token endpoint, empty accepts any secret based method.
+	//
+	// Pattern: client_secret_basic|client_secret_post|private_key_jwt|none
+	TokenEndpointAuthMethod string `json:"tokenEndpointAuthMethod"`
+	// TokenEndpointAuthSigningAlg of the client assertions of the private_key_jwt method (RS256 by default)
+	TokenEndpointAuthSigningAlg string `json:"tokenEndpointAuthSigningAlg"`
+	// JWKSURI is the URL of the public keys of the private_key_jwt method
+	JwksURI string `json:"jwksURI"`
+	// JWKS is the inline JSON Web Key Set of the private_key_jwt method
+	Jwks string `json:"jwks"`
 	// ExpiresAt contins the time of expiration of the client
 	ExpiresAt time.Time  `json:"expiresAt"`
 	CreatedAt time.Time  `json:"createdAt"`
@@ -89,6 +254,16 @@ type AuthClientCreateInput struct {
 	AllowedCORSOrigins []string `json:"allowedCORSOrigins,omitempty"`
 	// Public flag for the client
 	Public *bool `json:"public,omitempty"`
+	// RequirePKCE flag for the client
+	RequirePkce *bool `json:"requirePKCE,omitempty"`
+	// TokenEndpointAuthMethod of the client, empty accepts any secret based method
+	TokenEndpointAuthMethod *string `json:"tokenEndpointAuthMethod,omitempty"`
+	// TokenEndpointAuthSigningAlg of the client assertions
+	TokenEndpointAuthSigningAlg *string `json:"tokenEndpointAuthSigningAlg,omitempty"`
+	// JWKSURI of the public keys of the client
+	JwksURI *string `json:"jwksURI,omitempty"`
+	// JWKS is the inline JSON Web Key Set of the client
+	Jwks *string `json:"jwks,omitempty"`
 	// ExpiresAt time for the client
 	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
@@ -119,6 +294,17 @@ type AuthClientPayload struct {
 	AuthClient *AuthClient `json:"authClient,omitempty"`
 }
 
+// AuthClientSecretPayload contains the new secret of the client which is not available later
+type AuthClientSecretPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID string `json:"clientMutationID"`
+	// AuthClient ID operation result
+	AuthClientID string `json:"authClientID"`
+	// Secret in plain, the previous one is accepted until previousSecretExpiresAt
+	Secret                  string     `json:"secret"`
+	PreviousSecretExpiresAt *time.Time `json:"previousSecretExpiresAt,omitempty"`
+}
+
 // AuthClientUpdateInput is used to update an existing OAuth 2.0 client
 type AuthClientUpdateInput struct {
 	// AccountID of the auth client owner
@@ -145,18 +331,125 @@ type AuthClientUpdateInput struct {
 	AllowedCORSOrigins []string `json:"allowedCORSOrigins,omitempty"`
 	// Public flag for the client
 	Public *bool `json:"public,omitempty"`
+	// RequirePKCE flag for the client
+	RequirePkce *bool `json:"requirePKCE,omitempty"`
+	// TokenEndpointAuthMethod of the client, empty accepts any secret based method
+	TokenEndpointAuthMethod *string `json:"tokenEndpointAuthMethod,omitempty"`
+	// TokenEndpointAuthSigningAlg of the client assertions
+	TokenEndpointAuthSigningAlg *string `json:"tokenEndpointAuthSigningAlg,omitempty"`
+	// JWKSURI of the public keys of the client
+	JwksURI *string `json:"jwksURI,omitempty"`
+	// JWKS is the inline JSON Web Key Set of the client
+	Jwks *string `json:"jwks,omitempty"`
 	// ExpiresAt time for the client
 	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
-type DirectAccessToken struct {
-	ID          uint64    `json:"ID"`
-	Token       string    `json:"token"`
-	Description string    `json:"description"`
-	UserID      *uint64   `json:"userID,omitempty"`
-	AccountID   uint64    `json:"accountID"`
-	CreatedAt   time.Time `json:"createdAt"`
+// AuthConsentPayload contains the URL to continue the authorization after the decision
+type AuthConsentPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID string `json:"clientMutationID"`
+	// RedirectTo is the authorization URL the user agent has to follow
+	RedirectTo string `json:"redirectTo"`
+}
+
+// AuthConsentRequest is the authorization request of the client waiting for the user decision
+type AuthConsentRequest struct {
+	// Challenge of the consent request passed to the consent page
+	Challenge string `json:"challenge"`
+	// ClientID of the application which requests the access
+	ClientID string `json:"clientID"`
+	// ClientTitle is the human readable name of the application
+	ClientTitle string `json:"clientTitle"`
+	// Scopes requested by the application
+	RequestedScope []string `json:"requestedScope,omitempty"`
+	// Audiences requested by the application
+	RequestedAudience []string  `json:"requestedAudience,omitempty"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
+
+// AuthDeviceRequest is the device authorization request of the client (CLI, TV)
+// waiting for the user to enter the user code and approve it
+type AuthDeviceRequest struct {
+	// UserCode shown by the device
+	UserCode string `json:"userCode"`
+	// ClientID of the application which requests the access
+	ClientID string `json:"clientID"`
+	// ClientTitle is the human readable name of the application
+	ClientTitle string `json:"clientTitle"`
+	// Scopes requested by the application
+	RequestedScope []string `json:"requestedScope,omitempty"`
+	// Audiences requested by the application
+	RequestedAudience []string  `json:"requestedAudience,omitempty"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
+
+// AuthInitialAccessToken authorizes the dynamic client registration (RFC 7591),
+// the registered clients belong to the account of the token
+type AuthInitialAccessToken struct {
+	ID          uint64 `json:"ID"`
+	AccountID   uint64 `json:"accountID"`
+	UserID      uint64 `json:"userID"`
+	Description string `json:"description"`
+	// Scope allowed to the registered clients, empty doesn't restrict them
+	Scope string `json:"scope"`
+	// MaxClients which can be registered by the token, zero is unlimited
+	MaxClients  int       `json:"maxClients"`
+	ClientCount int       `json:"clientCount"`
 	ExpiresAt   time.Time `json:"expiresAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AuthInitialAccessTokenCreateInput is used to create the initial access token of the registration
+type AuthInitialAccessTokenCreateInput struct {
+	Description *string `json:"description,omitempty"`
+	// Scope allowed to the registered clients, empty doesn't restrict them
+	Scope *string `json:"scope,omitempty"`
+	// MaxClients which can be registered by the token, zero is unlimited
+	MaxClients *int      `json:"maxClients,omitempty"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// AuthInitialAccessTokenPayload contains the plain token value which is not available later
+type AuthInitialAccessTokenPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID string `json:"clientMutationID"`
+	// Plain token value for the Authorization header of the registration request
+	Token              string                  `json:"token"`
+	InitialAccessToken *AuthInitialAccessToken `json:"initialAccessToken"`
+}
+
+// AuthorizedApp is the application the current user granted the access to
+type AuthorizedApp struct {
+	ClientID string `json:"clientID"`
+	Title    string `json:"title"`
+	// Scopes granted to the application
+	Scope []string `json:"scope,omitempty"`
+	// Audiences granted to the application
+	Audience []string `json:"audience,omitempty"`
+	// ExpiresAt of the grant, the consent is asked again after it. Null means never.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type DirectAccessToken struct {
+	ID uint64 `json:"ID"`
+	// Plain token value is returned only once on generation, later it's masked
+	Token string `json:"token"`
+	// Visible beginning of the token for identification
+	TokenPrefix string  `json:"tokenPrefix"`
+	Description string  `json:"description"`
+	UserID      *uint64 `json:"userID,omitempty"`
+	AccountID   uint64  `json:"accountID"`
+	// RBAC permission patterns allowed for the token, empty means all rights of the owner
+	Scopes []string `json:"scopes,omitempty"`
+	// IP addresses or CIDR networks allowed to use the token, empty means any address
+	AllowedIPs []string   `json:"allowedIPs,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	LastUsedIP string     `json:"lastUsedIP"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
 }
 
 type DirectAccessTokenListFilter struct {
@@ -186,12 +479,14 @@ type DirectAccessTokenPayload struct {
 
 // HistoryAction is the model for history actions.
 type HistoryAction struct {
-	ID         uuid.UUID          `json:"ID"`
-	RequestID  string             `json:"RequestID"`
-	Name       string             `json:"name"`
-	Message    string             `json:"message"`
-	UserID     uint64             `json:"userID"`
-	AccountID  uint64             `json:"accountID"`
+	ID        uuid.UUID `json:"ID"`
+	RequestID string    `json:"RequestID"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	UserID    uint64    `json:"userID"`
+	AccountID uint64    `json:"accountID"`
+	// The real user who acted on behalf of the user by the impersonation, 0 if none
+	ActorID    uint64             `json:"actorID"`
 	ObjectType string             `json:"objectType"`
 	ObjectID   uint64             `json:"objectID"`
 	ObjectIDs  string             `json:"objectIDs"`
@@ -209,6 +504,8 @@ type HistoryActionListFilter struct {
 	UserID []uint64 `json:"userID,omitempty"`
 	// List of accounts that the user belongs to
 	AccountID []uint64 `json:"accountID,omitempty"`
+	// List of the real users who acted by the impersonation
+	ActorID []uint64 `json:"actorID,omitempty"`
 	// Type of the object that the action is performed on
 	ObjectType []string `json:"objectType,omitempty"`
 	// Object ID of the model that the action is performed on
@@ -240,6 +537,15 @@ type HistoryActionPayload struct {
 	Action *HistoryAction `json:"action"`
 }
 
+type InviteMemberByEmailInput struct {
+	// The email of the invitee
+	Email string `json:"email"`
+	// The roles to assign to the member
+	Roles []string `json:"roles"`
+	// Is the user an admin of the account
+	IsAdmin bool `json:"isAdmin"`
+}
+
 type InviteMemberInput struct {
 	// The ID of the member to invite
 	UserID uint64 `json:"userID"`
@@ -261,11 +567,20 @@ type Member struct {
 	AccountID uint64 `json:"accountID"`
 	// Is the user an admin of the account
 	IsAdmin bool `json:"isAdmin"`
+	// Is the user the owner of the account, the owner can't be removed or demoted
+	IsOwner bool `json:"isOwner"`
 	// Roles of the member
-	Roles     []*RBACRole `json:"roles,omitempty"`
-	CreatedAt time.Time   `json:"createdAt"`
-	UpdatedAt time.Time   `json:"updatedAt"`
-	DeletedAt *time.Time  `json:"deletedAt,omitempty"`
+	Roles []*RBACRole `json:"roles,omitempty"`
+	// Time of the last activity of the member in the account
+	LastActiveAt *time.Time `json:"lastActiveAt,omitempty"`
+	// Time of the member suspension, null if the member is active.
+	// The suspended member can't act in the account, the roles are kept
+	SuspendedAt *time.Time `json:"suspendedAt,omitempty"`
+	// The reason of the member suspension
+	SuspendReason *string    `json:"suspendReason,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+	DeletedAt     *time.Time `json:"deletedAt,omitempty"`
 }
 
 type MemberConnection struct {
@@ -284,22 +599,85 @@ type MemberInput struct {
 	IsAdmin bool `json:"isAdmin"`
 }
 
+// Invitation of the new member of the account by email
+type MemberInvite struct {
+	// The primary key of the invite
+	ID uint64 `json:"ID"`
+	// Account ID of the invite
+	AccountID uint64 `json:"accountID"`
+	// The invited email
+	Email string `json:"email"`
+	// Roles which will be assigned to the member
+	Roles []string `json:"roles,omitempty"`
+	// Is the invitee going to be an admin of the account
+	IsAdmin bool `json:"isAdmin"`
+	// Status of the invite
+	Status MemberInviteStatus `json:"status"`
+	// The user who sent the invite
+	InvitedBy uint64 `json:"invitedBy"`
+	// The user who accepted or declined the invite
+	UserID *uint64 `json:"userID,omitempty"`
+	// How many times the invite was sent
+	SentCount int       `json:"sentCount"`
+	SentAt    time.Time `json:"sentAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type MemberInviteConnection struct {
+	// The total number of invites
+	TotalCount int `json:"totalCount"`
+	// A list of the invites, as a convenience when edges are not needed.
+	List []*MemberInvite `json:"list,omitempty"`
+	// Information for paginating this connection
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+type MemberInviteListFilter struct {
+	ID        []uint64             `json:"ID,omitempty"`
+	AccountID []uint64             `json:"accountID,omitempty"`
+	Email     []string             `json:"email,omitempty"`
+	Status    []MemberInviteStatus `json:"status,omitempty"`
+}
+
+type MemberInviteListOrder struct {
+	ID        *Ordering `json:"ID,omitempty"`
+	Email     *Ordering `json:"email,omitempty"`
+	Status    *Ordering `json:"status,omitempty"`
+	CreatedAt *Ordering `json:"createdAt,omitempty"`
+	ExpiresAt *Ordering `json:"expiresAt,omitempty"`
+}
+
+type MemberInvitePayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID string `json:"clientMutationID"`
+	// Invite ID operation result
+	InviteID uint64 `json:"inviteID"`
+	// Invite object accessor
+	Invite *MemberInvite `json:"invite,omitempty"`
+}
+
 type MemberListFilter struct {
 	ID        []uint64        `json:"ID,omitempty"`
 	Status    []ApproveStatus `json:"status,omitempty"`
 	UserID    []uint64        `json:"userID,omitempty"`
 	AccountID []uint64        `json:"accountID,omitempty"`
+	TeamID    []uint64        `json:"teamID,omitempty"`
 	IsAdmin   *bool           `json:"isAdmin,omitempty"`
+	IsOwner   *bool           `json:"isOwner,omitempty"`
+	Suspended *bool           `json:"suspended,omitempty"`
 }
 
 type MemberListOrder struct {
-	ID        *Ordering `json:"ID,omitempty"`
-	Status    *Ordering `json:"status,omitempty"`
-	UserID    *Ordering `json:"userID,omitempty"`
-	AccountID *Ordering `json:"accountID,omitempty"`
-	IsAdmin   *Ordering `json:"isAdmin,omitempty"`
-	CreatedAt *Ordering `json:"createdAt,omitempty"`
-	UpdatedAt *Ordering `json:"updatedAt,omitempty"`
+	ID           *Ordering `json:"ID,omitempty"`
+	Status       *Ordering `json:"status,omitempty"`
+	UserID       *Ordering `json:"userID,omitempty"`
+	AccountID    *Ordering `json:"accountID,omitempty"`
+	IsAdmin      *Ordering `json:"isAdmin,omitempty"`
+	LastActiveAt *Ordering `json:"lastActiveAt,omitempty"`
+	CreatedAt    *Ordering `json:"createdAt,omitempty"`
+	UpdatedAt    *Ordering `json:"updatedAt,omitempty"`
 }
 
 type MemberPayload struct {
@@ -348,6 +726,19 @@ type OptionPayload struct {
 type Query struct {
 }
 
+// QuotaUsage is the current usage of the account resource and its limit.
+type QuotaUsage struct {
+	AccountID uint64 `json:"accountID"`
+	// The resource name of the quota, e.g. `account.member`
+	Resource string `json:"resource"`
+	// Number of the resource objects used by the account
+	Used int `json:"used"`
+	// The limit of the resource, null if the resource is unlimited
+	Limit *int `json:"limit,omitempty"`
+	// Number of the resource objects which can be created, null if the resource is unlimited
+	Remaining *int `json:"remaining,omitempty"`
+}
+
 type RBACPermission struct {
 	Name        string  `json:"name"`
 	Object      string  `json:"object"`
@@ -362,6 +753,8 @@ type RBACRole struct {
 	Name        string  `json:"name"`
 	Title       string  `json:"title"`
 	Description *string `json:"description,omitempty"`
+	// The account which owns the custom role, null for the global roles shared by all accounts
+	AccountID *uint64 `json:"accountID,omitempty"`
 	//  Context is a JSON object that defines the context of the role.
 	//  The context is used to determine whether the role is applicable to the object.
 	//  The context is a JSON object with the following structure:
@@ -385,11 +778,21 @@ type RBACRoleInput struct {
 	Title       *string             `json:"title,omitempty"`
 	Context     *types.NullableJSON `json:"context,omitempty"`
 	Permissions []string            `json:"permissions,omitempty"`
+	// The account of the custom role, the session account is used without the system permissions.
+	// The account roles grant only the owner and account permissions.
+	AccountID *uint64 `json:"accountID,omitempty"`
+	// The child roles, the account roles can include the global roles up to the account level
+	// and the roles of the same account
+	ChildRoles []uint64 `json:"childRoles,omitempty"`
 }
 
 type RBACRoleListFilter struct {
 	ID   []uint64 `json:"ID,omitempty"`
 	Name []string `json:"name,omitempty"`
+	// The custom roles of the accounts
+	AccountID []uint64 `json:"accountID,omitempty"`
+	// The global roles, together with accountID selects both
+	Global *bool `json:"global,omitempty"`
 }
 
 type RBACRoleListOrder struct {
@@ -414,6 +817,10 @@ type SessionToken struct {
 	ExpiresAt time.Time `json:"expiresAt"`
 	IsAdmin   bool      `json:"isAdmin"`
 	Roles     []string  `json:"roles,omitempty"`
+	// The session acts on behalf of the user by the impersonation
+	IsImpersonated bool `json:"isImpersonated"`
+	// The real user of the impersonated session
+	ImpersonatorID *uint64 `json:"impersonatorID,omitempty"`
 }
 
 type SocialAccount struct {
@@ -477,6 +884,18 @@ type SocialAccountSession struct {
 	DeletedAt       *time.Time `json:"deletedAt,omitempty"`
 }
 
+// SocialProvider describes the login provider of the current user
+type SocialProvider struct {
+	// The name of the provider
+	Provider string `json:"provider"`
+	// Whether the provider is connected to the current user
+	Connected bool `json:"connected"`
+	// The connected social account
+	SocialAccount *SocialAccount `json:"socialAccount,omitempty"`
+	// Whether the social account can be disconnected without losing the last login method
+	CanDisconnect bool `json:"canDisconnect"`
+}
+
 // Simple response type for the API
 type StatusResponse struct {
 	// Unique identifier for the client performing the mutation
@@ -487,6 +906,140 @@ type StatusResponse struct {
 	Message *string `json:"message,omitempty"`
 }
 
+// The status of the account ownership transfer
+type AccountOwnershipTransferStatus string
+
+const (
+	// The transfer waits for the confirmation of the new owner
+	AccountOwnershipTransferStatusPending AccountOwnershipTransferStatus = "PENDING"
+	// The new owner accepted the account
+	AccountOwnershipTransferStatusConfirmed AccountOwnershipTransferStatus = "CONFIRMED"
+	// The new owner refused the account
+	AccountOwnershipTransferStatusDeclined AccountOwnershipTransferStatus = "DECLINED"
+	// The transfer was withdrawn by the owner
+	AccountOwnershipTransferStatusCancelled AccountOwnershipTransferStatus = "CANCELLED"
+	// The pending transfer is out of date
+	AccountOwnershipTransferStatusExpired AccountOwnershipTransferStatus = "EXPIRED"
+)
+
+var AllAccountOwnershipTransferStatus = []AccountOwnershipTransferStatus{
+	AccountOwnershipTransferStatusPending,
+	AccountOwnershipTransferStatusConfirmed,
+	AccountOwnershipTransferStatusDeclined,
+	AccountOwnershipTransferStatusCancelled,
+	AccountOwnershipTransferStatusExpired,
+}
+
+func (e AccountOwnershipTransferStatus) IsValid() bool {
+	switch e {
+	case AccountOwnershipTransferStatusPending, AccountOwnershipTransferStatusConfirmed, AccountOwnershipTransferStatusDeclined, AccountOwnershipTransferStatusCancelled, AccountOwnershipTransferStatusExpired:
+		return true
+	}
+	return false
+}
+
+func (e AccountOwnershipTransferStatus) String() string {
+	return string(e)
+}
+
+func (e *AccountOwnershipTransferStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AccountOwnershipTransferStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AccountOwnershipTransferStatus", str)
+	}
+	return nil
+}
+
+func (e AccountOwnershipTransferStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AccountOwnershipTransferStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AccountOwnershipTransferStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// The status of the member invitation
+type MemberInviteStatus string
+
+const (
+	// The invite is sent and waits for the answer
+	MemberInviteStatusPending MemberInviteStatus = "PENDING"
+	// The invitee joined the account
+	MemberInviteStatusAccepted MemberInviteStatus = "ACCEPTED"
+	// The invitee refused the invite
+	MemberInviteStatusDeclined MemberInviteStatus = "DECLINED"
+	// The invite was cancelled by the account manager
+	MemberInviteStatusRevoked MemberInviteStatus = "REVOKED"
+	// The pending invite is out of date
+	MemberInviteStatusExpired MemberInviteStatus = "EXPIRED"
+)
+
+var AllMemberInviteStatus = []MemberInviteStatus{
+	MemberInviteStatusPending,
+	MemberInviteStatusAccepted,
+	MemberInviteStatusDeclined,
+	MemberInviteStatusRevoked,
+	MemberInviteStatusExpired,
+}
+
+func (e MemberInviteStatus) IsValid() bool {
+	switch e {
+	case MemberInviteStatusPending, MemberInviteStatusAccepted, MemberInviteStatusDeclined, MemberInviteStatusRevoked, MemberInviteStatusExpired:
+		return true
+	}
+	return false
+}
+
+func (e MemberInviteStatus) String() string {
+	return string(e)
+}
+
+func (e *MemberInviteStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MemberInviteStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MemberInviteStatus", str)
+	}
+	return nil
+}
+
+func (e MemberInviteStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MemberInviteStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MemberInviteStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 // Constants of the response status
 type ResponseStatus string
 
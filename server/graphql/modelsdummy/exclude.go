@@ -14,7 +14,16 @@ type Account struct {
 	// Status of Account active
 	Status models.ApproveStatus `json:"status"`
 	// Message which defined during user approve/rejection process
-	StatusMessage *string   `json:"statusMessage,omitempty"`
+	StatusMessage *string `json:"statusMessage,omitempty"`
+	// The parent organization account, null for the top level account
+	ParentID *uint64 `json:"parentID,omitempty"`
+	// The members of the parent account act in this account with their permissions
+	InheritPermissions bool `json:"inheritPermissions"`
+	// Time of the account suspension, null if the account is active.
+	// Nobody can act in the suspended account, the data is kept
+	SuspendedAt *time.Time `json:"suspendedAt,omitempty"`
+	// The reason of the account suspension
+	SuspendReason *string   `json:"suspendReason,omitempty"`
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
 }
@@ -37,6 +46,10 @@ type AccountListFilter struct {
 	ID     []uint64               `json:"ID,omitempty"`
 	UserID []uint64               `json:"UserID,omitempty"`
 	Status []models.ApproveStatus `json:"status,omitempty"`
+	// Direct children of the accounts
+	ParentID []uint64 `json:"parentID,omitempty"`
+	// The whole subtree of the accounts
+	AncestorID []uint64 `json:"ancestorID,omitempty"`
 }
 
 type AccountListOrder struct {
@@ -68,9 +81,16 @@ type User struct {
 	// Status of user active
 	Status models.ApproveStatus `json:"status"`
 	// Message which defined during user approve/rejection process
-	StatusMessage *string   `json:"statusMessage,omitempty"`
-	CreatedAt     time.Time `json:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	StatusMessage *string `json:"statusMessage,omitempty"`
+	// Time of the user suspension, null if the user is active.
+	// The suspended user can't login, the data is kept
+	SuspendedAt *time.Time `json:"suspendedAt,omitempty"`
+	// The reason of the user suspension
+	SuspendReason *string `json:"suspendReason,omitempty"`
+	// Time of the last activity of the user in any account
+	LastActiveAt *time.Time `json:"lastActiveAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
 	// Email address (optional trait — present only when user.Email is embedded).
 	Email string `json:"email"`
 	// Unique username (separate from email, optional trait).